@@ -28,15 +28,21 @@ type Applier interface {
 	Apply(ctx context.Context, authInfo authorization.Info, spaceGUID string, appInfo payloads.ManifestApplication, appState manifest.AppState) error
 }
 
+//counterfeiter:generate -o fake -fake-name Differ . Differ
+type Differ interface {
+	Diff(appIndex int, appInfo payloads.ManifestApplication, appState manifest.AppState) []manifest.DiffEntry
+}
+
 type Manifest struct {
 	domainRepo        shared.CFDomainRepository
 	defaultDomainName string
 	stateCollector    StateCollector
 	normalizer        Normalizer
 	applier           Applier
+	differ            Differ
 }
 
-func NewManifest(domainRepo shared.CFDomainRepository, defaultDomainName string, stateCollector StateCollector, normalizer Normalizer, applier Applier,
+func NewManifest(domainRepo shared.CFDomainRepository, defaultDomainName string, stateCollector StateCollector, normalizer Normalizer, applier Applier, differ Differ,
 ) *Manifest {
 	return &Manifest{
 		domainRepo:        domainRepo,
@@ -44,6 +50,7 @@ func NewManifest(domainRepo shared.CFDomainRepository, defaultDomainName string,
 		stateCollector:    stateCollector,
 		normalizer:        normalizer,
 		applier:           applier,
+		differ:            differ,
 	}
 }
 
@@ -68,6 +75,20 @@ func (a *Manifest) Apply(ctx context.Context, authInfo authorization.Info, space
 	return nil
 }
 
+func (a *Manifest) Diff(ctx context.Context, authInfo authorization.Info, spaceGUID string, manifesto payloads.Manifest) ([]manifest.DiffEntry, error) {
+	diff := []manifest.DiffEntry{}
+	for i, appInfo := range manifesto.Applications {
+		appState, err := a.stateCollector.CollectState(ctx, authInfo, appInfo.Name, spaceGUID)
+		if err != nil {
+			return nil, err
+		}
+		appInfo = a.normalizer.Normalize(appInfo, appState)
+		diff = append(diff, a.differ.Diff(i, appInfo, appState)...)
+	}
+
+	return diff, nil
+}
+
 func (a *Manifest) ensureDefaultDomainConfigured(ctx context.Context, authInfo authorization.Info) error {
 	domains, err := a.domainRepo.ListDomains(ctx, authInfo, repositories.ListDomainsMessage{
 		Names: []string{a.defaultDomainName},
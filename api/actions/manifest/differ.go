@@ -0,0 +1,173 @@
+package manifest
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"code.cloudfoundry.org/bytefmt"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	DiffOpAdd     = "add"
+	DiffOpReplace = "replace"
+	DiffOpRemove  = "remove"
+)
+
+// DiffEntry describes a change that applying the manifest would make. Path is
+// a JSON pointer into the manifest, e.g. /applications/0/processes/0/instances
+type DiffEntry struct {
+	Op    string
+	Path  string
+	Was   any
+	Value any
+}
+
+type Differ struct{}
+
+func NewDiffer() Differ {
+	return Differ{}
+}
+
+// Diff compares the normalized manifest application against the current app
+// state. Fields that are not set in the manifest are left untouched when the
+// manifest is applied and are therefore not reported. When the app does not
+// exist yet every field in the manifest is reported as an addition.
+func (d Differ) Diff(appIndex int, appInfo payloads.ManifestApplication, appState AppState) []DiffEntry {
+	diff := &diffBuilder{entries: []DiffEntry{}}
+	appPath := fmt.Sprintf("/applications/%d", appIndex)
+	appExists := appState.App.GUID != ""
+
+	if appExists {
+		diff.compare(appPath+"/name", appState.App.Name, appInfo.Name)
+	} else {
+		diff.add(appPath+"/name", appInfo.Name)
+	}
+
+	if len(appInfo.Buildpacks) > 0 {
+		if appExists {
+			diff.compare(appPath+"/buildpacks", appState.App.Lifecycle.Data.Buildpacks, appInfo.Buildpacks)
+		} else {
+			diff.add(appPath+"/buildpacks", appInfo.Buildpacks)
+		}
+	}
+
+	// the app state does not carry the environment, so only report the env
+	// of apps that are about to be created
+	if !appExists {
+		for _, name := range slices.Sorted(maps.Keys(appInfo.Env)) {
+			diff.add(appPath+"/env/"+name, appInfo.Env[name])
+		}
+	}
+
+	for i, processInfo := range appInfo.Processes {
+		processPath := fmt.Sprintf("%s/processes/%d", appPath, i)
+		process, processExists := appState.Processes[processInfo.Type]
+
+		if !processExists {
+			diff.add(processPath+"/type", processInfo.Type)
+		}
+
+		for _, field := range processFields(processInfo) {
+			if processExists {
+				diff.compare(processPath+"/"+field.name, field.current(process), field.value)
+			} else {
+				diff.add(processPath+"/"+field.name, field.value)
+			}
+		}
+	}
+
+	if appInfo.NoRoute {
+		if len(appState.Routes) > 0 {
+			diff.remove(appPath+"/routes", manifestRoutes(slices.Sorted(maps.Keys(appState.Routes))))
+		}
+	} else {
+		for i, route := range appInfo.Routes {
+			if _, ok := appState.Routes[*route.Route]; !ok {
+				diff.add(fmt.Sprintf("%s/routes/%d", appPath, i), map[string]string{"route": *route.Route})
+			}
+		}
+	}
+
+	return diff.entries
+}
+
+type processField struct {
+	name    string
+	value   any
+	current func(process repositories.ProcessRecord) any
+}
+
+func processFields(processInfo payloads.ManifestApplicationProcess) []processField {
+	fields := []processField{}
+
+	if processInfo.Command != nil {
+		fields = append(fields, processField{"command", *processInfo.Command, func(p repositories.ProcessRecord) any { return p.Command }})
+	}
+	if processInfo.DiskQuota != nil {
+		fields = append(fields, processField{"disk_quota", megabytes(*processInfo.DiskQuota), func(p repositories.ProcessRecord) any { return fmt.Sprintf("%dM", p.DiskQuotaMB) }})
+	}
+	if processInfo.HealthCheckHTTPEndpoint != nil {
+		fields = append(fields, processField{"health-check-http-endpoint", *processInfo.HealthCheckHTTPEndpoint, func(p repositories.ProcessRecord) any { return p.HealthCheck.Data.HTTPEndpoint }})
+	}
+	if processInfo.HealthCheckInvocationTimeout != nil {
+		fields = append(fields, processField{"health-check-invocation-timeout", *processInfo.HealthCheckInvocationTimeout, func(p repositories.ProcessRecord) any { return p.HealthCheck.Data.InvocationTimeoutSeconds }})
+	}
+	if processInfo.HealthCheckType != nil {
+		healthCheckType := *processInfo.HealthCheckType
+		// "none" is stored as "process", see ManifestApplicationProcess.ToProcessPatchMessage
+		if healthCheckType == "none" {
+			healthCheckType = "process"
+		}
+		fields = append(fields, processField{"health-check-type", healthCheckType, func(p repositories.ProcessRecord) any { return p.HealthCheck.Type }})
+	}
+	if processInfo.Instances != nil {
+		fields = append(fields, processField{"instances", *processInfo.Instances, func(p repositories.ProcessRecord) any { return p.DesiredInstances }})
+	}
+	if processInfo.Memory != nil {
+		fields = append(fields, processField{"memory", megabytes(*processInfo.Memory), func(p repositories.ProcessRecord) any { return fmt.Sprintf("%dM", p.MemoryMB) }})
+	}
+	if processInfo.Timeout != nil {
+		fields = append(fields, processField{"timeout", *processInfo.Timeout, func(p repositories.ProcessRecord) any { return p.HealthCheck.Data.TimeoutSeconds }})
+	}
+
+	return fields
+}
+
+// megabytes renders sizes such as 1G as 1024M so that they can be compared
+// against the process state
+func megabytes(size string) string {
+	// error intentionally ignored as the manifest is validated beforehand
+	mb, _ := bytefmt.ToMegabytes(size)
+	return fmt.Sprintf("%dM", mb)
+}
+
+func manifestRoutes(routes []string) []map[string]string {
+	result := []map[string]string{}
+	for _, route := range routes {
+		result = append(result, map[string]string{"route": route})
+	}
+	return result
+}
+
+type diffBuilder struct {
+	entries []DiffEntry
+}
+
+func (b *diffBuilder) add(path string, value any) {
+	b.entries = append(b.entries, DiffEntry{Op: DiffOpAdd, Path: path, Value: value})
+}
+
+func (b *diffBuilder) remove(path string, was any) {
+	b.entries = append(b.entries, DiffEntry{Op: DiffOpRemove, Path: path, Was: was})
+}
+
+func (b *diffBuilder) compare(path string, was, value any) {
+	if reflect.DeepEqual(was, value) {
+		return
+	}
+	b.entries = append(b.entries, DiffEntry{Op: DiffOpReplace, Path: path, Was: was, Value: value})
+}
@@ -0,0 +1,146 @@
+package manifest_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Differ", func() {
+	var (
+		appInfo  payloads.ManifestApplication
+		appState manifest.AppState
+		diff     []manifest.DiffEntry
+	)
+
+	BeforeEach(func() {
+		appInfo = payloads.ManifestApplication{
+			Name:       "my-app",
+			Buildpacks: []string{"java"},
+			Processes: []payloads.ManifestApplicationProcess{{
+				Type:            "web",
+				Command:         tools.PtrTo("start-web"),
+				Instances:       tools.PtrTo[int32](2),
+				Memory:          tools.PtrTo("1G"),
+				DiskQuota:       tools.PtrTo("512M"),
+				HealthCheckType: tools.PtrTo("none"),
+			}},
+			Routes: []payloads.ManifestRoute{{Route: tools.PtrTo("my-app.my.domain")}},
+		}
+
+		appState = manifest.AppState{
+			App: repositories.AppRecord{
+				GUID: "app-guid",
+				Name: "my-app",
+				Lifecycle: repositories.Lifecycle{
+					Data: repositories.LifecycleData{Buildpacks: []string{"java"}},
+				},
+			},
+			Processes: map[string]repositories.ProcessRecord{
+				"web": {
+					Type:             "web",
+					Command:          "start-web",
+					DesiredInstances: 2,
+					MemoryMB:         1024,
+					DiskQuotaMB:      512,
+					HealthCheck:      repositories.HealthCheck{Type: "process"},
+				},
+			},
+			Routes: map[string]repositories.RouteRecord{
+				"my-app.my.domain": {},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		diff = manifest.NewDiffer().Diff(1, appInfo, appState)
+	})
+
+	When("the manifest matches the app state", func() {
+		It("returns an empty diff", func() {
+			Expect(diff).To(BeEmpty())
+		})
+	})
+
+	When("the manifest changes the app", func() {
+		BeforeEach(func() {
+			appInfo.Buildpacks = []string{"go"}
+			appInfo.Processes[0].Instances = tools.PtrTo[int32](3)
+			appInfo.Processes[0].Memory = tools.PtrTo("2G")
+			appInfo.Processes = append(appInfo.Processes, payloads.ManifestApplicationProcess{
+				Type:    "worker",
+				Command: tools.PtrTo("start-worker"),
+			})
+			appInfo.Routes = append(appInfo.Routes, payloads.ManifestRoute{Route: tools.PtrTo("other.my.domain")})
+		})
+
+		It("reports the changes", func() {
+			Expect(diff).To(Equal([]manifest.DiffEntry{
+				{Op: "replace", Path: "/applications/1/buildpacks", Was: []string{"java"}, Value: []string{"go"}},
+				{Op: "replace", Path: "/applications/1/processes/0/instances", Was: int32(2), Value: int32(3)},
+				{Op: "replace", Path: "/applications/1/processes/0/memory", Was: "1024M", Value: "2048M"},
+				{Op: "add", Path: "/applications/1/processes/1/type", Value: "worker"},
+				{Op: "add", Path: "/applications/1/processes/1/command", Value: "start-worker"},
+				{Op: "add", Path: "/applications/1/routes/1", Value: map[string]string{"route": "other.my.domain"}},
+			}))
+		})
+	})
+
+	When("the manifest does not set some process fields", func() {
+		BeforeEach(func() {
+			appInfo.Processes[0] = payloads.ManifestApplicationProcess{
+				Type:   "web",
+				Memory: tools.PtrTo("1024M"),
+			}
+			appState.Processes["web"] = repositories.ProcessRecord{
+				Type:             "web",
+				DesiredInstances: 5,
+				MemoryMB:         1024,
+			}
+		})
+
+		It("does not report them", func() {
+			Expect(diff).To(BeEmpty())
+		})
+	})
+
+	When("the manifest removes all routes", func() {
+		BeforeEach(func() {
+			appInfo.NoRoute = true
+			appInfo.Routes = nil
+		})
+
+		It("reports the routes removal", func() {
+			Expect(diff).To(ConsistOf(manifest.DiffEntry{
+				Op:   "remove",
+				Path: "/applications/1/routes",
+				Was:  []map[string]string{{"route": "my-app.my.domain"}},
+			}))
+		})
+	})
+
+	When("the app does not exist", func() {
+		BeforeEach(func() {
+			appInfo.Env = map[string]string{"FOO": "bar"}
+			appState = manifest.AppState{}
+		})
+
+		It("reports every manifest field as an addition", func() {
+			Expect(diff).To(Equal([]manifest.DiffEntry{
+				{Op: "add", Path: "/applications/1/name", Value: "my-app"},
+				{Op: "add", Path: "/applications/1/buildpacks", Value: []string{"java"}},
+				{Op: "add", Path: "/applications/1/env/FOO", Value: "bar"},
+				{Op: "add", Path: "/applications/1/processes/0/type", Value: "web"},
+				{Op: "add", Path: "/applications/1/processes/0/command", Value: "start-web"},
+				{Op: "add", Path: "/applications/1/processes/0/disk_quota", Value: "512M"},
+				{Op: "add", Path: "/applications/1/processes/0/health-check-type", Value: "process"},
+				{Op: "add", Path: "/applications/1/processes/0/instances", Value: int32(2)},
+				{Op: "add", Path: "/applications/1/processes/0/memory", Value: "1024M"},
+				{Op: "add", Path: "/applications/1/routes/0", Value: map[string]string{"route": "my-app.my.domain"}},
+			}))
+		})
+	})
+})
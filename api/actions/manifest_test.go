@@ -25,6 +25,7 @@ var _ = Describe("ApplyManifest", func() {
 		stateCollector   *fake.StateCollector
 		normalizer       *fake.Normalizer
 		applier          *fake.Applier
+		differ           *fake.Differ
 
 		appManifest payloads.Manifest
 	)
@@ -34,6 +35,7 @@ var _ = Describe("ApplyManifest", func() {
 		stateCollector = new(fake.StateCollector)
 		normalizer = new(fake.Normalizer)
 		applier = new(fake.Applier)
+		differ = new(fake.Differ)
 
 		domainRepository.ListDomainsReturns([]repositories.DomainRecord{{}}, nil)
 		stateCollector.CollectStateReturnsOnCall(0, manifest.AppState{
@@ -64,7 +66,7 @@ var _ = Describe("ApplyManifest", func() {
 			}},
 		}
 
-		manifestAction = actions.NewManifest(domainRepository, "my.domain", stateCollector, normalizer, applier)
+		manifestAction = actions.NewManifest(domainRepository, "my.domain", stateCollector, normalizer, applier, differ)
 	})
 
 	JustBeforeEach(func() {
@@ -145,3 +147,78 @@ var _ = Describe("ApplyManifest", func() {
 		})
 	})
 })
+
+var _ = Describe("DiffManifest", func() {
+	var (
+		manifestAction *actions.Manifest
+		diff           []manifest.DiffEntry
+		diffErr        error
+
+		stateCollector *fake.StateCollector
+		normalizer     *fake.Normalizer
+		differ         *fake.Differ
+
+		appManifest payloads.Manifest
+	)
+
+	BeforeEach(func() {
+		stateCollector = new(fake.StateCollector)
+		normalizer = new(fake.Normalizer)
+		differ = new(fake.Differ)
+
+		stateCollector.CollectStateReturnsOnCall(0, manifest.AppState{
+			App: repositories.AppRecord{GUID: "app1-guid"},
+		}, nil)
+		stateCollector.CollectStateReturnsOnCall(1, manifest.AppState{}, nil)
+
+		normalizer.NormalizeReturnsOnCall(0, payloads.ManifestApplication{Name: "normalized-app1"})
+		normalizer.NormalizeReturnsOnCall(1, payloads.ManifestApplication{Name: "normalized-app2"})
+
+		differ.DiffReturnsOnCall(0, []manifest.DiffEntry{{Op: manifest.DiffOpReplace, Path: "/applications/0/name"}})
+		differ.DiffReturnsOnCall(1, []manifest.DiffEntry{{Op: manifest.DiffOpAdd, Path: "/applications/1/name"}})
+
+		appManifest = payloads.Manifest{
+			Applications: []payloads.ManifestApplication{{Name: "app1"}, {Name: "app2"}},
+		}
+
+		manifestAction = actions.NewManifest(new(reposfake.CFDomainRepository), "my.domain", stateCollector, normalizer, new(fake.Applier), differ)
+	})
+
+	JustBeforeEach(func() {
+		diff, diffErr = manifestAction.Diff(context.Background(), authorization.Info{}, "space-guid", appManifest)
+	})
+
+	It("diffs each normalized app against its state", func() {
+		Expect(diffErr).NotTo(HaveOccurred())
+
+		Expect(stateCollector.CollectStateCallCount()).To(Equal(2))
+		_, _, actualAppName, actualSpaceGUID := stateCollector.CollectStateArgsForCall(1)
+		Expect(actualAppName).To(Equal("app2"))
+		Expect(actualSpaceGUID).To(Equal("space-guid"))
+
+		Expect(differ.DiffCallCount()).To(Equal(2))
+		actualIndex, actualAppInManifest, actualState := differ.DiffArgsForCall(0)
+		Expect(actualIndex).To(Equal(0))
+		Expect(actualAppInManifest.Name).To(Equal("normalized-app1"))
+		Expect(actualState.App.GUID).To(Equal("app1-guid"))
+		actualIndex, actualAppInManifest, actualState = differ.DiffArgsForCall(1)
+		Expect(actualIndex).To(Equal(1))
+		Expect(actualAppInManifest.Name).To(Equal("normalized-app2"))
+		Expect(actualState.App.GUID).To(BeEmpty())
+
+		Expect(diff).To(Equal([]manifest.DiffEntry{
+			{Op: manifest.DiffOpReplace, Path: "/applications/0/name"},
+			{Op: manifest.DiffOpAdd, Path: "/applications/1/name"},
+		}))
+	})
+
+	When("collecting the app state fails", func() {
+		BeforeEach(func() {
+			stateCollector.CollectStateReturnsOnCall(0, manifest.AppState{}, errors.New("collect-state-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(diffErr).To(MatchError("collect-state-err"))
+		})
+	})
+})
@@ -24,6 +24,11 @@ const (
 	stateRunning             = "RUNNING"
 	stateDown                = "DOWN"
 	stateCrashed             = "CRASHED"
+
+	// CrashReasonOutOfMemory is reported as the details of crashed instances
+	// whose application container has been OOM killed
+	CrashReasonOutOfMemory   = "OutOfMemory"
+	containerReasonOOMKilled = "OOMKilled"
 )
 
 //counterfeiter:generate -o fake -fake-name MetricsRepository . MetricsRepository
@@ -41,12 +46,15 @@ type (
 	}
 
 	PodStatsRecord struct {
-		Type      string
-		Index     int
-		State     string `default:"DOWN"`
-		Usage     Usage
-		MemQuota  *int64
-		DiskQuota *int64
+		Type         string
+		Index        int
+		State        string `default:"DOWN"`
+		RestartCount int32
+		Usage        Usage
+		Uptime       *int64
+		MemQuota     *int64
+		DiskQuota    *int64
+		Details      *string
 	}
 
 	ProcessStats struct {
@@ -76,13 +84,7 @@ func (a *ProcessStats) FetchStats(ctx context.Context, authInfo authorization.In
 	}
 
 	if appRecord.State == repositories.StoppedState {
-		return []PodStatsRecord{
-			{
-				Type:  processRecord.Type,
-				Index: 0,
-				State: "DOWN",
-			},
-		}, nil
+		return []PodStatsRecord{downPodStatsRecord(processRecord.Type, 0)}, nil
 	}
 
 	metrics, err := a.metricsRepo.GetMetrics(ctx, authInfo, appRecord.SpaceGUID, client.MatchingLabels{
@@ -97,11 +99,7 @@ func (a *ProcessStats) FetchStats(ctx context.Context, authInfo authorization.In
 	// Initialize records slice with the pod instances we expect to exist
 	records := make([]PodStatsRecord, processRecord.DesiredInstances)
 	for i := range records {
-		records[i] = PodStatsRecord{
-			Type:  processRecord.Type,
-			Index: i,
-			State: stateDown,
-		}
+		records[i] = downPodStatsRecord(processRecord.Type, i)
 	}
 
 	for _, m := range metrics {
@@ -120,6 +118,12 @@ func (a *ProcessStats) FetchStats(ctx context.Context, authInfo authorization.In
 		}
 
 		records[index].State = podState
+		records[index].RestartCount = podRestartCount(m.Pod)
+		records[index].Uptime = podUptime(m.Pod)
+		if podState == stateCrashed {
+			records[index].Details = podCrashReason(m.Pod)
+		}
+		records[index].Usage = Usage{}
 
 		metricsMap := aggregateContainerMetrics(m.Metrics.Containers)
 		if len(metricsMap) == 0 {
@@ -153,6 +157,29 @@ func (a *ProcessStats) FetchStats(ctx context.Context, authInfo authorization.In
 	return records, nil
 }
 
+// downPodStatsRecord describes an instance that has no running pod, the
+// usage is zeroed rather than omitted
+func downPodStatsRecord(processType string, index int) PodStatsRecord {
+	return PodStatsRecord{
+		Type:  processType,
+		Index: index,
+		State: stateDown,
+		Usage: Usage{
+			CPU:  tools.PtrTo(0.0),
+			Mem:  tools.PtrTo(int64(0)),
+			Disk: tools.PtrTo(int64(0)),
+		},
+	}
+}
+
+func podUptime(pod corev1.Pod) *int64 {
+	if pod.Status.StartTime == nil {
+		return nil
+	}
+
+	return tools.PtrTo(int64(time.Since(pod.Status.StartTime.Time).Seconds()))
+}
+
 func extractIndex(pod corev1.Pod) (int, error) {
 	indexString, exists := pod.ObjectMeta.Labels[korifiv1alpha1.PodIndexLabelKey]
 	if !exists {
@@ -199,11 +226,37 @@ func podHasCrashedContainer(pod corev1.Pod) bool {
 		if cond.State.Waiting != nil && cond.State.Waiting.Reason == "CrashLoopBackOff" {
 			return true
 		}
+		if cond.State.Terminated != nil && cond.State.Terminated.Reason == containerReasonOOMKilled {
+			return true
+		}
 	}
 
 	return false
 }
 
+// podCrashReason tells OOM kills apart from generic crashes. Crash looping
+// containers report the reason of their last termination.
+func podCrashReason(pod corev1.Pod) *string {
+	for _, status := range pod.Status.ContainerStatuses {
+		for _, terminated := range []*corev1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+			if terminated != nil && terminated.Reason == containerReasonOOMKilled {
+				return tools.PtrTo(CrashReasonOutOfMemory)
+			}
+		}
+	}
+
+	return nil
+}
+
+func podRestartCount(pod corev1.Pod) int32 {
+	var restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+
+	return restarts
+}
+
 func podConditionStatus(pod corev1.Pod, conditionType corev1.PodConditionType) bool {
 	for _, cond := range pod.Status.Conditions {
 		if cond.Type == conditionType {
@@ -17,6 +17,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -122,6 +123,8 @@ var _ = Describe("ProcessStats", func() {
 		Expect(responseRecords[0].Usage.Disk).To(Equal(tools.PtrTo(int64(890))))
 		Expect(responseRecords[0].MemQuota).To(Equal(tools.PtrTo(int64(1024 * 1024 * 1024))))
 		Expect(responseRecords[0].DiskQuota).To(Equal(tools.PtrTo(int64(2048 * 1024 * 1024))))
+		Expect(responseRecords[0].Uptime).NotTo(BeNil())
+		Expect(*responseRecords[0].Uptime).To(BeNumerically("~", 60, 2))
 
 		Expect(responseRecords[1].Index).To(Equal(1))
 		Expect(responseRecords[1].Type).To(Equal("web"))
@@ -156,6 +159,11 @@ var _ = Describe("ProcessStats", func() {
 				Type:  "web",
 				Index: 0,
 				State: "DOWN",
+				Usage: Usage{
+					CPU:  tools.PtrTo(0.0),
+					Mem:  tools.PtrTo(int64(0)),
+					Disk: tools.PtrTo(int64(0)),
+				},
 			}))
 		})
 	})
@@ -192,6 +200,11 @@ var _ = Describe("ProcessStats", func() {
 				Type:  "web",
 				Index: 0,
 				State: "DOWN",
+				Usage: Usage{
+					CPU:  tools.PtrTo(0.0),
+					Mem:  tools.PtrTo(int64(0)),
+					Disk: tools.PtrTo(int64(0)),
+				},
 			}))
 		})
 	})
@@ -277,6 +290,53 @@ var _ = Describe("ProcessStats", func() {
 				It("is crashed", func() {
 					Expect(responseRecords[0].State).To(Equal("CRASHED"))
 				})
+
+				It("does not report a crash reason", func() {
+					Expect(responseRecords[0].Details).To(BeNil())
+				})
+
+				When("the container has been OOM killed", func() {
+					BeforeEach(func() {
+						podMetrics[0].Pod.Status.ContainerStatuses[0].LastTerminationState = corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137},
+						}
+					})
+
+					It("reports the OutOfMemory crash reason", func() {
+						Expect(responseRecords[0].State).To(Equal("CRASHED"))
+						Expect(responseRecords[0].Details).To(PointTo(Equal("OutOfMemory")))
+					})
+				})
+			})
+		})
+
+		When("the pod has an OOM killed container", func() {
+			BeforeEach(func() {
+				podMetrics[0].Pod.Status.Conditions = makeConditions("Initialized")
+				podMetrics[0].Pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+					{
+						Name: "application",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137},
+						},
+					},
+				}
+			})
+
+			It("is crashed with the OutOfMemory reason", func() {
+				Expect(responseRecords[0].State).To(Equal("CRASHED"))
+				Expect(responseRecords[0].Details).To(PointTo(Equal("OutOfMemory")))
+			})
+		})
+
+		When("a container has been restarted", func() {
+			BeforeEach(func() {
+				podMetrics[1].Pod.Status.ContainerStatuses[0].RestartCount = 4
+			})
+
+			It("reports the restart count of the instance", func() {
+				Expect(responseRecords[0].RestartCount).To(BeZero())
+				Expect(responseRecords[1].RestartCount).To(Equal(int32(4)))
 			})
 		})
 
@@ -314,6 +374,7 @@ func createPod(index, version string) corev1.Pod {
 		},
 		Status: corev1.PodStatus{
 			Phase:      corev1.PodRunning,
+			StartTime:  tools.PtrTo(metav1.NewTime(time.Now().Add(-time.Minute))),
 			Conditions: makeConditions("Ready"),
 			ContainerStatuses: []corev1.ContainerStatus{
 				{
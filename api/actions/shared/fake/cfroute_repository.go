@@ -41,6 +41,21 @@ type CFRouteRepository struct {
 		result1 repositories.RouteRecord
 		result2 error
 	}
+	ListRoutesStub        func(context.Context, authorization.Info, repositories.ListRoutesMessage) ([]repositories.RouteRecord, error)
+	listRoutesMutex       sync.RWMutex
+	listRoutesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListRoutesMessage
+	}
+	listRoutesReturns struct {
+		result1 []repositories.RouteRecord
+		result2 error
+	}
+	listRoutesReturnsOnCall map[int]struct {
+		result1 []repositories.RouteRecord
+		result2 error
+	}
 	ListRoutesForAppStub        func(context.Context, authorization.Info, string, string) ([]repositories.RouteRecord, error)
 	listRoutesForAppMutex       sync.RWMutex
 	listRoutesForAppArgsForCall []struct {
@@ -208,6 +223,72 @@ func (fake *CFRouteRepository) GetOrCreateRouteReturnsOnCall(i int, result1 repo
 	}{result1, result2}
 }
 
+func (fake *CFRouteRepository) ListRoutes(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListRoutesMessage) ([]repositories.RouteRecord, error) {
+	fake.listRoutesMutex.Lock()
+	ret, specificReturn := fake.listRoutesReturnsOnCall[len(fake.listRoutesArgsForCall)]
+	fake.listRoutesArgsForCall = append(fake.listRoutesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListRoutesMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListRoutesStub
+	fakeReturns := fake.listRoutesReturns
+	fake.recordInvocation("ListRoutes", []interface{}{arg1, arg2, arg3})
+	fake.listRoutesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouteRepository) ListRoutesCallCount() int {
+	fake.listRoutesMutex.RLock()
+	defer fake.listRoutesMutex.RUnlock()
+	return len(fake.listRoutesArgsForCall)
+}
+
+func (fake *CFRouteRepository) ListRoutesCalls(stub func(context.Context, authorization.Info, repositories.ListRoutesMessage) ([]repositories.RouteRecord, error)) {
+	fake.listRoutesMutex.Lock()
+	defer fake.listRoutesMutex.Unlock()
+	fake.ListRoutesStub = stub
+}
+
+func (fake *CFRouteRepository) ListRoutesArgsForCall(i int) (context.Context, authorization.Info, repositories.ListRoutesMessage) {
+	fake.listRoutesMutex.RLock()
+	defer fake.listRoutesMutex.RUnlock()
+	argsForCall := fake.listRoutesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouteRepository) ListRoutesReturns(result1 []repositories.RouteRecord, result2 error) {
+	fake.listRoutesMutex.Lock()
+	defer fake.listRoutesMutex.Unlock()
+	fake.ListRoutesStub = nil
+	fake.listRoutesReturns = struct {
+		result1 []repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) ListRoutesReturnsOnCall(i int, result1 []repositories.RouteRecord, result2 error) {
+	fake.listRoutesMutex.Lock()
+	defer fake.listRoutesMutex.Unlock()
+	fake.ListRoutesStub = nil
+	if fake.listRoutesReturnsOnCall == nil {
+		fake.listRoutesReturnsOnCall = make(map[int]struct {
+			result1 []repositories.RouteRecord
+			result2 error
+		})
+	}
+	fake.listRoutesReturnsOnCall[i] = struct {
+		result1 []repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFRouteRepository) ListRoutesForApp(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string) ([]repositories.RouteRecord, error) {
 	fake.listRoutesForAppMutex.Lock()
 	ret, specificReturn := fake.listRoutesForAppReturnsOnCall[len(fake.listRoutesForAppArgsForCall)]
@@ -348,6 +429,8 @@ func (fake *CFRouteRepository) Invocations() map[string][][]interface{} {
 	defer fake.addDestinationsToRouteMutex.RUnlock()
 	fake.getOrCreateRouteMutex.RLock()
 	defer fake.getOrCreateRouteMutex.RUnlock()
+	fake.listRoutesMutex.RLock()
+	defer fake.listRoutesMutex.RUnlock()
 	fake.listRoutesForAppMutex.RLock()
 	defer fake.listRoutesForAppMutex.RUnlock()
 	fake.removeDestinationFromRouteMutex.RLock()
@@ -0,0 +1,77 @@
+package actions
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/actions/shared"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type (
+	SpaceSummaryRecord struct {
+		SpaceGUID             string
+		AppsCount             int
+		RunningInstances      int
+		ServiceInstancesCount int
+		RoutesCount           int
+	}
+
+	SpaceSummary struct {
+		appRepo             shared.CFAppRepository
+		processRepo         shared.CFProcessRepository
+		serviceInstanceRepo shared.CFServiceInstanceRepository
+		routeRepo           shared.CFRouteRepository
+	}
+)
+
+func NewSpaceSummary(
+	appRepo shared.CFAppRepository,
+	processRepo shared.CFProcessRepository,
+	serviceInstanceRepo shared.CFServiceInstanceRepository,
+	routeRepo shared.CFRouteRepository,
+) *SpaceSummary {
+	return &SpaceSummary{
+		appRepo:             appRepo,
+		processRepo:         processRepo,
+		serviceInstanceRepo: serviceInstanceRepo,
+		routeRepo:           routeRepo,
+	}
+}
+
+// Summarize counts the resources in the space that are visible to the user.
+// Running instances are the actual instances of all processes in the space.
+func (a *SpaceSummary) Summarize(ctx context.Context, authInfo authorization.Info, spaceGUID string) (SpaceSummaryRecord, error) {
+	apps, err := a.appRepo.ListApps(ctx, authInfo, repositories.ListAppsMessage{SpaceGUIDs: []string{spaceGUID}})
+	if err != nil {
+		return SpaceSummaryRecord{}, err
+	}
+
+	processes, err := a.processRepo.ListProcesses(ctx, authInfo, repositories.ListProcessesMessage{SpaceGUID: spaceGUID})
+	if err != nil {
+		return SpaceSummaryRecord{}, err
+	}
+
+	serviceInstances, err := a.serviceInstanceRepo.ListServiceInstances(ctx, authInfo, repositories.ListServiceInstanceMessage{SpaceGUIDs: []string{spaceGUID}})
+	if err != nil {
+		return SpaceSummaryRecord{}, err
+	}
+
+	routes, err := a.routeRepo.ListRoutes(ctx, authInfo, repositories.ListRoutesMessage{SpaceGUIDs: []string{spaceGUID}})
+	if err != nil {
+		return SpaceSummaryRecord{}, err
+	}
+
+	runningInstances := 0
+	for _, process := range processes {
+		runningInstances += int(process.ActualInstances)
+	}
+
+	return SpaceSummaryRecord{
+		SpaceGUID:             spaceGUID,
+		AppsCount:             len(apps),
+		RunningInstances:      runningInstances,
+		ServiceInstancesCount: len(serviceInstances),
+		RoutesCount:           len(routes),
+	}, nil
+}
@@ -0,0 +1,121 @@
+package actions_test
+
+import (
+	"context"
+	"errors"
+
+	. "code.cloudfoundry.org/korifi/api/actions"
+	sfake "code.cloudfoundry.org/korifi/api/actions/shared/fake"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpaceSummary", func() {
+	var (
+		appRepo             *sfake.CFAppRepository
+		processRepo         *sfake.CFProcessRepository
+		serviceInstanceRepo *sfake.CFServiceInstanceRepository
+		routeRepo           *sfake.CFRouteRepository
+		authInfo            authorization.Info
+
+		summary    SpaceSummaryRecord
+		summaryErr error
+	)
+
+	BeforeEach(func() {
+		appRepo = new(sfake.CFAppRepository)
+		processRepo = new(sfake.CFProcessRepository)
+		serviceInstanceRepo = new(sfake.CFServiceInstanceRepository)
+		routeRepo = new(sfake.CFRouteRepository)
+		authInfo = authorization.Info{Token: "a-token"}
+
+		appRepo.ListAppsReturns([]repositories.AppRecord{{GUID: "app-1"}, {GUID: "app-2"}}, nil)
+		processRepo.ListProcessesReturns([]repositories.ProcessRecord{
+			{GUID: "process-1", ActualInstances: 2},
+			{GUID: "process-2", ActualInstances: 3},
+			{GUID: "process-3", DesiredInstances: 1},
+		}, nil)
+		serviceInstanceRepo.ListServiceInstancesReturns([]repositories.ServiceInstanceRecord{{GUID: "instance-1"}}, nil)
+		routeRepo.ListRoutesReturns([]repositories.RouteRecord{{GUID: "route-1"}, {GUID: "route-2"}, {GUID: "route-3"}}, nil)
+	})
+
+	JustBeforeEach(func() {
+		summary, summaryErr = NewSpaceSummary(appRepo, processRepo, serviceInstanceRepo, routeRepo).Summarize(context.Background(), authInfo, "space-guid")
+	})
+
+	It("counts the resources in the space", func() {
+		Expect(summaryErr).NotTo(HaveOccurred())
+		Expect(summary).To(Equal(SpaceSummaryRecord{
+			SpaceGUID:             "space-guid",
+			AppsCount:             2,
+			RunningInstances:      5,
+			ServiceInstancesCount: 1,
+			RoutesCount:           3,
+		}))
+	})
+
+	It("lists the resources in the space with the user's credentials", func() {
+		Expect(appRepo.ListAppsCallCount()).To(Equal(1))
+		_, actualAuthInfo, appsMessage := appRepo.ListAppsArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(appsMessage.SpaceGUIDs).To(ConsistOf("space-guid"))
+
+		Expect(processRepo.ListProcessesCallCount()).To(Equal(1))
+		_, actualAuthInfo, processesMessage := processRepo.ListProcessesArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(processesMessage.SpaceGUID).To(Equal("space-guid"))
+
+		Expect(serviceInstanceRepo.ListServiceInstancesCallCount()).To(Equal(1))
+		_, actualAuthInfo, serviceInstancesMessage := serviceInstanceRepo.ListServiceInstancesArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(serviceInstancesMessage.SpaceGUIDs).To(ConsistOf("space-guid"))
+
+		Expect(routeRepo.ListRoutesCallCount()).To(Equal(1))
+		_, actualAuthInfo, routesMessage := routeRepo.ListRoutesArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(routesMessage.SpaceGUIDs).To(ConsistOf("space-guid"))
+	})
+
+	When("listing apps fails", func() {
+		BeforeEach(func() {
+			appRepo.ListAppsReturns(nil, errors.New("list-apps-error"))
+		})
+
+		It("returns the error", func() {
+			Expect(summaryErr).To(MatchError("list-apps-error"))
+		})
+	})
+
+	When("listing processes fails", func() {
+		BeforeEach(func() {
+			processRepo.ListProcessesReturns(nil, errors.New("list-processes-error"))
+		})
+
+		It("returns the error", func() {
+			Expect(summaryErr).To(MatchError("list-processes-error"))
+		})
+	})
+
+	When("listing service instances fails", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.ListServiceInstancesReturns(nil, errors.New("list-service-instances-error"))
+		})
+
+		It("returns the error", func() {
+			Expect(summaryErr).To(MatchError("list-service-instances-error"))
+		})
+	})
+
+	When("listing routes fails", func() {
+		BeforeEach(func() {
+			routeRepo.ListRoutesReturns(nil, errors.New("list-routes-error"))
+		})
+
+		It("returns the error", func() {
+			Expect(summaryErr).To(MatchError("list-routes-error"))
+		})
+	})
+})
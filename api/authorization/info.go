@@ -13,7 +13,10 @@ type Info struct {
 
 type key int
 
-var infoKey key
+const (
+	infoKey key = iota
+	identityKey
+)
 
 func NewContext(ctx context.Context, info *Info) context.Context {
 	return context.WithValue(ctx, infoKey, info)
@@ -28,6 +31,15 @@ func InfoFromContext(ctx context.Context) (Info, bool) {
 	return *info, ok
 }
 
+func NewIdentityContext(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey, identity)
+}
+
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey).(Identity)
+	return identity, ok
+}
+
 func (i Info) Scheme() string {
 	if i.Token != "" {
 		return BearerScheme
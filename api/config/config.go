@@ -24,6 +24,8 @@ type (
 		ReadTimeout       int `yaml:"readTimeout"`
 		ReadHeaderTimeout int `yaml:"readHeaderTimeout"`
 		WriteTimeout      int `yaml:"writeTimeout"`
+		ShutdownDelay     int `yaml:"shutdownDelay"`
+		ShutdownTimeout   int `yaml:"shutdownTimeout"`
 
 		ExternalFQDN string `yaml:"externalFQDN"`
 		ExternalPort int    `yaml:"externalPort"`
@@ -36,11 +38,16 @@ type (
 		BuilderName                              string                 `yaml:"builderName"`
 		RunnerName                               string                 `yaml:"runnerName"`
 		ContainerRepositoryPrefix                string                 `yaml:"containerRepositoryPrefix"`
+		ContainerRepositoryPathTemplate          string                 `yaml:"containerRepositoryPathTemplate"`
 		ContainerRegistryType                    string                 `yaml:"containerRegistryType"`
 		PackageRegistrySecretNames               []string               `yaml:"packageRegistrySecretNames"`
 		DefaultDomainName                        string                 `yaml:"defaultDomainName"`
 		UserCertificateExpirationWarningDuration string                 `yaml:"userCertificateExpirationWarningDuration"`
 		DefaultLifecycleConfig                   DefaultLifecycleConfig `yaml:"defaultLifecycleConfig"`
+		LogSourcePrefixing                       bool                   `yaml:"logSourcePrefixing"`
+		StagingLogsMaxBytes                      int                    `yaml:"stagingLogsMaxBytes"`
+		CompressionMinBytes                      int                    `yaml:"compressionMinBytes"`
+		MaxAppRevisions                          int                    `yaml:"maxAppRevisions"`
 
 		RoleMappings map[string]Role `yaml:"roleMappings"`
 
@@ -48,9 +55,20 @@ type (
 		AuthProxyCACert string        `yaml:"authProxyCACert"`
 		LogLevel        zapcore.Level `yaml:"logLevel"`
 
+		RateLimit RateLimit `yaml:"rateLimit"`
+
 		Experimental Experimental `yaml:"experimental"`
 	}
 
+	// RateLimit configures the per-user token bucket applied to authenticated requests
+	RateLimit struct {
+		Enabled           bool     `yaml:"enabled"`
+		RequestsPerSecond float64  `yaml:"requestsPerSecond"`
+		Burst             int      `yaml:"burst"`
+		IdleTimeout       int      `yaml:"idleTimeout"`
+		ExemptUsers       []string `yaml:"exemptUsers"`
+	}
+
 	Experimental struct {
 		ManagedServices ManagedServices `yaml:"managedServices"`
 		UAA             UAA             `yaml:"uaa"`
@@ -142,6 +160,10 @@ func (c *APIConfig) validate() error {
 		return errors.New("BuilderName must have a value")
 	}
 
+	if c.RateLimit.Enabled && (c.RateLimit.RequestsPerSecond <= 0 || c.RateLimit.Burst <= 0) {
+		return errors.New("rateLimit requires positive values for requestsPerSecond and burst")
+	}
+
 	return nil
 }
 
@@ -153,6 +175,33 @@ func (c *APIConfig) GetUserCertificateDuration() time.Duration {
 	return d
 }
 
+// GetShutdownTimeout returns how long in-flight requests are given to complete
+// once the server starts shutting down
+func (c *APIConfig) GetShutdownTimeout() time.Duration {
+	if c.ShutdownTimeout == 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.ShutdownTimeout) * time.Second
+}
+
+// GetRateLimitIdleTimeout returns how long a user's rate limit bucket is kept
+// after their last request
+func (c *APIConfig) GetRateLimitIdleTimeout() time.Duration {
+	if c.RateLimit.IdleTimeout == 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(c.RateLimit.IdleTimeout) * time.Second
+}
+
+// GetMaxAppRevisions returns how many revisions are kept per app before the
+// oldest ones are deleted
+func (c *APIConfig) GetMaxAppRevisions() int {
+	if c.MaxAppRevisions == 0 {
+		return 100
+	}
+	return c.MaxAppRevisions
+}
+
 func (c *APIConfig) composeServerURL() (string, error) {
 	toReturn := defaultExternalProtocol + "://" + c.ExternalFQDN
 
@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"os"
+	"time"
 
 	"go.uber.org/zap/zapcore"
 
@@ -32,6 +33,8 @@ var _ = Describe("Config", func() {
 			"readTimeout":       3,
 			"readHeaderTimeout": 4,
 			"writeTimeout":      5,
+			"shutdownDelay":     6,
+			"shutdownTimeout":   7,
 
 			"externalFQDN": "api.foo",
 
@@ -41,6 +44,10 @@ var _ = Describe("Config", func() {
 			"packageRegistrySecretNames":               []string{"package-registry-secret"},
 			"defaultDomainName":                        "default.domain",
 			"userCertificateExpirationWarningDuration": "10s",
+			"logSourcePrefixing":                       true,
+			"stagingLogsMaxBytes":                      1024,
+			"compressionMinBytes":                      512,
+			"maxAppRevisions":                          20,
 			"defaultLifecycleConfig": config.DefaultLifecycleConfig{
 				Type:            "lc-type",
 				Stack:           "lc-stack",
@@ -79,6 +86,9 @@ var _ = Describe("Config", func() {
 		Expect(cfg.ReadTimeout).To(Equal(3))
 		Expect(cfg.ReadHeaderTimeout).To(Equal(4))
 		Expect(cfg.WriteTimeout).To(Equal(5))
+		Expect(cfg.ShutdownDelay).To(Equal(6))
+		Expect(cfg.ShutdownTimeout).To(Equal(7))
+		Expect(cfg.GetShutdownTimeout()).To(Equal(7 * time.Second))
 		Expect(cfg.ExternalFQDN).To(Equal("api.foo"))
 		Expect(cfg.ServerURL).To(Equal("https://api.foo"))
 		Expect(cfg.RootNamespace).To(Equal("root-ns"))
@@ -87,6 +97,10 @@ var _ = Describe("Config", func() {
 		Expect(cfg.PackageRegistrySecretNames).To(ConsistOf("package-registry-secret"))
 		Expect(cfg.DefaultDomainName).To(Equal("default.domain"))
 		Expect(cfg.UserCertificateExpirationWarningDuration).To(Equal("10s"))
+		Expect(cfg.LogSourcePrefixing).To(BeTrue())
+		Expect(cfg.CompressionMinBytes).To(Equal(512))
+		Expect(cfg.GetMaxAppRevisions()).To(Equal(20))
+		Expect(cfg.StagingLogsMaxBytes).To(Equal(1024))
 		Expect(cfg.DefaultLifecycleConfig).To(Equal(config.DefaultLifecycleConfig{
 			Type:            "lc-type",
 			Stack:           "lc-stack",
@@ -181,6 +195,72 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	When("the max app revisions is not set", func() {
+		BeforeEach(func() {
+			delete(configMap, "maxAppRevisions")
+		})
+
+		It("defaults to 100", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.GetMaxAppRevisions()).To(Equal(100))
+		})
+	})
+
+	When("the shutdown timeout is not set", func() {
+		BeforeEach(func() {
+			delete(configMap, "shutdownTimeout")
+		})
+
+		It("defaults to 30 seconds", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.GetShutdownTimeout()).To(Equal(30 * time.Second))
+		})
+	})
+
+	When("rate limiting is enabled", func() {
+		BeforeEach(func() {
+			configMap["rateLimit"] = map[string]interface{}{
+				"enabled":           true,
+				"requestsPerSecond": 2.5,
+				"burst":             10,
+				"idleTimeout":       60,
+				"exemptUsers":       []string{"admin"},
+			}
+		})
+
+		It("loads the rate limit config", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.RateLimit).To(Equal(config.RateLimit{
+				Enabled:           true,
+				RequestsPerSecond: 2.5,
+				Burst:             10,
+				IdleTimeout:       60,
+				ExemptUsers:       []string{"admin"},
+			}))
+			Expect(cfg.GetRateLimitIdleTimeout()).To(Equal(time.Minute))
+		})
+
+		When("the burst is not set", func() {
+			BeforeEach(func() {
+				delete(configMap["rateLimit"].(map[string]interface{}), "burst")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("rateLimit requires positive values")))
+			})
+		})
+
+		When("the idle timeout is not set", func() {
+			BeforeEach(func() {
+				delete(configMap["rateLimit"].(map[string]interface{}), "idleTimeout")
+			})
+
+			It("defaults to ten minutes", func() {
+				Expect(cfg.GetRateLimitIdleTimeout()).To(Equal(10 * time.Minute))
+			})
+		})
+	})
+
 	When("the builder is not specified", func() {
 		BeforeEach(func() {
 			delete(configMap, "builderName")
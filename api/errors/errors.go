@@ -290,6 +290,22 @@ func NewUniquenessError(cause error, detail string) UniquenessError {
 	}
 }
 
+type AssociationNotEmptyError struct {
+	apiError
+}
+
+func NewAssociationNotEmptyError(cause error, detail string) AssociationNotEmptyError {
+	return AssociationNotEmptyError{
+		apiError: apiError{
+			cause:      cause,
+			title:      "CF-AssociationNotEmpty",
+			detail:     detail,
+			code:       10006,
+			httpStatus: http.StatusUnprocessableEntity,
+		},
+	}
+}
+
 type InvalidRequestError struct {
 	apiError
 }
@@ -370,6 +386,41 @@ func NewRollingDeployNotSupportedError(runnerName string) RollingDeployNotSuppor
 	}
 }
 
+type RateLimitExceededError struct {
+	apiError
+}
+
+func NewRateLimitExceededError() RateLimitExceededError {
+	return RateLimitExceededError{
+		apiError: apiError{
+			title:      "CF-RateLimitExceeded",
+			detail:     "Rate Limit Exceeded",
+			code:       10013,
+			httpStatus: http.StatusTooManyRequests,
+		},
+	}
+}
+
+type FeatureDisabledError struct {
+	apiError
+}
+
+func NewFeatureDisabledError(featureName, customMessage string) FeatureDisabledError {
+	detail := featureName
+	if customMessage != "" {
+		detail = customMessage
+	}
+
+	return FeatureDisabledError{
+		apiError: apiError{
+			title:      "CF-FeatureDisabled",
+			detail:     "Feature Disabled: " + detail,
+			code:       330002,
+			httpStatus: http.StatusForbidden,
+		},
+	}
+}
+
 func FromK8sError(err error, resourceType string) error {
 	if webhookValidationError, ok := validation.WebhookErrorToValidationError(err); ok {
 		return NewUnprocessableEntityError(err, webhookValidationError.GetMessage())
@@ -10,12 +10,14 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers/include"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
 	"code.cloudfoundry.org/korifi/api/tools/singleton"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 
 	"github.com/go-logr/logr"
 )
@@ -39,7 +41,9 @@ const (
 	AppFeaturePath                    = "/v3/apps/{guid}/features/{name}"
 	AppPackagesPath                   = "/v3/apps/{guid}/packages"
 	AppSSHEnabledPath                 = "/v3/apps/{guid}/ssh_enabled"
+	AppRevisionsPath                  = "/v3/apps/{guid}/revisions"
 	AppInstanceRestartPath            = "/v3/apps/{guid}/processes/{processType}/instances/{instance}"
+	AppInstanceExecPath               = "/v3/apps/{guid}/processes/{processType}/instances/{instance}/exec"
 	invalidDropletMsg                 = "Unable to assign current droplet. Ensure the droplet exists and belongs to this app."
 
 	AppStartedState = "STARTED"
@@ -55,14 +59,17 @@ type CFAppRepository interface {
 	CreateApp(context.Context, authorization.Info, repositories.CreateAppMessage) (repositories.AppRecord, error)
 	SetCurrentDroplet(context.Context, authorization.Info, repositories.SetCurrentDropletMessage) (repositories.CurrentDropletRecord, error)
 	SetAppDesiredState(context.Context, authorization.Info, repositories.SetAppDesiredStateMessage) (repositories.AppRecord, error)
+	RestartApp(context.Context, authorization.Info, repositories.RestartAppMessage) (repositories.AppRecord, error)
 	DeleteApp(context.Context, authorization.Info, repositories.DeleteAppMessage) error
 	GetAppEnv(context.Context, authorization.Info, string) (repositories.AppEnvRecord, error)
 	PatchApp(context.Context, authorization.Info, repositories.PatchAppMessage) (repositories.AppRecord, error)
+	SetAppSSHEnabled(context.Context, authorization.Info, repositories.SetAppSSHEnabledMessage) (repositories.AppRecord, error)
 }
 
 //counterfeiter:generate -o fake -fake-name PodRepository . PodRepository
 type PodRepository interface {
 	DeletePod(context.Context, authorization.Info, string, repositories.ProcessRecord, string) error
+	ExecInstance(context.Context, authorization.Info, repositories.ExecInstanceMessage) (http.Handler, error)
 }
 
 type App struct {
@@ -77,6 +84,15 @@ type App struct {
 	packageRepo      CFPackageRepository
 	requestValidator RequestValidator
 	podRepo          PodRepository
+	revisionRepo     CFRevisionRepository
+	includeResolver  *include.IncludeResolver[
+		[]repositories.AppRecord,
+		repositories.AppRecord,
+	]
+	routeIncludeResolver *include.IncludeResolver[
+		[]repositories.RouteRecord,
+		repositories.RouteRecord,
+	]
 }
 
 func NewApp(
@@ -91,19 +107,24 @@ func NewApp(
 	packageRepo CFPackageRepository,
 	requestValidator RequestValidator,
 	podRepo PodRepository,
+	revisionRepo CFRevisionRepository,
+	relationshipRepo include.ResourceRelationshipRepository,
 ) *App {
 	return &App{
-		serverURL:        serverURL,
-		appRepo:          appRepo,
-		dropletRepo:      dropletRepo,
-		processRepo:      processRepo,
-		processStats:     processStatsFetcher,
-		routeRepo:        routeRepo,
-		domainRepo:       domainRepo,
-		spaceRepo:        spaceRepo,
-		packageRepo:      packageRepo,
-		requestValidator: requestValidator,
-		podRepo:          podRepo,
+		serverURL:            serverURL,
+		appRepo:              appRepo,
+		dropletRepo:          dropletRepo,
+		processRepo:          processRepo,
+		processStats:         processStatsFetcher,
+		routeRepo:            routeRepo,
+		domainRepo:           domainRepo,
+		spaceRepo:            spaceRepo,
+		packageRepo:          packageRepo,
+		requestValidator:     requestValidator,
+		podRepo:              podRepo,
+		revisionRepo:         revisionRepo,
+		includeResolver:      include.NewIncludeResolver[[]repositories.AppRecord](relationshipRepo, presenter.NewResource(serverURL)),
+		routeIncludeResolver: include.NewIncludeResolver[[]repositories.RouteRecord](relationshipRepo, presenter.NewResource(serverURL)),
 	}
 }
 
@@ -112,11 +133,29 @@ func (h *App) get(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get")
 
+	payload := new(payloads.AppGet)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
 	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "GUID", appGUID)
 	}
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
+
+	includedResources, err := h.includeResolver.ResolveIncludes(r.Context(), authInfo, []repositories.AppRecord{app}, payload.IncludeResourceRules)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to build included resources")
+	}
+
+	response := routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL, includedResources...))
+	if len(includedResources) == 0 {
+		// Included resources change independently of the app, so only the
+		// plain app representation can be tagged with its resource version
+		response = response.WithETag(app.ResourceVersion)
+	}
+
+	return response, nil
 }
 
 //nolint:dupl
@@ -170,7 +209,12 @@ func (h *App) list(r *http.Request) (*routing.Response, error) { //nolint:dupl
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch app(s) from Kubernetes")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForApp, appList, h.serverURL, *r.URL)), nil
+	includedResources, err := h.includeResolver.ResolveIncludes(r.Context(), authInfo, appList, payload.IncludeResourceRules)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to build included resources")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPaginatedList(presenter.ForApp, appList, h.serverURL, *r.URL, payload.PageNumber(), payload.PerPageNumber(), includedResources...)), nil
 }
 
 func (h *App) setCurrentDroplet(r *http.Request) (*routing.Response, error) {
@@ -215,25 +259,65 @@ func (h *App) setCurrentDroplet(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Error setting current droplet")
 	}
 
+	_, err = h.revisionRepo.CreateRevision(r.Context(), authInfo, repositories.CreateRevisionMessage{
+		AppGUID:     appGUID,
+		SpaceGUID:   app.SpaceGUID,
+		Description: repositories.RevisionDescriptionNewDroplet,
+	})
+	if err != nil {
+		// The droplet has already been set, so the request does not fail
+		// because of the revision
+		logger.Info("failed to record app revision", "AppGUID", appGUID, "reason", err)
+	}
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForCurrentDroplet(currentDroplet, h.serverURL)), nil
 }
 
+func (h *App) getCurrentDropletRelationship(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-current-droplet-relationship")
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForCurrentDroplet(repositories.CurrentDropletRecord{
+		AppGUID:     app.GUID,
+		DropletGUID: app.DropletGUID,
+	}, h.serverURL)), nil
+}
+
 func (h *App) listDroplets(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-droplets")
 	appGUID := routing.URLParam(r, "guid")
 
+	payload := new(payloads.AppListDroplets)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
 	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
 	}
 
-	droplets, err := h.dropletRepo.ListDroplets(r.Context(), authInfo, repositories.ListDropletsMessage{AppGUIDs: []string{appGUID}})
+	message := payload.ToMessage(appGUID)
+	if tools.ZeroIfNil(payload.Current) {
+		if app.DropletGUID == "" {
+			return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPaginatedList(presenter.ForDroplet, []repositories.DropletRecord{}, h.serverURL, *r.URL, payload.PageNumber(), payload.PerPageNumber())), nil
+		}
+		message.GUIDs = []string{app.DropletGUID}
+	}
+
+	droplets, err := h.dropletRepo.ListDroplets(r.Context(), authInfo, message)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch droplet from Kubernetes", "dropletGUID", app.DropletGUID)
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForDroplet, droplets, h.serverURL, *r.URL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPaginatedList(presenter.ForDroplet, droplets, h.serverURL, *r.URL, payload.PageNumber(), payload.PerPageNumber())), nil
 }
 
 func (h *App) getCurrentDroplet(r *http.Request) (*routing.Response, error) {
@@ -334,6 +418,11 @@ func (h *App) getProcesses(r *http.Request) (*routing.Response, error) {
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-processes")
 	appGUID := routing.URLParam(r, "guid")
 
+	payload := new(payloads.AppProcessList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
 	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
@@ -349,7 +438,7 @@ func (h *App) getProcesses(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch app Process(es) from Kubernetes")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcessList(processList, h.serverURL, *r.URL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcessList(processList, h.serverURL, *r.URL, payload.PageNumber(), payload.PerPageNumber())), nil
 }
 
 func (h *App) getRoutes(r *http.Request) (*routing.Response, error) {
@@ -357,17 +446,32 @@ func (h *App) getRoutes(r *http.Request) (*routing.Response, error) {
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-routes")
 	appGUID := routing.URLParam(r, "guid")
 
+	payload := new(payloads.AppRouteList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
 	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
 	}
 
-	routes, err := h.lookupAppRouteAndDomainList(r.Context(), authInfo, app.GUID, app.SpaceGUID)
+	routeRecords, err := h.routeRepo.ListRoutes(r.Context(), authInfo, payload.ToMessage(app.GUID))
 	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch route or domains from Kubernetes")
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch routes from Kubernetes")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRoute, routes, h.serverURL, *r.URL)), nil
+	routes, err := getDomainsForRoutes(r.Context(), h.domainRepo, authInfo, routeRecords)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch domains from Kubernetes")
+	}
+
+	includedResources, err := h.routeIncludeResolver.ResolveIncludes(r.Context(), authInfo, routes, payload.IncludeResourceRules)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to build included resources")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPaginatedList(presenter.ForRoute, routes, h.serverURL, *r.URL, payload.PageNumber(), payload.PerPageNumber(), includedResources...)), nil
 }
 
 func (h *App) scaleProcess(r *http.Request) (*routing.Response, error) {
@@ -436,22 +540,40 @@ func (h *App) restart(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
 	}
 
-	app, err = h.stopApp(r.Context(), authInfo, app)
-	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, err, "Failed to stop app", "AppGUID", appGUID)
+	if app.State != AppStartedState {
+		app, err = h.startApp(r.Context(), authInfo, app)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to start app", "AppGUID", appGUID)
+		}
+	} else {
+		// A running app is rolled rather than stopped and started, so that it
+		// never drops to zero instances
+		app, err = h.appRepo.RestartApp(r.Context(), authInfo, repositories.RestartAppMessage{
+			AppGUID:   app.GUID,
+			SpaceGUID: app.SpaceGUID,
+		})
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to restart app", "AppGUID", appGUID)
+		}
 	}
 
-	app, err = h.startApp(r.Context(), authInfo, app)
+	_, err = h.revisionRepo.CreateRevision(r.Context(), authInfo, repositories.CreateRevisionMessage{
+		AppGUID:     app.GUID,
+		SpaceGUID:   app.SpaceGUID,
+		Description: repositories.RevisionDescriptionRestart,
+	})
 	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, err, "Failed to start app", "AppGUID", appGUID)
+		// The app has already been restarted, so the request does not fail
+		// because of the revision
+		logger.Info("failed to record app revision", "AppGUID", appGUID, "reason", err)
 	}
 
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
 
-func (h *App) delete(r *http.Request) (*routing.Response, error) {
+func (h *App) getRevisions(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
-	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.delete")
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-revisions")
 	appGUID := routing.URLParam(r, "guid")
 
 	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
@@ -459,24 +581,36 @@ func (h *App) delete(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
 	}
 
-	err = h.appRepo.DeleteApp(r.Context(), authInfo, repositories.DeleteAppMessage{
+	revisions, err := h.revisionRepo.ListRevisions(r.Context(), authInfo, repositories.ListRevisionsMessage{
 		AppGUID:   appGUID,
 		SpaceGUID: app.SpaceGUID,
 	})
 	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete app", "AppGUID", appGUID)
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch app revisions from Kubernetes", "AppGUID", appGUID)
 	}
 
-	return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(appGUID, presenter.AppDeleteOperation, h.serverURL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRevision, revisions, h.serverURL, *r.URL)), nil
 }
 
-func (h *App) lookupAppRouteAndDomainList(ctx context.Context, authInfo authorization.Info, appGUID, spaceGUID string) ([]repositories.RouteRecord, error) {
-	routeRecords, err := h.routeRepo.ListRoutesForApp(ctx, authInfo, appGUID, spaceGUID)
+func (h *App) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.delete")
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
 	if err != nil {
-		return []repositories.RouteRecord{}, err
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
 	}
 
-	return getDomainsForRoutes(ctx, h.domainRepo, authInfo, routeRecords)
+	err = h.appRepo.DeleteApp(r.Context(), authInfo, repositories.DeleteAppMessage{
+		AppGUID:   appGUID,
+		SpaceGUID: app.SpaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete app", "AppGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(appGUID, presenter.AppDeleteOperation, h.serverURL)), nil
 }
 
 func getDomainsForRoutes(ctx context.Context, domainRepo CFDomainRepository, authInfo authorization.Info, routeRecords []repositories.RouteRecord) ([]repositories.RouteRecord, error) {
@@ -625,17 +759,17 @@ func (h *App) getPackages(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
 	}
 
-	fetchPackagesForAppMessage := repositories.ListPackagesMessage{
-		AppGUIDs: []string{appGUID},
-		States:   []string{},
+	payload := new(payloads.AppPackageList)
+	if err = h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
 	}
 
-	packageList, err := h.packageRepo.ListPackages(r.Context(), authInfo, fetchPackagesForAppMessage)
+	packageList, err := h.packageRepo.ListPackages(r.Context(), authInfo, payload.ToMessage(appGUID))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch app Package(s) from Kubernetes")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForPackage, packageList, h.serverURL, *r.URL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPaginatedList(presenter.ForPackage, packageList, h.serverURL, *r.URL, payload.PageNumber(), payload.PerPageNumber())), nil
 }
 
 //nolint:dupl
@@ -662,26 +796,92 @@ func (h *App) update(r *http.Request) (*routing.Response, error) {
 }
 
 func (h *App) getSSHEnabled(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-ssh-enabled")
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	if !app.SSHEnabled {
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
+			Enabled: false,
+			Reason:  "Disabled for app",
+		}), nil
+	}
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
-		Enabled: false,
-		Reason:  "Disabled globally",
+		Enabled: true,
+		Reason:  "",
 	}), nil
 }
 
 func (h *App) getAppFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-app-feature")
+	appGUID := routing.URLParam(r, "guid")
+	featureName := routing.URLParam(r, "name")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	return appFeatureResponse(app, featureName)
+}
+
+func (h *App) updateAppFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.update-app-feature")
+	appGUID := routing.URLParam(r, "guid")
 	featureName := routing.URLParam(r, "name")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	var payload payloads.AppFeatureUpdate
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	switch featureName {
+	case "ssh":
+		app, err = h.appRepo.SetAppSSHEnabled(r.Context(), authInfo, repositories.SetAppSSHEnabledMessage{
+			AppGUID:   app.GUID,
+			SpaceGUID: app.SpaceGUID,
+			Enabled:   *payload.Enabled,
+		})
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to update app ssh feature", "AppGUID", appGUID)
+		}
+	case "revisions":
+		if !*payload.Enabled {
+			return nil, apierrors.LogAndReturn(logger,
+				apierrors.NewUnprocessableEntityError(nil, "Revisions cannot be disabled"),
+				"Revisions cannot be disabled", "AppGUID", appGUID)
+		}
+	}
+
+	return appFeatureResponse(app, featureName)
+}
+
+func appFeatureResponse(app repositories.AppRecord, featureName string) (*routing.Response, error) {
 	switch featureName {
 	case "ssh":
 		return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
 			"name":        "ssh",
 			"description": "Enable SSHing into the app.",
-			"enabled":     false,
+			"enabled":     app.SSHEnabled,
 		}), nil
 	case "revisions":
 		return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
 			"name":        "revisions",
 			"description": "Enable versioning of an application",
-			"enabled":     false,
+			"enabled":     true,
 		}), nil
 	default:
 		return nil, apierrors.NewNotFoundError(nil, "Feature")
@@ -734,6 +934,64 @@ func (h *App) restartInstance(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusNoContent), nil
 }
 
+func (h *App) execInstance(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.exec-instance")
+	appGUID := routing.URLParam(r, "guid")
+	instanceID := routing.URLParam(r, "instance")
+	processType := routing.URLParam(r, "processType")
+
+	var payload payloads.AppInstanceExec
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	if !app.SSHEnabled {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewFeatureDisabledError("ssh", ""), "SSH is disabled for app", "AppGUID", appGUID)
+	}
+
+	appProcesses, err := h.processRepo.ListProcesses(r.Context(), authInfo, repositories.ListProcessesMessage{
+		AppGUIDs:  []string{appGUID},
+		SpaceGUID: app.SpaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list processes for app")
+	}
+
+	process, hasProcessType := findProcessType(appProcesses, processType)
+	if !hasProcessType {
+		return nil, apierrors.LogAndReturn(logger,
+			apierrors.NewNotFoundError(nil, repositories.ProcessResourceType),
+			"app does not have required process type",
+		)
+	}
+
+	instance, err := strconv.Atoi(instanceID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(err, "Invalid Instance ID. Instance ID is not a valid Integer.", apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+			"InstanceID", instanceID,
+		)
+	}
+	if int(process.DesiredInstances) <= instance {
+		return nil, apierrors.LogAndReturn(logger,
+			apierrors.NewNotFoundError(nil, fmt.Sprintf("Instance %d of process %s", instance, processType)), "Instance not found", "AppGUID", appGUID, "InstanceID", instanceID, "Process", process)
+	}
+
+	execHandler, err := h.podRepo.ExecInstance(r.Context(), authInfo, payload.ToMessage(app.Revision, process, instanceID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to exec into instance", "AppGUID", appGUID, "InstanceID", instanceID, "Process", process)
+	}
+
+	return routing.NewResponse(http.StatusSwitchingProtocols).WithUpgrade(execHandler), nil
+}
+
 func (h *App) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -743,6 +1001,7 @@ func (h *App) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: AppPath, Handler: h.get},
 		{Method: "GET", Pattern: AppsPath, Handler: h.list},
 		{Method: "POST", Pattern: AppsPath, Handler: h.create},
+		{Method: "GET", Pattern: AppCurrentDropletRelationshipPath, Handler: h.getCurrentDropletRelationship},
 		{Method: "PATCH", Pattern: AppCurrentDropletRelationshipPath, Handler: h.setCurrentDroplet},
 		{Method: "GET", Pattern: AppDropletsPath, Handler: h.listDroplets},
 		{Method: "GET", Pattern: AppCurrentDropletPath, Handler: h.getCurrentDroplet},
@@ -760,8 +1019,12 @@ func (h *App) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: AppEnvPath, Handler: h.getEnvironment},
 		{Method: "GET", Pattern: AppPackagesPath, Handler: h.getPackages},
 		{Method: "GET", Pattern: AppFeaturePath, Handler: h.getAppFeature},
+		{Method: "PATCH", Pattern: AppFeaturePath, Handler: h.updateAppFeature},
 		{Method: "PATCH", Pattern: AppPath, Handler: h.update},
 		{Method: "GET", Pattern: AppSSHEnabledPath, Handler: h.getSSHEnabled},
+		{Method: "GET", Pattern: AppRevisionsPath, Handler: h.getRevisions},
 		{Method: "DELETE", Pattern: AppInstanceRestartPath, Handler: h.restartInstance},
+		{Method: "GET", Pattern: AppInstanceExecPath, Handler: h.execInstance},
+		{Method: "POST", Pattern: AppInstanceExecPath, Handler: h.execInstance},
 	}
 }
@@ -12,7 +12,9 @@ import (
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/relationships"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
@@ -37,8 +39,10 @@ var _ = Describe("App", func() {
 		routeRepo        *fake.CFRouteRepository
 		domainRepo       *fake.CFDomainRepository
 		spaceRepo        *fake.CFSpaceRepository
+		orgRepo          *fake.CFOrgRepository
 		packageRepo      *fake.CFPackageRepository
 		podRepo          *fake.PodRepository
+		revisionRepo     *fake.CFRevisionRepository
 		requestValidator *fake.RequestValidator
 		req              *http.Request
 
@@ -53,9 +57,11 @@ var _ = Describe("App", func() {
 		routeRepo = new(fake.CFRouteRepository)
 		domainRepo = new(fake.CFDomainRepository)
 		spaceRepo = new(fake.CFSpaceRepository)
+		orgRepo = new(fake.CFOrgRepository)
 		packageRepo = new(fake.CFPackageRepository)
 		requestValidator = new(fake.RequestValidator)
 		podRepo = new(fake.PodRepository)
+		revisionRepo = new(fake.CFRevisionRepository)
 
 		apiHandler := NewApp(
 			*serverURL,
@@ -69,15 +75,25 @@ var _ = Describe("App", func() {
 			packageRepo,
 			requestValidator,
 			podRepo,
+			revisionRepo,
+			relationships.NewResourseRelationshipsRepo(
+				new(fake.CFServiceOfferingRepository),
+				new(fake.CFServiceBrokerRepository),
+				new(fake.CFServicePlanRepository),
+				domainRepo,
+				spaceRepo,
+				orgRepo,
+			),
 		)
 
 		appRecord = repositories.AppRecord{
-			GUID:        appGUID,
-			Name:        "test-app",
-			SpaceGUID:   spaceGUID,
-			State:       "STOPPED",
-			Revision:    "0",
-			DropletGUID: "test-droplet-guid",
+			GUID:            appGUID,
+			Name:            "test-app",
+			SpaceGUID:       spaceGUID,
+			State:           "STOPPED",
+			Revision:        "0",
+			DropletGUID:     "test-droplet-guid",
+			ResourceVersion: "42",
 			Lifecycle: repositories.Lifecycle{
 				Type: "buildpack",
 				Data: repositories.LifecycleData{
@@ -112,6 +128,7 @@ var _ = Describe("App", func() {
 
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("ETag", `W/"42"`))
 
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.guid", "test-app-guid"),
@@ -120,6 +137,68 @@ var _ = Describe("App", func() {
 			)))
 		})
 
+		When("the space and organization are included", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{
+					GUID:             spaceGUID,
+					Name:             "test-space",
+					OrganizationGUID: "test-org-guid",
+				}}, nil)
+				orgRepo.ListOrgsReturns([]repositories.OrgRecord{{
+					GUID: "test-org-guid",
+					Name: "test-org",
+				}}, nil)
+
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppGet{
+					IncludeResourceRules: []params.IncludeResourceRule{
+						{RelationshipPath: []string{"space"}, Fields: []string{}},
+						{RelationshipPath: []string{"space", "organization"}, Fields: []string{}},
+					},
+				})
+			})
+
+			It("includes them in the response", func() {
+				Expect(spaceRepo.ListSpacesCallCount()).To(Equal(2))
+				_, _, spaceMessage := spaceRepo.ListSpacesArgsForCall(0)
+				Expect(spaceMessage.GUIDs).To(ConsistOf(spaceGUID))
+
+				Expect(orgRepo.ListOrgsCallCount()).To(Equal(1))
+				_, _, orgMessage := orgRepo.ListOrgsArgsForCall(0)
+				Expect(orgMessage.GUIDs).To(ConsistOf("test-org-guid"))
+
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.guid", "test-app-guid"),
+					MatchJSONPath("$.included.spaces[0].guid", spaceGUID),
+					MatchJSONPath("$.included.organizations[0].guid", "test-org-guid"),
+				)))
+			})
+
+			It("does not tag the response", func() {
+				Expect(rr.Header()).NotTo(HaveKey("Etag"))
+			})
+
+			When("listing the included resources fails", func() {
+				BeforeEach(func() {
+					spaceRepo.ListSpacesReturns(nil, errors.New("list-spaces-err"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
+		When("decoding the query parameters fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewBadQueryParamValueError("include", "space", "space.organization"))
+			})
+
+			It("returns an error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusBadRequest))
+				Expect(appRepo.GetAppCallCount()).To(BeZero())
+			})
+		})
+
 		When("the app is not accessible", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
@@ -296,6 +375,20 @@ var _ = Describe("App", func() {
 			})
 		})
 
+		When("the user is not allowed to create apps in the space", func() {
+			BeforeEach(func() {
+				appRepo.CreateAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+
+			It("does not create the web process", func() {
+				Expect(processRepo.CreateProcessCallCount()).To(BeZero())
+			})
+		})
+
 		When("the action errors", func() {
 			BeforeEach(func() {
 				appRepo.CreateAppReturns(repositories.AppRecord{}, errors.New("nope"))
@@ -357,7 +450,10 @@ var _ = Describe("App", func() {
 			Expect(rr).Should(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps?foo=bar"),
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(1)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps?foo=bar&page=1&per_page=50"),
+				MatchJSONPath("$.pagination.last.href", "https://api.example.org/v3/apps?foo=bar&page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", "first-test-app-guid"),
 				MatchJSONPath("$.resources[0].state", "STOPPED"),
@@ -384,6 +480,72 @@ var _ = Describe("App", func() {
 			})
 		})
 
+		When("pagination query params are provided", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppList{
+					Pagination: payloads.Pagination{PerPage: "1", Page: "2"},
+				})
+			})
+
+			It("returns the requested page", func() {
+				Expect(rr).Should(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+					MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps?foo=bar&page=1&per_page=1"),
+					MatchJSONPath("$.pagination.last.href", "https://api.example.org/v3/apps?foo=bar&page=2&per_page=1"),
+					MatchJSONPath("$.pagination.previous.href", "https://api.example.org/v3/apps?foo=bar&page=1&per_page=1"),
+					MatchJSONPath("$.pagination.next", BeNil()),
+					MatchJSONPath("$.resources", HaveLen(1)),
+					MatchJSONPath("$.resources[0].guid", "second-test-app-guid"),
+				)))
+			})
+
+			When("the page exceeds the number of pages", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppList{
+						Pagination: payloads.Pagination{PerPage: "1", Page: "5"},
+					})
+				})
+
+				It("returns an empty list", func() {
+					Expect(rr).Should(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody(SatisfyAll(
+						MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+						MatchJSONPath("$.resources", BeEmpty()),
+					)))
+				})
+			})
+		})
+
+		When("the space is included", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{
+					GUID: "test-space-guid",
+					Name: "test-space",
+				}}, nil)
+
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppList{
+					IncludeResourceRules: []params.IncludeResourceRule{{
+						RelationshipPath: []string{"space"},
+						Fields:           []string{},
+					}},
+				})
+			})
+
+			It("includes the spaces in the response", func() {
+				Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+				_, _, message := spaceRepo.ListSpacesArgsForCall(0)
+				Expect(message.GUIDs).To(ContainElement("test-space-guid"))
+
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.resources", HaveLen(2)),
+					MatchJSONPath("$.included.spaces", HaveLen(1)),
+					MatchJSONPath("$.included.spaces[0].guid", "test-space-guid"),
+				)))
+			})
+		})
+
 		When("no apps can be found", func() {
 			BeforeEach(func() {
 				appRepo.ListAppsReturns([]repositories.AppRecord{}, nil)
@@ -582,6 +744,17 @@ var _ = Describe("App", func() {
 			Expect(message.SpaceGUID).To(Equal(spaceGUID))
 		})
 
+		It("records a new app revision", func() {
+			Expect(revisionRepo.CreateRevisionCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := revisionRepo.CreateRevisionArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateRevisionMessage{
+				AppGUID:     appGUID,
+				SpaceGUID:   spaceGUID,
+				Description: repositories.RevisionDescriptionNewDroplet,
+			}))
+		})
+
 		It("returns the droplet", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
@@ -605,6 +778,20 @@ var _ = Describe("App", func() {
 			It("returns a not authenticated error", func() {
 				expectUnknownError()
 			})
+
+			It("does not record a revision", func() {
+				Expect(revisionRepo.CreateRevisionCallCount()).To(BeZero())
+			})
+		})
+
+		When("recording the revision fails", func() {
+			BeforeEach(func() {
+				revisionRepo.CreateRevisionReturns(repositories.RevisionRecord{}, errors.New("create-revision-failed"))
+			})
+
+			It("still sets the droplet", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			})
 		})
 
 		When("getting the app fails", func() {
@@ -845,7 +1032,7 @@ var _ = Describe("App", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/"+appGUID+"/processes"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/"+appGUID+"/processes?page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", "process-1-guid"),
 				MatchJSONPath("$.resources[0].command", "[PRIVATE DATA HIDDEN IN LISTS]"),
@@ -854,6 +1041,32 @@ var _ = Describe("App", func() {
 			)))
 		})
 
+		When("a page is requested", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppProcessList{
+					Pagination: payloads.Pagination{Page: "2", PerPage: "1"},
+				})
+			})
+
+			It("returns the requested page", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+					MatchJSONPath("$.resources", HaveLen(1)),
+					MatchJSONPath("$.resources[0].guid", "process-2-guid"),
+				)))
+			})
+		})
+
+		When("decoding the url values fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(errors.New("foo"), "invalid page"))
+			})
+
+			It("returns an Unprocessable Entity error", func() {
+				expectUnprocessableEntityError("invalid page")
+			})
+		})
+
 		When("the app cannot be accessed", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
@@ -909,6 +1122,22 @@ var _ = Describe("App", func() {
 			)))
 		})
 
+		It("lists the app processes of the requested type", func() {
+			_, _, message := processRepo.ListProcessesArgsForCall(0)
+			Expect(message.AppGUIDs).To(ConsistOf(appGUID))
+			Expect(message.ProcessTypes).To(ConsistOf("web"))
+		})
+
+		When("the app has no process of the requested type", func() {
+			BeforeEach(func() {
+				processRepo.ListProcessesReturns([]repositories.ProcessRecord{}, nil)
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Process")
+			})
+		})
+
 		When("the user lacks access in the app namespace", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(errors.New("Forbidden"), repositories.AppResourceType))
@@ -1147,12 +1376,23 @@ var _ = Describe("App", func() {
 
 	Describe("GET /v3/apps/:guid/routes", func() {
 		BeforeEach(func() {
-			routeRepo.ListRoutesForAppReturns([]repositories.RouteRecord{
+			routeRepo.ListRoutesReturns([]repositories.RouteRecord{
 				{
 					GUID:     "test-route-guid",
 					Host:     "test-route-host",
 					Path:     "/some_path",
 					Protocol: "http",
+					Domain: repositories.DomainRecord{
+						GUID: "test-domain-guid",
+					},
+				},
+				{
+					GUID:     "other-route-guid",
+					Host:     "other-route-host",
+					Protocol: "http",
+					Domain: repositories.DomainRecord{
+						GUID: "test-domain-guid",
+					},
 				},
 			}, nil)
 
@@ -1169,8 +1409,8 @@ var _ = Describe("App", func() {
 			_, actualAuthInfo, _ := appRepo.GetAppArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
 
-			Expect(routeRepo.ListRoutesForAppCallCount()).To(Equal(1))
-			_, actualAuthInfo, _, _ = routeRepo.ListRoutesForAppArgsForCall(0)
+			Expect(routeRepo.ListRoutesCallCount()).To(Equal(1))
+			_, actualAuthInfo, _ = routeRepo.ListRoutesArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
 
 			Expect(domainRepo.GetDomainCallCount()).To(Equal(1))
@@ -1178,18 +1418,96 @@ var _ = Describe("App", func() {
 			Expect(actualAuthInfo).To(Equal(authInfo))
 		})
 
+		It("lists the routes with a destination pointing to the app", func() {
+			_, _, message := routeRepo.ListRoutesArgsForCall(0)
+			Expect(message.AppGUIDs).To(ConsistOf(appGUID))
+		})
+
 		It("returns the list of routes", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/"+appGUID+"/routes"),
-				MatchJSONPath("$.resources", HaveLen(1)),
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/"+appGUID+"/routes?page=1&per_page=50"),
+				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", "test-route-guid"),
 				MatchJSONPath("$.resources[0].url", "test-route-host.example.org/some_path"),
+				Not(ContainSubstring("included")),
 			)))
 		})
 
+		When("filters are provided", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppRouteList{
+					SpaceGUIDs:  "s1",
+					DomainGUIDs: "d1,d2",
+					Hosts:       "h1",
+					Paths:       "p1",
+				})
+			})
+
+			It("passes them to the repository", func() {
+				_, _, message := routeRepo.ListRoutesArgsForCall(0)
+				Expect(message.AppGUIDs).To(ConsistOf(appGUID))
+				Expect(message.SpaceGUIDs).To(ConsistOf("s1"))
+				Expect(message.DomainGUIDs).To(ConsistOf("d1", "d2"))
+				Expect(message.Hosts).To(ConsistOf("h1"))
+				Expect(message.Paths).To(ConsistOf("p1"))
+			})
+		})
+
+		When("a page is requested", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppRouteList{
+					Pagination: payloads.Pagination{Page: "2", PerPage: "1"},
+				})
+			})
+
+			It("returns the requested page", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+					MatchJSONPath("$.resources", HaveLen(1)),
+					MatchJSONPath("$.resources[0].guid", "other-route-guid"),
+				)))
+			})
+		})
+
+		When("the domain is included", func() {
+			BeforeEach(func() {
+				domainRepo.ListDomainsReturns([]repositories.DomainRecord{{
+					GUID: "test-domain-guid",
+					Name: "example.org",
+				}}, nil)
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppRouteList{
+					IncludeResourceRules: []params.IncludeResourceRule{{
+						RelationshipPath: []string{"domain"},
+						Fields:           []string{},
+					}},
+				})
+			})
+
+			It("includes the route domains in the response", func() {
+				Expect(domainRepo.ListDomainsCallCount()).To(Equal(1))
+				_, _, message := domainRepo.ListDomainsArgsForCall(0)
+				Expect(message.GUIDs).To(ContainElement("test-domain-guid"))
+
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.included.domains[0].guid", "test-domain-guid"),
+					MatchJSONPath("$.included.domains[0].name", "example.org"),
+				)))
+			})
+		})
+
+		When("decoding the url values fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(errors.New("foo"), "invalid include"))
+			})
+
+			It("returns an Unprocessable Entity error", func() {
+				expectUnprocessableEntityError("invalid include")
+			})
+		})
+
 		When("the app cannot be accessed", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
@@ -1212,7 +1530,7 @@ var _ = Describe("App", func() {
 
 		When("there is some error fetching the app's routes", func() {
 			BeforeEach(func() {
-				routeRepo.ListRoutesForAppReturns([]repositories.RouteRecord{}, errors.New("unknown!"))
+				routeRepo.ListRoutesReturns([]repositories.RouteRecord{}, errors.New("unknown!"))
 			})
 
 			It("returns an error", func() {
@@ -1306,6 +1624,59 @@ var _ = Describe("App", func() {
 		})
 	})
 
+	Describe("GET /v3/apps/:guid/relationships/current_droplet", func() {
+		BeforeEach(func() {
+			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/relationships/current_droplet", nil)
+		})
+
+		It("returns the current droplet relationship", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data.guid", "test-droplet-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/apps/"+appGUID+"/relationships/current_droplet"),
+				MatchJSONPath("$.links.related.href", "https://api.example.org/v3/apps/"+appGUID+"/droplets/current"),
+			)))
+		})
+
+		When("the app has no current droplet", func() {
+			BeforeEach(func() {
+				appRecord.DropletGUID = ""
+				appRepo.GetAppReturns(appRecord, nil)
+			})
+
+			It("returns null relationship data", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data", BeNil())))
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		When("there is some other error fetching the app", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, errors.New("unknown!"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("GET /v3/apps/:guid/droplets", func() {
 		BeforeEach(func() {
 			dropletRepo.ListDropletsReturns([]repositories.DropletRecord{{
@@ -1338,6 +1709,86 @@ var _ = Describe("App", func() {
 			)))
 		})
 
+		When("filtering by state", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppListDroplets{
+					States: "STAGED",
+				})
+			})
+
+			It("passes the states to the repository", func() {
+				Expect(dropletRepo.ListDropletsCallCount()).To(Equal(1))
+				_, _, dropletListMessage := dropletRepo.ListDropletsArgsForCall(0)
+				Expect(dropletListMessage).To(Equal(repositories.ListDropletsMessage{
+					AppGUIDs: []string{appGUID},
+					States:   []string{"STAGED"},
+				}))
+			})
+		})
+
+		When("a page is requested", func() {
+			BeforeEach(func() {
+				dropletRepo.ListDropletsReturns([]repositories.DropletRecord{
+					{GUID: dropletGUID, State: "STAGED", AppGUID: appGUID},
+					{GUID: "other-droplet-guid", State: "STAGED", AppGUID: appGUID},
+				}, nil)
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppListDroplets{
+					Pagination: payloads.Pagination{Page: "2", PerPage: "1"},
+				})
+			})
+
+			It("returns the requested page", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+					MatchJSONPath("$.resources", HaveLen(1)),
+					MatchJSONPath("$.resources[0].guid", "other-droplet-guid"),
+				)))
+			})
+		})
+
+		When("filtering by the current droplet", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppListDroplets{
+					Current: tools.PtrTo(true),
+				})
+			})
+
+			It("lists the current droplet of the app", func() {
+				Expect(dropletRepo.ListDropletsCallCount()).To(Equal(1))
+				_, _, dropletListMessage := dropletRepo.ListDropletsArgsForCall(0)
+				Expect(dropletListMessage).To(Equal(repositories.ListDropletsMessage{
+					GUIDs:    []string{"test-droplet-guid"},
+					AppGUIDs: []string{appGUID},
+				}))
+			})
+
+			When("the app has no current droplet", func() {
+				BeforeEach(func() {
+					appRecord.DropletGUID = ""
+					appRepo.GetAppReturns(appRecord, nil)
+				})
+
+				It("returns an empty list", func() {
+					Expect(dropletRepo.ListDropletsCallCount()).To(BeZero())
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody(SatisfyAll(
+						MatchJSONPath("$.pagination.total_results", BeEquivalentTo(0)),
+						MatchJSONPath("$.resources", BeEmpty()),
+					)))
+				})
+			})
+		})
+
+		When("the query parameters are invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(errors.New("foo"), "invalid query"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("invalid query")
+			})
+		})
+
 		When("the app is not accessible", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(
@@ -1391,7 +1842,7 @@ var _ = Describe("App", func() {
 		})
 	})
 
-	Describe("GET /v3/apps/:guid/actions/restart", func() {
+	Describe("POST /v3/apps/:guid/actions/restart", func() {
 		BeforeEach(func() {
 			updatedAppRecord := appRecord
 			updatedAppRecord.State = "STARTED"
@@ -1400,20 +1851,27 @@ var _ = Describe("App", func() {
 			req = createHttpRequest("POST", "/v3/apps/"+appGUID+"/actions/restart", nil)
 		})
 
-		It("restarts the app", func() {
+		It("starts the stopped app", func() {
 			Expect(appRepo.GetAppCallCount()).To(Equal(1))
 			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
 			Expect(actualAppGUID).To(Equal(appGUID))
 
-			Expect(appRepo.SetAppDesiredStateCallCount()).To(Equal(2))
+			Expect(appRepo.SetAppDesiredStateCallCount()).To(Equal(1))
 			_, actualAuthInfo, appDesiredStateMessage := appRepo.SetAppDesiredStateArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
-			Expect(appDesiredStateMessage.DesiredState).To(Equal("STOPPED"))
-			_, actualAuthInfo, appDesiredStateMessage = appRepo.SetAppDesiredStateArgsForCall(1)
-			Expect(actualAuthInfo).To(Equal(authInfo))
 			Expect(appDesiredStateMessage.DesiredState).To(Equal("STARTED"))
 
+			Expect(appRepo.RestartAppCallCount()).To(BeZero())
+
+			Expect(revisionRepo.CreateRevisionCallCount()).To(Equal(1))
+			_, _, revisionMessage := revisionRepo.CreateRevisionArgsForCall(0)
+			Expect(revisionMessage).To(Equal(repositories.CreateRevisionMessage{
+				AppGUID:     appGUID,
+				SpaceGUID:   spaceGUID,
+				Description: repositories.RevisionDescriptionRestart,
+			}))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
@@ -1447,7 +1905,6 @@ var _ = Describe("App", func() {
 			BeforeEach(func() {
 				appRecord.DropletGUID = ""
 				appRepo.GetAppReturns(appRecord, nil)
-				appRepo.SetAppDesiredStateReturns(appRecord, nil)
 			})
 
 			It("returns an error", func() {
@@ -1455,9 +1912,9 @@ var _ = Describe("App", func() {
 			})
 		})
 
-		When("stopping the app fails", func() {
+		When("starting the app fails", func() {
 			BeforeEach(func() {
-				appRepo.SetAppDesiredStateReturnsOnCall(0, repositories.AppRecord{}, errors.New("stop-app"))
+				appRepo.SetAppDesiredStateReturns(repositories.AppRecord{}, errors.New("start-app"))
 			})
 
 			It("returns an error", func() {
@@ -1465,44 +1922,108 @@ var _ = Describe("App", func() {
 			})
 		})
 
-		When("starting the app fails", func() {
+		When("the app is in STARTED state", func() {
 			BeforeEach(func() {
-				appRepo.SetAppDesiredStateReturnsOnCall(1, repositories.AppRecord{}, errors.New("start-app"))
+				appRecord.State = "STARTED"
+				appRepo.GetAppReturns(appRecord, nil)
+
+				restartedAppRecord := appRecord
+				restartedAppRecord.Revision = "1"
+				appRepo.RestartAppReturns(restartedAppRecord, nil)
 			})
 
-			It("returns a forbidden error", func() {
-				expectUnknownError()
+			It("rolls the app instead of stopping it", func() {
+				Expect(appRepo.SetAppDesiredStateCallCount()).To(BeZero())
+
+				Expect(appRepo.RestartAppCallCount()).To(Equal(1))
+				_, actualAuthInfo, restartMessage := appRepo.RestartAppArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(restartMessage).To(Equal(repositories.RestartAppMessage{
+					AppGUID:   appGUID,
+					SpaceGUID: spaceGUID,
+				}))
+			})
+
+			It("returns the restarted app", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.guid", "test-app-guid"),
+					MatchJSONPath("$.state", "STARTED"),
+				)))
+			})
+
+			It("records a restart revision", func() {
+				Expect(revisionRepo.CreateRevisionCallCount()).To(Equal(1))
+				_, _, revisionMessage := revisionRepo.CreateRevisionArgsForCall(0)
+				Expect(revisionMessage.Description).To(Equal(repositories.RevisionDescriptionRestart))
+			})
+
+			When("restarting the app fails", func() {
+				BeforeEach(func() {
+					appRepo.RestartAppReturns(repositories.AppRecord{}, errors.New("restart-app"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
 			})
 		})
 
-		When("the app is in STOPPED state", func() {
+		When("recording the revision fails", func() {
 			BeforeEach(func() {
-				appRecord.State = "STOPPED"
-				appRepo.GetAppReturns(appRecord, nil)
+				revisionRepo.CreateRevisionReturns(repositories.RevisionRecord{}, errors.New("create-revision"))
 			})
 
-			It("responds with a 200 code", func() {
+			It("still restarts the app", func() {
 				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			})
+		})
+	})
 
-			It("returns the app in the response with a state of STARTED", func() {
-				Expect(rr).To(HaveHTTPBody(ContainSubstring(`"state":"STARTED"`)))
-			})
+	Describe("GET /v3/apps/:guid/revisions", func() {
+		BeforeEach(func() {
+			revisionRepo.ListRevisionsReturns([]repositories.RevisionRecord{
+				{GUID: "revision-1", Version: 1, AppGUID: appGUID, DropletGUID: "droplet-1", Description: repositories.RevisionDescriptionNewDroplet},
+				{GUID: "revision-2", Version: 2, AppGUID: appGUID, DropletGUID: "droplet-1", Description: repositories.RevisionDescriptionRestart},
+			}, nil)
+
+			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/revisions", nil)
+		})
+
+		It("lists the app revisions", func() {
+			Expect(revisionRepo.ListRevisionsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := revisionRepo.ListRevisionsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListRevisionsMessage{
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/"+appGUID+"/revisions"),
+				MatchJSONPath("$.resources[0].guid", "revision-1"),
+				MatchJSONPath("$.resources[0].version", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].droplet.guid", "droplet-1"),
+				MatchJSONPath("$.resources[1].description", repositories.RevisionDescriptionRestart),
+			)))
 		})
 
-		When("setDesiredAppState to STOPPED returns an error", func() {
+		When("the app cannot be accessed", func() {
 			BeforeEach(func() {
-				appRepo.SetAppDesiredStateReturnsOnCall(0, repositories.AppRecord{}, errors.New("some-error"))
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
 			})
 
-			It("returns an error", func() {
-				expectUnknownError()
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
 			})
 		})
 
-		When("setDesiredAppState to STARTED returns an error", func() {
+		When("listing the revisions fails", func() {
 			BeforeEach(func() {
-				appRepo.SetAppDesiredStateReturnsOnCall(1, repositories.AppRecord{}, errors.New("some-error"))
+				revisionRepo.ListRevisionsReturns(nil, errors.New("list-revisions"))
 			})
 
 			It("returns an error", func() {
@@ -1736,7 +2257,7 @@ var _ = Describe("App", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/test-app-guid/packages"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/test-app-guid/packages?page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", "package-1-guid"),
 				MatchJSONPath("$.resources[0].state", "AWAITING_UPLOAD"),
@@ -1744,6 +2265,35 @@ var _ = Describe("App", func() {
 			)))
 		})
 
+		When("filtering the packages", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppPackageList{
+					States: "AWAITING_UPLOAD",
+					Types:  "bits",
+				})
+			})
+
+			It("lists the app packages matching the filter", func() {
+				Expect(packageRepo.ListPackagesCallCount()).To(Equal(1))
+				_, _, message := packageRepo.ListPackagesArgsForCall(0)
+				Expect(message).To(Equal(repositories.ListPackagesMessage{
+					AppGUIDs: []string{appGUID},
+					States:   []string{"AWAITING_UPLOAD"},
+					Types:    []string{"bits"},
+				}))
+			})
+		})
+
+		When("decoding the url values fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(errors.New("foo"), "invalid types"))
+			})
+
+			It("returns an Unprocessable Entity error", func() {
+				expectUnprocessableEntityError("invalid types")
+			})
+		})
+
 		When("the app cannot be accessed", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
@@ -1781,13 +2331,43 @@ var _ = Describe("App", func() {
 		})
 
 		It("returns false", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal(appGUID))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.enabled", BeFalse()),
-				MatchJSONPath("$.reason", Equal("Disabled globally")),
+				MatchJSONPath("$.reason", Equal("Disabled for app")),
 			)))
 		})
+
+		When("ssh is enabled for the app", func() {
+			BeforeEach(func() {
+				appRecord.SSHEnabled = true
+				appRepo.GetAppReturns(appRecord, nil)
+			})
+
+			It("returns true", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeTrue()),
+					MatchJSONPath("$.reason", BeEmpty()),
+				)))
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
 	})
 
 	Describe("GET /v3/apps/GUID/features", func() {
@@ -1805,19 +2385,31 @@ var _ = Describe("App", func() {
 					MatchJSONPath("$.enabled", BeFalse()),
 				)))
 			})
+
+			When("ssh is enabled for the app", func() {
+				BeforeEach(func() {
+					appRecord.SSHEnabled = true
+					appRepo.GetAppReturns(appRecord, nil)
+				})
+
+				It("returns ssh enabled true", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.enabled", BeTrue())))
+				})
+			})
 		})
 		When("feature revisions is called", func() {
 			BeforeEach(func() {
 				req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/features/revisions", nil)
 			})
 
-			It("returns revisions enabled false", func() {
+			It("returns revisions enabled true", func() {
 				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
 					MatchJSONPath("$.name", Equal("revisions")),
 					MatchJSONPath("$.description", Equal("Enable versioning of an application")),
-					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.enabled", BeTrue()),
 				)))
 			})
 		})
@@ -1839,6 +2431,197 @@ var _ = Describe("App", func() {
 		})
 	})
 
+	Describe("PATCH /v3/apps/GUID/features", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.AppFeatureUpdate{
+				Enabled: tools.PtrTo(true),
+			})
+			appRepo.SetAppSSHEnabledReturns(repositories.AppRecord{GUID: appGUID, SSHEnabled: true}, nil)
+			req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/ssh", strings.NewReader("the-json-body"))
+		})
+
+		It("enables ssh for the app", func() {
+			Expect(appRepo.SetAppSSHEnabledCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := appRepo.SetAppSSHEnabledArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.SetAppSSHEnabledMessage{
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+				Enabled:   true,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", Equal("ssh")),
+				MatchJSONPath("$.enabled", BeTrue()),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("updating the app fails", func() {
+			BeforeEach(func() {
+				appRepo.SetAppSSHEnabledReturns(repositories.AppRecord{}, errors.New("set-ssh-error"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("disabling revisions", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.AppFeatureUpdate{
+					Enabled: tools.PtrTo(false),
+				})
+				req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/revisions", strings.NewReader("the-json-body"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(appRepo.SetAppSSHEnabledCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Revisions cannot be disabled")
+			})
+		})
+
+		When("the feature does not exist", func() {
+			BeforeEach(func() {
+				req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/anything-else", strings.NewReader("the-json-body"))
+			})
+
+			It("returns feature not found", func() {
+				expectNotFoundError("Feature")
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/:guid/processes/:process/instances/:instance/exec", func() {
+		var execHandler http.Handler
+
+		BeforeEach(func() {
+			appRecord.SSHEnabled = true
+			appRepo.GetAppReturns(appRecord, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppInstanceExec{
+				Command: []string{"/bin/sh"},
+				TTY:     true,
+			})
+
+			processRepo.ListProcessesReturns([]repositories.ProcessRecord{
+				{
+					GUID:             "process-1-guid",
+					SpaceGUID:        spaceGUID,
+					AppGUID:          appGUID,
+					Type:             "web",
+					DesiredInstances: 2,
+				},
+			}, nil)
+
+			execHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusSwitchingProtocols)
+			})
+			podRepo.ExecInstanceReturns(execHandler, nil)
+
+			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/processes/web/instances/1/exec", nil)
+		})
+
+		It("hands the request over to the exec session", func() {
+			Expect(podRepo.ExecInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := podRepo.ExecInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.AppRevision).To(Equal("0"))
+			Expect(message.Process.GUID).To(Equal("process-1-guid"))
+			Expect(message.InstanceID).To(Equal("1"))
+			Expect(message.Command).To(Equal([]string{"/bin/sh"}))
+			Expect(message.TTY).To(BeTrue())
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusSwitchingProtocols))
+		})
+
+		When("ssh is disabled for the app", func() {
+			BeforeEach(func() {
+				appRecord.SSHEnabled = false
+				appRepo.GetAppReturns(appRecord, nil)
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(podRepo.ExecInstanceCallCount()).To(BeZero())
+				Expect(rr).To(HaveHTTPStatus(http.StatusForbidden))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.errors[0].title", Equal("CF-FeatureDisabled")),
+					MatchJSONPath("$.errors[0].detail", Equal("Feature Disabled: ssh")),
+				)))
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("the process does not exist", func() {
+			BeforeEach(func() {
+				req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/processes/boom/instances/0/exec", nil)
+			})
+
+			It("returns an error", func() {
+				expectNotFoundError("Process")
+			})
+		})
+
+		When("the instance does not exist", func() {
+			BeforeEach(func() {
+				req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/processes/web/instances/5/exec", nil)
+			})
+
+			It("returns an error", func() {
+				expectNotFoundError("Instance 5 of process web")
+			})
+		})
+
+		When("the user is not allowed to exec into the instance", func() {
+			BeforeEach(func() {
+				podRepo.ExecInstanceReturns(nil, apierrors.NewForbiddenError(nil, repositories.PodResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.PodResourceType)
+			})
+		})
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("invalid-query"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("DELETE /v3/apps/:guid/processes/:process/instances/:instance", func() {
 		BeforeEach(func() {
 			processRepo.ListProcessesReturns([]repositories.ProcessRecord{
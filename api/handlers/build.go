@@ -25,6 +25,7 @@ const (
 type CFBuildRepository interface {
 	GetBuild(context.Context, authorization.Info, string) (repositories.BuildRecord, error)
 	GetLatestBuildByAppGUID(context.Context, authorization.Info, string, string) (repositories.BuildRecord, error)
+	ListBuilds(context.Context, authorization.Info, repositories.ListBuildsMessage) ([]repositories.BuildRecord, error)
 	CreateBuild(context.Context, authorization.Info, repositories.CreateBuildMessage) (repositories.BuildRecord, error)
 }
 
@@ -62,7 +63,24 @@ func (h *Build) get(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to fetch "+repositories.BuildResourceType, "guid", buildGUID)
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForBuild(build, h.serverURL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForBuild(build, h.serverURL)).WithETag(build.ResourceVersion), nil
+}
+
+func (h *Build) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.build.list")
+
+	payload := new(payloads.BuildList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	builds, err := h.buildRepo.ListBuilds(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch builds from Kubernetes")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPaginatedList(presenter.ForBuild, builds, h.serverURL, *r.URL, payload.PageNumber(), payload.PerPageNumber())), nil
 }
 
 func (h *Build) create(r *http.Request) (*routing.Response, error) {
@@ -121,6 +139,7 @@ func (h *Build) UnauthenticatedRoutes() []routing.Route {
 func (h *Build) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: BuildPath, Handler: h.get},
+		{Method: "GET", Pattern: BuildsPath, Handler: h.list},
 		{Method: "POST", Pattern: BuildsPath, Handler: h.create},
 		{Method: "PATCH", Pattern: BuildPath, Handler: h.update},
 	}
@@ -77,8 +77,9 @@ var _ = Describe("Build", func() {
 						Stack:      "",
 					},
 				},
-				PackageGUID: packageGUID,
-				AppGUID:     appGUID,
+				PackageGUID:     packageGUID,
+				AppGUID:         appGUID,
+				ResourceVersion: "7",
 			}, nil)
 
 			var err error
@@ -89,6 +90,7 @@ var _ = Describe("Build", func() {
 		It("returns the Build", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("ETag", `W/"7"`))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.guid", "test-build-guid"),
 				MatchJSONPath("$.state", "STAGING"),
@@ -117,6 +119,72 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("the GET /v3/builds endpoint", func() {
+		BeforeEach(func() {
+			buildRepo.ListBuildsReturns([]repositories.BuildRecord{
+				{GUID: "build-1", State: "STAGED"},
+				{GUID: "build-2", State: "FAILED", StagingErrorMsg: "oops"},
+			}, nil)
+
+			payload := &payloads.BuildList{
+				PackageGUIDs: "package-guid",
+				AppGUIDs:     "app-1,app-2",
+				States:       "STAGED,FAILED",
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/builds", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the builds", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+			Expect(actualReq.URL).To(Equal(req.URL))
+
+			Expect(buildRepo.ListBuildsCallCount()).To(Equal(1))
+			_, actualAuthInfo, listMessage := buildRepo.ListBuildsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(listMessage).To(Equal(repositories.ListBuildsMessage{
+				PackageGUIDs: []string{"package-guid"},
+				AppGUIDs:     []string{"app-1", "app-2"},
+				States:       []string{"STAGED", "FAILED"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources", HaveLen(2)),
+				MatchJSONPath("$.resources[0].guid", "build-1"),
+				MatchJSONPath("$.resources[1].guid", "build-2"),
+				MatchJSONPath("$.resources[1].state", "FAILED"),
+				MatchJSONPath("$.resources[1].error", "oops"),
+			)))
+		})
+
+		When("decoding the query parameters fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the builds fails", func() {
+			BeforeEach(func() {
+				buildRepo.ListBuildsReturns(nil, errors.New("list-builds-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("the POST /v3/builds endpoint", func() {
 		var expectedLifecycleBuildpacks []string
 
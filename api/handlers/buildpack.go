@@ -16,12 +16,15 @@ import (
 )
 
 const (
-	BuildpacksPath = "/v3/buildpacks"
+	BuildpacksPath     = "/v3/buildpacks"
+	BuildpackOrderPath = "/v3/buildpacks/order"
 )
 
 //counterfeiter:generate -o fake -fake-name BuildpackRepository . BuildpackRepository
 type BuildpackRepository interface {
 	ListBuildpacks(ctx context.Context, authInfo authorization.Info, message repositories.ListBuildpacksMessage) ([]repositories.BuildpackRecord, error)
+	GetBuildpackOrder(ctx context.Context, authInfo authorization.Info) (repositories.BuildpackOrderRecord, error)
+	UpdateBuildpackOrder(ctx context.Context, authInfo authorization.Info, message repositories.UpdateBuildpackOrderMessage) (repositories.BuildpackOrderRecord, error)
 }
 
 type Buildpack struct {
@@ -59,6 +62,35 @@ func (h *Buildpack) list(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForBuildpack, buildpacks, h.serverURL, *r.URL)), nil
 }
 
+func (h *Buildpack) getOrder(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.buildpack.get-order")
+
+	order, err := h.buildpackRepo.GetBuildpackOrder(r.Context(), authInfo)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to get the default buildpack order")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForBuildpackOrder(order, h.serverURL)), nil
+}
+
+func (h *Buildpack) updateOrder(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.buildpack.update-order")
+
+	payload := new(payloads.BuildpackOrderUpdate)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	order, err := h.buildpackRepo.UpdateBuildpackOrder(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to update the default buildpack order")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForBuildpackOrder(order, h.serverURL)), nil
+}
+
 func (h *Buildpack) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -66,5 +98,7 @@ func (h *Buildpack) UnauthenticatedRoutes() []routing.Route {
 func (h *Buildpack) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: BuildpacksPath, Handler: h.list},
+		{Method: "GET", Pattern: BuildpackOrderPath, Handler: h.getOrder},
+		{Method: "PUT", Pattern: BuildpackOrderPath, Handler: h.updateOrder},
 	}
 }
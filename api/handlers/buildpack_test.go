@@ -3,10 +3,13 @@ package handlers_test
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
@@ -83,4 +86,112 @@ var _ = Describe("Buildpack", func() {
 			})
 		})
 	})
+
+	Describe("the GET /v3/buildpacks/order endpoint", func() {
+		BeforeEach(func() {
+			buildpackRepo.GetBuildpackOrderReturns(repositories.BuildpackOrderRecord{
+				Buildpacks: []string{"paketo-buildpacks/go", "paketo-buildpacks/java"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/buildpacks/order", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the default buildpack order", func() {
+			Expect(buildpackRepo.GetBuildpackOrderCallCount()).To(Equal(1))
+			_, actualAuthInfo := buildpackRepo.GetBuildpackOrderArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.buildpacks", Equal([]any{"paketo-buildpacks/go", "paketo-buildpacks/java"})),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/buildpacks/order"),
+			)))
+		})
+
+		When("getting the order is forbidden", func() {
+			BeforeEach(func() {
+				buildpackRepo.GetBuildpackOrderReturns(repositories.BuildpackOrderRecord{}, apierrors.NewForbiddenError(nil, repositories.BuildpackResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("getting the order fails", func() {
+			BeforeEach(func() {
+				buildpackRepo.GetBuildpackOrderReturns(repositories.BuildpackOrderRecord{}, errors.New("get-order-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the PUT /v3/buildpacks/order endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.BuildpackOrderUpdate{
+				Buildpacks: []string{"paketo-buildpacks/java", "paketo-buildpacks/go"},
+			})
+			buildpackRepo.UpdateBuildpackOrderReturns(repositories.BuildpackOrderRecord{
+				Buildpacks: []string{"paketo-buildpacks/java", "paketo-buildpacks/go"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PUT", "/v3/buildpacks/order", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("updates the default buildpack order", func() {
+			Expect(buildpackRepo.UpdateBuildpackOrderCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := buildpackRepo.UpdateBuildpackOrderArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Buildpacks).To(Equal([]string{"paketo-buildpacks/java", "paketo-buildpacks/go"}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(
+				MatchJSONPath("$.buildpacks", Equal([]any{"paketo-buildpacks/java", "paketo-buildpacks/go"})),
+			))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("a buildpack is not available in the builder", func() {
+			BeforeEach(func() {
+				buildpackRepo.UpdateBuildpackOrderReturns(repositories.BuildpackOrderRecord{}, apierrors.NewUnprocessableEntityError(nil, "not available"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("not available")
+			})
+		})
+
+		When("updating the order fails", func() {
+			BeforeEach(func() {
+				buildpackRepo.UpdateBuildpackOrderReturns(repositories.BuildpackOrderRecord{}, errors.New("update-order-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+)
+
+func isDeletionProtected(annotations map[string]string) bool {
+	return annotations[korifiv1alpha1.DeletionProtectedAnnotation] == "true"
+}
+
+func deletionProtectedSpaces(ctx context.Context, spaceRepo CFSpaceRepository, authInfo authorization.Info, orgGUID string) ([]string, error) {
+	spaces, err := spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{
+		OrganizationGUIDs: []string{orgGUID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	protectedSpaces := []string{}
+	for _, space := range spaces {
+		if isDeletionProtected(space.Annotations) {
+			protectedSpaces = append(protectedSpaces, space.Name)
+		}
+	}
+
+	return protectedSpaces, nil
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -18,8 +19,9 @@ import (
 )
 
 const (
-	DeploymentsPath = "/v3/deployments"
-	DeploymentPath  = "/v3/deployments/{guid}"
+	DeploymentsPath      = "/v3/deployments"
+	DeploymentPath       = "/v3/deployments/{guid}"
+	DeploymentCancelPath = "/v3/deployments/{guid}/actions/cancel"
 )
 
 //counterfeiter:generate -o fake -fake-name CFDeploymentRepository . CFDeploymentRepository
@@ -28,6 +30,7 @@ type CFDeploymentRepository interface {
 	GetDeployment(context.Context, authorization.Info, string) (repositories.DeploymentRecord, error)
 	CreateDeployment(context.Context, authorization.Info, repositories.CreateDeploymentMessage) (repositories.DeploymentRecord, error)
 	ListDeployments(context.Context, authorization.Info, repositories.ListDeploymentsMessage) ([]repositories.DeploymentRecord, error)
+	CancelDeployment(context.Context, authorization.Info, string) (repositories.DeploymentRecord, error)
 }
 
 //counterfeiter:generate -o fake -fake-name RunnerInfoRepository . RunnerInfoRepository
@@ -41,6 +44,7 @@ type Deployment struct {
 	requestValidator RequestValidator
 	deploymentRepo   CFDeploymentRepository
 	runnerInfoRepo   RunnerInfoRepository
+	revisionRepo     CFRevisionRepository
 	runnerName       string
 }
 
@@ -49,6 +53,7 @@ func NewDeployment(
 	requestValidator RequestValidator,
 	deploymentRepo CFDeploymentRepository,
 	runnerInfoRepo RunnerInfoRepository,
+	revisionRepo CFRevisionRepository,
 	runnerName string,
 ) *Deployment {
 	return &Deployment{
@@ -56,6 +61,7 @@ func NewDeployment(
 		requestValidator: requestValidator,
 		deploymentRepo:   deploymentRepo,
 		runnerInfoRepo:   runnerInfoRepo,
+		revisionRepo:     revisionRepo,
 		runnerName:       runnerName,
 	}
 }
@@ -90,6 +96,26 @@ func (h *Deployment) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Error creating deployment in repository")
 	}
 
+	revisionDescription := repositories.RevisionDescriptionNewDroplet
+	if deploymentCreateMessage.RevisionGUID != "" {
+		rolledBackRevision, err := h.revisionRepo.GetRevision(r.Context(), authInfo, deploymentCreateMessage.RevisionGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Error getting deployed revision", "RevisionGUID", deploymentCreateMessage.RevisionGUID)
+		}
+		revisionDescription = fmt.Sprintf(repositories.RevisionDescriptionRollback, rolledBackRevision.Version)
+	}
+
+	_, err = h.revisionRepo.CreateRevision(r.Context(), authInfo, repositories.CreateRevisionMessage{
+		AppGUID:     deployment.GUID,
+		SpaceGUID:   deployment.SpaceGUID,
+		Description: revisionDescription,
+	})
+	if err != nil {
+		// The deployment has already been created, so the request does not
+		// fail because of the revision
+		logger.Info("failed to record app revision", "AppGUID", deployment.GUID, "reason", err)
+	}
+
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForDeployment(deployment, h.serverURL)), nil
 }
 
@@ -107,6 +133,20 @@ func (h *Deployment) get(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDeployment(deployment, h.serverURL)), nil
 }
 
+func (h *Deployment) cancel(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.deployment.cancel")
+
+	deploymentGUID := routing.URLParam(r, "guid")
+
+	deployment, err := h.deploymentRepo.CancelDeployment(r.Context(), authInfo, deploymentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error canceling deployment in repository", "DeploymentGUID", deploymentGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDeployment(deployment, h.serverURL)), nil
+}
+
 func (h *Deployment) list(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.deployment.list")
@@ -133,5 +173,6 @@ func (h *Deployment) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: DeploymentPath, Handler: h.get},
 		{Method: "POST", Pattern: DeploymentsPath, Handler: h.create},
 		{Method: "GET", Pattern: DeploymentsPath, Handler: h.list},
+		{Method: "POST", Pattern: DeploymentCancelPath, Handler: h.cancel},
 	}
 }
@@ -22,6 +22,7 @@ var _ = Describe("Deployment", func() {
 		req              *http.Request
 		deploymentsRepo  *fake.CFDeploymentRepository
 		runnerInfoRepo   *fake.RunnerInfoRepository
+		revisionRepo     *fake.CFRevisionRepository
 		runnerName       string
 	)
 
@@ -29,9 +30,10 @@ var _ = Describe("Deployment", func() {
 		requestValidator = new(fake.RequestValidator)
 		deploymentsRepo = new(fake.CFDeploymentRepository)
 		runnerInfoRepo = new(fake.RunnerInfoRepository)
+		revisionRepo = new(fake.CFRevisionRepository)
 		runnerName = "statefulset-runner"
 
-		apiHandler := handlers.NewDeployment(*serverURL, requestValidator, deploymentsRepo, runnerInfoRepo, runnerName)
+		apiHandler := handlers.NewDeployment(*serverURL, requestValidator, deploymentsRepo, runnerInfoRepo, revisionRepo, runnerName)
 		routerBuilder.LoadRoutes(apiHandler)
 
 		runnerInfoRepo.GetRunnerInfoReturns(repositories.RunnerInfoRecord{
@@ -52,6 +54,7 @@ var _ = Describe("Deployment", func() {
 		BeforeEach(func() {
 			deploymentsRepo.CreateDeploymentReturns(repositories.DeploymentRecord{
 				GUID:        appGUID,
+				SpaceGUID:   spaceGUID,
 				DropletGUID: dropletGUID,
 				Status: repositories.DeploymentStatus{
 					Value:  "deployment-status-value",
@@ -130,6 +133,71 @@ var _ = Describe("Deployment", func() {
 			}))
 		})
 
+		It("records a new app revision", func() {
+			Expect(revisionRepo.CreateRevisionCallCount()).To(Equal(1))
+			_, actualAuthInfo, revisionMessage := revisionRepo.CreateRevisionArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(revisionMessage).To(Equal(repositories.CreateRevisionMessage{
+				AppGUID:     appGUID,
+				SpaceGUID:   spaceGUID,
+				Description: repositories.RevisionDescriptionNewDroplet,
+			}))
+		})
+
+		When("a revision is deployed", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DeploymentCreate{
+					Revision: &payloads.RevisionGUID{
+						Guid: "revision-guid",
+					},
+					Relationships: &payloads.DeploymentRelationships{
+						App: &payloads.Relationship{
+							Data: &payloads.RelationshipData{
+								GUID: appGUID,
+							},
+						},
+					},
+				})
+
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{
+					GUID:    "revision-guid",
+					Version: 4,
+				}, nil)
+			})
+
+			It("creates the deployment from the revision", func() {
+				Expect(deploymentsRepo.CreateDeploymentCallCount()).To(Equal(1))
+				_, _, createMessage := deploymentsRepo.CreateDeploymentArgsForCall(0)
+				Expect(createMessage).To(Equal(repositories.CreateDeploymentMessage{
+					AppGUID:      appGUID,
+					RevisionGUID: "revision-guid",
+				}))
+			})
+
+			It("records a rollback revision", func() {
+				Expect(revisionRepo.GetRevisionCallCount()).To(Equal(1))
+				_, _, actualRevisionGUID := revisionRepo.GetRevisionArgsForCall(0)
+				Expect(actualRevisionGUID).To(Equal("revision-guid"))
+
+				Expect(revisionRepo.CreateRevisionCallCount()).To(Equal(1))
+				_, _, revisionMessage := revisionRepo.CreateRevisionArgsForCall(0)
+				Expect(revisionMessage.Description).To(Equal("Rolled back to revision 4."))
+			})
+
+			When("the revision droplet no longer exists", func() {
+				BeforeEach(func() {
+					deploymentsRepo.CreateDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewUnprocessableEntityError(
+						errors.New("droplet not found"),
+						"Unable to deploy this revision, the droplet for this revision no longer exists.",
+					))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Unable to deploy this revision, the droplet for this revision no longer exists.")
+				})
+			})
+		})
+
 		When("the request payload is invalid", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
@@ -158,6 +226,20 @@ var _ = Describe("Deployment", func() {
 			It("returns an unknown error", func() {
 				expectUnknownError()
 			})
+
+			It("does not record a revision", func() {
+				Expect(revisionRepo.CreateRevisionCallCount()).To(BeZero())
+			})
+		})
+
+		When("recording the revision fails", func() {
+			BeforeEach(func() {
+				revisionRepo.CreateRevisionReturns(repositories.RevisionRecord{}, errors.New("create-revision-error"))
+			})
+
+			It("still creates the deployment", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
 		})
 	})
 
@@ -214,6 +296,65 @@ var _ = Describe("Deployment", func() {
 		})
 	})
 
+	Describe("POST /v3/deployments/{guid}/actions/cancel", func() {
+		BeforeEach(func() {
+			deploymentsRepo.CancelDeploymentReturns(repositories.DeploymentRecord{
+				GUID:        appGUID,
+				DropletGUID: dropletGUID,
+				Status: repositories.DeploymentStatus{
+					Value:  repositories.DeploymentStatusValueFinalized,
+					Reason: repositories.DeploymentStatusReasonCanceled,
+				},
+			}, nil)
+			req = createHttpRequest("POST", "/v3/deployments/"+appGUID+"/actions/cancel", nil)
+		})
+
+		It("cancels the deployment", func() {
+			Expect(deploymentsRepo.CancelDeploymentCallCount()).To(Equal(1))
+			_, actualAuthInfo, deploymentGUID := deploymentsRepo.CancelDeploymentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(deploymentGUID).To(Equal(appGUID))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", appGUID),
+				MatchJSONPath("$.status.value", "FINALIZED"),
+				MatchJSONPath("$.status.reason", "CANCELED"),
+			)))
+		})
+
+		When("the deployment is not active", func() {
+			BeforeEach(func() {
+				deploymentsRepo.CancelDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, "cannot cancel"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("cannot cancel")
+			})
+		})
+
+		When("canceling the deployment is forbidden", func() {
+			BeforeEach(func() {
+				deploymentsRepo.CancelDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewForbiddenError(nil, repositories.DeploymentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DeploymentResourceType)
+			})
+		})
+
+		When("canceling the deployment fails", func() {
+			BeforeEach(func() {
+				deploymentsRepo.CancelDeploymentReturns(repositories.DeploymentRecord{}, errors.New("cancel-deployment-error"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("GET /v3/deployments", func() {
 		var deploymentRecord repositories.DeploymentRecord
 
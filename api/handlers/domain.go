@@ -35,17 +35,20 @@ type Domain struct {
 	serverURL        url.URL
 	requestValidator RequestValidator
 	domainRepo       CFDomainRepository
+	orgRepo          CFOrgRepository
 }
 
 func NewDomain(
 	serverURL url.URL,
 	requestValidator RequestValidator,
 	domainRepo CFDomainRepository,
+	orgRepo CFOrgRepository,
 ) *Domain {
 	return &Domain{
 		serverURL:        serverURL,
 		requestValidator: requestValidator,
 		domainRepo:       domainRepo,
+		orgRepo:          orgRepo,
 	}
 }
 
@@ -64,6 +67,22 @@ func (h *Domain) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierr, apierr.Detail())
 	}
 
+	if domainCreateMessage.OrgGUID != "" {
+		if _, err = h.orgRepo.GetOrg(r.Context(), authInfo, domainCreateMessage.OrgGUID); err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(
+					err,
+					"Invalid organization. Ensure that the organization exists and you have access to it.",
+					apierrors.NotFoundError{},
+					apierrors.ForbiddenError{},
+				),
+				"Failed to fetch org from Kubernetes",
+				"orgGUID", domainCreateMessage.OrgGUID,
+			)
+		}
+	}
+
 	domain, err := h.domainRepo.CreateDomain(r.Context(), authInfo, domainCreateMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error creating domain in repository")
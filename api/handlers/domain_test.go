@@ -21,6 +21,7 @@ var _ = Describe("Domain", func() {
 	var (
 		apiHandler       *handlers.Domain
 		domainRepo       *fake.CFDomainRepository
+		orgRepo          *fake.CFOrgRepository
 		requestValidator *fake.RequestValidator
 		req              *http.Request
 	)
@@ -28,10 +29,12 @@ var _ = Describe("Domain", func() {
 	BeforeEach(func() {
 		requestValidator = new(fake.RequestValidator)
 		domainRepo = new(fake.CFDomainRepository)
+		orgRepo = new(fake.CFOrgRepository)
 		apiHandler = handlers.NewDomain(
 			*serverURL,
 			requestValidator,
 			domainRepo,
+			orgRepo,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -116,6 +119,62 @@ var _ = Describe("Domain", func() {
 			})
 		})
 
+		It("does not look up an organization", func() {
+			Expect(orgRepo.GetOrgCallCount()).To(BeZero())
+		})
+
+		When("the domain is private", func() {
+			BeforeEach(func() {
+				payload.Relationships = map[string]payloads.Relationship{
+					"organization": {Data: &payloads.RelationshipData{GUID: "org-guid"}},
+				}
+			})
+
+			It("creates the domain in the organization", func() {
+				Expect(orgRepo.GetOrgCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualOrgGUID := orgRepo.GetOrgArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualOrgGUID).To(Equal("org-guid"))
+
+				Expect(domainRepo.CreateDomainCallCount()).To(Equal(1))
+				_, _, createMessage := domainRepo.CreateDomainArgsForCall(0)
+				Expect(createMessage.OrgGUID).To(Equal("org-guid"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			When("the organization does not exist", func() {
+				BeforeEach(func() {
+					orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Invalid organization. Ensure that the organization exists and you have access to it.")
+					Expect(domainRepo.CreateDomainCallCount()).To(BeZero())
+				})
+			})
+
+			When("the organization is forbidden", func() {
+				BeforeEach(func() {
+					orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Invalid organization. Ensure that the organization exists and you have access to it.")
+				})
+			})
+
+			When("getting the organization fails", func() {
+				BeforeEach(func() {
+					orgRepo.GetOrgReturns(repositories.OrgRecord{}, errors.New("get-org-err"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
 		When("creating the domain fails", func() {
 			BeforeEach(func() {
 				domainRepo.CreateDomainReturns(repositories.DomainRecord{}, errors.New("domain-create-err"))
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 
@@ -17,7 +19,8 @@ import (
 )
 
 const (
-	DropletPath = "/v3/droplets/{guid}"
+	DropletPath         = "/v3/droplets/{guid}"
+	DropletDownloadPath = "/v3/droplets/{guid}/download"
 )
 
 //counterfeiter:generate -o fake -fake-name CFDropletRepository . CFDropletRepository
@@ -30,17 +33,23 @@ type CFDropletRepository interface {
 type Droplet struct {
 	serverURL        url.URL
 	dropletRepo      CFDropletRepository
+	appRepo          CFAppRepository
+	imageRepo        ImageRepository
 	requestValidator RequestValidator
 }
 
 func NewDroplet(
 	serverURL url.URL,
 	dropletRepo CFDropletRepository,
+	appRepo CFAppRepository,
+	imageRepo ImageRepository,
 	requestValidator RequestValidator,
 ) *Droplet {
 	return &Droplet{
 		serverURL:        serverURL,
 		dropletRepo:      dropletRepo,
+		appRepo:          appRepo,
+		imageRepo:        imageRepo,
 		requestValidator: requestValidator,
 	}
 }
@@ -93,6 +102,64 @@ func (h *Droplet) update(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
 }
 
+func (h *Droplet) download(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.download")
+
+	dropletGUID := routing.URLParam(r, "guid")
+
+	droplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.ForbiddenAsNotFound(err),
+			fmt.Sprintf("Failed to fetch %s from Kubernetes", repositories.DropletResourceType),
+			"guid", dropletGUID,
+		)
+	}
+
+	if droplet.State != "STAGED" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(errors.New("droplet not staged"), "Only staged droplets can be downloaded."),
+			"droplet is not staged", "guid", dropletGUID, "state", droplet.State,
+		)
+	}
+
+	if droplet.Lifecycle.Type == "docker" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(errors.New("docker droplet"), "Cannot download droplets with 'docker' lifecycle."),
+			"docker droplets have no bits", "guid", dropletGUID,
+		)
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, droplet.AppGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.ForbiddenAsNotFound(err),
+			fmt.Sprintf("Failed to fetch %s from Kubernetes", repositories.AppResourceType),
+			"guid", droplet.AppGUID,
+		)
+	}
+
+	bits, err := h.imageRepo.DownloadDroplet(r.Context(), authInfo, droplet)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to download droplet bits", "guid", dropletGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Type", "application/zip").
+		WithHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", app.Name+"-"+droplet.GUID+".zip")).
+		WithStream(func(w io.Writer, _ func()) error {
+			defer bits.Close()
+
+			_, err := io.Copy(w, bits)
+			return err
+		}), nil
+}
+
 func (h *Droplet) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -101,5 +168,6 @@ func (h *Droplet) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: DropletPath, Handler: h.get},
 		{Method: "PATCH", Pattern: DropletPath, Handler: h.update},
+		{Method: "GET", Pattern: DropletDownloadPath, Handler: h.download},
 	}
 }
@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -31,11 +32,15 @@ var _ = Describe("Droplet", func() {
 
 		requestValidator *fake.RequestValidator
 		dropletRepo      *fake.CFDropletRepository
+		appRepo          *fake.CFAppRepository
+		imageRepo        *fake.ImageRepository
 		req              *http.Request
 	)
 
 	BeforeEach(func() {
 		dropletRepo = new(fake.CFDropletRepository)
+		appRepo = new(fake.CFAppRepository)
+		imageRepo = new(fake.ImageRepository)
 		var err error
 		req, err = http.NewRequestWithContext(ctx, "GET", "/v3/droplets/"+dropletGUID, nil)
 		Expect(err).NotTo(HaveOccurred())
@@ -45,6 +50,8 @@ var _ = Describe("Droplet", func() {
 		apiHandler := NewDroplet(
 			*serverURL,
 			dropletRepo,
+			appRepo,
+			imageRepo,
 			requestValidator,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -240,4 +247,110 @@ var _ = Describe("Droplet", func() {
 			})
 		})
 	})
+
+	Describe("the GET /v3/droplets/:guid/download endpoint", func() {
+		BeforeEach(func() {
+			dropletRepo.GetDropletReturns(repositories.DropletRecord{
+				GUID:      dropletGUID,
+				State:     "STAGED",
+				AppGUID:   appGUID,
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+			}, nil)
+			appRepo.GetAppReturns(repositories.AppRecord{GUID: appGUID, Name: "my-app"}, nil)
+			imageRepo.DownloadDropletReturns(io.NopCloser(strings.NewReader("droplet-bits")), nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/droplets/"+dropletGUID+"/download", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("streams the droplet bits", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/zip"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Disposition", `attachment; filename="my-app-test-build-guid.zip"`))
+			Expect(rr).To(HaveHTTPBody("droplet-bits"))
+		})
+
+		It("downloads the droplet with the user's credentials", func() {
+			Expect(dropletRepo.GetDropletCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := dropletRepo.GetDropletArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal(dropletGUID))
+
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(imageRepo.DownloadDropletCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualDroplet := imageRepo.DownloadDropletArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualDroplet.GUID).To(Equal(dropletGUID))
+		})
+
+		When("the droplet is not staged", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{GUID: dropletGUID, State: "FAILED"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Only staged droplets can be downloaded.")
+				Expect(imageRepo.DownloadDropletCallCount()).To(BeZero())
+			})
+		})
+
+		When("the droplet has the docker lifecycle", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:      dropletGUID,
+					State:     "STAGED",
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot download droplets with 'docker' lifecycle.")
+			})
+		})
+
+		When("access to the droplet is forbidden", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(nil, repositories.DropletResourceType))
+			})
+
+			It("returns a Not Found error", func() {
+				expectNotFoundError("Droplet")
+			})
+		})
+
+		When("getting the app fails", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, errors.New("get-app-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("downloading the droplet bits fails", func() {
+			BeforeEach(func() {
+				imageRepo.DownloadDropletReturns(nil, errors.New("download-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the user is not authorized to download the droplet", func() {
+			BeforeEach(func() {
+				imageRepo.DownloadDropletReturns(nil, apierrors.NewForbiddenError(nil, repositories.DropletResourceType))
+			})
+
+			It("returns a Not Found error", func() {
+				expectNotFoundError("Droplet")
+			})
+		})
+	})
 })
@@ -11,6 +11,20 @@ import (
 )
 
 type BuildpackRepository struct {
+	GetBuildpackOrderStub        func(context.Context, authorization.Info) (repositories.BuildpackOrderRecord, error)
+	getBuildpackOrderMutex       sync.RWMutex
+	getBuildpackOrderArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}
+	getBuildpackOrderReturns struct {
+		result1 repositories.BuildpackOrderRecord
+		result2 error
+	}
+	getBuildpackOrderReturnsOnCall map[int]struct {
+		result1 repositories.BuildpackOrderRecord
+		result2 error
+	}
 	ListBuildpacksStub        func(context.Context, authorization.Info, repositories.ListBuildpacksMessage) ([]repositories.BuildpackRecord, error)
 	listBuildpacksMutex       sync.RWMutex
 	listBuildpacksArgsForCall []struct {
@@ -26,10 +40,90 @@ type BuildpackRepository struct {
 		result1 []repositories.BuildpackRecord
 		result2 error
 	}
+	UpdateBuildpackOrderStub        func(context.Context, authorization.Info, repositories.UpdateBuildpackOrderMessage) (repositories.BuildpackOrderRecord, error)
+	updateBuildpackOrderMutex       sync.RWMutex
+	updateBuildpackOrderArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateBuildpackOrderMessage
+	}
+	updateBuildpackOrderReturns struct {
+		result1 repositories.BuildpackOrderRecord
+		result2 error
+	}
+	updateBuildpackOrderReturnsOnCall map[int]struct {
+		result1 repositories.BuildpackOrderRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *BuildpackRepository) GetBuildpackOrder(arg1 context.Context, arg2 authorization.Info) (repositories.BuildpackOrderRecord, error) {
+	fake.getBuildpackOrderMutex.Lock()
+	ret, specificReturn := fake.getBuildpackOrderReturnsOnCall[len(fake.getBuildpackOrderArgsForCall)]
+	fake.getBuildpackOrderArgsForCall = append(fake.getBuildpackOrderArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}{arg1, arg2})
+	stub := fake.GetBuildpackOrderStub
+	fakeReturns := fake.getBuildpackOrderReturns
+	fake.recordInvocation("GetBuildpackOrder", []interface{}{arg1, arg2})
+	fake.getBuildpackOrderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BuildpackRepository) GetBuildpackOrderCallCount() int {
+	fake.getBuildpackOrderMutex.RLock()
+	defer fake.getBuildpackOrderMutex.RUnlock()
+	return len(fake.getBuildpackOrderArgsForCall)
+}
+
+func (fake *BuildpackRepository) GetBuildpackOrderCalls(stub func(context.Context, authorization.Info) (repositories.BuildpackOrderRecord, error)) {
+	fake.getBuildpackOrderMutex.Lock()
+	defer fake.getBuildpackOrderMutex.Unlock()
+	fake.GetBuildpackOrderStub = stub
+}
+
+func (fake *BuildpackRepository) GetBuildpackOrderArgsForCall(i int) (context.Context, authorization.Info) {
+	fake.getBuildpackOrderMutex.RLock()
+	defer fake.getBuildpackOrderMutex.RUnlock()
+	argsForCall := fake.getBuildpackOrderArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *BuildpackRepository) GetBuildpackOrderReturns(result1 repositories.BuildpackOrderRecord, result2 error) {
+	fake.getBuildpackOrderMutex.Lock()
+	defer fake.getBuildpackOrderMutex.Unlock()
+	fake.GetBuildpackOrderStub = nil
+	fake.getBuildpackOrderReturns = struct {
+		result1 repositories.BuildpackOrderRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) GetBuildpackOrderReturnsOnCall(i int, result1 repositories.BuildpackOrderRecord, result2 error) {
+	fake.getBuildpackOrderMutex.Lock()
+	defer fake.getBuildpackOrderMutex.Unlock()
+	fake.GetBuildpackOrderStub = nil
+	if fake.getBuildpackOrderReturnsOnCall == nil {
+		fake.getBuildpackOrderReturnsOnCall = make(map[int]struct {
+			result1 repositories.BuildpackOrderRecord
+			result2 error
+		})
+	}
+	fake.getBuildpackOrderReturnsOnCall[i] = struct {
+		result1 repositories.BuildpackOrderRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *BuildpackRepository) ListBuildpacks(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListBuildpacksMessage) ([]repositories.BuildpackRecord, error) {
 	fake.listBuildpacksMutex.Lock()
 	ret, specificReturn := fake.listBuildpacksReturnsOnCall[len(fake.listBuildpacksArgsForCall)]
@@ -96,11 +190,81 @@ func (fake *BuildpackRepository) ListBuildpacksReturnsOnCall(i int, result1 []re
 	}{result1, result2}
 }
 
+func (fake *BuildpackRepository) UpdateBuildpackOrder(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateBuildpackOrderMessage) (repositories.BuildpackOrderRecord, error) {
+	fake.updateBuildpackOrderMutex.Lock()
+	ret, specificReturn := fake.updateBuildpackOrderReturnsOnCall[len(fake.updateBuildpackOrderArgsForCall)]
+	fake.updateBuildpackOrderArgsForCall = append(fake.updateBuildpackOrderArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateBuildpackOrderMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateBuildpackOrderStub
+	fakeReturns := fake.updateBuildpackOrderReturns
+	fake.recordInvocation("UpdateBuildpackOrder", []interface{}{arg1, arg2, arg3})
+	fake.updateBuildpackOrderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackOrderCallCount() int {
+	fake.updateBuildpackOrderMutex.RLock()
+	defer fake.updateBuildpackOrderMutex.RUnlock()
+	return len(fake.updateBuildpackOrderArgsForCall)
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackOrderCalls(stub func(context.Context, authorization.Info, repositories.UpdateBuildpackOrderMessage) (repositories.BuildpackOrderRecord, error)) {
+	fake.updateBuildpackOrderMutex.Lock()
+	defer fake.updateBuildpackOrderMutex.Unlock()
+	fake.UpdateBuildpackOrderStub = stub
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackOrderArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateBuildpackOrderMessage) {
+	fake.updateBuildpackOrderMutex.RLock()
+	defer fake.updateBuildpackOrderMutex.RUnlock()
+	argsForCall := fake.updateBuildpackOrderArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackOrderReturns(result1 repositories.BuildpackOrderRecord, result2 error) {
+	fake.updateBuildpackOrderMutex.Lock()
+	defer fake.updateBuildpackOrderMutex.Unlock()
+	fake.UpdateBuildpackOrderStub = nil
+	fake.updateBuildpackOrderReturns = struct {
+		result1 repositories.BuildpackOrderRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackOrderReturnsOnCall(i int, result1 repositories.BuildpackOrderRecord, result2 error) {
+	fake.updateBuildpackOrderMutex.Lock()
+	defer fake.updateBuildpackOrderMutex.Unlock()
+	fake.UpdateBuildpackOrderStub = nil
+	if fake.updateBuildpackOrderReturnsOnCall == nil {
+		fake.updateBuildpackOrderReturnsOnCall = make(map[int]struct {
+			result1 repositories.BuildpackOrderRecord
+			result2 error
+		})
+	}
+	fake.updateBuildpackOrderReturnsOnCall[i] = struct {
+		result1 repositories.BuildpackOrderRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *BuildpackRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getBuildpackOrderMutex.RLock()
+	defer fake.getBuildpackOrderMutex.RUnlock()
 	fake.listBuildpacksMutex.RLock()
 	defer fake.listBuildpacksMutex.RUnlock()
+	fake.updateBuildpackOrderMutex.RLock()
+	defer fake.updateBuildpackOrderMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -114,6 +114,21 @@ type CFAppRepository struct {
 		result1 repositories.AppEnvVarsRecord
 		result2 error
 	}
+	RestartAppStub        func(context.Context, authorization.Info, repositories.RestartAppMessage) (repositories.AppRecord, error)
+	restartAppMutex       sync.RWMutex
+	restartAppArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.RestartAppMessage
+	}
+	restartAppReturns struct {
+		result1 repositories.AppRecord
+		result2 error
+	}
+	restartAppReturnsOnCall map[int]struct {
+		result1 repositories.AppRecord
+		result2 error
+	}
 	SetAppDesiredStateStub        func(context.Context, authorization.Info, repositories.SetAppDesiredStateMessage) (repositories.AppRecord, error)
 	setAppDesiredStateMutex       sync.RWMutex
 	setAppDesiredStateArgsForCall []struct {
@@ -129,6 +144,21 @@ type CFAppRepository struct {
 		result1 repositories.AppRecord
 		result2 error
 	}
+	SetAppSSHEnabledStub        func(context.Context, authorization.Info, repositories.SetAppSSHEnabledMessage) (repositories.AppRecord, error)
+	setAppSSHEnabledMutex       sync.RWMutex
+	setAppSSHEnabledArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.SetAppSSHEnabledMessage
+	}
+	setAppSSHEnabledReturns struct {
+		result1 repositories.AppRecord
+		result2 error
+	}
+	setAppSSHEnabledReturnsOnCall map[int]struct {
+		result1 repositories.AppRecord
+		result2 error
+	}
 	SetCurrentDropletStub        func(context.Context, authorization.Info, repositories.SetCurrentDropletMessage) (repositories.CurrentDropletRecord, error)
 	setCurrentDropletMutex       sync.RWMutex
 	setCurrentDropletArgsForCall []struct {
@@ -607,6 +637,72 @@ func (fake *CFAppRepository) PatchAppEnvVarsReturnsOnCall(i int, result1 reposit
 	}{result1, result2}
 }
 
+func (fake *CFAppRepository) RestartApp(arg1 context.Context, arg2 authorization.Info, arg3 repositories.RestartAppMessage) (repositories.AppRecord, error) {
+	fake.restartAppMutex.Lock()
+	ret, specificReturn := fake.restartAppReturnsOnCall[len(fake.restartAppArgsForCall)]
+	fake.restartAppArgsForCall = append(fake.restartAppArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.RestartAppMessage
+	}{arg1, arg2, arg3})
+	stub := fake.RestartAppStub
+	fakeReturns := fake.restartAppReturns
+	fake.recordInvocation("RestartApp", []interface{}{arg1, arg2, arg3})
+	fake.restartAppMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFAppRepository) RestartAppCallCount() int {
+	fake.restartAppMutex.RLock()
+	defer fake.restartAppMutex.RUnlock()
+	return len(fake.restartAppArgsForCall)
+}
+
+func (fake *CFAppRepository) RestartAppCalls(stub func(context.Context, authorization.Info, repositories.RestartAppMessage) (repositories.AppRecord, error)) {
+	fake.restartAppMutex.Lock()
+	defer fake.restartAppMutex.Unlock()
+	fake.RestartAppStub = stub
+}
+
+func (fake *CFAppRepository) RestartAppArgsForCall(i int) (context.Context, authorization.Info, repositories.RestartAppMessage) {
+	fake.restartAppMutex.RLock()
+	defer fake.restartAppMutex.RUnlock()
+	argsForCall := fake.restartAppArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFAppRepository) RestartAppReturns(result1 repositories.AppRecord, result2 error) {
+	fake.restartAppMutex.Lock()
+	defer fake.restartAppMutex.Unlock()
+	fake.RestartAppStub = nil
+	fake.restartAppReturns = struct {
+		result1 repositories.AppRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFAppRepository) RestartAppReturnsOnCall(i int, result1 repositories.AppRecord, result2 error) {
+	fake.restartAppMutex.Lock()
+	defer fake.restartAppMutex.Unlock()
+	fake.RestartAppStub = nil
+	if fake.restartAppReturnsOnCall == nil {
+		fake.restartAppReturnsOnCall = make(map[int]struct {
+			result1 repositories.AppRecord
+			result2 error
+		})
+	}
+	fake.restartAppReturnsOnCall[i] = struct {
+		result1 repositories.AppRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFAppRepository) SetAppDesiredState(arg1 context.Context, arg2 authorization.Info, arg3 repositories.SetAppDesiredStateMessage) (repositories.AppRecord, error) {
 	fake.setAppDesiredStateMutex.Lock()
 	ret, specificReturn := fake.setAppDesiredStateReturnsOnCall[len(fake.setAppDesiredStateArgsForCall)]
@@ -673,6 +769,72 @@ func (fake *CFAppRepository) SetAppDesiredStateReturnsOnCall(i int, result1 repo
 	}{result1, result2}
 }
 
+func (fake *CFAppRepository) SetAppSSHEnabled(arg1 context.Context, arg2 authorization.Info, arg3 repositories.SetAppSSHEnabledMessage) (repositories.AppRecord, error) {
+	fake.setAppSSHEnabledMutex.Lock()
+	ret, specificReturn := fake.setAppSSHEnabledReturnsOnCall[len(fake.setAppSSHEnabledArgsForCall)]
+	fake.setAppSSHEnabledArgsForCall = append(fake.setAppSSHEnabledArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.SetAppSSHEnabledMessage
+	}{arg1, arg2, arg3})
+	stub := fake.SetAppSSHEnabledStub
+	fakeReturns := fake.setAppSSHEnabledReturns
+	fake.recordInvocation("SetAppSSHEnabled", []interface{}{arg1, arg2, arg3})
+	fake.setAppSSHEnabledMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFAppRepository) SetAppSSHEnabledCallCount() int {
+	fake.setAppSSHEnabledMutex.RLock()
+	defer fake.setAppSSHEnabledMutex.RUnlock()
+	return len(fake.setAppSSHEnabledArgsForCall)
+}
+
+func (fake *CFAppRepository) SetAppSSHEnabledCalls(stub func(context.Context, authorization.Info, repositories.SetAppSSHEnabledMessage) (repositories.AppRecord, error)) {
+	fake.setAppSSHEnabledMutex.Lock()
+	defer fake.setAppSSHEnabledMutex.Unlock()
+	fake.SetAppSSHEnabledStub = stub
+}
+
+func (fake *CFAppRepository) SetAppSSHEnabledArgsForCall(i int) (context.Context, authorization.Info, repositories.SetAppSSHEnabledMessage) {
+	fake.setAppSSHEnabledMutex.RLock()
+	defer fake.setAppSSHEnabledMutex.RUnlock()
+	argsForCall := fake.setAppSSHEnabledArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFAppRepository) SetAppSSHEnabledReturns(result1 repositories.AppRecord, result2 error) {
+	fake.setAppSSHEnabledMutex.Lock()
+	defer fake.setAppSSHEnabledMutex.Unlock()
+	fake.SetAppSSHEnabledStub = nil
+	fake.setAppSSHEnabledReturns = struct {
+		result1 repositories.AppRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFAppRepository) SetAppSSHEnabledReturnsOnCall(i int, result1 repositories.AppRecord, result2 error) {
+	fake.setAppSSHEnabledMutex.Lock()
+	defer fake.setAppSSHEnabledMutex.Unlock()
+	fake.SetAppSSHEnabledStub = nil
+	if fake.setAppSSHEnabledReturnsOnCall == nil {
+		fake.setAppSSHEnabledReturnsOnCall = make(map[int]struct {
+			result1 repositories.AppRecord
+			result2 error
+		})
+	}
+	fake.setAppSSHEnabledReturnsOnCall[i] = struct {
+		result1 repositories.AppRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFAppRepository) SetCurrentDroplet(arg1 context.Context, arg2 authorization.Info, arg3 repositories.SetCurrentDropletMessage) (repositories.CurrentDropletRecord, error) {
 	fake.setCurrentDropletMutex.Lock()
 	ret, specificReturn := fake.setCurrentDropletReturnsOnCall[len(fake.setCurrentDropletArgsForCall)]
@@ -756,8 +918,12 @@ func (fake *CFAppRepository) Invocations() map[string][][]interface{} {
 	defer fake.patchAppMutex.RUnlock()
 	fake.patchAppEnvVarsMutex.RLock()
 	defer fake.patchAppEnvVarsMutex.RUnlock()
+	fake.restartAppMutex.RLock()
+	defer fake.restartAppMutex.RUnlock()
 	fake.setAppDesiredStateMutex.RLock()
 	defer fake.setAppDesiredStateMutex.RUnlock()
+	fake.setAppSSHEnabledMutex.RLock()
+	defer fake.setAppSSHEnabledMutex.RUnlock()
 	fake.setCurrentDropletMutex.RLock()
 	defer fake.setCurrentDropletMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
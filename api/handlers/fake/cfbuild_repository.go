@@ -57,6 +57,21 @@ type CFBuildRepository struct {
 		result1 repositories.BuildRecord
 		result2 error
 	}
+	ListBuildsStub        func(context.Context, authorization.Info, repositories.ListBuildsMessage) ([]repositories.BuildRecord, error)
+	listBuildsMutex       sync.RWMutex
+	listBuildsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListBuildsMessage
+	}
+	listBuildsReturns struct {
+		result1 []repositories.BuildRecord
+		result2 error
+	}
+	listBuildsReturnsOnCall map[int]struct {
+		result1 []repositories.BuildRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -260,6 +275,72 @@ func (fake *CFBuildRepository) GetLatestBuildByAppGUIDReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
+func (fake *CFBuildRepository) ListBuilds(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListBuildsMessage) ([]repositories.BuildRecord, error) {
+	fake.listBuildsMutex.Lock()
+	ret, specificReturn := fake.listBuildsReturnsOnCall[len(fake.listBuildsArgsForCall)]
+	fake.listBuildsArgsForCall = append(fake.listBuildsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListBuildsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListBuildsStub
+	fakeReturns := fake.listBuildsReturns
+	fake.recordInvocation("ListBuilds", []interface{}{arg1, arg2, arg3})
+	fake.listBuildsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFBuildRepository) ListBuildsCallCount() int {
+	fake.listBuildsMutex.RLock()
+	defer fake.listBuildsMutex.RUnlock()
+	return len(fake.listBuildsArgsForCall)
+}
+
+func (fake *CFBuildRepository) ListBuildsCalls(stub func(context.Context, authorization.Info, repositories.ListBuildsMessage) ([]repositories.BuildRecord, error)) {
+	fake.listBuildsMutex.Lock()
+	defer fake.listBuildsMutex.Unlock()
+	fake.ListBuildsStub = stub
+}
+
+func (fake *CFBuildRepository) ListBuildsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListBuildsMessage) {
+	fake.listBuildsMutex.RLock()
+	defer fake.listBuildsMutex.RUnlock()
+	argsForCall := fake.listBuildsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFBuildRepository) ListBuildsReturns(result1 []repositories.BuildRecord, result2 error) {
+	fake.listBuildsMutex.Lock()
+	defer fake.listBuildsMutex.Unlock()
+	fake.ListBuildsStub = nil
+	fake.listBuildsReturns = struct {
+		result1 []repositories.BuildRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFBuildRepository) ListBuildsReturnsOnCall(i int, result1 []repositories.BuildRecord, result2 error) {
+	fake.listBuildsMutex.Lock()
+	defer fake.listBuildsMutex.Unlock()
+	fake.ListBuildsStub = nil
+	if fake.listBuildsReturnsOnCall == nil {
+		fake.listBuildsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.BuildRecord
+			result2 error
+		})
+	}
+	fake.listBuildsReturnsOnCall[i] = struct {
+		result1 []repositories.BuildRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFBuildRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -269,6 +350,8 @@ func (fake *CFBuildRepository) Invocations() map[string][][]interface{} {
 	defer fake.getBuildMutex.RUnlock()
 	fake.getLatestBuildByAppGUIDMutex.RLock()
 	defer fake.getLatestBuildByAppGUIDMutex.RUnlock()
+	fake.listBuildsMutex.RLock()
+	defer fake.listBuildsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
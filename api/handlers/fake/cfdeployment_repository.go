@@ -11,6 +11,21 @@ import (
 )
 
 type CFDeploymentRepository struct {
+	CancelDeploymentStub        func(context.Context, authorization.Info, string) (repositories.DeploymentRecord, error)
+	cancelDeploymentMutex       sync.RWMutex
+	cancelDeploymentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	cancelDeploymentReturns struct {
+		result1 repositories.DeploymentRecord
+		result2 error
+	}
+	cancelDeploymentReturnsOnCall map[int]struct {
+		result1 repositories.DeploymentRecord
+		result2 error
+	}
 	CreateDeploymentStub        func(context.Context, authorization.Info, repositories.CreateDeploymentMessage) (repositories.DeploymentRecord, error)
 	createDeploymentMutex       sync.RWMutex
 	createDeploymentArgsForCall []struct {
@@ -60,6 +75,72 @@ type CFDeploymentRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *CFDeploymentRepository) CancelDeployment(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.DeploymentRecord, error) {
+	fake.cancelDeploymentMutex.Lock()
+	ret, specificReturn := fake.cancelDeploymentReturnsOnCall[len(fake.cancelDeploymentArgsForCall)]
+	fake.cancelDeploymentArgsForCall = append(fake.cancelDeploymentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CancelDeploymentStub
+	fakeReturns := fake.cancelDeploymentReturns
+	fake.recordInvocation("CancelDeployment", []interface{}{arg1, arg2, arg3})
+	fake.cancelDeploymentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFDeploymentRepository) CancelDeploymentCallCount() int {
+	fake.cancelDeploymentMutex.RLock()
+	defer fake.cancelDeploymentMutex.RUnlock()
+	return len(fake.cancelDeploymentArgsForCall)
+}
+
+func (fake *CFDeploymentRepository) CancelDeploymentCalls(stub func(context.Context, authorization.Info, string) (repositories.DeploymentRecord, error)) {
+	fake.cancelDeploymentMutex.Lock()
+	defer fake.cancelDeploymentMutex.Unlock()
+	fake.CancelDeploymentStub = stub
+}
+
+func (fake *CFDeploymentRepository) CancelDeploymentArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.cancelDeploymentMutex.RLock()
+	defer fake.cancelDeploymentMutex.RUnlock()
+	argsForCall := fake.cancelDeploymentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFDeploymentRepository) CancelDeploymentReturns(result1 repositories.DeploymentRecord, result2 error) {
+	fake.cancelDeploymentMutex.Lock()
+	defer fake.cancelDeploymentMutex.Unlock()
+	fake.CancelDeploymentStub = nil
+	fake.cancelDeploymentReturns = struct {
+		result1 repositories.DeploymentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDeploymentRepository) CancelDeploymentReturnsOnCall(i int, result1 repositories.DeploymentRecord, result2 error) {
+	fake.cancelDeploymentMutex.Lock()
+	defer fake.cancelDeploymentMutex.Unlock()
+	fake.CancelDeploymentStub = nil
+	if fake.cancelDeploymentReturnsOnCall == nil {
+		fake.cancelDeploymentReturnsOnCall = make(map[int]struct {
+			result1 repositories.DeploymentRecord
+			result2 error
+		})
+	}
+	fake.cancelDeploymentReturnsOnCall[i] = struct {
+		result1 repositories.DeploymentRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFDeploymentRepository) CreateDeployment(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateDeploymentMessage) (repositories.DeploymentRecord, error) {
 	fake.createDeploymentMutex.Lock()
 	ret, specificReturn := fake.createDeploymentReturnsOnCall[len(fake.createDeploymentArgsForCall)]
@@ -261,6 +342,8 @@ func (fake *CFDeploymentRepository) ListDeploymentsReturnsOnCall(i int, result1
 func (fake *CFDeploymentRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.cancelDeploymentMutex.RLock()
+	defer fake.cancelDeploymentMutex.RUnlock()
 	fake.createDeploymentMutex.RLock()
 	defer fake.createDeploymentMutex.RUnlock()
 	fake.getDeploymentMutex.RLock()
@@ -0,0 +1,289 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFRevisionRepository struct {
+	CreateRevisionStub        func(context.Context, authorization.Info, repositories.CreateRevisionMessage) (repositories.RevisionRecord, error)
+	createRevisionMutex       sync.RWMutex
+	createRevisionArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateRevisionMessage
+	}
+	createRevisionReturns struct {
+		result1 repositories.RevisionRecord
+		result2 error
+	}
+	createRevisionReturnsOnCall map[int]struct {
+		result1 repositories.RevisionRecord
+		result2 error
+	}
+	GetRevisionStub        func(context.Context, authorization.Info, string) (repositories.RevisionRecord, error)
+	getRevisionMutex       sync.RWMutex
+	getRevisionArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getRevisionReturns struct {
+		result1 repositories.RevisionRecord
+		result2 error
+	}
+	getRevisionReturnsOnCall map[int]struct {
+		result1 repositories.RevisionRecord
+		result2 error
+	}
+	ListRevisionsStub        func(context.Context, authorization.Info, repositories.ListRevisionsMessage) ([]repositories.RevisionRecord, error)
+	listRevisionsMutex       sync.RWMutex
+	listRevisionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListRevisionsMessage
+	}
+	listRevisionsReturns struct {
+		result1 []repositories.RevisionRecord
+		result2 error
+	}
+	listRevisionsReturnsOnCall map[int]struct {
+		result1 []repositories.RevisionRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFRevisionRepository) CreateRevision(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateRevisionMessage) (repositories.RevisionRecord, error) {
+	fake.createRevisionMutex.Lock()
+	ret, specificReturn := fake.createRevisionReturnsOnCall[len(fake.createRevisionArgsForCall)]
+	fake.createRevisionArgsForCall = append(fake.createRevisionArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateRevisionMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateRevisionStub
+	fakeReturns := fake.createRevisionReturns
+	fake.recordInvocation("CreateRevision", []interface{}{arg1, arg2, arg3})
+	fake.createRevisionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRevisionRepository) CreateRevisionCallCount() int {
+	fake.createRevisionMutex.RLock()
+	defer fake.createRevisionMutex.RUnlock()
+	return len(fake.createRevisionArgsForCall)
+}
+
+func (fake *CFRevisionRepository) CreateRevisionCalls(stub func(context.Context, authorization.Info, repositories.CreateRevisionMessage) (repositories.RevisionRecord, error)) {
+	fake.createRevisionMutex.Lock()
+	defer fake.createRevisionMutex.Unlock()
+	fake.CreateRevisionStub = stub
+}
+
+func (fake *CFRevisionRepository) CreateRevisionArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateRevisionMessage) {
+	fake.createRevisionMutex.RLock()
+	defer fake.createRevisionMutex.RUnlock()
+	argsForCall := fake.createRevisionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRevisionRepository) CreateRevisionReturns(result1 repositories.RevisionRecord, result2 error) {
+	fake.createRevisionMutex.Lock()
+	defer fake.createRevisionMutex.Unlock()
+	fake.CreateRevisionStub = nil
+	fake.createRevisionReturns = struct {
+		result1 repositories.RevisionRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRevisionRepository) CreateRevisionReturnsOnCall(i int, result1 repositories.RevisionRecord, result2 error) {
+	fake.createRevisionMutex.Lock()
+	defer fake.createRevisionMutex.Unlock()
+	fake.CreateRevisionStub = nil
+	if fake.createRevisionReturnsOnCall == nil {
+		fake.createRevisionReturnsOnCall = make(map[int]struct {
+			result1 repositories.RevisionRecord
+			result2 error
+		})
+	}
+	fake.createRevisionReturnsOnCall[i] = struct {
+		result1 repositories.RevisionRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRevisionRepository) GetRevision(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.RevisionRecord, error) {
+	fake.getRevisionMutex.Lock()
+	ret, specificReturn := fake.getRevisionReturnsOnCall[len(fake.getRevisionArgsForCall)]
+	fake.getRevisionArgsForCall = append(fake.getRevisionArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetRevisionStub
+	fakeReturns := fake.getRevisionReturns
+	fake.recordInvocation("GetRevision", []interface{}{arg1, arg2, arg3})
+	fake.getRevisionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRevisionRepository) GetRevisionCallCount() int {
+	fake.getRevisionMutex.RLock()
+	defer fake.getRevisionMutex.RUnlock()
+	return len(fake.getRevisionArgsForCall)
+}
+
+func (fake *CFRevisionRepository) GetRevisionCalls(stub func(context.Context, authorization.Info, string) (repositories.RevisionRecord, error)) {
+	fake.getRevisionMutex.Lock()
+	defer fake.getRevisionMutex.Unlock()
+	fake.GetRevisionStub = stub
+}
+
+func (fake *CFRevisionRepository) GetRevisionArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getRevisionMutex.RLock()
+	defer fake.getRevisionMutex.RUnlock()
+	argsForCall := fake.getRevisionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRevisionRepository) GetRevisionReturns(result1 repositories.RevisionRecord, result2 error) {
+	fake.getRevisionMutex.Lock()
+	defer fake.getRevisionMutex.Unlock()
+	fake.GetRevisionStub = nil
+	fake.getRevisionReturns = struct {
+		result1 repositories.RevisionRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRevisionRepository) GetRevisionReturnsOnCall(i int, result1 repositories.RevisionRecord, result2 error) {
+	fake.getRevisionMutex.Lock()
+	defer fake.getRevisionMutex.Unlock()
+	fake.GetRevisionStub = nil
+	if fake.getRevisionReturnsOnCall == nil {
+		fake.getRevisionReturnsOnCall = make(map[int]struct {
+			result1 repositories.RevisionRecord
+			result2 error
+		})
+	}
+	fake.getRevisionReturnsOnCall[i] = struct {
+		result1 repositories.RevisionRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRevisionRepository) ListRevisions(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListRevisionsMessage) ([]repositories.RevisionRecord, error) {
+	fake.listRevisionsMutex.Lock()
+	ret, specificReturn := fake.listRevisionsReturnsOnCall[len(fake.listRevisionsArgsForCall)]
+	fake.listRevisionsArgsForCall = append(fake.listRevisionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListRevisionsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListRevisionsStub
+	fakeReturns := fake.listRevisionsReturns
+	fake.recordInvocation("ListRevisions", []interface{}{arg1, arg2, arg3})
+	fake.listRevisionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRevisionRepository) ListRevisionsCallCount() int {
+	fake.listRevisionsMutex.RLock()
+	defer fake.listRevisionsMutex.RUnlock()
+	return len(fake.listRevisionsArgsForCall)
+}
+
+func (fake *CFRevisionRepository) ListRevisionsCalls(stub func(context.Context, authorization.Info, repositories.ListRevisionsMessage) ([]repositories.RevisionRecord, error)) {
+	fake.listRevisionsMutex.Lock()
+	defer fake.listRevisionsMutex.Unlock()
+	fake.ListRevisionsStub = stub
+}
+
+func (fake *CFRevisionRepository) ListRevisionsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListRevisionsMessage) {
+	fake.listRevisionsMutex.RLock()
+	defer fake.listRevisionsMutex.RUnlock()
+	argsForCall := fake.listRevisionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRevisionRepository) ListRevisionsReturns(result1 []repositories.RevisionRecord, result2 error) {
+	fake.listRevisionsMutex.Lock()
+	defer fake.listRevisionsMutex.Unlock()
+	fake.ListRevisionsStub = nil
+	fake.listRevisionsReturns = struct {
+		result1 []repositories.RevisionRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRevisionRepository) ListRevisionsReturnsOnCall(i int, result1 []repositories.RevisionRecord, result2 error) {
+	fake.listRevisionsMutex.Lock()
+	defer fake.listRevisionsMutex.Unlock()
+	fake.ListRevisionsStub = nil
+	if fake.listRevisionsReturnsOnCall == nil {
+		fake.listRevisionsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.RevisionRecord
+			result2 error
+		})
+	}
+	fake.listRevisionsReturnsOnCall[i] = struct {
+		result1 []repositories.RevisionRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRevisionRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createRevisionMutex.RLock()
+	defer fake.createRevisionMutex.RUnlock()
+	fake.getRevisionMutex.RLock()
+	defer fake.getRevisionMutex.RUnlock()
+	fake.listRevisionsMutex.RLock()
+	defer fake.listRevisionsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFRevisionRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFRevisionRepository = new(CFRevisionRepository)
@@ -41,6 +41,21 @@ type CFServiceInstanceRepository struct {
 		result1 repositories.ServiceInstanceRecord
 		result2 error
 	}
+	CreateVolumeServiceInstanceStub        func(context.Context, authorization.Info, repositories.CreateVolumeSIMessage) (repositories.ServiceInstanceRecord, error)
+	createVolumeServiceInstanceMutex       sync.RWMutex
+	createVolumeServiceInstanceArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateVolumeSIMessage
+	}
+	createVolumeServiceInstanceReturns struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}
+	createVolumeServiceInstanceReturnsOnCall map[int]struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}
 	DeleteServiceInstanceStub        func(context.Context, authorization.Info, repositories.DeleteServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
 	deleteServiceInstanceMutex       sync.RWMutex
 	deleteServiceInstanceArgsForCall []struct {
@@ -252,6 +267,72 @@ func (fake *CFServiceInstanceRepository) CreateUserProvidedServiceInstanceReturn
 	}{result1, result2}
 }
 
+func (fake *CFServiceInstanceRepository) CreateVolumeServiceInstance(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateVolumeSIMessage) (repositories.ServiceInstanceRecord, error) {
+	fake.createVolumeServiceInstanceMutex.Lock()
+	ret, specificReturn := fake.createVolumeServiceInstanceReturnsOnCall[len(fake.createVolumeServiceInstanceArgsForCall)]
+	fake.createVolumeServiceInstanceArgsForCall = append(fake.createVolumeServiceInstanceArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateVolumeSIMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateVolumeServiceInstanceStub
+	fakeReturns := fake.createVolumeServiceInstanceReturns
+	fake.recordInvocation("CreateVolumeServiceInstance", []interface{}{arg1, arg2, arg3})
+	fake.createVolumeServiceInstanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceInstanceRepository) CreateVolumeServiceInstanceCallCount() int {
+	fake.createVolumeServiceInstanceMutex.RLock()
+	defer fake.createVolumeServiceInstanceMutex.RUnlock()
+	return len(fake.createVolumeServiceInstanceArgsForCall)
+}
+
+func (fake *CFServiceInstanceRepository) CreateVolumeServiceInstanceCalls(stub func(context.Context, authorization.Info, repositories.CreateVolumeSIMessage) (repositories.ServiceInstanceRecord, error)) {
+	fake.createVolumeServiceInstanceMutex.Lock()
+	defer fake.createVolumeServiceInstanceMutex.Unlock()
+	fake.CreateVolumeServiceInstanceStub = stub
+}
+
+func (fake *CFServiceInstanceRepository) CreateVolumeServiceInstanceArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateVolumeSIMessage) {
+	fake.createVolumeServiceInstanceMutex.RLock()
+	defer fake.createVolumeServiceInstanceMutex.RUnlock()
+	argsForCall := fake.createVolumeServiceInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceInstanceRepository) CreateVolumeServiceInstanceReturns(result1 repositories.ServiceInstanceRecord, result2 error) {
+	fake.createVolumeServiceInstanceMutex.Lock()
+	defer fake.createVolumeServiceInstanceMutex.Unlock()
+	fake.CreateVolumeServiceInstanceStub = nil
+	fake.createVolumeServiceInstanceReturns = struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) CreateVolumeServiceInstanceReturnsOnCall(i int, result1 repositories.ServiceInstanceRecord, result2 error) {
+	fake.createVolumeServiceInstanceMutex.Lock()
+	defer fake.createVolumeServiceInstanceMutex.Unlock()
+	fake.CreateVolumeServiceInstanceStub = nil
+	if fake.createVolumeServiceInstanceReturnsOnCall == nil {
+		fake.createVolumeServiceInstanceReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServiceInstanceRecord
+			result2 error
+		})
+	}
+	fake.createVolumeServiceInstanceReturnsOnCall[i] = struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFServiceInstanceRepository) DeleteServiceInstance(arg1 context.Context, arg2 authorization.Info, arg3 repositories.DeleteServiceInstanceMessage) (repositories.ServiceInstanceRecord, error) {
 	fake.deleteServiceInstanceMutex.Lock()
 	ret, specificReturn := fake.deleteServiceInstanceReturnsOnCall[len(fake.deleteServiceInstanceArgsForCall)]
@@ -589,6 +670,8 @@ func (fake *CFServiceInstanceRepository) Invocations() map[string][][]interface{
 	defer fake.createManagedServiceInstanceMutex.RUnlock()
 	fake.createUserProvidedServiceInstanceMutex.RLock()
 	defer fake.createUserProvidedServiceInstanceMutex.RUnlock()
+	fake.createVolumeServiceInstanceMutex.RLock()
+	defer fake.createVolumeServiceInstanceMutex.RUnlock()
 	fake.deleteServiceInstanceMutex.RLock()
 	defer fake.deleteServiceInstanceMutex.RUnlock()
 	fake.getServiceInstanceMutex.RLock()
@@ -8,9 +8,25 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
 )
 
 type ImageRepository struct {
+	DownloadDropletStub        func(context.Context, authorization.Info, repositories.DropletRecord) (io.ReadCloser, error)
+	downloadDropletMutex       sync.RWMutex
+	downloadDropletArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DropletRecord
+	}
+	downloadDropletReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	downloadDropletReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
 	UploadSourceImageStub        func(context.Context, authorization.Info, string, io.Reader, string, ...string) (string, error)
 	uploadSourceImageMutex       sync.RWMutex
 	uploadSourceImageArgsForCall []struct {
@@ -33,6 +49,72 @@ type ImageRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ImageRepository) DownloadDroplet(arg1 context.Context, arg2 authorization.Info, arg3 repositories.DropletRecord) (io.ReadCloser, error) {
+	fake.downloadDropletMutex.Lock()
+	ret, specificReturn := fake.downloadDropletReturnsOnCall[len(fake.downloadDropletArgsForCall)]
+	fake.downloadDropletArgsForCall = append(fake.downloadDropletArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DropletRecord
+	}{arg1, arg2, arg3})
+	stub := fake.DownloadDropletStub
+	fakeReturns := fake.downloadDropletReturns
+	fake.recordInvocation("DownloadDroplet", []interface{}{arg1, arg2, arg3})
+	fake.downloadDropletMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageRepository) DownloadDropletCallCount() int {
+	fake.downloadDropletMutex.RLock()
+	defer fake.downloadDropletMutex.RUnlock()
+	return len(fake.downloadDropletArgsForCall)
+}
+
+func (fake *ImageRepository) DownloadDropletCalls(stub func(context.Context, authorization.Info, repositories.DropletRecord) (io.ReadCloser, error)) {
+	fake.downloadDropletMutex.Lock()
+	defer fake.downloadDropletMutex.Unlock()
+	fake.DownloadDropletStub = stub
+}
+
+func (fake *ImageRepository) DownloadDropletArgsForCall(i int) (context.Context, authorization.Info, repositories.DropletRecord) {
+	fake.downloadDropletMutex.RLock()
+	defer fake.downloadDropletMutex.RUnlock()
+	argsForCall := fake.downloadDropletArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ImageRepository) DownloadDropletReturns(result1 io.ReadCloser, result2 error) {
+	fake.downloadDropletMutex.Lock()
+	defer fake.downloadDropletMutex.Unlock()
+	fake.DownloadDropletStub = nil
+	fake.downloadDropletReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) DownloadDropletReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.downloadDropletMutex.Lock()
+	defer fake.downloadDropletMutex.Unlock()
+	fake.DownloadDropletStub = nil
+	if fake.downloadDropletReturnsOnCall == nil {
+		fake.downloadDropletReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.downloadDropletReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImageRepository) UploadSourceImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 io.Reader, arg5 string, arg6 ...string) (string, error) {
 	fake.uploadSourceImageMutex.Lock()
 	ret, specificReturn := fake.uploadSourceImageReturnsOnCall[len(fake.uploadSourceImageArgsForCall)]
@@ -105,6 +187,8 @@ func (fake *ImageRepository) UploadSourceImageReturnsOnCall(i int, result1 strin
 func (fake *ImageRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.downloadDropletMutex.RLock()
+	defer fake.downloadDropletMutex.RUnlock()
 	fake.uploadSourceImageMutex.RLock()
 	defer fake.uploadSourceImageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -26,6 +26,21 @@ type LogRepository struct {
 		result1 []repositories.LogRecord
 		result2 error
 	}
+	StreamAppLogsStub        func(context.Context, authorization.Info, repositories.StreamLogsMessage) (<-chan repositories.LogRecord, error)
+	streamAppLogsMutex       sync.RWMutex
+	streamAppLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.StreamLogsMessage
+	}
+	streamAppLogsReturns struct {
+		result1 <-chan repositories.LogRecord
+		result2 error
+	}
+	streamAppLogsReturnsOnCall map[int]struct {
+		result1 <-chan repositories.LogRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -96,11 +111,79 @@ func (fake *LogRepository) GetAppLogsReturnsOnCall(i int, result1 []repositories
 	}{result1, result2}
 }
 
+func (fake *LogRepository) StreamAppLogs(arg1 context.Context, arg2 authorization.Info, arg3 repositories.StreamLogsMessage) (<-chan repositories.LogRecord, error) {
+	fake.streamAppLogsMutex.Lock()
+	ret, specificReturn := fake.streamAppLogsReturnsOnCall[len(fake.streamAppLogsArgsForCall)]
+	fake.streamAppLogsArgsForCall = append(fake.streamAppLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.StreamLogsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.StreamAppLogsStub
+	fakeReturns := fake.streamAppLogsReturns
+	fake.recordInvocation("StreamAppLogs", []interface{}{arg1, arg2, arg3})
+	fake.streamAppLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *LogRepository) StreamAppLogsCallCount() int {
+	fake.streamAppLogsMutex.RLock()
+	defer fake.streamAppLogsMutex.RUnlock()
+	return len(fake.streamAppLogsArgsForCall)
+}
+
+func (fake *LogRepository) StreamAppLogsCalls(stub func(context.Context, authorization.Info, repositories.StreamLogsMessage) (<-chan repositories.LogRecord, error)) {
+	fake.streamAppLogsMutex.Lock()
+	defer fake.streamAppLogsMutex.Unlock()
+	fake.StreamAppLogsStub = stub
+}
+
+func (fake *LogRepository) StreamAppLogsArgsForCall(i int) (context.Context, authorization.Info, repositories.StreamLogsMessage) {
+	fake.streamAppLogsMutex.RLock()
+	defer fake.streamAppLogsMutex.RUnlock()
+	argsForCall := fake.streamAppLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *LogRepository) StreamAppLogsReturns(result1 <-chan repositories.LogRecord, result2 error) {
+	fake.streamAppLogsMutex.Lock()
+	defer fake.streamAppLogsMutex.Unlock()
+	fake.StreamAppLogsStub = nil
+	fake.streamAppLogsReturns = struct {
+		result1 <-chan repositories.LogRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LogRepository) StreamAppLogsReturnsOnCall(i int, result1 <-chan repositories.LogRecord, result2 error) {
+	fake.streamAppLogsMutex.Lock()
+	defer fake.streamAppLogsMutex.Unlock()
+	fake.StreamAppLogsStub = nil
+	if fake.streamAppLogsReturnsOnCall == nil {
+		fake.streamAppLogsReturnsOnCall = make(map[int]struct {
+			result1 <-chan repositories.LogRecord
+			result2 error
+		})
+	}
+	fake.streamAppLogsReturnsOnCall[i] = struct {
+		result1 <-chan repositories.LogRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *LogRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getAppLogsMutex.RLock()
 	defer fake.getAppLogsMutex.RUnlock()
+	fake.streamAppLogsMutex.RLock()
+	defer fake.streamAppLogsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
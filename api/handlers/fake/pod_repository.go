@@ -3,6 +3,7 @@ package fake
 
 import (
 	"context"
+	"net/http"
 	"sync"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
@@ -26,6 +27,21 @@ type PodRepository struct {
 	deletePodReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ExecInstanceStub        func(context.Context, authorization.Info, repositories.ExecInstanceMessage) (http.Handler, error)
+	execInstanceMutex       sync.RWMutex
+	execInstanceArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ExecInstanceMessage
+	}
+	execInstanceReturns struct {
+		result1 http.Handler
+		result2 error
+	}
+	execInstanceReturnsOnCall map[int]struct {
+		result1 http.Handler
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -95,11 +111,79 @@ func (fake *PodRepository) DeletePodReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *PodRepository) ExecInstance(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ExecInstanceMessage) (http.Handler, error) {
+	fake.execInstanceMutex.Lock()
+	ret, specificReturn := fake.execInstanceReturnsOnCall[len(fake.execInstanceArgsForCall)]
+	fake.execInstanceArgsForCall = append(fake.execInstanceArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ExecInstanceMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ExecInstanceStub
+	fakeReturns := fake.execInstanceReturns
+	fake.recordInvocation("ExecInstance", []interface{}{arg1, arg2, arg3})
+	fake.execInstanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PodRepository) ExecInstanceCallCount() int {
+	fake.execInstanceMutex.RLock()
+	defer fake.execInstanceMutex.RUnlock()
+	return len(fake.execInstanceArgsForCall)
+}
+
+func (fake *PodRepository) ExecInstanceCalls(stub func(context.Context, authorization.Info, repositories.ExecInstanceMessage) (http.Handler, error)) {
+	fake.execInstanceMutex.Lock()
+	defer fake.execInstanceMutex.Unlock()
+	fake.ExecInstanceStub = stub
+}
+
+func (fake *PodRepository) ExecInstanceArgsForCall(i int) (context.Context, authorization.Info, repositories.ExecInstanceMessage) {
+	fake.execInstanceMutex.RLock()
+	defer fake.execInstanceMutex.RUnlock()
+	argsForCall := fake.execInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *PodRepository) ExecInstanceReturns(result1 http.Handler, result2 error) {
+	fake.execInstanceMutex.Lock()
+	defer fake.execInstanceMutex.Unlock()
+	fake.ExecInstanceStub = nil
+	fake.execInstanceReturns = struct {
+		result1 http.Handler
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodRepository) ExecInstanceReturnsOnCall(i int, result1 http.Handler, result2 error) {
+	fake.execInstanceMutex.Lock()
+	defer fake.execInstanceMutex.Unlock()
+	fake.ExecInstanceStub = nil
+	if fake.execInstanceReturnsOnCall == nil {
+		fake.execInstanceReturnsOnCall = make(map[int]struct {
+			result1 http.Handler
+			result2 error
+		})
+	}
+	fake.execInstanceReturnsOnCall[i] = struct {
+		result1 http.Handler
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PodRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.deletePodMutex.RLock()
 	defer fake.deletePodMutex.RUnlock()
+	fake.execInstanceMutex.RLock()
+	defer fake.execInstanceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,206 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type QuotaRepository struct {
+	ListOrgQuotasStub        func(context.Context, authorization.Info, repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error)
+	listOrgQuotasMutex       sync.RWMutex
+	listOrgQuotasArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListOrgQuotasMessage
+	}
+	listOrgQuotasReturns struct {
+		result1 []repositories.OrgQuotaRecord
+		result2 error
+	}
+	listOrgQuotasReturnsOnCall map[int]struct {
+		result1 []repositories.OrgQuotaRecord
+		result2 error
+	}
+	ListSpaceQuotasStub        func(context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error)
+	listSpaceQuotasMutex       sync.RWMutex
+	listSpaceQuotasArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListSpaceQuotasMessage
+	}
+	listSpaceQuotasReturns struct {
+		result1 []repositories.SpaceQuotaRecord
+		result2 error
+	}
+	listSpaceQuotasReturnsOnCall map[int]struct {
+		result1 []repositories.SpaceQuotaRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *QuotaRepository) ListOrgQuotas(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error) {
+	fake.listOrgQuotasMutex.Lock()
+	ret, specificReturn := fake.listOrgQuotasReturnsOnCall[len(fake.listOrgQuotasArgsForCall)]
+	fake.listOrgQuotasArgsForCall = append(fake.listOrgQuotasArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListOrgQuotasMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListOrgQuotasStub
+	fakeReturns := fake.listOrgQuotasReturns
+	fake.recordInvocation("ListOrgQuotas", []interface{}{arg1, arg2, arg3})
+	fake.listOrgQuotasMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *QuotaRepository) ListOrgQuotasCallCount() int {
+	fake.listOrgQuotasMutex.RLock()
+	defer fake.listOrgQuotasMutex.RUnlock()
+	return len(fake.listOrgQuotasArgsForCall)
+}
+
+func (fake *QuotaRepository) ListOrgQuotasCalls(stub func(context.Context, authorization.Info, repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error)) {
+	fake.listOrgQuotasMutex.Lock()
+	defer fake.listOrgQuotasMutex.Unlock()
+	fake.ListOrgQuotasStub = stub
+}
+
+func (fake *QuotaRepository) ListOrgQuotasArgsForCall(i int) (context.Context, authorization.Info, repositories.ListOrgQuotasMessage) {
+	fake.listOrgQuotasMutex.RLock()
+	defer fake.listOrgQuotasMutex.RUnlock()
+	argsForCall := fake.listOrgQuotasArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *QuotaRepository) ListOrgQuotasReturns(result1 []repositories.OrgQuotaRecord, result2 error) {
+	fake.listOrgQuotasMutex.Lock()
+	defer fake.listOrgQuotasMutex.Unlock()
+	fake.ListOrgQuotasStub = nil
+	fake.listOrgQuotasReturns = struct {
+		result1 []repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *QuotaRepository) ListOrgQuotasReturnsOnCall(i int, result1 []repositories.OrgQuotaRecord, result2 error) {
+	fake.listOrgQuotasMutex.Lock()
+	defer fake.listOrgQuotasMutex.Unlock()
+	fake.ListOrgQuotasStub = nil
+	if fake.listOrgQuotasReturnsOnCall == nil {
+		fake.listOrgQuotasReturnsOnCall = make(map[int]struct {
+			result1 []repositories.OrgQuotaRecord
+			result2 error
+		})
+	}
+	fake.listOrgQuotasReturnsOnCall[i] = struct {
+		result1 []repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *QuotaRepository) ListSpaceQuotas(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error) {
+	fake.listSpaceQuotasMutex.Lock()
+	ret, specificReturn := fake.listSpaceQuotasReturnsOnCall[len(fake.listSpaceQuotasArgsForCall)]
+	fake.listSpaceQuotasArgsForCall = append(fake.listSpaceQuotasArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListSpaceQuotasMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListSpaceQuotasStub
+	fakeReturns := fake.listSpaceQuotasReturns
+	fake.recordInvocation("ListSpaceQuotas", []interface{}{arg1, arg2, arg3})
+	fake.listSpaceQuotasMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *QuotaRepository) ListSpaceQuotasCallCount() int {
+	fake.listSpaceQuotasMutex.RLock()
+	defer fake.listSpaceQuotasMutex.RUnlock()
+	return len(fake.listSpaceQuotasArgsForCall)
+}
+
+func (fake *QuotaRepository) ListSpaceQuotasCalls(stub func(context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error)) {
+	fake.listSpaceQuotasMutex.Lock()
+	defer fake.listSpaceQuotasMutex.Unlock()
+	fake.ListSpaceQuotasStub = stub
+}
+
+func (fake *QuotaRepository) ListSpaceQuotasArgsForCall(i int) (context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) {
+	fake.listSpaceQuotasMutex.RLock()
+	defer fake.listSpaceQuotasMutex.RUnlock()
+	argsForCall := fake.listSpaceQuotasArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *QuotaRepository) ListSpaceQuotasReturns(result1 []repositories.SpaceQuotaRecord, result2 error) {
+	fake.listSpaceQuotasMutex.Lock()
+	defer fake.listSpaceQuotasMutex.Unlock()
+	fake.ListSpaceQuotasStub = nil
+	fake.listSpaceQuotasReturns = struct {
+		result1 []repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *QuotaRepository) ListSpaceQuotasReturnsOnCall(i int, result1 []repositories.SpaceQuotaRecord, result2 error) {
+	fake.listSpaceQuotasMutex.Lock()
+	defer fake.listSpaceQuotasMutex.Unlock()
+	fake.ListSpaceQuotasStub = nil
+	if fake.listSpaceQuotasReturnsOnCall == nil {
+		fake.listSpaceQuotasReturnsOnCall = make(map[int]struct {
+			result1 []repositories.SpaceQuotaRecord
+			result2 error
+		})
+	}
+	fake.listSpaceQuotasReturnsOnCall[i] = struct {
+		result1 []repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *QuotaRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listOrgQuotasMutex.RLock()
+	defer fake.listOrgQuotasMutex.RUnlock()
+	fake.listSpaceQuotasMutex.RLock()
+	defer fake.listSpaceQuotasMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *QuotaRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.QuotaRepository = new(QuotaRepository)
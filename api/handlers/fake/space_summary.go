@@ -0,0 +1,123 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+)
+
+type SpaceSummary struct {
+	SummarizeStub        func(context.Context, authorization.Info, string) (actions.SpaceSummaryRecord, error)
+	summarizeMutex       sync.RWMutex
+	summarizeArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	summarizeReturns struct {
+		result1 actions.SpaceSummaryRecord
+		result2 error
+	}
+	summarizeReturnsOnCall map[int]struct {
+		result1 actions.SpaceSummaryRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SpaceSummary) Summarize(arg1 context.Context, arg2 authorization.Info, arg3 string) (actions.SpaceSummaryRecord, error) {
+	fake.summarizeMutex.Lock()
+	ret, specificReturn := fake.summarizeReturnsOnCall[len(fake.summarizeArgsForCall)]
+	fake.summarizeArgsForCall = append(fake.summarizeArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.SummarizeStub
+	fakeReturns := fake.summarizeReturns
+	fake.recordInvocation("Summarize", []interface{}{arg1, arg2, arg3})
+	fake.summarizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SpaceSummary) SummarizeCallCount() int {
+	fake.summarizeMutex.RLock()
+	defer fake.summarizeMutex.RUnlock()
+	return len(fake.summarizeArgsForCall)
+}
+
+func (fake *SpaceSummary) SummarizeCalls(stub func(context.Context, authorization.Info, string) (actions.SpaceSummaryRecord, error)) {
+	fake.summarizeMutex.Lock()
+	defer fake.summarizeMutex.Unlock()
+	fake.SummarizeStub = stub
+}
+
+func (fake *SpaceSummary) SummarizeArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.summarizeMutex.RLock()
+	defer fake.summarizeMutex.RUnlock()
+	argsForCall := fake.summarizeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SpaceSummary) SummarizeReturns(result1 actions.SpaceSummaryRecord, result2 error) {
+	fake.summarizeMutex.Lock()
+	defer fake.summarizeMutex.Unlock()
+	fake.SummarizeStub = nil
+	fake.summarizeReturns = struct {
+		result1 actions.SpaceSummaryRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SpaceSummary) SummarizeReturnsOnCall(i int, result1 actions.SpaceSummaryRecord, result2 error) {
+	fake.summarizeMutex.Lock()
+	defer fake.summarizeMutex.Unlock()
+	fake.SummarizeStub = nil
+	if fake.summarizeReturnsOnCall == nil {
+		fake.summarizeReturnsOnCall = make(map[int]struct {
+			result1 actions.SpaceSummaryRecord
+			result2 error
+		})
+	}
+	fake.summarizeReturnsOnCall[i] = struct {
+		result1 actions.SpaceSummaryRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SpaceSummary) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.summarizeMutex.RLock()
+	defer fake.summarizeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SpaceSummary) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.SpaceSummary = new(SpaceSummary)
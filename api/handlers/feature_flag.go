@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	FeatureFlagsPath = "/v3/feature_flags"
+	FeatureFlagPath  = "/v3/feature_flags/{name}"
+)
+
+//counterfeiter:generate -o fake -fake-name FeatureFlagRepository . FeatureFlagRepository
+type FeatureFlagRepository interface {
+	ListFeatureFlags(ctx context.Context, authInfo authorization.Info) ([]repositories.FeatureFlagRecord, error)
+	GetFeatureFlag(ctx context.Context, authInfo authorization.Info, name string) (repositories.FeatureFlagRecord, error)
+	UpdateFeatureFlag(ctx context.Context, authInfo authorization.Info, message repositories.UpdateFeatureFlagMessage) (repositories.FeatureFlagRecord, error)
+	CanUpdateFeatureFlags(ctx context.Context, authInfo authorization.Info) (bool, error)
+}
+
+type FeatureFlag struct {
+	serverURL        url.URL
+	featureFlagRepo  FeatureFlagRepository
+	requestValidator RequestValidator
+}
+
+func NewFeatureFlag(
+	serverURL url.URL,
+	featureFlagRepo FeatureFlagRepository,
+	requestValidator RequestValidator,
+) *FeatureFlag {
+	return &FeatureFlag{
+		serverURL:        serverURL,
+		featureFlagRepo:  featureFlagRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *FeatureFlag) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.feature-flag.list")
+
+	featureFlags, err := h.featureFlagRepo.ListFeatureFlags(r.Context(), authInfo)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list feature flags")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForFeatureFlag, featureFlags, h.serverURL, *r.URL)), nil
+}
+
+func (h *FeatureFlag) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.feature-flag.get")
+
+	name := routing.URLParam(r, "name")
+
+	featureFlag, err := h.featureFlagRepo.GetFeatureFlag(r.Context(), authInfo, name)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to get feature flag", "Name", name)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForFeatureFlag(featureFlag, h.serverURL)), nil
+}
+
+func (h *FeatureFlag) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.feature-flag.update")
+
+	name := routing.URLParam(r, "name")
+
+	payload := new(payloads.FeatureFlagUpdate)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	featureFlag, err := h.featureFlagRepo.UpdateFeatureFlag(r.Context(), authInfo, payload.ToMessage(name))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to update feature flag", "Name", name)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForFeatureFlag(featureFlag, h.serverURL)), nil
+}
+
+func (h *FeatureFlag) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *FeatureFlag) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: FeatureFlagsPath, Handler: h.list},
+		{Method: "GET", Pattern: FeatureFlagPath, Handler: h.get},
+		{Method: "PATCH", Pattern: FeatureFlagPath, Handler: h.update},
+	}
+}
+
+// checkFeatureFlag returns a feature disabled error when the named flag is
+// turned off, unless the user is an admin.
+func checkFeatureFlag(ctx context.Context, featureFlagRepo FeatureFlagRepository, authInfo authorization.Info, name string) error {
+	featureFlag, err := featureFlagRepo.GetFeatureFlag(ctx, authInfo, name)
+	if err != nil {
+		return err
+	}
+
+	if featureFlag.Enabled {
+		return nil
+	}
+
+	isAdmin, err := featureFlagRepo.CanUpdateFeatureFlags(ctx, authInfo)
+	if err != nil {
+		return err
+	}
+
+	if !isAdmin {
+		return apierrors.NewFeatureDisabledError(featureFlag.Name, featureFlag.CustomErrorMessage)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FeatureFlag", func() {
+	var (
+		featureFlagRepo  *fake.FeatureFlagRepository
+		req              *http.Request
+		requestValidator *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		featureFlagRepo = new(fake.FeatureFlagRepository)
+
+		requestValidator = new(fake.RequestValidator)
+		apiHandler := NewFeatureFlag(*serverURL, featureFlagRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("the GET /v3/feature_flags endpoint", func() {
+		BeforeEach(func() {
+			featureFlagRepo.ListFeatureFlagsReturns([]repositories.FeatureFlagRecord{
+				{Name: "service_instance_creation", Enabled: true},
+				{Name: "user_org_creation", Enabled: false},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/feature_flags", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the feature flags", func() {
+			Expect(featureFlagRepo.ListFeatureFlagsCallCount()).To(Equal(1))
+			_, actualAuthInfo := featureFlagRepo.ListFeatureFlagsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/feature_flags"),
+				MatchJSONPath("$.resources", HaveLen(2)),
+				MatchJSONPath("$.resources[0].name", "service_instance_creation"),
+				MatchJSONPath("$.resources[1].enabled", BeFalse()),
+			)))
+		})
+
+		When("listing the feature flags fails", func() {
+			BeforeEach(func() {
+				featureFlagRepo.ListFeatureFlagsReturns(nil, errors.New("list-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/feature_flags/{name} endpoint", func() {
+		BeforeEach(func() {
+			featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{
+				Name:    "user_org_creation",
+				Enabled: true,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/feature_flags/user_org_creation", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the feature flag", func() {
+			Expect(featureFlagRepo.GetFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualName := featureFlagRepo.GetFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualName).To(Equal("user_org_creation"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "user_org_creation"),
+				MatchJSONPath("$.enabled", BeTrue()),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/feature_flags/user_org_creation"),
+			)))
+		})
+
+		When("the feature flag does not exist", func() {
+			BeforeEach(func() {
+				featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{}, apierrors.NewNotFoundError(nil, repositories.FeatureFlagResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.FeatureFlagResourceType)
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/feature_flags/{name} endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.FeatureFlagUpdate{
+				Enabled:            tools.PtrTo(false),
+				CustomErrorMessage: tools.PtrTo("ask your admin"),
+			})
+			featureFlagRepo.UpdateFeatureFlagReturns(repositories.FeatureFlagRecord{
+				Name:               "user_org_creation",
+				Enabled:            false,
+				CustomErrorMessage: "ask your admin",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/feature_flags/user_org_creation", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("updates the feature flag", func() {
+			Expect(featureFlagRepo.UpdateFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := featureFlagRepo.UpdateFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UpdateFeatureFlagMessage{
+				Name:               "user_org_creation",
+				Enabled:            tools.PtrTo(false),
+				CustomErrorMessage: tools.PtrTo("ask your admin"),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.enabled", BeFalse()),
+				MatchJSONPath("$.custom_error_message", "ask your admin"),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the user is not allowed to update the flag", func() {
+			BeforeEach(func() {
+				featureFlagRepo.UpdateFeatureFlagReturns(repositories.FeatureFlagRecord{}, apierrors.NewForbiddenError(nil, repositories.FeatureFlagResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("updating the flag fails", func() {
+			BeforeEach(func() {
+				featureFlagRepo.UpdateFeatureFlagReturns(repositories.FeatureFlagRecord{}, errors.New("update-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
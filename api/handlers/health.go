@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"code.cloudfoundry.org/korifi/api/routing"
+)
+
+const (
+	HealthzPath = "/healthz"
+	ReadyzPath  = "/readyz"
+)
+
+// Health serves the liveness and readiness probes. Liveness only reports that
+// the process is serving requests, while readiness is controlled by the server
+// bootstrap so that it can be withdrawn ahead of a graceful shutdown.
+type Health struct {
+	ready *atomic.Bool
+}
+
+func NewHealth(ready *atomic.Bool) *Health {
+	return &Health{
+		ready: ready,
+	}
+}
+
+func (h *Health) healthz(r *http.Request) (*routing.Response, error) {
+	return routing.NewResponse(http.StatusOK), nil
+}
+
+func (h *Health) readyz(r *http.Request) (*routing.Response, error) {
+	if !h.ready.Load() {
+		return routing.NewResponse(http.StatusServiceUnavailable), nil
+	}
+
+	return routing.NewResponse(http.StatusOK), nil
+}
+
+func (h *Health) UnauthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: HealthzPath, Handler: h.healthz},
+		{Method: "GET", Pattern: ReadyzPath, Handler: h.readyz},
+	}
+}
+
+func (h *Health) AuthenticatedRoutes() []routing.Route {
+	return nil
+}
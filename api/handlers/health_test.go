@@ -0,0 +1,72 @@
+package handlers_test
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"code.cloudfoundry.org/korifi/api/handlers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Health", func() {
+	var (
+		ready *atomic.Bool
+		req   *http.Request
+	)
+
+	BeforeEach(func() {
+		ready = &atomic.Bool{}
+		ready.Store(true)
+
+		routerBuilder.LoadRoutes(handlers.NewHealth(ready))
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /healthz", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequest("GET", "/healthz", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns 200", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		})
+
+		When("the server is not ready", func() {
+			BeforeEach(func() {
+				ready.Store(false)
+			})
+
+			It("still returns 200", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			})
+		})
+	})
+
+	Describe("GET /readyz", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequest("GET", "/readyz", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns 200", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		})
+
+		When("the server is not ready", func() {
+			BeforeEach(func() {
+				ready.Store(false)
+			})
+
+			It("returns 503", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusServiceUnavailable))
+			})
+		})
+	})
+})
@@ -14,19 +14,21 @@ const (
 )
 
 type InfoV3 struct {
-	baseURL    url.URL
-	infoConfig config.InfoConfig
+	baseURL      url.URL
+	infoConfig   config.InfoConfig
+	experimental config.Experimental
 }
 
-func NewInfoV3(baseURL url.URL, infoConfig config.InfoConfig) *InfoV3 {
+func NewInfoV3(baseURL url.URL, infoConfig config.InfoConfig, experimental config.Experimental) *InfoV3 {
 	return &InfoV3{
-		baseURL:    baseURL,
-		infoConfig: infoConfig,
+		baseURL:      baseURL,
+		infoConfig:   infoConfig,
+		experimental: experimental,
 	}
 }
 
 func (h *InfoV3) get(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForInfoV3(h.baseURL, h.infoConfig)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForInfoV3(h.baseURL, h.infoConfig, h.experimental)), nil
 }
 
 func (h *InfoV3) UnauthenticatedRoutes() []routing.Route {
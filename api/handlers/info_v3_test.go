@@ -20,6 +20,7 @@ var _ = Describe("InfoV3", func() {
 		apiHandler := handlers.NewInfoV3(
 			*serverURL,
 			infoConfig,
+			config.Experimental{},
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -2,9 +2,13 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -12,19 +16,24 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	"code.cloudfoundry.org/korifi/tools"
 
 	"github.com/go-logr/logr"
 )
 
 const (
-	LogCacheInfoPath = "/api/v1/info"
-	LogCacheReadPath = "/api/v1/read/{guid}"
-	logCacheVersion  = "2.11.4+cf-k8s"
+	LogCacheInfoPath   = "/api/v1/info"
+	LogCacheReadPath   = "/api/v1/read/{guid}"
+	LogCacheStreamPath = "/api/v1/stream/{guid}"
+	AppLogsPath        = "/v3/apps/{guid}/logs"
+	AppRecentLogsPath  = "/v3/apps/{guid}/logs/recent"
+	logCacheVersion    = "2.11.4+cf-k8s"
 )
 
 //counterfeiter:generate -o fake -fake-name LogRepository . LogRepository
 type LogRepository interface {
 	GetAppLogs(context.Context, authorization.Info, repositories.GetLogsMessage) ([]repositories.LogRecord, error)
+	StreamAppLogs(context.Context, authorization.Info, repositories.StreamLogsMessage) (<-chan repositories.LogRecord, error)
 }
 
 // LogCache implements the minimal set of log-cache API endpoints/features necessary
@@ -34,6 +43,7 @@ type LogCache struct {
 	appRepo          CFAppRepository
 	buildRepo        CFBuildRepository
 	logRepo          LogRepository
+	sourcePrefixing  bool
 }
 
 func NewLogCache(
@@ -41,12 +51,14 @@ func NewLogCache(
 	appRepo CFAppRepository,
 	buildRepository CFBuildRepository,
 	logRepo LogRepository,
+	sourcePrefixing bool,
 ) *LogCache {
 	return &LogCache{
 		requestValidator: requestValidator,
 		appRepo:          appRepo,
 		buildRepo:        buildRepository,
 		logRepo:          logRepo,
+		sourcePrefixing:  sourcePrefixing,
 	}
 }
 
@@ -73,7 +85,186 @@ func (h *LogCache) read(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app logs", "app", appGUID)
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForLogs(logs)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForLogs(logs, h.sourcePrefixing)), nil
+}
+
+// stream follows the app logs as Server-Sent Events. Each event id is the log
+// timestamp, so that clients reconnecting with the Last-Event-ID header resume
+// right after the last log line they have received.
+func (h *LogCache) stream(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.log-cache.stream")
+
+	payload := payloads.LogStream{}
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		lastTimestamp, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, apierrors.NewInvalidRequestError(err, "Last-Event-ID must be a log timestamp"), "invalid Last-Event-ID header", "lastEventID", lastEventID)
+		}
+		payload.StartTime = tools.PtrTo(lastTimestamp + 1)
+	}
+
+	appGUID := routing.URLParam(r, "guid")
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "app", appGUID)
+	}
+
+	logs, err := h.logRepo.StreamAppLogs(r.Context(), authInfo, repositories.StreamLogsMessage{
+		App:       app,
+		StartTime: payload.StartTime,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to stream app logs", "app", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Type", "text/event-stream").
+		WithHeader("Cache-Control", "no-cache").
+		WithStream(func(w io.Writer, flush func()) error {
+			for logRecord := range logs {
+				if err := writeLogEvent(w, presenter.ForLogEnvelope(logRecord, h.sourcePrefixing)); err != nil {
+					return err
+				}
+				flush()
+			}
+			return nil
+		}), nil
+}
+
+// appLogs returns the app logs, including the staging logs of its latest
+// build, as Server-Sent Events. When following, the stream goes on with the
+// live logs of the app instances (and of the build while it is staging) until
+// the client disconnects.
+func (h *LogCache) appLogs(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.log-cache.app-logs")
+
+	payload := payloads.AppLogs{}
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	appGUID := routing.URLParam(r, "guid")
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "app", appGUID)
+	}
+
+	streamStartTime := time.Now().UnixNano()
+	recentLogs := []repositories.LogRecord{}
+	var stagingBuild *repositories.BuildRecord
+
+	build, err := h.buildRepo.GetLatestBuildByAppGUID(r.Context(), authInfo, app.SpaceGUID, app.GUID)
+	if err != nil {
+		if !errors.As(err, new(apierrors.NotFoundError)) {
+			return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get latest app build", "app", appGUID)
+		}
+	} else {
+		recentLogs, err = h.logRepo.GetAppLogs(r.Context(), authInfo, repositories.GetLogsMessage{
+			App:   app,
+			Build: build,
+		})
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to get app logs", "app", appGUID, "build", build.GUID)
+		}
+
+		if build.State == repositories.BuildStateStaging {
+			stagingBuild = &build
+		}
+	}
+
+	var liveLogs <-chan repositories.LogRecord
+	if payload.Follow {
+		if len(recentLogs) > 0 {
+			streamStartTime = recentLogs[len(recentLogs)-1].Timestamp + 1
+		}
+
+		liveLogs, err = h.logRepo.StreamAppLogs(r.Context(), authInfo, repositories.StreamLogsMessage{
+			App:       app,
+			Build:     stagingBuild,
+			StartTime: tools.PtrTo(streamStartTime),
+		})
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to stream app logs", "app", appGUID)
+		}
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Type", "text/event-stream").
+		WithHeader("Cache-Control", "no-cache").
+		WithStream(func(w io.Writer, flush func()) error {
+			for _, logRecord := range recentLogs {
+				if err := writeAppLogEvent(w, logRecord); err != nil {
+					return err
+				}
+			}
+			flush()
+
+			if liveLogs == nil {
+				return nil
+			}
+
+			for logRecord := range liveLogs {
+				if err := writeAppLogEvent(w, logRecord); err != nil {
+					return err
+				}
+				flush()
+			}
+			return nil
+		}), nil
+}
+
+func (h *LogCache) recentAppLogs(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.log-cache.recent-app-logs")
+
+	payload := payloads.AppRecentLogs{}
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	appGUID := routing.URLParam(r, "guid")
+
+	// fetch the logs newest first so that the limit keeps the latest lines
+	logs, err := h.getAppLogs(r.Context(), logger, authInfo, appGUID, payloads.LogRead{
+		Limit:      tools.PtrTo(payload.LimitNumber()),
+		Descending: true,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app logs", "app", appGUID)
+	}
+
+	appLogs := make([]presenter.AppLogResponse, 0, len(logs))
+	for i := len(logs) - 1; i >= 0; i-- {
+		appLogs = append(appLogs, presenter.ForAppLog(logs[i]))
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(appLogs), nil
+}
+
+func writeAppLogEvent(w io.Writer, logRecord repositories.LogRecord) error {
+	data, err := json.Marshal(presenter.ForAppLog(logRecord))
+	if err != nil {
+		return fmt.Errorf("failed to marshal app log: %w", err)
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", logRecord.Timestamp, data)
+	return err
+}
+
+func writeLogEvent(w io.Writer, envelope presenter.LogCacheReadResponseBatch) error {
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log envelope: %w", err)
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", envelope.Timestamp, data)
+	return err
 }
 
 func (h *LogCache) getAppLogs(ctx context.Context, logger logr.Logger, authInfo authorization.Info, appGUID string, payload payloads.LogRead) ([]repositories.LogRecord, error) {
@@ -113,5 +304,8 @@ func (h *LogCache) UnauthenticatedRoutes() []routing.Route {
 func (h *LogCache) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: LogCacheReadPath, Handler: h.read},
+		{Method: "GET", Pattern: LogCacheStreamPath, Handler: h.stream},
+		{Method: "GET", Pattern: AppLogsPath, Handler: h.appLogs},
+		{Method: "GET", Pattern: AppRecentLogsPath, Handler: h.recentAppLogs},
 	}
 }
@@ -1,9 +1,11 @@
 package handlers_test
 
 import (
+	"bufio"
 	"encoding/base64"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
@@ -47,6 +49,7 @@ var _ = Describe("LogCache", func() {
 			appRepo,
 			buildRepo,
 			logRepo,
+			true,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -198,4 +201,378 @@ var _ = Describe("LogCache", func() {
 			)))
 		})
 	})
+
+	Describe("GET /api/v1/stream/<app-guid>", func() {
+		var (
+			payload *payloads.LogStream
+			logs    chan repositories.LogRecord
+		)
+
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/api/v1/stream/app-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			payload = &payloads.LogStream{
+				StartTime: tools.PtrTo[int64](12345),
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			logs = make(chan repositories.LogRecord, 2)
+			logs <- repositories.LogRecord{Timestamp: 1, Message: "log1"}
+			logs <- repositories.LogRecord{Timestamp: 2, Message: "log2"}
+			close(logs)
+			logRepo.StreamAppLogsReturns(logs, nil)
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+			_, actualPayload := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+			Expect(actualPayload).To(Equal(payload))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid-payload"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("invalid-payload")
+			})
+		})
+
+		It("gets the app", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		It("streams the app logs", func() {
+			Expect(logRepo.StreamAppLogsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := logRepo.StreamAppLogsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(MatchAllFields(Fields{
+				"App": MatchFields(IgnoreExtras, Fields{
+					"GUID": Equal("app-guid"),
+				}),
+				"Build":     BeNil(),
+				"StartTime": PointTo(BeEquivalentTo(12345)),
+			}))
+		})
+
+		When("the request has a Last-Event-ID header", func() {
+			BeforeEach(func() {
+				req.Header.Set("Last-Event-ID", "555")
+			})
+
+			It("resumes the stream after the last event", func() {
+				Expect(logRepo.StreamAppLogsCallCount()).To(Equal(1))
+				_, _, actualMessage := logRepo.StreamAppLogsArgsForCall(0)
+				Expect(actualMessage.StartTime).To(PointTo(BeEquivalentTo(556)))
+			})
+		})
+
+		When("the Last-Event-ID header is not a timestamp", func() {
+			BeforeEach(func() {
+				req.Header.Set("Last-Event-ID", "foo")
+			})
+
+			It("returns an error", func() {
+				expectErrorResponse(http.StatusBadRequest, "CF-InvalidRequest", "Last-Event-ID must be a log timestamp", 10004)
+			})
+		})
+
+		When("streaming the logs fails", func() {
+			BeforeEach(func() {
+				logRepo.StreamAppLogsReturns(nil, errors.New("stream-logs-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		It("returns the app logs as server-sent events", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "text/event-stream"))
+			Expect(rr).To(HaveHTTPBody(
+				`id: 1` + "\n" + `data: {"timestamp":1,"log":{"payload":"bG9nMQ==","type":0}}` + "\n\n" +
+					`id: 2` + "\n" + `data: {"timestamp":2,"log":{"payload":"bG9nMg==","type":0}}` + "\n\n",
+			))
+		})
+
+		When("a client is connected", func() {
+			var (
+				server *httptest.Server
+				events *bufio.Reader
+			)
+
+			JustBeforeEach(func() {
+				logs = make(chan repositories.LogRecord)
+				logRepo.StreamAppLogsReturns(logs, nil)
+
+				server = httptest.NewServer(routerBuilder.Build())
+				DeferCleanup(server.Close)
+
+				resp, err := http.Get(server.URL + "/api/v1/stream/app-guid")
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(resp.Body.Close)
+				DeferCleanup(func() { close(logs) })
+
+				Expect(resp).To(HaveHTTPStatus(http.StatusOK))
+				events = bufio.NewReader(resp.Body)
+			})
+
+			It("pushes log lines to the client as they are produced", func() {
+				logs <- repositories.LogRecord{Timestamp: 3, Message: "log3"}
+				Expect(events.ReadString('\n')).To(Equal("id: 3\n"))
+				Expect(events.ReadString('\n')).To(ContainSubstring(`"payload":"bG9nMw=="`))
+
+				logs <- repositories.LogRecord{Timestamp: 4, Message: "log4"}
+				Expect(events.ReadString('\n')).To(Equal("\n"))
+				Expect(events.ReadString('\n')).To(Equal("id: 4\n"))
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/<app-guid>/logs", func() {
+		var (
+			payload *payloads.AppLogs
+			logs    chan repositories.LogRecord
+		)
+
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/app-guid/logs", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			payload = &payloads.AppLogs{}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			logRepo.GetAppLogsReturns([]repositories.LogRecord{
+				{Timestamp: 1, Message: "staging", Tags: map[string]string{"source_type": "STG"}},
+				{Timestamp: 2, Message: "app", Tags: map[string]string{"source_type": "APP"}, ProcessType: "web", InstanceID: "1"},
+			}, nil)
+
+			logs = make(chan repositories.LogRecord, 1)
+			logs <- repositories.LogRecord{Timestamp: 3, Message: "live", Tags: map[string]string{"source_type": "APP"}, ProcessType: "web", InstanceID: "0"}
+			close(logs)
+			logRepo.StreamAppLogsReturns(logs, nil)
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid-payload"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("invalid-payload")
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		It("gets the app and latest build logs", func() {
+			Expect(logRepo.GetAppLogsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := logRepo.GetAppLogsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage.App.GUID).To(Equal("app-guid"))
+			Expect(actualMessage.Build.GUID).To(Equal("build-guid"))
+		})
+
+		It("returns the recent logs as server-sent events", func() {
+			Expect(logRepo.StreamAppLogsCallCount()).To(BeZero())
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "text/event-stream"))
+			Expect(rr).To(HaveHTTPBody(
+				`id: 1` + "\n" + `data: {"timestamp":"1970-01-01T00:00:00.000000001Z","source":"STG","message":"staging"}` + "\n\n" +
+					`id: 2` + "\n" + `data: {"timestamp":"1970-01-01T00:00:00.000000002Z","source":"APP","process_type":"web","instance":1,"message":"app"}` + "\n\n",
+			))
+		})
+
+		When("the app has no build", func() {
+			BeforeEach(func() {
+				buildRepo.GetLatestBuildByAppGUIDReturns(repositories.BuildRecord{}, apierrors.NewNotFoundError(nil, repositories.BuildResourceType))
+			})
+
+			It("returns no logs", func() {
+				Expect(logRepo.GetAppLogsCallCount()).To(BeZero())
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(BeEmpty()))
+			})
+		})
+
+		When("there is an error fetching the build", func() {
+			BeforeEach(func() {
+				buildRepo.GetLatestBuildByAppGUIDReturns(repositories.BuildRecord{}, errors.New("get-build-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("there is an error fetching the logs", func() {
+			BeforeEach(func() {
+				logRepo.GetAppLogsReturns(nil, errors.New("get-logs-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("following the logs", func() {
+			BeforeEach(func() {
+				payload.Follow = true
+			})
+
+			It("streams the live logs after the recent ones", func() {
+				Expect(logRepo.StreamAppLogsCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualMessage := logRepo.StreamAppLogsArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualMessage.App.GUID).To(Equal("app-guid"))
+				Expect(actualMessage.Build).To(BeNil())
+				Expect(actualMessage.StartTime).To(PointTo(BeEquivalentTo(3)))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					ContainSubstring(`"message":"app"`),
+					HaveSuffix(`id: 3`+"\n"+`data: {"timestamp":"1970-01-01T00:00:00.000000003Z","source":"APP","process_type":"web","instance":0,"message":"live"}`+"\n\n"),
+				)))
+			})
+
+			When("the latest build is staging", func() {
+				BeforeEach(func() {
+					buildRepo.GetLatestBuildByAppGUIDReturns(repositories.BuildRecord{
+						GUID:  "build-guid",
+						State: repositories.BuildStateStaging,
+					}, nil)
+				})
+
+				It("follows the staging logs too", func() {
+					Expect(logRepo.StreamAppLogsCallCount()).To(Equal(1))
+					_, _, actualMessage := logRepo.StreamAppLogsArgsForCall(0)
+					Expect(actualMessage.Build).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal("build-guid"),
+					})))
+				})
+			})
+
+			When("streaming the logs fails", func() {
+				BeforeEach(func() {
+					logRepo.StreamAppLogsReturns(nil, errors.New("stream-logs-error"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/<app-guid>/logs/recent", func() {
+		var payload *payloads.AppRecentLogs
+
+		BeforeEach(func() {
+			req = createHttpRequest("GET", "/v3/apps/app-guid/logs/recent", nil)
+
+			payload = &payloads.AppRecentLogs{Limit: "2"}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			logRepo.GetAppLogsReturns([]repositories.LogRecord{
+				{Timestamp: 2, Message: "newer", Tags: map[string]string{"source_type": "APP"}, ProcessType: "web", InstanceID: "1"},
+				{Timestamp: 1, Message: "older", Tags: map[string]string{"source_type": "STG"}},
+			}, nil)
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid-payload"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("invalid-payload")
+			})
+		})
+
+		It("gets the latest logs", func() {
+			Expect(logRepo.GetAppLogsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := logRepo.GetAppLogsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage.App.GUID).To(Equal("app-guid"))
+			Expect(actualMessage.Build.GUID).To(Equal("build-guid"))
+			Expect(actualMessage.Limit).To(PointTo(BeEquivalentTo(2)))
+			Expect(actualMessage.Descending).To(BeTrue())
+		})
+
+		It("returns the logs oldest first", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`[
+				{
+					"timestamp": "1970-01-01T00:00:00.000000001Z",
+					"source": "STG",
+					"message": "older"
+				},
+				{
+					"timestamp": "1970-01-01T00:00:00.000000002Z",
+					"source": "APP",
+					"process_type": "web",
+					"instance": 1,
+					"message": "newer"
+				}
+			]`)))
+		})
+
+		When("the app has no build", func() {
+			BeforeEach(func() {
+				buildRepo.GetLatestBuildByAppGUIDReturns(repositories.BuildRecord{}, apierrors.NewNotFoundError(nil, repositories.BuildResourceType))
+			})
+
+			It("returns an empty list", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`[]`)))
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		When("there is an error fetching the logs", func() {
+			BeforeEach(func() {
+				logRepo.GetAppLogsReturns(nil, errors.New("get-logs-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -40,17 +41,21 @@ type CFOrgRepository interface {
 type Org struct {
 	apiBaseURL                               url.URL
 	orgRepo                                  CFOrgRepository
+	spaceRepo                                CFSpaceRepository
 	domainRepo                               CFDomainRepository
+	featureFlagRepo                          FeatureFlagRepository
 	requestValidator                         RequestValidator
 	userCertificateExpirationWarningDuration time.Duration
 	defaultDomainName                        string
 }
 
-func NewOrg(apiBaseURL url.URL, orgRepo CFOrgRepository, domainRepo CFDomainRepository, requestValidator RequestValidator, userCertificateExpirationWarningDuration time.Duration, defaultDomainName string) *Org {
+func NewOrg(apiBaseURL url.URL, orgRepo CFOrgRepository, spaceRepo CFSpaceRepository, domainRepo CFDomainRepository, featureFlagRepo FeatureFlagRepository, requestValidator RequestValidator, userCertificateExpirationWarningDuration time.Duration, defaultDomainName string) *Org {
 	return &Org{
 		apiBaseURL:                               apiBaseURL,
 		orgRepo:                                  orgRepo,
+		spaceRepo:                                spaceRepo,
 		domainRepo:                               domainRepo,
+		featureFlagRepo:                          featureFlagRepo,
 		requestValidator:                         requestValidator,
 		userCertificateExpirationWarningDuration: userCertificateExpirationWarningDuration,
 		defaultDomainName:                        defaultDomainName,
@@ -66,6 +71,10 @@ func (h *Org) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "invalid-payload-for-create-org")
 	}
 
+	if err := checkFeatureFlag(r.Context(), h.featureFlagRepo, authInfo, repositories.UserOrgCreationFeatureFlag); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Org creation is not allowed")
+	}
+
 	org := payload.ToMessage()
 	record, err := h.orgRepo.CreateOrg(r.Context(), authInfo, org)
 	if err != nil {
@@ -105,10 +114,43 @@ func (h *Org) delete(r *http.Request) (*routing.Response, error) {
 
 	orgGUID := routing.URLParam(r, "guid")
 
+	payload := new(payloads.OrgDelete)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	org, err := h.orgRepo.GetOrg(r.Context(), authInfo, orgGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch org", "OrgGUID", orgGUID)
+	}
+
+	if isDeletionProtected(org.Annotations) && !payload.OverrideProtection {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "The organization is protected from deletion. Retry with override_protection=true to delete it anyway."),
+			"Org is protected from deletion", "OrgGUID", orgGUID,
+		)
+	}
+
+	if !payload.OverrideProtection {
+		protectedSpaces, err := deletionProtectedSpaces(r.Context(), h.spaceRepo, authInfo, orgGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to list org spaces", "OrgGUID", orgGUID)
+		}
+
+		if len(protectedSpaces) > 0 {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("The organization contains spaces protected from deletion: %s. Retry with override_protection=true to delete it anyway.", strings.Join(protectedSpaces, ", "))),
+				"Org contains protected spaces", "OrgGUID", orgGUID,
+			)
+		}
+	}
+
 	deleteOrgMessage := repositories.DeleteOrgMessage{
 		GUID: orgGUID,
 	}
-	err := h.orgRepo.DeleteOrg(r.Context(), authInfo, deleteOrgMessage)
+	err = h.orgRepo.DeleteOrg(r.Context(), authInfo, deleteOrgMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to delete org", "OrgGUID", orgGUID)
 	}
@@ -131,7 +173,7 @@ func (h *Org) list(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to fetch orgs")
 	}
 
-	resp := routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForOrg, orgs, h.apiBaseURL, *r.URL))
+	resp := routing.NewResponse(http.StatusOK).WithBody(presenter.ForPaginatedList(presenter.ForOrg, orgs, h.apiBaseURL, *r.URL, listFilter.PageNumber(), listFilter.PerPageNumber()))
 	notAfter, certParsed := decodePEMNotAfter(authInfo.CertData)
 
 	if !isExpirationValid(notAfter, h.userCertificateExpirationWarningDuration, certParsed) {
@@ -156,7 +198,10 @@ func (h *Org) listDomains(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Unable to parse request query parameters")
 	}
 
-	domainList, err := h.domainRepo.ListDomains(r.Context(), authInfo, domainListFilter.ToMessage())
+	domainListMessage := domainListFilter.ToMessage()
+	domainListMessage.OrgGUID = orgGUID
+
+	domainList, err := h.domainRepo.ListDomains(r.Context(), authInfo, domainListMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch domain(s) from Kubernetes")
 	}
@@ -200,7 +245,7 @@ func (h *Org) get(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get org", "OrgGUID", orgGUID)
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrg(org, h.apiBaseURL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrg(org, h.apiBaseURL)).WithETag(org.ResourceVersion), nil
 }
 
 func (h *Org) UnauthenticatedRoutes() []routing.Route {
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
@@ -23,8 +24,10 @@ var _ = Describe("Org", func() {
 	var (
 		apiHandler       *handlers.Org
 		orgRepo          *fake.CFOrgRepository
+		spaceRepo        *fake.CFSpaceRepository
 		now              time.Time
 		domainRepo       *fake.CFDomainRepository
+		featureFlagRepo  *fake.FeatureFlagRepository
 		requestValidator *fake.RequestValidator
 	)
 
@@ -32,10 +35,13 @@ var _ = Describe("Org", func() {
 		now = time.Unix(1631892190, 0) // 2021-09-17T15:23:10Z
 
 		orgRepo = new(fake.CFOrgRepository)
+		spaceRepo = new(fake.CFSpaceRepository)
 		domainRepo = new(fake.CFDomainRepository)
+		featureFlagRepo = new(fake.FeatureFlagRepository)
+		featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{Enabled: true}, nil)
 		requestValidator = new(fake.RequestValidator)
 
-		apiHandler = handlers.NewOrg(*serverURL, orgRepo, domainRepo, requestValidator, time.Hour, "the-default.domain")
+		apiHandler = handlers.NewOrg(*serverURL, orgRepo, spaceRepo, domainRepo, featureFlagRepo, requestValidator, time.Hour, "the-default.domain")
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -96,6 +102,68 @@ var _ = Describe("Org", func() {
 			)))
 		})
 
+		It("checks the user_org_creation feature flag", func() {
+			Expect(featureFlagRepo.GetFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualName := featureFlagRepo.GetFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualName).To(Equal(repositories.UserOrgCreationFeatureFlag))
+		})
+
+		When("org creation is disabled", func() {
+			BeforeEach(func() {
+				featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{
+					Name:               repositories.UserOrgCreationFeatureFlag,
+					Enabled:            false,
+					CustomErrorMessage: "ask your admin",
+				}, nil)
+			})
+
+			It("returns a feature disabled error", func() {
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: ask your admin", 330002)
+			})
+
+			It("does not create the org", func() {
+				Expect(orgRepo.CreateOrgCallCount()).To(BeZero())
+			})
+
+			It("checks whether the user is an admin", func() {
+				Expect(featureFlagRepo.CanUpdateFeatureFlagsCallCount()).To(Equal(1))
+				_, actualAuthInfo := featureFlagRepo.CanUpdateFeatureFlagsArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+			})
+
+			When("the user is an admin", func() {
+				BeforeEach(func() {
+					featureFlagRepo.CanUpdateFeatureFlagsReturns(true, nil)
+				})
+
+				It("creates the org", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+					Expect(orgRepo.CreateOrgCallCount()).To(Equal(1))
+				})
+			})
+
+			When("checking whether the user is an admin fails", func() {
+				BeforeEach(func() {
+					featureFlagRepo.CanUpdateFeatureFlagsReturns(false, errors.New("boom"))
+				})
+
+				It("returns unknown error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
+		When("getting the feature flag fails", func() {
+			BeforeEach(func() {
+				featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{}, errors.New("boom"))
+			})
+
+			It("returns unknown error", func() {
+				expectUnknownError()
+			})
+		})
+
 		When("the org repo returns an error", func() {
 			BeforeEach(func() {
 				orgRepo.CreateOrgReturns(repositories.OrgRecord{}, errors.New("boom"))
@@ -157,7 +225,8 @@ var _ = Describe("Org", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/organizations?names=a,b"),
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/organizations?names=a%2Cb&page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", "a-l-i-c-e"),
 				MatchJSONPath("$.resources[0].links.self.href", "https://api.example.org/v3/organizations/a-l-i-c-e"),
@@ -182,6 +251,57 @@ var _ = Describe("Org", func() {
 			})
 		})
 
+		When("guids are specified", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(
+					&payloads.OrgList{
+						GUIDs: "g1,g2",
+					},
+				)
+			})
+
+			It("filters by them", func() {
+				Expect(orgRepo.ListOrgsCallCount()).To(Equal(1))
+				_, _, message := orgRepo.ListOrgsArgsForCall(0)
+				Expect(message.GUIDs).To(ConsistOf("g1", "g2"))
+			})
+		})
+
+		When("order_by is specified", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(
+					&payloads.OrgList{
+						OrderBy: "-name",
+					},
+				)
+			})
+
+			It("orders by it", func() {
+				Expect(orgRepo.ListOrgsCallCount()).To(Equal(1))
+				_, _, message := orgRepo.ListOrgsArgsForCall(0)
+				Expect(message.OrderBy).To(Equal("-name"))
+			})
+		})
+
+		When("a page is requested", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(
+					&payloads.OrgList{
+						Pagination: payloads.Pagination{Page: "2", PerPage: "1"},
+					},
+				)
+			})
+
+			It("returns the requested page", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+					MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+					MatchJSONPath("$.resources", HaveLen(1)),
+					MatchJSONPath("$.resources[0].guid", "b-o-b"),
+				)))
+			})
+		})
+
 		When("fetching the orgs fails", func() {
 			BeforeEach(func() {
 				orgRepo.ListOrgsReturns(nil, errors.New("boom!"))
@@ -332,6 +452,127 @@ var _ = Describe("Org", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/org.delete~org-guid"))
 		})
 
+		It("fetches the org", func() {
+			Expect(orgRepo.GetOrgCallCount()).To(Equal(1))
+			_, info, actualOrgGUID := orgRepo.GetOrgArgsForCall(0)
+			Expect(info).To(Equal(authInfo))
+			Expect(actualOrgGUID).To(Equal("org-guid"))
+		})
+
+		When("the query parameters are invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+
+			It("does not delete the org", func() {
+				Expect(orgRepo.DeleteOrgCallCount()).To(Equal(0))
+			})
+		})
+
+		When("fetching the org is forbidden", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewForbiddenError(errors.New("boom"), repositories.OrgResourceType))
+			})
+
+			It("returns NotFound error", func() {
+				expectNotFoundError(repositories.OrgResourceType)
+			})
+		})
+
+		When("the org is protected from deletion", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{
+					GUID: "org-guid",
+					Annotations: map[string]string{
+						korifiv1alpha1.DeletionProtectedAnnotation: "true",
+					},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("The organization is protected from deletion. Retry with override_protection=true to delete it anyway.")
+			})
+
+			It("does not delete the org", func() {
+				Expect(orgRepo.DeleteOrgCallCount()).To(Equal(0))
+			})
+
+			When("the protection is overridden", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.OrgDelete{
+						OverrideProtection: true,
+					})
+				})
+
+				It("deletes the org", func() {
+					Expect(orgRepo.DeleteOrgCallCount()).To(Equal(1))
+					Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				})
+			})
+		})
+
+		It("lists the org spaces", func() {
+			Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+			_, info, message := spaceRepo.ListSpacesArgsForCall(0)
+			Expect(info).To(Equal(authInfo))
+			Expect(message.OrganizationGUIDs).To(ConsistOf("org-guid"))
+		})
+
+		When("the org contains spaces protected from deletion", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{
+					{
+						Name: "unprotected-space",
+					},
+					{
+						Name: "protected-space",
+						Annotations: map[string]string{
+							korifiv1alpha1.DeletionProtectedAnnotation: "true",
+						},
+					},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("The organization contains spaces protected from deletion: protected-space. Retry with override_protection=true to delete it anyway.")
+			})
+
+			It("does not delete the org", func() {
+				Expect(orgRepo.DeleteOrgCallCount()).To(Equal(0))
+			})
+
+			When("the protection is overridden", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.OrgDelete{
+						OverrideProtection: true,
+					})
+				})
+
+				It("deletes the org", func() {
+					Expect(orgRepo.DeleteOrgCallCount()).To(Equal(1))
+					Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				})
+			})
+		})
+
+		When("listing the org spaces fails", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns(nil, errors.New("list-spaces-err"))
+			})
+
+			It("returns unknown error", func() {
+				expectUnknownError()
+			})
+
+			It("does not delete the org", func() {
+				Expect(orgRepo.DeleteOrgCallCount()).To(Equal(0))
+			})
+		})
+
 		When("invoking the delete org repository yields a forbidden error", func() {
 			BeforeEach(func() {
 				orgRepo.DeleteOrgReturns(apierrors.NewForbiddenError(errors.New("boom"), repositories.OrgResourceType))
@@ -381,6 +622,10 @@ var _ = Describe("Org", func() {
 			actualReq, _ := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
 			Expect(actualReq.URL.String()).To(HaveSuffix(requestURL))
 
+			Expect(domainRepo.ListDomainsCallCount()).To(Equal(1))
+			_, _, actualMessage := domainRepo.ListDomainsArgsForCall(0)
+			Expect(actualMessage.OrgGUID).To(Equal("org-guid"))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
@@ -510,8 +755,9 @@ var _ = Describe("Org", func() {
 	Describe("get an org", func() {
 		BeforeEach(func() {
 			orgRepo.GetOrgReturns(repositories.OrgRecord{
-				Name: "org-name",
-				GUID: "org-guid",
+				Name:            "org-name",
+				GUID:            "org-guid",
+				ResourceVersion: "7",
 			}, nil)
 		})
 
@@ -529,6 +775,7 @@ var _ = Describe("Org", func() {
 
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("ETag", `W/"7"`))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.guid", "org-guid"),
 				MatchJSONPath("$.name", "org-name"),
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"archive/zip"
 	"context"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
@@ -38,6 +40,7 @@ type CFPackageRepository interface {
 
 type ImageRepository interface {
 	UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	DownloadDroplet(ctx context.Context, authInfo authorization.Info, droplet repositories.DropletRecord) (io.ReadCloser, error)
 }
 
 type Package struct {
@@ -80,7 +83,7 @@ func (h Package) get(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching package with repository")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPackage(record, h.serverURL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPackage(record, h.serverURL)).WithETag(record.ResourceVersion), nil
 }
 
 //nolint:dupl
@@ -99,13 +102,17 @@ func (h Package) list(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Error fetching package with repository")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForPackage, records, h.serverURL, *r.URL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPaginatedList(presenter.ForPackage, records, h.serverURL, *r.URL, payload.PageNumber(), payload.PerPageNumber())), nil
 }
 
 func (h Package) create(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.create")
 
+	if sourceGUID := r.URL.Query().Get("source_guid"); sourceGUID != "" {
+		return h.copy(r, sourceGUID)
+	}
+
 	var payload payloads.PackageCreate
 	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
@@ -126,6 +133,10 @@ func (h Package) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
+	if err = validatePackageType(payload.Type, appRecord); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "package type does not match the app lifecycle")
+	}
+
 	record, err := h.packageRepo.CreatePackage(r.Context(), authInfo, payload.ToMessage(appRecord))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error creating package with repository")
@@ -134,6 +145,82 @@ func (h Package) create(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForPackage(record, h.serverURL)), nil
 }
 
+// validatePackageType checks that the app can stage packages of the given
+// type: buildpack apps stage uploaded bits while docker apps run the
+// referenced image as is
+func validatePackageType(packageType string, appRecord repositories.AppRecord) error {
+	lifecycleType := repositories.PackageTypeToLifecycleType[korifiv1alpha1.PackageType(packageType)]
+	if lifecycleType != korifiv1alpha1.LifecycleType(appRecord.Lifecycle.Type) {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Cannot create a %s package for a %s app.", packageType, appRecord.Lifecycle.Type))
+	}
+
+	return nil
+}
+
+// copy creates a package on the target app with the type and metadata of the
+// source package. The package controller copies the source onto the new
+// package, which is COPYING until that is done.
+func (h Package) copy(r *http.Request, sourceGUID string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.copy")
+
+	var payload payloads.PackageCopy
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	sourceRecord, err := h.packageRepo.GetPackage(r.Context(), authInfo, sourceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"Source package is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding source package",
+			"Source package GUID", sourceGUID,
+		)
+	}
+
+	if sourceRecord.State != repositories.PackageStateReady {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Source package must be in the READY state to be copied."),
+			"source package is not ready",
+			"Source package GUID", sourceGUID,
+			"state", sourceRecord.State,
+		)
+	}
+
+	appRecord, err := h.appRepo.GetApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"App is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding App",
+			"App GUID", payload.Relationships.App.Data.GUID,
+		)
+	}
+
+	if err = validatePackageType(sourceRecord.Type, appRecord); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "source package type does not match the app lifecycle")
+	}
+
+	record, err := h.packageRepo.CreatePackage(r.Context(), authInfo, payload.ToMessage(appRecord, sourceRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating package with repository")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForPackage(record, h.serverURL)), nil
+}
+
 func (h Package) update(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.update")
@@ -162,7 +249,7 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewInvalidRequestError(err, "Unable to parse body as multipart form"), "Error parsing multipart form")
 	}
 
-	bitsFile, _, err := r.FormFile("bits")
+	bitsFile, bitsHeader, err := r.FormFile("bits")
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "Upload must include bits"), "Error reading form file \"bits\"")
 	}
@@ -185,6 +272,10 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewPackageBitsAlreadyUploadedError(err), "Error, cannot call package upload state was not AWAITING_UPLOAD", "packageGUID", packageGUID)
 	}
 
+	if err = validateZip(bitsFile, bitsHeader.Size); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "Bits must be a valid zip archive."), "Error validating bits", "packageGUID", packageGUID)
+	}
+
 	uploadedImageRef, err := h.imageRepo.UploadSourceImage(r.Context(), authInfo, packageRecord.ImageRef, bitsFile, packageRecord.SpaceGUID, packageGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error calling uploadSourceImage")
@@ -203,6 +294,35 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPackage(packageRecord, h.serverURL)), nil
 }
 
+// validateZip reads every entry of the archive so that truncated or corrupted
+// archives are detected by their checksums. Entries are streamed rather than
+// loaded in memory as packages can be large.
+func validateZip(bits io.ReaderAt, size int64) error {
+	zipReader, err := zip.NewReader(bits, size)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range zipReader.File {
+		if err = validateZipEntry(file); err != nil {
+			return fmt.Errorf("invalid zip entry %q: %w", file.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateZipEntry(file *zip.File) error {
+	entry, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer entry.Close()
+
+	_, err = io.Copy(io.Discard, entry)
+	return err
+}
+
 func (h Package) listDroplets(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.list-droplets")
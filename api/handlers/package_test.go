@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"archive/zip"
 	"bytes"
 	"errors"
 	"fmt"
@@ -82,6 +83,7 @@ var _ = Describe("Package", func() {
 				Annotations: map[string]string{
 					"baz": "fof",
 				},
+				ResourceVersion: "7",
 			}, nil)
 		})
 
@@ -99,6 +101,7 @@ var _ = Describe("Package", func() {
 
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("ETag", `W/"7"`))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.guid", packageGUID),
 				MatchJSONPath("$.state", "AWAITING_UPLOAD"),
@@ -172,7 +175,7 @@ var _ = Describe("Package", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/packages?foo=bar"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/packages?foo=bar&page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", packageGUID),
 				MatchJSONPath("$.resources[0].state", Equal("AWAITING_UPLOAD")),
@@ -237,6 +240,39 @@ var _ = Describe("Package", func() {
 			})
 		})
 
+		When("the 'types' parameter is sent", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(
+					&payloads.PackageList{
+						Types: "docker",
+					},
+				)
+			})
+
+			It("calls repository ListPackage with the correct message object", func() {
+				_, _, message := packageRepo.ListPackagesArgsForCall(0)
+				Expect(message).To(Equal(repositories.ListPackagesMessage{
+					Types: []string{"docker"},
+				}))
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			})
+		})
+
+		When("a page is requested", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.PackageList{
+					Pagination: payloads.Pagination{Page: "2", PerPage: "1"},
+				})
+			})
+
+			It("returns the requested page", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+					MatchJSONPath("$.resources", HaveLen(1)),
+				)))
+			})
+		})
+
 		When("request is invalid", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("foo"))
@@ -314,6 +350,7 @@ var _ = Describe("Package", func() {
 				SpaceGUID: spaceGUID,
 				GUID:      appGUID,
 				EtcdUID:   appUID,
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
 			}, nil)
 		})
 
@@ -398,6 +435,270 @@ var _ = Describe("Package", func() {
 			itDoesntCreateAPackage()
 		})
 
+		When("the package type matches the app lifecycle", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{
+					SpaceGUID: spaceGUID,
+					GUID:      appGUID,
+					Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				}, nil)
+			})
+
+			It("creates the package", func() {
+				Expect(packageRepo.CreatePackageCallCount()).To(Equal(1))
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+		})
+
+		When("creating a bits package for a docker app", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{
+					SpaceGUID: spaceGUID,
+					GUID:      appGUID,
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot create a bits package for a docker app.")
+			})
+
+			itDoesntCreateAPackage()
+		})
+
+		When("creating a docker package for a buildpack app", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.PackageCreate{
+					Type: "docker",
+					Relationships: &payloads.PackageRelationships{
+						App: &payloads.Relationship{
+							Data: &payloads.RelationshipData{
+								GUID: appGUID,
+							},
+						},
+					},
+					Data: &payloads.PackageData{Image: "some/image"},
+				})
+				appRepo.GetAppReturns(repositories.AppRecord{
+					SpaceGUID: spaceGUID,
+					GUID:      appGUID,
+					Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot create a docker package for a buildpack app.")
+			})
+
+			itDoesntCreateAPackage()
+		})
+
+		When("the request JSON is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "test-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("test-error")
+			})
+		})
+
+		When("creating the package in the repo errors", func() {
+			BeforeEach(func() {
+				packageRepo.CreatePackageReturns(repositories.PackageRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the POST /v3/packages?source_guid=:guid endpoint", func() {
+		var sourcePackageGUID string
+
+		BeforeEach(func() {
+			sourcePackageGUID = generateGUID("source-package")
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.PackageCopy{
+				Relationships: &payloads.PackageRelationships{
+					App: &payloads.Relationship{
+						Data: &payloads.RelationshipData{
+							GUID: appGUID,
+						},
+					},
+				},
+			})
+
+			packageRepo.GetPackageReturns(repositories.PackageRecord{
+				Type:        "bits",
+				GUID:        sourcePackageGUID,
+				SpaceGUID:   "source-space-guid",
+				State:       "READY",
+				Labels:      map[string]string{"bob": "foo"},
+				Annotations: map[string]string{"jim": "foo"},
+				ImageRef:    "source-app-packages",
+			}, nil)
+
+			appRepo.GetAppReturns(repositories.AppRecord{
+				SpaceGUID: spaceGUID,
+				GUID:      appGUID,
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+			}, nil)
+
+			packageRepo.CreatePackageReturns(repositories.PackageRecord{
+				Type:      "bits",
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+				GUID:      packageGUID,
+				State:     "COPYING",
+				ImageRef:  "target-app-packages",
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "POST", "/v3/packages?source_guid="+sourcePackageGUID, strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("creates a copy of the source package on the target app", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(packageRepo.GetPackageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSourceGUID := packageRepo.GetPackageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSourceGUID).To(Equal(sourcePackageGUID))
+
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(packageRepo.CreatePackageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualCreate := packageRepo.CreatePackageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualCreate).To(Equal(repositories.CreatePackageMessage{
+				Type:      "bits",
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+				Metadata: repositories.Metadata{
+					Labels:      map[string]string{"bob": "foo"},
+					Annotations: map[string]string{"jim": "foo"},
+				},
+				SourcePackageGUID:      sourcePackageGUID,
+				SourcePackageSpaceGUID: "source-space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", packageGUID),
+				MatchJSONPath("$.state", "COPYING"),
+			)))
+		})
+
+		It("leaves copying the bits to the package controller", func() {
+			Expect(packageRepo.UpdatePackageSourceCallCount()).To(BeZero())
+		})
+
+		When("the source package is a docker package", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					Type:     "docker",
+					GUID:     sourcePackageGUID,
+					State:    "READY",
+					ImageRef: "some/image",
+				}, nil)
+				packageRepo.CreatePackageReturns(repositories.PackageRecord{
+					Type:  "docker",
+					GUID:  packageGUID,
+					State: "READY",
+				}, nil)
+				appRepo.GetAppReturns(repositories.AppRecord{
+					SpaceGUID: spaceGUID,
+					GUID:      appGUID,
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+			})
+
+			It("creates a package referencing the same image", func() {
+				Expect(packageRepo.CreatePackageCallCount()).To(Equal(1))
+				_, _, actualCreate := packageRepo.CreatePackageArgsForCall(0)
+				Expect(actualCreate.Data).To(Equal(&repositories.PackageData{Image: "some/image"}))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			When("the target app is a buildpack app", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{
+						SpaceGUID: spaceGUID,
+						GUID:      appGUID,
+						Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+					}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Cannot create a docker package for a buildpack app.")
+				})
+
+				It("doesn't create a package", func() {
+					Expect(packageRepo.CreatePackageCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		When("the source package is not ready", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					Type:  "bits",
+					GUID:  sourcePackageGUID,
+					State: "AWAITING_UPLOAD",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Source package must be in the READY state to be copied.")
+			})
+
+			It("doesn't create a package", func() {
+				Expect(packageRepo.CreatePackageCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the source package doesn't exist", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewNotFoundError(errors.New("NotFound"), repositories.PackageResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Source package is invalid. Ensure it exists and you have access to it.")
+			})
+		})
+
+		When("getting the source package fails", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the target app doesn't exist", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(errors.New("NotFound"), repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("App is invalid. Ensure it exists and you have access to it.")
+			})
+		})
+
 		When("the request JSON is invalid", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "test-error"))
@@ -512,6 +813,7 @@ var _ = Describe("Package", func() {
 	Describe("the POST /v3/packages/upload endpoint", func() {
 		var (
 			imageRefWithDigest string
+			bits               []byte
 			body               io.Reader
 			formDataHeader     string
 		)
@@ -541,19 +843,31 @@ var _ = Describe("Package", func() {
 			imageRefWithDigest = "some-org/the-package-guid@SHA256:some-sha-256"
 			imageRepo.UploadSourceImageReturns(imageRefWithDigest, nil)
 
-			var b bytes.Buffer
-			writer := multipart.NewWriter(&b)
-			part, err := writer.CreateFormFile("bits", "unused.zip")
+			var zipBuffer bytes.Buffer
+			zipWriter := zip.NewWriter(&zipBuffer)
+			zipEntry, err := zipWriter.Create("app.js")
 			Expect(err).NotTo(HaveOccurred())
-			_, err = io.Copy(part, strings.NewReader("the-src-file-contents"))
+			_, err = zipEntry.Write([]byte("the-src-file-contents"))
 			Expect(err).NotTo(HaveOccurred())
-			Expect(writer.Close()).To(Succeed())
-			formDataHeader = writer.FormDataContentType()
-
-			body = &b
+			Expect(zipWriter.Close()).To(Succeed())
+			bits = zipBuffer.Bytes()
+			body = nil
 		})
 
 		JustBeforeEach(func() {
+			if body == nil {
+				var b bytes.Buffer
+				writer := multipart.NewWriter(&b)
+				part, err := writer.CreateFormFile("bits", "unused.zip")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = part.Write(bits)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(writer.Close()).To(Succeed())
+				formDataHeader = writer.FormDataContentType()
+
+				body = &b
+			}
+
 			req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("/v3/packages/%s/upload", packageGUID), body)
 			Expect(err).NotTo(HaveOccurred())
 			req.Header.Add("Content-Type", formDataHeader)
@@ -574,7 +888,7 @@ var _ = Describe("Package", func() {
 			Expect(repoRef).To(Equal("registry.repo/foo"))
 			actualSrcContents, err := io.ReadAll(srcFile)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(string(actualSrcContents)).To(Equal("the-src-file-contents"))
+			Expect(actualSrcContents).To(Equal(bits))
 			Expect(actualSpaceGUID).To(Equal(spaceGUID))
 			Expect(actualTags).To(HaveLen(1))
 			Expect(actualTags[0]).To(Equal(packageGUID))
@@ -658,6 +972,30 @@ var _ = Describe("Package", func() {
 			itDoesntUpdateAnyPackages()
 		})
 
+		When("the bits are not a zip archive", func() {
+			BeforeEach(func() {
+				bits = []byte("the-src-file-contents")
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Bits must be a valid zip archive.")
+			})
+			itDoesntUploadSourceImage()
+			itDoesntUpdateAnyPackages()
+		})
+
+		When("the zip archive is truncated", func() {
+			BeforeEach(func() {
+				bits = bits[:len(bits)/2]
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Bits must be a valid zip archive.")
+			})
+			itDoesntUploadSourceImage()
+			itDoesntUpdateAnyPackages()
+		})
+
 		When("preparing to upload the source image errors", func() {
 			BeforeEach(func() {
 				imageRepo.UploadSourceImageReturns("", errors.New("boom"))
@@ -131,26 +131,12 @@ func (h *Process) getSidecars(r *http.Request) (*routing.Response, error) {
 
 	processGUID := routing.URLParam(r, "guid")
 
-	_, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch process from Kubernetes", "ProcessGUID", processGUID)
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(map[string]interface{}{
-		"pagination": map[string]interface{}{
-			"total_results": 0,
-			"total_pages":   1,
-			"first": map[string]interface{}{
-				"href": fmt.Sprintf("%s/v3/processes/%s/sidecars", h.serverURL.String(), processGUID),
-			},
-			"last": map[string]interface{}{
-				"href": fmt.Sprintf("%s/v3/processes/%s/sidecars", h.serverURL.String(), processGUID),
-			},
-			"next":     nil,
-			"previous": nil,
-		},
-		"resources": []string{},
-	}), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForProcessSidecar, process.Sidecars, h.serverURL, *r.URL)), nil
 }
 
 func (h *Process) scale(r *http.Request) (*routing.Response, error) {
@@ -210,7 +196,7 @@ func (h *Process) list(r *http.Request) (*routing.Response, error) { //nolint:du
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch processes(s) from Kubernetes")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcessList(processList, h.serverURL, *r.URL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcessList(processList, h.serverURL, *r.URL, processListFilter.PageNumber(), processListFilter.PerPageNumber())), nil
 }
 
 func (h *Process) update(r *http.Request) (*routing.Response, error) {
@@ -112,9 +112,39 @@ var _ = Describe("Process", func() {
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeZero()),
 				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/processes/process-guid/sidecars"),
+				MatchJSONPath("$.resources", BeEmpty()),
 			)))
 		})
 
+		When("the process has sidecars", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{
+					Sidecars: []repositories.SidecarRecord{{
+						GUID:         "sidecar-guid",
+						Name:         "my-sidecar",
+						Command:      "start-sidecar",
+						ProcessTypes: []string{"web"},
+						MemoryMB:     128,
+						AppGUID:      "app-guid",
+					}},
+				}, nil)
+			})
+
+			It("lists the sidecars", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+					MatchJSONPath("$.resources[0].guid", "sidecar-guid"),
+					MatchJSONPath("$.resources[0].name", "my-sidecar"),
+					MatchJSONPath("$.resources[0].command", "start-sidecar"),
+					MatchJSONPath("$.resources[0].process_types", ConsistOf("web")),
+					MatchJSONPath("$.resources[0].memory_in_mb", BeEquivalentTo(128)),
+					MatchJSONPath("$.resources[0].origin", "user"),
+					MatchJSONPath("$.resources[0].relationships.app.data.guid", "app-guid"),
+				)))
+			})
+		})
+
 		When("the process isn't accessible to the user", func() {
 			BeforeEach(func() {
 				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
@@ -232,6 +262,16 @@ var _ = Describe("Process", func() {
 				expectUnknownError()
 			})
 		})
+
+		When("the sidecars do not fit into the requested memory", func() {
+			BeforeEach(func() {
+				processRepo.ScaleProcessReturns(repositories.ProcessRecord{}, apierrors.NewUnprocessableEntityError(nil, "The requested memory allocation is not large enough to run all of your sidecar processes."))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("The requested memory allocation is not large enough to run all of your sidecar processes.")
+			})
+		})
 	})
 
 	Describe("the GET /v3/processes/<guid>/stats endpoint", func() {
@@ -319,11 +359,31 @@ var _ = Describe("Process", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/processes"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/processes?page=1&per_page=50"),
 				MatchJSONPath("$.resources[0].guid", "process-guid"),
 			)))
 		})
 
+		When("a page is requested", func() {
+			BeforeEach(func() {
+				processRepo.ListProcessesReturns([]repositories.ProcessRecord{
+					{GUID: "process-1-guid"},
+					{GUID: "process-2-guid"},
+				}, nil)
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ProcessList{
+					Pagination: payloads.Pagination{Page: "2", PerPage: "1"},
+				})
+			})
+
+			It("returns the requested page", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+					MatchJSONPath("$.resources", HaveLen(1)),
+					MatchJSONPath("$.resources[0].guid", "process-2-guid"),
+				)))
+			})
+		})
+
 		When("app_guids query parameter is provided", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ProcessList{
@@ -385,6 +445,7 @@ var _ = Describe("Process", func() {
 						InvocationTimeout: tools.PtrTo[int32](2),
 					},
 				},
+				ConnectionDrainTimeout: tools.PtrTo[int32](15),
 			})
 		})
 
@@ -407,6 +468,7 @@ var _ = Describe("Process", func() {
 			Expect(actualMsg.HealthCheckTimeoutSeconds).To(Equal(tools.PtrTo(int32(5))))
 			Expect(actualMsg.HealthCheckHTTPEndpoint).To(Equal(tools.PtrTo("http://myapp.com/health")))
 			Expect(actualMsg.HealthCheckType).To(Equal(tools.PtrTo("port")))
+			Expect(actualMsg.ConnectionDrainTimeoutSeconds).To(Equal(tools.PtrTo(int32(15))))
 			Expect(actualMsg.MetadataPatch.Labels).To(Equal(map[string]*string{"foo": tools.PtrTo("value1")}))
 
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	OrgQuotasPath   = "/v3/organization_quotas"
+	SpaceQuotasPath = "/v3/space_quotas"
+)
+
+//counterfeiter:generate -o fake -fake-name QuotaRepository . QuotaRepository
+
+type QuotaRepository interface {
+	ListOrgQuotas(context.Context, authorization.Info, repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error)
+	ListSpaceQuotas(context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error)
+}
+
+type Quota struct {
+	serverURL        url.URL
+	requestValidator RequestValidator
+	quotaRepo        QuotaRepository
+}
+
+func NewQuota(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	quotaRepo QuotaRepository,
+) *Quota {
+	return &Quota{
+		serverURL:        serverURL,
+		requestValidator: requestValidator,
+		quotaRepo:        quotaRepo,
+	}
+}
+
+func (h *Quota) listOrgQuotas(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.quota.list-org-quotas")
+
+	payload := new(payloads.OrgQuotaList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	orgQuotas, err := h.quotaRepo.ListOrgQuotas(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch organization quotas from Kubernetes")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForOrgQuota, orgQuotas, h.serverURL, *r.URL)), nil
+}
+
+func (h *Quota) listSpaceQuotas(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.quota.list-space-quotas")
+
+	payload := new(payloads.SpaceQuotaList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	spaceQuotas, err := h.quotaRepo.ListSpaceQuotas(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch space quotas from Kubernetes")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSpaceQuota, spaceQuotas, h.serverURL, *r.URL)), nil
+}
+
+func (h *Quota) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *Quota) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: OrgQuotasPath, Handler: h.listOrgQuotas},
+		{Method: "GET", Pattern: SpaceQuotasPath, Handler: h.listSpaceQuotas},
+	}
+}
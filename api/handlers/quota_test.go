@@ -0,0 +1,148 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quota", func() {
+	var (
+		quotaRepo        *fake.QuotaRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		quotaRepo = new(fake.QuotaRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewQuota(*serverURL, requestValidator, quotaRepo)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/organization_quotas", func() {
+		BeforeEach(func() {
+			quotaRepo.ListOrgQuotasReturns([]repositories.OrgQuotaRecord{{
+				GUID: "org-quota-guid",
+				Name: "org-quota",
+				Apps: repositories.AppsQuotaRecord{
+					TotalMemoryMB: tools.PtrTo[int64](1024),
+				},
+				OrgGUIDs: []string{"org-guid"},
+			}}, nil)
+
+			payload := &payloads.OrgQuotaList{Names: "org-quota"}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/organization_quotas?names=org-quota", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the org quotas", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+			Expect(actualReq.URL).To(Equal(req.URL))
+
+			Expect(quotaRepo.ListOrgQuotasCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := quotaRepo.ListOrgQuotasArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListOrgQuotasMessage{
+				Names: []string{"org-quota"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "org-quota-guid"),
+				MatchJSONPath("$.resources[0].apps.total_memory_in_mb", BeEquivalentTo(1024)),
+				MatchJSONPath("$.resources[0].relationships.organizations.data[0].guid", "org-guid"),
+			)))
+		})
+
+		When("decoding the query parameters fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the quotas fails", func() {
+			BeforeEach(func() {
+				quotaRepo.ListOrgQuotasReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/space_quotas", func() {
+		BeforeEach(func() {
+			quotaRepo.ListSpaceQuotasReturns([]repositories.SpaceQuotaRecord{{
+				GUID: "space-quota-guid",
+				Name: "space-quota",
+				Apps: repositories.AppsQuotaRecord{
+					TotalInstances: tools.PtrTo[int32](5),
+				},
+				OrgGUID:    "org-guid",
+				SpaceGUIDs: []string{"space-guid"},
+			}}, nil)
+
+			payload := &payloads.SpaceQuotaList{OrganizationGUIDs: "org-guid"}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/space_quotas?organization_guids=org-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the space quotas", func() {
+			Expect(quotaRepo.ListSpaceQuotasCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := quotaRepo.ListSpaceQuotasArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListSpaceQuotasMessage{
+				OrgGUIDs: []string{"org-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "space-quota-guid"),
+				MatchJSONPath("$.resources[0].apps.total_instances", BeEquivalentTo(5)),
+				MatchJSONPath("$.resources[0].relationships.organization.data.guid", "org-guid"),
+				MatchJSONPath("$.resources[0].relationships.spaces.data[0].guid", "space-guid"),
+			)))
+		})
+
+		When("listing the quotas fails", func() {
+			BeforeEach(func() {
+				quotaRepo.ListSpaceQuotasReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
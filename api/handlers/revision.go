@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	RevisionPath = "/v3/revisions/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFRevisionRepository . CFRevisionRepository
+
+type CFRevisionRepository interface {
+	GetRevision(context.Context, authorization.Info, string) (repositories.RevisionRecord, error)
+	ListRevisions(context.Context, authorization.Info, repositories.ListRevisionsMessage) ([]repositories.RevisionRecord, error)
+	CreateRevision(context.Context, authorization.Info, repositories.CreateRevisionMessage) (repositories.RevisionRecord, error)
+}
+
+type Revision struct {
+	serverURL    url.URL
+	revisionRepo CFRevisionRepository
+}
+
+func NewRevision(
+	serverURL url.URL,
+	revisionRepo CFRevisionRepository,
+) *Revision {
+	return &Revision{
+		serverURL:    serverURL,
+		revisionRepo: revisionRepo,
+	}
+}
+
+func (h *Revision) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.revision.get")
+
+	revisionGUID := routing.URLParam(r, "guid")
+
+	revision, err := h.revisionRepo.GetRevision(r.Context(), authInfo, revisionGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch revision from Kubernetes", "RevisionGUID", revisionGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRevision(revision, h.serverURL)), nil
+}
+
+func (h *Revision) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *Revision) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: RevisionPath, Handler: h.get},
+	}
+}
@@ -0,0 +1,83 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Revision", func() {
+	var (
+		req          *http.Request
+		revisionRepo *fake.CFRevisionRepository
+	)
+
+	BeforeEach(func() {
+		revisionRepo = new(fake.CFRevisionRepository)
+
+		apiHandler := handlers.NewRevision(*serverURL, revisionRepo)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/revisions/{guid}", func() {
+		BeforeEach(func() {
+			revisionRepo.GetRevisionReturns(repositories.RevisionRecord{
+				GUID:        "revision-guid",
+				Version:     2,
+				AppGUID:     appGUID,
+				DropletGUID: dropletGUID,
+				Description: repositories.RevisionDescriptionRestart,
+			}, nil)
+			req = createHttpRequest("GET", "/v3/revisions/revision-guid", nil)
+		})
+
+		It("returns the revision", func() {
+			Expect(revisionRepo.GetRevisionCallCount()).To(Equal(1))
+			_, actualAuthInfo, revisionGUID := revisionRepo.GetRevisionArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(revisionGUID).To(Equal("revision-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "revision-guid"),
+				MatchJSONPath("$.version", BeEquivalentTo(2)),
+				MatchJSONPath("$.droplet.guid", dropletGUID),
+				MatchJSONPath("$.description", repositories.RevisionDescriptionRestart),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/revisions/revision-guid"),
+			)))
+		})
+
+		When("getting the revision is forbidden", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, apierrors.NewForbiddenError(nil, repositories.RevisionResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.RevisionResourceType)
+			})
+		})
+
+		When("getting the revision fails", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, errors.New("get-revision-error"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
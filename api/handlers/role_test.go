@@ -186,7 +186,7 @@ var _ = Describe("Role", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/roles?foo=bar"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/roles?foo=bar&page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", "role-1"),
 				MatchJSONPath("$.resources[0].links.self.href", "https://api.example.org/v3/roles/role-1"),
@@ -194,6 +194,23 @@ var _ = Describe("Role", func() {
 			)))
 		})
 
+		When("a page is requested", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RoleList{
+					Pagination: payloads.Pagination{Page: "2", PerPage: "1"},
+				})
+			})
+
+			It("returns the requested page", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+					MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+					MatchJSONPath("$.resources", HaveLen(1)),
+					MatchJSONPath("$.resources[0].guid", "role-2"),
+				)))
+			})
+		})
+
 		Describe("filtering", func() {
 			BeforeEach(func() {
 				roleRepo.ListRolesReturns([]repositories.RoleRecord{
@@ -303,6 +320,16 @@ var _ = Describe("Role", func() {
 			})
 		})
 
+		When("deleting the role is forbidden", func() {
+			BeforeEach(func() {
+				roleRepo.DeleteRoleReturns(apierrors.NewForbiddenError(nil, "Role"))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
 		When("deleting the role from the repo fails", func() {
 			BeforeEach(func() {
 				roleRepo.DeleteRoleReturns(errors.New("delete-role-err"))
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/config"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/routing"
 )
@@ -13,17 +14,19 @@ const (
 )
 
 type RootV3 struct {
-	baseURL url.URL
+	baseURL               url.URL
+	managedServicesConfig config.ManagedServices
 }
 
-func NewRootV3(baseURL url.URL) *RootV3 {
+func NewRootV3(baseURL url.URL, managedServicesConfig config.ManagedServices) *RootV3 {
 	return &RootV3{
-		baseURL: baseURL,
+		baseURL:               baseURL,
+		managedServicesConfig: managedServicesConfig,
 	}
 }
 
 func (h *RootV3) get(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRootV3(h.baseURL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRootV3(h.baseURL, h.managedServicesConfig)), nil
 }
 
 func (h *RootV3) UnauthenticatedRoutes() []routing.Route {
@@ -3,6 +3,7 @@ package handlers_test
 import (
 	"net/http"
 
+	"code.cloudfoundry.org/korifi/api/config"
 	"code.cloudfoundry.org/korifi/api/handlers"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	. "github.com/onsi/ginkgo/v2"
@@ -13,7 +14,7 @@ var _ = Describe("RootV3", func() {
 	var req *http.Request
 
 	BeforeEach(func() {
-		apiHandler := handlers.NewRootV3(*serverURL)
+		apiHandler := handlers.NewRootV3(*serverURL, config.ManagedServices{})
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -34,6 +35,7 @@ var _ = Describe("RootV3", func() {
 
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.links.self.href", "https://api.example.org/v3"),
+				MatchJSONPath("$.links.apps.href", "https://api.example.org/v3/apps"),
 			)))
 		})
 	})
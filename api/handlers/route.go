@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers/include"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -27,7 +28,6 @@ const (
 type CFRouteRepository interface {
 	GetRoute(context.Context, authorization.Info, string) (repositories.RouteRecord, error)
 	ListRoutes(context.Context, authorization.Info, repositories.ListRoutesMessage) ([]repositories.RouteRecord, error)
-	ListRoutesForApp(context.Context, authorization.Info, string, string) ([]repositories.RouteRecord, error)
 	CreateRoute(context.Context, authorization.Info, repositories.CreateRouteMessage) (repositories.RouteRecord, error)
 	DeleteRoute(context.Context, authorization.Info, repositories.DeleteRouteMessage) error
 	AddDestinationsToRoute(ctx context.Context, c authorization.Info, message repositories.AddDestinationsMessage) (repositories.RouteRecord, error)
@@ -42,6 +42,10 @@ type Route struct {
 	appRepo          CFAppRepository
 	spaceRepo        CFSpaceRepository
 	requestValidator RequestValidator
+	includeResolver  *include.IncludeResolver[
+		[]repositories.RouteRecord,
+		repositories.RouteRecord,
+	]
 }
 
 func NewRoute(
@@ -51,6 +55,7 @@ func NewRoute(
 	appRepo CFAppRepository,
 	spaceRepo CFSpaceRepository,
 	requestValidator RequestValidator,
+	relationshipRepo include.ResourceRelationshipRepository,
 ) *Route {
 	return &Route{
 		serverURL:        serverURL,
@@ -59,6 +64,7 @@ func NewRoute(
 		appRepo:          appRepo,
 		spaceRepo:        spaceRepo,
 		requestValidator: requestValidator,
+		includeResolver:  include.NewIncludeResolver[[]repositories.RouteRecord](relationshipRepo, presenter.NewResource(serverURL)),
 	}
 }
 
@@ -90,7 +96,12 @@ func (h *Route) list(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch routes from Kubernetes")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRoute, routes, h.serverURL, *r.URL)), nil
+	includedResources, err := h.includeResolver.ResolveIncludes(r.Context(), authInfo, routes, routeListFilter.IncludeResourceRules)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to build included resources")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPaginatedList(presenter.ForRoute, routes, h.serverURL, *r.URL, routeListFilter.PageNumber(), routeListFilter.PerPageNumber(), includedResources...)), nil
 }
 
 func (h *Route) listDestinations(r *http.Request) (*routing.Response, error) {
@@ -140,6 +151,7 @@ func (h *Route) create(r *http.Request) (*routing.Response, error) {
 				err,
 				"Invalid domain. Ensure that the domain exists and you have access to it.",
 				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
 			),
 			"Failed to fetch space from Kubernetes",
 			"spaceGUID", spaceGUID,
@@ -10,7 +10,9 @@ import (
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/relationships"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
@@ -73,6 +75,14 @@ var _ = Describe("Route", func() {
 			appRepo,
 			spaceRepo,
 			requestValidator,
+			relationships.NewResourseRelationshipsRepo(
+				new(fake.CFServiceOfferingRepository),
+				new(fake.CFServiceBrokerRepository),
+				new(fake.CFServicePlanRepository),
+				domainRepo,
+				new(fake.CFSpaceRepository),
+				new(fake.CFOrgRepository),
+			),
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -187,7 +197,7 @@ var _ = Describe("Route", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/routes?foo=bar"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/routes?foo=bar&page=1&per_page=50"),
 				MatchJSONPath("$.resources[0].guid", "test-route-guid"),
 				MatchJSONPath("$.resources[0].url", "test-route-host.example.org/some_path"),
 				MatchJSONPath("$.resources[1].guid", "other-test-route-guid"),
@@ -218,6 +228,58 @@ var _ = Describe("Route", func() {
 			})
 		})
 
+		When("a page is requested", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RouteList{
+					Pagination: payloads.Pagination{Page: "2", PerPage: "1"},
+				})
+			})
+
+			It("returns the requested page", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+					MatchJSONPath("$.resources", HaveLen(1)),
+					MatchJSONPath("$.resources[0].guid", "other-test-route-guid"),
+				)))
+			})
+		})
+
+		When("the domain is included", func() {
+			BeforeEach(func() {
+				domainRepo.ListDomainsReturns([]repositories.DomainRecord{{
+					GUID: "test-domain-guid",
+					Name: "example.org",
+				}}, nil)
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RouteList{
+					IncludeResourceRules: []params.IncludeResourceRule{{
+						RelationshipPath: []string{"domain"},
+						Fields:           []string{},
+					}},
+				})
+			})
+
+			It("includes the route domains in the response", func() {
+				Expect(domainRepo.ListDomainsCallCount()).To(Equal(1))
+				_, _, message := domainRepo.ListDomainsArgsForCall(0)
+				Expect(message.GUIDs).To(ContainElement("test-domain-guid"))
+
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.included.domains[0].guid", "test-domain-guid"),
+					MatchJSONPath("$.included.domains[0].name", "example.org"),
+				)))
+			})
+
+			When("listing the included domains fails", func() {
+				BeforeEach(func() {
+					domainRepo.ListDomainsReturns(nil, errors.New("list-domains-err"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
 		When("there is a failure Listing Routes", func() {
 			BeforeEach(func() {
 				routeRepo.ListRoutesReturns([]repositories.RouteRecord{}, errors.New("unknown!"))
@@ -372,6 +434,16 @@ var _ = Describe("Route", func() {
 			})
 		})
 
+		When("the domain is forbidden", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{}, apierrors.NewForbiddenError(nil, repositories.DomainResourceType))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Invalid domain. Ensure that the domain exists and you have access to it.")
+			})
+		})
+
 		When("GetDomain returns an unknown error", func() {
 			BeforeEach(func() {
 				domainRepo.GetDomainReturns(repositories.DomainRecord{}, errors.New("random error"))
@@ -382,6 +454,35 @@ var _ = Describe("Route", func() {
 			})
 		})
 
+		When("the domain is private", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+					GUID:             "test-space-guid",
+					OrganizationGUID: "test-org-guid",
+				}, nil)
+				domainRepo.GetDomainReturns(repositories.DomainRecord{
+					GUID:    "test-domain-guid",
+					Name:    "private.org",
+					OrgGUID: "test-org-guid",
+				}, nil)
+			})
+
+			It("creates the route", func() {
+				Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			When("the domain belongs to a different org", func() {
+				BeforeEach(func() {
+					routeRepo.CreateRouteReturns(repositories.RouteRecord{}, apierrors.NewUnprocessableEntityError(nil, "Invalid domain. Domain 'private.org' is not available in organization 'test-org-guid'."))
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Invalid domain. Domain 'private.org' is not available in organization 'test-org-guid'.")
+				})
+			})
+		})
+
 		When("CreateRoute returns an unknown error", func() {
 			BeforeEach(func() {
 				routeRepo.CreateRouteReturns(repositories.RouteRecord{},
@@ -737,6 +838,16 @@ var _ = Describe("Route", func() {
 			})
 		})
 
+		When("the destination doesn't exist on the route", func() {
+			BeforeEach(func() {
+				routeRepo.RemoveDestinationFromRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteDestinationResourceType))
+			})
+
+			It("responds with 404 and an error", func() {
+				expectNotFoundError("Destination")
+			})
+		})
+
 		When("removing the destinations from the Route errors", func() {
 			BeforeEach(func() {
 				routeRepo.RemoveDestinationFromRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -80,21 +81,23 @@ func (h *ServiceBinding) create(r *http.Request) (*routing.Response, error) {
 		}
 	}
 
-	if serviceInstance.Type == korifiv1alpha1.UserProvidedType {
-		return h.createUserProvided(ctx, &payload, serviceInstance)
+	if serviceInstance.Type == korifiv1alpha1.ManagedType {
+		return h.createManaged(ctx, &payload, serviceInstance)
 	}
 
-	return h.createManaged(ctx, &payload, serviceInstance)
+	return h.createUnmanaged(ctx, &payload, serviceInstance)
 }
 
-func (h *ServiceBinding) createUserProvided(ctx context.Context, payload *payloads.ServiceBindingCreate, serviceInstance repositories.ServiceInstanceRecord) (*routing.Response, error) {
+// createUnmanaged creates bindings of user-provided and volume service
+// instances, which are not brokered and therefore created synchronously
+func (h *ServiceBinding) createUnmanaged(ctx context.Context, payload *payloads.ServiceBindingCreate, serviceInstance repositories.ServiceInstanceRecord) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(ctx)
-	logger := logr.FromContextOrDiscard(ctx).WithName("handlers.service-binding.create-user-provided")
+	logger := logr.FromContextOrDiscard(ctx).WithName("handlers.service-binding.create-unmanaged")
 
 	if payload.Type == korifiv1alpha1.CFServiceBindingTypeKey {
 		return nil, apierrors.LogAndReturn(
 			logger,
-			apierrors.NewUnprocessableEntityError(nil, "Service credential bindings of type 'key' are not supported for user-provided service instances."),
+			apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Service credential bindings of type 'key' are not supported for %s service instances.", serviceInstance.Type)),
 			"",
 		)
 	}
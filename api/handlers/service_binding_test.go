@@ -106,6 +106,21 @@ var _ = Describe("ServiceBinding", func() {
 				})
 			})
 
+			When("binding to a volume service instance", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+						GUID:      "service-instance-guid",
+						SpaceGUID: "space-guid",
+						Type:      korifiv1alpha1.VolumeType,
+					}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(0))
+					expectUnprocessableEntityError("Service credential bindings of type 'key' are not supported for volume service instances.")
+				})
+			})
+
 			When("binding to a managed service", func() {
 				BeforeEach(func() {
 					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
@@ -199,6 +214,31 @@ var _ = Describe("ServiceBinding", func() {
 				})
 			})
 
+			When("binding to a volume service instance", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+						GUID:      "service-instance-guid",
+						SpaceGUID: "space-guid",
+						Type:      korifiv1alpha1.VolumeType,
+					}, nil)
+
+					serviceBindingRepo.CreateServiceBindingReturns(repositories.ServiceBindingRecord{
+						GUID: "service-binding-guid",
+						Type: korifiv1alpha1.CFServiceBindingTypeApp,
+					}, nil)
+				})
+
+				It("creates the service binding synchronously", func() {
+					Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(1))
+					_, _, createServiceBindingMessage := serviceBindingRepo.CreateServiceBindingArgsForCall(0)
+					Expect(createServiceBindingMessage.ServiceInstanceGUID).To(Equal("service-instance-guid"))
+					Expect(createServiceBindingMessage.Type).To(Equal(korifiv1alpha1.CFServiceBindingTypeApp))
+
+					Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+					Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "service-binding-guid")))
+				})
+			})
+
 			When("binding to a managed service instance", func() {
 				BeforeEach(func() {
 					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/handlers/include"
@@ -30,6 +32,7 @@ const (
 type CFServiceInstanceRepository interface {
 	CreateUserProvidedServiceInstance(context.Context, authorization.Info, repositories.CreateUPSIMessage) (repositories.ServiceInstanceRecord, error)
 	CreateManagedServiceInstance(context.Context, authorization.Info, repositories.CreateManagedSIMessage) (repositories.ServiceInstanceRecord, error)
+	CreateVolumeServiceInstance(context.Context, authorization.Info, repositories.CreateVolumeSIMessage) (repositories.ServiceInstanceRecord, error)
 	PatchServiceInstance(context.Context, authorization.Info, repositories.PatchServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
 	ListServiceInstances(context.Context, authorization.Info, repositories.ListServiceInstanceMessage) ([]repositories.ServiceInstanceRecord, error)
 	GetServiceInstance(context.Context, authorization.Info, string) (repositories.ServiceInstanceRecord, error)
@@ -40,7 +43,9 @@ type CFServiceInstanceRepository interface {
 type ServiceInstance struct {
 	serverURL           url.URL
 	serviceInstanceRepo CFServiceInstanceRepository
+	serviceBindingRepo  CFServiceBindingRepository
 	spaceRepo           CFSpaceRepository
+	featureFlagRepo     FeatureFlagRepository
 	requestValidator    RequestValidator
 	includeResolver     *include.IncludeResolver[
 		[]repositories.ServiceInstanceRecord,
@@ -51,14 +56,18 @@ type ServiceInstance struct {
 func NewServiceInstance(
 	serverURL url.URL,
 	serviceInstanceRepo CFServiceInstanceRepository,
+	serviceBindingRepo CFServiceBindingRepository,
 	spaceRepo CFSpaceRepository,
+	featureFlagRepo FeatureFlagRepository,
 	requestValidator RequestValidator,
 	relationshipRepo include.ResourceRelationshipRepository,
 ) *ServiceInstance {
 	return &ServiceInstance{
 		serverURL:           serverURL,
 		serviceInstanceRepo: serviceInstanceRepo,
+		serviceBindingRepo:  serviceBindingRepo,
 		spaceRepo:           spaceRepo,
+		featureFlagRepo:     featureFlagRepo,
 		requestValidator:    requestValidator,
 		includeResolver:     include.NewIncludeResolver[[]repositories.ServiceInstanceRecord](relationshipRepo, presenter.NewResource(serverURL)),
 	}
@@ -122,6 +131,10 @@ func (h *ServiceInstance) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if err := checkFeatureFlag(r.Context(), h.featureFlagRepo, authInfo, repositories.ServiceInstanceCreationFeatureFlag); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Service instance creation is not allowed")
+	}
+
 	spaceGUID := payload.Relationships.Space.Data.GUID
 	_, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
 	if err != nil {
@@ -137,6 +150,10 @@ func (h *ServiceInstance) create(r *http.Request) (*routing.Response, error) {
 		return h.createManagedServiceInstance(r.Context(), logger, authInfo, payload)
 	}
 
+	if payload.Type == "volume" {
+		return h.createVolumeServiceInstance(r.Context(), logger, authInfo, payload)
+	}
+
 	return h.createUserProvidedServiceInstance(r.Context(), logger, authInfo, payload)
 }
 
@@ -169,6 +186,20 @@ func (h *ServiceInstance) createUserProvidedServiceInstance(
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForServiceInstance(serviceInstanceRecord, h.serverURL)), nil
 }
 
+func (h *ServiceInstance) createVolumeServiceInstance(
+	ctx context.Context,
+	logger logr.Logger,
+	authInfo authorization.Info,
+	payload payloads.ServiceInstanceCreate,
+) (*routing.Response, error) {
+	serviceInstanceRecord, err := h.serviceInstanceRepo.CreateVolumeServiceInstance(ctx, authInfo, payload.ToVolumeSICreateMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create volume service instance", "Service Instance Name", payload.Name)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForServiceInstance(serviceInstanceRecord, h.serverURL)), nil
+}
+
 func (h *ServiceInstance) patch(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.patch")
@@ -228,6 +259,32 @@ func (h *ServiceInstance) delete(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
 	}
 
+	// purging deletes the bindings along with the instance
+	if !payload.Purge {
+		bindings, err := h.serviceBindingRepo.ListServiceBindings(r.Context(), authInfo, repositories.ListServiceBindingsMessage{
+			ServiceInstanceGUIDs: []string{serviceInstanceGUID},
+		})
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to list service instance bindings", "guid", serviceInstanceGUID)
+		}
+
+		if len(bindings) > 0 {
+			bindingGUIDs := []string{}
+			for _, binding := range bindings {
+				bindingGUIDs = append(bindingGUIDs, binding.GUID)
+			}
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewAssociationNotEmptyError(nil, fmt.Sprintf(
+					"Cannot delete service instance %s, its service credential bindings must first be deleted: %s",
+					serviceInstanceGUID,
+					strings.Join(bindingGUIDs, ", "),
+				)),
+				"service instance has bindings", "guid", serviceInstanceGUID,
+			)
+		}
+	}
+
 	serviceInstance, err := h.serviceInstanceRepo.DeleteServiceInstance(r.Context(), authInfo, payload.ToMessage(serviceInstanceGUID))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "error when deleting service instance", "guid", serviceInstanceGUID)
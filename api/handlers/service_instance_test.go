@@ -25,7 +25,9 @@ import (
 var _ = Describe("ServiceInstance", func() {
 	var (
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
+		serviceBindingRepo  *fake.CFServiceBindingRepository
 		spaceRepo           *fake.CFSpaceRepository
+		featureFlagRepo     *fake.FeatureFlagRepository
 		serviceOfferingRepo *fake.CFServiceOfferingRepository
 		servicePlanRepo     *fake.CFServicePlanRepository
 		serviceBrokerRepo   *fake.CFServiceBrokerRepository
@@ -43,7 +45,10 @@ var _ = Describe("ServiceInstance", func() {
 			Type:      korifiv1alpha1.UserProvidedType,
 		}, nil)
 
+		serviceBindingRepo = new(fake.CFServiceBindingRepository)
 		spaceRepo = new(fake.CFSpaceRepository)
+		featureFlagRepo = new(fake.FeatureFlagRepository)
+		featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{Enabled: true}, nil)
 		serviceBrokerRepo = new(fake.CFServiceBrokerRepository)
 		serviceOfferingRepo = new(fake.CFServiceOfferingRepository)
 		servicePlanRepo = new(fake.CFServicePlanRepository)
@@ -53,12 +58,17 @@ var _ = Describe("ServiceInstance", func() {
 		apiHandler := NewServiceInstance(
 			*serverURL,
 			serviceInstanceRepo,
+			serviceBindingRepo,
 			spaceRepo,
+			featureFlagRepo,
 			requestValidator,
 			relationships.NewResourseRelationshipsRepo(
 				serviceOfferingRepo,
 				serviceBrokerRepo,
 				servicePlanRepo,
+				new(fake.CFDomainRepository),
+				new(fake.CFSpaceRepository),
+				new(fake.CFOrgRepository),
 			),
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -379,6 +389,42 @@ var _ = Describe("ServiceInstance", func() {
 			})
 		})
 
+		It("checks the service_instance_creation feature flag", func() {
+			Expect(featureFlagRepo.GetFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualName := featureFlagRepo.GetFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualName).To(Equal(repositories.ServiceInstanceCreationFeatureFlag))
+		})
+
+		When("service instance creation is disabled", func() {
+			BeforeEach(func() {
+				featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{
+					Name:    repositories.ServiceInstanceCreationFeatureFlag,
+					Enabled: false,
+				}, nil)
+			})
+
+			It("returns a feature disabled error", func() {
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: service_instance_creation", 330002)
+			})
+
+			It("does not create the service instance", func() {
+				Expect(serviceInstanceRepo.CreateUserProvidedServiceInstanceCallCount()).To(BeZero())
+				Expect(serviceInstanceRepo.CreateManagedServiceInstanceCallCount()).To(BeZero())
+			})
+
+			When("the user is an admin", func() {
+				BeforeEach(func() {
+					featureFlagRepo.CanUpdateFeatureFlagsReturns(true, nil)
+				})
+
+				It("creates the service instance", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+					Expect(serviceInstanceRepo.CreateUserProvidedServiceInstanceCallCount()).To(Equal(1))
+				})
+			})
+		})
+
 		When("the space does not exist", func() {
 			BeforeEach(func() {
 				spaceRepo.GetSpaceReturns(
@@ -501,6 +547,57 @@ var _ = Describe("ServiceInstance", func() {
 					ContainSubstring("/v3/jobs/managed_service_instance.create~service-instance-guid")))
 			})
 		})
+
+		When("the service instance is a volume", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstanceCreate{
+					Name: "service-instance-name",
+					Type: "volume",
+					Volume: &payloads.ServiceInstanceVolume{
+						SizeMB:       1024,
+						StorageClass: tools.PtrTo("nfs"),
+						MountPath:    "/var/vcap/data/shared",
+					},
+					Relationships: &payloads.ServiceInstanceRelationships{
+						Space: &payloads.Relationship{
+							Data: &payloads.RelationshipData{
+								GUID: "space-guid",
+							},
+						},
+					},
+				})
+
+				serviceInstanceRepo.CreateVolumeServiceInstanceReturns(repositories.ServiceInstanceRecord{GUID: "service-instance-guid"}, nil)
+			})
+
+			It("creates a volume service instance with the repository", func() {
+				Expect(serviceInstanceRepo.CreateVolumeServiceInstanceCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualCreate := serviceInstanceRepo.CreateVolumeServiceInstanceArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualCreate).To(Equal(repositories.CreateVolumeSIMessage{
+					Name:             "service-instance-name",
+					SpaceGUID:        "space-guid",
+					SizeMB:           1024,
+					StorageClassName: tools.PtrTo("nfs"),
+					MountPath:        "/var/vcap/data/shared",
+				}))
+			})
+
+			When("creating the volume service instance fails", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.CreateVolumeServiceInstanceReturns(repositories.ServiceInstanceRecord{}, errors.New("create-volume-err"))
+				})
+
+				It("returns unknown error", func() {
+					expectUnknownError()
+				})
+			})
+
+			It("returns HTTP 201 Created response", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "service-instance-guid")))
+			})
+		})
 	})
 
 	Describe("GET /v3/service_instances", func() {
@@ -802,6 +899,43 @@ var _ = Describe("ServiceInstance", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
 		})
 
+		It("checks for bindings of the service instance", func() {
+			Expect(serviceBindingRepo.ListServiceBindingsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceBindingRepo.ListServiceBindingsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.ServiceInstanceGUIDs).To(ConsistOf("service-instance-guid"))
+		})
+
+		When("the service instance has bindings", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.ListServiceBindingsReturns([]repositories.ServiceBindingRecord{
+					{GUID: "binding-1"},
+					{GUID: "binding-2"},
+				}, nil)
+			})
+
+			It("returns an association not empty error listing the bindings", func() {
+				Expect(serviceInstanceRepo.DeleteServiceInstanceCallCount()).To(BeZero())
+				expectErrorResponse(
+					http.StatusUnprocessableEntity,
+					"CF-AssociationNotEmpty",
+					"Cannot delete service instance service-instance-guid, its service credential bindings must first be deleted: binding-1, binding-2",
+					10006,
+				)
+			})
+		})
+
+		When("listing the service instance bindings fails", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.ListServiceBindingsReturns(nil, errors.New("list-bindings-error"))
+			})
+
+			It("returns an error", func() {
+				Expect(serviceInstanceRepo.DeleteServiceInstanceCallCount()).To(BeZero())
+				expectUnknownError()
+			})
+		})
+
 		When("the service instance is managed", func() {
 			BeforeEach(func() {
 				serviceInstanceRepo.DeleteServiceInstanceReturns(repositories.ServiceInstanceRecord{
@@ -832,6 +966,10 @@ var _ = Describe("ServiceInstance", func() {
 				})
 			})
 
+			It("does not check for bindings", func() {
+				Expect(serviceBindingRepo.ListServiceBindingsCallCount()).To(BeZero())
+			})
+
 			It("purges the service instance", func() {
 				Expect(serviceInstanceRepo.DeleteServiceInstanceCallCount()).To(Equal(1))
 				_, actualAuthInfo, message := serviceInstanceRepo.DeleteServiceInstanceArgsForCall(0)
@@ -43,6 +43,9 @@ var _ = Describe("ServiceOffering", func() {
 				serviceOfferingRepo,
 				serviceBrokerRepo,
 				servicePlanRepo,
+				new(fake.CFDomainRepository),
+				new(fake.CFSpaceRepository),
+				new(fake.CFOrgRepository),
 			),
 		)
 		routerBuilder.LoadRoutes(apiHandler)
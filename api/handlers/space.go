@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/actions"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
@@ -17,8 +18,9 @@ import (
 )
 
 const (
-	SpacesPath = "/v3/spaces"
-	SpacePath  = "/v3/spaces/{guid}"
+	SpacesPath       = "/v3/spaces"
+	SpacePath        = "/v3/spaces/{guid}"
+	SpaceSummaryPath = "/v3/spaces/{guid}/summary"
 )
 
 //counterfeiter:generate -o fake -fake-name CFSpaceRepository . CFSpaceRepository
@@ -32,16 +34,24 @@ type CFSpaceRepository interface {
 	GetDeletedAt(context.Context, authorization.Info, string) (*time.Time, error)
 }
 
+//counterfeiter:generate -o fake -fake-name SpaceSummary . SpaceSummary
+
+type SpaceSummary interface {
+	Summarize(context.Context, authorization.Info, string) (actions.SpaceSummaryRecord, error)
+}
+
 type Space struct {
 	spaceRepo        CFSpaceRepository
+	spaceSummary     SpaceSummary
 	apiBaseURL       url.URL
 	requestValidator RequestValidator
 }
 
-func NewSpace(apiBaseURL url.URL, spaceRepo CFSpaceRepository, requestValidator RequestValidator) *Space {
+func NewSpace(apiBaseURL url.URL, spaceRepo CFSpaceRepository, spaceSummary SpaceSummary, requestValidator RequestValidator) *Space {
 	return &Space{
 		apiBaseURL:       apiBaseURL,
 		spaceRepo:        spaceRepo,
+		spaceSummary:     spaceSummary,
 		requestValidator: requestValidator,
 	}
 }
@@ -83,7 +93,7 @@ func (h *Space) list(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch spaces")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSpace, spaces, h.apiBaseURL, *r.URL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPaginatedList(presenter.ForSpace, spaces, h.apiBaseURL, *r.URL, spaceList.PageNumber(), spaceList.PerPageNumber())), nil
 }
 
 //nolint:dupl
@@ -117,11 +127,24 @@ func (h *Space) delete(r *http.Request) (*routing.Response, error) {
 
 	spaceGUID := routing.URLParam(r, "guid")
 
+	payload := new(payloads.SpaceDelete)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
 	spaceRecord, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch space", "SpaceGUID", spaceGUID)
 	}
 
+	if isDeletionProtected(spaceRecord.Annotations) && !payload.OverrideProtection {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "The space is protected from deletion. Retry with override_protection=true to delete it anyway."),
+			"Space is protected from deletion", "SpaceGUID", spaceGUID,
+		)
+	}
+
 	deleteSpaceMessage := repositories.DeleteSpaceMessage{
 		GUID:             spaceRecord.GUID,
 		OrganizationGUID: spaceRecord.OrganizationGUID,
@@ -145,7 +168,26 @@ func (h *Space) get(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "spaceGUID", spaceGUID)
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpace(space, h.apiBaseURL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpace(space, h.apiBaseURL)).WithETag(space.ResourceVersion), nil
+}
+
+func (h *Space) getSummary(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space.get-summary")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	_, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "spaceGUID", spaceGUID)
+	}
+
+	summary, err := h.spaceSummary.Summarize(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to summarize space", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceSummary(summary, h.apiBaseURL)), nil
 }
 
 func (h *Space) UnauthenticatedRoutes() []routing.Route {
@@ -159,5 +201,6 @@ func (h *Space) AuthenticatedRoutes() []routing.Route {
 		{Method: "PATCH", Pattern: SpacePath, Handler: h.update},
 		{Method: "DELETE", Pattern: SpacePath, Handler: h.delete},
 		{Method: "GET", Pattern: SpacePath, Handler: h.get},
+		{Method: "GET", Pattern: SpaceSummaryPath, Handler: h.getSummary},
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
@@ -29,6 +30,7 @@ type SpaceManifest struct {
 //counterfeiter:generate -o fake -fake-name ManifestApplier . ManifestApplier
 type ManifestApplier interface {
 	Apply(ctx context.Context, authInfo authorization.Info, spaceGUID string, manifest payloads.Manifest) error
+	Diff(ctx context.Context, authInfo authorization.Info, spaceGUID string, manifest payloads.Manifest) ([]manifest.DiffEntry, error)
 }
 
 func NewSpaceManifest(
@@ -79,10 +81,19 @@ func (h *SpaceManifest) diff(r *http.Request) (*routing.Response, error) {
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-manifest.diff")
 
 	spaceGUID := routing.URLParam(r, "spaceGUID")
+	var appManifest payloads.Manifest
+	if err := h.requestValidator.DecodeAndValidateYAMLPayload(r, &appManifest); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
 
 	if _, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID); err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space", "guid", spaceGUID)
 	}
 
-	return routing.NewResponse(http.StatusAccepted).WithBody(map[string]interface{}{"diff": []string{}}), nil
+	diff, err := h.manifestApplier.Diff(r.Context(), authInfo, spaceGUID, appManifest)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error diffing manifest", "guid", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithBody(presenter.ForManifestDiff(diff)), nil
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
@@ -135,16 +136,66 @@ var _ = Describe("SpaceManifest", func() {
 	Describe("POST /v3/spaces/{spaceGUID}/manifest_diff", func() {
 		BeforeEach(func() {
 			requestPath = "/v3/spaces/test-space-guid/manifest_diff"
+			requestValidator.DecodeAndValidateYAMLPayloadStub = decodeAndValidatePayloadStub(&payloads.Manifest{
+				Version: 1,
+				Applications: []payloads.ManifestApplication{{
+					Name:      "app1",
+					Instances: tools.PtrTo[int32](2),
+				}},
+			})
+
+			manifestApplier.DiffReturns([]manifest.DiffEntry{{
+				Op:    manifest.DiffOpReplace,
+				Path:  "/applications/0/processes/0/instances",
+				Was:   int32(1),
+				Value: int32(2),
+			}}, nil)
+		})
+
+		It("diffs the manifest", func() {
+			Expect(manifestApplier.DiffCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID, actualManifest := manifestApplier.DiffArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("test-space-guid"))
+			Expect(actualManifest.Applications).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Name":      Equal("app1"),
+				"Instances": PointTo(BeEquivalentTo(2)),
+			})))
 		})
 
-		It("returns 202 with an empty diff", func() {
+		It("returns 202 with the diff", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
-				"diff": []
+				"diff": [{
+					"op": "replace",
+					"path": "/applications/0/processes/0/instances",
+					"was": 1,
+					"value": 2
+				}]
 			}`)))
 		})
 
+		When("the manifest is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateYAMLPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("diffing the manifest fails", func() {
+			BeforeEach(func() {
+				manifestApplier.DiffReturns(nil, errors.New("diff-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
 		When("getting the space errors", func() {
 			BeforeEach(func() {
 				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("foo"))
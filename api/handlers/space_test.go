@@ -5,11 +5,13 @@ import (
 	"net/http"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/actions"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
 
 	. "code.cloudfoundry.org/korifi/tests/matchers"
@@ -22,6 +24,7 @@ var _ = Describe("Space", func() {
 	var (
 		apiHandler       *handlers.Space
 		spaceRepo        *fake.CFSpaceRepository
+		spaceSummary     *fake.SpaceSummary
 		requestValidator *fake.RequestValidator
 		requestMethod    string
 		requestPath      string
@@ -32,6 +35,7 @@ var _ = Describe("Space", func() {
 
 		requestValidator = new(fake.RequestValidator)
 		spaceRepo = new(fake.CFSpaceRepository)
+		spaceSummary = new(fake.SpaceSummary)
 		spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
 			Name:             "the-space",
 			GUID:             "the-space-guid",
@@ -41,6 +45,7 @@ var _ = Describe("Space", func() {
 		apiHandler = handlers.NewSpace(
 			*serverURL,
 			spaceRepo,
+			spaceSummary,
 			requestValidator,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -157,7 +162,8 @@ var _ = Describe("Space", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/spaces?foo=bar"),
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/spaces?foo=bar&page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", "test-space-1-guid"),
 				MatchJSONPath("$.resources[0].links.self.href", "https://api.example.org/v3/spaces/test-space-1-guid"),
@@ -165,6 +171,37 @@ var _ = Describe("Space", func() {
 			)))
 		})
 
+		When("order_by is specified", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SpaceList{
+					OrderBy: "created_at",
+				})
+			})
+
+			It("orders by it", func() {
+				Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+				_, _, message := spaceRepo.ListSpacesArgsForCall(0)
+				Expect(message.OrderBy).To(Equal("created_at"))
+			})
+		})
+
+		When("a page is requested", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SpaceList{
+					Pagination: payloads.Pagination{Page: "2", PerPage: "1"},
+				})
+			})
+
+			It("returns the requested page", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+					MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+					MatchJSONPath("$.resources", HaveLen(1)),
+					MatchJSONPath("$.resources[0].guid", "test-space-2-guid"),
+				)))
+			})
+		})
+
 		When("fetching the spaces fails", func() {
 			BeforeEach(func() {
 				spaceRepo.ListSpacesReturns(nil, errors.New("boom!"))
@@ -200,6 +237,26 @@ var _ = Describe("Space", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/space.delete~the-space-guid"))
 		})
 
+		It("validates the query parameters", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+			Expect(actualReq.URL.String()).To(HaveSuffix(requestPath))
+		})
+
+		When("the query parameters are invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+
+			It("does not delete the space", func() {
+				Expect(spaceRepo.DeleteSpaceCallCount()).To(Equal(0))
+			})
+		})
+
 		When("fetching the space errors", func() {
 			BeforeEach(func() {
 				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("boom"))
@@ -210,6 +267,39 @@ var _ = Describe("Space", func() {
 			})
 		})
 
+		When("the space is protected from deletion", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+					GUID:             "the-space-guid",
+					OrganizationGUID: "the-org-guid",
+					Annotations: map[string]string{
+						korifiv1alpha1.DeletionProtectedAnnotation: "true",
+					},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("The space is protected from deletion. Retry with override_protection=true to delete it anyway.")
+			})
+
+			It("does not delete the space", func() {
+				Expect(spaceRepo.DeleteSpaceCallCount()).To(Equal(0))
+			})
+
+			When("the protection is overridden", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SpaceDelete{
+						OverrideProtection: true,
+					})
+				})
+
+				It("deletes the space", func() {
+					Expect(spaceRepo.DeleteSpaceCallCount()).To(Equal(1))
+					Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				})
+			})
+		})
+
 		When("deleting the space errors", func() {
 			BeforeEach(func() {
 				spaceRepo.DeleteSpaceReturns(errors.New("boom"))
@@ -318,8 +408,9 @@ var _ = Describe("Space", func() {
 			requestPath += "/the-space-guid"
 
 			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
-				Name: "space-name",
-				GUID: "space-guid",
+				Name:            "space-name",
+				GUID:            "space-guid",
+				ResourceVersion: "7",
 			}, nil)
 		})
 
@@ -331,6 +422,7 @@ var _ = Describe("Space", func() {
 
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("ETag", `W/"7"`))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.guid", "space-guid"),
 				MatchJSONPath("$.name", "space-name"),
@@ -358,4 +450,65 @@ var _ = Describe("Space", func() {
 			})
 		})
 	})
+
+	Describe("get a space summary", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath += "/the-space-guid/summary"
+
+			spaceSummary.SummarizeReturns(actions.SpaceSummaryRecord{
+				SpaceGUID:             "the-space-guid",
+				AppsCount:             2,
+				RunningInstances:      5,
+				ServiceInstancesCount: 1,
+				RoutesCount:           3,
+			}, nil)
+		})
+
+		It("gets the space", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, info, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(info).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("the-space-guid"))
+		})
+
+		It("returns the space summary", func() {
+			Expect(spaceSummary.SummarizeCallCount()).To(Equal(1))
+			_, info, actualSpaceGUID := spaceSummary.SummarizeArgsForCall(0)
+			Expect(info).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("the-space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "the-space-guid"),
+				MatchJSONPath("$.apps_count", BeEquivalentTo(2)),
+				MatchJSONPath("$.running_instances", BeEquivalentTo(5)),
+				MatchJSONPath("$.service_instances_count", BeEquivalentTo(1)),
+				MatchJSONPath("$.routes_count", BeEquivalentTo(3)),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/spaces/the-space-guid/summary"),
+			)))
+		})
+
+		When("getting the space is forbidden", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+				Expect(spaceSummary.SummarizeCallCount()).To(BeZero())
+			})
+		})
+
+		When("summarizing the space fails", func() {
+			BeforeEach(func() {
+				spaceSummary.SummarizeReturns(actions.SpaceSummaryRecord{}, errors.New("summarize-err"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -17,6 +18,7 @@ import (
 
 const (
 	TasksPath                = "/v3/apps/{appGUID}/tasks"
+	SpaceTasksPath           = "/v3/spaces/{spaceGUID}/tasks"
 	TaskRoot                 = "/v3/tasks"
 	TaskPath                 = TaskRoot + "/{taskGUID}"
 	TaskCancelPath           = TaskRoot + "/{taskGUID}/actions/cancel"
@@ -35,6 +37,8 @@ type CFTaskRepository interface {
 type Task struct {
 	serverURL        url.URL
 	appRepo          CFAppRepository
+	spaceRepo        CFSpaceRepository
+	dropletRepo      CFDropletRepository
 	taskRepo         CFTaskRepository
 	requestValidator RequestValidator
 }
@@ -42,6 +46,8 @@ type Task struct {
 func NewTask(
 	serverURL url.URL,
 	appRepo CFAppRepository,
+	spaceRepo CFSpaceRepository,
+	dropletRepo CFDropletRepository,
 	taskRepo CFTaskRepository,
 	requestValidator RequestValidator,
 ) *Task {
@@ -49,6 +55,8 @@ func NewTask(
 		serverURL:        serverURL,
 		taskRepo:         taskRepo,
 		appRepo:          appRepo,
+		spaceRepo:        spaceRepo,
+		dropletRepo:      dropletRepo,
 		requestValidator: requestValidator,
 	}
 }
@@ -71,12 +79,53 @@ func (h *Task) list(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.task.list")
 
-	tasks, err := h.taskRepo.ListTasks(r.Context(), authInfo, repositories.ListTaskMessage{})
+	taskListFilter := new(payloads.TaskList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, taskListFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "unable to decode request query parameters")
+	}
+
+	tasks, err := h.taskRepo.ListTasks(r.Context(), authInfo, taskListFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list tasks")
+	}
+
+	return h.paginatedTaskList(tasks, r, taskListFilter), nil
+}
+
+func (h *Task) listForSpace(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.task.list-for-space")
+
+	spaceGUID := routing.URLParam(r, "spaceGUID")
+
+	if _, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "error finding space", "spaceGUID", spaceGUID)
+	}
+
+	taskListFilter := new(payloads.TaskList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, taskListFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "unable to decode request query parameters")
+	}
+
+	taskListMessage := taskListFilter.ToMessage()
+	taskListMessage.SpaceGUIDs = []string{spaceGUID}
+	tasks, err := h.taskRepo.ListTasks(r.Context(), authInfo, taskListMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to list tasks")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForTask, tasks, h.serverURL, *r.URL)), nil
+	return h.paginatedTaskList(tasks, r, taskListFilter), nil
+}
+
+func (h *Task) paginatedTaskList(tasks []repositories.TaskRecord, r *http.Request, taskListFilter *payloads.TaskList) *routing.Response {
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPaginatedList(
+		presenter.ForTask,
+		tasks,
+		h.serverURL,
+		*r.URL,
+		taskListFilter.PageNumber(),
+		taskListFilter.PerPageNumber(),
+	))
 }
 
 func (h *Task) create(r *http.Request) (*routing.Response, error) {
@@ -103,7 +152,15 @@ func (h *Task) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
-	taskRecord, err := h.taskRepo.CreateTask(r.Context(), authInfo, payload.ToMessage(appRecord))
+	createTaskMessage := payload.ToMessage(appRecord)
+	if createTaskMessage.Command == "" && payload.Template != nil {
+		createTaskMessage.Command, err = h.dropletProcessCommand(r.Context(), authInfo, appRecord, payload.Template.Process.Type)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to get task command from droplet process type", "processType", payload.Template.Process.Type)
+		}
+	}
+
+	taskRecord, err := h.taskRepo.CreateTask(r.Context(), authInfo, createTaskMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to create task")
 	}
@@ -111,6 +168,20 @@ func (h *Task) create(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForTask(taskRecord, h.serverURL)), nil
 }
 
+func (h *Task) dropletProcessCommand(ctx context.Context, authInfo authorization.Info, appRecord repositories.AppRecord, processType string) (string, error) {
+	droplet, err := h.dropletRepo.GetDroplet(ctx, authInfo, appRecord.DropletGUID)
+	if err != nil {
+		return "", err
+	}
+
+	command, ok := droplet.ProcessTypes[processType]
+	if !ok {
+		return "", apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Process type %q is not defined in the app droplet", processType))
+	}
+
+	return command, nil
+}
+
 func (h *Task) listForApp(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.task.list-for-app")
@@ -134,7 +205,7 @@ func (h *Task) listForApp(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to list tasks")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForTask, tasks, h.serverURL, *r.URL)), nil
+	return h.paginatedTaskList(tasks, r, taskListFilter), nil
 }
 
 func (h *Task) cancel(r *http.Request) (*routing.Response, error) {
@@ -189,6 +260,7 @@ func (h *Task) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: TaskRoot, Handler: h.list},
 		{Method: "POST", Pattern: TasksPath, Handler: h.create},
 		{Method: "GET", Pattern: TasksPath, Handler: h.listForApp},
+		{Method: "GET", Pattern: SpaceTasksPath, Handler: h.listForSpace},
 		{Method: "POST", Pattern: TaskCancelPath, Handler: h.cancel},
 		{Method: "PUT", Pattern: TaskCancelPathDeprecated, Handler: h.cancel},
 	}
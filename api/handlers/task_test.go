@@ -25,6 +25,8 @@ var _ = Describe("Task", func() {
 		requestMethod    string
 		requestPath      string
 		appRepo          *fake.CFAppRepository
+		spaceRepo        *fake.CFSpaceRepository
+		dropletRepo      *fake.CFDropletRepository
 		taskRepo         *fake.CFTaskRepository
 		requestValidator *fake.RequestValidator
 	)
@@ -36,9 +38,24 @@ var _ = Describe("Task", func() {
 		appRepo = new(fake.CFAppRepository)
 
 		appRepo.GetAppReturns(repositories.AppRecord{
-			GUID:      "the-app-guid",
-			SpaceGUID: "the-space-guid",
-			IsStaged:  true,
+			GUID:        "the-app-guid",
+			SpaceGUID:   "the-space-guid",
+			DropletGUID: "the-droplet-guid",
+			IsStaged:    true,
+		}, nil)
+
+		dropletRepo = new(fake.CFDropletRepository)
+		dropletRepo.GetDropletReturns(repositories.DropletRecord{
+			GUID: "the-droplet-guid",
+			ProcessTypes: map[string]string{
+				"web":        "bundle exec rackup",
+				"db:migrate": "bundle exec rake db:migrate",
+			},
+		}, nil)
+
+		spaceRepo = new(fake.CFSpaceRepository)
+		spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+			GUID: "the-space-guid",
 		}, nil)
 
 		taskRepo = new(fake.CFTaskRepository)
@@ -49,7 +66,7 @@ var _ = Describe("Task", func() {
 
 		requestValidator = new(fake.RequestValidator)
 
-		apiHandler := handlers.NewTask(*serverURL, appRepo, taskRepo, requestValidator)
+		apiHandler := handlers.NewTask(*serverURL, appRepo, spaceRepo, dropletRepo, taskRepo, requestValidator)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -172,6 +189,74 @@ var _ = Describe("Task", func() {
 				expectUnknownError()
 			})
 		})
+
+		When("the task references a droplet process type instead of a command", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.TaskCreate{
+					Template: &payloads.TaskTemplate{
+						Process: payloads.TaskTemplateProcess{Type: "db:migrate"},
+					},
+				})
+			})
+
+			It("gets the app droplet", func() {
+				Expect(dropletRepo.GetDropletCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualDropletGUID := dropletRepo.GetDropletArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualDropletGUID).To(Equal("the-droplet-guid"))
+			})
+
+			It("creates the task with the droplet process type command", func() {
+				Expect(taskRepo.CreateTaskCallCount()).To(Equal(1))
+				_, _, createTaskMessage := taskRepo.CreateTaskArgsForCall(0)
+				Expect(createTaskMessage.Command).To(Equal("bundle exec rake db:migrate"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			When("the droplet does not define the process type", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.TaskCreate{
+						Template: &payloads.TaskTemplate{
+							Process: payloads.TaskTemplateProcess{Type: "worker"},
+						},
+					})
+				})
+
+				It("returns an Unprocessable Entity error", func() {
+					Expect(taskRepo.CreateTaskCallCount()).To(BeZero())
+					expectUnprocessableEntityError(`Process type "worker" is not defined in the app droplet`)
+				})
+			})
+
+			When("getting the droplet fails", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{}, errors.New("get-droplet-err"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+
+			When("a command is given too", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.TaskCreate{
+						Command: "echo hello",
+						Template: &payloads.TaskTemplate{
+							Process: payloads.TaskTemplateProcess{Type: "db:migrate"},
+						},
+					})
+				})
+
+				It("uses the given command", func() {
+					Expect(dropletRepo.GetDropletCallCount()).To(BeZero())
+					Expect(taskRepo.CreateTaskCallCount()).To(Equal(1))
+					_, _, createTaskMessage := taskRepo.CreateTaskArgsForCall(0)
+					Expect(createTaskMessage.Command).To(Equal("echo hello"))
+				})
+			})
+		})
 	})
 
 	Describe("listing tasks", func() {
@@ -192,7 +277,7 @@ var _ = Describe("Task", func() {
 				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
-					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/tasks"),
+					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/tasks?page=1&per_page=50"),
 					MatchJSONPath("$.resources", HaveLen(2)),
 					MatchJSONPath("$.resources[0].guid", "guid-1"),
 					MatchJSONPath("$.resources[0].links.self.href", "https://api.example.org/v3/tasks/guid-1"),
@@ -200,6 +285,126 @@ var _ = Describe("Task", func() {
 				)))
 			})
 
+			When("filtering tasks by state and app", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.TaskList{
+						AppGUIDs: "app-1,app-2",
+						States:   "RUNNING",
+					})
+				})
+
+				It("provides a list task message with the filters to the repository", func() {
+					Expect(taskRepo.ListTasksCallCount()).To(Equal(1))
+					_, _, listMsg := taskRepo.ListTasksArgsForCall(0)
+					Expect(listMsg.AppGUIDs).To(ConsistOf("app-1", "app-2"))
+					Expect(listMsg.States).To(ConsistOf("RUNNING"))
+				})
+			})
+
+			When("paginating", func() {
+				BeforeEach(func() {
+					requestPath = "/v3/tasks?per_page=1&page=2"
+					requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.TaskList{
+						Pagination: payloads.Pagination{PerPage: "1", Page: "2"},
+					})
+				})
+
+				It("returns the requested page", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody(SatisfyAll(
+						MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+						MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+						MatchJSONPath("$.resources", HaveLen(1)),
+						MatchJSONPath("$.resources[0].guid", "guid-2"),
+					)))
+				})
+			})
+
+			When("the request is invalid", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+
+			When("listing tasks fails", func() {
+				BeforeEach(func() {
+					taskRepo.ListTasksReturns(nil, errors.New("list-err"))
+				})
+
+				It("returns an Internal Server Error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
+		Describe("GET /v3/spaces/{space-guid}/tasks", func() {
+			BeforeEach(func() {
+				requestPath = "/v3/spaces/the-space-guid/tasks?foo=bar"
+
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.TaskList{
+					States: "SUCCEEDED",
+				})
+			})
+
+			It("lists the tasks in the space", func() {
+				Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+				_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+				Expect(actualSpaceGUID).To(Equal("the-space-guid"))
+
+				Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+				actualReq, _ := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+				Expect(actualReq.URL.String()).To(HaveSuffix(requestPath))
+
+				Expect(taskRepo.ListTasksCallCount()).To(Equal(1))
+				_, info, listMsg := taskRepo.ListTasksArgsForCall(0)
+				Expect(info).To(Equal(authInfo))
+				Expect(listMsg.SpaceGUIDs).To(ConsistOf("the-space-guid"))
+				Expect(listMsg.States).To(ConsistOf("SUCCEEDED"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/spaces/the-space-guid/tasks?foo=bar&page=1&per_page=50"),
+					MatchJSONPath("$.resources", HaveLen(2)),
+					MatchJSONPath("$.resources[0].guid", "guid-1"),
+					MatchJSONPath("$.resources[1].guid", "guid-2"),
+				)))
+			})
+
+			When("the user cannot see the space", func() {
+				BeforeEach(func() {
+					spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+				})
+
+				It("returns a Not Found error", func() {
+					expectNotFoundError("Space")
+				})
+			})
+
+			When("getting the space fails", func() {
+				BeforeEach(func() {
+					spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("boom"))
+				})
+
+				It("returns an Internal Server Error", func() {
+					expectUnknownError()
+				})
+			})
+
+			When("the request is invalid", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+
 			When("listing tasks fails", func() {
 				BeforeEach(func() {
 					taskRepo.ListTasksReturns(nil, errors.New("list-err"))
@@ -232,7 +437,7 @@ var _ = Describe("Task", func() {
 				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
-					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/the-app-guid/tasks?foo=bar"),
+					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/the-app-guid/tasks?foo=bar&page=1&per_page=50"),
 					MatchJSONPath("$.resources", HaveLen(2)),
 					MatchJSONPath("$.resources[0].guid", "guid-1"),
 					MatchJSONPath("$.resources[0].links.self.href", "https://api.example.org/v3/tasks/guid-1"),
@@ -3,12 +3,16 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/actions"
@@ -128,12 +132,18 @@ func main() {
 		panic(fmt.Sprintf("could not parse server URL: %v", err))
 	}
 
+	repositoryRefBuilder, err := toolsregistry.NewRepositoryRefBuilder(cfg.ContainerRepositoryPrefix, cfg.ContainerRepositoryPathTemplate)
+	if err != nil {
+		panic(fmt.Sprintf("could not create container repository ref builder: %v", err))
+	}
+
 	orgRepo := repositories.NewOrgRepo(
 		cfg.RootNamespace,
 		privilegedClient,
 		userClientFactoryUnfiltered,
 		nsPermissions,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFOrg, korifiv1alpha1.CFOrg, korifiv1alpha1.CFOrgList](conditionTimeout),
+		repositories.NewOrgSorter(),
 	)
 	spaceRepo := repositories.NewSpaceRepo(
 		namespaceRetriever,
@@ -141,6 +151,7 @@ func main() {
 		userClientFactoryUnfiltered,
 		nsPermissions,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFSpace, korifiv1alpha1.CFSpace, korifiv1alpha1.CFSpaceList](conditionTimeout),
+		repositories.NewSpaceSorter(),
 	)
 	processRepo := repositories.NewProcessRepo(
 		namespaceRetriever,
@@ -148,6 +159,7 @@ func main() {
 	)
 	podRepo := repositories.NewPodRepo(
 		userClientFactoryUnfiltered,
+		k8sClientConfig,
 	)
 	appRepo := repositories.NewAppRepo(
 		namespaceRetriever,
@@ -166,6 +178,12 @@ func main() {
 	domainRepo := repositories.NewDomainRepo(
 		userClientFactoryUnfiltered,
 		namespaceRetriever,
+		nsPermissions,
+		cfg.RootNamespace,
+	)
+	quotaRepo := repositories.NewQuotaRepo(
+		userClientFactoryUnfiltered,
+		nsPermissions,
 		cfg.RootNamespace,
 	)
 	deploymentRepo := repositories.NewDeploymentRepo(
@@ -181,6 +199,7 @@ func main() {
 		userClientFactoryUnfiltered,
 		authorization.NewUnprivilegedClientsetFactory(k8sClientConfig),
 		repositories.DefaultLogStreamer,
+		cfg.StagingLogsMaxBytes,
 	)
 	runnerInfoRepo := repositories.NewRunnerInfoRepository(
 		userClientFactoryUnfiltered,
@@ -191,7 +210,7 @@ func main() {
 		userClientFactory,
 		namespaceRetriever,
 		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType),
-		cfg.ContainerRepositoryPrefix,
+		repositoryRefBuilder,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFPackage, korifiv1alpha1.CFPackage, korifiv1alpha1.CFPackageList](conditionTimeout),
 		repositories.NewPackageSorter(),
 	)
@@ -216,6 +235,16 @@ func main() {
 		cfg.RootNamespace,
 		repositories.NewBuildpackSorter(),
 	)
+	featureFlagRepo := repositories.NewFeatureFlagRepo(
+		cfg.RootNamespace,
+		privilegedClient,
+		userClientFactoryUnfiltered,
+	)
+	revisionRepo := repositories.NewRevisionRepo(
+		userClientFactory,
+		namespaceRetriever,
+		cfg.GetMaxAppRevisions(),
+	)
 	roleRepo := repositories.NewRoleRepo(
 		userClientFactory,
 		spaceRepo,
@@ -230,6 +259,7 @@ func main() {
 	imageRepo := repositories.NewImageRepository(
 		userClientFactoryUnfiltered,
 		imageClient,
+		imageClient,
 		cfg.PackageRegistrySecretNames,
 		cfg.RootNamespace,
 	)
@@ -244,12 +274,14 @@ func main() {
 	servicePlanRepo := repositories.NewServicePlanRepo(userClientFactory, cfg.RootNamespace, orgRepo)
 
 	processStats := actions.NewProcessStats(processRepo, appRepo, metricsRepo)
+	spaceSummary := actions.NewSpaceSummary(appRepo, processRepo, serviceInstanceRepo, routeRepo)
 	manifest := actions.NewManifest(
 		domainRepo,
 		cfg.DefaultDomainName,
 		manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
 		manifest.NewNormalizer(cfg.DefaultDomainName),
 		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
+		manifest.NewDiffer(),
 	)
 
 	requestValidator := validation.NewDefaultDecoderValidator()
@@ -259,6 +291,7 @@ func main() {
 		middleware.Correlation(ctrl.Log),
 		middleware.CFCliVersion,
 		middleware.HTTPLogging,
+		middleware.Compression(cfg.CompressionMinBytes),
 		chiMiddlewares.StripSlashes,
 	)
 
@@ -272,6 +305,19 @@ func main() {
 			authInfoParser,
 			cachingIdentityProvider,
 		),
+	)
+
+	if cfg.RateLimit.Enabled {
+		routerBuilder.UseAuthMiddleware(middleware.RateLimit(
+			cfg.RateLimit.RequestsPerSecond,
+			cfg.RateLimit.Burst,
+			cfg.GetRateLimitIdleTimeout(),
+			cfg.RateLimit.ExemptUsers,
+			cache.NewExpiring(),
+		))
+	}
+
+	routerBuilder.UseAuthMiddleware(
 		middleware.CFUser(
 			nsPermissions,
 			cachingIdentityProvider,
@@ -284,14 +330,21 @@ func main() {
 		serviceOfferingRepo,
 		serviceBrokerRepo,
 		servicePlanRepo,
+		domainRepo,
+		spaceRepo,
+		orgRepo,
 	)
 
+	ready := &atomic.Bool{}
+
 	apiHandlers := []routing.Routable{
-		handlers.NewRootV3(*serverURL),
+		handlers.NewHealth(ready),
+		handlers.NewRootV3(*serverURL, cfg.Experimental.ManagedServices),
 		handlers.NewRoot(*serverURL, cfg.Experimental.UAA),
 		handlers.NewInfoV3(
 			*serverURL,
 			cfg.InfoConfig,
+			cfg.Experimental,
 		),
 		handlers.NewResourceMatches(),
 		handlers.NewApp(
@@ -306,6 +359,8 @@ func main() {
 			packageRepo,
 			requestValidator,
 			podRepo,
+			revisionRepo,
+			relationshipsRepo,
 		),
 		handlers.NewRoute(
 			*serverURL,
@@ -314,6 +369,7 @@ func main() {
 			appRepo,
 			spaceRepo,
 			requestValidator,
+			relationshipsRepo,
 		),
 		handlers.NewServiceRouteBinding(
 			*serverURL,
@@ -337,6 +393,8 @@ func main() {
 		handlers.NewDroplet(
 			*serverURL,
 			dropletRepo,
+			appRepo,
+			imageRepo,
 			requestValidator,
 		),
 		handlers.NewProcess(
@@ -350,18 +408,25 @@ func main() {
 			*serverURL,
 			requestValidator,
 			domainRepo,
+			orgRepo,
 		),
 		handlers.NewDeployment(
 			*serverURL,
 			requestValidator,
 			deploymentRepo,
 			runnerInfoRepo,
+			revisionRepo,
 			cfg.RunnerName,
 		),
 		handlers.NewStack(
 			*serverURL,
 			stackRepo,
 		),
+		handlers.NewQuota(
+			*serverURL,
+			requestValidator,
+			quotaRepo,
+		),
 		handlers.NewJob(
 			*serverURL,
 			map[string]handlers.DeletionRepository{
@@ -388,11 +453,14 @@ func main() {
 			appRepo,
 			buildRepo,
 			logRepo,
+			cfg.LogSourcePrefixing,
 		),
 		handlers.NewOrg(
 			*serverURL,
 			orgRepo,
+			spaceRepo,
 			domainRepo,
+			featureFlagRepo,
 			requestValidator,
 			cfg.GetUserCertificateDuration(),
 			cfg.DefaultDomainName,
@@ -400,6 +468,7 @@ func main() {
 		handlers.NewSpace(
 			*serverURL,
 			spaceRepo,
+			spaceSummary,
 			requestValidator,
 		),
 		handlers.NewSpaceManifest(
@@ -420,10 +489,21 @@ func main() {
 			buildpackRepo,
 			requestValidator,
 		),
+		handlers.NewFeatureFlag(
+			*serverURL,
+			featureFlagRepo,
+			requestValidator,
+		),
+		handlers.NewRevision(
+			*serverURL,
+			revisionRepo,
+		),
 		handlers.NewServiceInstance(
 			*serverURL,
 			serviceInstanceRepo,
+			serviceBindingRepo,
 			spaceRepo,
+			featureFlagRepo,
 			requestValidator,
 			relationshipsRepo,
 		),
@@ -437,6 +517,8 @@ func main() {
 		handlers.NewTask(
 			*serverURL,
 			appRepo,
+			spaceRepo,
+			dropletRepo,
 			taskRepo,
 			requestValidator,
 		),
@@ -482,6 +564,7 @@ func main() {
 		ErrorLog:          log.New(&tools.LogrWriter{Logger: ctrl.Log, Message: "HTTP server error"}, "", 0),
 	}
 
+	listen := srv.ListenAndServe
 	if tlsFound {
 		ctrl.Log.Info("listening with TLS on " + portString)
 		certPath := filepath.Join(tlsPath, "tls.crt")
@@ -506,19 +589,52 @@ func main() {
 			MinVersion:     tls.VersionTLS12,
 			GetCertificate: certWatcher.GetCertificate,
 		}
-		err = srv.ListenAndServeTLS("", "")
-		if err != nil {
-			ctrl.Log.Error(err, "error serving TLS")
-			os.Exit(1)
-		}
+		listen = func() error { return srv.ListenAndServeTLS("", "") }
 	} else {
 		ctrl.Log.Info("listening without TLS on " + portString)
-		err := srv.ListenAndServe()
-		if err != nil {
-			ctrl.Log.Error(err, "error serving HTTP")
-			os.Exit(1)
-		}
 	}
+
+	if err = serve(srv, listen, ready, cfg); err != nil {
+		ctrl.Log.Error(err, "error serving HTTP")
+		os.Exit(1)
+	}
+}
+
+// serve runs the server until SIGTERM or SIGINT is received. On shutdown the
+// server is first reported as not ready and, after the configured delay, stops
+// accepting connections and waits for in-flight requests to complete.
+func serve(srv *http.Server, listen func() error, ready *atomic.Bool, cfg *config.APIConfig) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- listen()
+	}()
+	ready.Store(true)
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	ready.Store(false)
+	ctrl.Log.Info("shutting down", "delay", cfg.ShutdownDelay, "timeout", cfg.GetShutdownTimeout().Seconds())
+	time.Sleep(time.Duration(cfg.ShutdownDelay) * time.Second)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.GetShutdownTimeout())
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down gracefully: %w", err)
+	}
+
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	ctrl.Log.Info("shutdown complete")
+	return nil
 }
 
 func wireIdentityProvider(client client.Client, restConfig *rest.Config) authorization.IdentityProvider {
@@ -51,12 +51,15 @@ func (a *authentication) middleware(next http.Handler) http.Handler {
 
 		r = r.WithContext(authorization.NewContext(r.Context(), &authInfo))
 
-		_, err = a.identityProvider.GetIdentity(r.Context(), authInfo)
+		identity, err := a.identityProvider.GetIdentity(r.Context(), authInfo)
 		if err != nil {
 			routing.PresentError(logger, w, apierrors.LogAndReturn(logger, err, "failed to get identity"))
 			return
 		}
 
+		r = r.WithContext(authorization.NewIdentityContext(r.Context(), identity))
+		setRequestUser(r.Context(), identity.Name)
+
 		next.ServeHTTP(w, r)
 	})
 }
@@ -11,6 +11,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 const authHeader = "Authorization: something"
@@ -37,7 +38,7 @@ var _ = Describe("Authentication Middleware", func() {
 		authInfoParser.ParseReturns(authorization.Info{Token: "the-token"}, nil)
 
 		identityProvider = new(fake.IdentityProvider)
-		identityProvider.GetIdentityReturns(authorization.Identity{}, nil)
+		identityProvider.GetIdentityReturns(authorization.Identity{Name: "the-user", Kind: rbacv1.UserKind}, nil)
 
 		authMiddleware = middleware.Authentication(
 			authInfoParser,
@@ -69,6 +70,12 @@ var _ = Describe("Authentication Middleware", func() {
 		Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "the-token"}))
 	})
 
+	It("injects the resolved identity in the request context", func() {
+		actualIdentity, ok := authorization.IdentityFromContext(actualReq.Context())
+		Expect(ok).To(BeTrue())
+		Expect(actualIdentity).To(Equal(authorization.Identity{Name: "the-user", Kind: rbacv1.UserKind}))
+	})
+
 	When("parsing the Authorization header fails", func() {
 		BeforeEach(func() {
 			authInfoParser.ParseReturns(authorization.Info{}, apierrors.NewInvalidAuthError(nil))
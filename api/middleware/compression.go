@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Compression gzips JSON responses for clients that accept it. Bodies are
+// buffered until they reach minSize bytes, so that small responses are sent
+// as they are. Other content types, such as droplet downloads and log
+// streams, are never compressed.
+func Compression(minSize int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gzw := &gzipResponseWriter{
+				writer:      w,
+				acceptsGzip: acceptsGzip(r),
+				minSize:     minSize,
+			}
+			defer gzw.close()
+
+			next.ServeHTTP(gzw, r)
+		})
+	}
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if strings.EqualFold(strings.TrimSpace(name), "gzip") && strings.ReplaceAll(params, " ", "") != "q=0" {
+			return true
+		}
+	}
+
+	return false
+}
+
+type compressionState int
+
+const (
+	stateUndecided compressionState = iota
+	stateBuffering
+	statePassthrough
+	stateCompressing
+)
+
+type gzipResponseWriter struct {
+	writer      http.ResponseWriter
+	acceptsGzip bool
+	minSize     int
+
+	state  compressionState
+	status int
+	buffer bytes.Buffer
+	gz     *gzip.Writer
+}
+
+func (w *gzipResponseWriter) Header() http.Header {
+	return w.writer.Header()
+}
+
+func (w *gzipResponseWriter) WriteHeader(statusCode int) {
+	if w.state != stateUndecided {
+		return
+	}
+
+	w.status = statusCode
+	if !w.compressible() {
+		w.passthrough()
+		return
+	}
+
+	w.Header().Add("Vary", "Accept-Encoding")
+	if !w.acceptsGzip {
+		w.passthrough()
+		return
+	}
+
+	w.state = stateBuffering
+}
+
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	if w.state == stateUndecided {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	switch w.state {
+	case stateBuffering:
+		w.buffer.Write(data)
+		if w.buffer.Len() >= w.minSize {
+			if err := w.startCompressing(); err != nil {
+				return 0, err
+			}
+		}
+		return len(data), nil
+	case stateCompressing:
+		return w.gz.Write(data)
+	default:
+		return w.writer.Write(data)
+	}
+}
+
+// Flush sends whatever has been buffered so far. A response that is flushed
+// before reaching the size threshold is being streamed, so it is sent
+// uncompressed from then on.
+func (w *gzipResponseWriter) Flush() {
+	switch w.state {
+	case stateUndecided, stateBuffering:
+		w.passthrough()
+	case stateCompressing:
+		_ = w.gz.Flush()
+	}
+
+	_ = http.NewResponseController(w.writer).Flush()
+}
+
+// Unwrap allows http.ResponseController to reach the underlying writer, e.g.
+// to set write deadlines on streamed responses
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.writer
+}
+
+func (w *gzipResponseWriter) compressible() bool {
+	if w.status < http.StatusOK || w.status == http.StatusNoContent || w.status == http.StatusNotModified {
+		return false
+	}
+
+	if w.Header().Get("Content-Encoding") != "" {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+func (w *gzipResponseWriter) passthrough() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	w.state = statePassthrough
+	w.writer.WriteHeader(w.status)
+	if w.buffer.Len() > 0 {
+		_, _ = w.writer.Write(w.buffer.Bytes())
+		w.buffer.Reset()
+	}
+}
+
+func (w *gzipResponseWriter) startCompressing() error {
+	w.Header().Set("Content-Encoding", "gzip")
+	w.Header().Del("Content-Length")
+	w.writer.WriteHeader(w.status)
+
+	w.state = stateCompressing
+	w.gz = gzip.NewWriter(w.writer)
+	_, err := w.gz.Write(w.buffer.Bytes())
+	w.buffer.Reset()
+
+	return err
+}
+
+func (w *gzipResponseWriter) close() {
+	switch w.state {
+	case stateBuffering:
+		w.passthrough()
+	case stateCompressing:
+		_ = w.gz.Close()
+	}
+}
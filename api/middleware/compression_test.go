@@ -0,0 +1,175 @@
+package middleware_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/middleware"
+	"code.cloudfoundry.org/korifi/api/routing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Compression", func() {
+	var (
+		handler        http.Handler
+		acceptEncoding string
+		contentType    string
+		body           string
+	)
+
+	BeforeEach(func() {
+		acceptEncoding = "deflate, gzip;q=0.8"
+		contentType = "application/json"
+		body = `{"resources":"` + strings.Repeat("a", 100) + `"}`
+
+		handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(http.StatusTeapot)
+			_, _ = io.WriteString(w, body)
+		})
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequest(http.MethodGet, "/v3/apps", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+
+		middleware.Compression(50)(handler).ServeHTTP(rr, req)
+	})
+
+	decompressedBody := func() string {
+		GinkgoHelper()
+
+		reader, err := gzip.NewReader(rr.Body)
+		Expect(err).NotTo(HaveOccurred())
+		decompressed, err := io.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		return string(decompressed)
+	}
+
+	It("compresses the response", func() {
+		Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+		Expect(rr).To(HaveHTTPHeaderWithValue("Content-Encoding", "gzip"))
+		Expect(rr).To(HaveHTTPHeaderWithValue("Vary", "Accept-Encoding"))
+		Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+		Expect(decompressedBody()).To(Equal(body))
+	})
+
+	When("the client does not accept gzip", func() {
+		BeforeEach(func() {
+			acceptEncoding = "gzip;q=0, deflate"
+		})
+
+		It("does not compress the response", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			Expect(rr.Header()).NotTo(HaveKey("Content-Encoding"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Vary", "Accept-Encoding"))
+			Expect(rr).To(HaveHTTPBody(body))
+		})
+	})
+
+	When("the body is below the threshold", func() {
+		BeforeEach(func() {
+			body = `{"foo":"bar"}`
+		})
+
+		It("does not compress the response", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			Expect(rr.Header()).NotTo(HaveKey("Content-Encoding"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Vary", "Accept-Encoding"))
+			Expect(rr).To(HaveHTTPBody(body))
+		})
+	})
+
+	When("the response is not JSON", func() {
+		BeforeEach(func() {
+			contentType = "application/zip"
+		})
+
+		It("does not compress the response", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			Expect(rr.Header()).NotTo(HaveKey("Content-Encoding"))
+			Expect(rr.Header()).NotTo(HaveKey("Vary"))
+			Expect(rr).To(HaveHTTPBody(body))
+		})
+	})
+
+	When("the handler does not write the header explicitly", func() {
+		BeforeEach(func() {
+			handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				_, _ = io.WriteString(w, body)
+			})
+		})
+
+		It("compresses the response with an OK status", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Encoding", "gzip"))
+			Expect(decompressedBody()).To(Equal(body))
+		})
+	})
+
+	When("the response is streamed", func() {
+		BeforeEach(func() {
+			handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				w.WriteHeader(http.StatusOK)
+				Expect(http.NewResponseController(w).Flush()).To(Succeed())
+
+				_, _ = io.WriteString(w, "data: hello\n\n")
+				Expect(http.NewResponseController(w).Flush()).To(Succeed())
+			})
+		})
+
+		It("streams the response uncompressed", func() {
+			Expect(rr.Flushed).To(BeTrue())
+			Expect(rr.Header()).NotTo(HaveKey("Content-Encoding"))
+			Expect(rr).To(HaveHTTPBody("data: hello\n\n"))
+		})
+	})
+
+	When("a routing stream response is served", func() {
+		BeforeEach(func() {
+			handler = routing.Handler(func(*http.Request) (*routing.Response, error) {
+				return routing.NewResponse(http.StatusOK).
+					WithHeader("Content-Type", "text/event-stream").
+					WithStream(func(w io.Writer, flush func()) error {
+						for range 3 {
+							if _, err := io.WriteString(w, "data: "+strings.Repeat("a", 50)+"\n\n"); err != nil {
+								return err
+							}
+							flush()
+						}
+						return nil
+					}), nil
+			})
+		})
+
+		It("streams every chunk uncompressed", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr.Flushed).To(BeTrue())
+			Expect(rr.Header()).NotTo(HaveKey("Content-Encoding"))
+			Expect(rr).To(HaveHTTPBody(strings.Repeat("data: "+strings.Repeat("a", 50)+"\n\n", 3)))
+		})
+	})
+
+	When("a JSON response is flushed before reaching the threshold", func() {
+		BeforeEach(func() {
+			handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = io.WriteString(w, "[")
+				Expect(http.NewResponseController(w).Flush()).To(Succeed())
+				_, _ = io.WriteString(w, body+"]")
+			})
+		})
+
+		It("sends the whole response uncompressed", func() {
+			Expect(rr.Flushed).To(BeTrue())
+			Expect(rr.Header()).NotTo(HaveKey("Content-Encoding"))
+			Expect(rr).To(HaveHTTPBody("[" + body + "]"))
+		})
+	})
+})
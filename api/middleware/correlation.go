@@ -1,18 +1,24 @@
 package middleware
 
 import (
+	"cmp"
 	"net/http"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 )
 
-const CorrelationIDHeader = "X-Correlation-ID"
+const (
+	CorrelationIDHeader = "X-Correlation-ID"
+	VcapRequestIDHeader = "X-Vcap-Request-Id"
+)
 
+// Correlation tags the request logger with the X-Vcap-Request-Id set by CF
+// clients and routers, falling back to X-Correlation-ID or a new ID
 func Correlation(logger logr.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			id := r.Header.Get(CorrelationIDHeader)
+			id := cmp.Or(r.Header.Get(VcapRequestIDHeader), r.Header.Get(CorrelationIDHeader))
 			if id == "" {
 				id = uuid.NewString()
 			}
@@ -20,7 +26,8 @@ func Correlation(logger logr.Logger) func(http.Handler) http.Handler {
 			l := logger.WithValues("correlation-id", id)
 			r = r.WithContext(logr.NewContext(r.Context(), l))
 
-			w.Header().Add(CorrelationIDHeader, id)
+			w.Header().Set(CorrelationIDHeader, id)
+			w.Header().Set(VcapRequestIDHeader, id)
 
 			next.ServeHTTP(w, r)
 		})
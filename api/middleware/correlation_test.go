@@ -40,10 +40,24 @@ var _ = Describe("Correlation", func() {
 	It("logs with the correlation ID and returns it in a header", func() {
 		Expect(rr).To(HaveHTTPHeaderWithValue("X-Correlation-Id", Not(BeEmpty())))
 		corrID := rr.Header().Get("X-Correlation-Id")
+		Expect(rr).To(HaveHTTPHeaderWithValue("X-Vcap-Request-Id", corrID))
 		Expect(buf.String()).To(ContainSubstring("hello"))
 		Expect(buf.String()).To(ContainSubstring(`"correlation-id":"` + corrID + `"`))
 	})
 
+	When("a vcap request ID is passed in a header", func() {
+		BeforeEach(func() {
+			requestHeaders.Set("X-Vcap-Request-Id", "my-vcap-id")
+			requestHeaders.Set("X-Correlation-Id", "my-corr-id")
+		})
+
+		It("prefers that ID", func() {
+			Expect(rr).To(HaveHTTPHeaderWithValue("X-Vcap-Request-Id", Equal("my-vcap-id")))
+			Expect(rr).To(HaveHTTPHeaderWithValue("X-Correlation-Id", Equal("my-vcap-id")))
+			Expect(buf.String()).To(ContainSubstring(`"correlation-id":"my-vcap-id"`))
+		})
+	})
+
 	When("correlation ID is passed in a header", func() {
 		BeforeEach(func() {
 			requestHeaders.Set("X-Correlation-Id", "my-corr-id")
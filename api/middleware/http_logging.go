@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -18,6 +19,10 @@ func (w *responseWriterWrapper) Header() http.Header {
 }
 
 func (w *responseWriterWrapper) Write(bytes []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
 	size, err := w.writer.Write(bytes)
 	w.size += size
 	return size, err
@@ -28,6 +33,26 @@ func (w *responseWriterWrapper) WriteHeader(statusCode int) {
 	w.status = statusCode
 }
 
+// Unwrap allows http.ResponseController to reach the underlying writer, e.g.
+// for flushing streamed responses
+func (w *responseWriterWrapper) Unwrap() http.ResponseWriter {
+	return w.writer
+}
+
+// requestUser is filled in by the authentication middleware further down the
+// chain, so that the user can be logged once the response has been written
+type requestUser struct {
+	name string
+}
+
+type requestUserKey struct{}
+
+func setRequestUser(ctx context.Context, name string) {
+	if user, ok := ctx.Value(requestUserKey{}).(*requestUser); ok {
+		user.name = name
+	}
+}
+
 func HTTPLogging(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t1 := time.Now()
@@ -35,8 +60,17 @@ func HTTPLogging(next http.Handler) http.Handler {
 
 		logger.Info("request", "url", r.URL, "method", r.Method, "remoteAddr", r.RemoteAddr, "contentLength", r.ContentLength)
 
+		user := &requestUser{}
 		wrapper := &responseWriterWrapper{writer: w}
-		next.ServeHTTP(wrapper, r)
-		logger.Info("response", "url", r.URL, "method", r.Method, "status", wrapper.status, "size", wrapper.size, "durationMillis", time.Since(t1).Milliseconds())
+		next.ServeHTTP(wrapper, r.WithContext(context.WithValue(r.Context(), requestUserKey{}, user)))
+		logger.Info("response",
+			"url", r.URL,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", wrapper.status,
+			"size", wrapper.size,
+			"durationMillis", time.Since(t1).Milliseconds(),
+			"user", user.name,
+		)
 	})
 }
@@ -8,16 +8,23 @@ import (
 	"net/http/httptest"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/middleware"
+	"code.cloudfoundry.org/korifi/api/middleware/fake"
 	"github.com/go-logr/logr"
 	"github.com/go-logr/logr/funcr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 var _ = Describe("HttpLoggingMiddleware", func() {
 	var logLines []string
 
+	BeforeEach(func() {
+		logLines = nil
+	})
+
 	It("logs the request", func() {
 		res := httptest.NewRecorder()
 		ctx := logr.NewContext(context.Background(), funcr.NewJSON(func(obj string) {
@@ -52,5 +59,33 @@ var _ = Describe("HttpLoggingMiddleware", func() {
 		Expect(resLog).To(HaveKeyWithValue("msg", "response"))
 		Expect(resLog).To(HaveKeyWithValue("status", float64(http.StatusTeapot)))
 		Expect(resLog).To(HaveKeyWithValue("size", float64(13)))
+		Expect(resLog).To(HaveKeyWithValue("method", "POST"))
+		Expect(resLog).To(HaveKeyWithValue("path", "/path"))
+		Expect(resLog).To(HaveKey("durationMillis"))
+		Expect(resLog).To(HaveKeyWithValue("user", ""))
+	})
+
+	It("logs the user resolved by the authentication middleware", func() {
+		ctx := logr.NewContext(context.Background(), funcr.NewJSON(func(obj string) {
+			logLines = append(logLines, obj)
+		}, funcr.Options{}))
+		req, err := http.NewRequestWithContext(ctx, "GET", "/v3/apps", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		authInfoParser := new(fake.AuthInfoParser)
+		identityProvider := new(fake.IdentityProvider)
+		identityProvider.GetIdentityReturns(authorization.Identity{Name: "the-user", Kind: rbacv1.UserKind}, nil)
+
+		middleware.HTTPLogging(
+			middleware.Authentication(authInfoParser, identityProvider)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, "hello, world!")
+			})),
+		).ServeHTTP(httptest.NewRecorder(), req)
+
+		resLog := map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(logLines[len(logLines)-1]), &resLog)).To(Succeed())
+		Expect(resLog).To(HaveKeyWithValue("msg", "response"))
+		Expect(resLog).To(HaveKeyWithValue("status", float64(http.StatusOK)))
+		Expect(resLog).To(HaveKeyWithValue("user", "the-user"))
 	})
 })
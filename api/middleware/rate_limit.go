@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+type rateLimit struct {
+	limit       rate.Limit
+	burst       int
+	idleTimeout time.Duration
+	exemptUsers map[string]bool
+	buckets     *cache.Expiring
+	bucketsMu   sync.Mutex
+}
+
+// RateLimit applies a token bucket to each authenticated identity. Buckets
+// are kept in the given cache and dropped once unused for idleTimeout, so
+// identities that only make a handful of requests do not accumulate.
+func RateLimit(
+	requestsPerSecond float64,
+	burst int,
+	idleTimeout time.Duration,
+	exemptUsers []string,
+	buckets *cache.Expiring,
+) func(http.Handler) http.Handler {
+	exempt := map[string]bool{}
+	for _, user := range exemptUsers {
+		exempt[user] = true
+	}
+
+	return (&rateLimit{
+		limit:       rate.Limit(requestsPerSecond),
+		burst:       burst,
+		idleTimeout: idleTimeout,
+		exemptUsers: exempt,
+		buckets:     buckets,
+	}).middleware
+}
+
+func (m *rateLimit) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := authorization.IdentityFromContext(r.Context())
+		if !ok || m.exemptUsers[identity.Name] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		reservation := m.bucket(identity).Reserve()
+		if delay := reservation.Delay(); delay > 0 {
+			reservation.Cancel()
+
+			logger := logr.FromContextOrDiscard(r.Context()).WithName("rate-limit-middleware")
+			logger.Info("rate limit exceeded", "user", identity.Name, "retryAfter", delay)
+
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			routing.PresentError(logger, w, apierrors.NewRateLimitExceededError())
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *rateLimit) bucket(identity authorization.Identity) *rate.Limiter {
+	m.bucketsMu.Lock()
+	defer m.bucketsMu.Unlock()
+
+	key := identity.Hash()
+	limiter, ok := m.buckets.Get(key)
+	if !ok {
+		limiter = rate.NewLimiter(m.limit, m.burst)
+	}
+
+	// Setting the bucket on every request pushes back its expiry, so only
+	// buckets that have been idle for the whole timeout get evicted
+	m.buckets.Set(key, limiter, m.idleTimeout)
+
+	return limiter.(*rate.Limiter)
+}
@@ -0,0 +1,129 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/middleware"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/utils/clock/testing"
+)
+
+var _ = Describe("RateLimit", func() {
+	var (
+		rateLimitMiddleware func(http.Handler) http.Handler
+		teapotHandler       http.Handler
+		fakeClock           *testing.FakeClock
+		buckets             *cache.Expiring
+		exemptUsers         []string
+		ctx                 context.Context
+	)
+
+	serve := func() *httptest.ResponseRecorder {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/v3/apps", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		recorder := httptest.NewRecorder()
+		rateLimitMiddleware(teapotHandler).ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	BeforeEach(func() {
+		teapotHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+
+		ctx = authorization.NewIdentityContext(context.Background(), authorization.Identity{
+			Name: "bob",
+			Kind: rbacv1.UserKind,
+		})
+
+		fakeClock = testing.NewFakeClock(time.Now())
+		buckets = cache.NewExpiringWithClock(fakeClock)
+		exemptUsers = []string{"admin"}
+	})
+
+	JustBeforeEach(func() {
+		rateLimitMiddleware = middleware.RateLimit(0.01, 2, time.Minute, exemptUsers, buckets)
+	})
+
+	It("lets requests within the burst through", func() {
+		Expect(serve()).To(HaveHTTPStatus(http.StatusTeapot))
+		Expect(serve()).To(HaveHTTPStatus(http.StatusTeapot))
+	})
+
+	When("the burst is exhausted", func() {
+		JustBeforeEach(func() {
+			serve()
+			serve()
+		})
+
+		It("returns a rate limit exceeded error with a Retry-After header", func() {
+			recorder := serve()
+			Expect(recorder).To(HaveHTTPStatus(http.StatusTooManyRequests))
+			Expect(recorder).To(HaveHTTPHeaderWithValue("Retry-After", "100"))
+			Expect(recorder).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.errors[0].title", "CF-RateLimitExceeded"),
+				MatchJSONPath("$.errors[0].code", BeEquivalentTo(10013)),
+			)))
+		})
+
+		It("does not limit other users", func() {
+			ctx = authorization.NewIdentityContext(context.Background(), authorization.Identity{
+				Name: "alice",
+				Kind: rbacv1.UserKind,
+			})
+			Expect(serve()).To(HaveHTTPStatus(http.StatusTeapot))
+		})
+
+		It("keeps the bucket while it is in use", func() {
+			Expect(buckets.Len()).To(Equal(1))
+		})
+
+		When("the bucket has been idle for longer than the idle timeout", func() {
+			JustBeforeEach(func() {
+				fakeClock.Step(2 * time.Minute)
+			})
+
+			It("evicts it", func() {
+				ctx = authorization.NewIdentityContext(context.Background(), authorization.Identity{
+					Name: "alice",
+					Kind: rbacv1.UserKind,
+				})
+				serve()
+				Expect(buckets.Len()).To(Equal(1))
+			})
+		})
+	})
+
+	When("the user is exempt", func() {
+		BeforeEach(func() {
+			exemptUsers = []string{"bob"}
+		})
+
+		It("is never limited", func() {
+			for range 5 {
+				Expect(serve()).To(HaveHTTPStatus(http.StatusTeapot))
+			}
+		})
+	})
+
+	When("there is no identity in the context", func() {
+		BeforeEach(func() {
+			ctx = context.Background()
+		})
+
+		It("passes the request through", func() {
+			for range 5 {
+				Expect(serve()).To(HaveHTTPStatus(http.StatusTeapot))
+			}
+		})
+	})
+})
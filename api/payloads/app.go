@@ -6,6 +6,7 @@ import (
 	"regexp"
 
 	"code.cloudfoundry.org/korifi/api/config"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -81,17 +82,92 @@ func (d AppSetCurrentDroplet) Validate() error {
 	)
 }
 
+type AppListDroplets struct {
+	States  string
+	Current *bool
+	Pagination
+}
+
+func (a AppListDroplets) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.States, jellidation.By(func(value any) error {
+			states, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%T is not supported, string is expected", value)
+			}
+
+			return jellidation.Each(validation.OneOf(
+				"AWAITING_UPLOAD",
+				"PROCESSING_UPLOAD",
+				"STAGED",
+				"COPYING",
+				"FAILED",
+				"EXPIRED",
+			)).Validate(parse.ArrayParam(states))
+		})),
+		jellidation.Field(&a.Pagination),
+	)
+}
+
+func (a *AppListDroplets) ToMessage(appGUID string) repositories.ListDropletsMessage {
+	return repositories.ListDropletsMessage{
+		AppGUIDs: []string{appGUID},
+		States:   parse.ArrayParam(a.States),
+	}
+}
+
+func (a *AppListDroplets) SupportedKeys() []string {
+	return []string{"states", "current", "per_page", "page"}
+}
+
+func (a *AppListDroplets) DecodeFromURLValues(values url.Values) error {
+	a.States = values.Get("states")
+
+	current, err := parseBool(values.Get("current"))
+	if err != nil {
+		return fmt.Errorf("failed to parse 'current' query parameter: %w", err)
+	}
+	a.Current = current
+
+	return a.Pagination.DecodeFromURLValues(values)
+}
+
+var appIncludes = []string{"space", "space.organization"}
+
+type AppGet struct {
+	IncludeResourceRules []params.IncludeResourceRule
+}
+
+func (a AppGet) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.IncludeResourceRules, validation.OneOfIncludes(appIncludes...)),
+	)
+}
+
+func (a *AppGet) SupportedKeys() []string {
+	return []string{"include"}
+}
+
+func (a *AppGet) DecodeFromURLValues(values url.Values) error {
+	a.IncludeResourceRules = append(a.IncludeResourceRules, params.ParseIncludes(values)...)
+	return nil
+}
+
 type AppList struct {
-	Names         string
-	GUIDs         string
-	SpaceGUIDs    string
-	OrderBy       string
-	LabelSelector string
+	Names                string
+	GUIDs                string
+	SpaceGUIDs           string
+	OrderBy              string
+	LabelSelector        string
+	IncludeResourceRules []params.IncludeResourceRule
+	Pagination
 }
 
 func (a AppList) Validate() error {
 	return jellidation.ValidateStruct(&a,
 		jellidation.Field(&a.OrderBy, validation.OneOfOrderBy("created_at", "updated_at", "name", "state")),
+		jellidation.Field(&a.IncludeResourceRules, validation.OneOfIncludes(appIncludes...)),
+		jellidation.Field(&a.Pagination),
 	)
 }
 
@@ -106,7 +182,7 @@ func (a *AppList) ToMessage() repositories.ListAppsMessage {
 }
 
 func (a *AppList) SupportedKeys() []string {
-	return []string{"names", "guids", "space_guids", "order_by", "per_page", "page", "label_selector"}
+	return []string{"names", "guids", "space_guids", "order_by", "per_page", "page", "label_selector", "include"}
 }
 
 func (a *AppList) DecodeFromURLValues(values url.Values) error {
@@ -115,7 +191,8 @@ func (a *AppList) DecodeFromURLValues(values url.Values) error {
 	a.SpaceGUIDs = values.Get("space_guids")
 	a.OrderBy = values.Get("order_by")
 	a.LabelSelector = values.Get("label_selector")
-	return nil
+	a.IncludeResourceRules = append(a.IncludeResourceRules, params.ParseIncludes(values)...)
+	return a.Pagination.DecodeFromURLValues(values)
 }
 
 type AppPatchEnvVars struct {
@@ -204,3 +281,48 @@ func (a *AppPatch) ToMessage(appGUID, spaceGUID string) repositories.PatchAppMes
 
 	return msg
 }
+
+type AppFeatureUpdate struct {
+	Enabled *bool `json:"enabled"`
+}
+
+func (f AppFeatureUpdate) Validate() error {
+	return jellidation.ValidateStruct(&f,
+		jellidation.Field(&f.Enabled, jellidation.NotNil),
+	)
+}
+
+const defaultExecCommand = "/bin/sh"
+
+type AppInstanceExec struct {
+	Command []string
+	TTY     bool
+}
+
+func (e *AppInstanceExec) SupportedKeys() []string {
+	return []string{"command", "tty"}
+}
+
+func (e *AppInstanceExec) DecodeFromURLValues(values url.Values) error {
+	e.Command = values["command"]
+	if len(e.Command) == 0 {
+		e.Command = []string{defaultExecCommand}
+	}
+
+	var err error
+	if e.TTY, err = getBool(values, "tty"); err != nil {
+		return fmt.Errorf("failed to parse 'tty' query parameter: %w", err)
+	}
+
+	return nil
+}
+
+func (e *AppInstanceExec) ToMessage(appRevision string, process repositories.ProcessRecord, instanceID string) repositories.ExecInstanceMessage {
+	return repositories.ExecInstanceMessage{
+		AppRevision: appRevision,
+		Process:     process,
+		InstanceID:  instanceID,
+		Command:     e.Command,
+		TTY:         e.TTY,
+	}
+}
@@ -1,7 +1,9 @@
 package payloads_test
 
 import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
@@ -9,6 +11,37 @@ import (
 	"github.com/onsi/gomega/gstruct"
 )
 
+var _ = Describe("AppGet", func() {
+	DescribeTable("valid query",
+		func(query string, expectedAppGet payloads.AppGet) {
+			actualAppGet, decodeErr := decodeQuery[payloads.AppGet](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualAppGet).To(Equal(expectedAppGet))
+		},
+		Entry("no include", "", payloads.AppGet{}),
+		Entry("include space and organization", "include=space,space.organization", payloads.AppGet{IncludeResourceRules: []params.IncludeResourceRule{
+			{
+				RelationshipPath: []string{"space"},
+				Fields:           []string{},
+			},
+			{
+				RelationshipPath: []string{"space", "organization"},
+				Fields:           []string{},
+			},
+		}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErr error) {
+			_, decodeErr := decodeQuery[payloads.AppGet](query)
+			Expect(decodeErr).To(BeAssignableToTypeOf(expectedErr))
+		},
+		Entry("unsupported key", "foo=bar", apierrors.UnknownKeyError{}),
+		Entry("unsupported include", "include=organization", apierrors.BadQueryParamValueError{}),
+	)
+})
+
 var _ = Describe("AppList", func() {
 	Describe("Validation", func() {
 		DescribeTable("valid query",
@@ -31,6 +64,17 @@ var _ = Describe("AppList", func() {
 			Entry("order_by state", "order_by=state", payloads.AppList{OrderBy: "state"}),
 			Entry("order_by -state", "order_by=-state", payloads.AppList{OrderBy: "-state"}),
 			Entry("label_selector=foo", "label_selector=foo", payloads.AppList{LabelSelector: "foo"}),
+			Entry("per_page", "per_page=10", payloads.AppList{Pagination: payloads.Pagination{PerPage: "10"}}),
+			Entry("per_page max", "per_page=5000", payloads.AppList{Pagination: payloads.Pagination{PerPage: "5000"}}),
+			Entry("page", "page=3", payloads.AppList{Pagination: payloads.Pagination{Page: "3"}}),
+			Entry("include space", "include=space", payloads.AppList{IncludeResourceRules: []params.IncludeResourceRule{{
+				RelationshipPath: []string{"space"},
+				Fields:           []string{},
+			}}}),
+			Entry("include space.organization", "include=space.organization", payloads.AppList{IncludeResourceRules: []params.IncludeResourceRule{{
+				RelationshipPath: []string{"space", "organization"},
+				Fields:           []string{},
+			}}}),
 		)
 
 		DescribeTable("invalid query",
@@ -38,8 +82,38 @@ var _ = Describe("AppList", func() {
 				_, decodeErr := decodeQuery[payloads.AppList](query)
 				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
 			},
-			Entry("invalid order_by", "order_by=foo", "value must be one of"),
+			Entry("per_page is not a number", "per_page=foo", "per_page: must be an integer between 1 and 5000"),
+			Entry("per_page is zero", "per_page=0", "per_page: must be an integer between 1 and 5000"),
+			Entry("per_page is too large", "per_page=5001", "per_page: must be an integer between 1 and 5000"),
+			Entry("page is not a number", "page=foo", "page: must be a positive integer"),
+			Entry("page is zero", "page=0", "page: must be a positive integer"),
 		)
+
+		It("rejects an unsupported order_by with a bad query parameter error", func() {
+			_, decodeErr := decodeQuery[payloads.AppList]("order_by=foo")
+			Expect(decodeErr).To(BeAssignableToTypeOf(apierrors.BadQueryParamValueError{}))
+			Expect(decodeErr.(apierrors.BadQueryParamValueError).Detail()).To(ContainSubstring("order_by can only be"))
+		})
+
+		It("rejects unsupported includes with a bad query parameter error", func() {
+			_, decodeErr := decodeQuery[payloads.AppList]("include=droplet")
+			Expect(decodeErr).To(BeAssignableToTypeOf(apierrors.BadQueryParamValueError{}))
+			Expect(decodeErr.(apierrors.BadQueryParamValueError).Detail()).To(ContainSubstring("include can only be: 'space', 'space.organization'"))
+		})
+	})
+
+	Describe("pagination", func() {
+		It("defaults to the first page of the default page size", func() {
+			appList := payloads.AppList{}
+			Expect(appList.PageNumber()).To(Equal(1))
+			Expect(appList.PerPageNumber()).To(Equal(payloads.DefaultPerPage))
+		})
+
+		It("returns the requested page and page size", func() {
+			appList := payloads.AppList{Pagination: payloads.Pagination{Page: "3", PerPage: "20"}}
+			Expect(appList.PageNumber()).To(Equal(3))
+			Expect(appList.PerPageNumber()).To(Equal(20))
+		})
 	})
 
 	Describe("ToMessage", func() {
@@ -62,6 +136,82 @@ var _ = Describe("AppList", func() {
 	})
 })
 
+var _ = Describe("AppListDroplets", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedAppListDroplets payloads.AppListDroplets) {
+				actualAppListDroplets, decodeErr := decodeQuery[payloads.AppListDroplets](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualAppListDroplets).To(Equal(expectedAppListDroplets))
+			},
+
+			Entry("states", "states=STAGED,FAILED", payloads.AppListDroplets{States: "STAGED,FAILED"}),
+			Entry("current", "current=true", payloads.AppListDroplets{Current: tools.PtrTo(true)}),
+			Entry("page", "page=3", payloads.AppListDroplets{Pagination: payloads.Pagination{Page: "3"}}),
+			Entry("per_page", "per_page=10", payloads.AppListDroplets{Pagination: payloads.Pagination{PerPage: "10"}}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.AppListDroplets](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid states", "states=STAGED,foo", "value must be one of"),
+			Entry("invalid current", "current=foo", "failed to parse 'current' query parameter"),
+			Entry("invalid per_page", "per_page=0", "must be an integer between"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			appListDroplets := payloads.AppListDroplets{States: "STAGED,FAILED"}
+			Expect(appListDroplets.ToMessage("app-guid")).To(Equal(repositories.ListDropletsMessage{
+				AppGUIDs: []string{"app-guid"},
+				States:   []string{"STAGED", "FAILED"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("AppInstanceExec", func() {
+	DescribeTable("valid query",
+		func(query string, expectedAppInstanceExec payloads.AppInstanceExec) {
+			actualAppInstanceExec, decodeErr := decodeQuery[payloads.AppInstanceExec](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualAppInstanceExec).To(Equal(expectedAppInstanceExec))
+		},
+
+		Entry("default command", "", payloads.AppInstanceExec{Command: []string{"/bin/sh"}}),
+		Entry("command", "command=ls&command=-la", payloads.AppInstanceExec{Command: []string{"ls", "-la"}}),
+		Entry("tty", "tty=true", payloads.AppInstanceExec{Command: []string{"/bin/sh"}, TTY: true}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AppInstanceExec](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid tty", "tty=foo", "failed to parse 'tty' query parameter"),
+		Entry("unsupported key", "foo=bar", "unsupported query parameter"),
+	)
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			appInstanceExec := payloads.AppInstanceExec{Command: []string{"ls"}, TTY: true}
+			process := repositories.ProcessRecord{GUID: "process-guid"}
+			Expect(appInstanceExec.ToMessage("app-rev", process, "2")).To(Equal(repositories.ExecInstanceMessage{
+				AppRevision: "app-rev",
+				Process:     process,
+				InstanceID:  "2",
+				Command:     []string{"ls"},
+				TTY:         true,
+			}))
+		})
+	})
+})
+
 var _ = Describe("App payload validation", func() {
 	var validatorErr error
 
@@ -449,6 +599,40 @@ var _ = Describe("App payload validation", func() {
 		})
 	})
 
+	Describe("AppFeatureUpdate", func() {
+		var (
+			payload        payloads.AppFeatureUpdate
+			decodedPayload *payloads.AppFeatureUpdate
+		)
+
+		BeforeEach(func() {
+			payload = payloads.AppFeatureUpdate{
+				Enabled: tools.PtrTo(true),
+			}
+
+			decodedPayload = new(payloads.AppFeatureUpdate)
+		})
+
+		JustBeforeEach(func() {
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+		})
+
+		When("enabled is not set", func() {
+			BeforeEach(func() {
+				payload.Enabled = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "enabled is required")
+			})
+		})
+	})
+
 	Describe("AppPatchEnvVars", func() {
 		var (
 			payload        payloads.AppPatchEnvVars
@@ -1,6 +1,10 @@
 package payloads
 
 import (
+	"fmt"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/jellydator/validation"
@@ -46,3 +50,47 @@ func (c *BuildCreate) ToMessage(appRecord repositories.AppRecord) repositories.C
 
 	return toReturn
 }
+
+type BuildList struct {
+	PackageGUIDs string
+	AppGUIDs     string
+	States       string
+	Pagination
+}
+
+func (b BuildList) Validate() error {
+	return validation.ValidateStruct(&b,
+		validation.Field(&b.States, validation.By(func(value any) error {
+			states, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%T is not supported, string is expected", value)
+			}
+
+			return validation.Each(payload_validation.OneOf(
+				repositories.BuildStateStaging,
+				repositories.BuildStateStaged,
+				repositories.BuildStateFailed,
+			)).Validate(parse.ArrayParam(states))
+		})),
+		validation.Field(&b.Pagination),
+	)
+}
+
+func (b *BuildList) ToMessage() repositories.ListBuildsMessage {
+	return repositories.ListBuildsMessage{
+		PackageGUIDs: parse.ArrayParam(b.PackageGUIDs),
+		AppGUIDs:     parse.ArrayParam(b.AppGUIDs),
+		States:       parse.ArrayParam(b.States),
+	}
+}
+
+func (b *BuildList) SupportedKeys() []string {
+	return []string{"package_guids", "app_guids", "states", "per_page", "page"}
+}
+
+func (b *BuildList) DecodeFromURLValues(values url.Values) error {
+	b.PackageGUIDs = values.Get("package_guids")
+	b.AppGUIDs = values.Get("app_guids")
+	b.States = values.Get("states")
+	return b.Pagination.DecodeFromURLValues(values)
+}
@@ -145,3 +145,46 @@ var _ = Describe("BuildCreate", func() {
 		})
 	})
 })
+
+var _ = Describe("BuildList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedBuildList payloads.BuildList) {
+				actualBuildList, decodeErr := decodeQuery[payloads.BuildList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualBuildList).To(Equal(expectedBuildList))
+			},
+
+			Entry("package_guids", "package_guids=p1,p2", payloads.BuildList{PackageGUIDs: "p1,p2"}),
+			Entry("app_guids", "app_guids=a1,a2", payloads.BuildList{AppGUIDs: "a1,a2"}),
+			Entry("states", "states=STAGING,STAGED,FAILED", payloads.BuildList{States: "STAGING,STAGED,FAILED"}),
+			Entry("page", "page=3", payloads.BuildList{Pagination: payloads.Pagination{Page: "3"}}),
+			Entry("per_page", "per_page=10", payloads.BuildList{Pagination: payloads.Pagination{PerPage: "10"}}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.BuildList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid states", "states=STAGED,foo", "value must be one of"),
+			Entry("invalid per_page", "per_page=0", "must be an integer between"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			buildList := payloads.BuildList{
+				PackageGUIDs: "p1,p2",
+				AppGUIDs:     "a1",
+				States:       "STAGED,FAILED",
+			}
+			Expect(buildList.ToMessage()).To(Equal(repositories.ListBuildsMessage{
+				PackageGUIDs: []string{"p1", "p2"},
+				AppGUIDs:     []string{"a1"},
+				States:       []string{"STAGED", "FAILED"},
+			}))
+		})
+	})
+})
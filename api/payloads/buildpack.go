@@ -1,6 +1,8 @@
 package payloads
 
 import (
+	"errors"
+	"fmt"
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
@@ -32,3 +34,36 @@ func (d BuildpackList) Validate() error {
 		jellidation.Field(&d.OrderBy, validation.OneOfOrderBy("created_at", "updated_at", "position")),
 	)
 }
+
+type BuildpackOrderUpdate struct {
+	Buildpacks []string `json:"buildpacks"`
+}
+
+func (b BuildpackOrderUpdate) Validate() error {
+	return jellidation.ValidateStruct(&b,
+		jellidation.Field(&b.Buildpacks, jellidation.Required, jellidation.Each(jellidation.Required), jellidation.By(validateNoDuplicates)),
+	)
+}
+
+func (b BuildpackOrderUpdate) ToMessage() repositories.UpdateBuildpackOrderMessage {
+	return repositories.UpdateBuildpackOrderMessage{
+		Buildpacks: b.Buildpacks,
+	}
+}
+
+func validateNoDuplicates(value any) error {
+	names, ok := value.([]string)
+	if !ok {
+		return errors.New("wrong input")
+	}
+
+	seen := map[string]bool{}
+	for _, name := range names {
+		if seen[name] {
+			return fmt.Errorf("%q is listed more than once", name)
+		}
+		seen[name] = true
+	}
+
+	return nil
+}
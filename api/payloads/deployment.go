@@ -5,10 +5,12 @@ import (
 	"net/url"
 	"regexp"
 	"slices"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 	"github.com/BooleanCat/go-functional/v2/it"
 	jellidation "github.com/jellydator/validation"
 )
@@ -17,21 +19,55 @@ type DropletGUID struct {
 	Guid string `json:"guid"`
 }
 
+type RevisionGUID struct {
+	Guid string `json:"guid"`
+}
+
+func (r RevisionGUID) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Guid, jellidation.Required))
+}
+
 type DeploymentCreate struct {
 	Droplet       DropletGUID              `json:"droplet"`
+	Revision      *RevisionGUID            `json:"revision,omitempty"`
 	Relationships *DeploymentRelationships `json:"relationships"`
+	Options       *DeploymentOptions       `json:"options,omitempty"`
 }
 
 func (c DeploymentCreate) Validate() error {
 	return jellidation.ValidateStruct(&c,
-		jellidation.Field(&c.Relationships, jellidation.NotNil))
+		jellidation.Field(&c.Revision, jellidation.When(c.Droplet.Guid != "", jellidation.Nil.Error("and droplet may not be used together"))),
+		jellidation.Field(&c.Relationships, jellidation.NotNil),
+		jellidation.Field(&c.Options))
 }
 
 func (c *DeploymentCreate) ToMessage() repositories.CreateDeploymentMessage {
-	return repositories.CreateDeploymentMessage{
+	message := repositories.CreateDeploymentMessage{
 		AppGUID:     c.Relationships.App.Data.GUID,
 		DropletGUID: c.Droplet.Guid,
 	}
+
+	if c.Revision != nil {
+		message.RevisionGUID = c.Revision.Guid
+	}
+
+	if c.Options != nil && c.Options.ReadinessTimeout != nil {
+		message.ReadinessTimeout = tools.PtrTo(time.Duration(*c.Options.ReadinessTimeout) * time.Second)
+	}
+
+	return message
+}
+
+type DeploymentOptions struct {
+	// ReadinessTimeout is the number of seconds the app has to become ready
+	// before the deployment is rolled back to the previous droplet
+	ReadinessTimeout *int64 `json:"readiness_timeout,omitempty"`
+}
+
+func (o DeploymentOptions) Validate() error {
+	return jellidation.ValidateStruct(&o,
+		jellidation.Field(&o.ReadinessTimeout, jellidation.Min(1), jellidation.NilOrNotEmpty.Error("must be no less than 1")))
 }
 
 type DeploymentRelationships struct {
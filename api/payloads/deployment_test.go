@@ -1,8 +1,12 @@
 package payloads_test
 
 import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"time"
+
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 	"github.com/onsi/gomega/gstruct"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -86,6 +90,64 @@ var _ = Describe("DeploymentCreate", func() {
 				expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
 			})
 		})
+
+		When("a revision is specified instead of a droplet", func() {
+			BeforeEach(func() {
+				createDeployment.Droplet = payloads.DropletGUID{}
+				createDeployment.Revision = &payloads.RevisionGUID{Guid: "the-revision"}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedDeploymentPayload).To(gstruct.PointTo(Equal(createDeployment)))
+			})
+		})
+
+		When("both a revision and a droplet are specified", func() {
+			BeforeEach(func() {
+				createDeployment.Revision = &payloads.RevisionGUID{Guid: "the-revision"}
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "revision and droplet may not be used together")
+			})
+		})
+
+		When("the revision guid is blank", func() {
+			BeforeEach(func() {
+				createDeployment.Droplet = payloads.DropletGUID{}
+				createDeployment.Revision = &payloads.RevisionGUID{}
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "revision.guid cannot be blank")
+			})
+		})
+
+		When("a readiness timeout is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Options = &payloads.DeploymentOptions{
+					ReadinessTimeout: tools.PtrTo[int64](60),
+				}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedDeploymentPayload).To(gstruct.PointTo(Equal(createDeployment)))
+			})
+		})
+
+		When("the readiness timeout is not positive", func() {
+			BeforeEach(func() {
+				createDeployment.Options = &payloads.DeploymentOptions{
+					ReadinessTimeout: tools.PtrTo[int64](0),
+				}
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "options.readiness_timeout must be no less than 1")
+			})
+		})
 	})
 
 	Describe("ToMessage", func() {
@@ -101,6 +163,32 @@ var _ = Describe("DeploymentCreate", func() {
 				DropletGUID: "the-droplet",
 			}))
 		})
+
+		When("a readiness timeout is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Options = &payloads.DeploymentOptions{
+					ReadinessTimeout: tools.PtrTo[int64](60),
+				}
+			})
+
+			It("sets it on the message", func() {
+				Expect(createMessage.ReadinessTimeout).To(gstruct.PointTo(Equal(time.Minute)))
+			})
+		})
+
+		When("a revision is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Droplet = payloads.DropletGUID{}
+				createDeployment.Revision = &payloads.RevisionGUID{Guid: "the-revision"}
+			})
+
+			It("sets it on the message", func() {
+				Expect(createMessage).To(Equal(repositories.CreateDeploymentMessage{
+					AppGUID:      "the-app",
+					RevisionGUID: "the-revision",
+				}))
+			})
+		})
 	})
 })
 
@@ -128,9 +216,13 @@ var _ = Describe("DeploymentList", func() {
 				_, decodeErr := decodeQuery[payloads.DeploymentList](query)
 				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
 			},
-			Entry("invalid order_by", "order_by=foo", "value must be one of"),
 			Entry("invalid status_values", "status_values=foo", "value must be one of"),
 		)
+
+		It("rejects an unsupported order_by with a bad query parameter error", func() {
+			_, decodeErr := decodeQuery[payloads.DeploymentList]("order_by=foo")
+			Expect(decodeErr).To(BeAssignableToTypeOf(apierrors.BadQueryParamValueError{}))
+		})
 	})
 
 	Describe("ToMessage", func() {
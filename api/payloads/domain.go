@@ -30,17 +30,24 @@ func (c *DomainCreate) ToMessage() (repositories.CreateDomainMessage, error) {
 		return repositories.CreateDomainMessage{}, errors.New("internal domains are not supported")
 	}
 
-	if len(c.Relationships) > 0 {
-		return repositories.CreateDomainMessage{}, errors.New("private domains are not supported")
+	orgRelationship, hasOrg := c.Relationships["organization"]
+	if len(c.Relationships) > 1 || (len(c.Relationships) == 1 && !hasOrg) {
+		return repositories.CreateDomainMessage{}, errors.New("only the organization relationship is supported")
 	}
 
-	return repositories.CreateDomainMessage{
+	message := repositories.CreateDomainMessage{
 		Name: c.Name,
 		Metadata: repositories.Metadata{
 			Labels:      c.Metadata.Labels,
 			Annotations: c.Metadata.Annotations,
 		},
-	}, nil
+	}
+
+	if hasOrg && orgRelationship.Data != nil {
+		message.OrgGUID = orgRelationship.Data.GUID
+	}
+
+	return message, nil
 }
 
 type DomainUpdate struct {
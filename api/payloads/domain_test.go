@@ -123,7 +123,20 @@ var _ = Describe("DomainCreate", func() {
 			})
 		})
 
-		When("the payload has relationships", func() {
+		When("the payload has an organization relationship", func() {
+			BeforeEach(func() {
+				createPayload.Relationships = map[string]payloads.Relationship{
+					"organization": {Data: &payloads.RelationshipData{GUID: "org-guid"}},
+				}
+			})
+
+			It("returns a private domain create message", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createMessage.OrgGUID).To(Equal("org-guid"))
+			})
+		})
+
+		When("the payload has other relationships", func() {
 			BeforeEach(func() {
 				createPayload.Relationships = map[string]payloads.Relationship{
 					"foo": {},
@@ -131,7 +144,7 @@ var _ = Describe("DomainCreate", func() {
 			})
 
 			It("errors", func() {
-				Expect(err).To(MatchError(ContainSubstring("private domains are not supported")))
+				Expect(err).To(MatchError(ContainSubstring("only the organization relationship is supported")))
 			})
 		})
 	})
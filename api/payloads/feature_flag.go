@@ -0,0 +1,25 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/jellydator/validation"
+)
+
+type FeatureFlagUpdate struct {
+	Enabled            *bool   `json:"enabled"`
+	CustomErrorMessage *string `json:"custom_error_message"`
+}
+
+func (f FeatureFlagUpdate) Validate() error {
+	return validation.ValidateStruct(&f,
+		validation.Field(&f.Enabled, validation.NotNil),
+	)
+}
+
+func (f FeatureFlagUpdate) ToMessage(name string) repositories.UpdateFeatureFlagMessage {
+	return repositories.UpdateFeatureFlagMessage{
+		Name:               name,
+		Enabled:            f.Enabled,
+		CustomErrorMessage: f.CustomErrorMessage,
+	}
+}
@@ -0,0 +1,56 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("FeatureFlagUpdate", func() {
+	var (
+		updatePayload        payloads.FeatureFlagUpdate
+		decodedUpdatePayload *payloads.FeatureFlagUpdate
+		validatorErr         error
+	)
+
+	BeforeEach(func() {
+		decodedUpdatePayload = new(payloads.FeatureFlagUpdate)
+
+		updatePayload = payloads.FeatureFlagUpdate{
+			Enabled:            tools.PtrTo(false),
+			CustomErrorMessage: tools.PtrTo("ask your admin"),
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedUpdatePayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedUpdatePayload).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("enabled is not set", func() {
+		BeforeEach(func() {
+			updatePayload.Enabled = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "enabled is required")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(updatePayload.ToMessage("user_org_creation")).To(Equal(repositories.UpdateFeatureFlagMessage{
+				Name:               "user_org_creation",
+				Enabled:            tools.PtrTo(false),
+				CustomErrorMessage: tools.PtrTo("ask your admin"),
+			}))
+		})
+	})
+})
@@ -42,6 +42,69 @@ func (l *LogRead) DecodeFromURLValues(values url.Values) error {
 	return nil
 }
 
+type LogStream struct {
+	StartTime *int64
+}
+
+func (l *LogStream) SupportedKeys() []string {
+	return []string{"start_time"}
+}
+
+func (l *LogStream) DecodeFromURLValues(values url.Values) error {
+	var err error
+	l.StartTime, err = getIntPtr(values, "start_time")
+	return err
+}
+
+type AppLogs struct {
+	Follow bool
+}
+
+func (l *AppLogs) SupportedKeys() []string {
+	return []string{"follow"}
+}
+
+func (l *AppLogs) DecodeFromURLValues(values url.Values) error {
+	var err error
+	l.Follow, err = getBool(values, "follow")
+	return err
+}
+
+const (
+	DefaultRecentLogsLimit = 100
+	MaxRecentLogsLimit     = 1000
+)
+
+type AppRecentLogs struct {
+	Limit string `json:"limit"`
+}
+
+func (l AppRecentLogs) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Limit, validation.IntegerBetween(1, MaxRecentLogsLimit)),
+	)
+}
+
+func (l *AppRecentLogs) SupportedKeys() []string {
+	return []string{"limit"}
+}
+
+func (l *AppRecentLogs) DecodeFromURLValues(values url.Values) error {
+	l.Limit = values.Get("limit")
+	return nil
+}
+
+// LimitNumber returns the requested number of log lines, defaulting to
+// DefaultRecentLogsLimit. It assumes the payload has been validated.
+func (l AppRecentLogs) LimitNumber() int64 {
+	if l.Limit == "" {
+		return DefaultRecentLogsLimit
+	}
+
+	limit, _ := strconv.ParseInt(l.Limit, 10, 64)
+	return limit
+}
+
 func getIntPtr(values url.Values, key string) (*int64, error) {
 	if !values.Has(key) {
 		return nil, nil
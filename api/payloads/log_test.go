@@ -40,3 +40,71 @@ var _ = Describe("LogRead", func() {
 		)
 	})
 })
+
+var _ = Describe("LogStream", func() {
+	DescribeTable("valid query",
+		func(query string, expectedLogStream payloads.LogStream) {
+			actualLogStream, decodeErr := decodeQuery[payloads.LogStream](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualLogStream).To(Equal(expectedLogStream))
+		},
+		Entry("start_time", "start_time=123", payloads.LogStream{StartTime: tools.PtrTo[int64](123)}),
+		Entry("start_time missing", "", payloads.LogStream{}),
+	)
+
+	It("fails on invalid start_time", func() {
+		_, decodeErr := decodeQuery[payloads.LogStream]("start_time=foo")
+		Expect(decodeErr).To(MatchError(ContainSubstring("invalid syntax")))
+	})
+})
+
+var _ = Describe("AppLogs", func() {
+	DescribeTable("valid query",
+		func(query string, expectedAppLogs payloads.AppLogs) {
+			actualAppLogs, decodeErr := decodeQuery[payloads.AppLogs](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualAppLogs).To(Equal(expectedAppLogs))
+		},
+		Entry("follow", "follow=true", payloads.AppLogs{Follow: true}),
+		Entry("follow missing", "", payloads.AppLogs{}),
+	)
+
+	It("fails on invalid follow", func() {
+		_, decodeErr := decodeQuery[payloads.AppLogs]("follow=foo")
+		Expect(decodeErr).To(MatchError(ContainSubstring("invalid syntax")))
+	})
+})
+
+var _ = Describe("AppRecentLogs", func() {
+	DescribeTable("valid query",
+		func(query string, expectedAppRecentLogs payloads.AppRecentLogs) {
+			actualAppRecentLogs, decodeErr := decodeQuery[payloads.AppRecentLogs](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualAppRecentLogs).To(Equal(expectedAppRecentLogs))
+		},
+		Entry("limit", "limit=10", payloads.AppRecentLogs{Limit: "10"}),
+		Entry("max limit", "limit=1000", payloads.AppRecentLogs{Limit: "1000"}),
+		Entry("limit missing", "", payloads.AppRecentLogs{}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AppRecentLogs](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("limit too small", "limit=0", "limit: must be an integer between 1 and 1000"),
+		Entry("limit too large", "limit=1001", "limit: must be an integer between 1 and 1000"),
+		Entry("invalid limit", "limit=foo", "limit: must be an integer between 1 and 1000"),
+	)
+
+	DescribeTable("LimitNumber",
+		func(payload payloads.AppRecentLogs, expectedLimit int64) {
+			Expect(payload.LimitNumber()).To(Equal(expectedLimit))
+		},
+		Entry("default", payloads.AppRecentLogs{}, int64(100)),
+		Entry("set", payloads.AppRecentLogs{Limit: "20"}, int64(20)),
+	)
+})
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/jellydator/validation"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 )
 
 type BuildMetadata struct {
@@ -28,8 +29,8 @@ type Metadata struct {
 
 func (m Metadata) Validate() error {
 	return validation.ValidateStruct(&m,
-		validation.Field(&m.Annotations, validation.Map().Keys(validation.By(cloudfoundryKeyCheck)).AllowExtraKeys()),
-		validation.Field(&m.Labels, validation.Map().Keys(validation.By(cloudfoundryKeyCheck)).AllowExtraKeys()),
+		validation.Field(&m.Annotations, annotationsRule()),
+		validation.Field(&m.Labels, labelsRule()),
 	)
 }
 
@@ -40,11 +41,59 @@ type MetadataPatch struct {
 
 func (p MetadataPatch) Validate() error {
 	return validation.ValidateStruct(&p,
-		validation.Field(&p.Annotations, validation.Map().Keys(validation.By(cloudfoundryKeyCheck)).AllowExtraKeys()),
-		validation.Field(&p.Labels, validation.Map().Keys(validation.By(cloudfoundryKeyCheck)).AllowExtraKeys()),
+		validation.Field(&p.Annotations, annotationsRule()),
+		validation.Field(&p.Labels, labelsRule()),
 	)
 }
 
+// Labels and annotations are stored as is on the underlying kubernetes
+// objects, so their keys (and label values) have to satisfy the kubernetes
+// constraints on top of the cloudfoundry ones
+func annotationsRule() validation.MapRule {
+	return validation.Map().
+		Keys(validation.By(cloudfoundryKeyCheck), validation.By(qualifiedNameCheck)).
+		AllowExtraKeys()
+}
+
+func labelsRule() validation.MapRule {
+	return validation.Map().
+		Keys(validation.By(cloudfoundryKeyCheck), validation.By(qualifiedNameCheck)).
+		Values(validation.By(labelValueCheck)).
+		AllowExtraKeys()
+}
+
+func qualifiedNameCheck(key any) error {
+	keyStr, ok := key.(string)
+	if !ok {
+		return fmt.Errorf("expected string key, got %T", key)
+	}
+
+	if errs := k8svalidation.IsQualifiedName(keyStr); len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func labelValueCheck(value any) error {
+	var valueStr string
+	switch v := value.(type) {
+	case string:
+		valueStr = v
+	case *string:
+		if v == nil {
+			return nil
+		}
+		valueStr = *v
+	default:
+		return fmt.Errorf("expected string value, got %T", value)
+	}
+
+	if errs := k8svalidation.IsValidLabelValue(valueStr); len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
 func cloudfoundryKeyCheck(key any) error {
 	keyStr, ok := key.(string)
 	if !ok {
@@ -55,6 +55,46 @@ var _ = Describe("Metadata", func() {
 			expectUnprocessableEntityError(validatorErr, "cannot use the cloudfoundry.org domain")
 		})
 	})
+
+	When("labels contains a key that is not a valid kubernetes name", func() {
+		BeforeEach(func() {
+			metadataPayload.Labels["foo bar"] = "jim"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name part must consist of alphanumeric characters")
+		})
+	})
+
+	When("labels contains a value that is not a valid kubernetes label value", func() {
+		BeforeEach(func() {
+			metadataPayload.Labels["foo"] = "not/valid"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "a valid label must be an empty string or consist of alphanumeric characters")
+		})
+	})
+
+	When("annotations contains a key that is not a valid kubernetes name", func() {
+		BeforeEach(func() {
+			metadataPayload.Annotations["example.org/jim/bob"] = "hello"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "a qualified name must consist of alphanumeric characters")
+		})
+	})
+
+	When("annotations contains a value that is not a valid label value", func() {
+		BeforeEach(func() {
+			metadataPayload.Annotations["example.org/jim"] = "any value / goes"
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+		})
+	})
 })
 
 var _ = Describe("MetadataPatch", func() {
@@ -104,4 +144,34 @@ var _ = Describe("MetadataPatch", func() {
 			expectUnprocessableEntityError(validatorErr, "cannot use the cloudfoundry.org domain")
 		})
 	})
+
+	When("metadata.labels contains a key that is not a valid kubernetes name", func() {
+		BeforeEach(func() {
+			metadataPatchPayload.Labels["-foo"] = tools.PtrTo("jim")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name part must consist of alphanumeric characters")
+		})
+	})
+
+	When("metadata.labels contains a value that is not a valid kubernetes label value", func() {
+		BeforeEach(func() {
+			metadataPatchPayload.Labels["foo"] = tools.PtrTo("not valid")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "a valid label must be an empty string or consist of alphanumeric characters")
+		})
+	})
+
+	When("metadata.labels are removed", func() {
+		BeforeEach(func() {
+			metadataPatchPayload.Labels["foo"] = nil
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+		})
+	})
 })
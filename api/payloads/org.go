@@ -4,6 +4,7 @@ import (
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/jellydator/validation"
 )
@@ -50,20 +51,48 @@ func (p OrgPatch) ToMessage(orgGUID string) repositories.PatchOrgMetadataMessage
 }
 
 type OrgList struct {
-	Names string
+	Names   string
+	GUIDs   string
+	OrderBy string
+	Pagination
+}
+
+func (d OrgList) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.OrderBy, payload_validation.OneOfOrderBy("created_at", "updated_at", "name")),
+		validation.Field(&d.Pagination),
+	)
 }
 
 func (d *OrgList) ToMessage() repositories.ListOrgsMessage {
 	return repositories.ListOrgsMessage{
-		Names: parse.ArrayParam(d.Names),
+		Names:   parse.ArrayParam(d.Names),
+		GUIDs:   parse.ArrayParam(d.GUIDs),
+		OrderBy: d.OrderBy,
 	}
 }
 
 func (d *OrgList) SupportedKeys() []string {
-	return []string{"names", "order_by", "per_page", "page"}
+	return []string{"names", "guids", "order_by", "per_page", "page"}
 }
 
 func (d *OrgList) DecodeFromURLValues(values url.Values) error {
 	d.Names = values.Get("names")
-	return nil
+	d.GUIDs = values.Get("guids")
+	d.OrderBy = values.Get("order_by")
+	return d.Pagination.DecodeFromURLValues(values)
+}
+
+type OrgDelete struct {
+	OverrideProtection bool
+}
+
+func (d *OrgDelete) SupportedKeys() []string {
+	return []string{"override_protection"}
+}
+
+func (d *OrgDelete) DecodeFromURLValues(values url.Values) error {
+	var err error
+	d.OverrideProtection, err = getBool(values, "override_protection")
+	return err
 }
@@ -3,6 +3,7 @@ package payloads_test
 import (
 	"net/http"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
@@ -111,6 +112,38 @@ var _ = Describe("Org", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(orgList.Names).To(Equal("foo,bar"))
 			})
+
+			DescribeTable("valid query",
+				func(query string, expectedOrgList payloads.OrgList) {
+					actualOrgList, decodeErr := decodeQuery[payloads.OrgList](query)
+
+					Expect(decodeErr).NotTo(HaveOccurred())
+					Expect(*actualOrgList).To(Equal(expectedOrgList))
+				},
+				Entry("guids", "guids=g1,g2", payloads.OrgList{GUIDs: "g1,g2"}),
+				Entry("page", "page=3", payloads.OrgList{Pagination: payloads.Pagination{Page: "3"}}),
+				Entry("per_page", "per_page=10", payloads.OrgList{Pagination: payloads.Pagination{PerPage: "10"}}),
+				Entry("order_by created_at", "order_by=created_at", payloads.OrgList{OrderBy: "created_at"}),
+				Entry("order_by -name", "order_by=-name", payloads.OrgList{OrderBy: "-name"}),
+				Entry("order_by -updated_at", "order_by=-updated_at", payloads.OrgList{OrderBy: "-updated_at"}),
+			)
+
+			DescribeTable("invalid query",
+				func(query string, expectedErrMsg string) {
+					_, decodeErr := decodeQuery[payloads.OrgList](query)
+					Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+				},
+				Entry("invalid per_page", "per_page=0", "per_page: must be an integer between 1 and 5000"),
+				Entry("invalid page", "page=foo", "page: must be a positive integer"),
+			)
+
+			It("rejects an unsupported order_by with a bad query parameter error", func() {
+				_, decodeErr := decodeQuery[payloads.OrgList]("order_by=foo")
+				Expect(decodeErr).To(BeAssignableToTypeOf(apierrors.BadQueryParamValueError{}))
+				Expect(decodeErr.(apierrors.BadQueryParamValueError).Detail()).To(ContainSubstring(
+					"order_by can only be: 'created_at', '-created_at', 'updated_at', '-updated_at', 'name', '-name'",
+				))
+			})
 		})
 
 		Describe("ToMessage", func() {
@@ -120,6 +153,42 @@ var _ = Describe("Org", func() {
 				}
 				Expect(orgList.ToMessage().Names).To(ConsistOf("foo", "bar"))
 			})
+
+			It("splits guids to strings", func() {
+				orgList := payloads.OrgList{
+					GUIDs: "g1,g2",
+				}
+				Expect(orgList.ToMessage().GUIDs).To(ConsistOf("g1", "g2"))
+			})
+
+			It("passes the order_by through", func() {
+				orgList := payloads.OrgList{
+					OrderBy: "-created_at",
+				}
+				Expect(orgList.ToMessage().OrderBy).To(Equal("-created_at"))
+			})
 		})
 	})
+
+	Describe("OrgDelete", func() {
+		DescribeTable("valid query",
+			func(query string, expectedOrgDelete payloads.OrgDelete) {
+				actualOrgDelete, decodeErr := decodeQuery[payloads.OrgDelete](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualOrgDelete).To(Equal(expectedOrgDelete))
+			},
+			Entry("no query", "", payloads.OrgDelete{}),
+			Entry("override_protection", "override_protection=true", payloads.OrgDelete{OverrideProtection: true}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string) {
+				_, decodeErr := decodeQuery[payloads.OrgDelete](query)
+				Expect(decodeErr).To(HaveOccurred())
+			},
+			Entry("unsupported param", "foo=bar"),
+			Entry("invalid value for override_protection", "override_protection=foo"),
+		)
+	})
 })
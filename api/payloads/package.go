@@ -1,6 +1,7 @@
 package payloads
 
 import (
+	"fmt"
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
@@ -48,6 +49,41 @@ func (c PackageCreate) ToMessage(record repositories.AppRecord) repositories.Cre
 	return message
 }
 
+// PackageCopy is the payload of a package create request with a source_guid
+// query parameter. Type, data and metadata are all taken from the source
+// package.
+type PackageCopy struct {
+	Relationships *PackageRelationships `json:"relationships"`
+}
+
+func (c PackageCopy) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Relationships, jellidation.NotNil),
+	)
+}
+
+func (c PackageCopy) ToMessage(appRecord repositories.AppRecord, sourcePackage repositories.PackageRecord) repositories.CreatePackageMessage {
+	message := repositories.CreatePackageMessage{
+		Type:      sourcePackage.Type,
+		AppGUID:   appRecord.GUID,
+		SpaceGUID: appRecord.SpaceGUID,
+		Metadata: repositories.Metadata{
+			Annotations: sourcePackage.Annotations,
+			Labels:      sourcePackage.Labels,
+		},
+		SourcePackageGUID:      sourcePackage.GUID,
+		SourcePackageSpaceGUID: sourcePackage.SpaceGUID,
+	}
+
+	if sourcePackage.Type == "docker" {
+		message.Data = &repositories.PackageData{
+			Image: sourcePackage.ImageRef,
+		}
+	}
+
+	return message
+}
+
 type PackageData struct {
 	Image    string  `json:"image"`
 	Username *string `json:"username"`
@@ -93,7 +129,9 @@ type PackageList struct {
 	GUIDs    string
 	AppGUIDs string
 	States   string
+	Types    string
 	OrderBy  string
+	Pagination
 }
 
 func (p *PackageList) ToMessage() repositories.ListPackagesMessage {
@@ -101,28 +139,76 @@ func (p *PackageList) ToMessage() repositories.ListPackagesMessage {
 		GUIDs:    parse.ArrayParam(p.GUIDs),
 		AppGUIDs: parse.ArrayParam(p.AppGUIDs),
 		States:   parse.ArrayParam(p.States),
+		Types:    parse.ArrayParam(p.Types),
 		OrderBy:  p.OrderBy,
 	}
 }
 
 func (p *PackageList) SupportedKeys() []string {
-	return []string{"guids", "app_guids", "states", "order_by", "per_page", "page"}
+	return []string{"guids", "app_guids", "states", "types", "order_by", "per_page", "page"}
 }
 
 func (p *PackageList) DecodeFromURLValues(values url.Values) error {
 	p.GUIDs = values.Get("guids")
 	p.AppGUIDs = values.Get("app_guids")
 	p.States = values.Get("states")
+	p.Types = values.Get("types")
 	p.OrderBy = values.Get("order_by")
-	return nil
+	return p.Pagination.DecodeFromURLValues(values)
 }
 
 func (p PackageList) Validate() error {
 	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Types, jellidation.By(validatePackageTypes)),
+		jellidation.Field(&p.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
+		jellidation.Field(&p.Pagination),
+	)
+}
+
+type AppPackageList struct {
+	States  string
+	Types   string
+	OrderBy string
+	Pagination
+}
+
+func (p AppPackageList) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Types, jellidation.By(validatePackageTypes)),
 		jellidation.Field(&p.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
+		jellidation.Field(&p.Pagination),
 	)
 }
 
+func (p *AppPackageList) ToMessage(appGUID string) repositories.ListPackagesMessage {
+	return repositories.ListPackagesMessage{
+		AppGUIDs: []string{appGUID},
+		States:   parse.ArrayParam(p.States),
+		Types:    parse.ArrayParam(p.Types),
+		OrderBy:  p.OrderBy,
+	}
+}
+
+func (p *AppPackageList) SupportedKeys() []string {
+	return []string{"states", "types", "order_by", "per_page", "page"}
+}
+
+func (p *AppPackageList) DecodeFromURLValues(values url.Values) error {
+	p.States = values.Get("states")
+	p.Types = values.Get("types")
+	p.OrderBy = values.Get("order_by")
+	return p.Pagination.DecodeFromURLValues(values)
+}
+
+func validatePackageTypes(value any) error {
+	types, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("%T is not supported, string is expected", value)
+	}
+
+	return jellidation.Each(validation.OneOf("bits", "docker")).Validate(parse.ArrayParam(types))
+}
+
 type PackageListDroplets struct{}
 
 func (p *PackageListDroplets) ToMessage(packageGUIDs []string) repositories.ListDropletsMessage {
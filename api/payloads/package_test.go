@@ -1,6 +1,7 @@
 package payloads_test
 
 import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
@@ -236,6 +237,105 @@ var _ = Describe("PackageCreate", func() {
 	})
 })
 
+var _ = Describe("PackageCopy", func() {
+	var copyPayload payloads.PackageCopy
+
+	BeforeEach(func() {
+		copyPayload = payloads.PackageCopy{
+			Relationships: &payloads.PackageRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "some-guid",
+					},
+				},
+			},
+		}
+	})
+
+	Describe("Validate", func() {
+		var (
+			packageCopy  *payloads.PackageCopy
+			validatorErr error
+		)
+
+		BeforeEach(func() {
+			packageCopy = new(payloads.PackageCopy)
+		})
+
+		JustBeforeEach(func() {
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(copyPayload), packageCopy)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(packageCopy).To(gstruct.PointTo(Equal(copyPayload)))
+		})
+
+		When("relationships are not specified", func() {
+			BeforeEach(func() {
+				copyPayload.Relationships = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "relationships is required")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		var (
+			sourcePackage repositories.PackageRecord
+			createMessage repositories.CreatePackageMessage
+		)
+
+		BeforeEach(func() {
+			sourcePackage = repositories.PackageRecord{
+				GUID:        "source-package-guid",
+				SpaceGUID:   "source-space-guid",
+				Type:        "bits",
+				Labels:      map[string]string{"foo": "bar"},
+				Annotations: map[string]string{"example.org/jim": "hello"},
+				ImageRef:    "source-app-packages",
+			}
+		})
+
+		JustBeforeEach(func() {
+			createMessage = copyPayload.ToMessage(repositories.AppRecord{
+				GUID:      "guid",
+				SpaceGUID: "space-guid",
+			}, sourcePackage)
+		})
+
+		It("creates the message from the source package", func() {
+			Expect(createMessage).To(Equal(repositories.CreatePackageMessage{
+				Type:      "bits",
+				AppGUID:   "guid",
+				SpaceGUID: "space-guid",
+				Metadata: repositories.Metadata{
+					Labels:      map[string]string{"foo": "bar"},
+					Annotations: map[string]string{"example.org/jim": "hello"},
+				},
+				SourcePackageGUID:      "source-package-guid",
+				SourcePackageSpaceGUID: "source-space-guid",
+			}))
+		})
+
+		When("the source package type is docker", func() {
+			BeforeEach(func() {
+				sourcePackage.Type = "docker"
+				sourcePackage.ImageRef = "some/image"
+			})
+
+			It("copies the image", func() {
+				Expect(createMessage.Type).To(Equal("docker"))
+				Expect(createMessage.Data).To(Equal(&repositories.PackageData{
+					Image: "some/image",
+				}))
+			})
+		})
+	})
+})
+
 var _ = Describe("PackageUpdate", func() {
 	var payload payloads.PackageUpdate
 
@@ -306,11 +406,14 @@ var _ = Describe("PackageList", func() {
 		Entry("guids", "guids=g1,g2", payloads.PackageList{GUIDs: "g1,g2"}),
 		Entry("app_guids", "app_guids=ag1,ag2", payloads.PackageList{AppGUIDs: "ag1,ag2"}),
 		Entry("states", "states=s1,s2", payloads.PackageList{States: "s1,s2"}),
+		Entry("types", "types=bits,docker", payloads.PackageList{Types: "bits,docker"}),
 		Entry("created_at", "order_by=created_at", payloads.PackageList{OrderBy: "created_at"}),
 		Entry("-created_at", "order_by=-created_at", payloads.PackageList{OrderBy: "-created_at"}),
 		Entry("updated_at", "order_by=updated_at", payloads.PackageList{OrderBy: "updated_at"}),
 		Entry("-updated_at", "order_by=-updated_at", payloads.PackageList{OrderBy: "-updated_at"}),
 		Entry("empty", "order_by=", payloads.PackageList{OrderBy: ""}),
+		Entry("page", "page=3", payloads.PackageList{Pagination: payloads.Pagination{Page: "3"}}),
+		Entry("per_page", "per_page=10", payloads.PackageList{Pagination: payloads.Pagination{PerPage: "10"}}),
 	)
 
 	DescribeTable("ToMessage",
@@ -322,6 +425,7 @@ var _ = Describe("PackageList", func() {
 		Entry("guids", payloads.PackageList{GUIDs: "g1,g2"}, repositories.ListPackagesMessage{GUIDs: []string{"g1", "g2"}}),
 		Entry("app_guids", payloads.PackageList{AppGUIDs: "ag1,ag2"}, repositories.ListPackagesMessage{AppGUIDs: []string{"ag1", "ag2"}}),
 		Entry("states", payloads.PackageList{States: "s1,s2"}, repositories.ListPackagesMessage{States: []string{"s1", "s2"}}),
+		Entry("types", payloads.PackageList{Types: "bits,docker"}, repositories.ListPackagesMessage{Types: []string{"bits", "docker"}}),
 		Entry("empty", payloads.PackageList{}, repositories.ListPackagesMessage{}),
 	)
 
@@ -330,6 +434,52 @@ var _ = Describe("PackageList", func() {
 			_, decodeErr := decodeQuery[payloads.PackageList](query)
 			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
 		},
-		Entry("invalid order_by", "order_by=foo", "value must be one of"),
+		Entry("invalid types", "types=bits,foo", "value must be one of"),
+		Entry("invalid per_page", "per_page=0", "per_page: must be an integer between 1 and 5000"),
+	)
+
+	It("rejects an unsupported order_by with a bad query parameter error", func() {
+		_, decodeErr := decodeQuery[payloads.PackageList]("order_by=foo")
+		Expect(decodeErr).To(BeAssignableToTypeOf(apierrors.BadQueryParamValueError{}))
+	})
+})
+
+var _ = Describe("AppPackageList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedPackageList payloads.AppPackageList) {
+			actualPackageList, decodeErr := decodeQuery[payloads.AppPackageList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualPackageList).To(Equal(expectedPackageList))
+		},
+		Entry("states", "states=s1,s2", payloads.AppPackageList{States: "s1,s2"}),
+		Entry("types", "types=bits", payloads.AppPackageList{Types: "bits"}),
+		Entry("order_by", "order_by=-created_at", payloads.AppPackageList{OrderBy: "-created_at"}),
+		Entry("page", "page=3", payloads.AppPackageList{Pagination: payloads.Pagination{Page: "3"}}),
+		Entry("per_page", "per_page=10", payloads.AppPackageList{Pagination: payloads.Pagination{PerPage: "10"}}),
 	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AppPackageList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid types", "types=foo", "value must be one of"),
+		Entry("invalid page", "page=foo", "page: must be a positive integer"),
+		Entry("unsupported key", "app_guids=foo", "unsupported query parameter: app_guids"),
+	)
+
+	It("rejects an unsupported order_by with a bad query parameter error", func() {
+		_, decodeErr := decodeQuery[payloads.AppPackageList]("order_by=foo")
+		Expect(decodeErr).To(BeAssignableToTypeOf(apierrors.BadQueryParamValueError{}))
+	})
+
+	It("lists the packages of the app", func() {
+		packageList := payloads.AppPackageList{States: "READY", Types: "bits,docker"}
+		Expect(packageList.ToMessage("app-guid")).To(Equal(repositories.ListPackagesMessage{
+			AppGUIDs: []string{"app-guid"},
+			States:   []string{"READY"},
+			Types:    []string{"bits", "docker"},
+		}))
+	})
 })
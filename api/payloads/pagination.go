@@ -0,0 +1,54 @@
+package payloads
+
+import (
+	"net/url"
+	"strconv"
+
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	jellidation "github.com/jellydator/validation"
+)
+
+const (
+	DefaultPerPage = 50
+	MaxPerPage     = 5000
+)
+
+type Pagination struct {
+	PerPage string `json:"per_page"`
+	Page    string `json:"page"`
+}
+
+func (p Pagination) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.PerPage, validation.IntegerBetween(1, MaxPerPage)),
+		jellidation.Field(&p.Page, validation.PositiveInteger()),
+	)
+}
+
+func (p *Pagination) DecodeFromURLValues(values url.Values) error {
+	p.PerPage = values.Get("per_page")
+	p.Page = values.Get("page")
+	return nil
+}
+
+// PageNumber returns the requested page, defaulting to the first one. It
+// assumes the pagination has been validated.
+func (p Pagination) PageNumber() int {
+	if p.Page == "" {
+		return 1
+	}
+
+	page, _ := strconv.Atoi(p.Page)
+	return page
+}
+
+// PerPageNumber returns the requested page size, defaulting to
+// DefaultPerPage. It assumes the pagination has been validated.
+func (p Pagination) PerPageNumber() int {
+	if p.PerPage == "" {
+		return DefaultPerPage
+	}
+
+	perPage, _ := strconv.Atoi(p.PerPage)
+	return perPage
+}
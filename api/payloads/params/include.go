@@ -41,10 +41,12 @@ func ParseIncludes(values url.Values) []IncludeResourceRule {
 		}
 
 		for _, value := range values {
-			includes = append(includes, IncludeResourceRule{
-				RelationshipPath: strings.Split(value, "."),
-				Fields:           []string{},
-			})
+			for _, include := range strings.Split(value, ",") {
+				includes = append(includes, IncludeResourceRule{
+					RelationshipPath: strings.Split(include, "."),
+					Fields:           []string{},
+				})
+			}
 		}
 	}
 
@@ -4,6 +4,7 @@ import (
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/jellydator/validation"
 )
@@ -23,9 +24,10 @@ func (p ProcessScale) Validate() error {
 }
 
 type ProcessPatch struct {
-	Metadata    *MetadataPatch `json:"metadata"`
-	Command     *string        `json:"command"`
-	HealthCheck *HealthCheck   `json:"health_check"`
+	Metadata               *MetadataPatch `json:"metadata"`
+	Command                *string        `json:"command"`
+	HealthCheck            *HealthCheck   `json:"health_check"`
+	ConnectionDrainTimeout *int32         `json:"connection_drain_timeout"`
 }
 
 type HealthCheck struct {
@@ -39,6 +41,36 @@ type Data struct {
 	InvocationTimeout *int32  `json:"invocation_timeout"`
 }
 
+func (p ProcessPatch) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Metadata),
+		validation.Field(&p.HealthCheck),
+		validation.Field(&p.ConnectionDrainTimeout, validation.Min(int32(0)).Error("must be 0 or greater")),
+	)
+}
+
+func (h HealthCheck) Validate() error {
+	isHTTP := h.Type != nil && *h.Type == "http"
+
+	return validation.ValidateStruct(&h,
+		validation.Field(&h.Type, payload_validation.OneOf("http", "port", "process")),
+		validation.Field(&h.Data, validation.When(isHTTP, validation.Required, validation.By(httpEndpointRequired))),
+	)
+}
+
+// httpEndpointRequired ensures that http health checks specify the endpoint
+// to probe
+func httpEndpointRequired(value any) error {
+	data, ok := value.(*Data)
+	if !ok || data == nil {
+		return nil
+	}
+
+	return validation.ValidateStruct(data,
+		validation.Field(&data.Endpoint, validation.Required),
+	)
+}
+
 func (p ProcessScale) ToRecord() repositories.ProcessScaleValues {
 	return repositories.ProcessScaleValues{
 		Instances: p.Instances,
@@ -49,6 +81,13 @@ func (p ProcessScale) ToRecord() repositories.ProcessScaleValues {
 
 type ProcessList struct {
 	AppGUIDs string
+	Pagination
+}
+
+func (p ProcessList) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Pagination),
+	)
 }
 
 func (p *ProcessList) ToMessage() repositories.ListProcessesMessage {
@@ -63,14 +102,33 @@ func (p *ProcessList) SupportedKeys() []string {
 
 func (p *ProcessList) DecodeFromURLValues(values url.Values) error {
 	p.AppGUIDs = values.Get("app_guids")
-	return nil
+	return p.Pagination.DecodeFromURLValues(values)
+}
+
+type AppProcessList struct {
+	Pagination
+}
+
+func (p AppProcessList) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Pagination),
+	)
+}
+
+func (p *AppProcessList) SupportedKeys() []string {
+	return []string{"per_page", "page"}
+}
+
+func (p *AppProcessList) DecodeFromURLValues(values url.Values) error {
+	return p.Pagination.DecodeFromURLValues(values)
 }
 
 func (p ProcessPatch) ToProcessPatchMessage(processGUID, spaceGUID string) repositories.PatchProcessMessage {
 	message := repositories.PatchProcessMessage{
-		ProcessGUID: processGUID,
-		SpaceGUID:   spaceGUID,
-		Command:     p.Command,
+		ProcessGUID:                   processGUID,
+		SpaceGUID:                     spaceGUID,
+		Command:                       p.Command,
+		ConnectionDrainTimeoutSeconds: p.ConnectionDrainTimeout,
 	}
 
 	if p.HealthCheck != nil {
@@ -24,6 +24,48 @@ var _ = Describe("ProcessList", func() {
 			}))
 		})
 	})
+
+	DescribeTable("valid query",
+		func(query string, expectedProcessList payloads.ProcessList) {
+			actualProcessList, decodeErr := decodeQuery[payloads.ProcessList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualProcessList).To(Equal(expectedProcessList))
+		},
+		Entry("page", "page=3", payloads.ProcessList{Pagination: payloads.Pagination{Page: "3"}}),
+		Entry("per_page", "per_page=10", payloads.ProcessList{Pagination: payloads.Pagination{PerPage: "10"}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.ProcessList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid page", "page=foo", "page: must be a positive integer"),
+		Entry("invalid per_page", "per_page=0", "per_page: must be an integer between 1 and 5000"),
+	)
+})
+
+var _ = Describe("AppProcessList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedProcessList payloads.AppProcessList) {
+			actualProcessList, decodeErr := decodeQuery[payloads.AppProcessList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualProcessList).To(Equal(expectedProcessList))
+		},
+		Entry("page", "page=3", payloads.AppProcessList{Pagination: payloads.Pagination{Page: "3"}}),
+		Entry("per_page", "per_page=10", payloads.AppProcessList{Pagination: payloads.Pagination{PerPage: "10"}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AppProcessList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid page", "page=foo", "page: must be a positive integer"),
+		Entry("unsupported key", "app_guids=foo", "unsupported query parameter: app_guids"),
+	)
 })
 
 var _ = Describe("Process payload validation", func() {
@@ -84,4 +126,113 @@ var _ = Describe("Process payload validation", func() {
 			})
 		})
 	})
+
+	Describe("ProcessPatch", func() {
+		var (
+			payload        payloads.ProcessPatch
+			decodedPayload *payloads.ProcessPatch
+		)
+
+		BeforeEach(func() {
+			payload = payloads.ProcessPatch{
+				Command: tools.PtrTo("start-web"),
+				HealthCheck: &payloads.HealthCheck{
+					Type: tools.PtrTo("http"),
+					Data: &payloads.Data{
+						Endpoint:          tools.PtrTo("/healthy"),
+						Timeout:           tools.PtrTo[int32](5),
+						InvocationTimeout: tools.PtrTo[int32](2),
+					},
+				},
+				ConnectionDrainTimeout: tools.PtrTo[int32](15),
+			}
+
+			decodedPayload = new(payloads.ProcessPatch)
+		})
+
+		JustBeforeEach(func() {
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+		})
+
+		When("the connection drain timeout is negative", func() {
+			BeforeEach(func() {
+				payload.ConnectionDrainTimeout = tools.PtrTo[int32](-1)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "connection_drain_timeout must be 0 or greater")
+			})
+		})
+
+		When("the health check type is not supported", func() {
+			BeforeEach(func() {
+				payload.HealthCheck.Type = tools.PtrTo("none")
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "health_check.type value must be one of: http, port, process")
+			})
+		})
+
+		When("an http health check has no data", func() {
+			BeforeEach(func() {
+				payload.HealthCheck.Data = nil
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "health_check.data cannot be blank")
+			})
+		})
+
+		When("an http health check has no endpoint", func() {
+			BeforeEach(func() {
+				payload.HealthCheck.Data.Endpoint = nil
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "health_check.data.endpoint cannot be blank")
+			})
+		})
+
+		When("an http health check has an empty endpoint", func() {
+			BeforeEach(func() {
+				payload.HealthCheck.Data.Endpoint = tools.PtrTo("")
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "health_check.data.endpoint cannot be blank")
+			})
+		})
+
+		When("a port health check has no endpoint", func() {
+			BeforeEach(func() {
+				payload.HealthCheck = &payloads.HealthCheck{
+					Type: tools.PtrTo("port"),
+				}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the metadata is invalid", func() {
+			BeforeEach(func() {
+				payload.Metadata = &payloads.MetadataPatch{
+					Labels: map[string]*string{
+						"foo.cloudfoundry.org/bar": tools.PtrTo("baz"),
+					},
+				}
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "label/annotation key cannot use the cloudfoundry.org domain")
+			})
+		})
+	})
 })
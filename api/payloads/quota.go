@@ -0,0 +1,55 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type OrgQuotaList struct {
+	GUIDs string
+	Names string
+}
+
+func (l *OrgQuotaList) ToMessage() repositories.ListOrgQuotasMessage {
+	return repositories.ListOrgQuotasMessage{
+		GUIDs: parse.ArrayParam(l.GUIDs),
+		Names: parse.ArrayParam(l.Names),
+	}
+}
+
+func (l *OrgQuotaList) SupportedKeys() []string {
+	return []string{"guids", "names", "per_page", "page"}
+}
+
+func (l *OrgQuotaList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	return nil
+}
+
+type SpaceQuotaList struct {
+	GUIDs             string
+	Names             string
+	OrganizationGUIDs string
+}
+
+func (l *SpaceQuotaList) ToMessage() repositories.ListSpaceQuotasMessage {
+	return repositories.ListSpaceQuotasMessage{
+		GUIDs:    parse.ArrayParam(l.GUIDs),
+		Names:    parse.ArrayParam(l.Names),
+		OrgGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+	}
+}
+
+func (l *SpaceQuotaList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "per_page", "page"}
+}
+
+func (l *SpaceQuotaList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	return nil
+}
@@ -0,0 +1,61 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OrgQuotaList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedOrgQuotaList payloads.OrgQuotaList) {
+			actualOrgQuotaList, decodeErr := decodeQuery[payloads.OrgQuotaList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualOrgQuotaList).To(Equal(expectedOrgQuotaList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.OrgQuotaList{GUIDs: "g1,g2"}),
+		Entry("names", "names=n1,n2", payloads.OrgQuotaList{Names: "n1,n2"}),
+	)
+
+	It("fails on unsupported keys", func() {
+		_, decodeErr := decodeQuery[payloads.OrgQuotaList]("organization_guids=o1")
+		Expect(decodeErr).To(MatchError(ContainSubstring("unsupported query parameter")))
+	})
+
+	Describe("ToMessage", func() {
+		It("splits the parameters", func() {
+			orgQuotaList := payloads.OrgQuotaList{GUIDs: "g1,g2", Names: "n1"}
+			Expect(orgQuotaList.ToMessage()).To(Equal(repositories.ListOrgQuotasMessage{
+				GUIDs: []string{"g1", "g2"},
+				Names: []string{"n1"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("SpaceQuotaList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedSpaceQuotaList payloads.SpaceQuotaList) {
+			actualSpaceQuotaList, decodeErr := decodeQuery[payloads.SpaceQuotaList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualSpaceQuotaList).To(Equal(expectedSpaceQuotaList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.SpaceQuotaList{GUIDs: "g1,g2"}),
+		Entry("names", "names=n1,n2", payloads.SpaceQuotaList{Names: "n1,n2"}),
+		Entry("organization_guids", "organization_guids=o1,o2", payloads.SpaceQuotaList{OrganizationGUIDs: "o1,o2"}),
+	)
+
+	Describe("ToMessage", func() {
+		It("splits the parameters", func() {
+			spaceQuotaList := payloads.SpaceQuotaList{GUIDs: "g1", Names: "n1", OrganizationGUIDs: "o1,o2"}
+			Expect(spaceQuotaList.ToMessage()).To(Equal(repositories.ListSpaceQuotasMessage{
+				GUIDs:    []string{"g1"},
+				Names:    []string{"n1"},
+				OrgGUIDs: []string{"o1", "o2"},
+			}))
+		})
+	})
+})
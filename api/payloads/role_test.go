@@ -213,6 +213,8 @@ var _ = Describe("role list", func() {
 		Entry("order_by3", "order_by=updated_at", payloads.RoleList{OrderBy: "updated_at"}),
 		Entry("order_by4", "order_by=-updated_at", payloads.RoleList{OrderBy: "-updated_at"}),
 		Entry("include", "include=foo", payloads.RoleList{}),
+		Entry("page", "page=3", payloads.RoleList{Pagination: payloads.Pagination{Page: "3"}}),
+		Entry("per_page", "per_page=10", payloads.RoleList{Pagination: payloads.Pagination{PerPage: "10"}}),
 	)
 
 	DescribeTable("invalid query",
@@ -220,9 +222,15 @@ var _ = Describe("role list", func() {
 			_, decodeErr := decodeQuery[payloads.RoleList](query)
 			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
 		},
-		Entry("invalid order_by", "order_by=foo", "value must be one of"),
+		Entry("invalid per_page", "per_page=0", "per_page: must be an integer between 1 and 5000"),
+		Entry("invalid page", "page=foo", "page: must be a positive integer"),
 	)
 
+	It("rejects an unsupported order_by with a bad query parameter error", func() {
+		_, decodeErr := decodeQuery[payloads.RoleList]("order_by=foo")
+		Expect(decodeErr).To(BeAssignableToTypeOf(errors.BadQueryParamValueError{}))
+	})
+
 	DescribeTable("ToMessage",
 		func(roleList payloads.RoleList, expectedListRolesMessage repositories.ListRolesMessage) {
 			actualListRolesMessage := roleList.ToMessage()
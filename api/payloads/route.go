@@ -3,6 +3,7 @@ package payloads
 import (
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -51,11 +52,20 @@ func (r RouteRelationships) Validate() error {
 }
 
 type RouteList struct {
-	AppGUIDs    string
-	SpaceGUIDs  string
-	DomainGUIDs string
-	Hosts       string
-	Paths       string
+	AppGUIDs             string
+	SpaceGUIDs           string
+	DomainGUIDs          string
+	Hosts                string
+	Paths                string
+	IncludeResourceRules []params.IncludeResourceRule
+	Pagination
+}
+
+func (p RouteList) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.IncludeResourceRules, validation.OneOfIncludes("domain")),
+		jellidation.Field(&p.Pagination),
+	)
 }
 
 func (p RouteList) ToMessage() repositories.ListRoutesMessage {
@@ -69,7 +79,7 @@ func (p RouteList) ToMessage() repositories.ListRoutesMessage {
 }
 
 func (p RouteList) SupportedKeys() []string {
-	return []string{"app_guids", "space_guids", "domain_guids", "hosts", "paths", "per_page", "page"}
+	return []string{"app_guids", "space_guids", "domain_guids", "hosts", "paths", "include", "per_page", "page"}
 }
 
 func (p *RouteList) DecodeFromURLValues(values url.Values) error {
@@ -78,7 +88,47 @@ func (p *RouteList) DecodeFromURLValues(values url.Values) error {
 	p.DomainGUIDs = values.Get("domain_guids")
 	p.Hosts = values.Get("hosts")
 	p.Paths = values.Get("paths")
-	return nil
+	p.IncludeResourceRules = append(p.IncludeResourceRules, params.ParseIncludes(values)...)
+	return p.Pagination.DecodeFromURLValues(values)
+}
+
+type AppRouteList struct {
+	SpaceGUIDs           string
+	DomainGUIDs          string
+	Hosts                string
+	Paths                string
+	IncludeResourceRules []params.IncludeResourceRule
+	Pagination
+}
+
+func (p AppRouteList) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.IncludeResourceRules, validation.OneOfIncludes("domain")),
+		jellidation.Field(&p.Pagination),
+	)
+}
+
+func (p AppRouteList) ToMessage(appGUID string) repositories.ListRoutesMessage {
+	return repositories.ListRoutesMessage{
+		AppGUIDs:    []string{appGUID},
+		SpaceGUIDs:  parse.ArrayParam(p.SpaceGUIDs),
+		DomainGUIDs: parse.ArrayParam(p.DomainGUIDs),
+		Hosts:       parse.ArrayParam(p.Hosts),
+		Paths:       parse.ArrayParam(p.Paths),
+	}
+}
+
+func (p AppRouteList) SupportedKeys() []string {
+	return []string{"space_guids", "domain_guids", "hosts", "paths", "include", "per_page", "page"}
+}
+
+func (p *AppRouteList) DecodeFromURLValues(values url.Values) error {
+	p.SpaceGUIDs = values.Get("space_guids")
+	p.DomainGUIDs = values.Get("domain_guids")
+	p.Hosts = values.Get("hosts")
+	p.Paths = values.Get("paths")
+	p.IncludeResourceRules = append(p.IncludeResourceRules, params.ParseIncludes(values)...)
+	return p.Pagination.DecodeFromURLValues(values)
 }
 
 type RoutePatch struct {
@@ -5,6 +5,8 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -16,16 +18,16 @@ var _ = Describe("RouteList", func() {
 		var (
 			routeList payloads.RouteList
 			decodeErr error
-			params    string
+			query     string
 		)
 
 		BeforeEach(func() {
 			routeList = payloads.RouteList{}
-			params = "app_guids=app_guid&space_guids=space_guid&domain_guids=domain_guid&hosts=host&paths=path"
+			query = "app_guids=app_guid&space_guids=space_guid&domain_guids=domain_guid&hosts=host&paths=path"
 		})
 
 		JustBeforeEach(func() {
-			req, err := http.NewRequest("GET", "http://foo.com/bar?"+params, nil)
+			req, err := http.NewRequest("GET", "http://foo.com/bar?"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 			decodeErr = validator.DecodeAndValidateURLValues(req, &routeList)
 		})
@@ -43,13 +45,112 @@ var _ = Describe("RouteList", func() {
 
 		When("it contains an invalid key", func() {
 			BeforeEach(func() {
-				params = "foo=bar"
+				query = "foo=bar"
 			})
 
 			It("fails", func() {
 				Expect(decodeErr).To(MatchError("unsupported query parameter: foo"))
 			})
 		})
+
+		When("it requests a page", func() {
+			BeforeEach(func() {
+				query = "page=2&per_page=10"
+			})
+
+			It("decodes the pagination", func() {
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(routeList.Pagination).To(Equal(payloads.Pagination{Page: "2", PerPage: "10"}))
+			})
+		})
+
+		When("it includes the domain", func() {
+			BeforeEach(func() {
+				query = "include=domain"
+			})
+
+			It("decodes the include rule", func() {
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(routeList.IncludeResourceRules).To(ConsistOf(params.IncludeResourceRule{
+					RelationshipPath: []string{"domain"},
+					Fields:           []string{},
+				}))
+			})
+		})
+
+		When("it includes an unsupported resource", func() {
+			BeforeEach(func() {
+				query = "include=space"
+			})
+
+			It("fails with a bad query parameter error", func() {
+				Expect(decodeErr).To(BeAssignableToTypeOf(errors.BadQueryParamValueError{}))
+				Expect(decodeErr.(errors.BadQueryParamValueError).Detail()).To(ContainSubstring("include can only be: 'domain'"))
+			})
+		})
+
+		When("the per_page is invalid", func() {
+			BeforeEach(func() {
+				query = "per_page=0"
+			})
+
+			It("fails", func() {
+				Expect(decodeErr).To(MatchError(ContainSubstring("per_page: must be an integer between 1 and 5000")))
+			})
+		})
+	})
+})
+
+var _ = Describe("AppRouteList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedRouteList payloads.AppRouteList) {
+			actualRouteList, decodeErr := decodeQuery[payloads.AppRouteList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualRouteList).To(Equal(expectedRouteList))
+		},
+		Entry("space_guids", "space_guids=s1,s2", payloads.AppRouteList{SpaceGUIDs: "s1,s2"}),
+		Entry("domain_guids", "domain_guids=d1,d2", payloads.AppRouteList{DomainGUIDs: "d1,d2"}),
+		Entry("hosts", "hosts=h1,h2", payloads.AppRouteList{Hosts: "h1,h2"}),
+		Entry("paths", "paths=p1,p2", payloads.AppRouteList{Paths: "p1,p2"}),
+		Entry("page", "page=3", payloads.AppRouteList{Pagination: payloads.Pagination{Page: "3"}}),
+		Entry("include", "include=domain", payloads.AppRouteList{IncludeResourceRules: []params.IncludeResourceRule{{
+			RelationshipPath: []string{"domain"},
+			Fields:           []string{},
+		}}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AppRouteList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("app_guids", "app_guids=a1", "unsupported query parameter: app_guids"),
+		Entry("invalid per_page", "per_page=0", "per_page: must be an integer between 1 and 5000"),
+	)
+
+	It("rejects unsupported includes", func() {
+		_, decodeErr := decodeQuery[payloads.AppRouteList]("include=space")
+		Expect(decodeErr).To(BeAssignableToTypeOf(errors.BadQueryParamValueError{}))
+	})
+
+	Describe("ToMessage", func() {
+		It("filters by the app", func() {
+			message := payloads.AppRouteList{
+				SpaceGUIDs:  "s1",
+				DomainGUIDs: "d1",
+				Hosts:       "h1",
+				Paths:       "p1",
+			}.ToMessage("app-guid")
+
+			Expect(message).To(Equal(repositories.ListRoutesMessage{
+				AppGUIDs:    []string{"app-guid"},
+				SpaceGUIDs:  []string{"s1"},
+				DomainGUIDs: []string{"d1"},
+				Hosts:       []string{"h1"},
+				Paths:       []string{"p1"},
+			}))
+		})
 	})
 })
 
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"path"
 	"regexp"
 	"strings"
 
@@ -16,13 +17,35 @@ import (
 )
 
 type ServiceInstanceCreate struct {
-	Name          string                        `json:"name"`
-	Type          string                        `json:"type"`
-	Tags          []string                      `json:"tags"`
-	Credentials   map[string]any                `json:"credentials"`
-	Parameters    map[string]any                `json:"parameters"`
-	Relationships *ServiceInstanceRelationships `json:"relationships"`
-	Metadata      Metadata                      `json:"metadata"`
+	Name           string                        `json:"name"`
+	Type           string                        `json:"type"`
+	Tags           []string                      `json:"tags"`
+	Credentials    map[string]any                `json:"credentials"`
+	Parameters     map[string]any                `json:"parameters"`
+	SyslogDrainURL *string                       `json:"syslog_drain_url"`
+	Volume         *ServiceInstanceVolume        `json:"volume"`
+	Relationships  *ServiceInstanceRelationships `json:"relationships"`
+	Metadata       Metadata                      `json:"metadata"`
+}
+
+type ServiceInstanceVolume struct {
+	SizeMB       int64   `json:"size_mb"`
+	StorageClass *string `json:"storage_class"`
+	AccessMode   string  `json:"access_mode"`
+	MountPath    string  `json:"mount_path"`
+}
+
+func (v ServiceInstanceVolume) Validate() error {
+	return jellidation.ValidateStruct(&v,
+		jellidation.Field(&v.SizeMB, jellidation.Required.Error("must be greater than 0"), jellidation.Min(1).Error("must be greater than 0")),
+		jellidation.Field(&v.AccessMode, validation.OneOf("ReadWriteOnce", "ReadWriteOncePod", "ReadWriteMany")),
+		jellidation.Field(&v.MountPath, jellidation.Required, jellidation.By(func(value any) error {
+			if !path.IsAbs(value.(string)) {
+				return errors.New("must be an absolute path")
+			}
+			return nil
+		})),
+	)
 }
 
 const maxTagsLength = 2048
@@ -47,11 +70,13 @@ func validateTagLength(tags any) error {
 func (c ServiceInstanceCreate) Validate() error {
 	return jellidation.ValidateStruct(&c,
 		jellidation.Field(&c.Name, jellidation.Required),
-		jellidation.Field(&c.Type, jellidation.Required, validation.OneOf("user-provided", "managed")),
+		jellidation.Field(&c.Type, jellidation.Required, validation.OneOf("user-provided", "managed", "volume")),
 		jellidation.Field(&c.Tags, jellidation.By(validateTagLength)),
+		jellidation.Field(&c.SyslogDrainURL, jellidation.When(c.Type != "user-provided", jellidation.Nil)),
+		jellidation.Field(&c.Volume, jellidation.When(c.Type == "volume", jellidation.NotNil).Else(jellidation.Nil)),
 		jellidation.Field(&c.Relationships, jellidation.NotNil, jellidation.By(func(r any) error {
 			rel := r.(*ServiceInstanceRelationships)
-			if c.Type == "user-provided" {
+			if c.Type == "user-provided" || c.Type == "volume" {
 				return rel.ValidateUserProvidedRelationships()
 			}
 
@@ -63,12 +88,27 @@ func (c ServiceInstanceCreate) Validate() error {
 
 func (p ServiceInstanceCreate) ToUPSICreateMessage() repositories.CreateUPSIMessage {
 	return repositories.CreateUPSIMessage{
-		Name:        p.Name,
-		SpaceGUID:   p.Relationships.Space.Data.GUID,
-		Credentials: p.Credentials,
-		Tags:        p.Tags,
-		Labels:      p.Metadata.Labels,
-		Annotations: p.Metadata.Annotations,
+		Name:           p.Name,
+		SpaceGUID:      p.Relationships.Space.Data.GUID,
+		Credentials:    p.Credentials,
+		Tags:           p.Tags,
+		SyslogDrainURL: p.SyslogDrainURL,
+		Labels:         p.Metadata.Labels,
+		Annotations:    p.Metadata.Annotations,
+	}
+}
+
+func (p ServiceInstanceCreate) ToVolumeSICreateMessage() repositories.CreateVolumeSIMessage {
+	return repositories.CreateVolumeSIMessage{
+		Name:             p.Name,
+		SpaceGUID:        p.Relationships.Space.Data.GUID,
+		SizeMB:           p.Volume.SizeMB,
+		StorageClassName: p.Volume.StorageClass,
+		AccessMode:       p.Volume.AccessMode,
+		MountPath:        p.Volume.MountPath,
+		Tags:             p.Tags,
+		Labels:           p.Metadata.Labels,
+		Annotations:      p.Metadata.Annotations,
 	}
 }
 
@@ -158,10 +198,11 @@ func (g *ServiceInstanceGet) DecodeFromURLValues(values url.Values) error {
 }
 
 type ServiceInstancePatch struct {
-	Name        *string         `json:"name,omitempty"`
-	Tags        *[]string       `json:"tags,omitempty"`
-	Credentials *map[string]any `json:"credentials,omitempty"`
-	Metadata    MetadataPatch   `json:"metadata"`
+	Name           *string         `json:"name,omitempty"`
+	Tags           *[]string       `json:"tags,omitempty"`
+	Credentials    *map[string]any `json:"credentials,omitempty"`
+	SyslogDrainURL *string         `json:"syslog_drain_url,omitempty"`
+	Metadata       MetadataPatch   `json:"metadata"`
 }
 
 func (p ServiceInstancePatch) Validate() error {
@@ -172,11 +213,12 @@ func (p ServiceInstancePatch) Validate() error {
 
 func (p ServiceInstancePatch) ToServiceInstancePatchMessage(spaceGUID, appGUID string) repositories.PatchServiceInstanceMessage {
 	return repositories.PatchServiceInstanceMessage{
-		SpaceGUID:   spaceGUID,
-		GUID:        appGUID,
-		Name:        p.Name,
-		Credentials: p.Credentials,
-		Tags:        p.Tags,
+		SpaceGUID:      spaceGUID,
+		GUID:           appGUID,
+		Name:           p.Name,
+		Credentials:    p.Credentials,
+		Tags:           p.Tags,
+		SyslogDrainURL: p.SyslogDrainURL,
 		MetadataPatch: repositories.MetadataPatch{
 			Labels:      p.Metadata.Labels,
 			Annotations: p.Metadata.Annotations,
@@ -217,6 +259,7 @@ type ServiceInstanceList struct {
 	GUIDs                string
 	SpaceGUIDs           string
 	PlanGUIDs            string
+	Type                 string
 	OrderBy              string
 	LabelSelector        string
 	IncludeResourceRules []params.IncludeResourceRule
@@ -225,6 +268,7 @@ type ServiceInstanceList struct {
 func (l ServiceInstanceList) Validate() error {
 	return jellidation.ValidateStruct(&l,
 		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "name", "updated_at")),
+		jellidation.Field(&l.Type, validation.OneOf("managed", "user-provided")),
 		jellidation.Field(&l.IncludeResourceRules, jellidation.Each(jellidation.By(func(value any) error {
 			rule, ok := value.(params.IncludeResourceRule)
 			if !ok {
@@ -266,6 +310,7 @@ func (l *ServiceInstanceList) ToMessage() repositories.ListServiceInstanceMessag
 		Names:         parse.ArrayParam(l.Names),
 		SpaceGUIDs:    parse.ArrayParam(l.SpaceGUIDs),
 		GUIDs:         parse.ArrayParam(l.GUIDs),
+		Type:          l.Type,
 		OrderBy:       l.OrderBy,
 		LabelSelector: l.LabelSelector,
 		PlanGUIDs:     parse.ArrayParam(l.PlanGUIDs),
@@ -283,6 +328,7 @@ func (l *ServiceInstanceList) SupportedKeys() []string {
 		"fields[service_plan.service_offering.service_broker]",
 		"fields[service_plan]",
 		"service_plan_guids",
+		"type",
 	}
 }
 
@@ -301,6 +347,7 @@ func (l *ServiceInstanceList) DecodeFromURLValues(values url.Values) error {
 	l.LabelSelector = values.Get("label_selector")
 	l.IncludeResourceRules = append(l.IncludeResourceRules, params.ParseFields(values)...)
 	l.PlanGUIDs = values.Get("service_plan_guids")
+	l.Type = values.Get("type")
 	return nil
 }
 
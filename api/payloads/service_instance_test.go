@@ -1,6 +1,7 @@
 package payloads_test
 
 import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"encoding/json"
 	"strings"
 
@@ -51,6 +52,8 @@ var _ = Describe("ServiceInstanceList", func() {
 			}}}),
 		Entry("label_selector=foo", "label_selector=foo", payloads.ServiceInstanceList{LabelSelector: "foo"}),
 		Entry("service_plan_guids=plan-guid", "service_plan_guids=plan-guid", payloads.ServiceInstanceList{PlanGUIDs: "plan-guid"}),
+		Entry("type=managed", "type=managed", payloads.ServiceInstanceList{Type: "managed"}),
+		Entry("type=user-provided", "type=user-provided", payloads.ServiceInstanceList{Type: "user-provided"}),
 	)
 
 	DescribeTable("invalid query",
@@ -58,13 +61,18 @@ var _ = Describe("ServiceInstanceList", func() {
 			_, decodeErr := decodeQuery[payloads.ServiceInstanceList](query)
 			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
 		},
-		Entry("invalid order_by", "order_by=foo", "value must be one of"),
+		Entry("invalid type", "type=foo", "value must be one of"),
 		Entry("invalid fields", "fields[foo]=bar", "unsupported query parameter: fields[foo]"),
 		Entry("invalid service offering fields", "fields[service_plan.service_offering]=foo", "value must be one of"),
 		Entry("invalid service broker fields", "fields[service_plan.service_offering.service_broker]=foo", "value must be one of"),
 		Entry("invalid service plan fields", "fields[service_plan]=foo", "value must be one of"),
 	)
 
+	It("rejects an unsupported order_by with a bad query parameter error", func() {
+		_, decodeErr := decodeQuery[payloads.ServiceInstanceList]("order_by=foo")
+		Expect(decodeErr).To(BeAssignableToTypeOf(apierrors.BadQueryParamValueError{}))
+	})
+
 	Describe("ToMessage", func() {
 		var (
 			payload payloads.ServiceInstanceList
@@ -76,6 +84,7 @@ var _ = Describe("ServiceInstanceList", func() {
 				Names:         "n1,n2",
 				GUIDs:         "g1,g2",
 				SpaceGUIDs:    "sg1,sg2",
+				Type:          "managed",
 				OrderBy:       "order",
 				LabelSelector: "foo=bar",
 				PlanGUIDs:     "p1,p2",
@@ -91,6 +100,7 @@ var _ = Describe("ServiceInstanceList", func() {
 				Names:         []string{"n1", "n2"},
 				SpaceGUIDs:    []string{"sg1", "sg2"},
 				GUIDs:         []string{"g1", "g2"},
+				Type:          "managed",
 				OrderBy:       "order",
 				LabelSelector: "foo=bar",
 				PlanGUIDs:     []string{"p1", "p2"},
@@ -237,6 +247,17 @@ var _ = Describe("ServiceInstanceCreate", func() {
 			})
 		})
 
+		When("a syslog drain url is set", func() {
+			BeforeEach(func() {
+				createPayload.SyslogDrainURL = tools.PtrTo("syslog://logs.example.org")
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(serviceInstanceCreate).To(PointTo(Equal(createPayload)))
+			})
+		})
+
 		When("metadata is invalid", func() {
 			BeforeEach(func() {
 				createPayload.Metadata = payloads.Metadata{
@@ -276,6 +297,142 @@ var _ = Describe("ServiceInstanceCreate", func() {
 					expectUnprocessableEntityError(validatorErr, "relationships.service_plan is required")
 				})
 			})
+
+			When("a syslog drain url is set", func() {
+				BeforeEach(func() {
+					createPayload.SyslogDrainURL = tools.PtrTo("syslog://logs.example.org")
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "syslog_drain_url must be blank")
+				})
+			})
+		})
+
+		When("the instance type is volume", func() {
+			BeforeEach(func() {
+				createPayload.Type = "volume"
+				createPayload.Credentials = nil
+				createPayload.Volume = &payloads.ServiceInstanceVolume{
+					SizeMB:    1024,
+					MountPath: "/var/vcap/data/shared",
+				}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(serviceInstanceCreate).To(PointTo(Equal(createPayload)))
+			})
+
+			When("the volume is not set", func() {
+				BeforeEach(func() {
+					createPayload.Volume = nil
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "volume is required")
+				})
+			})
+
+			When("the volume size is not positive", func() {
+				BeforeEach(func() {
+					createPayload.Volume.SizeMB = 0
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "volume.size_mb must be greater than 0")
+				})
+			})
+
+			When("the access mode is not supported", func() {
+				BeforeEach(func() {
+					createPayload.Volume.AccessMode = "ReadOnlyMany"
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "volume.access_mode value must be one of: ReadWriteOnce, ReadWriteOncePod, ReadWriteMany")
+				})
+			})
+
+			When("the mount path is not absolute", func() {
+				BeforeEach(func() {
+					createPayload.Volume.MountPath = "data"
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "volume.mount_path must be an absolute path")
+				})
+			})
+
+			When("a syslog drain url is set", func() {
+				BeforeEach(func() {
+					createPayload.SyslogDrainURL = tools.PtrTo("syslog://logs.example.org")
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "syslog_drain_url must be blank")
+				})
+			})
+		})
+
+		When("a volume is set on a non-volume instance", func() {
+			BeforeEach(func() {
+				createPayload.Volume = &payloads.ServiceInstanceVolume{
+					SizeMB:    1024,
+					MountPath: "/var/vcap/data/shared",
+				}
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "volume must be blank")
+			})
+		})
+	})
+
+	Describe("ToVolumeSICreateMessage()", func() {
+		var msg repositories.CreateVolumeSIMessage
+
+		BeforeEach(func() {
+			createPayload = payloads.ServiceInstanceCreate{
+				Name: "service-instance-name",
+				Type: "volume",
+				Tags: []string{"foo", "bar"},
+				Volume: &payloads.ServiceInstanceVolume{
+					SizeMB:       1024,
+					StorageClass: tools.PtrTo("nfs"),
+					AccessMode:   "ReadWriteMany",
+					MountPath:    "/var/vcap/data/shared",
+				},
+				Relationships: &payloads.ServiceInstanceRelationships{
+					Space: &payloads.Relationship{
+						Data: &payloads.RelationshipData{
+							GUID: "space-guid",
+						},
+					},
+				},
+				Metadata: payloads.Metadata{
+					Annotations: map[string]string{"ann1": "val_ann1"},
+					Labels:      map[string]string{"lab1": "val_lab1"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			msg = createPayload.ToVolumeSICreateMessage()
+		})
+
+		It("converts to repo message correctly", func() {
+			Expect(msg).To(Equal(repositories.CreateVolumeSIMessage{
+				Name:             "service-instance-name",
+				SpaceGUID:        "space-guid",
+				SizeMB:           1024,
+				StorageClassName: tools.PtrTo("nfs"),
+				AccessMode:       "ReadWriteMany",
+				MountPath:        "/var/vcap/data/shared",
+				Tags:             []string{"foo", "bar"},
+				Labels:           map[string]string{"lab1": "val_lab1"},
+				Annotations:      map[string]string{"ann1": "val_ann1"},
+			}))
 		})
 	})
 
@@ -294,6 +451,7 @@ var _ = Describe("ServiceInstanceCreate", func() {
 						"a": "b",
 					},
 				},
+				SyslogDrainURL: tools.PtrTo("syslog://logs.example.org"),
 				Relationships: &payloads.ServiceInstanceRelationships{
 					Space: &payloads.Relationship{
 						Data: &payloads.RelationshipData{
@@ -316,6 +474,7 @@ var _ = Describe("ServiceInstanceCreate", func() {
 			Expect(msg.Name).To(Equal("service-instance-name"))
 			Expect(msg.SpaceGUID).To(Equal("space-guid"))
 			Expect(msg.Tags).To(ConsistOf("foo", "bar"))
+			Expect(msg.SyslogDrainURL).To(PointTo(Equal("syslog://logs.example.org")))
 			Expect(msg.Annotations).To(HaveLen(1))
 			Expect(msg.Annotations).To(HaveKeyWithValue("ann1", "val_ann1"))
 			Expect(msg.Labels).To(HaveLen(1))
@@ -451,6 +610,7 @@ var _ = Describe("ServiceInstancePatch", func() {
 					"a": "b",
 				},
 			},
+			SyslogDrainURL: tools.PtrTo("syslog://logs.example.org"),
 			Metadata: payloads.MetadataPatch{
 				Annotations: map[string]*string{"ann1": tools.PtrTo("val_ann1")},
 				Labels:      map[string]*string{"lab1": tools.PtrTo("val_lab1")},
@@ -495,6 +655,7 @@ var _ = Describe("ServiceInstancePatch", func() {
 			Expect(msg.GUID).To(Equal("app-guid"))
 			Expect(msg.Name).To(PointTo(Equal("service-instance-name")))
 			Expect(msg.Tags).To(PointTo(ConsistOf("foo", "bar")))
+			Expect(msg.SyslogDrainURL).To(PointTo(Equal("syslog://logs.example.org")))
 			Expect(msg.Annotations).To(MatchAllKeys(Keys{
 				"ann1": PointTo(Equal("val_ann1")),
 			}))
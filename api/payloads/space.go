@@ -4,6 +4,7 @@ import (
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/jellydator/validation"
 )
@@ -64,6 +65,16 @@ type SpaceList struct {
 	Names             string
 	GUIDs             string
 	OrganizationGUIDs string
+	LabelSelector     string
+	OrderBy           string
+	Pagination
+}
+
+func (l SpaceList) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.OrderBy, payload_validation.OneOfOrderBy("created_at", "updated_at", "name")),
+		validation.Field(&l.Pagination),
+	)
 }
 
 func (l *SpaceList) ToMessage() repositories.ListSpacesMessage {
@@ -71,16 +82,34 @@ func (l *SpaceList) ToMessage() repositories.ListSpacesMessage {
 		Names:             parse.ArrayParam(l.Names),
 		GUIDs:             parse.ArrayParam(l.GUIDs),
 		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+		LabelSelector:     l.LabelSelector,
+		OrderBy:           l.OrderBy,
 	}
 }
 
 func (l *SpaceList) SupportedKeys() []string {
-	return []string{"names", "guids", "organization_guids", "order_by", "per_page", "page"}
+	return []string{"names", "guids", "organization_guids", "order_by", "per_page", "page", "label_selector"}
 }
 
 func (l *SpaceList) DecodeFromURLValues(values url.Values) error {
 	l.Names = values.Get("names")
 	l.GUIDs = values.Get("guids")
 	l.OrganizationGUIDs = values.Get("organization_guids")
-	return nil
+	l.LabelSelector = values.Get("label_selector")
+	l.OrderBy = values.Get("order_by")
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+type SpaceDelete struct {
+	OverrideProtection bool
+}
+
+func (d *SpaceDelete) SupportedKeys() []string {
+	return []string{"override_protection"}
+}
+
+func (d *SpaceDelete) DecodeFromURLValues(values url.Values) error {
+	var err error
+	d.OverrideProtection, err = getBool(values, "override_protection")
+	return err
 }
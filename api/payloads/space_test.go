@@ -1,6 +1,7 @@
 package payloads_test
 
 import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
@@ -148,10 +149,28 @@ var _ = Describe("Space", func() {
 				Entry("names", "names=name", payloads.SpaceList{Names: "name"}),
 				Entry("guids", "guids=guid", payloads.SpaceList{GUIDs: "guid"}),
 				Entry("organization_guids", "organization_guids=org-guid", payloads.SpaceList{OrganizationGUIDs: "org-guid"}),
-				Entry("order_by", "order_by=something", payloads.SpaceList{}),
-				Entry("per_page", "per_page=few", payloads.SpaceList{}),
-				Entry("page", "page=3", payloads.SpaceList{}),
+				Entry("order_by name", "order_by=name", payloads.SpaceList{OrderBy: "name"}),
+				Entry("order_by -created_at", "order_by=-created_at", payloads.SpaceList{OrderBy: "-created_at"}),
+				Entry("order_by updated_at", "order_by=updated_at", payloads.SpaceList{OrderBy: "updated_at"}),
+				Entry("per_page", "per_page=10", payloads.SpaceList{Pagination: payloads.Pagination{PerPage: "10"}}),
+				Entry("page", "page=3", payloads.SpaceList{Pagination: payloads.Pagination{Page: "3"}}),
+				Entry("label_selector", "label_selector=foo", payloads.SpaceList{LabelSelector: "foo"}),
 			)
+
+			DescribeTable("invalid query",
+				func(query string, expectedErrMsg string) {
+					_, decodeErr := decodeQuery[payloads.SpaceList](query)
+					Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+				},
+				Entry("per_page is not a number", "per_page=few", "per_page: must be an integer between 1 and 5000"),
+				Entry("page is zero", "page=0", "page: must be a positive integer"),
+			)
+
+			It("rejects an unsupported order_by with a bad query parameter error", func() {
+				_, decodeErr := decodeQuery[payloads.SpaceList]("order_by=state")
+				Expect(decodeErr).To(BeAssignableToTypeOf(apierrors.BadQueryParamValueError{}))
+				Expect(decodeErr.(apierrors.BadQueryParamValueError).Detail()).To(ContainSubstring("order_by can only be"))
+			})
 		})
 
 		Describe("ToMessage", func() {
@@ -160,13 +179,39 @@ var _ = Describe("Space", func() {
 					Names:             "foo,bar",
 					GUIDs:             "g1,g2",
 					OrganizationGUIDs: "org1,org2",
+					LabelSelector:     "foo=bar",
+					OrderBy:           "-name",
 				}
 				Expect(spaceList.ToMessage()).To(Equal(repositories.ListSpacesMessage{
 					Names:             []string{"foo", "bar"},
 					GUIDs:             []string{"g1", "g2"},
 					OrganizationGUIDs: []string{"org1", "org2"},
+					LabelSelector:     "foo=bar",
+					OrderBy:           "-name",
 				}))
 			})
 		})
 	})
+
+	Describe("SpaceDelete", func() {
+		DescribeTable("valid query",
+			func(query string, expectedSpaceDelete payloads.SpaceDelete) {
+				actualSpaceDelete, decodeErr := decodeQuery[payloads.SpaceDelete](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualSpaceDelete).To(Equal(expectedSpaceDelete))
+			},
+			Entry("no query", "", payloads.SpaceDelete{}),
+			Entry("override_protection", "override_protection=true", payloads.SpaceDelete{OverrideProtection: true}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string) {
+				_, decodeErr := decodeQuery[payloads.SpaceDelete](query)
+				Expect(decodeErr).To(HaveOccurred())
+			},
+			Entry("unsupported param", "foo=bar"),
+			Entry("invalid value for override_protection", "override_protection=foo"),
+		)
+	})
 })
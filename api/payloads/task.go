@@ -5,18 +5,49 @@ import (
 	"strconv"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 	"github.com/jellydator/validation"
 )
 
 type TaskCreate struct {
-	Command  string   `json:"command"`
-	Metadata Metadata `json:"metadata"`
+	Command  string        `json:"command"`
+	Name     string        `json:"name"`
+	MemoryMB *int64        `json:"memory_in_mb"`
+	DiskMB   *int64        `json:"disk_in_mb"`
+	Template *TaskTemplate `json:"template"`
+	Metadata Metadata      `json:"metadata"`
+}
+
+// TaskTemplate allows omitting the task command in favour of the command of
+// a process type defined in the app droplet
+type TaskTemplate struct {
+	Process TaskTemplateProcess `json:"process"`
+}
+
+type TaskTemplateProcess struct {
+	Type string `json:"type"`
+}
+
+func (t TaskTemplate) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Process),
+	)
+}
+
+func (p TaskTemplateProcess) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Type, validation.Required),
+	)
 }
 
 func (c TaskCreate) Validate() error {
 	return validation.ValidateStruct(&c,
-		validation.Field(&c.Command, validation.Required),
+		validation.Field(&c.Command, validation.When(c.Template == nil, validation.Required)),
+		validation.Field(&c.Template),
+		validation.Field(&c.MemoryMB, validation.Min(1).Error("must be greater than 0")),
+		validation.Field(&c.DiskMB, validation.Min(1).Error("must be greater than 0")),
 		validation.Field(&c.Metadata),
 	)
 }
@@ -26,22 +57,36 @@ func (p TaskCreate) ToMessage(appRecord repositories.AppRecord) repositories.Cre
 		Command:   p.Command,
 		SpaceGUID: appRecord.SpaceGUID,
 		AppGUID:   appRecord.GUID,
+		Name:      p.Name,
+		MemoryMB:  tools.ZeroIfNil(p.MemoryMB),
+		DiskMB:    tools.ZeroIfNil(p.DiskMB),
 		Metadata:  repositories.Metadata(p.Metadata),
 	}
 }
 
 type TaskList struct {
 	SequenceIDs []int64
+	AppGUIDs    string
+	States      string
+	Pagination
+}
+
+func (t TaskList) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Pagination),
+	)
 }
 
 func (t *TaskList) ToMessage() repositories.ListTaskMessage {
 	return repositories.ListTaskMessage{
 		SequenceIDs: t.SequenceIDs,
+		AppGUIDs:    parse.ArrayParam(t.AppGUIDs),
+		States:      parse.ArrayParam(t.States),
 	}
 }
 
 func (t *TaskList) SupportedKeys() []string {
-	return []string{"sequence_ids", "per_page", "page"}
+	return []string{"sequence_ids", "app_guids", "states", "per_page", "page"}
 }
 
 func (a *TaskList) DecodeFromURLValues(values url.Values) error {
@@ -60,7 +105,9 @@ func (a *TaskList) DecodeFromURLValues(values url.Values) error {
 	}
 
 	a.SequenceIDs = ids
-	return nil
+	a.AppGUIDs = values.Get("app_guids")
+	a.States = values.Get("states")
+	return a.Pagination.DecodeFromURLValues(values)
 }
 
 type TaskUpdate struct {
@@ -34,7 +34,26 @@ var _ = Describe("TaskList", func() {
 		Entry("empty sequence_ids", "sequence_ids=", payloads.TaskList{}, ""),
 		Entry("empty sequence_id", "sequence_ids=1,,3", payloads.TaskList{SequenceIDs: []int64{1, 3}}, ""),
 		Entry("invalid sequence_ids", "sequence_ids=1,two,3", payloads.TaskList{}, "invalid syntax"),
+		Entry("app_guids", "app_guids=app1,app2", payloads.TaskList{AppGUIDs: "app1,app2"}, ""),
+		Entry("states", "states=RUNNING,FAILED", payloads.TaskList{States: "RUNNING,FAILED"}, ""),
+		Entry("pagination", "per_page=10&page=2", payloads.TaskList{Pagination: payloads.Pagination{PerPage: "10", Page: "2"}}, ""),
+		Entry("invalid per_page", "per_page=0", payloads.TaskList{}, "per_page: must be an integer between"),
 	)
+
+	Describe("ToMessage", func() {
+		It("converts to repo message correctly", func() {
+			taskList := payloads.TaskList{
+				SequenceIDs: []int64{1, 2},
+				AppGUIDs:    "app1,app2",
+				States:      "RUNNING,FAILED",
+			}
+			Expect(taskList.ToMessage()).To(Equal(repositories.ListTaskMessage{
+				SequenceIDs: []int64{1, 2},
+				AppGUIDs:    []string{"app1", "app2"},
+				States:      []string{"RUNNING", "FAILED"},
+			}))
+		})
+	})
 })
 
 var _ = Describe("TaskCreate", func() {
@@ -42,7 +61,10 @@ var _ = Describe("TaskCreate", func() {
 
 	BeforeEach(func() {
 		payload = payloads.TaskCreate{
-			Command: "sleep 9000",
+			Command:  "sleep 9000",
+			Name:     "migrate",
+			MemoryMB: tools.PtrTo(int64(256)),
+			DiskMB:   tools.PtrTo(int64(1024)),
 			Metadata: payloads.Metadata{
 				Labels: map[string]string{
 					"foo": "bar",
@@ -79,6 +101,49 @@ var _ = Describe("TaskCreate", func() {
 			It("returns an appropriate error", func() {
 				expectUnprocessableEntityError(validatorErr, "command cannot be blank")
 			})
+
+			When("a template process type is set", func() {
+				BeforeEach(func() {
+					payload.Template = &payloads.TaskTemplate{
+						Process: payloads.TaskTemplateProcess{Type: "db:migrate"},
+					}
+				})
+
+				It("succeeds", func() {
+					Expect(validatorErr).NotTo(HaveOccurred())
+					Expect(decodedPayload.Template.Process.Type).To(Equal("db:migrate"))
+				})
+			})
+
+			When("the template process type is empty", func() {
+				BeforeEach(func() {
+					payload.Template = &payloads.TaskTemplate{}
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "template.process.type cannot be blank")
+				})
+			})
+		})
+
+		When("memory_in_mb is negative", func() {
+			BeforeEach(func() {
+				payload.MemoryMB = tools.PtrTo(int64(-1))
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "memory_in_mb must be greater than 0")
+			})
+		})
+
+		When("disk_in_mb is negative", func() {
+			BeforeEach(func() {
+				payload.DiskMB = tools.PtrTo(int64(-1))
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "disk_in_mb must be greater than 0")
+			})
 		})
 
 		When("metadata is invalid", func() {
@@ -101,6 +166,9 @@ var _ = Describe("TaskCreate", func() {
 			msg := payload.ToMessage(repositories.AppRecord{GUID: "appGUID", SpaceGUID: "spaceGUID"})
 			Expect(msg.AppGUID).To(Equal("appGUID"))
 			Expect(msg.SpaceGUID).To(Equal("spaceGUID"))
+			Expect(msg.Name).To(Equal("migrate"))
+			Expect(msg.MemoryMB).To(BeEquivalentTo(256))
+			Expect(msg.DiskMB).To(BeEquivalentTo(1024))
 			Expect(msg.Metadata.Labels).To(Equal(map[string]string{
 				"foo": "bar",
 				"bar": "baz",
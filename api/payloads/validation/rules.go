@@ -3,8 +3,12 @@ package validation
 import (
 	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"github.com/jellydator/validation"
 )
 
@@ -56,11 +60,59 @@ func OneOf(allowed ...any) validation.Rule {
 	return validation.In(allowed...).Error(errorMsg.String())
 }
 
+// OneOfOrderBy rejects sorting by anything but the given fields, ascending or
+// descending (prefixed with "-"). As in the CF API, this is a bad query
+// parameter rather than an unprocessable entity.
 func OneOfOrderBy(orderBys ...string) validation.Rule {
-	var allAllowed []any
+	var allowed []string
 	for _, a := range orderBys {
-		allAllowed = append(allAllowed, a, "-"+a)
+		allowed = append(allowed, a, "-"+a)
 	}
 
-	return OneOf(allAllowed...)
+	return validation.By(func(value any) error {
+		orderBy, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%T is not supported, string is expected", value)
+		}
+
+		if orderBy != "" && !slices.Contains(allowed, orderBy) {
+			return apierrors.NewBadQueryParamValueError("order_by", allowed...)
+		}
+
+		return nil
+	})
+}
+
+// OneOfIncludes rejects include rules whose relationship path is not one of
+// the given ones. As in the CF API, this is a bad query parameter rather than
+// an unprocessable entity.
+func OneOfIncludes(includes ...string) validation.Rule {
+	return validation.By(func(value any) error {
+		rules, ok := value.([]params.IncludeResourceRule)
+		if !ok {
+			return fmt.Errorf("%T is not supported, []IncludeResourceRule is expected", value)
+		}
+
+		for _, rule := range rules {
+			if !slices.Contains(includes, strings.Join(rule.RelationshipPath, ".")) {
+				return apierrors.NewBadQueryParamValueError("include", includes...)
+			}
+		}
+
+		return nil
+	})
+}
+
+func IntegerBetween(minValue, maxValue int) validation.Rule {
+	return validation.NewStringRule(func(value string) bool {
+		i, err := strconv.Atoi(value)
+		return err == nil && i >= minValue && i <= maxValue
+	}, fmt.Sprintf("must be an integer between %d and %d", minValue, maxValue))
+}
+
+func PositiveInteger() validation.Rule {
+	return validation.NewStringRule(func(value string) bool {
+		i, err := strconv.Atoi(value)
+		return err == nil && i > 0
+	}, "must be a positive integer")
 }
@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
@@ -117,12 +119,39 @@ func (dv *DecoderValidator) validatePayload(object any) error {
 	}
 
 	if err := t.Validate(); err != nil {
+		if apiErr, ok := apiErrorFrom(err); ok {
+			return apiErr
+		}
+
 		return apierrors.NewUnprocessableEntityError(err, strings.Join(errorMessages(err), ", "))
 	}
 
 	return nil
 }
 
+// apiErrorFrom looks for an api error returned by one of the validation rules,
+// e.g. a bad query parameter. It takes precedence over the unprocessable
+// entity error reported for all other validation failures.
+func apiErrorFrom(err error) (apierrors.ApiError, bool) {
+	var apiErr apierrors.ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+
+	errs, ok := err.(validation.Errors)
+	if !ok {
+		return nil, false
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(errs)) {
+		if apiErr, ok := apiErrorFrom(errs[field]); ok {
+			return apiErr, true
+		}
+	}
+
+	return nil, false
+}
+
 func errorMessages(err error) []string {
 	errs := prefixedErrorMessages("", err)
 	sort.Strings(errs)
@@ -73,6 +73,21 @@ var _ = Describe("Validator", func() {
 			})
 		})
 
+		When("a validation rule returns an api error", func() {
+			BeforeEach(func() {
+				requestUrl = "http://foo.com?key=101"
+			})
+
+			It("returns the api error", func() {
+				Expect(decodeErr).To(HaveOccurred())
+				badQueryParamErr, ok := decodeErr.(apierrors.BadQueryParamValueError)
+				Expect(ok).To(BeTrue())
+				Expect(badQueryParamErr.Detail()).To(ContainSubstring("key can only be: '0', '100'"))
+				Expect(badQueryParamErr.Title()).To(Equal("CF-BadQueryParameter"))
+				Expect(badQueryParamErr.HttpStatus()).To(Equal(http.StatusBadRequest))
+			})
+		})
+
 		When("the payload input contains unsupported key", func() {
 			BeforeEach(func() {
 				requestUrl = "http://foo.com?foo=bar"
@@ -108,7 +123,12 @@ type DecodeTestPayload struct {
 
 func (p DecodeTestPayload) Validate() error {
 	return jellidation.ValidateStruct(&p,
-		jellidation.Field(&p.Key, jellidation.Min(0)),
+		jellidation.Field(&p.Key, jellidation.Min(0), jellidation.By(func(value any) error {
+			if value.(int) > 100 {
+				return apierrors.NewBadQueryParamValueError("key", "0", "100")
+			}
+			return nil
+		})),
 	)
 }
 
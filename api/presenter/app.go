@@ -22,6 +22,7 @@ type AppResponse struct {
 	Lifecycle     Lifecycle                          `json:"lifecycle"`
 	Metadata      Metadata                           `json:"metadata"`
 	Links         AppLinks                           `json:"links"`
+	Included      map[string][]any                   `json:"included,omitempty"`
 }
 
 type AppLinks struct {
@@ -102,11 +103,12 @@ func ForApp(responseApp repositories.AppRecord, baseURL url.URL, includes ...mod
 				HRef: buildURL(baseURL).appendPath(appsBase, responseApp.GUID, "features").build(),
 			},
 		},
+		Included: includedResources(includes...),
 	}
 }
 
 type CurrentDropletResponse struct {
-	Data  RelationshipData    `json:"data"`
+	Data  *RelationshipData   `json:"data"`
 	Links CurrentDropletLinks `json:"links"`
 }
 
@@ -115,11 +117,16 @@ type CurrentDropletLinks struct {
 	Related Link `json:"related"`
 }
 
+// ForCurrentDroplet presents the current droplet relationship of an app. The
+// relationship data is null when the app has no current droplet.
 func ForCurrentDroplet(record repositories.CurrentDropletRecord, baseURL url.URL) CurrentDropletResponse {
+	var data *RelationshipData
+	if record.DropletGUID != "" {
+		data = &RelationshipData{GUID: record.DropletGUID}
+	}
+
 	return CurrentDropletResponse{
-		Data: RelationshipData{
-			GUID: record.DropletGUID,
-		},
+		Data: data,
 		Links: CurrentDropletLinks{
 			Self: Link{
 				HRef: buildURL(baseURL).appendPath(appsBase, record.AppGUID, "relationships/current_droplet").build(),
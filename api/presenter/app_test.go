@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
@@ -26,7 +27,10 @@ var _ = Describe("App", func() {
 	})
 
 	Describe("App Response", func() {
-		var record repositories.AppRecord
+		var (
+			record   repositories.AppRecord
+			includes []model.IncludedResource
+		)
 
 		BeforeEach(func() {
 			record = repositories.AppRecord{
@@ -47,10 +51,11 @@ var _ = Describe("App", func() {
 				UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
 				IsStaged:  false,
 			}
+			includes = nil
 		})
 
 		JustBeforeEach(func() {
-			response := presenter.ForApp(record, *baseURL)
+			response := presenter.ForApp(record, *baseURL, includes...)
 			var err error
 			output, err = json.Marshal(response)
 			Expect(err).NotTo(HaveOccurred())
@@ -173,6 +178,21 @@ var _ = Describe("App", func() {
 				Expect(output).To(MatchJSONPath("$.lifecycle.data", BeEmpty()))
 			})
 		})
+
+		When("includes are specified", func() {
+			BeforeEach(func() {
+				includes = []model.IncludedResource{{
+					Type: "spaces",
+					Resource: map[string]string{
+						"guid": "space-guid",
+					},
+				}}
+			})
+
+			It("renders the included resources", func() {
+				Expect(output).To(MatchJSONPath("$.included.spaces[0].guid", Equal("space-guid")))
+			})
+		})
 	})
 
 	Describe("Droplet Response", func() {
@@ -207,6 +227,16 @@ var _ = Describe("App", func() {
 				}
 			}`))
 		})
+
+		When("the app has no current droplet", func() {
+			BeforeEach(func() {
+				record.DropletGUID = ""
+			})
+
+			It("presents null relationship data", func() {
+				Expect(output).To(MatchJSONPath("$.data", BeNil()))
+			})
+		})
 	})
 
 	Describe("App Env", func() {
@@ -29,7 +29,7 @@ type BuildResponse struct {
 	Links           map[string]Link                    `json:"links"`
 }
 
-func ForBuild(buildRecord repositories.BuildRecord, baseURL url.URL) BuildResponse {
+func ForBuild(buildRecord repositories.BuildRecord, baseURL url.URL, includes ...model.IncludedResource) BuildResponse {
 	toReturn := BuildResponse{
 		GUID:            buildRecord.GUID,
 		CreatedAt:       formatTimestamp(&buildRecord.CreatedAt),
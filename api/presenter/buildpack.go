@@ -7,6 +7,10 @@ import (
 	"code.cloudfoundry.org/korifi/model"
 )
 
+const (
+	buildpacksBase = "/v3/buildpacks"
+)
+
 type BuildpackResponse struct {
 	GUID      string          `json:"guid"`
 	CreatedAt string          `json:"created_at"`
@@ -41,3 +45,23 @@ func ForBuildpack(buildpackRecord repositories.BuildpackRecord, _ url.URL, inclu
 
 	return toReturn
 }
+
+type BuildpackOrderResponse struct {
+	Buildpacks []string            `json:"buildpacks"`
+	Links      BuildpackOrderLinks `json:"links"`
+}
+
+type BuildpackOrderLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForBuildpackOrder(record repositories.BuildpackOrderRecord, apiBaseURL url.URL) BuildpackOrderResponse {
+	return BuildpackOrderResponse{
+		Buildpacks: record.Buildpacks,
+		Links: BuildpackOrderLinks{
+			Self: Link{
+				HRef: buildURL(apiBaseURL).appendPath(buildpacksBase, "order").build(),
+			},
+		},
+	}
+}
@@ -55,3 +55,28 @@ var _ = Describe("Buildpacks", func() {
 		}`))
 	})
 })
+
+var _ = Describe("BuildpackOrder", func() {
+	var output []byte
+
+	JustBeforeEach(func() {
+		baseURL, err := url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+
+		output, err = json.Marshal(presenter.ForBuildpackOrder(repositories.BuildpackOrderRecord{
+			Buildpacks: []string{"paketo-buildpacks/go", "paketo-buildpacks/java"},
+		}, *baseURL))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"buildpacks": ["paketo-buildpacks/go", "paketo-buildpacks/java"],
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/buildpacks/order"
+				}
+			}
+		}`))
+	})
+})
@@ -37,7 +37,7 @@ type DomainRelationships struct {
 }
 
 type Organization struct {
-	Data *string `json:"data"`
+	Data *RelationshipData `json:"data"`
 }
 
 type SharedOrganizations struct {
@@ -60,7 +60,7 @@ func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL, includ
 		},
 		Relationships: DomainRelationships{
 			Organization: Organization{
-				Data: nil,
+				Data: forDomainOrganization(responseDomain),
 			},
 			SharedOrganizations: SharedOrganizations{
 				Data: []string{},
@@ -77,3 +77,11 @@ func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL, includ
 		},
 	}
 }
+
+func forDomainOrganization(domain repositories.DomainRecord) *RelationshipData {
+	if domain.OrgGUID == "" {
+		return nil
+	}
+
+	return &RelationshipData{GUID: domain.OrgGUID}
+}
@@ -82,6 +82,16 @@ var _ = Describe("Domains", func() {
 		}`))
 	})
 
+	When("the domain is private", func() {
+		BeforeEach(func() {
+			record.OrgGUID = "org-guid"
+		})
+
+		It("includes the owning organization relationship", func() {
+			Expect(output).To(MatchJSONPath("$.relationships.organization.data.guid", "org-guid"))
+		})
+	})
+
 	When("labels is nil", func() {
 		BeforeEach(func() {
 			record.Labels = nil
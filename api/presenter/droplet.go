@@ -81,6 +81,10 @@ func ForDroplet(dropletRecord repositories.DropletRecord, baseURL url.URL, inclu
 	}
 	if dropletRecord.Lifecycle.Type == "docker" {
 		toReturn.Image = &dropletRecord.Image
+	} else {
+		toReturn.Links["download"] = &Link{
+			HRef: buildURL(baseURL).appendPath(dropletsBase, dropletRecord.GUID, "download").build(),
+		}
 	}
 	return toReturn
 }
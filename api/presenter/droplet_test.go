@@ -93,7 +93,9 @@ var _ = Describe("Droplet", func() {
 					"href": "https://api.example.org/v3/apps/the-app-guid/relationships/current_droplet",
 					"method": "PATCH"
 				},
-				"download": null
+				"download": {
+					"href": "https://api.example.org/v3/droplets/the-droplet-guid/download"
+				}
 			},
 			"metadata": {
 				"labels": {
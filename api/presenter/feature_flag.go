@@ -0,0 +1,38 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
+)
+
+const (
+	featureFlagsBase = "/v3/feature_flags"
+)
+
+type FeatureFlagResponse struct {
+	Name               string           `json:"name"`
+	Enabled            bool             `json:"enabled"`
+	UpdatedAt          string           `json:"updated_at"`
+	CustomErrorMessage string           `json:"custom_error_message"`
+	Links              FeatureFlagLinks `json:"links"`
+}
+
+type FeatureFlagLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForFeatureFlag(record repositories.FeatureFlagRecord, baseURL url.URL, includes ...model.IncludedResource) FeatureFlagResponse {
+	return FeatureFlagResponse{
+		Name:               record.Name,
+		Enabled:            record.Enabled,
+		UpdatedAt:          formatTimestamp(record.UpdatedAt),
+		CustomErrorMessage: record.CustomErrorMessage,
+		Links: FeatureFlagLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(featureFlagsBase, record.Name).build(),
+			},
+		},
+	}
+}
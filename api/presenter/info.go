@@ -14,6 +14,7 @@ type InfoV3Response struct {
 	Name        string                 `json:"name"`
 	Version     string                 `json:"version"`
 	Custom      map[string]interface{} `json:"custom"`
+	Features    map[string]bool        `json:"features"`
 
 	Links map[string]Link `json:"links"`
 }
@@ -23,7 +24,10 @@ type InfoCLIVersion struct {
 	Recommended string `json:"recommended"`
 }
 
-func ForInfoV3(baseURL url.URL, infoConfig config.InfoConfig) InfoV3Response {
+// ForInfoV3 presents the info document. Besides the standard CF fields it
+// reports which optional Korifi features are enabled, so that clients can tell
+// which endpoints they can rely on.
+func ForInfoV3(baseURL url.URL, infoConfig config.InfoConfig, experimental config.Experimental) InfoV3Response {
 	return InfoV3Response{
 		Build:       version.Version,
 		Description: infoConfig.Description,
@@ -34,6 +38,10 @@ func ForInfoV3(baseURL url.URL, infoConfig config.InfoConfig) InfoV3Response {
 			Recommended: infoConfig.RecommendedCLIVersion,
 		},
 		Custom: emptyMapIfNil(infoConfig.Custom),
+		Features: map[string]bool{
+			"managed_services": experimental.ManagedServices.Enabled,
+			"uaa":              experimental.UAA.Enabled,
+		},
 		Links: map[string]Link{
 			"self": {
 				HRef: buildURL(baseURL).appendPath("v3/info").build(),
@@ -13,9 +13,10 @@ import (
 
 var _ = Describe("Info endpoints", func() {
 	var (
-		baseURL    *url.URL
-		infoConfig config.InfoConfig
-		output     []byte
+		baseURL      *url.URL
+		infoConfig   config.InfoConfig
+		experimental config.Experimental
+		output       []byte
 	)
 
 	BeforeEach(func() {
@@ -26,11 +27,14 @@ var _ = Describe("Info endpoints", func() {
 		infoConfig = config.InfoConfig{
 			SupportAddress: "https://www.cloudfoundry.org/technology/korifi/",
 		}
+		experimental = config.Experimental{
+			ManagedServices: config.ManagedServices{Enabled: true},
+		}
 	})
 
 	Context("/v3/info", func() {
 		JustBeforeEach(func() {
-			response := presenter.ForInfoV3(*baseURL, infoConfig)
+			response := presenter.ForInfoV3(*baseURL, infoConfig, experimental)
 			var err error
 			output, err = json.Marshal(response)
 			Expect(err).NotTo(HaveOccurred())
@@ -47,6 +51,10 @@ var _ = Describe("Info endpoints", func() {
 				"name": "",
 				"version": "v9999.99.99-local.dev",
 				"custom": {},
+				"features": {
+					"managed_services": true,
+					"uaa": false
+				},
 				"links": {
 					"self": {
 							"href": "https://api.example.org/v3/info"
@@ -1,6 +1,11 @@
 package presenter
 
 import (
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
 	"code.cloudfoundry.org/go-loggregator/v8/rpc/loggregator_v2"
 	"code.cloudfoundry.org/korifi/api/repositories"
 )
@@ -14,9 +19,10 @@ type LogCacheReadResponseEnvelopes struct {
 }
 
 type LogCacheReadResponseBatch struct {
-	Timestamp int64                   `json:"timestamp"`
-	Log       LogCacheReadResponseLog `json:"log"`
-	Tags      map[string]string       `json:"tags,omitempty"`
+	Timestamp  int64                   `json:"timestamp"`
+	InstanceID string                  `json:"instance_id,omitempty"`
+	Log        LogCacheReadResponseLog `json:"log"`
+	Tags       map[string]string       `json:"tags,omitempty"`
 }
 
 type LogCacheReadResponseLog struct {
@@ -24,19 +30,10 @@ type LogCacheReadResponseLog struct {
 	Type    loggregator_v2.Log_Type `json:"type"`
 }
 
-func ForLogs(logRecords []repositories.LogRecord) LogCacheReadResponse {
+func ForLogs(logRecords []repositories.LogRecord, sourcePrefixing bool) LogCacheReadResponse {
 	envelopes := make([]LogCacheReadResponseBatch, 0, len(logRecords))
 	for _, logRecord := range logRecords {
-		batch := LogCacheReadResponseBatch{
-			Timestamp: logRecord.Timestamp,
-			Log: LogCacheReadResponseLog{
-				Payload: []byte(logRecord.Message),
-				Type:    loggregator_v2.Log_OUT,
-			},
-			Tags: logRecord.Tags,
-		}
-
-		envelopes = append(envelopes, batch)
+		envelopes = append(envelopes, ForLogEnvelope(logRecord, sourcePrefixing))
 	}
 
 	return LogCacheReadResponse{
@@ -45,3 +42,51 @@ func ForLogs(logRecords []repositories.LogRecord) LogCacheReadResponse {
 		},
 	}
 }
+
+// ForLogEnvelope renders a log record as a log cache envelope. When source
+// prefixing is enabled app logs are attributed to the process instance that
+// produced them, so that the CLI renders them as e.g. `[APP/PROC/WEB/0]`.
+func ForLogEnvelope(logRecord repositories.LogRecord, sourcePrefixing bool) LogCacheReadResponseBatch {
+	envelope := LogCacheReadResponseBatch{
+		Timestamp: logRecord.Timestamp,
+		Log: LogCacheReadResponseLog{
+			Payload: []byte(logRecord.Message),
+			Type:    loggregator_v2.Log_OUT,
+		},
+		Tags: logRecord.Tags,
+	}
+
+	if sourcePrefixing && logRecord.Tags["source_type"] == "APP" && logRecord.ProcessType != "" {
+		envelope.Tags = maps.Clone(logRecord.Tags)
+		envelope.Tags["source_type"] = "APP/PROC/" + strings.ToUpper(logRecord.ProcessType)
+		envelope.InstanceID = logRecord.InstanceID
+	}
+
+	return envelope
+}
+
+// AppLogResponse is a log line as returned by the app logs endpoints. Source
+// is either APP or STG, the process type and instance index are only set for
+// lines produced by an app instance.
+type AppLogResponse struct {
+	Timestamp   string `json:"timestamp"`
+	Source      string `json:"source"`
+	ProcessType string `json:"process_type,omitempty"`
+	Instance    *int   `json:"instance,omitempty"`
+	Message     string `json:"message"`
+}
+
+func ForAppLog(logRecord repositories.LogRecord) AppLogResponse {
+	response := AppLogResponse{
+		Timestamp:   time.Unix(0, logRecord.Timestamp).UTC().Format(time.RFC3339Nano),
+		Source:      logRecord.Tags["source_type"],
+		ProcessType: logRecord.ProcessType,
+		Message:     logRecord.Message,
+	}
+
+	if instance, err := strconv.Atoi(logRecord.InstanceID); err == nil {
+		response.Instance = &instance
+	}
+
+	return response
+}
@@ -12,8 +12,9 @@ import (
 
 var _ = Describe("LogCache", func() {
 	var (
-		output  []byte
-		records []repositories.LogRecord
+		output          []byte
+		records         []repositories.LogRecord
+		sourcePrefixing bool
 	)
 
 	BeforeEach(func() {
@@ -30,10 +31,11 @@ var _ = Describe("LogCache", func() {
 				Timestamp: 456,
 			},
 		}
+		sourcePrefixing = false
 	})
 
 	JustBeforeEach(func() {
-		response := presenter.ForLogs(records)
+		response := presenter.ForLogs(records, sourcePrefixing)
 		var err error
 		output, err = json.Marshal(response)
 		Expect(err).NotTo(HaveOccurred())
@@ -64,4 +66,109 @@ var _ = Describe("LogCache", func() {
 			}
 		}`))
 	})
+
+	When("source prefixing is enabled", func() {
+		BeforeEach(func() {
+			sourcePrefixing = true
+			records = []repositories.LogRecord{
+				{
+					Message:     "app-message",
+					Timestamp:   123,
+					Tags:        map[string]string{"source_type": "APP"},
+					ProcessType: "web",
+					InstanceID:  "0",
+				},
+				{
+					Message:   "staging-message",
+					Timestamp: 456,
+					Tags:      map[string]string{"source_type": "STG"},
+				},
+			}
+		})
+
+		It("attributes app logs to the process instance", func() {
+			Expect(output).To(MatchJSON(`{
+				"envelopes": {
+					"batch": [
+						{
+							"timestamp": 123,
+							"instance_id": "0",
+							"log": {
+								"payload": "YXBwLW1lc3NhZ2U=",
+								"type": 0
+							},
+							"tags": {
+								"source_type": "APP/PROC/WEB"
+							}
+						},
+						{
+							"timestamp": 456,
+							"log": {
+								"payload": "c3RhZ2luZy1tZXNzYWdl",
+								"type": 0
+							},
+							"tags": {
+								"source_type": "STG"
+							}
+						}
+					]
+				}
+			}`))
+		})
+
+		It("does not modify the log record tags", func() {
+			Expect(records[0].Tags).To(Equal(map[string]string{"source_type": "APP"}))
+		})
+	})
+})
+
+var _ = Describe("AppLog", func() {
+	var (
+		output []byte
+		record repositories.LogRecord
+	)
+
+	BeforeEach(func() {
+		record = repositories.LogRecord{
+			Message:     "app-message",
+			Timestamp:   1500000000,
+			Tags:        map[string]string{"source_type": "APP"},
+			ProcessType: "web",
+			InstanceID:  "0",
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForAppLog(record))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected app log json", func() {
+		Expect(output).To(MatchJSON(`{
+			"timestamp": "1970-01-01T00:00:01.5Z",
+			"source": "APP",
+			"process_type": "web",
+			"instance": 0,
+			"message": "app-message"
+		}`))
+	})
+
+	When("the log line is a staging log", func() {
+		BeforeEach(func() {
+			record = repositories.LogRecord{
+				Message:   "staging-message",
+				Timestamp: 1500000000,
+				Tags:      map[string]string{"source_type": "STG"},
+			}
+		})
+
+		It("omits the process instance", func() {
+			Expect(output).To(MatchJSON(`{
+				"timestamp": "1970-01-01T00:00:01.5Z",
+				"source": "STG",
+				"message": "staging-message"
+			}`))
+		})
+	})
 })
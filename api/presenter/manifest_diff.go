@@ -0,0 +1,30 @@
+package presenter
+
+import "code.cloudfoundry.org/korifi/api/actions/manifest"
+
+type ManifestDiffResponse struct {
+	Diff []ManifestDiffEntry `json:"diff"`
+}
+
+type ManifestDiffEntry struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Was   any    `json:"was,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+func ForManifestDiff(diff []manifest.DiffEntry) ManifestDiffResponse {
+	entries := make([]ManifestDiffEntry, 0, len(diff))
+	for _, entry := range diff {
+		entries = append(entries, ManifestDiffEntry{
+			Op:    entry.Op,
+			Path:  entry.Path,
+			Was:   entry.Was,
+			Value: entry.Value,
+		})
+	}
+
+	return ManifestDiffResponse{
+		Diff: entries,
+	}
+}
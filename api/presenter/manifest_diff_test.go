@@ -0,0 +1,52 @@
+package presenter_test
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
+	"code.cloudfoundry.org/korifi/api/presenter"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ManifestDiff", func() {
+	var (
+		output []byte
+		diff   []manifest.DiffEntry
+	)
+
+	BeforeEach(func() {
+		diff = []manifest.DiffEntry{
+			{Op: manifest.DiffOpAdd, Path: "/applications/0/env/FOO", Value: "bar"},
+			{Op: manifest.DiffOpReplace, Path: "/applications/0/processes/0/instances", Was: int32(0), Value: int32(2)},
+			{Op: manifest.DiffOpRemove, Path: "/applications/0/routes", Was: []map[string]string{{"route": "my.route"}}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForManifestDiff(diff))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected diff json", func() {
+		Expect(output).To(MatchJSON(`{
+			"diff": [
+				{"op": "add", "path": "/applications/0/env/FOO", "value": "bar"},
+				{"op": "replace", "path": "/applications/0/processes/0/instances", "was": 0, "value": 2},
+				{"op": "remove", "path": "/applications/0/routes", "was": [{"route": "my.route"}]}
+			]
+		}`))
+	})
+
+	When("there is no diff", func() {
+		BeforeEach(func() {
+			diff = nil
+		})
+
+		It("produces an empty diff array", func() {
+			Expect(output).To(MatchJSON(`{"diff": []}`))
+		})
+	})
+})
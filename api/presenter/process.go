@@ -15,18 +15,19 @@ const (
 )
 
 type ProcessResponse struct {
-	GUID          string                             `json:"guid"`
-	Type          string                             `json:"type"`
-	Command       string                             `json:"command"`
-	Instances     int32                              `json:"instances"`
-	MemoryMB      int64                              `json:"memory_in_mb"`
-	DiskQuotaMB   int64                              `json:"disk_in_mb"`
-	HealthCheck   ProcessResponseHealthCheck         `json:"health_check"`
-	Relationships map[string]model.ToOneRelationship `json:"relationships"`
-	Metadata      Metadata                           `json:"metadata"`
-	CreatedAt     string                             `json:"created_at"`
-	UpdatedAt     string                             `json:"updated_at"`
-	Links         ProcessLinks                       `json:"links"`
+	GUID                   string                             `json:"guid"`
+	Type                   string                             `json:"type"`
+	Command                string                             `json:"command"`
+	Instances              int32                              `json:"instances"`
+	MemoryMB               int64                              `json:"memory_in_mb"`
+	DiskQuotaMB            int64                              `json:"disk_in_mb"`
+	HealthCheck            ProcessResponseHealthCheck         `json:"health_check"`
+	ConnectionDrainTimeout int32                              `json:"connection_drain_timeout"`
+	Relationships          map[string]model.ToOneRelationship `json:"relationships"`
+	Metadata               Metadata                           `json:"metadata"`
+	CreatedAt              string                             `json:"created_at"`
+	UpdatedAt              string                             `json:"updated_at"`
+	Links                  ProcessLinks                       `json:"links"`
 }
 
 type ProcessLinks struct {
@@ -115,7 +116,8 @@ func ForProcess(responseProcess repositories.ProcessRecord, baseURL url.URL) Pro
 				HTTPEndpoint:      responseProcess.HealthCheck.Data.HTTPEndpoint,
 			},
 		},
-		Relationships: ForRelationships(responseProcess.Relationships()),
+		ConnectionDrainTimeout: responseProcess.ConnectionDrainTimeoutSeconds,
+		Relationships:          ForRelationships(responseProcess.Relationships()),
 		Metadata: Metadata{
 			Labels:      responseProcess.Labels,
 			Annotations: responseProcess.Annotations,
@@ -143,10 +145,38 @@ func ForProcess(responseProcess repositories.ProcessRecord, baseURL url.URL) Pro
 	}
 }
 
-func ForProcessList(processRecordList []repositories.ProcessRecord, baseURL, requestURL url.URL, includes ...model.IncludedResource) ListResponse[ProcessResponse] {
-	return ForList(func(process repositories.ProcessRecord, baseURL url.URL, includes ...model.IncludedResource) ProcessResponse {
+func ForProcessList(processRecordList []repositories.ProcessRecord, baseURL, requestURL url.URL, page, perPage int, includes ...model.IncludedResource) ListResponse[ProcessResponse] {
+	return ForPaginatedList(func(process repositories.ProcessRecord, baseURL url.URL, includes ...model.IncludedResource) ProcessResponse {
 		processResponse := ForProcess(process, baseURL)
 		processResponse.Command = "[PRIVATE DATA HIDDEN IN LISTS]"
 		return processResponse
-	}, processRecordList, baseURL, requestURL)
+	}, processRecordList, baseURL, requestURL, page, perPage)
+}
+
+type ProcessSidecarResponse struct {
+	GUID          string                             `json:"guid"`
+	Name          string                             `json:"name"`
+	Command       string                             `json:"command"`
+	ProcessTypes  []string                           `json:"process_types"`
+	MemoryMB      int64                              `json:"memory_in_mb"`
+	Origin        string                             `json:"origin"`
+	Relationships map[string]model.ToOneRelationship `json:"relationships"`
+	CreatedAt     string                             `json:"created_at"`
+	UpdatedAt     string                             `json:"updated_at"`
+}
+
+// ForProcessSidecar presents sidecars as user defined as korifi does not
+// support buildpack defined sidecars
+func ForProcessSidecar(sidecar repositories.SidecarRecord, _ url.URL, _ ...model.IncludedResource) ProcessSidecarResponse {
+	return ProcessSidecarResponse{
+		GUID:          sidecar.GUID,
+		Name:          sidecar.Name,
+		Command:       sidecar.Command,
+		ProcessTypes:  sidecar.ProcessTypes,
+		MemoryMB:      sidecar.MemoryMB,
+		Origin:        "user",
+		Relationships: ForRelationships(map[string]string{"app": sidecar.AppGUID}),
+		CreatedAt:     formatTimestamp(&sidecar.CreatedAt),
+		UpdatedAt:     formatTimestamp(sidecar.UpdatedAt),
+	}
 }
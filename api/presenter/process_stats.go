@@ -10,15 +10,16 @@ type ProcessStatsResource struct {
 	Type             string                 `json:"type"`
 	Index            int                    `json:"index"`
 	State            string                 `json:"state"`
+	RestartCount     int32                  `json:"restart_count"`
 	Usage            ProcessUsage           `json:"usage"`
 	Host             *string                `json:"host"`
 	InstancePorts    *[]ProcessInstancePort `json:"instance_ports,omitempty"`
-	Uptime           *int                   `json:"uptime"`
+	Uptime           *int64                 `json:"uptime"`
 	MemQuota         *int64                 `json:"mem_quota"`
 	DiskQuota        *int64                 `json:"disk_quota"`
 	FDSQuota         *int                   `json:"fds_quota"`
 	IsolationSegment *string                `json:"isolation_segment"`
-	Details          *ProcessDetails        `json:"details"`
+	Details          *string                `json:"details"`
 }
 
 type ProcessUsage struct {
@@ -35,8 +36,6 @@ type ProcessInstancePort struct {
 	InternalTLSProxyPort int `json:"internal_tls_proxy_port"`
 }
 
-type ProcessDetails struct{}
-
 func ForProcessStats(records []actions.PodStatsRecord) ProcessStatsResponse {
 	resources := []ProcessStatsResource{}
 	for _, record := range records {
@@ -54,6 +53,7 @@ func statRecordToResource(record actions.PodStatsRecord) ProcessStatsResource {
 		Type:          record.Type,
 		Index:         record.Index,
 		State:         record.State,
+		RestartCount:  record.RestartCount,
 		InstancePorts: processInstancePorts,
 		Usage: ProcessUsage{
 			Time: record.Usage.Time,
@@ -61,7 +61,9 @@ func statRecordToResource(record actions.PodStatsRecord) ProcessStatsResource {
 			Mem:  record.Usage.Mem,
 			Disk: record.Usage.Disk,
 		},
+		Uptime:    record.Uptime,
 		MemQuota:  record.MemQuota,
 		DiskQuota: record.DiskQuota,
+		Details:   record.Details,
 	}
 }
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/actions"
 	"code.cloudfoundry.org/korifi/api/presenter"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -35,9 +36,11 @@ var _ = Describe("Process Stats", func() {
 				DiskQuota: tools.PtrTo(int64(2048)),
 			},
 			{
-				Type:  "web",
-				Index: 1,
-				State: "RUNNING",
+				Type:         "web",
+				Index:        1,
+				State:        "RUNNING",
+				RestartCount: 3,
+				Uptime:       tools.PtrTo(int64(42)),
 				Usage: actions.Usage{
 					Time: tools.PtrTo("t2"),
 					CPU:  tools.PtrTo(501.0),
@@ -64,6 +67,7 @@ var _ = Describe("Process Stats", func() {
 					"type": "web",
 					"index": 0,
 					"state": "RUNNING",
+					"restart_count": 0,
 					"host": null,
 					"uptime": null,
 					"mem_quota": 1024,
@@ -83,8 +87,9 @@ var _ = Describe("Process Stats", func() {
 					"type": "web",
 					"index": 1,
 					"state": "RUNNING",
+					"restart_count": 3,
 					"host": null,
-					"uptime": null,
+					"uptime": 42,
 					"mem_quota": 1024,
 					"disk_quota": 2048,
 					"fds_quota": null,
@@ -112,4 +117,16 @@ var _ = Describe("Process Stats", func() {
 			Expect(output).ToNot(ContainSubstring("instance_ports"))
 		})
 	})
+
+	When("an instance has crashed", func() {
+		BeforeEach(func() {
+			records[0].State = "CRASHED"
+			records[0].Details = tools.PtrTo("OutOfMemory")
+		})
+
+		It("reports the crash reason in the details", func() {
+			Expect(output).To(MatchJSONPath("$.resources[0].details", "OutOfMemory"))
+			Expect(output).To(MatchJSONPath("$.resources[1].details", BeNil()))
+		})
+	})
 })
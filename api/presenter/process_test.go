@@ -40,6 +40,7 @@ var _ = Describe("Process", func() {
 				HealthCheck: repositories.HealthCheck{
 					Type: "port",
 				},
+				ConnectionDrainTimeoutSeconds: 15,
 				Labels: map[string]string{
 					"label-key": "label-val",
 				},
@@ -73,6 +74,7 @@ var _ = Describe("Process", func() {
 						"invocation_timeout": null
 					}
 				},
+				"connection_drain_timeout": 15,
 				"relationships": {
 					"app": {
 						"data": {
@@ -111,4 +113,42 @@ var _ = Describe("Process", func() {
 			}`))
 		})
 	})
+
+	Describe("Process Sidecar Response", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForProcessSidecar(repositories.SidecarRecord{
+				GUID:         "sidecar-guid",
+				Name:         "my-sidecar",
+				Command:      "start-sidecar",
+				ProcessTypes: []string{"web", "worker"},
+				MemoryMB:     128,
+				AppGUID:      "app-guid",
+				CreatedAt:    time.UnixMilli(1000),
+				UpdatedAt:    tools.PtrTo(time.UnixMilli(2000)),
+			}, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).Should(MatchJSON(`{
+				"guid": "sidecar-guid",
+				"name": "my-sidecar",
+				"command": "start-sidecar",
+				"process_types": ["web", "worker"],
+				"memory_in_mb": 128,
+				"origin": "user",
+				"relationships": {
+					"app": {
+						"data": {
+							"guid": "app-guid"
+						}
+					}
+				},
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z"
+			}`))
+		})
+	})
 })
@@ -0,0 +1,118 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
+)
+
+const (
+	orgQuotasBase   = "/v3/organization_quotas"
+	spaceQuotasBase = "/v3/space_quotas"
+)
+
+type AppsQuotaResponse struct {
+	TotalMemoryInMB      *int64 `json:"total_memory_in_mb"`
+	PerProcessMemoryInMB *int64 `json:"per_process_memory_in_mb"`
+	TotalInstances       *int32 `json:"total_instances"`
+	TotalApps            *int32 `json:"total_apps"`
+}
+
+type ToManyRelationship struct {
+	Data []RelationshipData `json:"data"`
+}
+
+type QuotaLinks struct {
+	Self         Link  `json:"self"`
+	Organization *Link `json:"organization,omitempty"`
+}
+
+type OrgQuotaRelationships struct {
+	Organizations ToManyRelationship `json:"organizations"`
+}
+
+type OrgQuotaResponse struct {
+	GUID          string                `json:"guid"`
+	CreatedAt     string                `json:"created_at"`
+	UpdatedAt     string                `json:"updated_at"`
+	Name          string                `json:"name"`
+	Apps          AppsQuotaResponse     `json:"apps"`
+	Relationships OrgQuotaRelationships `json:"relationships"`
+	Links         QuotaLinks            `json:"links"`
+}
+
+type SpaceQuotaRelationships struct {
+	Organization model.ToOneRelationship `json:"organization"`
+	Spaces       ToManyRelationship      `json:"spaces"`
+}
+
+type SpaceQuotaResponse struct {
+	GUID          string                  `json:"guid"`
+	CreatedAt     string                  `json:"created_at"`
+	UpdatedAt     string                  `json:"updated_at"`
+	Name          string                  `json:"name"`
+	Apps          AppsQuotaResponse       `json:"apps"`
+	Relationships SpaceQuotaRelationships `json:"relationships"`
+	Links         QuotaLinks              `json:"links"`
+}
+
+func ForOrgQuota(record repositories.OrgQuotaRecord, baseURL url.URL, includes ...model.IncludedResource) OrgQuotaResponse {
+	return OrgQuotaResponse{
+		GUID:      record.GUID,
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		Name:      record.Name,
+		Apps:      forAppsQuota(record.Apps),
+		Relationships: OrgQuotaRelationships{
+			Organizations: forToManyRelationship(record.OrgGUIDs),
+		},
+		Links: QuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(orgQuotasBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+func ForSpaceQuota(record repositories.SpaceQuotaRecord, baseURL url.URL, includes ...model.IncludedResource) SpaceQuotaResponse {
+	return SpaceQuotaResponse{
+		GUID:      record.GUID,
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		Name:      record.Name,
+		Apps:      forAppsQuota(record.Apps),
+		Relationships: SpaceQuotaRelationships{
+			Organization: model.ToOneRelationship{
+				Data: model.Relationship{GUID: record.OrgGUID},
+			},
+			Spaces: forToManyRelationship(record.SpaceGUIDs),
+		},
+		Links: QuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spaceQuotasBase, record.GUID).build(),
+			},
+			Organization: &Link{
+				HRef: buildURL(baseURL).appendPath(orgsBase, record.OrgGUID).build(),
+			},
+		},
+	}
+}
+
+func forAppsQuota(apps repositories.AppsQuotaRecord) AppsQuotaResponse {
+	return AppsQuotaResponse{
+		TotalMemoryInMB:      apps.TotalMemoryMB,
+		PerProcessMemoryInMB: apps.PerProcessMemoryMB,
+		TotalInstances:       apps.TotalInstances,
+		TotalApps:            apps.TotalApps,
+	}
+}
+
+func forToManyRelationship(guids []string) ToManyRelationship {
+	data := []RelationshipData{}
+	for _, guid := range guids {
+		data = append(data, RelationshipData{GUID: guid})
+	}
+
+	return ToManyRelationship{Data: data}
+}
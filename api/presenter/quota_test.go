@@ -0,0 +1,143 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quotas", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForOrgQuota", func() {
+		var record repositories.OrgQuotaRecord
+
+		BeforeEach(func() {
+			record = repositories.OrgQuotaRecord{
+				GUID: "org-quota-guid",
+				Name: "org-quota",
+				Apps: repositories.AppsQuotaRecord{
+					TotalMemoryMB:  tools.PtrTo[int64](2048),
+					TotalInstances: tools.PtrTo[int32](10),
+					TotalApps:      tools.PtrTo[int32](5),
+				},
+				OrgGUIDs:  []string{"org-1", "org-2"},
+				CreatedAt: time.UnixMilli(1000),
+				UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForOrgQuota(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "org-quota-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "org-quota",
+				"apps": {
+					"total_memory_in_mb": 2048,
+					"per_process_memory_in_mb": null,
+					"total_instances": 10,
+					"total_apps": 5
+				},
+				"relationships": {
+					"organizations": {
+						"data": [{"guid": "org-1"}, {"guid": "org-2"}]
+					}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/organization_quotas/org-quota-guid"
+					}
+				}
+			}`))
+		})
+
+		When("the quota is not applied to any org", func() {
+			BeforeEach(func() {
+				record.OrgGUIDs = nil
+			})
+
+			It("renders an empty relationship list", func() {
+				Expect(output).To(MatchJSONPath("$.relationships.organizations.data", BeEmpty()))
+			})
+		})
+	})
+
+	Describe("ForSpaceQuota", func() {
+		var record repositories.SpaceQuotaRecord
+
+		BeforeEach(func() {
+			record = repositories.SpaceQuotaRecord{
+				GUID: "space-quota-guid",
+				Name: "space-quota",
+				Apps: repositories.AppsQuotaRecord{
+					PerProcessMemoryMB: tools.PtrTo[int64](512),
+				},
+				OrgGUID:    "org-guid",
+				SpaceGUIDs: []string{"space-1"},
+				CreatedAt:  time.UnixMilli(1000),
+				UpdatedAt:  tools.PtrTo(time.UnixMilli(2000)),
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForSpaceQuota(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "space-quota-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "space-quota",
+				"apps": {
+					"total_memory_in_mb": null,
+					"per_process_memory_in_mb": 512,
+					"total_instances": null,
+					"total_apps": null
+				},
+				"relationships": {
+					"organization": {
+						"data": {"guid": "org-guid"}
+					},
+					"spaces": {
+						"data": [{"guid": "space-1"}]
+					}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/space_quotas/space-quota-guid"
+					},
+					"organization": {
+						"href": "https://api.example.org/v3/organizations/org-guid"
+					}
+				}
+			}`))
+		})
+	})
+})
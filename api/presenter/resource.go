@@ -25,6 +25,12 @@ func (r *Resource) PresentResource(resource relationships.Resource) any {
 		return ForServiceOffering(res, r.serverURL)
 	case repositories.ServicePlanRecord:
 		return ForServicePlan(res, r.serverURL)
+	case repositories.DomainRecord:
+		return ForDomain(res, r.serverURL)
+	case repositories.SpaceRecord:
+		return ForSpace(res, r.serverURL)
+	case repositories.OrgRecord:
+		return ForOrg(res, r.serverURL)
 	default:
 		return resource
 	}
@@ -69,4 +69,34 @@ var _ = Describe("Resource", func() {
 			Expect(presentedResource).To(BeAssignableToTypeOf(presenter.ServicePlanResponse{}))
 		})
 	})
+
+	When("the resource is a domain", func() {
+		BeforeEach(func() {
+			resource = repositories.DomainRecord{}
+		})
+
+		It("returns presented domain", func() {
+			Expect(presentedResource).To(BeAssignableToTypeOf(presenter.DomainResponse{}))
+		})
+	})
+
+	When("the resource is a space", func() {
+		BeforeEach(func() {
+			resource = repositories.SpaceRecord{}
+		})
+
+		It("returns presented space", func() {
+			Expect(presentedResource).To(BeAssignableToTypeOf(presenter.SpaceResponse{}))
+		})
+	})
+
+	When("the resource is an org", func() {
+		BeforeEach(func() {
+			resource = repositories.OrgRecord{}
+		})
+
+		It("returns presented org", func() {
+			Expect(presentedResource).To(BeAssignableToTypeOf(presenter.OrgResponse{}))
+		})
+	})
 })
@@ -0,0 +1,65 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
+)
+
+const (
+	revisionsBase = "/v3/revisions"
+)
+
+type RevisionResponse struct {
+	GUID          string                             `json:"guid"`
+	Version       int                                `json:"version"`
+	Droplet       DropletGUID                        `json:"droplet"`
+	Processes     map[string]RevisionProcess         `json:"processes"`
+	Description   string                             `json:"description"`
+	Deployable    bool                               `json:"deployable"`
+	Relationships map[string]model.ToOneRelationship `json:"relationships"`
+	CreatedAt     string                             `json:"created_at"`
+	UpdatedAt     string                             `json:"updated_at"`
+	Links         RevisionLinks                      `json:"links"`
+}
+
+type RevisionProcess struct {
+	Command *string `json:"command"`
+}
+
+type RevisionLinks struct {
+	Self Link `json:"self"`
+	App  Link `json:"app"`
+}
+
+func ForRevision(revisionRecord repositories.RevisionRecord, baseURL url.URL, includes ...model.IncludedResource) RevisionResponse {
+	processes := map[string]RevisionProcess{}
+	for _, process := range revisionRecord.Processes {
+		var command *string
+		if process.Command != "" {
+			command = &process.Command
+		}
+		processes[process.Type] = RevisionProcess{Command: command}
+	}
+
+	return RevisionResponse{
+		GUID:          revisionRecord.GUID,
+		Version:       revisionRecord.Version,
+		Droplet:       DropletGUID{Guid: revisionRecord.DropletGUID},
+		Processes:     processes,
+		Description:   revisionRecord.Description,
+		Deployable:    revisionRecord.DropletGUID != "",
+		Relationships: ForRelationships(revisionRecord.Relationships()),
+		CreatedAt:     formatTimestamp(&revisionRecord.CreatedAt),
+		UpdatedAt:     formatTimestamp(revisionRecord.UpdatedAt),
+		Links: RevisionLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, revisionRecord.GUID).build(),
+			},
+			App: Link{
+				HRef: buildURL(baseURL).appendPath(appsBase, revisionRecord.AppGUID).build(),
+			},
+		},
+	}
+}
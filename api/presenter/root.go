@@ -2,6 +2,7 @@ package presenter
 
 import (
 	"net/url"
+	"slices"
 
 	"code.cloudfoundry.org/korifi/api/config"
 )
@@ -83,12 +84,54 @@ type RootV3Response struct {
 	Links map[string]Link `json:"links"`
 }
 
-func ForRootV3(baseURL url.URL) RootV3Response {
-	return RootV3Response{
-		Links: map[string]Link{
-			"self": {
-				HRef: buildURL(baseURL).appendPath("v3").build(),
-			},
+var rootV3Resources = []string{
+	"apps",
+	"buildpacks",
+	"builds",
+	"deployments",
+	"domains",
+	"droplets",
+	"feature_flags",
+	"info",
+	"organizations",
+	"packages",
+	"processes",
+	"resource_matches",
+	"roles",
+	"routes",
+	"service_credential_bindings",
+	"service_instances",
+	"service_route_bindings",
+	"spaces",
+	"stacks",
+	"tasks",
+	"users",
+}
+
+var rootV3ManagedServicesResources = []string{
+	"service_brokers",
+	"service_offerings",
+	"service_plans",
+}
+
+func ForRootV3(baseURL url.URL, managedServicesConfig config.ManagedServices) RootV3Response {
+	resources := rootV3Resources
+	if managedServicesConfig.Enabled {
+		resources = append(slices.Clone(resources), rootV3ManagedServicesResources...)
+	}
+
+	links := map[string]Link{
+		"self": {
+			HRef: buildURL(baseURL).appendPath("v3").build(),
 		},
 	}
+	for _, resource := range resources {
+		links[resource] = Link{
+			HRef: buildURL(baseURL).appendPath("v3", resource).build(),
+		}
+	}
+
+	return RootV3Response{
+		Links: links,
+	}
 }
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/config"
 	"code.cloudfoundry.org/korifi/api/presenter"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -137,8 +138,14 @@ var _ = Describe("Root endpoints", func() {
 	})
 
 	Context("/v3", func() {
+		var managedServicesConfig config.ManagedServices
+
+		BeforeEach(func() {
+			managedServicesConfig = config.ManagedServices{}
+		})
+
 		JustBeforeEach(func() {
-			response := presenter.ForRootV3(*baseURL)
+			response := presenter.ForRootV3(*baseURL, managedServicesConfig)
 			var err error
 			output, err = json.Marshal(response)
 			Expect(err).NotTo(HaveOccurred())
@@ -147,11 +154,44 @@ var _ = Describe("Root endpoints", func() {
 		It("produces expected root v3 json", func() {
 			Expect(output).To(MatchJSON(`{
 				"links": {
-					"self": {
-						"href": "https://api.example.org/v3"
-					}
+					"self": { "href": "https://api.example.org/v3" },
+					"apps": { "href": "https://api.example.org/v3/apps" },
+					"buildpacks": { "href": "https://api.example.org/v3/buildpacks" },
+					"builds": { "href": "https://api.example.org/v3/builds" },
+					"deployments": { "href": "https://api.example.org/v3/deployments" },
+					"domains": { "href": "https://api.example.org/v3/domains" },
+					"droplets": { "href": "https://api.example.org/v3/droplets" },
+					"feature_flags": { "href": "https://api.example.org/v3/feature_flags" },
+					"info": { "href": "https://api.example.org/v3/info" },
+					"organizations": { "href": "https://api.example.org/v3/organizations" },
+					"packages": { "href": "https://api.example.org/v3/packages" },
+					"processes": { "href": "https://api.example.org/v3/processes" },
+					"resource_matches": { "href": "https://api.example.org/v3/resource_matches" },
+					"roles": { "href": "https://api.example.org/v3/roles" },
+					"routes": { "href": "https://api.example.org/v3/routes" },
+					"service_credential_bindings": { "href": "https://api.example.org/v3/service_credential_bindings" },
+					"service_instances": { "href": "https://api.example.org/v3/service_instances" },
+					"service_route_bindings": { "href": "https://api.example.org/v3/service_route_bindings" },
+					"spaces": { "href": "https://api.example.org/v3/spaces" },
+					"stacks": { "href": "https://api.example.org/v3/stacks" },
+					"tasks": { "href": "https://api.example.org/v3/tasks" },
+					"users": { "href": "https://api.example.org/v3/users" }
 				}
 			}`))
 		})
+
+		When("managed services are enabled", func() {
+			BeforeEach(func() {
+				managedServicesConfig.Enabled = true
+			})
+
+			It("links the managed services resources", func() {
+				Expect(output).To(SatisfyAll(
+					MatchJSONPath("$.links.service_brokers.href", "https://api.example.org/v3/service_brokers"),
+					MatchJSONPath("$.links.service_offerings.href", "https://api.example.org/v3/service_offerings"),
+					MatchJSONPath("$.links.service_plans.href", "https://api.example.org/v3/service_plans"),
+				))
+			})
+		})
 	})
 })
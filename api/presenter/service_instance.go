@@ -58,9 +58,10 @@ func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord
 			State:       serviceInstanceRecord.LastOperation.State,
 			Type:        serviceInstanceRecord.LastOperation.Type,
 		},
-		CreatedAt:     formatTimestamp(&serviceInstanceRecord.CreatedAt),
-		UpdatedAt:     formatTimestamp(serviceInstanceRecord.UpdatedAt),
-		Relationships: ForRelationships(serviceInstanceRecord.Relationships()),
+		SyslogDrainURL: serviceInstanceRecord.SyslogDrainURL,
+		CreatedAt:      formatTimestamp(&serviceInstanceRecord.CreatedAt),
+		UpdatedAt:      formatTimestamp(serviceInstanceRecord.UpdatedAt),
+		Relationships:  ForRelationships(serviceInstanceRecord.Relationships()),
 		Metadata: Metadata{
 			Labels:      emptyMapIfNil(serviceInstanceRecord.Labels),
 			Annotations: emptyMapIfNil(serviceInstanceRecord.Annotations),
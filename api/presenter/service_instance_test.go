@@ -109,6 +109,16 @@ var _ = Describe("Service Instance", func() {
 		}`))
 	})
 
+	When("the user-provided service instance has a syslog drain url", func() {
+		BeforeEach(func() {
+			record.SyslogDrainURL = tools.PtrTo("syslog://logs.example.org")
+		})
+
+		It("includes the syslog drain url", func() {
+			Expect(output).To(MatchJSONPath("$.syslog_drain_url", "syslog://logs.example.org"))
+		})
+	})
+
 	When("labels is nil", func() {
 		BeforeEach(func() {
 			record.Labels = nil
@@ -4,9 +4,11 @@ import (
 	"maps"
 	"net/url"
 	"path"
+	"strconv"
 	"time"
 
 	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/tools"
 	"github.com/BooleanCat/go-functional/v2/it"
 )
 
@@ -51,12 +53,12 @@ type ListResponse[T any] struct {
 }
 
 type PaginationData struct {
-	TotalResults int     `json:"total_results"`
-	TotalPages   int     `json:"total_pages"`
-	First        PageRef `json:"first"`
-	Last         PageRef `json:"last"`
-	Next         *int    `json:"next"`
-	Previous     *int    `json:"previous"`
+	TotalResults int      `json:"total_results"`
+	TotalPages   int      `json:"total_pages"`
+	First        PageRef  `json:"first"`
+	Last         PageRef  `json:"last"`
+	Next         *PageRef `json:"next"`
+	Previous     *PageRef `json:"previous"`
 }
 
 type PageRef struct {
@@ -86,6 +88,47 @@ func ForList[T, S any](itemPresenter itemPresenter[T, S], resources []T, baseURL
 	}
 }
 
+func ForPaginatedList[T, S any](itemPresenter itemPresenter[T, S], resources []T, baseURL, requestURL url.URL, page, perPage int, includes ...model.IncludedResource) ListResponse[S] {
+	totalPages := max(1, (len(resources)+perPage-1)/perPage)
+
+	pageStart := min(len(resources), (page-1)*perPage)
+	pageEnd := min(len(resources), pageStart+perPage)
+
+	presenters := []S{}
+	for _, resource := range resources[pageStart:pageEnd] {
+		presenters = append(presenters, itemPresenter(resource, baseURL))
+	}
+
+	paginationData := PaginationData{
+		TotalResults: len(resources),
+		TotalPages:   totalPages,
+		First:        pageRef(baseURL, requestURL, 1, perPage),
+		Last:         pageRef(baseURL, requestURL, totalPages, perPage),
+	}
+	if page < totalPages {
+		paginationData.Next = tools.PtrTo(pageRef(baseURL, requestURL, page+1, perPage))
+	}
+	if page > 1 && page <= totalPages {
+		paginationData.Previous = tools.PtrTo(pageRef(baseURL, requestURL, page-1, perPage))
+	}
+
+	return ListResponse[S]{
+		PaginationData: paginationData,
+		Resources:      presenters,
+		Included:       includedResources(includes...),
+	}
+}
+
+func pageRef(baseURL, requestURL url.URL, page, perPage int) PageRef {
+	query := requestURL.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(perPage))
+
+	return PageRef{
+		HREF: buildURL(baseURL).appendPath(requestURL.Path).setQuery(query.Encode()).build(),
+	}
+}
+
 func includedResources(includes ...model.IncludedResource) map[string][]any {
 	resources := map[string][]any{}
 	for _, include := range includes {
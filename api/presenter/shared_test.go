@@ -172,6 +172,148 @@ var _ = Describe("Shared", func() {
 		})
 	})
 
+	Describe("ForPaginatedList", func() {
+		var (
+			records    []record
+			baseURL    *url.URL
+			requestURL *url.URL
+			page       int
+			perPage    int
+			output     []byte
+		)
+
+		BeforeEach(func() {
+			var err error
+			baseURL, err = url.Parse("https://api.example.org")
+			Expect(err).NotTo(HaveOccurred())
+
+			requestURL, err = url.Parse("https://api.example.org/v3/records?foo=bar")
+			Expect(err).NotTo(HaveOccurred())
+
+			records = []record{{N: 42}, {N: 43}, {N: 44}, {N: 45}, {N: 46}}
+			page = 2
+			perPage = 2
+		})
+
+		JustBeforeEach(func() {
+			response := presenter.ForPaginatedList(forRecord, records, *baseURL, *requestURL, page, perPage)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the requested page", func() {
+			Expect(output).To(MatchJSON(`{
+				"pagination": {
+					"total_results": 5,
+					"total_pages": 3,
+					"first": {
+						"href": "https://api.example.org/v3/records?foo=bar&page=1&per_page=2"
+					},
+					"last": {
+						"href": "https://api.example.org/v3/records?foo=bar&page=3&per_page=2"
+					},
+					"next": {
+						"href": "https://api.example.org/v3/records?foo=bar&page=3&per_page=2"
+					},
+					"previous": {
+						"href": "https://api.example.org/v3/records?foo=bar&page=1&per_page=2"
+					}
+				},
+				"resources": [
+					{
+						"m": 44,
+						"u": "https://api.example.org"
+					},
+					{
+						"m": 45,
+						"u": "https://api.example.org"
+					}
+				]
+			}`))
+		})
+
+		When("the last page is requested", func() {
+			BeforeEach(func() {
+				page = 3
+			})
+
+			It("returns the remaining records and no next page", func() {
+				Expect(output).To(MatchJSON(`{
+					"pagination": {
+						"total_results": 5,
+						"total_pages": 3,
+						"first": {
+							"href": "https://api.example.org/v3/records?foo=bar&page=1&per_page=2"
+						},
+						"last": {
+							"href": "https://api.example.org/v3/records?foo=bar&page=3&per_page=2"
+						},
+						"next": null,
+						"previous": {
+							"href": "https://api.example.org/v3/records?foo=bar&page=2&per_page=2"
+						}
+					},
+					"resources": [
+						{
+							"m": 46,
+							"u": "https://api.example.org"
+						}
+					]
+				}`))
+			})
+		})
+
+		When("the page exceeds the number of pages", func() {
+			BeforeEach(func() {
+				page = 4
+			})
+
+			It("returns an empty resources list", func() {
+				Expect(output).To(MatchJSON(`{
+					"pagination": {
+						"total_results": 5,
+						"total_pages": 3,
+						"first": {
+							"href": "https://api.example.org/v3/records?foo=bar&page=1&per_page=2"
+						},
+						"last": {
+							"href": "https://api.example.org/v3/records?foo=bar&page=3&per_page=2"
+						},
+						"next": null,
+						"previous": null
+					},
+					"resources": []
+				}`))
+			})
+		})
+
+		When("records are empty", func() {
+			BeforeEach(func() {
+				records = nil
+				page = 1
+			})
+
+			It("returns a single empty page", func() {
+				Expect(output).To(MatchJSON(`{
+					"pagination": {
+						"total_results": 0,
+						"total_pages": 1,
+						"first": {
+							"href": "https://api.example.org/v3/records?foo=bar&page=1&per_page=2"
+						},
+						"last": {
+							"href": "https://api.example.org/v3/records?foo=bar&page=1&per_page=2"
+						},
+						"next": null,
+						"previous": null
+					},
+					"resources": []
+				}`))
+			})
+		})
+	})
+
 	Describe("ForRelationships", func() {
 		It("presents relationships", func() {
 			Expect(presenter.ForRelationships(map[string]string{
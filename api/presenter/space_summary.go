@@ -0,0 +1,39 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+)
+
+type SpaceSummaryResponse struct {
+	GUID                  string            `json:"guid"`
+	AppsCount             int               `json:"apps_count"`
+	RunningInstances      int               `json:"running_instances"`
+	ServiceInstancesCount int               `json:"service_instances_count"`
+	RoutesCount           int               `json:"routes_count"`
+	Links                 SpaceSummaryLinks `json:"links"`
+}
+
+type SpaceSummaryLinks struct {
+	Self  Link `json:"self"`
+	Space Link `json:"space"`
+}
+
+func ForSpaceSummary(summary actions.SpaceSummaryRecord, apiBaseURL url.URL) SpaceSummaryResponse {
+	return SpaceSummaryResponse{
+		GUID:                  summary.SpaceGUID,
+		AppsCount:             summary.AppsCount,
+		RunningInstances:      summary.RunningInstances,
+		ServiceInstancesCount: summary.ServiceInstancesCount,
+		RoutesCount:           summary.RoutesCount,
+		Links: SpaceSummaryLinks{
+			Self: Link{
+				HRef: buildURL(apiBaseURL).appendPath(spacesBase, summary.SpaceGUID, "summary").build(),
+			},
+			Space: Link{
+				HRef: buildURL(apiBaseURL).appendPath(spacesBase, summary.SpaceGUID).build(),
+			},
+		},
+	}
+}
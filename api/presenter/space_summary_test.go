@@ -0,0 +1,55 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/presenter"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpaceSummary", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForSpaceSummary(actions.SpaceSummaryRecord{
+			SpaceGUID:             "space-guid",
+			AppsCount:             2,
+			RunningInstances:      5,
+			ServiceInstancesCount: 1,
+			RoutesCount:           3,
+		}, *baseURL))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "space-guid",
+			"apps_count": 2,
+			"running_instances": 5,
+			"service_instances_count": 1,
+			"routes_count": 3,
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/spaces/space-guid/summary"
+				},
+				"space": {
+					"href": "https://api.example.org/v3/spaces/space-guid"
+				}
+			}
+		}`))
+	})
+})
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -65,28 +66,34 @@ func (s *appSorter) Sort(records []AppRecord, order string) []AppRecord {
 	return s.sorter.Sort(records, order)
 }
 
+// AppComparator falls back to comparing GUIDs so that apps with equal sort
+// keys are always returned in the same order
 func AppComparator(fieldName string) func(AppRecord, AppRecord) int {
 	return func(a1, a2 AppRecord) int {
-		switch fieldName {
-		case "", "name":
-			return strings.Compare(a1.Name, a2.Name)
-		case "-name":
-			return strings.Compare(a2.Name, a1.Name)
-		case "created_at":
-			return tools.CompareTimePtr(&a1.CreatedAt, &a2.CreatedAt)
-		case "-created_at":
-			return tools.CompareTimePtr(&a2.CreatedAt, &a1.CreatedAt)
-		case "updated_at":
-			return tools.CompareTimePtr(a1.UpdatedAt, a2.UpdatedAt)
-		case "-updated_at":
-			return tools.CompareTimePtr(a2.UpdatedAt, a1.UpdatedAt)
-		case "state":
-			return strings.Compare(string(a1.State), string(a2.State))
-		case "-state":
-			return strings.Compare(string(a2.State), string(a1.State))
-		}
-		return 0
+		return cmp.Or(compareApps(fieldName, a1, a2), strings.Compare(a1.GUID, a2.GUID))
+	}
+}
+
+func compareApps(fieldName string, a1, a2 AppRecord) int {
+	switch fieldName {
+	case "", "name":
+		return strings.Compare(a1.Name, a2.Name)
+	case "-name":
+		return strings.Compare(a2.Name, a1.Name)
+	case "created_at":
+		return tools.CompareTimePtr(&a1.CreatedAt, &a2.CreatedAt)
+	case "-created_at":
+		return tools.CompareTimePtr(&a2.CreatedAt, &a1.CreatedAt)
+	case "updated_at":
+		return tools.CompareTimePtr(a1.UpdatedAt, a2.UpdatedAt)
+	case "-updated_at":
+		return tools.CompareTimePtr(a2.UpdatedAt, a1.UpdatedAt)
+	case "state":
+		return strings.Compare(string(a1.State), string(a2.State))
+	case "-state":
+		return strings.Compare(string(a2.State), string(a1.State))
 	}
+	return 0
 }
 
 func NewAppRepo(
@@ -107,6 +114,7 @@ type AppRecord struct {
 	Name                  string
 	GUID                  string
 	EtcdUID               types.UID
+	ResourceVersion       string
 	Revision              string
 	SpaceGUID             string
 	DropletGUID           string
@@ -118,6 +126,7 @@ type AppRecord struct {
 	UpdatedAt             *time.Time
 	DeletedAt             *time.Time
 	IsStaged              bool
+	SSHEnabled            bool
 	envSecretName         string
 	vcapServiceSecretName string
 	vcapAppSecretName     string
@@ -223,6 +232,17 @@ type SetAppDesiredStateMessage struct {
 	DesiredState string
 }
 
+type RestartAppMessage struct {
+	AppGUID   string
+	SpaceGUID string
+}
+
+type SetAppSSHEnabledMessage struct {
+	AppGUID   string
+	SpaceGUID string
+	Enabled   bool
+}
+
 type ListAppsMessage struct {
 	Names         []string
 	Guids         []string
@@ -317,6 +337,12 @@ func (f *AppRepo) PatchApp(ctx context.Context, authInfo authorization.Info, app
 		appPatchMessage.Apply(cfApp)
 	})
 	if err != nil {
+		if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+			if validationError.Type == validation.DuplicateNameErrorType {
+				return AppRecord{}, apierrors.NewUniquenessError(err, validationError.GetMessage())
+			}
+		}
+
 		return AppRecord{}, apierrors.FromK8sError(err, AppResourceType)
 	}
 
@@ -475,6 +501,75 @@ func (f *AppRepo) SetAppDesiredState(ctx context.Context, authInfo authorization
 	return cfAppToAppRecord(*cfApp), nil
 }
 
+// RestartApp rolls the instances of a started app by bumping its app-rev.
+// Unlike stopping and starting the app, the workloads are replaced one
+// instance at a time, so the app keeps serving traffic during the restart.
+func (f *AppRepo) RestartApp(ctx context.Context, authInfo authorization.Info, message RestartAppMessage) (AppRecord, error) {
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.AppGUID}, cfApp)
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("failed to get app: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	appRev, ok := cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey]
+	if !ok {
+		appRev = korifiv1alpha1.CFAppRevisionKeyDefault
+	}
+
+	newRev, err := bumpAppRev(appRev)
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("expected app-rev to be an integer: %w", err)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfApp, func() {
+		if cfApp.Annotations == nil {
+			cfApp.Annotations = map[string]string{}
+		}
+		cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = newRev
+		cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+	})
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("failed to restart app: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return cfAppToAppRecord(*cfApp), nil
+}
+
+func (f *AppRepo) SetAppSSHEnabled(ctx context.Context, authInfo authorization.Info, message SetAppSSHEnabledMessage) (AppRecord, error) {
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.AppGUID}, cfApp)
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("failed to get app: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfApp, func() {
+		if !message.Enabled {
+			delete(cfApp.Annotations, korifiv1alpha1.CFAppSSHEnabledAnnotation)
+			return
+		}
+
+		if cfApp.Annotations == nil {
+			cfApp.Annotations = map[string]string{}
+		}
+		cfApp.Annotations[korifiv1alpha1.CFAppSSHEnabledAnnotation] = "true"
+	})
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("failed to set app ssh enabled: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return cfAppToAppRecord(*cfApp), nil
+}
+
 func (f *AppRepo) DeleteApp(ctx context.Context, authInfo authorization.Info, message DeleteAppMessage) error {
 	cfApp := &korifiv1alpha1.CFApp{
 		ObjectMeta: metav1.ObjectMeta{
@@ -650,15 +745,16 @@ func (m *PatchAppMessage) Apply(app *korifiv1alpha1.CFApp) {
 
 func cfAppToAppRecord(cfApp korifiv1alpha1.CFApp) AppRecord {
 	return AppRecord{
-		GUID:        cfApp.Name,
-		EtcdUID:     cfApp.GetUID(),
-		Revision:    getLabelOrAnnotation(cfApp.GetAnnotations(), korifiv1alpha1.CFAppRevisionKey),
-		Name:        cfApp.Spec.DisplayName,
-		SpaceGUID:   cfApp.Namespace,
-		DropletGUID: cfApp.Spec.CurrentDropletRef.Name,
-		Labels:      cfApp.Labels,
-		Annotations: cfApp.Annotations,
-		State:       DesiredState(cfApp.Spec.DesiredState),
+		GUID:            cfApp.Name,
+		EtcdUID:         cfApp.GetUID(),
+		ResourceVersion: cfApp.ResourceVersion,
+		Revision:        getLabelOrAnnotation(cfApp.GetAnnotations(), korifiv1alpha1.CFAppRevisionKey),
+		Name:            cfApp.Spec.DisplayName,
+		SpaceGUID:       cfApp.Namespace,
+		DropletGUID:     cfApp.Spec.CurrentDropletRef.Name,
+		Labels:          cfApp.Labels,
+		Annotations:     cfApp.Annotations,
+		State:           DesiredState(cfApp.Spec.DesiredState),
 		Lifecycle: Lifecycle{
 			Type: string(cfApp.Spec.Lifecycle.Type),
 			Data: LifecycleData{
@@ -670,6 +766,7 @@ func cfAppToAppRecord(cfApp korifiv1alpha1.CFApp) AppRecord {
 		UpdatedAt:             getLastUpdatedTime(&cfApp),
 		DeletedAt:             golangTime(cfApp.DeletionTimestamp),
 		IsStaged:              cfApp.Spec.CurrentDropletRef.Name != "",
+		SSHEnabled:            cfApp.Annotations[korifiv1alpha1.CFAppSSHEnabledAnnotation] == "true",
 		envSecretName:         cfApp.Spec.EnvSecretName,
 		vcapServiceSecretName: cfApp.Status.VCAPServicesSecretName,
 		vcapAppSecretName:     cfApp.Status.VCAPApplicationSecretName,
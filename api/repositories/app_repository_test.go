@@ -96,6 +96,7 @@ var _ = Describe("AppRepository", func() {
 
 				Expect(app.GUID).To(Equal(cfApp.Name))
 				Expect(app.EtcdUID).To(Equal(cfApp.GetUID()))
+				Expect(app.ResourceVersion).NotTo(BeEmpty())
 				Expect(app.Revision).To(Equal(CFAppRevisionValue))
 				Expect(app.Name).To(Equal(cfApp.Spec.DisplayName))
 				Expect(app.SpaceGUID).To(Equal(cfSpace.Name))
@@ -542,6 +543,63 @@ var _ = Describe("AppRepository", func() {
 		})
 	})
 
+	Describe("SetAppSSHEnabled", func() {
+		var (
+			appRecord repositories.AppRecord
+			setErr    error
+			enabled   bool
+		)
+
+		BeforeEach(func() {
+			enabled = true
+		})
+
+		JustBeforeEach(func() {
+			appRecord, setErr = appRepo.SetAppSSHEnabled(ctx, authInfo, repositories.SetAppSSHEnabledMessage{
+				AppGUID:   cfApp.Name,
+				SpaceGUID: cfSpace.Name,
+				Enabled:   enabled,
+			})
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("enables ssh for the app", func() {
+				Expect(setErr).NotTo(HaveOccurred())
+				Expect(appRecord.SSHEnabled).To(BeTrue())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppSSHEnabledAnnotation, "true"))
+			})
+
+			When("disabling ssh", func() {
+				BeforeEach(func() {
+					enabled = false
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Annotations = map[string]string{korifiv1alpha1.CFAppSSHEnabledAnnotation: "true"}
+					})).To(Succeed())
+				})
+
+				It("disables ssh for the app", func() {
+					Expect(setErr).NotTo(HaveOccurred())
+					Expect(appRecord.SSHEnabled).To(BeFalse())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppSSHEnabledAnnotation))
+				})
+			})
+		})
+
+		When("the user is not authorized in the space", func() {
+			It("returns a forbidden error", func() {
+				Expect(setErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
 	Describe("PatchApp", func() {
 		var (
 			patchedAppRecord repositories.AppRecord
@@ -1008,6 +1066,65 @@ var _ = Describe("AppRepository", func() {
 		})
 	})
 
+	Describe("RestartApp", func() {
+		var (
+			appRecord  repositories.AppRecord
+			restartErr error
+		)
+
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+				cfApp.Annotations = map[string]string{korifiv1alpha1.CFAppRevisionKey: "4"}
+				cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			appRecord, restartErr = appRepo.RestartApp(ctx, authInfo, repositories.RestartAppMessage{
+				AppGUID:   cfApp.Name,
+				SpaceGUID: cfSpace.Name,
+			})
+		})
+
+		When("the user has permission to restart the app", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("bumps the app revision", func() {
+				Expect(restartErr).NotTo(HaveOccurred())
+				Expect(appRecord.GUID).To(Equal(cfApp.Name))
+				Expect(appRecord.Revision).To(Equal("5"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRevisionKey, "5"))
+			})
+
+			It("keeps the app started", func() {
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.DesiredState).To(Equal(korifiv1alpha1.StartedState))
+			})
+
+			When("the app revision is not an integer", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "nope"
+					})).To(Succeed())
+				})
+
+				It("returns an error", func() {
+					Expect(restartErr).To(MatchError(ContainSubstring("expected app-rev to be an integer")))
+				})
+			})
+		})
+
+		When("the user is not authorized", func() {
+			It("returns a forbidden error", func() {
+				Expect(restartErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
 	Describe("DeleteApp", func() {
 		var (
 			appGUID      string
@@ -1425,4 +1542,10 @@ var _ = DescribeTable("AppSorter",
 		"-state",
 		BeNumerically(">", 0),
 	),
+	Entry("equal keys fall back to the guid",
+		repositories.AppRecord{Name: "app", GUID: "a-guid"},
+		repositories.AppRecord{Name: "app", GUID: "b-guid"},
+		"name",
+		BeNumerically("<", 0),
+	),
 )
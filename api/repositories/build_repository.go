@@ -3,13 +3,17 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -32,6 +36,7 @@ const (
 type BuildRecord struct {
 	GUID            string
 	SpaceGUID       string
+	ResourceVersion string
 	State           string
 	CreatedAt       time.Time
 	UpdatedAt       *time.Time
@@ -114,6 +119,39 @@ func (b *BuildRepo) GetLatestBuildByAppGUID(ctx context.Context, authInfo author
 	return b.cfBuildToBuildRecord(sortByAge(buildList.Items)[0]), nil
 }
 
+type ListBuildsMessage struct {
+	PackageGUIDs []string
+	AppGUIDs     []string
+	States       []string
+}
+
+func (m ListBuildsMessage) matches(build BuildRecord) bool {
+	return tools.EmptyOrContains(m.PackageGUIDs, build.PackageGUID) &&
+		tools.EmptyOrContains(m.AppGUIDs, build.AppGUID) &&
+		tools.EmptyOrContains(m.States, build.State)
+}
+
+// ListBuilds returns the builds visible to the user ordered by creation time,
+// oldest first
+func (b *BuildRepo) ListBuilds(ctx context.Context, authInfo authorization.Info, message ListBuildsMessage) ([]BuildRecord, error) {
+	userClient, err := b.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	buildList := &korifiv1alpha1.CFBuildList{}
+	err = userClient.List(ctx, buildList)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, BuildResourceType)
+	}
+
+	buildRecords := it.Filter(it.Map(itx.FromSlice(buildList.Items), b.cfBuildToBuildRecord), message.matches)
+
+	return slices.SortedStableFunc(buildRecords, func(b1, b2 BuildRecord) int {
+		return b1.CreatedAt.Compare(b2.CreatedAt)
+	}), nil
+}
+
 func sortByAge(builds []korifiv1alpha1.CFBuild) []korifiv1alpha1.CFBuild {
 	sort.Slice(builds, func(i, j int) bool {
 		return !builds[i].CreationTimestamp.Before(&builds[j].CreationTimestamp)
@@ -125,6 +163,7 @@ func (b *BuildRepo) cfBuildToBuildRecord(cfBuild korifiv1alpha1.CFBuild) BuildRe
 	toReturn := BuildRecord{
 		GUID:            cfBuild.Name,
 		SpaceGUID:       cfBuild.Namespace,
+		ResourceVersion: cfBuild.ResourceVersion,
 		State:           BuildStateStaging,
 		CreatedAt:       cfBuild.CreationTimestamp.Time,
 		UpdatedAt:       getLastUpdatedTime(&cfBuild),
@@ -19,6 +19,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 )
 
 var _ = Describe("BuildRepository", func() {
@@ -371,6 +372,110 @@ var _ = Describe("BuildRepository", func() {
 		})
 	})
 
+	Describe("ListBuilds", func() {
+		var (
+			space        *korifiv1alpha1.CFSpace
+			build1       *korifiv1alpha1.CFBuild
+			build2       *korifiv1alpha1.CFBuild
+			build3       *korifiv1alpha1.CFBuild
+			message      repositories.ListBuildsMessage
+			buildRecords []repositories.BuildRecord
+			listErr      error
+		)
+
+		BeforeEach(func() {
+			org := createOrgWithCleanup(ctx, prefixedGUID("list-builds-org"))
+			space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("list-builds-space"))
+			otherSpace := createSpaceWithCleanup(ctx, org.Name, prefixedGUID("list-builds-other-space"))
+
+			build1 = createBuild(ctx, k8sClient, space.Name, prefixedGUID("build1"), "package-1", "app-1")
+			Expect(k8s.Patch(ctx, k8sClient, build1, func() {
+				meta.SetStatusCondition(&build1.Status.Conditions, metav1.Condition{
+					Type:   repositories.StagingConditionType,
+					Status: metav1.ConditionFalse,
+					Reason: "BuildNotRunning",
+				})
+				meta.SetStatusCondition(&build1.Status.Conditions, metav1.Condition{
+					Type:    repositories.SucceededConditionType,
+					Status:  metav1.ConditionFalse,
+					Reason:  "BuildFailed",
+					Message: "oops",
+				})
+			})).To(Succeed())
+			time.Sleep(1001 * time.Millisecond)
+			build2 = createBuild(ctx, k8sClient, space.Name, prefixedGUID("build2"), "package-2", "app-1")
+			build3 = createBuild(ctx, k8sClient, space.Name, prefixedGUID("build3"), "package-3", "app-2")
+			createBuild(ctx, k8sClient, otherSpace.Name, prefixedGUID("build4"), "package-4", "app-3")
+
+			message = repositories.ListBuildsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			buildRecords, listErr = buildRepo.ListBuilds(ctx, authInfo, message)
+		})
+
+		It("returns an empty list as the user has no roles", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(buildRecords).To(BeEmpty())
+		})
+
+		When("the user has space developer role", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the builds in the space ordered by creation time", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(buildRecords).To(HaveLen(3))
+				Expect(buildRecords[0].GUID).To(Equal(build1.Name))
+				Expect(buildRecords[0].State).To(Equal(repositories.BuildStateFailed))
+				Expect(buildRecords[0].StagingErrorMsg).To(Equal("oops"))
+				Expect([]string{buildRecords[1].GUID, buildRecords[2].GUID}).To(ConsistOf(build2.Name, build3.Name))
+			})
+
+			When("filtering by package guids", func() {
+				BeforeEach(func() {
+					message.PackageGUIDs = []string{"package-2", "package-3"}
+				})
+
+				It("returns the builds of those packages", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(buildRecords).To(ConsistOf(
+						gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"GUID": Equal(build2.Name)}),
+						gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"GUID": Equal(build3.Name)}),
+					))
+				})
+			})
+
+			When("filtering by app guids", func() {
+				BeforeEach(func() {
+					message.AppGUIDs = []string{"app-2"}
+				})
+
+				It("returns the builds of those apps", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(buildRecords).To(ConsistOf(
+						gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"GUID": Equal(build3.Name)}),
+					))
+				})
+			})
+
+			When("filtering by states", func() {
+				BeforeEach(func() {
+					message.States = []string{repositories.BuildStateStaging}
+				})
+
+				It("returns the builds in those states", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(buildRecords).To(ConsistOf(
+						gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"GUID": Equal(build2.Name)}),
+						gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"GUID": Equal(build3.Name)}),
+					))
+				})
+			})
+		})
+	})
+
 	Describe("CreateBuild", func() {
 		const (
 			appGUID     = "the-app-guid"
@@ -11,10 +11,14 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories/compare"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 	"github.com/BooleanCat/go-functional/v2/it"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -80,6 +84,16 @@ type ListBuildpacksMessage struct {
 	OrderBy string
 }
 
+// BuildpackOrderRecord is the order in which buildpacks are detected when
+// staging apps that do not specify their buildpacks
+type BuildpackOrderRecord struct {
+	Buildpacks []string
+}
+
+type UpdateBuildpackOrderMessage struct {
+	Buildpacks []string
+}
+
 func NewBuildpackRepository(
 	builderName string,
 	userClientFactory authorization.UserClientFactory,
@@ -95,14 +109,96 @@ func NewBuildpackRepository(
 }
 
 func (r *BuildpackRepository) ListBuildpacks(ctx context.Context, authInfo authorization.Info, message ListBuildpacksMessage) ([]BuildpackRecord, error) {
-	var builderInfo korifiv1alpha1.BuilderInfo
-
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build user client: %w", err)
 	}
 
-	err = userClient.Get(
+	builderInfo, err := r.getReadyBuilderInfo(ctx, userClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sorter.Sort(builderInfoToBuildpackRecords(builderInfo), message.OrderBy), nil
+}
+
+// GetBuildpackOrder returns the default buildpack order. When no order has
+// been configured the order of the builder is used.
+func (r *BuildpackRepository) GetBuildpackOrder(ctx context.Context, authInfo authorization.Info) (BuildpackOrderRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return BuildpackOrderRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	orderConfigMap := &corev1.ConfigMap{}
+	err = userClient.Get(ctx, types.NamespacedName{Namespace: r.rootNamespace, Name: korifiv1alpha1.DefaultBuildpackOrderConfigMapName}, orderConfigMap)
+	if err == nil {
+		return BuildpackOrderRecord{Buildpacks: tools.FromBuildpackOrderData(orderConfigMap.Data[korifiv1alpha1.DefaultBuildpackOrderKey])}, nil
+	}
+	if !errors.IsNotFound(err) {
+		return BuildpackOrderRecord{}, apierrors.FromK8sError(err, BuildpackResourceType)
+	}
+
+	builderInfo, err := r.getReadyBuilderInfo(ctx, userClient)
+	if err != nil {
+		return BuildpackOrderRecord{}, err
+	}
+
+	return BuildpackOrderRecord{
+		Buildpacks: slices.Collect(it.Map(slices.Values(builderInfo.Status.Buildpacks), func(b korifiv1alpha1.BuilderInfoStatusBuildpack) string {
+			return b.Name
+		})),
+	}, nil
+}
+
+func (r *BuildpackRepository) UpdateBuildpackOrder(ctx context.Context, authInfo authorization.Info, message UpdateBuildpackOrderMessage) (BuildpackOrderRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return BuildpackOrderRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	builderInfo, err := r.getReadyBuilderInfo(ctx, userClient)
+	if err != nil {
+		return BuildpackOrderRecord{}, err
+	}
+
+	for _, buildpack := range message.Buildpacks {
+		if !slices.ContainsFunc(builderInfo.Status.Buildpacks, func(b korifiv1alpha1.BuilderInfoStatusBuildpack) bool {
+			return b.Name == buildpack
+		}) {
+			return BuildpackOrderRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Buildpack %q is not available in the builder", buildpack))
+		}
+	}
+
+	orderConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      korifiv1alpha1.DefaultBuildpackOrderConfigMapName,
+		},
+	}
+	order := tools.ToBuildpackOrderData(message.Buildpacks)
+
+	err = userClient.Get(ctx, client.ObjectKeyFromObject(orderConfigMap), orderConfigMap)
+	switch {
+	case errors.IsNotFound(err):
+		orderConfigMap.Data = map[string]string{korifiv1alpha1.DefaultBuildpackOrderKey: order}
+		err = userClient.Create(ctx, orderConfigMap)
+	case err == nil:
+		err = k8s.PatchResource(ctx, userClient, orderConfigMap, func() {
+			orderConfigMap.Data = map[string]string{korifiv1alpha1.DefaultBuildpackOrderKey: order}
+		})
+	}
+	if err != nil {
+		return BuildpackOrderRecord{}, apierrors.FromK8sError(err, BuildpackResourceType)
+	}
+
+	return BuildpackOrderRecord{Buildpacks: message.Buildpacks}, nil
+}
+
+func (r *BuildpackRepository) getReadyBuilderInfo(ctx context.Context, userClient client.Client) (korifiv1alpha1.BuilderInfo, error) {
+	var builderInfo korifiv1alpha1.BuilderInfo
+
+	err := userClient.Get(
 		ctx,
 		types.NamespacedName{
 			Namespace: r.rootNamespace,
@@ -112,10 +208,10 @@ func (r *BuildpackRepository) ListBuildpacks(ctx context.Context, authInfo autho
 	)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			return nil, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not found in namespace %q", r.builderName, r.rootNamespace))
+			return korifiv1alpha1.BuilderInfo{}, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not found in namespace %q", r.builderName, r.rootNamespace))
 		}
 
-		return nil, apierrors.FromK8sError(err, BuildpackResourceType)
+		return korifiv1alpha1.BuilderInfo{}, apierrors.FromK8sError(err, BuildpackResourceType)
 	}
 
 	if !meta.IsStatusConditionTrue(builderInfo.Status.Conditions, korifiv1alpha1.StatusConditionReady) {
@@ -130,10 +226,10 @@ func (r *BuildpackRepository) ListBuildpacks(ctx context.Context, authInfo autho
 			conditionNotReadyMessage = "resource not reconciled"
 		}
 
-		return nil, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not ready: %s", r.builderName, conditionNotReadyMessage))
+		return korifiv1alpha1.BuilderInfo{}, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not ready: %s", r.builderName, conditionNotReadyMessage))
 	}
 
-	return r.sorter.Sort(builderInfoToBuildpackRecords(builderInfo), message.OrderBy), nil
+	return builderInfo, nil
 }
 
 func builderInfoToBuildpackRecords(info korifiv1alpha1.BuilderInfo) []BuildpackRecord {
@@ -7,6 +7,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/meta"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -16,7 +17,10 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	gomega_types "github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("BuildpackRepository", func() {
@@ -166,8 +170,149 @@ var _ = Describe("BuildpackRepository", func() {
 			})
 		})
 	})
+
+	Describe("GetBuildpackOrder", func() {
+		var (
+			order  BuildpackOrderRecord
+			getErr error
+		)
+
+		BeforeEach(func() {
+			createBuilderInfoWithCleanup(ctx, builderName, "io.buildpacks.stacks.bionic", []buildpackInfo{
+				{name: "paketo-buildpacks/buildpack-1-1", version: "1.1"},
+				{name: "paketo-buildpacks/buildpack-2-1", version: "2.1"},
+			})
+		})
+
+		JustBeforeEach(func() {
+			order, getErr = buildpackRepo.GetBuildpackOrder(ctx, authInfo)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the builder order", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(order.Buildpacks).To(Equal([]string{"paketo-buildpacks/buildpack-1-1", "paketo-buildpacks/buildpack-2-1"}))
+			})
+
+			When("the default buildpack order has been configured", func() {
+				BeforeEach(func() {
+					createBuildpackOrderConfigMapWithCleanup(ctx, "paketo-buildpacks/buildpack-2-1\npaketo-buildpacks/buildpack-1-1")
+				})
+
+				It("returns the configured order", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(order.Buildpacks).To(Equal([]string{"paketo-buildpacks/buildpack-2-1", "paketo-buildpacks/buildpack-1-1"}))
+				})
+			})
+		})
+	})
+
+	Describe("UpdateBuildpackOrder", func() {
+		var (
+			message   UpdateBuildpackOrderMessage
+			order     BuildpackOrderRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			createBuilderInfoWithCleanup(ctx, builderName, "io.buildpacks.stacks.bionic", []buildpackInfo{
+				{name: "paketo-buildpacks/buildpack-1-1", version: "1.1"},
+				{name: "paketo-buildpacks/buildpack-2-1", version: "2.1"},
+			})
+
+			message = UpdateBuildpackOrderMessage{
+				Buildpacks: []string{"paketo-buildpacks/buildpack-2-1", "paketo-buildpacks/buildpack-1-1"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			order, updateErr = buildpackRepo.UpdateBuildpackOrder(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(updateErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				DeferCleanup(func() {
+					Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      korifiv1alpha1.DefaultBuildpackOrderConfigMapName,
+						},
+					}))).To(Succeed())
+				})
+			})
+
+			It("stores the order in the default buildpack order config map", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(order.Buildpacks).To(Equal([]string{"paketo-buildpacks/buildpack-2-1", "paketo-buildpacks/buildpack-1-1"}))
+
+				orderConfigMap := &corev1.ConfigMap{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: rootNamespace, Name: korifiv1alpha1.DefaultBuildpackOrderConfigMapName}, orderConfigMap)).To(Succeed())
+				Expect(orderConfigMap.Data).To(Equal(map[string]string{
+					korifiv1alpha1.DefaultBuildpackOrderKey: "paketo-buildpacks/buildpack-2-1\npaketo-buildpacks/buildpack-1-1",
+				}))
+			})
+
+			When("the default buildpack order has already been configured", func() {
+				BeforeEach(func() {
+					createBuildpackOrderConfigMapWithCleanup(ctx, "paketo-buildpacks/buildpack-1-1")
+				})
+
+				It("updates the order", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+
+					orderConfigMap := &corev1.ConfigMap{}
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: rootNamespace, Name: korifiv1alpha1.DefaultBuildpackOrderConfigMapName}, orderConfigMap)).To(Succeed())
+					Expect(orderConfigMap.Data).To(HaveKeyWithValue(
+						korifiv1alpha1.DefaultBuildpackOrderKey, "paketo-buildpacks/buildpack-2-1\npaketo-buildpacks/buildpack-1-1",
+					))
+				})
+			})
+
+			When("a buildpack is not available in the builder", func() {
+				BeforeEach(func() {
+					message.Buildpacks = append(message.Buildpacks, "paketo-buildpacks/unknown")
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(updateErr).To(SatisfyAll(
+						BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}),
+						MatchError(ContainSubstring(`Buildpack "paketo-buildpacks/unknown" is not available in the builder`)),
+					))
+				})
+			})
+		})
+	})
 })
 
+func createBuildpackOrderConfigMapWithCleanup(ctx context.Context, order string) {
+	orderConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+			Name:      korifiv1alpha1.DefaultBuildpackOrderConfigMapName,
+		},
+		Data: map[string]string{
+			korifiv1alpha1.DefaultBuildpackOrderKey: order,
+		},
+	}
+	Expect(k8sClient.Create(ctx, orderConfigMap)).To(Succeed())
+	DeferCleanup(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, orderConfigMap))).To(Succeed())
+	})
+}
+
 type buildpackInfo struct {
 	name    string
 	version string
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strconv"
@@ -18,11 +19,18 @@ import (
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"github.com/go-logr/logr"
 
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const DeploymentResourceType = "Deployment"
+const (
+	DeploymentResourceType = "Deployment"
+
+	invalidRevisionMsg        = "Unable to use revision. Ensure that the revision exists and you have access to it."
+	revisionDropletDeletedMsg = "Unable to deploy this revision, the droplet for this revision no longer exists."
+)
 
 type DeploymentRepo struct {
 	userClientFactory  authorization.UserClientFactory
@@ -32,6 +40,7 @@ type DeploymentRepo struct {
 
 type DeploymentRecord struct {
 	GUID        string
+	SpaceGUID   string
 	CreatedAt   time.Time
 	UpdatedAt   *time.Time
 	DropletGUID string
@@ -87,6 +96,8 @@ type DeploymentStatusReason string
 const (
 	DeploymentStatusReasonDeploying DeploymentStatusReason = "DEPLOYING"
 	DeploymentStatusReasonDeployed  DeploymentStatusReason = "DEPLOYED"
+	DeploymentStatusReasonRollback  DeploymentStatusReason = "ROLLBACK"
+	DeploymentStatusReasonCanceled  DeploymentStatusReason = "CANCELED"
 )
 
 type DeploymentStatus struct {
@@ -95,8 +106,10 @@ type DeploymentStatus struct {
 }
 
 type CreateDeploymentMessage struct {
-	AppGUID     string
-	DropletGUID string
+	AppGUID          string
+	DropletGUID      string
+	RevisionGUID     string
+	ReadinessTimeout *time.Duration
 }
 
 type ListDeploymentsMessage struct {
@@ -166,11 +179,20 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 		return DeploymentRecord{}, err
 	}
 
-	dropletGUID := app.Spec.CurrentDropletRef.Name
+	previousDropletGUID := app.Spec.CurrentDropletRef.Name
+	dropletGUID := previousDropletGUID
 	if message.DropletGUID != "" {
 		dropletGUID = message.DropletGUID
 	}
 
+	var revisionEnv map[string][]byte
+	if message.RevisionGUID != "" {
+		dropletGUID, revisionEnv, err = getRevisionToRestore(ctx, userClient, app, message.RevisionGUID)
+		if err != nil {
+			return DeploymentRecord{}, err
+		}
+	}
+
 	appRev := app.Annotations[korifiv1alpha1.CFAppRevisionKey]
 	newRev, err := bumpAppRev(appRev)
 	if err != nil {
@@ -184,6 +206,131 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 		}
 		app.Annotations[korifiv1alpha1.CFAppRevisionKey] = newRev
 		app.Spec.DesiredState = korifiv1alpha1.StartedState
+
+		delete(app.Annotations, korifiv1alpha1.CFAppRolledBackDropletAnnotation)
+		delete(app.Annotations, korifiv1alpha1.CFAppCanceledDropletAnnotation)
+		delete(app.Annotations, korifiv1alpha1.CFAppPreviousDropletAnnotation)
+		delete(app.Annotations, korifiv1alpha1.CFAppReadinessDeadlineAnnotation)
+		if previousDropletGUID != "" && previousDropletGUID != dropletGUID {
+			app.Annotations[korifiv1alpha1.CFAppPreviousDropletAnnotation] = previousDropletGUID
+			if message.ReadinessTimeout != nil {
+				app.Annotations[korifiv1alpha1.CFAppReadinessDeadlineAnnotation] = time.Now().Add(*message.ReadinessTimeout).UTC().Format(time.RFC3339)
+			}
+		}
+	})
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	// The env is only restored once the app has been patched, so that a
+	// failed deployment does not leave the app with the env of the revision
+	if message.RevisionGUID != "" {
+		if err = restoreAppEnv(ctx, userClient, app, revisionEnv); err != nil {
+			return DeploymentRecord{}, err
+		}
+	}
+
+	return appToDeploymentRecord(*app), nil
+}
+
+// getRevisionToRestore returns the droplet of the revision, which is expected
+// to still exist, and the environment variables captured in it
+func getRevisionToRestore(ctx context.Context, userClient client.Client, app *korifiv1alpha1.CFApp, revisionGUID string) (string, map[string][]byte, error) {
+	revision := &korifiv1alpha1.CFRevision{}
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: revisionGUID}, revision)
+	if err != nil {
+		return "", nil, apierrors.AsUnprocessableEntity(
+			apierrors.FromK8sError(err, RevisionResourceType),
+			invalidRevisionMsg,
+			apierrors.ForbiddenError{},
+			apierrors.NotFoundError{},
+		)
+	}
+
+	if revision.Spec.AppRef.Name != app.Name {
+		return "", nil, apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("revision %s does not belong to app %s", revisionGUID, app.Name),
+			invalidRevisionMsg,
+		)
+	}
+
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: revision.Spec.DropletRef.Name}, &korifiv1alpha1.CFBuild{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", nil, apierrors.NewUnprocessableEntityError(err, revisionDropletDeletedMsg)
+		}
+		return "", nil, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	revisionEnv := map[string][]byte{}
+	if revision.Spec.EnvSecretName != "" {
+		revisionEnvSecret := &corev1.Secret{}
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: revision.Spec.EnvSecretName}, revisionEnvSecret)
+		if err != nil {
+			return "", nil, apierrors.FromK8sError(err, RevisionResourceType)
+		}
+		revisionEnv = revisionEnvSecret.Data
+	}
+
+	return revision.Spec.DropletRef.Name, revisionEnv, nil
+}
+
+func restoreAppEnv(ctx context.Context, userClient client.Client, app *korifiv1alpha1.CFApp, env map[string][]byte) error {
+	appEnvSecret := &corev1.Secret{}
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: app.Spec.EnvSecretName}, appEnvSecret)
+	if err != nil {
+		return apierrors.FromK8sError(err, AppEnvResourceType)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, appEnvSecret, func() {
+		appEnvSecret.Data = env
+	})
+	if err != nil {
+		return apierrors.FromK8sError(err, AppEnvResourceType)
+	}
+
+	return nil
+}
+
+// CancelDeployment stops an active deployment by putting the droplet the app
+// was running before it back in place. Deployments only ever replace the
+// instances of the app workloads, so the instance counts the app had before
+// the deployment are kept. Deployments that did not change the droplet (e.g.
+// restarts) cannot be reverted and therefore cannot be canceled.
+func (r *DeploymentRepo) CancelDeployment(ctx context.Context, authInfo authorization.Info, deploymentGUID string) (DeploymentRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, deploymentGUID, AppResourceType)
+	if err != nil {
+		return DeploymentRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return DeploymentRecord{}, fmt.Errorf("cancel-deployment failed to create user client: %w", err)
+	}
+
+	app := &korifiv1alpha1.CFApp{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: deploymentGUID}, app)
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	deployment := appToDeploymentRecord(*app)
+	if deployment.Status.Value != DeploymentStatusValueActive {
+		msg := fmt.Sprintf("Cannot cancel a deployment with status: %s and reason: %s", deployment.Status.Value, deployment.Status.Reason)
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(errors.New(msg), msg)
+	}
+
+	previousDropletGUID := app.Annotations[korifiv1alpha1.CFAppPreviousDropletAnnotation]
+	if previousDropletGUID == "" {
+		msg := "Cannot cancel a deployment that has no previous droplet to revert to"
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(errors.New(msg), msg)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, app, func() {
+		app.Annotations[korifiv1alpha1.CFAppCanceledDropletAnnotation] = app.Spec.CurrentDropletRef.Name
+		app.Spec.CurrentDropletRef.Name = previousDropletGUID
+		delete(app.Annotations, korifiv1alpha1.CFAppPreviousDropletAnnotation)
+		delete(app.Annotations, korifiv1alpha1.CFAppReadinessDeadlineAnnotation)
 	})
 	if err != nil {
 		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
@@ -222,6 +369,7 @@ func bumpAppRev(appRev string) (string, error) {
 func appToDeploymentRecord(cfApp korifiv1alpha1.CFApp) DeploymentRecord {
 	deploymentRecord := DeploymentRecord{
 		GUID:        cfApp.Name,
+		SpaceGUID:   cfApp.Namespace,
 		CreatedAt:   cfApp.CreationTimestamp.Time,
 		UpdatedAt:   getLastUpdatedTime(&cfApp),
 		DropletGUID: cfApp.Spec.CurrentDropletRef.Name,
@@ -238,6 +386,20 @@ func appToDeploymentRecord(cfApp korifiv1alpha1.CFApp) DeploymentRecord {
 		}
 	}
 
+	if cfApp.Annotations[korifiv1alpha1.CFAppRolledBackDropletAnnotation] != "" {
+		deploymentRecord.Status = DeploymentStatus{
+			Value:  DeploymentStatusValueFinalized,
+			Reason: DeploymentStatusReasonRollback,
+		}
+	}
+
+	if cfApp.Annotations[korifiv1alpha1.CFAppCanceledDropletAnnotation] != "" {
+		deploymentRecord.Status = DeploymentStatus{
+			Value:  DeploymentStatusValueFinalized,
+			Reason: DeploymentStatusReasonCanceled,
+		}
+	}
+
 	return deploymentRecord
 }
 
@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"code.cloudfoundry.org/korifi/version"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -122,6 +123,37 @@ var _ = Describe("DeploymentRepository", func() {
 				})
 			})
 
+			When("the deployment has been rolled back", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppRolledBackDropletAnnotation] = "failed-droplet"
+					})).To(Succeed())
+				})
+
+				It("returns a finalized rollback deployment", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+
+					Expect(deployment.DropletGUID).To(Equal(cfApp.Spec.CurrentDropletRef.Name))
+					Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueFinalized))
+					Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonRollback))
+				})
+			})
+
+			When("the deployment has been canceled", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppCanceledDropletAnnotation] = "canceled-droplet"
+					})).To(Succeed())
+				})
+
+				It("returns a finalized canceled deployment", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+
+					Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueFinalized))
+					Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonCanceled))
+				})
+			})
+
 			When("the app does not exist", func() {
 				BeforeEach(func() {
 					cfAppGUID = "i-do-not-exist"
@@ -227,6 +259,176 @@ var _ = Describe("DeploymentRepository", func() {
 					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
 					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(newDropletGUID))
 				})
+
+				It("records the previous droplet without a readiness deadline", func() {
+					previousDropletGUID := cfApp.Spec.CurrentDropletRef.Name
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppPreviousDropletAnnotation, previousDropletGUID))
+					Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppReadinessDeadlineAnnotation))
+				})
+			})
+
+			When("a revision guid is set on the create message", func() {
+				var (
+					revisionDropletGUID string
+					revision            *korifiv1alpha1.CFRevision
+				)
+
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      cfApp.Spec.EnvSecretName,
+						},
+						Data: map[string][]byte{
+							"FOO": []byte("changed"),
+							"BAR": []byte("added-later"),
+						},
+					})).To(Succeed())
+
+					revisionDropletGUID = uuid.NewString()
+					createDropletCR(ctx, k8sClient, revisionDropletGUID, cfApp.Name, cfSpace.Name)
+
+					revision = &korifiv1alpha1.CFRevision{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFRevisionSpec{
+							AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+							Version:       1,
+							DropletRef:    corev1.LocalObjectReference{Name: revisionDropletGUID},
+							EnvSecretName: "revision-env",
+						},
+					}
+					Expect(k8sClient.Create(ctx, revision)).To(Succeed())
+
+					Expect(k8sClient.Create(ctx, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      "revision-env",
+						},
+						Data: map[string][]byte{
+							"FOO": []byte("original"),
+						},
+					})).To(Succeed())
+
+					createDeploymentMessage.RevisionGUID = revision.Name
+				})
+
+				It("deploys the revision droplet", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(deployment.DropletGUID).To(Equal(revisionDropletGUID))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(revisionDropletGUID))
+				})
+
+				It("restores the env vars captured in the revision", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					appEnvSecret := &corev1.Secret{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfSpace.Name, Name: cfApp.Spec.EnvSecretName}, appEnvSecret)).To(Succeed())
+					Expect(appEnvSecret.Data).To(Equal(map[string][]byte{
+						"FOO": []byte("original"),
+					}))
+				})
+
+				When("the revision droplet has been deleted", func() {
+					BeforeEach(func() {
+						Expect(k8sClient.Delete(ctx, &korifiv1alpha1.CFBuild{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: cfSpace.Name,
+								Name:      revisionDropletGUID,
+							},
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(createErr.(apierrors.UnprocessableEntityError).Detail()).To(Equal(
+							"Unable to deploy this revision, the droplet for this revision no longer exists.",
+						))
+					})
+
+					It("does not change the app env", func() {
+						appEnvSecret := &corev1.Secret{}
+						Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfSpace.Name, Name: cfApp.Spec.EnvSecretName}, appEnvSecret)).To(Succeed())
+						Expect(appEnvSecret.Data).To(HaveKeyWithValue("FOO", []byte("changed")))
+					})
+				})
+
+				When("the revision belongs to another app", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, revision, func() {
+							revision.Spec.AppRef.Name = "another-app"
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+			})
+
+			It("does not set a readiness deadline on the app", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppReadinessDeadlineAnnotation))
+				Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppPreviousDropletAnnotation))
+			})
+
+			When("a readiness timeout is set on the create message", func() {
+				var previousDropletGUID string
+
+				BeforeEach(func() {
+					previousDropletGUID = cfApp.Spec.CurrentDropletRef.Name
+					createDeploymentMessage.DropletGUID = uuid.NewString()
+					createDeploymentMessage.ReadinessTimeout = tools.PtrTo(time.Minute)
+				})
+
+				It("records the previous droplet and the readiness deadline on the app", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppPreviousDropletAnnotation, previousDropletGUID))
+
+					deadline, err := time.Parse(time.RFC3339, cfApp.Annotations[korifiv1alpha1.CFAppReadinessDeadlineAnnotation])
+					Expect(err).NotTo(HaveOccurred())
+					Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Minute), timeCheckThreshold))
+				})
+
+				When("the app does not change its droplet", func() {
+					BeforeEach(func() {
+						createDeploymentMessage.DropletGUID = ""
+					})
+
+					It("does not set a readiness deadline on the app", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppReadinessDeadlineAnnotation))
+					})
+				})
+			})
+
+			When("the previous deployment was rolled back", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppRolledBackDropletAnnotation] = "failed-droplet"
+					})).To(Succeed())
+				})
+
+				It("clears the rollback", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonDeploying))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppRolledBackDropletAnnotation))
+				})
 			})
 
 			When("the app does not exist", func() {
@@ -259,6 +461,112 @@ var _ = Describe("DeploymentRepository", func() {
 		})
 	})
 
+	Describe("CancelDeployment", func() {
+		var (
+			deployment          repositories.DeploymentRecord
+			cancelErr           error
+			previousDropletGUID string
+			deployedDropletGUID string
+		)
+
+		BeforeEach(func() {
+			previousDropletGUID = cfApp.Spec.CurrentDropletRef.Name
+			deployedDropletGUID = uuid.NewString()
+
+			Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+				cfApp.Spec.CurrentDropletRef.Name = deployedDropletGUID
+				cfApp.Annotations[korifiv1alpha1.CFAppPreviousDropletAnnotation] = previousDropletGUID
+				cfApp.Annotations[korifiv1alpha1.CFAppReadinessDeadlineAnnotation] = time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			deployment, cancelErr = deploymentRepo.CancelDeployment(ctx, authInfo, cfApp.Name)
+		})
+
+		It("returns a forbidden error (as the user is not allowed to get apps)", func() {
+			Expect(cancelErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns a canceled deployment", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+
+				Expect(deployment.GUID).To(Equal(cfApp.Name))
+				Expect(deployment.DropletGUID).To(Equal(previousDropletGUID))
+				Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueFinalized))
+				Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonCanceled))
+			})
+
+			It("puts the previous droplet back", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(previousDropletGUID))
+				Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppCanceledDropletAnnotation, deployedDropletGUID))
+				Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppPreviousDropletAnnotation))
+				Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppReadinessDeadlineAnnotation))
+			})
+
+			When("the deployment did not change the droplet", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						delete(cfApp.Annotations, korifiv1alpha1.CFAppPreviousDropletAnnotation)
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(cancelErr).To(MatchError(ContainSubstring("Cannot cancel a deployment that has no previous droplet to revert to")))
+					Expect(cancelErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+
+				It("does not cancel the deployment", func() {
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(deployedDropletGUID))
+					Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppCanceledDropletAnnotation))
+					Expect(cfApp.Annotations).To(HaveKey(korifiv1alpha1.CFAppReadinessDeadlineAnnotation))
+				})
+			})
+
+			When("the deployment is finalized", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						meta.SetStatusCondition(&cfApp.Status.Conditions, metav1.Condition{
+							Type:   korifiv1alpha1.StatusConditionReady,
+							Status: metav1.ConditionTrue,
+							Reason: "ready",
+						})
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(cancelErr).To(MatchError(ContainSubstring("Cannot cancel a deployment with status: FINALIZED and reason: DEPLOYED")))
+					Expect(cancelErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+
+				It("does not change the droplet", func() {
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(deployedDropletGUID))
+				})
+			})
+
+			When("the app does not exist", func() {
+				JustBeforeEach(func() {
+					_, cancelErr = deploymentRepo.CancelDeployment(ctx, authInfo, "i-do-not-exist")
+				})
+
+				It("returns a not found error", func() {
+					Expect(cancelErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
 	Describe("ListDeployments", func() {
 		var (
 			message     repositories.ListDeploymentsMessage
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"maps"
 	"slices"
 	"sort"
 	"time"
@@ -26,26 +27,32 @@ const (
 )
 
 type DomainRepo struct {
-	userClientFactory  authorization.UserClientFactory
-	namespaceRetriever NamespaceRetriever
-	rootNamespace      string
+	userClientFactory    authorization.UserClientFactory
+	namespaceRetriever   NamespaceRetriever
+	namespacePermissions *authorization.NamespacePermissions
+	rootNamespace        string
 }
 
 func NewDomainRepo(
 	userClientFactory authorization.UserClientFactory,
 	namespaceRetriever NamespaceRetriever,
+	namespacePermissions *authorization.NamespacePermissions,
 	rootNamespace string,
 ) *DomainRepo {
 	return &DomainRepo{
-		userClientFactory:  userClientFactory,
-		namespaceRetriever: namespaceRetriever,
-		rootNamespace:      rootNamespace,
+		userClientFactory:    userClientFactory,
+		namespaceRetriever:   namespaceRetriever,
+		namespacePermissions: namespacePermissions,
+		rootNamespace:        rootNamespace,
 	}
 }
 
 type DomainRecord struct {
-	Name        string
-	GUID        string
+	Name string
+	GUID string
+	// OrgGUID is the GUID of the owning organization of a private domain.
+	// It is empty for shared domains.
+	OrgGUID     string
 	Labels      map[string]string
 	Annotations map[string]string
 	Namespace   string
@@ -58,8 +65,15 @@ func (r DomainRecord) GetResourceType() string {
 	return DomainResourceType
 }
 
+func (r DomainRecord) Relationships() map[string]string {
+	return map[string]string{
+		"organization": r.OrgGUID,
+	}
+}
+
 type CreateDomainMessage struct {
 	Name     string
+	OrgGUID  string
 	Metadata Metadata
 }
 
@@ -69,11 +83,17 @@ type UpdateDomainMessage struct {
 }
 
 type ListDomainsMessage struct {
+	GUIDs []string
 	Names []string
+	// OrgGUID restricts private domains to the ones owned by the given
+	// organization. Shared domains are always listed.
+	OrgGUID string
 }
 
-func (m *ListDomainsMessage) matches(d korifiv1alpha1.CFDomain) bool {
-	return tools.EmptyOrContains(m.Names, d.Spec.Name)
+func (m *ListDomainsMessage) matches(d DomainRecord) bool {
+	return tools.EmptyOrContains(m.GUIDs, d.GUID) &&
+		tools.EmptyOrContains(m.Names, d.Name) &&
+		(m.OrgGUID == "" || d.OrgGUID == "" || d.OrgGUID == m.OrgGUID)
 }
 
 func (r *DomainRepo) GetDomain(ctx context.Context, authInfo authorization.Info, domainGUID string) (DomainRecord, error) {
@@ -93,7 +113,7 @@ func (r *DomainRepo) GetDomain(ctx context.Context, authInfo authorization.Info,
 		return DomainRecord{}, fmt.Errorf("get-domain failed: %w", apierrors.FromK8sError(err, DomainResourceType))
 	}
 
-	return cfDomainToDomainRecord(*domain), nil
+	return r.cfDomainToDomainRecord(*domain), nil
 }
 
 func (r *DomainRepo) CreateDomain(ctx context.Context, authInfo authorization.Info, message CreateDomainMessage) (DomainRecord, error) {
@@ -102,10 +122,15 @@ func (r *DomainRepo) CreateDomain(ctx context.Context, authInfo authorization.In
 		return DomainRecord{}, fmt.Errorf("create-domain failed to create user client: %w", err)
 	}
 
+	namespace := r.rootNamespace
+	if message.OrgGUID != "" {
+		namespace = message.OrgGUID
+	}
+
 	cfDomain := &korifiv1alpha1.CFDomain{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        uuid.NewString(),
-			Namespace:   r.rootNamespace,
+			Namespace:   namespace,
 			Labels:      message.Metadata.Labels,
 			Annotations: message.Metadata.Annotations,
 		},
@@ -119,10 +144,15 @@ func (r *DomainRepo) CreateDomain(ctx context.Context, authInfo authorization.In
 		return DomainRecord{}, fmt.Errorf("create-domain failed: %w", apierrors.FromK8sError(err, DomainResourceType))
 	}
 
-	return cfDomainToDomainRecord(*cfDomain), nil
+	return r.cfDomainToDomainRecord(*cfDomain), nil
 }
 
 func (r *DomainRepo) UpdateDomain(ctx context.Context, authInfo authorization.Info, message UpdateDomainMessage) (DomainRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, message.GUID, DomainResourceType)
+	if err != nil {
+		return DomainRecord{}, err
+	}
+
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return DomainRecord{}, fmt.Errorf("create-domain failed to create user client: %w", err)
@@ -131,7 +161,7 @@ func (r *DomainRepo) UpdateDomain(ctx context.Context, authInfo authorization.In
 	domain := &korifiv1alpha1.CFDomain{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      message.GUID,
-			Namespace: r.rootNamespace,
+			Namespace: ns,
 		},
 	}
 
@@ -147,7 +177,7 @@ func (r *DomainRepo) UpdateDomain(ctx context.Context, authInfo authorization.In
 		return DomainRecord{}, fmt.Errorf("failed to patch domain metadata: %w", apierrors.FromK8sError(err, DomainResourceType))
 	}
 
-	return cfDomainToDomainRecord(*domain), nil
+	return r.cfDomainToDomainRecord(*domain), nil
 }
 
 func (r *DomainRepo) ListDomains(ctx context.Context, authInfo authorization.Info, message ListDomainsMessage) ([]DomainRecord, error) {
@@ -156,18 +186,30 @@ func (r *DomainRepo) ListDomains(ctx context.Context, authInfo authorization.Inf
 		return []DomainRecord{}, fmt.Errorf("list-domain failed to create user client: %w", err)
 	}
 
-	cfdomainList := &korifiv1alpha1.CFDomainList{}
-	err = userClient.List(ctx, cfdomainList, client.InNamespace(r.rootNamespace))
+	orgNamespaces, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
 	if err != nil {
+		return []DomainRecord{}, fmt.Errorf("failed to list authorized org namespaces: %w", err)
+	}
+
+	namespaces := append([]string{r.rootNamespace}, slices.Sorted(maps.Keys(orgNamespaces))...)
+
+	cfDomains := []korifiv1alpha1.CFDomain{}
+	for _, ns := range namespaces {
+		cfdomainList := &korifiv1alpha1.CFDomainList{}
+		err = userClient.List(ctx, cfdomainList, client.InNamespace(ns))
 		if k8serrors.IsForbidden(err) {
-			return []DomainRecord{}, nil
+			continue
+		}
+		if err != nil {
+			return []DomainRecord{}, fmt.Errorf("failed to list domains in namespace %s: %w", ns, apierrors.FromK8sError(err, DomainResourceType))
 		}
-		return []DomainRecord{}, fmt.Errorf("failed to list domains in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, DomainResourceType))
+
+		cfDomains = append(cfDomains, cfdomainList.Items...)
 	}
 
-	domainRecords := slices.Collect(it.Map(
-		itx.FromSlice(cfdomainList.Items).Filter(message.matches),
-		cfDomainToDomainRecord,
+	domainRecords := slices.Collect(it.Filter(
+		it.Map(itx.FromSlice(cfDomains), r.cfDomainToDomainRecord),
+		message.matches,
 	))
 	sort.Slice(domainRecords, func(i, j int) bool {
 		return domainRecords[i].CreatedAt.Before(domainRecords[j].CreatedAt)
@@ -182,9 +224,14 @@ func (r *DomainRepo) DeleteDomain(ctx context.Context, authInfo authorization.In
 		return fmt.Errorf("delete-domain failed to create user client: %w", err)
 	}
 
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, domainGUID, DomainResourceType)
+	if err != nil {
+		return err
+	}
+
 	cfDomain := &korifiv1alpha1.CFDomain{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
+			Namespace: ns,
 			Name:      domainGUID,
 		},
 	}
@@ -202,10 +249,16 @@ func (r *DomainRepo) GetDeletedAt(ctx context.Context, authInfo authorization.In
 	return domain.DeletedAt, err
 }
 
-func cfDomainToDomainRecord(cfDomain korifiv1alpha1.CFDomain) DomainRecord {
+func (r *DomainRepo) cfDomainToDomainRecord(cfDomain korifiv1alpha1.CFDomain) DomainRecord {
+	orgGUID := ""
+	if cfDomain.Namespace != r.rootNamespace {
+		orgGUID = cfDomain.Namespace
+	}
+
 	return DomainRecord{
 		Name:        cfDomain.Spec.Name,
 		GUID:        cfDomain.Name,
+		OrgGUID:     orgGUID,
 		Namespace:   cfDomain.Namespace,
 		CreatedAt:   cfDomain.CreationTimestamp.Time,
 		UpdatedAt:   getLastUpdatedTime(&cfDomain),
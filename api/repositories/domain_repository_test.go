@@ -44,7 +44,7 @@ var _ = Describe("DomainRepository", func() {
 		}
 		Expect(k8sClient.Create(ctx, cfDomain)).To(Succeed())
 
-		domainRepo = NewDomainRepo(userClientFactory, namespaceRetriever, rootNamespace)
+		domainRepo = NewDomainRepo(userClientFactory, namespaceRetriever, nsPerms, rootNamespace)
 	})
 
 	AfterEach(func() {
@@ -141,6 +141,34 @@ var _ = Describe("DomainRepository", func() {
 				Expect(createdCFDomain.Annotations).To(HaveKeyWithValue("bar", "baz"))
 			})
 		})
+
+		When("the domain is private", func() {
+			var cfOrg *korifiv1alpha1.CFOrg
+
+			BeforeEach(func() {
+				cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+				domainCreate.OrgGUID = cfOrg.Name
+			})
+
+			It("fails because the user is not an org manager", func() {
+				Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is an org manager", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgManagerRole.Name, cfOrg.Name)
+				})
+
+				It("creates the domain in the org namespace", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdDomain.OrgGUID).To(Equal(cfOrg.Name))
+
+					createdCFDomain := new(korifiv1alpha1.CFDomain)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdDomain.GUID, Namespace: cfOrg.Name}, createdCFDomain)).To(Succeed())
+					Expect(createdCFDomain.Spec.Name).To(Equal("my.domain"))
+				})
+			})
+		})
 	})
 
 	Describe("UpdateDomain", func() {
@@ -286,6 +314,71 @@ var _ = Describe("DomainRepository", func() {
 			})
 		})
 
+		When("private domains exist", func() {
+			var (
+				cfOrg          *korifiv1alpha1.CFOrg
+				anotherOrg     *korifiv1alpha1.CFOrg
+				privateDomain  *korifiv1alpha1.CFDomain
+				anotherPrivate *korifiv1alpha1.CFDomain
+			)
+
+			BeforeEach(func() {
+				cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+				anotherOrg = createOrgWithCleanup(ctx, uuid.NewString())
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, orgUserRole.Name, anotherOrg.Name)
+
+				privateDomain = &korifiv1alpha1.CFDomain{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: cfOrg.Name,
+					},
+					Spec: korifiv1alpha1.CFDomainSpec{
+						Name: "private.domain",
+					},
+				}
+				Expect(k8sClient.Create(ctx, privateDomain)).To(Succeed())
+
+				anotherPrivate = &korifiv1alpha1.CFDomain{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: anotherOrg.Name,
+					},
+					Spec: korifiv1alpha1.CFDomainSpec{
+						Name: "another-private.domain",
+					},
+				}
+				Expect(k8sClient.Create(ctx, anotherPrivate)).To(Succeed())
+			})
+
+			It("lists shared domains and private domains of the user's orgs", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(domainRecords).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID), "OrgGUID": BeEmpty()}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID1), "OrgGUID": BeEmpty()}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(privateDomain.Name), "OrgGUID": Equal(cfOrg.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(anotherPrivate.Name), "OrgGUID": Equal(anotherOrg.Name)}),
+				))
+			})
+
+			When("filtering by org", func() {
+				BeforeEach(func() {
+					domainListMessage = ListDomainsMessage{
+						OrgGUID: cfOrg.Name,
+					}
+				})
+
+				It("lists shared domains and the private domains of that org", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(domainRecords).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID)}),
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID1)}),
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(privateDomain.Name)}),
+					))
+				})
+			})
+		})
+
 		When("the user has no permission to list domains in the root namespace", func() {
 			BeforeEach(func() {
 				userName = uuid.NewString()
@@ -6,6 +6,7 @@ import (
 	"slices"
 	"time"
 
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/BooleanCat/go-functional/v2/it/itx"
@@ -41,6 +42,7 @@ func NewDropletRepo(
 
 type DropletRecord struct {
 	GUID            string
+	SpaceGUID       string
 	State           string
 	CreatedAt       time.Time
 	UpdatedAt       *time.Time
@@ -53,6 +55,7 @@ type DropletRecord struct {
 	Labels          map[string]string
 	Annotations     map[string]string
 	Image           string
+	ImageRef        string
 	Ports           []int32
 }
 
@@ -63,8 +66,10 @@ func (r DropletRecord) Relationships() map[string]string {
 }
 
 type ListDropletsMessage struct {
+	GUIDs        []string
 	PackageGUIDs []string
 	AppGUIDs     []string
+	States       []string
 }
 
 func (m *ListDropletsMessage) createSelector() map[string]string {
@@ -78,6 +83,11 @@ func (m *ListDropletsMessage) createSelector() map[string]string {
 	return newSelector
 }
 
+func (m *ListDropletsMessage) matches(droplet DropletRecord) bool {
+	return tools.EmptyOrContains(m.GUIDs, droplet.GUID) &&
+		tools.EmptyOrContains(m.States, droplet.State)
+}
+
 func (r *DropletRepo) GetDroplet(ctx context.Context, authInfo authorization.Info, dropletGUID string) (DropletRecord, error) {
 	build, _, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, dropletGUID)
 	if err != nil {
@@ -126,6 +136,7 @@ func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
 
 	result := DropletRecord{
 		GUID:      cfBuild.Name,
+		SpaceGUID: cfBuild.Namespace,
 		State:     "STAGED",
 		CreatedAt: cfBuild.CreationTimestamp.Time,
 		UpdatedAt: getLastUpdatedTime(&cfBuild),
@@ -143,6 +154,7 @@ func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
 		Labels:       cfBuild.Labels,
 		Annotations:  cfBuild.Annotations,
 		Ports:        cfBuild.Status.Droplet.Ports,
+		ImageRef:     cfBuild.Status.Droplet.Registry.Image,
 	}
 
 	if cfBuild.Spec.Lifecycle.Type == "docker" {
@@ -165,8 +177,8 @@ func (r *DropletRepo) ListDroplets(ctx context.Context, authInfo authorization.I
 		return []DropletRecord{}, apierrors.FromK8sError(err, BuildResourceType)
 	}
 
-	filteredBuilds := itx.FromSlice(buildList.Items)
-	return slices.Collect(it.Map(filteredBuilds, cfBuildToDropletRecord)), nil
+	dropletRecords := it.Map(itx.FromSlice(buildList.Items), cfBuildToDropletRecord)
+	return slices.Collect(it.Filter(dropletRecords, message.matches)), nil
 }
 
 type UpdateDropletMessage struct {
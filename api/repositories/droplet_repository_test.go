@@ -159,6 +159,8 @@ var _ = Describe("DropletRepository", func() {
 					Expect(dropletRecord.Lifecycle.Data.Buildpacks).To(BeEmpty())
 					Expect(dropletRecord.Lifecycle.Data.Stack).To(Equal(build.Spec.Lifecycle.Data.Stack))
 					Expect(dropletRecord.Image).To(BeEmpty())
+					Expect(dropletRecord.ImageRef).To(Equal(registryImage))
+					Expect(dropletRecord.SpaceGUID).To(Equal(space.Name))
 					Expect(dropletRecord.Ports).To(ConsistOf(int32(1234), int32(2345)))
 					Expect(dropletRecord.AppGUID).To(Equal(build.Spec.AppRef.Name))
 					Expect(dropletRecord.PackageGUID).To(Equal(build.Spec.PackageRef.Name))
@@ -401,6 +403,29 @@ var _ = Describe("DropletRepository", func() {
 					Expect(dropletRecords[0].AppGUID).To(Equal(appGUID))
 				})
 			})
+
+			When("filtering by guid", func() {
+				BeforeEach(func() {
+					message.GUIDs = []string{buildGUID}
+				})
+
+				It("returns the matching droplet", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(dropletRecords).To(HaveLen(1))
+					Expect(dropletRecords[0].GUID).To(Equal(buildGUID))
+				})
+			})
+
+			When("filtering by state", func() {
+				BeforeEach(func() {
+					message.States = []string{"FAILED"}
+				})
+
+				It("returns the matching droplets", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(dropletRecords).To(BeEmpty())
+				})
+			})
 		})
 	})
 
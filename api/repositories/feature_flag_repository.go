@@ -0,0 +1,203 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/BooleanCat/go-functional/v2/it"
+	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get,namespace=ROOT_NAMESPACE
+
+const (
+	FeatureFlagResourceType   = "Feature Flag"
+	FeatureFlagsConfigMapName = "korifi-feature-flags"
+
+	UserOrgCreationFeatureFlag         = "user_org_creation"
+	ServiceInstanceCreationFeatureFlag = "service_instance_creation"
+
+	customErrorMessageKeySuffix = ".custom_error_message"
+)
+
+// featureFlagDefaults lists the supported feature flags and the value they
+// have until an admin changes them. As in CF, the flags only restrict
+// non-admin users.
+var featureFlagDefaults = map[string]bool{
+	UserOrgCreationFeatureFlag:         false,
+	ServiceInstanceCreationFeatureFlag: true,
+}
+
+type FeatureFlagRecord struct {
+	Name               string
+	Enabled            bool
+	CustomErrorMessage string
+	UpdatedAt          *time.Time
+}
+
+type UpdateFeatureFlagMessage struct {
+	Name               string
+	Enabled            *bool
+	CustomErrorMessage *string
+}
+
+// FeatureFlagRepo stores feature flags in a config map in the root
+// namespace. Flags are read with the privileged client, as they are needed to
+// authorise requests from users that have no access to the root namespace,
+// while updates are made on behalf of the user.
+type FeatureFlagRepo struct {
+	rootNamespace     string
+	privilegedClient  client.Client
+	userClientFactory authorization.UserClientFactory
+}
+
+func NewFeatureFlagRepo(
+	rootNamespace string,
+	privilegedClient client.Client,
+	userClientFactory authorization.UserClientFactory,
+) *FeatureFlagRepo {
+	return &FeatureFlagRepo{
+		rootNamespace:     rootNamespace,
+		privilegedClient:  privilegedClient,
+		userClientFactory: userClientFactory,
+	}
+}
+
+func (r *FeatureFlagRepo) ListFeatureFlags(ctx context.Context, authInfo authorization.Info) ([]FeatureFlagRecord, error) {
+	configMap, err := r.getConfigMap(ctx, r.privilegedClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Collect(it.Map(slices.Values(slices.Sorted(maps.Keys(featureFlagDefaults))), func(name string) FeatureFlagRecord {
+		return toFeatureFlagRecord(configMap, name)
+	})), nil
+}
+
+func (r *FeatureFlagRepo) GetFeatureFlag(ctx context.Context, authInfo authorization.Info, name string) (FeatureFlagRecord, error) {
+	if _, ok := featureFlagDefaults[name]; !ok {
+		return FeatureFlagRecord{}, apierrors.NewNotFoundError(fmt.Errorf("unknown feature flag %q", name), FeatureFlagResourceType)
+	}
+
+	configMap, err := r.getConfigMap(ctx, r.privilegedClient)
+	if err != nil {
+		return FeatureFlagRecord{}, err
+	}
+
+	return toFeatureFlagRecord(configMap, name), nil
+}
+
+func (r *FeatureFlagRepo) UpdateFeatureFlag(ctx context.Context, authInfo authorization.Info, message UpdateFeatureFlagMessage) (FeatureFlagRecord, error) {
+	if _, ok := featureFlagDefaults[message.Name]; !ok {
+		return FeatureFlagRecord{}, apierrors.NewNotFoundError(fmt.Errorf("unknown feature flag %q", message.Name), FeatureFlagResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return FeatureFlagRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      FeatureFlagsConfigMapName,
+		},
+	}
+
+	err = userClient.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)
+	switch {
+	case errors.IsNotFound(err):
+		configMap.Data = map[string]string{}
+		message.apply(configMap)
+		err = userClient.Create(ctx, configMap)
+	case err == nil:
+		err = k8s.PatchResource(ctx, userClient, configMap, func() {
+			message.apply(configMap)
+		})
+	}
+	if err != nil {
+		return FeatureFlagRecord{}, apierrors.FromK8sError(err, FeatureFlagResourceType)
+	}
+
+	return toFeatureFlagRecord(configMap, message.Name), nil
+}
+
+// CanUpdateFeatureFlags tells whether the user is allowed to change the
+// feature flags, i.e. whether they are an admin
+func (r *FeatureFlagRepo) CanUpdateFeatureFlags(ctx context.Context, authInfo authorization.Info) (bool, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return false, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: r.rootNamespace,
+				Verb:      "patch",
+				Resource:  "configmaps",
+				Name:      FeatureFlagsConfigMapName,
+			},
+		},
+	}
+	if err := userClient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("failed to create self subject access review: %w", apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
+func (m UpdateFeatureFlagMessage) apply(configMap *corev1.ConfigMap) {
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+
+	if m.Enabled != nil {
+		configMap.Data[m.Name] = strconv.FormatBool(*m.Enabled)
+	}
+
+	if m.CustomErrorMessage != nil {
+		configMap.Data[m.Name+customErrorMessageKeySuffix] = *m.CustomErrorMessage
+	}
+}
+
+func (r *FeatureFlagRepo) getConfigMap(ctx context.Context, k8sClient client.Client) (*corev1.ConfigMap, error) {
+	configMap := &corev1.ConfigMap{}
+	err := k8sClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: FeatureFlagsConfigMapName}, configMap)
+	if errors.IsNotFound(err) {
+		return &corev1.ConfigMap{}, nil
+	}
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, FeatureFlagResourceType)
+	}
+
+	return configMap, nil
+}
+
+func toFeatureFlagRecord(configMap *corev1.ConfigMap, name string) FeatureFlagRecord {
+	record := FeatureFlagRecord{
+		Name:               name,
+		Enabled:            featureFlagDefaults[name],
+		CustomErrorMessage: configMap.Data[name+customErrorMessageKeySuffix],
+	}
+
+	if value, ok := configMap.Data[name]; ok {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			record.Enabled = enabled
+			record.UpdatedAt = getLastUpdatedTime(configMap)
+		}
+	}
+
+	return record
+}
@@ -0,0 +1,216 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("FeatureFlagRepository", func() {
+	var featureFlagRepo *repositories.FeatureFlagRepo
+
+	BeforeEach(func() {
+		featureFlagRepo = repositories.NewFeatureFlagRepo(rootNamespace, k8sClient, userClientFactory)
+	})
+
+	createFeatureFlagsConfigMap := func(data map[string]string) {
+		Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      repositories.FeatureFlagsConfigMapName,
+			},
+			Data: data,
+		})).To(Succeed())
+	}
+
+	Describe("ListFeatureFlags", func() {
+		var (
+			records []repositories.FeatureFlagRecord
+			listErr error
+		)
+
+		JustBeforeEach(func() {
+			records, listErr = featureFlagRepo.ListFeatureFlags(ctx, authInfo)
+		})
+
+		It("returns the default flags", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(Equal([]repositories.FeatureFlagRecord{
+				{Name: repositories.ServiceInstanceCreationFeatureFlag, Enabled: true},
+				{Name: repositories.UserOrgCreationFeatureFlag, Enabled: false},
+			}))
+		})
+
+		When("a flag has been set", func() {
+			BeforeEach(func() {
+				createFeatureFlagsConfigMap(map[string]string{
+					repositories.UserOrgCreationFeatureFlag:                           "false",
+					repositories.UserOrgCreationFeatureFlag + ".custom_error_message": "no orgs for you",
+				})
+			})
+
+			It("returns the stored value", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(2))
+				Expect(records[1].Name).To(Equal(repositories.UserOrgCreationFeatureFlag))
+				Expect(records[1].Enabled).To(BeFalse())
+				Expect(records[1].CustomErrorMessage).To(Equal("no orgs for you"))
+				Expect(records[1].UpdatedAt).NotTo(BeNil())
+			})
+		})
+	})
+
+	Describe("GetFeatureFlag", func() {
+		var (
+			flagName string
+			record   repositories.FeatureFlagRecord
+			getErr   error
+		)
+
+		BeforeEach(func() {
+			flagName = repositories.ServiceInstanceCreationFeatureFlag
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = featureFlagRepo.GetFeatureFlag(ctx, authInfo, flagName)
+		})
+
+		It("returns the default value", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(record.Name).To(Equal(repositories.ServiceInstanceCreationFeatureFlag))
+			Expect(record.Enabled).To(BeTrue())
+			Expect(record.UpdatedAt).To(BeNil())
+		})
+
+		When("the flag has been disabled", func() {
+			BeforeEach(func() {
+				createFeatureFlagsConfigMap(map[string]string{
+					repositories.ServiceInstanceCreationFeatureFlag: "false",
+				})
+			})
+
+			It("returns it as disabled", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.Enabled).To(BeFalse())
+			})
+		})
+
+		When("the flag does not exist", func() {
+			BeforeEach(func() {
+				flagName = "not-a-flag"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("CanUpdateFeatureFlags", func() {
+		var (
+			canUpdate bool
+			canErr    error
+		)
+
+		JustBeforeEach(func() {
+			canUpdate, canErr = featureFlagRepo.CanUpdateFeatureFlags(ctx, authInfo)
+		})
+
+		It("returns false", func() {
+			Expect(canErr).NotTo(HaveOccurred())
+			Expect(canUpdate).To(BeFalse())
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns true", func() {
+				Expect(canErr).NotTo(HaveOccurred())
+				Expect(canUpdate).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("UpdateFeatureFlag", func() {
+		var (
+			message   repositories.UpdateFeatureFlagMessage
+			record    repositories.FeatureFlagRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			message = repositories.UpdateFeatureFlagMessage{
+				Name:               repositories.UserOrgCreationFeatureFlag,
+				Enabled:            tools.PtrTo(false),
+				CustomErrorMessage: tools.PtrTo("ask an admin"),
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = featureFlagRepo.UpdateFeatureFlag(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(updateErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("stores the flag in a new config map", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal(repositories.UserOrgCreationFeatureFlag))
+				Expect(record.Enabled).To(BeFalse())
+				Expect(record.CustomErrorMessage).To(Equal("ask an admin"))
+
+				configMap := &corev1.ConfigMap{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: repositories.FeatureFlagsConfigMapName}, configMap)).To(Succeed())
+				Expect(configMap.Data).To(Equal(map[string]string{
+					repositories.UserOrgCreationFeatureFlag:                           "false",
+					repositories.UserOrgCreationFeatureFlag + ".custom_error_message": "ask an admin",
+				}))
+			})
+
+			When("the config map already exists", func() {
+				BeforeEach(func() {
+					createFeatureFlagsConfigMap(map[string]string{
+						repositories.ServiceInstanceCreationFeatureFlag: "false",
+						repositories.UserOrgCreationFeatureFlag:         "true",
+					})
+					message.CustomErrorMessage = nil
+				})
+
+				It("only updates the requested flag", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+
+					configMap := &corev1.ConfigMap{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: repositories.FeatureFlagsConfigMapName}, configMap)).To(Succeed())
+					Expect(configMap.Data).To(Equal(map[string]string{
+						repositories.ServiceInstanceCreationFeatureFlag: "false",
+						repositories.UserOrgCreationFeatureFlag:         "false",
+					}))
+				})
+			})
+
+			When("the flag does not exist", func() {
+				BeforeEach(func() {
+					message.Name = "not-a-flag"
+				})
+
+				It("returns a not found error", func() {
+					Expect(updateErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+})
@@ -25,9 +25,16 @@ type ImagePusher interface {
 	Push(ctx context.Context, creds image.Creds, repoRef string, zipReader io.Reader, tags ...string) (string, error)
 }
 
+//counterfeiter:generate -o fake -fake-name ImageExporter . ImageExporter
+
+type ImageExporter interface {
+	Export(ctx context.Context, creds image.Creds, imageRef string) (io.ReadCloser, error)
+}
+
 type ImageRepository struct {
 	userClientFactory   authorization.UserClientFactory
 	pusher              ImagePusher
+	exporter            ImageExporter
 	pushSecretNames     []string
 	pushSecretNamespace string
 }
@@ -35,19 +42,21 @@ type ImageRepository struct {
 func NewImageRepository(
 	userClientFactory authorization.UserClientFactory,
 	pusher ImagePusher,
+	exporter ImageExporter,
 	pushSecretNames []string,
 	pushSecretNamespace string,
 ) *ImageRepository {
 	return &ImageRepository{
 		userClientFactory:   userClientFactory,
 		pusher:              pusher,
+		exporter:            exporter,
 		pushSecretNames:     pushSecretNames,
 		pushSecretNamespace: pushSecretNamespace,
 	}
 }
 
 func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (string, error) {
-	authorized, err := r.canIPatchCFPackage(ctx, authInfo, spaceGUID)
+	authorized, err := r.canI(ctx, authInfo, spaceGUID, "patch", "cfpackages")
 	if err != nil {
 		return "", fmt.Errorf("checking auth to upload source image for failed: %w", err)
 	}
@@ -72,24 +81,48 @@ func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo author
 	return pushedRef, nil
 }
 
-func (r *ImageRepository) canIPatchCFPackage(ctx context.Context, authInfo authorization.Info, spaceGUID string) (bool, error) {
+// DownloadDroplet returns the droplet bits as a zip archive. Droplet images
+// are pushed to the same registry as the source images, hence the push
+// secrets are used to pull them.
+func (r *ImageRepository) DownloadDroplet(ctx context.Context, authInfo authorization.Info, droplet DropletRecord) (io.ReadCloser, error) {
+	authorized, err := r.canI(ctx, authInfo, droplet.SpaceGUID, "get", "cfbuilds")
+	if err != nil {
+		return nil, fmt.Errorf("checking auth to download droplet failed: %w", err)
+	}
+
+	if !authorized {
+		return nil, apierrors.NewForbiddenError(errors.New("not authorized to get cfbuild"), DropletResourceType)
+	}
+
+	bits, err := r.exporter.Export(ctx, image.Creds{
+		Namespace:   r.pushSecretNamespace,
+		SecretNames: r.pushSecretNames,
+	}, droplet.ImageRef)
+	if err != nil {
+		return nil, fmt.Errorf("exporting droplet image '%s' failed: %w", droplet.ImageRef, err)
+	}
+
+	return bits, nil
+}
+
+func (r *ImageRepository) canI(ctx context.Context, authInfo authorization.Info, spaceGUID, verb, resource string) (bool, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
-		return false, fmt.Errorf("canIPatchCFPackage: failed to create user k8s client: %w", err)
+		return false, fmt.Errorf("canI %s %s: failed to create user k8s client: %w", verb, resource, err)
 	}
 
 	review := authv1.SelfSubjectAccessReview{
 		Spec: authv1.SelfSubjectAccessReviewSpec{
 			ResourceAttributes: &authv1.ResourceAttributes{
 				Namespace: spaceGUID,
-				Verb:      "patch",
+				Verb:      verb,
 				Group:     "korifi.cloudfoundry.org",
-				Resource:  "cfpackages",
+				Resource:  resource,
 			},
 		},
 	}
 	if err := userClient.Create(ctx, &review); err != nil {
-		return false, fmt.Errorf("canIPatchCFPackage: failed to create self subject access review: %w", apierrors.FromK8sError(err, PackageResourceType))
+		return false, fmt.Errorf("canI %s %s: failed to create self subject access review: %w", verb, resource, apierrors.FromK8sError(err, PackageResourceType))
 	}
 
 	return review.Status.Allowed, nil
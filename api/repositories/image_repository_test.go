@@ -17,90 +17,150 @@ import (
 
 var _ = Describe("ImageRepository", func() {
 	var (
-		imagePusher *fake.ImagePusher
-		imageSource io.Reader
-		imageRepo   *repositories.ImageRepository
-		imageName   string
-		imageRef    string
-		tags        []string
-		uploadErr   error
-		org         *korifiv1alpha1.CFOrg
-		space       *korifiv1alpha1.CFSpace
+		imagePusher   *fake.ImagePusher
+		imageExporter *fake.ImageExporter
+		imageRepo     *repositories.ImageRepository
+		org           *korifiv1alpha1.CFOrg
+		space         *korifiv1alpha1.CFSpace
 	)
 
 	BeforeEach(func() {
-		imageName = "my-image"
 		imagePusher = new(fake.ImagePusher)
 		imagePusher.PushReturns("my-pushed-image", nil)
-
-		imageSource = bytes.NewBufferString("")
-
-		var err error
-		Expect(err).NotTo(HaveOccurred())
+		imageExporter = new(fake.ImageExporter)
 
 		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
 		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
 
-		tags = []string{"foo", "bar"}
-
 		imageRepo = repositories.NewImageRepository(
 			userClientFactory,
 			imagePusher,
+			imageExporter,
 			[]string{"push-secret-name"},
 			rootNamespace,
 		)
 	})
 
-	JustBeforeEach(func() {
-		imageRef, uploadErr = imageRepo.UploadSourceImage(context.Background(), authInfo, imageName, imageSource, space.Name, tags...)
-	})
-
-	It("fails with unauthorized error without a valid role in the space", func() {
-		Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
-	})
+	Describe("UploadSourceImage", func() {
+		var (
+			imageSource io.Reader
+			imageName   string
+			imageRef    string
+			tags        []string
+			uploadErr   error
+		)
 
-	When("user has role SpaceDeveloper", func() {
 		BeforeEach(func() {
-			createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
+			imageName = "my-image"
+			imageSource = bytes.NewBufferString("")
+			tags = []string{"foo", "bar"}
 		})
 
-		It("succeeds", func() {
-			Expect(uploadErr).NotTo(HaveOccurred())
-			Expect(imageRef).To(Equal("my-pushed-image"))
+		JustBeforeEach(func() {
+			imageRef, uploadErr = imageRepo.UploadSourceImage(context.Background(), authInfo, imageName, imageSource, space.Name, tags...)
 		})
 
-		It("uploads the image to the registry", func() {
-			Expect(imagePusher.PushCallCount()).To(Equal(1))
-			_, creds, actualRef, zipReader, actualTags := imagePusher.PushArgsForCall(0)
-			Expect(creds.Namespace).To(Equal(rootNamespace))
-			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
-			Expect(actualRef).To(Equal("my-image"))
-			Expect(zipReader).To(Equal(imageSource))
-			Expect(actualTags).To(Equal(tags))
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
 		})
 
-		When("the image name is invalid", func() {
+		When("user has role SpaceDeveloper", func() {
 			BeforeEach(func() {
-				imageName = "invAlid-image"
+				createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
 			})
 
-			It("fails with an easy to understand unprocessible entity error ", func() {
-				var apiError apierrors.UnprocessableEntityError
-				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
-				Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
+			It("succeeds", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-pushed-image"))
+			})
+
+			It("uploads the image to the registry", func() {
+				Expect(imagePusher.PushCallCount()).To(Equal(1))
+				_, creds, actualRef, zipReader, actualTags := imagePusher.PushArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(zipReader).To(Equal(imageSource))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("the image name is invalid", func() {
+				BeforeEach(func() {
+					imageName = "invAlid-image"
+				})
+
+				It("fails with an easy to understand unprocessible entity error ", func() {
+					var apiError apierrors.UnprocessableEntityError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
+				})
+			})
+
+			When("pushing the image fails", func() {
+				BeforeEach(func() {
+					imagePusher.PushReturns("", errors.New("push-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
+					var apiError apierrors.BlobstoreUnavailableError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal("Error uploading source package to the container registry"))
+				})
 			})
 		})
+	})
 
-		When("pushing the image fails", func() {
+	Describe("DownloadDroplet", func() {
+		var (
+			droplet     repositories.DropletRecord
+			bits        io.ReadCloser
+			downloadErr error
+		)
+
+		BeforeEach(func() {
+			droplet = repositories.DropletRecord{
+				GUID:      "droplet-guid",
+				SpaceGUID: space.Name,
+				ImageRef:  "my-droplet-image",
+			}
+			imageExporter.ExportReturns(io.NopCloser(bytes.NewBufferString("droplet-bits")), nil)
+		})
+
+		JustBeforeEach(func() {
+			bits, downloadErr = imageRepo.DownloadDroplet(context.Background(), authInfo, droplet)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
 			BeforeEach(func() {
-				imagePusher.PushReturns("", errors.New("push-error"))
+				createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the droplet bits", func() {
+				Expect(downloadErr).NotTo(HaveOccurred())
+				Expect(io.ReadAll(bits)).To(Equal([]byte("droplet-bits")))
 			})
 
-			It("fails with a blobstore unavailable error", func() {
-				Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
-				var apiError apierrors.BlobstoreUnavailableError
-				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
-				Expect(apiError.Detail()).To(Equal("Error uploading source package to the container registry"))
+			It("exports the droplet image", func() {
+				Expect(imageExporter.ExportCallCount()).To(Equal(1))
+				_, creds, actualRef := imageExporter.ExportArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-droplet-image"))
+			})
+
+			When("exporting the image fails", func() {
+				BeforeEach(func() {
+					imageExporter.ExportReturns(nil, errors.New("export-error"))
+				})
+
+				It("returns the error", func() {
+					Expect(downloadErr).To(MatchError(ContainSubstring("export-error")))
+				})
 			})
 		})
 	})
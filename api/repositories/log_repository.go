@@ -7,6 +7,7 @@ import (
 	"iter"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
@@ -26,6 +27,8 @@ import (
 
 const (
 	BuildWorkloadLabelKey = "korifi.cloudfoundry.org/build-workload-name"
+
+	logStreamPodPollInterval = 2 * time.Second
 )
 
 //counterfeiter:generate -o fake -fake-name LogStreamer . LogStreamer
@@ -40,11 +43,25 @@ type GetLogsMessage struct {
 	Descending bool
 }
 
+// StreamLogsMessage describes which app logs to follow. Only log lines
+// produced at or after StartTime (in nanoseconds) are streamed. When
+// StartTime is not set the stream starts at the time it is opened. When Build
+// is set, the logs of its staging pods are followed as well.
+type StreamLogsMessage struct {
+	App       AppRecord
+	Build     *BuildRecord
+	StartTime *int64
+}
+
+// LogRecord is a single log line. ProcessType and InstanceID identify the app
+// instance that produced the line and are empty for staging logs.
 type LogRecord struct {
-	Message   string
-	Timestamp int64
-	Header    string
-	Tags      map[string]string
+	Message     string
+	Timestamp   int64
+	Header      string
+	Tags        map[string]string
+	ProcessType string
+	InstanceID  string
 }
 
 var DefaultLogStreamer LogStreamer = func(
@@ -70,17 +87,20 @@ type LogRepo struct {
 	userClientFactory    authorization.UserClientFactory
 	userClientsetFactory authorization.UserClientsetFactory
 	logStreamer          LogStreamer
+	stagingLogsMaxBytes  int
 }
 
 func NewLogRepo(
 	userClientFactory authorization.UserClientFactory,
 	userClientsetFactory authorization.UserClientsetFactory,
 	logStreamer LogStreamer,
+	stagingLogsMaxBytes int,
 ) *LogRepo {
 	return &LogRepo{
 		userClientFactory:    userClientFactory,
 		userClientsetFactory: userClientsetFactory,
 		logStreamer:          logStreamer,
+		stagingLogsMaxBytes:  stagingLogsMaxBytes,
 	}
 }
 
@@ -123,7 +143,203 @@ func (r *LogRepo) GetAppLogs(ctx context.Context, authInfo authorization.Info, m
 		return logs, nil
 	}
 
-	return logs[:len(logs)-int(*message.Limit)], nil
+	return logs[:*message.Limit], nil
+}
+
+// StreamAppLogs follows the logs of the app instances until ctx is done, at
+// which point the returned channel is closed. Pods are listed periodically so
+// that instances started after the stream has been opened are followed as
+// well, while pods that go away simply stop contributing log lines.
+func (r *LogRepo) StreamAppLogs(ctx context.Context, authInfo authorization.Info, message StreamLogsMessage) (<-chan LogRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	logClient, err := r.userClientsetFactory.BuildClientset(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	labelSelector, err := labels.ValidatedSelectorFromSet(map[string]string{
+		korifiv1alpha1.CFAppGUIDLabelKey: message.App.GUID,
+		korifiv1alpha1.VersionLabelKey:   message.App.Revision,
+	})
+	if err != nil {
+		return nil, err
+	}
+	podListOptions := []*client.ListOptions{{
+		Namespace:     message.App.SpaceGUID,
+		LabelSelector: labelSelector,
+	}}
+
+	if message.Build != nil {
+		buildLabelSelector, err := labels.ValidatedSelectorFromSet(map[string]string{
+			BuildWorkloadLabelKey: message.Build.GUID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		podListOptions = append(podListOptions, &client.ListOptions{
+			Namespace:     message.Build.SpaceGUID,
+			LabelSelector: buildLabelSelector,
+		})
+	}
+
+	listPods := func() ([]corev1.Pod, error) {
+		pods := []corev1.Pod{}
+		for _, listOptions := range podListOptions {
+			podList := corev1.PodList{}
+			err := userClient.List(ctx, &podList, listOptions)
+			if err != nil {
+				return nil, apierrors.FromK8sError(err, PodResourceType)
+			}
+			pods = append(pods, podList.Items...)
+		}
+		return pods, nil
+	}
+
+	pods, err := listPods()
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now().UnixNano()
+	if message.StartTime != nil {
+		startTime = *message.StartTime
+	}
+
+	records := make(chan LogRecord)
+	go func() {
+		logger := logr.FromContextOrDiscard(ctx).WithName("stream-app-logs").WithValues("app", message.App.GUID)
+
+		follower := &containerLogsFollower{
+			logStreamer: r.logStreamer,
+			logClient:   logClient,
+			records:     records,
+			startTime:   startTime,
+			following:   map[string]bool{},
+			lastSeen:    map[string]int64{},
+		}
+		defer close(records)
+		defer follower.wg.Wait()
+
+		ticker := time.NewTicker(logStreamPodPollInterval)
+		defer ticker.Stop()
+
+		for {
+			for _, pod := range pods {
+				for _, containerName := range getReadyContainers(pod) {
+					follower.follow(ctx, pod, containerName)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			refreshedPods, err := listPods()
+			if err != nil {
+				logger.Info("failed to list app pods", "reason", err)
+				continue
+			}
+			pods = refreshedPods
+		}
+	}()
+
+	return records, nil
+}
+
+type containerLogsFollower struct {
+	logStreamer LogStreamer
+	logClient   k8sclient.Interface
+	records     chan<- LogRecord
+	startTime   int64
+
+	wg        sync.WaitGroup
+	mu        sync.Mutex
+	following map[string]bool
+	lastSeen  map[string]int64
+}
+
+// follow starts streaming the logs of the pod container unless they are
+// already being streamed. When the container is followed again (e.g. after it
+// has been restarted) lines that have already been sent are skipped.
+func (f *containerLogsFollower) follow(ctx context.Context, pod corev1.Pod, containerName string) {
+	key := pod.Name + "/" + containerName
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.following[key] {
+		return
+	}
+	f.following[key] = true
+
+	since := f.startTime
+	if lastSeen, ok := f.lastSeen[key]; ok {
+		since = lastSeen + 1
+	}
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+
+		lastSeen := f.streamContainerLogs(ctx, pod, containerName, since)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.following, key)
+		f.lastSeen[key] = lastSeen
+	}()
+}
+
+func (f *containerLogsFollower) streamContainerLogs(ctx context.Context, pod corev1.Pod, containerName string, since int64) int64 {
+	logger := logr.FromContextOrDiscard(ctx).WithName("stream-container-logs").WithValues("pod", pod.Name, "container", containerName)
+	lastSeen := since - 1
+
+	logReadCloser, err := f.logStreamer(ctx, f.logClient, pod, corev1.PodLogOptions{
+		Container:  containerName,
+		Follow:     true,
+		Timestamps: true,
+		SinceTime:  toMetav1Time(&since),
+	})
+	if err != nil {
+		logger.Info("failed to stream logs", "reason", err)
+		return lastSeen
+	}
+	defer logReadCloser.Close()
+
+	for logLine, err := range it.LinesString(logReadCloser) {
+		if err != nil {
+			logger.Info("failed to read pod logs", "reason", err)
+			return lastSeen
+		}
+
+		if len(logLine) == 0 {
+			continue
+		}
+
+		record := withPodSource(pod, logLineToLogRecord(logLine))
+		// `SinceTime` has a precision of a second, filter out the earlier lines
+		if record.Timestamp < since {
+			continue
+		}
+		record.Tags = map[string]string{
+			"source_type": podSourceType(pod),
+		}
+
+		select {
+		case f.records <- record:
+			lastSeen = record.Timestamp
+		case <-ctx.Done():
+			return lastSeen
+		}
+	}
+
+	return lastSeen
 }
 
 func (r *LogRepo) getBuildLogs(
@@ -154,7 +370,14 @@ func (r *LogRepo) getBuildLogs(
 		return nil, err
 	}
 
-	return it.Map(logs, func(record LogRecord) LogRecord {
+	buildLogs := slices.Collect(logs)
+	if len(buildLogs) == 0 && build.State == BuildStateFailed {
+		// the builder pod has been garbage collected, fall back to the
+		// failure recorded on the build
+		buildLogs = []LogRecord{failedStagingLogRecord(build)}
+	}
+
+	return it.Map(slices.Values(r.truncateBuildLogs(buildLogs)), func(record LogRecord) LogRecord {
 		record.Tags = map[string]string{
 			"source_type": "STG",
 		}
@@ -162,6 +385,50 @@ func (r *LogRepo) getBuildLogs(
 	}), nil
 }
 
+func failedStagingLogRecord(build BuildRecord) LogRecord {
+	failedAt := build.CreatedAt
+	if build.UpdatedAt != nil {
+		failedAt = *build.UpdatedAt
+	}
+
+	return LogRecord{
+		Message:   "Staging failed: " + build.StagingErrorMsg,
+		Timestamp: failedAt.UnixNano(),
+	}
+}
+
+// truncateBuildLogs keeps the latest build log lines that fit within
+// stagingLogsMaxBytes, as staging failures are reported at the end of the
+// logs. The dropped lines are replaced with a single line saying so.
+func (r *LogRepo) truncateBuildLogs(logs []LogRecord) []LogRecord {
+	if r.stagingLogsMaxBytes <= 0 {
+		return logs
+	}
+
+	logs = slices.SortedFunc(slices.Values(logs), ascendingOrder)
+
+	size := 0
+	for i := len(logs) - 1; i >= 0; i-- {
+		size += len(logs[i].Message)
+		if size <= r.stagingLogsMaxBytes {
+			continue
+		}
+
+		kept := logs[i+1:]
+		truncatedAt := logs[i].Timestamp
+		if len(kept) > 0 {
+			truncatedAt = kept[0].Timestamp - 1
+		}
+
+		return append([]LogRecord{{
+			Message:   fmt.Sprintf("[%d earlier staging log lines truncated]", i+1),
+			Timestamp: truncatedAt,
+		}}, kept...)
+	}
+
+	return logs
+}
+
 func (r *LogRepo) getAppLogs(
 	ctx context.Context,
 	authInfo authorization.Info,
@@ -241,7 +508,19 @@ func (r *LogRepo) getLogsForPod(ctx context.Context, k8sClient k8sclient.Interfa
 		})
 	}))
 
-	return it.Chain(readyContainerLogs...)
+	// the kubelet keeps the logs of the last terminated instance of a
+	// container, so that the output of crashed instances is not lost
+	previousContainerLogs := slices.Collect(it.Map(slices.Values(getRestartedContainers(pod)), func(containerName string) func(func(LogRecord) bool) {
+		return r.getContainerLogs(ctx, k8sClient, pod, corev1.PodLogOptions{
+			Container:  containerName,
+			Previous:   true,
+			Timestamps: true,
+			SinceTime:  toMetav1Time(startTime),
+			TailLines:  limit,
+		})
+	}))
+
+	return it.Chain(append(previousContainerLogs, readyContainerLogs...)...)
 }
 
 func (r *LogRepo) getContainerLogs(ctx context.Context, k8sClient k8sclient.Interface, pod corev1.Pod, logOpts corev1.PodLogOptions) iter.Seq[LogRecord] {
@@ -259,7 +538,24 @@ func (r *LogRepo) getContainerLogs(ctx context.Context, k8sClient k8sclient.Inte
 		return len(logLine) > 0
 	})
 
-	return it.Map(logLines, logLineToLogRecord)
+	return it.Map(logLines, func(logLine string) LogRecord {
+		return withPodSource(pod, logLineToLogRecord(logLine))
+	})
+}
+
+func withPodSource(pod corev1.Pod, record LogRecord) LogRecord {
+	record.ProcessType = pod.Labels[korifiv1alpha1.CFProcessTypeLabelKey]
+	record.InstanceID = pod.Labels[korifiv1alpha1.PodIndexLabelKey]
+	return record
+}
+
+// podSourceType tells staging pods apart from app instance pods, using the
+// same source types as the log cache
+func podSourceType(pod corev1.Pod) string {
+	if _, ok := pod.Labels[BuildWorkloadLabelKey]; ok {
+		return "STG"
+	}
+	return "APP"
 }
 
 func getReadyContainers(pod corev1.Pod) []string {
@@ -273,6 +569,17 @@ func getReadyContainers(pod corev1.Pod) []string {
 	}))
 }
 
+func getRestartedContainers(pod corev1.Pod) []string {
+	containerStatuses := append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...)
+	restartedContainers := it.Filter(slices.Values(containerStatuses), func(status corev1.ContainerStatus) bool {
+		return status.RestartCount > 0
+	})
+
+	return slices.Collect(it.Map(restartedContainers, func(container corev1.ContainerStatus) string {
+		return container.Name
+	}))
+}
+
 func readLines(ctx context.Context, r io.Reader) []string {
 	logger := logr.FromContextOrDiscard(ctx)
 
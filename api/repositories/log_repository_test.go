@@ -77,8 +77,10 @@ var _ = Describe("LogRepository", func() {
 				Namespace: cfSpace.Name,
 				Name:      appGUID,
 				Labels: map[string]string{
-					korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
-					korifiv1alpha1.VersionLabelKey:   "7",
+					korifiv1alpha1.CFAppGUIDLabelKey:     appGUID,
+					korifiv1alpha1.VersionLabelKey:       "7",
+					korifiv1alpha1.CFProcessTypeLabelKey: "web",
+					korifiv1alpha1.PodIndexLabelKey:      "0",
 				},
 			},
 			Spec: corev1.PodSpec{
@@ -116,7 +118,7 @@ var _ = Describe("LogRepository", func() {
 			return nil, nil
 		}
 
-		logRepo = repositories.NewLogRepo(userClientFactory, userClientsetFactory, logStreamer.Spy)
+		logRepo = repositories.NewLogRepo(userClientFactory, userClientsetFactory, logStreamer.Spy, 0)
 
 		message = repositories.GetLogsMessage{
 			App: repositories.AppRecord{
@@ -190,6 +192,38 @@ var _ = Describe("LogRepository", func() {
 			})
 		})
 
+		When("a pod container has been restarted", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, appPod, func() {
+					appPod.Status = corev1.PodStatus{
+						ContainerStatuses: []corev1.ContainerStatus{{
+							Name:         "app-container",
+							RestartCount: 1,
+							State: corev1.ContainerState{
+								Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+							},
+						}},
+					}
+				})).To(Succeed())
+			})
+
+			It("fetches the logs of the previous container instance", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logStreamer.CallCount()).To(Equal(2))
+
+				_, _, actualPod, actualLogOptions := logStreamer.ArgsForCall(1)
+				Expect(actualPod.Name).To(Equal(appPod.Name))
+				Expect(actualLogOptions).To(Equal(corev1.PodLogOptions{
+					Container:  "app-container",
+					Previous:   true,
+					SinceTime:  tools.PtrTo(metav1.NewTime(time.Unix(0, 1000))),
+					Timestamps: true,
+				}))
+
+				Expect(logRecords).To(ContainElement(matchLogRecord(1100, "a1", "APP")))
+			})
+		})
+
 		It("merges build and app log entries later than the start time specified in ascending order", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(logRecords).To(HaveLen(4))
@@ -199,6 +233,81 @@ var _ = Describe("LogRepository", func() {
 			Expect(logRecords[3]).To(matchLogRecord(2100, "a2", "APP"))
 		})
 
+		It("sets the app instance the log entries are coming from", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logRecords[0].ProcessType).To(BeEmpty())
+			Expect(logRecords[0].InstanceID).To(BeEmpty())
+			Expect(logRecords[1].ProcessType).To(Equal("web"))
+			Expect(logRecords[1].InstanceID).To(Equal("0"))
+		})
+
+		When("the build has failed", func() {
+			BeforeEach(func() {
+				message.Build.State = repositories.BuildStateFailed
+				message.Build.StagingErrorMsg = "detect failed"
+				message.Build.UpdatedAt = tools.PtrTo(time.Unix(0, 1500))
+
+				Expect(k8s.Patch(ctx, k8sClient, buildPod, func() {
+					buildPod.Status = corev1.PodStatus{
+						Phase: corev1.PodFailed,
+						InitContainerStatuses: []corev1.ContainerStatus{{
+							Name: "build-container",
+							State: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{ExitCode: 1},
+							},
+						}},
+						ContainerStatuses: []corev1.ContainerStatus{{
+							Name: "build-completion-container",
+							State: corev1.ContainerState{
+								Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"},
+							},
+						}},
+					}
+				})).To(Succeed())
+			})
+
+			It("returns the logs of the failed builder container", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logRecords).To(ContainElements(
+					matchLogRecord(1000, "b1", "STG"),
+					matchLogRecord(2000, "b2", "STG"),
+				))
+				Expect(logRecords).NotTo(ContainElement(matchLogRecord(1500, "Staging failed: detect failed", "STG")))
+			})
+
+			When("the builder pod has been garbage collected", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Delete(ctx, buildPod)).To(Succeed())
+				})
+
+				It("returns the recorded failure reason", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(logRecords).To(HaveLen(3))
+					Expect(logRecords[0]).To(matchLogRecord(1100, "a1", "APP"))
+					Expect(logRecords[1]).To(matchLogRecord(1500, "Staging failed: detect failed", "STG"))
+					Expect(logRecords[2]).To(matchLogRecord(2100, "a2", "APP"))
+				})
+			})
+		})
+
+		When("the staging logs are larger than the configured maximum size", func() {
+			BeforeEach(func() {
+				logRepo = repositories.NewLogRepo(userClientFactory, userClientsetFactory, logStreamer.Spy, 4)
+				message.StartTime = nil
+			})
+
+			It("drops the earliest staging log lines", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logRecords).To(HaveLen(6))
+				Expect(logRecords[0]).To(matchLogRecord(110, "a0", "APP"))
+				Expect(logRecords[1]).To(matchLogRecord(999, "[1 earlier staging log lines truncated]", "STG"))
+				Expect(logRecords[2]).To(matchLogRecord(1000, "b1", "STG"))
+				Expect(logRecords[3]).To(matchLogRecord(1100, "a1", "APP"))
+				Expect(logRecords[4]).To(matchLogRecord(2000, "b2", "STG"))
+				Expect(logRecords[5]).To(matchLogRecord(2100, "a2", "APP"))
+			})
+		})
+
 		When("start time is not provided", func() {
 			BeforeEach(func() {
 				message.StartTime = nil
@@ -241,6 +350,19 @@ var _ = Describe("LogRepository", func() {
 				Expect(logRecords[1]).To(matchLogRecord(1100, "a1", "APP"))
 			})
 
+			When("descending is requested", func() {
+				BeforeEach(func() {
+					message.Descending = true
+				})
+
+				It("returns the latest logs", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(logRecords).To(HaveLen(2))
+					Expect(logRecords[0]).To(matchLogRecord(2100, "a2", "APP"))
+					Expect(logRecords[1]).To(matchLogRecord(2000, "b2", "STG"))
+				})
+			})
+
 			When("the limit is greater than the number of log lines", func() {
 				BeforeEach(func() {
 					message.Limit = tools.PtrTo[int64](1000)
@@ -271,6 +393,109 @@ var _ = Describe("LogRepository", func() {
 				Expect(logRecords[3]).To(matchLogRecord(1000, "b1", "STG"))
 			})
 		})
+
+		Describe("StreamAppLogs", func() {
+			var (
+				streamCtx    context.Context
+				cancelStream context.CancelFunc
+				appLogs      *io.PipeWriter
+				records      <-chan repositories.LogRecord
+				streamBuild  *repositories.BuildRecord
+			)
+
+			BeforeEach(func() {
+				streamCtx, cancelStream = context.WithCancel(ctx)
+				DeferCleanup(cancelStream)
+				streamBuild = nil
+			})
+
+			JustBeforeEach(func() {
+				var appLogsReader *io.PipeReader
+				appLogsReader, appLogs = io.Pipe()
+				DeferCleanup(appLogs.Close)
+
+				logStreamer.Stub = func(_ context.Context, _ kubernetes.Interface, pod corev1.Pod, _ corev1.PodLogOptions) (io.ReadCloser, error) {
+					if pod.Name == appPod.Name {
+						return appLogsReader, nil
+					}
+					return readerFor(map[time.Time]string{time.Unix(0, 3000): "new-pod-log"}), nil
+				}
+				callsBeforeStream := logStreamer.CallCount()
+
+				var streamErr error
+				records, streamErr = logRepo.StreamAppLogs(streamCtx, authInfo, repositories.StreamLogsMessage{
+					App:       message.App,
+					Build:     streamBuild,
+					StartTime: tools.PtrTo[int64](1000),
+				})
+				Expect(streamErr).NotTo(HaveOccurred())
+
+				Eventually(logStreamer.CallCount).Should(BeNumerically(">", callsBeforeStream))
+			})
+
+			It("follows the app pod container logs", func() {
+				_, _, actualPod, actualLogOptions := logStreamer.ArgsForCall(logStreamer.CallCount() - 1)
+				Expect(actualPod.Name).To(Equal(appPod.Name))
+				Expect(actualLogOptions).To(Equal(corev1.PodLogOptions{
+					Container:  "app-container",
+					Follow:     true,
+					SinceTime:  tools.PtrTo(metav1.NewTime(time.Unix(0, 1000))),
+					Timestamps: true,
+				}))
+			})
+
+			It("pushes log lines later than the start time as they are written", func() {
+				fmt.Fprintf(appLogs, "%s a-old\n", time.Unix(0, 500).Format(time.RFC3339Nano))
+				fmt.Fprintf(appLogs, "%s a-live\n", time.Unix(0, 2000).Format(time.RFC3339Nano))
+				Eventually(records).Should(Receive(matchLogRecord(2000, "a-live", "APP")))
+			})
+
+			When("the app build is being staged", func() {
+				BeforeEach(func() {
+					streamBuild = &message.Build
+				})
+
+				It("follows the staging logs too", func() {
+					Eventually(records).Should(Receive(matchLogRecord(3000, "new-pod-log", "STG")))
+				})
+			})
+
+			When("an app instance is started after the stream is opened", func() {
+				JustBeforeEach(func() {
+					newPod := &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      uuid.NewString(),
+							Labels:    appPod.Labels,
+						},
+						Spec: appPod.Spec,
+					}
+					Expect(k8sClient.Create(ctx, newPod)).To(Succeed())
+					Expect(k8s.Patch(ctx, k8sClient, newPod, func() {
+						newPod.Status = corev1.PodStatus{
+							ContainerStatuses: []corev1.ContainerStatus{{
+								Name: "app-container",
+							}},
+						}
+					})).To(Succeed())
+				})
+
+				It("follows the new instance logs too", func() {
+					Eventually(records).WithTimeout(5 * time.Second).Should(Receive(matchLogRecord(3000, "new-pod-log", "APP")))
+				})
+			})
+
+			When("the stream context is done", func() {
+				JustBeforeEach(func() {
+					cancelStream()
+					Expect(appLogs.Close()).To(Succeed())
+				})
+
+				It("closes the log records channel", func() {
+					Eventually(records).Should(BeClosed())
+				})
+			})
+		})
 	})
 })
 
@@ -284,11 +509,11 @@ func readerFor(logs map[time.Time]string) io.ReadCloser {
 }
 
 func matchLogRecord(timestamp int64, message string, tag string) types.GomegaMatcher {
-	return Equal(repositories.LogRecord{
-		Message:   message,
-		Timestamp: timestamp,
-		Tags: map[string]string{
+	return MatchFields(IgnoreExtras, Fields{
+		"Message":   Equal(message),
+		"Timestamp": Equal(timestamp),
+		"Tags": Equal(map[string]string{
 			"source_type": tag,
-		},
+		}),
 	})
 }
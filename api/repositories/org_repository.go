@@ -1,14 +1,18 @@
 package repositories
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/compare"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -32,8 +36,9 @@ type CreateOrgMessage struct {
 }
 
 type ListOrgsMessage struct {
-	Names []string
-	GUIDs []string
+	Names   []string
+	GUIDs   []string
+	OrderBy string
 }
 
 func (m *ListOrgsMessage) matches(org korifiv1alpha1.CFOrg) bool {
@@ -52,14 +57,19 @@ type PatchOrgMetadataMessage struct {
 }
 
 type OrgRecord struct {
-	Name        string
-	GUID        string
-	Suspended   bool
-	Labels      map[string]string
-	Annotations map[string]string
-	CreatedAt   time.Time
-	UpdatedAt   *time.Time
-	DeletedAt   *time.Time
+	Name            string
+	GUID            string
+	Suspended       bool
+	ResourceVersion string
+	Labels          map[string]string
+	Annotations     map[string]string
+	CreatedAt       time.Time
+	UpdatedAt       *time.Time
+	DeletedAt       *time.Time
+}
+
+func (r OrgRecord) Relationships() map[string]string {
+	return map[string]string{}
 }
 
 type OrgRepo struct {
@@ -68,6 +78,50 @@ type OrgRepo struct {
 	userClientFactory authorization.UserClientFactory
 	nsPerms           *authorization.NamespacePermissions
 	conditionAwaiter  Awaiter[*korifiv1alpha1.CFOrg]
+	sorter            OrgSorter
+}
+
+//counterfeiter:generate -o fake -fake-name OrgSorter . OrgSorter
+type OrgSorter interface {
+	Sort(records []OrgRecord, order string) []OrgRecord
+}
+
+type orgSorter struct {
+	sorter *compare.Sorter[OrgRecord]
+}
+
+func NewOrgSorter() *orgSorter {
+	return &orgSorter{
+		sorter: compare.NewSorter(OrgComparator),
+	}
+}
+
+func (s *orgSorter) Sort(records []OrgRecord, order string) []OrgRecord {
+	return s.sorter.Sort(records, order)
+}
+
+func OrgComparator(fieldName string) func(OrgRecord, OrgRecord) int {
+	return func(o1, o2 OrgRecord) int {
+		return cmp.Or(compareOrgs(fieldName, o1, o2), strings.Compare(o1.GUID, o2.GUID))
+	}
+}
+
+func compareOrgs(fieldName string, o1, o2 OrgRecord) int {
+	switch fieldName {
+	case "", "created_at":
+		return tools.CompareTimePtr(&o1.CreatedAt, &o2.CreatedAt)
+	case "-created_at":
+		return tools.CompareTimePtr(&o2.CreatedAt, &o1.CreatedAt)
+	case "updated_at":
+		return tools.CompareTimePtr(o1.UpdatedAt, o2.UpdatedAt)
+	case "-updated_at":
+		return tools.CompareTimePtr(o2.UpdatedAt, o1.UpdatedAt)
+	case "name":
+		return strings.Compare(o1.Name, o2.Name)
+	case "-name":
+		return strings.Compare(o2.Name, o1.Name)
+	}
+	return 0
 }
 
 func NewOrgRepo(
@@ -76,6 +130,7 @@ func NewOrgRepo(
 	userClientFactory authorization.UserClientFactory,
 	nsPerms *authorization.NamespacePermissions,
 	conditionAwaiter Awaiter[*korifiv1alpha1.CFOrg],
+	sorter OrgSorter,
 ) *OrgRepo {
 	return &OrgRepo{
 		rootNamespace:     rootNamespace,
@@ -83,6 +138,7 @@ func NewOrgRepo(
 		userClientFactory: userClientFactory,
 		nsPerms:           nsPerms,
 		conditionAwaiter:  conditionAwaiter,
+		sorter:            sorter,
 	}
 }
 
@@ -106,6 +162,12 @@ func (r *OrgRepo) CreateOrg(ctx context.Context, info authorization.Info, messag
 
 	err = userClient.Create(ctx, cfOrg)
 	if err != nil {
+		if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+			if validationError.Type == validation.DuplicateNameErrorType {
+				return OrgRecord{}, apierrors.NewUniquenessError(err, validationError.GetMessage())
+			}
+		}
+
 		return OrgRecord{}, fmt.Errorf("failed to create cf org: %w", apierrors.FromK8sError(err, OrgResourceType))
 	}
 
@@ -137,7 +199,7 @@ func (r *OrgRepo) ListOrgs(ctx context.Context, info authorization.Info, message
 	filteredOrgs := itx.FromSlice(cfOrgList.Items).Filter(func(org korifiv1alpha1.CFOrg) bool {
 		return authorizedNamespaces[org.Name] && message.matches(org)
 	})
-	return slices.Collect(it.Map(filteredOrgs, cfOrgToOrgRecord)), nil
+	return r.sorter.Sort(slices.Collect(it.Map(filteredOrgs, cfOrgToOrgRecord)), message.OrderBy), nil
 }
 
 func (r *OrgRepo) GetOrg(ctx context.Context, info authorization.Info, orgGUID string) (OrgRecord, error) {
@@ -214,13 +276,14 @@ func (r *OrgRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info,
 
 func cfOrgToOrgRecord(cfOrg korifiv1alpha1.CFOrg) OrgRecord {
 	return OrgRecord{
-		GUID:        cfOrg.Name,
-		Name:        cfOrg.Spec.DisplayName,
-		Suspended:   false,
-		Labels:      cfOrg.Labels,
-		Annotations: cfOrg.Annotations,
-		CreatedAt:   cfOrg.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(&cfOrg),
-		DeletedAt:   golangTime(cfOrg.DeletionTimestamp),
+		GUID:            cfOrg.Name,
+		Name:            cfOrg.Spec.DisplayName,
+		Suspended:       false,
+		ResourceVersion: cfOrg.ResourceVersion,
+		Labels:          cfOrg.Labels,
+		Annotations:     cfOrg.Annotations,
+		CreatedAt:       cfOrg.CreationTimestamp.Time,
+		UpdatedAt:       getLastUpdatedTime(&cfOrg),
+		DeletedAt:       golangTime(cfOrg.DeletionTimestamp),
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
@@ -17,6 +18,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	gomega_types "github.com/onsi/gomega/types"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +33,7 @@ var _ = Describe("OrgRepository", func() {
 			korifiv1alpha1.CFOrgList,
 			*korifiv1alpha1.CFOrgList,
 		]
+		sorter  *fake.OrgSorter
 		orgRepo *repositories.OrgRepo
 	)
 
@@ -41,7 +44,11 @@ var _ = Describe("OrgRepository", func() {
 			korifiv1alpha1.CFOrgList,
 			*korifiv1alpha1.CFOrgList,
 		]{}
-		orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, conditionAwaiter)
+		sorter = new(fake.OrgSorter)
+		sorter.SortStub = func(records []repositories.OrgRecord, _ string) []repositories.OrgRecord {
+			return records
+		}
+		orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, conditionAwaiter, sorter)
 	})
 
 	Describe("CreateOrg", func() {
@@ -198,6 +205,19 @@ var _ = Describe("OrgRepository", func() {
 			))
 		})
 
+		It("sorts the orgs", func() {
+			_, err := orgRepo.ListOrgs(ctx, authInfo, repositories.ListOrgsMessage{OrderBy: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sorter.SortCallCount()).To(Equal(1))
+			sortedOrgs, field := sorter.SortArgsForCall(0)
+			Expect(field).To(Equal("foo"))
+			Expect(sortedOrgs).To(ContainElements(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfOrg1.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfOrg3.Name)}),
+			))
+		})
+
 		When("the org is not ready", func() {
 			BeforeEach(func() {
 				meta.SetStatusCondition(&(cfOrg1.Status.Conditions), metav1.Condition{
@@ -677,3 +697,51 @@ var _ = Describe("OrgRepository", func() {
 		})
 	})
 })
+
+var _ = DescribeTable("OrgSorter",
+	func(o1, o2 repositories.OrgRecord, field string, match gomega_types.GomegaMatcher) {
+		Expect(repositories.OrgComparator(field)(o1, o2)).To(match)
+	},
+	Entry("default sorting",
+		repositories.OrgRecord{CreatedAt: time.UnixMilli(1)},
+		repositories.OrgRecord{CreatedAt: time.UnixMilli(2)},
+		"",
+		BeNumerically("<", 0),
+	),
+	Entry("name",
+		repositories.OrgRecord{Name: "first-org"},
+		repositories.OrgRecord{Name: "second-org"},
+		"name",
+		BeNumerically("<", 0),
+	),
+	Entry("-name",
+		repositories.OrgRecord{Name: "first-org"},
+		repositories.OrgRecord{Name: "second-org"},
+		"-name",
+		BeNumerically(">", 0),
+	),
+	Entry("created_at",
+		repositories.OrgRecord{CreatedAt: time.UnixMilli(1)},
+		repositories.OrgRecord{CreatedAt: time.UnixMilli(2)},
+		"created_at",
+		BeNumerically("<", 0),
+	),
+	Entry("-created_at",
+		repositories.OrgRecord{CreatedAt: time.UnixMilli(1)},
+		repositories.OrgRecord{CreatedAt: time.UnixMilli(2)},
+		"-created_at",
+		BeNumerically(">", 0),
+	),
+	Entry("updated_at",
+		repositories.OrgRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(1))},
+		repositories.OrgRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(2))},
+		"updated_at",
+		BeNumerically("<", 0),
+	),
+	Entry("equal keys fall back to the guid",
+		repositories.OrgRecord{Name: "org", GUID: "a-guid"},
+		repositories.OrgRecord{Name: "org", GUID: "b-guid"},
+		"name",
+		BeNumerically("<", 0),
+	),
+)
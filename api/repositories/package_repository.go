@@ -3,7 +3,9 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"maps"
 	"slices"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
@@ -14,6 +16,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/dockercfg"
 	"code.cloudfoundry.org/korifi/tools/k8s"
+	"code.cloudfoundry.org/korifi/tools/registry"
 
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/BooleanCat/go-functional/v2/it/itx"
@@ -33,54 +36,71 @@ const (
 
 	PackageStateAwaitingUpload = "AWAITING_UPLOAD"
 	PackageStateReady          = "READY"
+	PackageStateCopying        = "COPYING"
+	PackageStateFailed         = "FAILED"
+
+	// PackageCopySourceAnnotation marks packages created as a copy of another
+	// package. Such packages are COPYING until the package controller has
+	// copied the source onto them.
+	PackageCopySourceAnnotation = korifiv1alpha1.CFPackageCopySourceGUIDAnnotation
+
+	// PackageRepositoryAnnotation records the repository the bits of a package
+	// are uploaded to. It is resolved once when the package is created, so
+	// that changing the repository path template does not affect it.
+	PackageRepositoryAnnotation = korifiv1alpha1.CFPackageRepositoryAnnotation
 
 	PackageResourceType = "Package"
 )
 
-var packageTypeToLifecycleType = map[korifiv1alpha1.PackageType]korifiv1alpha1.LifecycleType{
+// PackageTypeToLifecycleType maps package types to the lifecycle of the apps
+// that can stage them
+var PackageTypeToLifecycleType = map[korifiv1alpha1.PackageType]korifiv1alpha1.LifecycleType{
 	"bits":   "buildpack",
 	"docker": "docker",
 }
 
 type PackageRepo struct {
-	userClientFactory  authorization.UserClientFactory
-	namespaceRetriever NamespaceRetriever
-	repositoryCreator  RepositoryCreator
-	repositoryPrefix   string
-	awaiter            Awaiter[*korifiv1alpha1.CFPackage]
-	sorter             PackageSorter
+	userClientFactory    authorization.UserClientFactory
+	namespaceRetriever   NamespaceRetriever
+	repositoryCreator    RepositoryCreator
+	repositoryRefBuilder *registry.RepositoryRefBuilder
+	awaiter              Awaiter[*korifiv1alpha1.CFPackage]
+	sorter               PackageSorter
+	// spaces never move between orgs, so their org GUIDs can be cached
+	spaceOrgGUIDs sync.Map
 }
 
 func NewPackageRepo(
 	userClientFactory authorization.UserClientFactory,
 	namespaceRetriever NamespaceRetriever,
 	repositoryCreator RepositoryCreator,
-	repositoryPrefix string,
+	repositoryRefBuilder *registry.RepositoryRefBuilder,
 	awaiter Awaiter[*korifiv1alpha1.CFPackage],
 	sorter PackageSorter,
 ) *PackageRepo {
 	return &PackageRepo{
-		userClientFactory:  userClientFactory,
-		namespaceRetriever: namespaceRetriever,
-		repositoryCreator:  repositoryCreator,
-		repositoryPrefix:   repositoryPrefix,
-		awaiter:            awaiter,
-		sorter:             sorter,
+		userClientFactory:    userClientFactory,
+		namespaceRetriever:   namespaceRetriever,
+		repositoryCreator:    repositoryCreator,
+		repositoryRefBuilder: repositoryRefBuilder,
+		awaiter:              awaiter,
+		sorter:               sorter,
 	}
 }
 
 type PackageRecord struct {
-	GUID        string
-	UID         types.UID
-	Type        string
-	AppGUID     string
-	SpaceGUID   string
-	State       string
-	CreatedAt   time.Time
-	UpdatedAt   *time.Time
-	Labels      map[string]string
-	Annotations map[string]string
-	ImageRef    string
+	GUID            string
+	UID             types.UID
+	ResourceVersion string
+	Type            string
+	AppGUID         string
+	SpaceGUID       string
+	State           string
+	CreatedAt       time.Time
+	UpdatedAt       *time.Time
+	Labels          map[string]string
+	Annotations     map[string]string
+	ImageRef        string
 }
 
 func (r PackageRecord) Relationships() map[string]string {
@@ -128,12 +148,14 @@ type ListPackagesMessage struct {
 	GUIDs    []string
 	AppGUIDs []string
 	States   []string
+	Types    []string
 	OrderBy  string
 }
 
 func (m *ListPackagesMessage) matches(p korifiv1alpha1.CFPackage) bool {
 	return tools.EmptyOrContains(m.GUIDs, p.Name) &&
 		tools.EmptyOrContains(m.AppGUIDs, p.Spec.AppRef.Name) &&
+		tools.EmptyOrContains(m.Types, string(p.Spec.Type)) &&
 		m.matchesState(p)
 }
 
@@ -154,11 +176,15 @@ func (m *ListPackagesMessage) matchesState(p korifiv1alpha1.CFPackage) bool {
 }
 
 type CreatePackageMessage struct {
-	Type      string
-	AppGUID   string
-	SpaceGUID string
-	Metadata  Metadata
-	Data      *PackageData
+	Type              string
+	AppGUID           string
+	SpaceGUID         string
+	Metadata          Metadata
+	Data              *PackageData
+	SourcePackageGUID string
+	// SourcePackageSpaceGUID is the space of the package the new package is a
+	// copy of
+	SourcePackageSpaceGUID string
 }
 
 type PackageData struct {
@@ -191,6 +217,12 @@ func (message CreatePackageMessage) toCFPackage() *korifiv1alpha1.CFPackage {
 		pkg.Spec.Source.Registry.Image = message.Data.Image
 	}
 
+	if message.SourcePackageGUID != "" {
+		pkg.Annotations = maps.Clone(pkg.Annotations)
+		pkg.Annotations = tools.SetMapValue(pkg.Annotations, PackageCopySourceAnnotation, message.SourcePackageGUID)
+		pkg.Annotations = tools.SetMapValue(pkg.Annotations, korifiv1alpha1.CFPackageCopySourceNamespaceAnnotation, message.SourcePackageSpaceGUID)
+	}
+
 	return pkg
 }
 
@@ -231,17 +263,26 @@ func (r *PackageRepo) CreatePackage(ctx context.Context, authInfo authorization.
 	}
 
 	cfPackage := message.toCFPackage()
-	err = userClient.Create(ctx, cfPackage)
-	if err != nil {
-		return PackageRecord{}, apierrors.FromK8sError(err, PackageResourceType)
+	if cfPackage.Spec.Type == "bits" {
+		var repositoryRef string
+		repositoryRef, err = r.buildPackageRepositoryRef(ctx, cfPackage.Namespace, cfPackage.Spec.AppRef.Name)
+		if err != nil {
+			return PackageRecord{}, err
+		}
+		cfPackage.Annotations = tools.SetMapValue(maps.Clone(cfPackage.Annotations), PackageRepositoryAnnotation, repositoryRef)
 	}
 
-	if packageTypeToLifecycleType[cfPackage.Spec.Type] != cfApp.Spec.Lifecycle.Type {
+	if PackageTypeToLifecycleType[cfPackage.Spec.Type] != cfApp.Spec.Lifecycle.Type {
 		return PackageRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("cannot create %s package for a %s app", cfPackage.Spec.Type, cfApp.Spec.Lifecycle.Type))
 	}
 
+	err = userClient.Create(ctx, cfPackage)
+	if err != nil {
+		return PackageRecord{}, apierrors.FromK8sError(err, PackageResourceType)
+	}
+
 	if cfPackage.Spec.Type == "bits" {
-		err = r.repositoryCreator.CreateRepository(ctx, r.repositoryRef(*cfPackage))
+		err = r.repositoryCreator.CreateRepository(ctx, cfPackage.Annotations[PackageRepositoryAnnotation])
 		if err != nil {
 			return PackageRecord{}, fmt.Errorf("failed to create package repository: %w", err)
 		}
@@ -259,7 +300,7 @@ func (r *PackageRepo) CreatePackage(ctx context.Context, authInfo authorization.
 		return PackageRecord{}, fmt.Errorf("failed waiting for Initialized condition: %w", err)
 	}
 
-	return r.cfPackageToPackageRecord(*cfPackage), nil
+	return r.cfPackageToPackageRecord(ctx, *cfPackage)
 }
 
 func isPrivateDockerImage(message CreatePackageMessage) bool {
@@ -332,7 +373,7 @@ func (r *PackageRepo) UpdatePackage(ctx context.Context, authInfo authorization.
 		return PackageRecord{}, fmt.Errorf("failed to patch package metadata: %w", apierrors.FromK8sError(err, PackageResourceType))
 	}
 
-	return r.cfPackageToPackageRecord(*cfPackage), nil
+	return r.cfPackageToPackageRecord(ctx, *cfPackage)
 }
 
 func (r *PackageRepo) GetPackage(ctx context.Context, authInfo authorization.Info, guid string) (PackageRecord, error) {
@@ -351,7 +392,7 @@ func (r *PackageRepo) GetPackage(ctx context.Context, authInfo authorization.Inf
 		return PackageRecord{}, fmt.Errorf("failed to get package %q: %w", guid, apierrors.FromK8sError(err, PackageResourceType))
 	}
 
-	return r.cfPackageToPackageRecord(*cfPackage), nil
+	return r.cfPackageToPackageRecord(ctx, *cfPackage)
 }
 
 func (r *PackageRepo) ListPackages(ctx context.Context, authInfo authorization.Info, message ListPackagesMessage) ([]PackageRecord, error) {
@@ -366,8 +407,16 @@ func (r *PackageRepo) ListPackages(ctx context.Context, authInfo authorization.I
 		return []PackageRecord{}, fmt.Errorf("failed to list packages: %w", apierrors.FromK8sError(err, PackageResourceType))
 	}
 
-	filteredPackages := itx.FromSlice(packageList.Items).Filter(message.matches)
-	return r.sorter.Sort(slices.Collect(it.Map(filteredPackages, r.cfPackageToPackageRecord)), message.OrderBy), nil
+	packageRecords := []PackageRecord{}
+	for cfPackage := range itx.FromSlice(packageList.Items).Filter(message.matches) {
+		packageRecord, err := r.cfPackageToPackageRecord(ctx, cfPackage)
+		if err != nil {
+			return []PackageRecord{}, err
+		}
+		packageRecords = append(packageRecords, packageRecord)
+	}
+
+	return r.sorter.Sort(packageRecords, message.OrderBy), nil
 }
 
 func (r *PackageRepo) UpdatePackageSource(ctx context.Context, authInfo authorization.Info, message UpdatePackageSourceMessage) (PackageRecord, error) {
@@ -397,34 +446,82 @@ func (r *PackageRepo) UpdatePackageSource(ctx context.Context, authInfo authoriz
 		return PackageRecord{}, fmt.Errorf("failed awaiting Ready status condition: %w", err)
 	}
 
-	record := r.cfPackageToPackageRecord(*cfPackage)
-	return record, nil
+	return r.cfPackageToPackageRecord(ctx, *cfPackage)
 }
 
-func (r *PackageRepo) cfPackageToPackageRecord(cfPackage korifiv1alpha1.CFPackage) PackageRecord {
+func (r *PackageRepo) cfPackageToPackageRecord(ctx context.Context, cfPackage korifiv1alpha1.CFPackage) (PackageRecord, error) {
 	state := PackageStateAwaitingUpload
-	if meta.IsStatusConditionTrue(cfPackage.Status.Conditions, korifiv1alpha1.StatusConditionReady) {
+	readyCondition := meta.FindStatusCondition(cfPackage.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+	if readyCondition != nil && readyCondition.Status == metav1.ConditionTrue {
 		state = PackageStateReady
+	} else if readyCondition != nil && readyCondition.Reason == korifiv1alpha1.CFPackageCopyFailedReason {
+		state = PackageStateFailed
+	} else if _, isCopy := cfPackage.Annotations[PackageCopySourceAnnotation]; isCopy {
+		state = PackageStateCopying
 	}
-	return PackageRecord{
-		GUID:        cfPackage.Name,
-		UID:         cfPackage.UID,
-		SpaceGUID:   cfPackage.Namespace,
-		Type:        string(cfPackage.Spec.Type),
-		AppGUID:     cfPackage.Spec.AppRef.Name,
-		State:       state,
-		CreatedAt:   cfPackage.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(&cfPackage),
-		Labels:      cfPackage.Labels,
-		Annotations: cfPackage.Annotations,
-		ImageRef:    r.repositoryRef(cfPackage),
+
+	imageRef, err := r.repositoryRef(ctx, cfPackage)
+	if err != nil {
+		return PackageRecord{}, err
 	}
+
+	return PackageRecord{
+		GUID:            cfPackage.Name,
+		UID:             cfPackage.UID,
+		ResourceVersion: cfPackage.ResourceVersion,
+		SpaceGUID:       cfPackage.Namespace,
+		Type:            string(cfPackage.Spec.Type),
+		AppGUID:         cfPackage.Spec.AppRef.Name,
+		State:           state,
+		CreatedAt:       cfPackage.CreationTimestamp.Time,
+		UpdatedAt:       getLastUpdatedTime(&cfPackage),
+		Labels:          cfPackage.Labels,
+		Annotations:     cfPackage.Annotations,
+		ImageRef:        imageRef,
+	}, nil
 }
 
-func (r *PackageRepo) repositoryRef(cfPackage korifiv1alpha1.CFPackage) string {
+func (r *PackageRepo) repositoryRef(ctx context.Context, cfPackage korifiv1alpha1.CFPackage) (string, error) {
 	if cfPackage.Spec.Type == "docker" {
-		return cfPackage.Spec.Source.Registry.Image
+		return cfPackage.Spec.Source.Registry.Image, nil
+	}
+
+	if repositoryRef, ok := cfPackage.Annotations[PackageRepositoryAnnotation]; ok {
+		return repositoryRef, nil
+	}
+
+	// packages created before the repository was recorded on them
+	return r.buildPackageRepositoryRef(ctx, cfPackage.Namespace, cfPackage.Spec.AppRef.Name)
+}
+
+func (r *PackageRepo) buildPackageRepositoryRef(ctx context.Context, spaceGUID, appGUID string) (string, error) {
+	orgGUID, err := r.orgGUIDForSpace(ctx, spaceGUID)
+	if err != nil {
+		return "", err
+	}
+
+	repositoryRef, err := r.repositoryRefBuilder.Build(registry.RepositoryPathValues{
+		OrgGUID:   orgGUID,
+		SpaceGUID: spaceGUID,
+		AppGUID:   appGUID,
+	}, "-packages")
+	if err != nil {
+		return "", fmt.Errorf("failed to build the package repository: %w", err)
+	}
+
+	return repositoryRef, nil
+}
+
+func (r *PackageRepo) orgGUIDForSpace(ctx context.Context, spaceGUID string) (string, error) {
+	if orgGUID, ok := r.spaceOrgGUIDs.Load(spaceGUID); ok {
+		return orgGUID.(string), nil
+	}
+
+	orgGUID, err := r.namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the org of space %q: %w", spaceGUID, err)
 	}
 
-	return r.repositoryPrefix + cfPackage.Spec.AppRef.Name + "-packages"
+	r.spaceOrgGUIDs.Store(spaceGUID, orgGUID)
+	return orgGUID, nil
 }
@@ -14,6 +14,7 @@ import (
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
+	"code.cloudfoundry.org/korifi/tools/registry"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
@@ -63,7 +64,7 @@ var _ = Describe("PackageRepository", func() {
 			}),
 			namespaceRetriever,
 			repoCreator,
-			"container.registry/foo/my/prefix-",
+			newRepositoryRefBuilder(""),
 			conditionAwaiter,
 			sorter,
 		)
@@ -180,6 +181,10 @@ var _ = Describe("PackageRepository", func() {
 
 					Expect(createdCFPackage.Labels).To(HaveKeyWithValue("bob", "foo"))
 					Expect(createdCFPackage.Annotations).To(HaveKeyWithValue("jim", "bar"))
+					Expect(createdCFPackage.Annotations).To(HaveKeyWithValue(
+						repositories.PackageRepositoryAnnotation,
+						fmt.Sprintf("container.registry/foo/my/prefix-%s-packages", appGUID),
+					))
 				})
 
 				It("awaits the Initialized status", func() {
@@ -196,6 +201,52 @@ var _ = Describe("PackageRepository", func() {
 					Expect(repoName).To(Equal("container.registry/foo/my/prefix-" + appGUID + "-packages"))
 				})
 
+				When("the package is a copy of another package", func() {
+					BeforeEach(func() {
+						packageCreate.SourcePackageGUID = "source-package-guid"
+						packageCreate.SourcePackageSpaceGUID = "source-space-guid"
+					})
+
+					It("creates a COPYING package annotated with its source package", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+						Expect(createdPackage.State).To(Equal("COPYING"))
+						Expect(createdPackage.Annotations).To(SatisfyAll(
+							HaveKeyWithValue("jim", "bar"),
+							HaveKeyWithValue(repositories.PackageCopySourceAnnotation, "source-package-guid"),
+							HaveKeyWithValue(korifiv1alpha1.CFPackageCopySourceNamespaceAnnotation, "source-space-guid"),
+						))
+					})
+
+					It("does not modify the annotations on the message", func() {
+						Expect(packageCreate.Metadata.Annotations).NotTo(HaveKey(repositories.PackageCopySourceAnnotation))
+					})
+				})
+
+				When("a repository path template is configured", func() {
+					BeforeEach(func() {
+						packageRepo = repositories.NewPackageRepo(
+							userClientFactory.WithWrappingFunc(func(client client.WithWatch) client.WithWatch {
+								return authorization.NewSpaceFilteringClient(client, k8sClient, nsPerms)
+							}),
+							namespaceRetriever,
+							repoCreator,
+							newRepositoryRefBuilder("{{.OrgGUID}}/{{.SpaceGUID}}/{{.AppGUID}}"),
+							conditionAwaiter,
+							sorter,
+						)
+					})
+
+					It("builds the image ref from the template", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+						expectedRef := fmt.Sprintf("container.registry/foo/my/prefix-%s/%s/%s-packages", org.Name, space.Name, appGUID)
+						Expect(createdPackage.ImageRef).To(Equal(expectedRef))
+
+						Expect(repoCreator.CreateRepositoryCallCount()).To(Equal(1))
+						_, repoName := repoCreator.CreateRepositoryArgsForCall(0)
+						Expect(repoName).To(Equal(expectedRef))
+					})
+				})
+
 				When("the package does not become initialized", func() {
 					BeforeEach(func() {
 						conditionAwaiter.AwaitConditionReturns(&korifiv1alpha1.CFPackage{}, errors.New("time-out-err"))
@@ -226,6 +277,12 @@ var _ = Describe("PackageRepository", func() {
 					It("returns an error", func() {
 						Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
 					})
+
+					It("does not create the package", func() {
+						packageList := &korifiv1alpha1.CFPackageList{}
+						Expect(k8sClient.List(ctx, packageList, client.InNamespace(space.Name))).To(Succeed())
+						Expect(packageList.Items).To(BeEmpty())
+					})
 				})
 			})
 
@@ -395,6 +452,7 @@ var _ = Describe("PackageRepository", func() {
 				Expect(packageRecord.Type).To(Equal("bits"))
 				Expect(packageRecord.AppGUID).To(Equal(appGUID))
 				Expect(packageRecord.State).To(Equal("AWAITING_UPLOAD"))
+				Expect(packageRecord.ResourceVersion).NotTo(BeEmpty())
 				Expect(packageRecord.Labels).To(HaveKeyWithValue("foo", "the-original-value"))
 				Expect(packageRecord.Annotations).To(HaveKeyWithValue("bar", "the-original-value"))
 				Expect(packageRecord.ImageRef).To(Equal(fmt.Sprintf("container.registry/foo/my/prefix-%s-packages", appGUID)))
@@ -402,11 +460,53 @@ var _ = Describe("PackageRepository", func() {
 				Expect(packageRecord.UpdatedAt).To(PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
 			})
 
+			When("the package repository has been recorded on the package", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfPackage, func() {
+						cfPackage.Annotations[repositories.PackageRepositoryAnnotation] = "container.registry/foo/my/old-path-packages"
+					})).To(Succeed())
+				})
+
+				It("uses the recorded repository rather than the current path template", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(packageRecord.ImageRef).To(Equal("container.registry/foo/my/old-path-packages"))
+				})
+			})
+
 			Describe("State field", func() {
 				It("equals AWAITING_UPLOAD by default", func() {
 					Expect(packageRecord.State).To(Equal("AWAITING_UPLOAD"))
 				})
 
+				When("the package is a copy of another package", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, cfPackage, func() {
+							cfPackage.Annotations[repositories.PackageCopySourceAnnotation] = "source-package-guid"
+						})).To(Succeed())
+					})
+
+					It("equals COPYING", func() {
+						Expect(packageRecord.State).To(Equal("COPYING"))
+					})
+
+					When("copying the source failed", func() {
+						BeforeEach(func() {
+							Expect(k8s.Patch(ctx, k8sClient, cfPackage, func() {
+								meta.SetStatusCondition(&cfPackage.Status.Conditions, metav1.Condition{
+									Type:               korifiv1alpha1.StatusConditionReady,
+									Status:             metav1.ConditionFalse,
+									Reason:             korifiv1alpha1.CFPackageCopyFailedReason,
+									ObservedGeneration: cfPackage.Generation,
+								})
+							})).To(Succeed())
+						})
+
+						It("equals FAILED", func() {
+							Expect(packageRecord.State).To(Equal("FAILED"))
+						})
+					})
+				})
+
 				When("the package is ready", func() {
 					BeforeEach(func() {
 						Expect(k8s.Patch(ctx, k8sClient, cfPackage, func() {
@@ -609,6 +709,20 @@ var _ = Describe("PackageRepository", func() {
 					})
 				})
 
+				When("the types filter is provided", func() {
+					BeforeEach(func() {
+						listMessage = repositories.ListPackagesMessage{Types: []string{"docker"}}
+					})
+
+					It("filters the packages", func() {
+						Expect(packageList).NotTo(ContainElement(
+							MatchFields(IgnoreExtras, Fields{
+								"Type": Equal("bits"),
+							}),
+						))
+					})
+				})
+
 				When("the state filter is provided", func() {
 					When("filtering by State=READY", func() {
 						BeforeEach(func() {
@@ -915,3 +1029,9 @@ var _ = DescribeTable("PackageSorter",
 		BeNumerically(">", 0),
 	),
 )
+
+func newRepositoryRefBuilder(pathTemplate string) *registry.RepositoryRefBuilder {
+	refBuilder, err := registry.NewRepositoryRefBuilder("container.registry/foo/my/prefix-", pathTemplate)
+	Expect(err).NotTo(HaveOccurred())
+	return refBuilder
+}
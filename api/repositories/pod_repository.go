@@ -3,24 +3,61 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httputil"
 	"strings"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 
 	"github.com/BooleanCat/go-functional/v2/it/itx"
+	authv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
+	k8sclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// appContainerName is the name of the container running the app process in
+// the instance pods created by the workload runners
+const appContainerName = "application"
+
+// execRequestHeaders are the only headers of the user request forwarded to
+// the exec endpoint. They negotiate the streaming protocol upgrade, any other
+// header (credentials, impersonation) must not reach the API server along
+// with the API credentials.
+var execRequestHeaders = []string{
+	"Connection",
+	"Upgrade",
+	"X-Stream-Protocol-Version",
+	"Sec-Websocket-Key",
+	"Sec-Websocket-Version",
+	"Sec-Websocket-Protocol",
+	"Sec-Websocket-Extensions",
+}
+
 type PodRepo struct {
 	userClientFactory authorization.UserClientFactory
+	privilegedConfig  *rest.Config
 }
 
-func NewPodRepo(userClientFactory authorization.UserClientFactory) *PodRepo {
+type ExecInstanceMessage struct {
+	AppRevision string
+	Process     ProcessRecord
+	InstanceID  string
+	Command     []string
+	TTY         bool
+}
+
+func NewPodRepo(
+	userClientFactory authorization.UserClientFactory,
+	privilegedConfig *rest.Config,
+) *PodRepo {
 	return &PodRepo{
 		userClientFactory: userClientFactory,
+		privilegedConfig:  privilegedConfig,
 	}
 }
 
@@ -30,37 +67,134 @@ func (r *PodRepo) DeletePod(ctx context.Context, authInfo authorization.Info, ap
 		return fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	pod, err := findInstancePod(ctx, userClient, appRevision, process, instanceID)
+	if err != nil {
+		return err
+	}
+
+	err = userClient.Delete(ctx, &pod)
+	if err != nil {
+		return fmt.Errorf("failed to 'delete' pod: %w", apierrors.FromK8sError(err, PodResourceType))
+	}
+	return nil
+}
+
+// ExecInstance returns a handler that proxies an exec session into the app
+// container of a process instance. The session is opened with the privileged
+// config, which is only allowed to exec into pods of space namespaces, once
+// the user has been authorised to exec into the pod. The proxied request is
+// expected to be a streaming protocol upgrade (SPDY or websocket) as done by
+// Kubernetes exec clients.
+func (r *PodRepo) ExecInstance(ctx context.Context, authInfo authorization.Info, message ExecInstanceMessage) (http.Handler, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	pod, err := findInstancePod(ctx, userClient, message.AppRevision, message.Process, message.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = authorizeExec(ctx, userClient, pod); err != nil {
+		return nil, err
+	}
+
+	clientset, err := k8sclient.NewForConfig(r.privilegedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build privileged clientset: %w", err)
+	}
+
+	transport, err := rest.TransportFor(r.privilegedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build privileged transport: %w", err)
+	}
+
+	execURL := clientset.CoreV1().RESTClient().Post().
+		Namespace(pod.Namespace).
+		Resource("pods").
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: appContainerName,
+			Command:   message.Command,
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    !message.TTY,
+			TTY:       message.TTY,
+		}, scheme.ParameterCodec).
+		URL()
+
+	return &httputil.ReverseProxy{
+		Rewrite: func(proxyReq *httputil.ProxyRequest) {
+			proxyReq.Out.URL = execURL
+			proxyReq.Out.Host = execURL.Host
+
+			header := http.Header{}
+			for _, name := range execRequestHeaders {
+				if values := proxyReq.Out.Header.Values(name); len(values) > 0 {
+					header[http.CanonicalHeaderKey(name)] = values
+				}
+			}
+			proxyReq.Out.Header = header
+		},
+		Transport: transport,
+	}, nil
+}
+
+// authorizeExec checks that the user is allowed to exec into the pod, which
+// only space developers and admins are
+func authorizeExec(ctx context.Context, userClient client.Client, pod corev1.Pod) error {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace:   pod.Namespace,
+				Verb:        "create",
+				Resource:    "pods",
+				Subresource: "exec",
+				Name:        pod.Name,
+			},
+		},
+	}
+	if err := userClient.Create(ctx, &review); err != nil {
+		return fmt.Errorf("failed to create self subject access review: %w", apierrors.FromK8sError(err, PodResourceType))
+	}
+
+	if !review.Status.Allowed {
+		return apierrors.NewForbiddenError(nil, PodResourceType)
+	}
+
+	return nil
+}
+
+func findInstancePod(ctx context.Context, userClient client.Client, appRevision string, process ProcessRecord, instanceID string) (corev1.Pod, error) {
 	labelSelector, err := labels.ValidatedSelectorFromSet(map[string]string{
 		"korifi.cloudfoundry.org/app-guid":     process.AppGUID,
 		"korifi.cloudfoundry.org/version":      appRevision,
 		"korifi.cloudfoundry.org/process-type": process.Type,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to build labelSelector: %w", apierrors.FromK8sError(err, PodResourceType))
+		return corev1.Pod{}, fmt.Errorf("failed to build labelSelector: %w", apierrors.FromK8sError(err, PodResourceType))
 	}
 	listOpts := client.ListOptions{Namespace: process.SpaceGUID, LabelSelector: labelSelector}
 
 	podList := corev1.PodList{}
 	err = userClient.List(ctx, &podList, &listOpts)
 	if err != nil {
-		return fmt.Errorf("failed to list pods: %w", apierrors.FromK8sError(err, PodResourceType))
+		return corev1.Pod{}, fmt.Errorf("failed to list pods: %w", apierrors.FromK8sError(err, PodResourceType))
 	}
 
-	podsToDelete := itx.FromSlice(podList.Items).Filter(func(pod corev1.Pod) bool {
+	instancePods := itx.FromSlice(podList.Items).Filter(func(pod corev1.Pod) bool {
 		return strings.HasSuffix(pod.Name, instanceID)
 	}).Collect()
 
-	if len(podsToDelete) == 0 {
-		return apierrors.NewNotFoundError(nil, PodResourceType)
+	if len(instancePods) == 0 {
+		return corev1.Pod{}, apierrors.NewNotFoundError(nil, PodResourceType)
 	}
 
-	if len(podsToDelete) > 1 {
-		return apierrors.NewUnprocessableEntityError(nil, "multiple pods found")
+	if len(instancePods) > 1 {
+		return corev1.Pod{}, apierrors.NewUnprocessableEntityError(nil, "multiple pods found")
 	}
 
-	err = userClient.Delete(ctx, &podsToDelete[0])
-	if err != nil {
-		return fmt.Errorf("failed to 'delete' pod: %w", apierrors.FromK8sError(err, PodResourceType))
-	}
-	return nil
+	return instancePods[0], nil
 }
@@ -1,6 +1,8 @@
 package repositories_test
 
 import (
+	"net/http"
+
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -30,7 +32,7 @@ var _ = Describe("PodRepository", func() {
 	BeforeEach(func() {
 		instance = "2"
 		appRevision = "1"
-		podRepo = repositories.NewPodRepo(userClientFactory)
+		podRepo = repositories.NewPodRepo(userClientFactory, testEnv.Config)
 		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
 		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
 		appGUID = uuid.NewString()
@@ -156,4 +158,56 @@ var _ = Describe("PodRepository", func() {
 			})
 		})
 	})
+
+	Describe("ExecInstance", func() {
+		var (
+			execHandler http.Handler
+			err         error
+		)
+
+		JustBeforeEach(func() {
+			execHandler, err = podRepo.ExecInstance(ctx, authInfo, repositories.ExecInstanceMessage{
+				AppRevision: appRevision,
+				Process:     process,
+				InstanceID:  instance,
+				Command:     []string{"/bin/sh"},
+				TTY:         true,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns an exec handler", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(execHandler).NotTo(BeNil())
+			})
+
+			When("the instance does not exist", func() {
+				BeforeEach(func() {
+					instance = "3"
+				})
+
+				It("returns a not found error", func() {
+					Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		When("the user is a SpaceSupporter", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceSupporterRole.Name, space.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
 })
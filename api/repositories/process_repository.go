@@ -34,19 +34,22 @@ type ProcessRepo struct {
 }
 
 type ProcessRecord struct {
-	GUID             string
-	SpaceGUID        string
-	AppGUID          string
-	Type             string
-	Command          string
-	DesiredInstances int32
-	MemoryMB         int64
-	DiskQuotaMB      int64
-	HealthCheck      HealthCheck
-	Labels           map[string]string
-	Annotations      map[string]string
-	CreatedAt        time.Time
-	UpdatedAt        *time.Time
+	GUID                          string
+	SpaceGUID                     string
+	AppGUID                       string
+	Type                          string
+	Command                       string
+	DesiredInstances              int32
+	ActualInstances               int32
+	MemoryMB                      int64
+	DiskQuotaMB                   int64
+	HealthCheck                   HealthCheck
+	ConnectionDrainTimeoutSeconds int32
+	Sidecars                      []SidecarRecord
+	Labels                        map[string]string
+	Annotations                   map[string]string
+	CreatedAt                     time.Time
+	UpdatedAt                     *time.Time
 }
 
 func (r ProcessRecord) Relationships() map[string]string {
@@ -59,6 +62,17 @@ func (r ProcessRecord) GetResourceType() string {
 	return ProcessResourceType
 }
 
+type SidecarRecord struct {
+	GUID         string
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     int64
+	AppGUID      string
+	CreatedAt    time.Time
+	UpdatedAt    *time.Time
+}
+
 type HealthCheck struct {
 	Type string
 	Data HealthCheckData
@@ -102,6 +116,7 @@ type PatchProcessMessage struct {
 	HealthCheckInvocationTimeoutSeconds *int32
 	HealthCheckTimeoutSeconds           *int32
 	HealthCheckType                     *string
+	ConnectionDrainTimeoutSeconds       *int32
 	DesiredInstances                    *int32
 	MemoryMB                            *int64
 	MetadataPatch                       *MetadataPatch
@@ -283,6 +298,9 @@ func (r *ProcessRepo) PatchProcess(ctx context.Context, authInfo authorization.I
 		if message.HealthCheckTimeoutSeconds != nil {
 			updatedProcess.Spec.HealthCheck.Data.TimeoutSeconds = *message.HealthCheckTimeoutSeconds
 		}
+		if message.ConnectionDrainTimeoutSeconds != nil {
+			updatedProcess.Spec.ConnectionDrainTimeoutSeconds = message.ConnectionDrainTimeoutSeconds
+		}
 		if message.MetadataPatch != nil {
 			message.MetadataPatch.Apply(updatedProcess)
 		}
@@ -307,6 +325,7 @@ func cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) ProcessRecord
 		Type:             cfProcess.Spec.ProcessType,
 		Command:          cmd,
 		DesiredInstances: *cfProcess.Spec.DesiredInstances,
+		ActualInstances:  cfProcess.Status.ActualInstances,
 		MemoryMB:         cfProcess.Spec.MemoryMB,
 		DiskQuotaMB:      cfProcess.Spec.DiskQuotaMB,
 		HealthCheck: HealthCheck{
@@ -317,9 +336,34 @@ func cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) ProcessRecord
 				TimeoutSeconds:           cfProcess.Spec.HealthCheck.Data.TimeoutSeconds,
 			},
 		},
-		Labels:      cfProcess.Labels,
-		Annotations: cfProcess.Annotations,
-		CreatedAt:   cfProcess.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(&cfProcess),
+		ConnectionDrainTimeoutSeconds: tools.ZeroIfNil(cfProcess.Spec.ConnectionDrainTimeoutSeconds),
+		Sidecars:                      cfProcessToSidecarRecords(cfProcess),
+		Labels:                        cfProcess.Labels,
+		Annotations:                   cfProcess.Annotations,
+		CreatedAt:                     cfProcess.CreationTimestamp.Time,
+		UpdatedAt:                     getLastUpdatedTime(&cfProcess),
+	}
+}
+
+// cfProcessToSidecarRecords only returns the sidecars that run alongside the
+// process type, see the Sidecar ProcessTypes field
+func cfProcessToSidecarRecords(cfProcess korifiv1alpha1.CFProcess) []SidecarRecord {
+	sidecars := []SidecarRecord{}
+	for _, sidecar := range cfProcess.Spec.Sidecars {
+		if !slices.Contains(sidecar.ProcessTypes, cfProcess.Spec.ProcessType) {
+			continue
+		}
+
+		sidecars = append(sidecars, SidecarRecord{
+			GUID:         tools.NamespacedUUID(cfProcess.Name, sidecar.Name),
+			Name:         sidecar.Name,
+			Command:      sidecar.Command,
+			ProcessTypes: sidecar.ProcessTypes,
+			MemoryMB:     sidecar.MemoryMB,
+			AppGUID:      cfProcess.Spec.AppRef.Name,
+			CreatedAt:    cfProcess.CreationTimestamp.Time,
+			UpdatedAt:    getLastUpdatedTime(&cfProcess),
+		})
 	}
+	return sidecars
 }
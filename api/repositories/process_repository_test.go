@@ -10,6 +10,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -89,6 +90,30 @@ var _ = Describe("ProcessRepo", func() {
 				Expect(processRecord.Relationships()).To(Equal(map[string]string{
 					"app": app1GUID,
 				}))
+				Expect(processRecord.Sidecars).To(BeEmpty())
+			})
+
+			When("the process declares sidecars", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfProcess1, func() {
+						cfProcess1.Spec.Sidecars = []korifiv1alpha1.Sidecar{
+							{Name: "web-sidecar", Command: "start-web-sidecar", ProcessTypes: []string{"web"}, MemoryMB: 64},
+							{Name: "worker-sidecar", Command: "start-worker-sidecar", ProcessTypes: []string{"worker"}, MemoryMB: 32},
+						}
+					})).To(Succeed())
+				})
+
+				It("returns the sidecars of the process type", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(processRecord.Sidecars).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID":         Equal(tools.NamespacedUUID(process1GUID, "web-sidecar")),
+						"Name":         Equal("web-sidecar"),
+						"Command":      Equal("start-web-sidecar"),
+						"ProcessTypes": ConsistOf("web"),
+						"MemoryMB":     BeEquivalentTo(64),
+						"AppGUID":      Equal(app1GUID),
+					})))
+				})
 			})
 		})
 
@@ -542,6 +567,7 @@ var _ = Describe("ProcessRepo", func() {
 							HealthCheckHTTPEndpoint:             tools.PtrTo("/healthz"),
 							HealthCheckInvocationTimeoutSeconds: tools.PtrTo(int32(20)),
 							HealthCheckTimeoutSeconds:           tools.PtrTo(int32(10)),
+							ConnectionDrainTimeoutSeconds:       tools.PtrTo(int32(15)),
 							DesiredInstances:                    tools.PtrTo[int32](42),
 							MemoryMB:                            tools.PtrTo(int64(456)),
 							DiskQuotaMB:                         tools.PtrTo(int64(123)),
@@ -562,6 +588,7 @@ var _ = Describe("ProcessRepo", func() {
 						Expect(updatedProcessRecord.HealthCheck.Data.HTTPEndpoint).To(Equal(*message.HealthCheckHTTPEndpoint))
 						Expect(updatedProcessRecord.HealthCheck.Data.TimeoutSeconds).To(Equal(*message.HealthCheckTimeoutSeconds))
 						Expect(updatedProcessRecord.HealthCheck.Data.InvocationTimeoutSeconds).To(Equal(*message.HealthCheckInvocationTimeoutSeconds))
+						Expect(updatedProcessRecord.ConnectionDrainTimeoutSeconds).To(Equal(*message.ConnectionDrainTimeoutSeconds))
 						Expect(updatedProcessRecord.DesiredInstances).To(Equal(*message.DesiredInstances))
 						Expect(updatedProcessRecord.MemoryMB).To(Equal(*message.MemoryMB))
 						Expect(updatedProcessRecord.DiskQuotaMB).To(Equal(*message.DiskQuotaMB))
@@ -582,9 +609,10 @@ var _ = Describe("ProcessRepo", func() {
 									TimeoutSeconds:           10,
 								},
 							},
-							DesiredInstances: tools.PtrTo[int32](42),
-							MemoryMB:         456,
-							DiskQuotaMB:      123,
+							DesiredInstances:              tools.PtrTo[int32](42),
+							MemoryMB:                      456,
+							DiskQuotaMB:                   123,
+							ConnectionDrainTimeoutSeconds: tools.PtrTo[int32](15),
 						}))
 						Expect(process.Labels).To(HaveKey("foo"))
 						Expect(process.Annotations).To(HaveKey("foo"))
@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	OrgQuotaResourceType   = "Organization Quota"
+	SpaceQuotaResourceType = "Space Quota"
+)
+
+type QuotaRepo struct {
+	userClientFactory    authorization.UserClientFactory
+	namespacePermissions *authorization.NamespacePermissions
+	rootNamespace        string
+}
+
+func NewQuotaRepo(
+	userClientFactory authorization.UserClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+	rootNamespace string,
+) *QuotaRepo {
+	return &QuotaRepo{
+		userClientFactory:    userClientFactory,
+		namespacePermissions: namespacePermissions,
+		rootNamespace:        rootNamespace,
+	}
+}
+
+// AppsQuotaRecord holds the app limits of a quota. Nil limits are unlimited.
+type AppsQuotaRecord struct {
+	TotalMemoryMB      *int64
+	PerProcessMemoryMB *int64
+	TotalInstances     *int32
+	TotalApps          *int32
+}
+
+type OrgQuotaRecord struct {
+	GUID      string
+	Name      string
+	Apps      AppsQuotaRecord
+	OrgGUIDs  []string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type SpaceQuotaRecord struct {
+	GUID       string
+	Name       string
+	Apps       AppsQuotaRecord
+	OrgGUID    string
+	SpaceGUIDs []string
+	CreatedAt  time.Time
+	UpdatedAt  *time.Time
+}
+
+type ListOrgQuotasMessage struct {
+	GUIDs []string
+	Names []string
+}
+
+func (m *ListOrgQuotasMessage) matches(q OrgQuotaRecord) bool {
+	return tools.EmptyOrContains(m.GUIDs, q.GUID) &&
+		tools.EmptyOrContains(m.Names, q.Name)
+}
+
+type ListSpaceQuotasMessage struct {
+	GUIDs    []string
+	Names    []string
+	OrgGUIDs []string
+}
+
+func (m *ListSpaceQuotasMessage) matches(q SpaceQuotaRecord) bool {
+	return tools.EmptyOrContains(m.GUIDs, q.GUID) &&
+		tools.EmptyOrContains(m.Names, q.Name)
+}
+
+func (m *ListSpaceQuotasMessage) matchesNamespace(ns string) bool {
+	return tools.EmptyOrContains(m.OrgGUIDs, ns)
+}
+
+func (r *QuotaRepo) ListOrgQuotas(ctx context.Context, authInfo authorization.Info, message ListOrgQuotasMessage) ([]OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuotaList := &korifiv1alpha1.CFOrgQuotaList{}
+	err = userClient.List(ctx, cfOrgQuotaList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return []OrgQuotaRecord{}, fmt.Errorf("failed to list org quotas: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	orgQuotaRecords := slices.Collect(it.Filter(
+		it.Map(itx.FromSlice(cfOrgQuotaList.Items), cfOrgQuotaToOrgQuotaRecord),
+		message.matches,
+	))
+	sort.Slice(orgQuotaRecords, func(i, j int) bool {
+		return orgQuotaRecords[i].CreatedAt.Before(orgQuotaRecords[j].CreatedAt)
+	})
+
+	return orgQuotaRecords, nil
+}
+
+func (r *QuotaRepo) ListSpaceQuotas(ctx context.Context, authInfo authorization.Info, message ListSpaceQuotasMessage) ([]SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	orgNamespaces, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return []SpaceQuotaRecord{}, fmt.Errorf("failed to list authorized org namespaces: %w", err)
+	}
+
+	cfSpaceQuotas := []korifiv1alpha1.CFSpaceQuota{}
+	for _, ns := range slices.Sorted(maps.Keys(orgNamespaces)) {
+		if !message.matchesNamespace(ns) {
+			continue
+		}
+
+		cfSpaceQuotaList := &korifiv1alpha1.CFSpaceQuotaList{}
+		err = userClient.List(ctx, cfSpaceQuotaList, client.InNamespace(ns))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return []SpaceQuotaRecord{}, fmt.Errorf("failed to list space quotas in namespace %s: %w", ns, apierrors.FromK8sError(err, SpaceQuotaResourceType))
+		}
+
+		cfSpaceQuotas = append(cfSpaceQuotas, cfSpaceQuotaList.Items...)
+	}
+
+	spaceQuotaRecords := slices.Collect(it.Filter(
+		it.Map(itx.FromSlice(cfSpaceQuotas), cfSpaceQuotaToSpaceQuotaRecord),
+		message.matches,
+	))
+	sort.Slice(spaceQuotaRecords, func(i, j int) bool {
+		return spaceQuotaRecords[i].CreatedAt.Before(spaceQuotaRecords[j].CreatedAt)
+	})
+
+	return spaceQuotaRecords, nil
+}
+
+func cfOrgQuotaToOrgQuotaRecord(cfOrgQuota korifiv1alpha1.CFOrgQuota) OrgQuotaRecord {
+	return OrgQuotaRecord{
+		GUID:      cfOrgQuota.Name,
+		Name:      cfOrgQuota.Spec.DisplayName,
+		Apps:      appsQuotaToAppsQuotaRecord(cfOrgQuota.Spec.Apps),
+		OrgGUIDs:  cfOrgQuota.Spec.Orgs,
+		CreatedAt: cfOrgQuota.CreationTimestamp.Time,
+		UpdatedAt: getLastUpdatedTime(&cfOrgQuota),
+	}
+}
+
+func cfSpaceQuotaToSpaceQuotaRecord(cfSpaceQuota korifiv1alpha1.CFSpaceQuota) SpaceQuotaRecord {
+	return SpaceQuotaRecord{
+		GUID:       cfSpaceQuota.Name,
+		Name:       cfSpaceQuota.Spec.DisplayName,
+		Apps:       appsQuotaToAppsQuotaRecord(cfSpaceQuota.Spec.Apps),
+		OrgGUID:    cfSpaceQuota.Namespace,
+		SpaceGUIDs: cfSpaceQuota.Spec.Spaces,
+		CreatedAt:  cfSpaceQuota.CreationTimestamp.Time,
+		UpdatedAt:  getLastUpdatedTime(&cfSpaceQuota),
+	}
+}
+
+func appsQuotaToAppsQuotaRecord(apps korifiv1alpha1.AppsQuota) AppsQuotaRecord {
+	return AppsQuotaRecord{
+		TotalMemoryMB:      apps.TotalMemoryMB,
+		PerProcessMemoryMB: apps.PerProcessMemoryMB,
+		TotalInstances:     apps.TotalInstances,
+		TotalApps:          apps.TotalApps,
+	}
+}
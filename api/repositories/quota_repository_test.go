@@ -0,0 +1,163 @@
+package repositories_test
+
+import (
+	"context"
+
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("QuotaRepository", func() {
+	var quotaRepo *QuotaRepo
+
+	BeforeEach(func() {
+		quotaRepo = NewQuotaRepo(userClientFactory, nsPerms, rootNamespace)
+	})
+
+	Describe("ListOrgQuotas", func() {
+		var (
+			cfOrgQuota *korifiv1alpha1.CFOrgQuota
+			message    ListOrgQuotasMessage
+			records    []OrgQuotaRecord
+			listErr    error
+		)
+
+		BeforeEach(func() {
+			message = ListOrgQuotasMessage{}
+
+			cfOrgQuota = &korifiv1alpha1.CFOrgQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace,
+				},
+				Spec: korifiv1alpha1.CFOrgQuotaSpec{
+					DisplayName: "org-quota",
+					Apps: korifiv1alpha1.AppsQuota{
+						TotalMemoryMB:  tools.PtrTo[int64](2048),
+						TotalInstances: tools.PtrTo[int32](10),
+						TotalApps:      tools.PtrTo[int32](5),
+					},
+					Orgs: []string{"org-guid"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfOrgQuota)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), cfOrgQuota))).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = quotaRepo.ListOrgQuotas(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(listErr).To(HaveOccurred())
+		})
+
+		When("the user is allowed to list in the root namespace", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+			})
+
+			It("returns the org quotas", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"GUID": Equal(cfOrgQuota.Name),
+					"Name": Equal("org-quota"),
+					"Apps": Equal(AppsQuotaRecord{
+						TotalMemoryMB:  tools.PtrTo[int64](2048),
+						TotalInstances: tools.PtrTo[int32](10),
+						TotalApps:      tools.PtrTo[int32](5),
+					}),
+					"OrgGUIDs": ConsistOf("org-guid"),
+				})))
+			})
+
+			When("filtering by a name that does not match", func() {
+				BeforeEach(func() {
+					message.Names = []string{"another-quota"}
+				})
+
+				It("returns an empty list", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("ListSpaceQuotas", func() {
+		var (
+			cfOrg        *korifiv1alpha1.CFOrg
+			cfSpaceQuota *korifiv1alpha1.CFSpaceQuota
+			message      ListSpaceQuotasMessage
+			records      []SpaceQuotaRecord
+			listErr      error
+		)
+
+		BeforeEach(func() {
+			message = ListSpaceQuotasMessage{}
+
+			cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+			cfSpaceQuota = &korifiv1alpha1.CFSpaceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: cfOrg.Name,
+				},
+				Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+					DisplayName: "space-quota",
+					Apps: korifiv1alpha1.AppsQuota{
+						PerProcessMemoryMB: tools.PtrTo[int64](512),
+					},
+					Spaces: []string{"space-guid"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfSpaceQuota)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = quotaRepo.ListSpaceQuotas(ctx, authInfo, message)
+		})
+
+		It("does not return quotas of orgs the user has no role in", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(BeEmpty())
+		})
+
+		When("the user is a member of the org", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+			})
+
+			It("returns the space quotas of the org", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"GUID":       Equal(cfSpaceQuota.Name),
+					"Name":       Equal("space-quota"),
+					"Apps":       Equal(AppsQuotaRecord{PerProcessMemoryMB: tools.PtrTo[int64](512)}),
+					"OrgGUID":    Equal(cfOrg.Name),
+					"SpaceGUIDs": ConsistOf("space-guid"),
+				})))
+			})
+
+			When("filtering by another org", func() {
+				BeforeEach(func() {
+					message.OrgGUIDs = []string{uuid.NewString()}
+				})
+
+				It("returns an empty list", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(BeEmpty())
+				})
+			})
+		})
+	})
+})
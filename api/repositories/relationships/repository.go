@@ -26,6 +26,21 @@ type ServicePlanRepository interface {
 	ListPlans(context.Context, authorization.Info, repositories.ListServicePlanMessage) ([]repositories.ServicePlanRecord, error)
 }
 
+//counterfeiter:generate -o fake -fake-name DomainRepository . DomainRepository
+type DomainRepository interface {
+	ListDomains(context.Context, authorization.Info, repositories.ListDomainsMessage) ([]repositories.DomainRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name SpaceRepository . SpaceRepository
+type SpaceRepository interface {
+	ListSpaces(context.Context, authorization.Info, repositories.ListSpacesMessage) ([]repositories.SpaceRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name OrgRepository . OrgRepository
+type OrgRepository interface {
+	ListOrgs(context.Context, authorization.Info, repositories.ListOrgsMessage) ([]repositories.OrgRecord, error)
+}
+
 //counterfeiter:generate -o fake -fake-name Resource . Resource
 type Resource interface {
 	Relationships() map[string]string
@@ -35,17 +50,26 @@ type ResourceRelationshipsRepo struct {
 	serviceOfferingRepo ServiceOfferingRepository
 	serviceBrokerRepo   ServiceBrokerRepository
 	servicePlanRepo     ServicePlanRepository
+	domainRepo          DomainRepository
+	spaceRepo           SpaceRepository
+	orgRepo             OrgRepository
 }
 
 func NewResourseRelationshipsRepo(
 	serviceOfferingRepo ServiceOfferingRepository,
 	serviceBrokerRepo ServiceBrokerRepository,
 	servicePlanRepo ServicePlanRepository,
+	domainRepo DomainRepository,
+	spaceRepo SpaceRepository,
+	orgRepo OrgRepository,
 ) *ResourceRelationshipsRepo {
 	return &ResourceRelationshipsRepo{
 		serviceOfferingRepo: serviceOfferingRepo,
 		serviceBrokerRepo:   serviceBrokerRepo,
 		servicePlanRepo:     servicePlanRepo,
+		domainRepo:          domainRepo,
+		spaceRepo:           spaceRepo,
+		orgRepo:             orgRepo,
 	}
 }
 
@@ -80,8 +104,24 @@ func (r *ResourceRelationshipsRepo) ListRelatedResources(ctx context.Context, au
 			authInfo,
 			repositories.ListServicePlanMessage{GUIDs: relatedResourceGUIDs},
 		))
-	case "space", "organization":
-		return []Resource{}, nil
+	case "domain":
+		return asResources(r.domainRepo.ListDomains(
+			ctx,
+			authInfo,
+			repositories.ListDomainsMessage{GUIDs: relatedResourceGUIDs},
+		))
+	case "space":
+		return asResources(r.spaceRepo.ListSpaces(
+			ctx,
+			authInfo,
+			repositories.ListSpacesMessage{GUIDs: relatedResourceGUIDs},
+		))
+	case "organization":
+		return asResources(r.orgRepo.ListOrgs(
+			ctx,
+			authInfo,
+			repositories.ListOrgsMessage{GUIDs: relatedResourceGUIDs},
+		))
 	}
 
 	return nil, fmt.Errorf("no repository for type %q", relatedResourceType)
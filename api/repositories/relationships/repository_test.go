@@ -16,6 +16,9 @@ var _ = Describe("ResourceRelationshipsRepository", func() {
 		serviceOfferingRepo *fake.ServiceOfferingRepository
 		serviceBrokerRepo   *fake.ServiceBrokerRepository
 		servicePlanRepo     *fake.ServicePlanRepository
+		domainRepo          *fake.DomainRepository
+		spaceRepo           *fake.SpaceRepository
+		orgRepo             *fake.OrgRepository
 		relationshipsRepo   relationships.ResourceRelationshipsRepo
 
 		resourceType   string
@@ -36,7 +39,10 @@ var _ = Describe("ResourceRelationshipsRepository", func() {
 		serviceOfferingRepo = new(fake.ServiceOfferingRepository)
 		serviceBrokerRepo = new(fake.ServiceBrokerRepository)
 		servicePlanRepo = new(fake.ServicePlanRepository)
-		relationshipsRepo = *relationships.NewResourseRelationshipsRepo(serviceOfferingRepo, serviceBrokerRepo, servicePlanRepo)
+		domainRepo = new(fake.DomainRepository)
+		spaceRepo = new(fake.SpaceRepository)
+		orgRepo = new(fake.OrgRepository)
+		relationshipsRepo = *relationships.NewResourseRelationshipsRepo(serviceOfferingRepo, serviceBrokerRepo, servicePlanRepo, domainRepo, spaceRepo, orgRepo)
 	})
 
 	JustBeforeEach(func() {
@@ -202,22 +208,123 @@ var _ = Describe("ResourceRelationshipsRepository", func() {
 	Describe("resource type space", func() {
 		BeforeEach(func() {
 			resourceType = "space"
+
+			inputResource.RelationshipsReturns(map[string]string{
+				"space": "space-guid",
+			})
+
+			spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{
+				GUID: "space-guid",
+			}}, nil)
 		})
 
-		It("returns a empty list", func() {
+		It("delegates to the space repository", func() {
+			Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+			_, _, actualMessage := spaceRepo.ListSpacesArgsForCall(0)
+			Expect(actualMessage).To(Equal(repositories.ListSpacesMessage{
+				GUIDs: []string{"space-guid"},
+			}))
+		})
+
+		It("returns a list of related spaces", func() {
 			Expect(listError).NotTo(HaveOccurred())
-			Expect(result).To(BeEmpty())
+			Expect(result).To(ConsistOf(
+				repositories.SpaceRecord{
+					GUID: "space-guid",
+				},
+			))
+		})
+
+		When("the underlying repo returns an error", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns(nil, errors.New("list-space-error"))
+			})
+
+			It("returns an error", func() {
+				Expect(listError).To(MatchError("list-space-error"))
+			})
 		})
 	})
 
-	Describe("resource type space", func() {
+	Describe("resource type organization", func() {
 		BeforeEach(func() {
-			resourceType = "space"
+			resourceType = "organization"
+
+			inputResource.RelationshipsReturns(map[string]string{
+				"organization": "org-guid",
+			})
+
+			orgRepo.ListOrgsReturns([]repositories.OrgRecord{{
+				GUID: "org-guid",
+			}}, nil)
+		})
+
+		It("delegates to the org repository", func() {
+			Expect(orgRepo.ListOrgsCallCount()).To(Equal(1))
+			_, _, actualMessage := orgRepo.ListOrgsArgsForCall(0)
+			Expect(actualMessage).To(Equal(repositories.ListOrgsMessage{
+				GUIDs: []string{"org-guid"},
+			}))
 		})
 
-		It("returns a empty list", func() {
+		It("returns a list of related orgs", func() {
 			Expect(listError).NotTo(HaveOccurred())
-			Expect(result).To(BeEmpty())
+			Expect(result).To(ConsistOf(
+				repositories.OrgRecord{
+					GUID: "org-guid",
+				},
+			))
+		})
+
+		When("the underlying repo returns an error", func() {
+			BeforeEach(func() {
+				orgRepo.ListOrgsReturns(nil, errors.New("list-org-error"))
+			})
+
+			It("returns an error", func() {
+				Expect(listError).To(MatchError("list-org-error"))
+			})
+		})
+	})
+
+	Describe("resource type domain", func() {
+		BeforeEach(func() {
+			resourceType = "domain"
+
+			inputResource.RelationshipsReturns(map[string]string{
+				"domain": "domain-guid",
+			})
+
+			domainRepo.ListDomainsReturns([]repositories.DomainRecord{{
+				GUID: "domain-guid",
+			}}, nil)
+		})
+
+		It("delegates to the domain repository", func() {
+			Expect(domainRepo.ListDomainsCallCount()).To(Equal(1))
+			_, _, acutalMessage := domainRepo.ListDomainsArgsForCall(0)
+			Expect(acutalMessage).To(Equal(repositories.ListDomainsMessage{
+				GUIDs: []string{"domain-guid"},
+			}))
+		})
+
+		It("returns a list of related domains", func() {
+			Expect(listError).NotTo(HaveOccurred())
+			Expect(result).To(ConsistOf(
+				repositories.DomainRecord{
+					GUID: "domain-guid",
+				},
+			))
+		})
+
+		When("the underlying repo returns an error", func() {
+			BeforeEach(func() {
+				domainRepo.ListDomainsReturns(nil, errors.New("list-domain-error"))
+			})
+
+			It("returns an error", func() {
+				Expect(listError).To(MatchError("list-domain-error"))
+			})
 		})
 	})
 })
@@ -67,6 +67,7 @@ var (
 	orgManagerRole        *rbacv1.ClusterRole
 	orgUserRole           *rbacv1.ClusterRole
 	spaceAuditorRole      *rbacv1.ClusterRole
+	spaceSupporterRole    *rbacv1.ClusterRole
 	rootNamespaceUserRole *rbacv1.ClusterRole
 )
 
@@ -106,6 +107,7 @@ var _ = BeforeSuite(func() {
 	spaceDeveloperRole = createClusterRole(context.Background(), "cf_space_developer")
 	spaceManagerRole = createClusterRole(context.Background(), "cf_space_manager")
 	spaceAuditorRole = createClusterRole(context.Background(), "cf_space_auditor")
+	spaceSupporterRole = createClusterRole(context.Background(), "cf_space_supporter")
 	rootNamespaceUserRole = createClusterRole(context.Background(), "cf_root_namespace_user")
 })
 
@@ -0,0 +1,286 @@
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	RevisionResourceType = "Revision"
+
+	RevisionDescriptionNewDroplet = "New droplet deployed."
+	RevisionDescriptionRestart    = "App restarted."
+	RevisionDescriptionRollback   = "Rolled back to revision %d."
+)
+
+type RevisionRecord struct {
+	GUID        string
+	Version     int
+	AppGUID     string
+	SpaceGUID   string
+	DropletGUID string
+	Description string
+	Processes   []RevisionProcessRecord
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+}
+
+func (r RevisionRecord) Relationships() map[string]string {
+	return map[string]string{
+		"app": r.AppGUID,
+	}
+}
+
+type RevisionProcessRecord struct {
+	Type        string
+	Command     string
+	Instances   *int32
+	MemoryMB    int64
+	DiskQuotaMB int64
+}
+
+type CreateRevisionMessage struct {
+	AppGUID     string
+	SpaceGUID   string
+	Description string
+}
+
+type ListRevisionsMessage struct {
+	AppGUID   string
+	SpaceGUID string
+}
+
+type RevisionRepo struct {
+	userClientFactory  authorization.UserClientFactory
+	namespaceRetriever NamespaceRetriever
+	maxRevisions       int
+}
+
+func NewRevisionRepo(
+	userClientFactory authorization.UserClientFactory,
+	namespaceRetriever NamespaceRetriever,
+	maxRevisions int,
+) *RevisionRepo {
+	return &RevisionRepo{
+		userClientFactory:  userClientFactory,
+		namespaceRetriever: namespaceRetriever,
+		maxRevisions:       maxRevisions,
+	}
+}
+
+func (r *RevisionRepo) GetRevision(ctx context.Context, authInfo authorization.Info, revisionGUID string) (RevisionRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, revisionGUID, RevisionResourceType)
+	if err != nil {
+		return RevisionRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return RevisionRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	revision := &korifiv1alpha1.CFRevision{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: revisionGUID}, revision)
+	if err != nil {
+		return RevisionRecord{}, apierrors.FromK8sError(err, RevisionResourceType)
+	}
+
+	return cfRevisionToRevisionRecord(*revision), nil
+}
+
+// ListRevisions returns the revisions of an app, oldest first
+func (r *RevisionRepo) ListRevisions(ctx context.Context, authInfo authorization.Info, message ListRevisionsMessage) ([]RevisionRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	revisions, err := listAppRevisions(ctx, userClient, message.SpaceGUID, message.AppGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Collect(it.Map(slices.Values(revisions), cfRevisionToRevisionRecord)), nil
+}
+
+// CreateRevision takes a snapshot of the droplet, environment variables and
+// process configuration of an app. Once the app has more than the configured
+// number of revisions the oldest ones are deleted.
+func (r *RevisionRepo) CreateRevision(ctx context.Context, authInfo authorization.Info, message CreateRevisionMessage) (RevisionRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return RevisionRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.AppGUID}, cfApp)
+	if err != nil {
+		return RevisionRecord{}, apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	existingRevisions, err := listAppRevisions(ctx, userClient, message.SpaceGUID, message.AppGUID)
+	if err != nil {
+		return RevisionRecord{}, err
+	}
+
+	version := 1
+	if len(existingRevisions) > 0 {
+		version = existingRevisions[len(existingRevisions)-1].Spec.Version + 1
+	}
+
+	processes := &korifiv1alpha1.CFProcessList{}
+	err = userClient.List(ctx, processes, client.InNamespace(message.SpaceGUID))
+	if err != nil {
+		return RevisionRecord{}, apierrors.FromK8sError(err, ProcessResourceType)
+	}
+
+	revision := &korifiv1alpha1.CFRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SpaceGUID,
+			Name:      uuid.NewString(),
+			Labels: map[string]string{
+				CFAppGUIDLabel: message.AppGUID,
+			},
+		},
+		Spec: korifiv1alpha1.CFRevisionSpec{
+			AppRef:      corev1.LocalObjectReference{Name: message.AppGUID},
+			Version:     version,
+			DropletRef:  cfApp.Spec.CurrentDropletRef,
+			Description: message.Description,
+		},
+	}
+
+	for _, process := range processes.Items {
+		if process.Spec.AppRef.Name != message.AppGUID {
+			continue
+		}
+
+		revision.Spec.Processes = append(revision.Spec.Processes, korifiv1alpha1.CFRevisionProcess{
+			ProcessType:      process.Spec.ProcessType,
+			Command:          process.Spec.Command,
+			DesiredInstances: process.Spec.DesiredInstances,
+			MemoryMB:         process.Spec.MemoryMB,
+			DiskQuotaMB:      process.Spec.DiskQuotaMB,
+		})
+	}
+	slices.SortFunc(revision.Spec.Processes, func(a, b korifiv1alpha1.CFRevisionProcess) int {
+		return cmp.Compare(a.ProcessType, b.ProcessType)
+	})
+
+	// The env secret may not have been created yet by the app controller, in
+	// which case the revision simply has no environment variables
+	envSecret := &corev1.Secret{}
+	if cfApp.Spec.EnvSecretName != "" {
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: cfApp.Spec.EnvSecretName}, envSecret)
+		if client.IgnoreNotFound(err) != nil {
+			return RevisionRecord{}, apierrors.FromK8sError(err, AppEnvResourceType)
+		}
+		if err == nil {
+			revision.Spec.EnvSecretName = revision.Name + "-env"
+		}
+	}
+
+	err = controllerutil.SetOwnerReference(cfApp, revision, scheme.Scheme)
+	if err != nil {
+		return RevisionRecord{}, fmt.Errorf("failed to set owner reference on revision: %w", err)
+	}
+
+	err = userClient.Create(ctx, revision)
+	if err != nil {
+		return RevisionRecord{}, apierrors.FromK8sError(err, RevisionResourceType)
+	}
+
+	if revision.Spec.EnvSecretName != "" {
+		revisionEnvSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: message.SpaceGUID,
+				Name:      revision.Spec.EnvSecretName,
+				Labels: map[string]string{
+					CFAppGUIDLabel: message.AppGUID,
+				},
+			},
+			Data: envSecret.Data,
+		}
+		err = controllerutil.SetOwnerReference(revision, revisionEnvSecret, scheme.Scheme)
+		if err != nil {
+			return RevisionRecord{}, fmt.Errorf("failed to set owner reference on revision env secret: %w", err)
+		}
+
+		err = userClient.Create(ctx, revisionEnvSecret)
+		if err != nil {
+			return RevisionRecord{}, apierrors.FromK8sError(err, RevisionResourceType)
+		}
+	}
+
+	if err = r.pruneRevisions(ctx, userClient, append(existingRevisions, *revision)); err != nil {
+		return RevisionRecord{}, err
+	}
+
+	return cfRevisionToRevisionRecord(*revision), nil
+}
+
+// pruneRevisions deletes the oldest of the given revisions, which are sorted
+// by version, so that no more than the configured maximum remain. Revision
+// env secrets are owned by their revision and get garbage collected with it.
+func (r *RevisionRepo) pruneRevisions(ctx context.Context, userClient client.Client, revisions []korifiv1alpha1.CFRevision) error {
+	for len(revisions) > r.maxRevisions {
+		err := userClient.Delete(ctx, &revisions[0])
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete revision %q: %w", revisions[0].Name, apierrors.FromK8sError(err, RevisionResourceType))
+		}
+		revisions = revisions[1:]
+	}
+
+	return nil
+}
+
+func listAppRevisions(ctx context.Context, userClient client.Client, spaceGUID, appGUID string) ([]korifiv1alpha1.CFRevision, error) {
+	revisionList := &korifiv1alpha1.CFRevisionList{}
+	err := userClient.List(ctx, revisionList, client.InNamespace(spaceGUID), client.MatchingLabels{CFAppGUIDLabel: appGUID})
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, RevisionResourceType)
+	}
+
+	slices.SortFunc(revisionList.Items, func(a, b korifiv1alpha1.CFRevision) int {
+		return cmp.Compare(a.Spec.Version, b.Spec.Version)
+	})
+
+	return revisionList.Items, nil
+}
+
+func cfRevisionToRevisionRecord(revision korifiv1alpha1.CFRevision) RevisionRecord {
+	return RevisionRecord{
+		GUID:        revision.Name,
+		Version:     revision.Spec.Version,
+		AppGUID:     revision.Spec.AppRef.Name,
+		SpaceGUID:   revision.Namespace,
+		DropletGUID: revision.Spec.DropletRef.Name,
+		Description: revision.Spec.Description,
+		Processes: slices.Collect(it.Map(slices.Values(revision.Spec.Processes), func(p korifiv1alpha1.CFRevisionProcess) RevisionProcessRecord {
+			return RevisionProcessRecord{
+				Type:        p.ProcessType,
+				Command:     p.Command,
+				Instances:   p.DesiredInstances,
+				MemoryMB:    p.MemoryMB,
+				DiskQuotaMB: p.DiskQuotaMB,
+			}
+		})),
+		CreatedAt: revision.CreationTimestamp.Time,
+		UpdatedAt: getLastUpdatedTime(&revision),
+	}
+}
@@ -0,0 +1,211 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("RevisionRepository", func() {
+	var (
+		revisionRepo *repositories.RevisionRepo
+		cfOrg        *korifiv1alpha1.CFOrg
+		cfSpace      *korifiv1alpha1.CFSpace
+		cfApp        *korifiv1alpha1.CFApp
+		maxRevisions int
+	)
+
+	BeforeEach(func() {
+		maxRevisions = 100
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+		cfApp = createApp(cfSpace.Name)
+	})
+
+	JustBeforeEach(func() {
+		revisionRepo = repositories.NewRevisionRepo(userClientFactory, namespaceRetriever, maxRevisions)
+	})
+
+	createRevision := func(description string) repositories.RevisionRecord {
+		GinkgoHelper()
+
+		record, err := revisionRepo.CreateRevision(ctx, authInfo, repositories.CreateRevisionMessage{
+			AppGUID:     cfApp.Name,
+			SpaceGUID:   cfSpace.Name,
+			Description: description,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return record
+	}
+
+	Describe("CreateRevision", func() {
+		var (
+			record    repositories.RevisionRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			record, createErr = revisionRepo.CreateRevision(ctx, authInfo, repositories.CreateRevisionMessage{
+				AppGUID:     cfApp.Name,
+				SpaceGUID:   cfSpace.Name,
+				Description: repositories.RevisionDescriptionNewDroplet,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+
+				Expect(k8sClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: cfSpace.Name,
+						Name:      cfApp.Spec.EnvSecretName,
+					},
+					Data: map[string][]byte{"FOO": []byte("bar")},
+				})).To(Succeed())
+
+				createProcessCR(ctx, k8sClient, uuid.NewString(), cfSpace.Name, cfApp.Name)
+			})
+
+			It("snapshots the app", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.GUID).NotTo(BeEmpty())
+				Expect(record.Version).To(Equal(1))
+				Expect(record.AppGUID).To(Equal(cfApp.Name))
+				Expect(record.SpaceGUID).To(Equal(cfSpace.Name))
+				Expect(record.DropletGUID).To(Equal(cfApp.Spec.CurrentDropletRef.Name))
+				Expect(record.Description).To(Equal(repositories.RevisionDescriptionNewDroplet))
+				Expect(record.Processes).To(ConsistOf(repositories.RevisionProcessRecord{
+					Type:        "web",
+					Instances:   tools.PtrTo[int32](1),
+					MemoryMB:    500,
+					DiskQuotaMB: 512,
+				}))
+			})
+
+			It("copies the app env into a secret owned by the revision", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				revision := &korifiv1alpha1.CFRevision{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfSpace.Name, Name: record.GUID}, revision)).To(Succeed())
+				Expect(revision.Spec.EnvSecretName).NotTo(BeEmpty())
+
+				envSecret := &corev1.Secret{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfSpace.Name, Name: revision.Spec.EnvSecretName}, envSecret)).To(Succeed())
+				Expect(envSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("bar")}))
+				Expect(envSecret.OwnerReferences).To(ConsistOf(HaveField("Name", record.GUID)))
+			})
+
+			When("the app already has revisions", func() {
+				BeforeEach(func() {
+					maxRevisions = 2
+				})
+
+				JustBeforeEach(func() {
+					createRevision(repositories.RevisionDescriptionRestart)
+					createRevision(repositories.RevisionDescriptionRestart)
+				})
+
+				It("bumps the version and prunes the oldest revisions", func() {
+					records, err := revisionRepo.ListRevisions(ctx, authInfo, repositories.ListRevisionsMessage{
+						AppGUID:   cfApp.Name,
+						SpaceGUID: cfSpace.Name,
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(records).To(HaveLen(2))
+					Expect(records[0].Version).To(Equal(2))
+					Expect(records[1].Version).To(Equal(3))
+				})
+			})
+		})
+	})
+
+	Describe("ListRevisions", func() {
+		var (
+			records []repositories.RevisionRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+		})
+
+		JustBeforeEach(func() {
+			createRevision(repositories.RevisionDescriptionNewDroplet)
+			createRevision(repositories.RevisionDescriptionRestart)
+
+			otherApp := createApp(cfSpace.Name)
+			_, err := revisionRepo.CreateRevision(ctx, authInfo, repositories.CreateRevisionMessage{
+				AppGUID:   otherApp.Name,
+				SpaceGUID: cfSpace.Name,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			records, listErr = revisionRepo.ListRevisions(ctx, authInfo, repositories.ListRevisionsMessage{
+				AppGUID:   cfApp.Name,
+				SpaceGUID: cfSpace.Name,
+			})
+		})
+
+		It("returns the app revisions ordered by version", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(2))
+			Expect(records[0].Version).To(Equal(1))
+			Expect(records[0].Description).To(Equal(repositories.RevisionDescriptionNewDroplet))
+			Expect(records[1].Version).To(Equal(2))
+			Expect(records[1].Description).To(Equal(repositories.RevisionDescriptionRestart))
+		})
+	})
+
+	Describe("GetRevision", func() {
+		var (
+			revisionGUID string
+			record       repositories.RevisionRecord
+			getErr       error
+		)
+
+		BeforeEach(func() {
+			revisionGUID = ""
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+		})
+
+		JustBeforeEach(func() {
+			if revisionGUID == "" {
+				revisionGUID = createRevision(repositories.RevisionDescriptionRestart).GUID
+			}
+
+			record, getErr = revisionRepo.GetRevision(ctx, authInfo, revisionGUID)
+		})
+
+		It("returns the revision", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(record.GUID).To(Equal(revisionGUID))
+			Expect(record.AppGUID).To(Equal(cfApp.Name))
+			Expect(record.Version).To(Equal(1))
+		})
+
+		When("the revision does not exist", func() {
+			BeforeEach(func() {
+				revisionGUID = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"cmp"
 	"context"
 	"crypto/sha256"
 	"fmt"
@@ -30,6 +31,7 @@ const (
 	RoleGuidLabel         = "cloudfoundry.org/role-guid"
 	roleBindingNamePrefix = "cf"
 	cfUserRoleType        = "cf_user"
+	orgUserRoleType       = "organization_user"
 	RoleResourceType      = "Role"
 )
 
@@ -128,7 +130,8 @@ func RoleComparator(fieldName string) func(RoleRecord, RoleRecord) int {
 		case "-updated_at":
 			return tools.CompareTimePtr(r2.UpdatedAt, r1.UpdatedAt)
 		}
-		return 0
+		// keep the order stable across pages when no order is requested
+		return cmp.Compare(r1.GUID, r2.GUID)
 	}
 }
 
@@ -179,7 +182,7 @@ func (r *RoleRepo) CreateRole(ctx context.Context, authInfo authorization.Info,
 	}
 
 	if role.Space != "" {
-		if err = r.validateOrgRequirements(ctx, role, userIdentity, authInfo); err != nil {
+		if err = r.ensureOrgUser(ctx, userClient, role, userIdentity, authInfo); err != nil {
 			return RoleRecord{}, err
 		}
 	}
@@ -230,7 +233,10 @@ func (r *RoleRepo) CreateRole(ctx context.Context, authInfo authorization.Info,
 	return roleRecord, nil
 }
 
-func (r *RoleRepo) validateOrgRequirements(ctx context.Context, role CreateRoleMessage, userIdentity authorization.Identity, authInfo authorization.Info) error {
+// ensureOrgUser makes the user an organization_user of the space parent org
+// unless they already have a role in it, as CF does not allow space roles
+// without an org role
+func (r *RoleRepo) ensureOrgUser(ctx context.Context, userClient client.Client, role CreateRoleMessage, userIdentity authorization.Identity, authInfo authorization.Info) error {
 	space, err := r.spaceRepo.GetSpace(ctx, authInfo, role.Space)
 	if err != nil {
 		return apierrors.AsUnprocessableEntity(err, "space not found", apierrors.NotFoundError{}, apierrors.ForbiddenError{})
@@ -241,12 +247,21 @@ func (r *RoleRepo) validateOrgRequirements(ctx context.Context, role CreateRoleM
 		return fmt.Errorf("failed to check for role in parent org: %w", err)
 	}
 
-	if !hasOrgBinding {
-		return apierrors.NewUnprocessableEntityError(
-			fmt.Errorf("no RoleBinding found in parent org %q for user %q", space.OrganizationGUID, userIdentity.Name),
-			"no RoleBinding found in parent org",
-		)
+	if hasOrgBinding {
+		return nil
 	}
+
+	orgUserk8sRoleConfig, ok := r.roleMappings[orgUserRoleType]
+	if !ok {
+		return fmt.Errorf("invalid role type: %q", orgUserRoleType)
+	}
+
+	orgUserRoleBinding := createRoleBinding(space.OrganizationGUID, orgUserRoleType, role.Kind, role.User, role.ServiceAccountNamespace, uuid.NewString(), orgUserk8sRoleConfig.Name, orgUserk8sRoleConfig.Propagate)
+	err = userClient.Create(ctx, &orgUserRoleBinding)
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to assign user %q to role %q: %w", role.User, orgUserRoleType, apierrors.FromK8sError(err, RoleResourceType))
+	}
+
 	return nil
 }
 
@@ -55,13 +55,13 @@ var _ = Describe("RoleRepository", func() {
 			korifiv1alpha1.CFOrg,
 			korifiv1alpha1.CFOrgList,
 			*korifiv1alpha1.CFOrgList,
-		]{})
+		]{}, repositories.NewOrgSorter())
 		spaceRepo := repositories.NewSpaceRepo(namespaceRetriever, orgRepo, userClientFactory, nsPerms, &fakeawaiter.FakeAwaiter[
 			*korifiv1alpha1.CFSpace,
 			korifiv1alpha1.CFSpace,
 			korifiv1alpha1.CFSpaceList,
 			*korifiv1alpha1.CFSpaceList,
-		]{})
+		]{}, repositories.NewSpaceSorter())
 		roleRepo = repositories.NewRoleRepo(
 			userClientFactory,
 			spaceRepo,
@@ -406,11 +406,31 @@ var _ = Describe("RoleRepository", func() {
 				authorizedInChecker.AuthorizedInReturns(false, nil)
 			})
 
-			It("returns an unprocessable entity error", func() {
-				Expect(createErr).To(SatisfyAll(
-					BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}),
-					MatchError(ContainSubstring("no RoleBinding found")),
-				))
+			It("makes the user an organization user of the parent org", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				// Sha256 sum of "organization_user::myuser@example.com"
+				roleBinding := getTheRoleBinding("cf-2a6f4cbdd1777d57b5b7b2ee835785dafa68c147719c10948397cfc2ea7246a3", cfOrg.Name)
+				Expect(roleBinding.Labels).To(HaveKey(repositories.RoleGuidLabel))
+				Expect(roleBinding.RoleRef.Name).To(Equal(orgUserRole.Name))
+				Expect(roleBinding.Subjects).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Kind": Equal(rbacv1.UserKind),
+					"Name": Equal("myuser@example.com"),
+				})))
+			})
+
+			It("creates the space role binding", func() {
+				Expect(getTheRoleBinding(expectedName, cfSpace.Name).RoleRef.Name).To(Equal(spaceDeveloperRole.Name))
+			})
+		})
+
+		When("the user already has a role in the parent organization", func() {
+			It("does not create an organization user role binding", func() {
+				roleBindings := &rbacv1.RoleBindingList{}
+				Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(cfOrg.Name))).To(Succeed())
+				Expect(roleBindings.Items).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"RoleRef": MatchFields(IgnoreExtras, Fields{"Name": Equal(orgUserRole.Name)}),
+				})))
 			})
 		})
 	})
@@ -772,4 +792,10 @@ var _ = DescribeTable("RoleSorter",
 		"-updated_at",
 		BeNumerically(">", 0),
 	),
+	Entry("no order",
+		repositories.RoleRecord{GUID: "a"},
+		repositories.RoleRecord{GUID: "b"},
+		"",
+		BeNumerically("<", 0),
+	),
 )
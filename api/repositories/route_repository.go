@@ -21,7 +21,8 @@ import (
 )
 
 const (
-	RouteResourceType = "Route"
+	RouteResourceType            = "Route"
+	RouteDestinationResourceType = "Destination"
 )
 
 type RouteRepo struct {
@@ -338,7 +339,7 @@ func (r *RouteRepo) RemoveDestinationFromRoute(ctx context.Context, authInfo aut
 
 	updatedDestinations := itx.FromSlice(cfRoute.Spec.Destinations).Exclude(message.matches).Collect()
 	if len(updatedDestinations) == len(cfRoute.Spec.Destinations) {
-		return RouteRecord{}, apierrors.NewUnprocessableEntityError(nil, "Unable to unmap route from destination. Ensure the route has a destination with this guid.")
+		return RouteRecord{}, apierrors.NewNotFoundError(nil, RouteDestinationResourceType)
 	}
 
 	err = k8s.PatchResource(ctx, userClient, cfRoute, func() {
@@ -1173,8 +1173,8 @@ var _ = Describe("RouteRepository", func() {
 					destinationGUID = "some-bogus-guid"
 				})
 
-				It("returns an unprocessable entity error", func() {
-					Expect(removeDestinationErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				It("returns a not found error", func() {
+					Expect(removeDestinationErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
 				})
 			})
 		})
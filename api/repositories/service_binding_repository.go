@@ -318,32 +318,57 @@ func serviceBindingRecordLastOperation(binding korifiv1alpha1.CFServiceBinding)
 		}
 	}
 
+	secretCondition := meta.FindStatusCondition(binding.Status.Conditions, korifiv1alpha1.BindingSecretAvailableCondition)
+
 	if readyCondition.Status == metav1.ConditionTrue {
 		return ServiceBindingLastOperation{
-			Type:      "create",
-			State:     "succeeded",
-			CreatedAt: binding.CreationTimestamp.Time,
-			UpdatedAt: getLastUpdatedTime(&binding),
+			Type:        "create",
+			State:       "succeeded",
+			Description: conditionMessage(secretCondition),
+			CreatedAt:   binding.CreationTimestamp.Time,
+			UpdatedAt:   getLastUpdatedTime(&binding),
 		}
 	}
 
-	if meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.BindingFailedCondition) {
+	if secretCondition != nil && secretCondition.Status == metav1.ConditionFalse {
 		return ServiceBindingLastOperation{
-			Type:      "create",
-			State:     "failed",
-			CreatedAt: binding.CreationTimestamp.Time,
-			UpdatedAt: tools.PtrTo(readyCondition.LastTransitionTime.Time),
+			Type:        "create",
+			State:       "failed",
+			Description: conditionMessage(secretCondition),
+			CreatedAt:   binding.CreationTimestamp.Time,
+			UpdatedAt:   tools.PtrTo(secretCondition.LastTransitionTime.Time),
+		}
+	}
+
+	if failedCondition := meta.FindStatusCondition(binding.Status.Conditions, korifiv1alpha1.BindingFailedCondition); failedCondition != nil && failedCondition.Status == metav1.ConditionTrue {
+		return ServiceBindingLastOperation{
+			Type:        "create",
+			State:       "failed",
+			Description: conditionMessage(failedCondition),
+			CreatedAt:   binding.CreationTimestamp.Time,
+			UpdatedAt:   tools.PtrTo(readyCondition.LastTransitionTime.Time),
 		}
 	}
 
+	// the binding secret is not there yet, the ready condition tells what the
+	// binding is waiting for
 	return ServiceBindingLastOperation{
-		Type:      "create",
-		State:     "in progress",
-		CreatedAt: binding.CreationTimestamp.Time,
-		UpdatedAt: tools.PtrTo(readyCondition.LastTransitionTime.Time),
+		Type:        "create",
+		State:       "in progress",
+		Description: conditionMessage(readyCondition),
+		CreatedAt:   binding.CreationTimestamp.Time,
+		UpdatedAt:   tools.PtrTo(readyCondition.LastTransitionTime.Time),
 	}
 }
 
+func conditionMessage(condition *metav1.Condition) *string {
+	if condition == nil || condition.Message == "" {
+		return nil
+	}
+
+	return tools.PtrTo(condition.Message)
+}
+
 func (r *ServiceBindingRepo) UpdateServiceBinding(ctx context.Context, authInfo authorization.Info, updateMsg UpdateServiceBindingMessage) (ServiceBindingRecord, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
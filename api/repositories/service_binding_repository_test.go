@@ -448,9 +448,28 @@ var _ = Describe("ServiceBindingRepo", func() {
 			It("returns succeeded last operation", func() {
 				Expect(serviceBindingRecord.LastOperation.Type).To(Equal("create"))
 				Expect(serviceBindingRecord.LastOperation.State).To(Equal("succeeded"))
+				Expect(serviceBindingRecord.LastOperation.Description).To(BeNil())
 				Expect(serviceBindingRecord.LastOperation.CreatedAt).To(Equal(serviceBindingRecord.CreatedAt))
 				Expect(serviceBindingRecord.LastOperation.UpdatedAt).To(Equal(serviceBindingRecord.UpdatedAt))
 			})
+
+			When("the binding secret is available", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfServiceBinding, func() {
+						meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+							Type:    korifiv1alpha1.BindingSecretAvailableCondition,
+							Status:  metav1.ConditionTrue,
+							Reason:  "SecretAvailable",
+							Message: "The binding secret is available",
+						})
+					})).To(Succeed())
+				})
+
+				It("describes the last operation with the condition message", func() {
+					Expect(serviceBindingRecord.LastOperation.State).To(Equal("succeeded"))
+					Expect(serviceBindingRecord.LastOperation.Description).To(PointTo(Equal("The binding secret is available")))
+				})
+			})
 		})
 
 		When("binding is in progress", func() {
@@ -461,6 +480,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 						Status:             metav1.ConditionFalse,
 						LastTransitionTime: metav1.NewTime(time.UnixMilli(1000)),
 						Reason:             "NotReady",
+						Message:            "Service instance credentials not available yet",
 					})
 				})).To(Succeed())
 			})
@@ -468,6 +488,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 			It("returns in progress last operation", func() {
 				Expect(serviceBindingRecord.LastOperation.Type).To(Equal("create"))
 				Expect(serviceBindingRecord.LastOperation.State).To(Equal("in progress"))
+				Expect(serviceBindingRecord.LastOperation.Description).To(PointTo(Equal("Service instance credentials not available yet")))
 				Expect(serviceBindingRecord.LastOperation.CreatedAt).To(Equal(serviceBindingRecord.CreatedAt))
 				Expect(serviceBindingRecord.LastOperation.UpdatedAt).To(PointTo(Equal(time.UnixMilli(1000))))
 			})
@@ -484,19 +505,50 @@ var _ = Describe("ServiceBindingRepo", func() {
 					})
 
 					meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
-						Type:   korifiv1alpha1.BindingFailedCondition,
-						Status: metav1.ConditionTrue,
-						Reason: "Failed",
+						Type:    korifiv1alpha1.BindingFailedCondition,
+						Status:  metav1.ConditionTrue,
+						Reason:  "Failed",
+						Message: "broker said no",
 					})
 				})).To(Succeed())
 			})
 			It("returns failed last operation", func() {
 				Expect(serviceBindingRecord.LastOperation.Type).To(Equal("create"))
 				Expect(serviceBindingRecord.LastOperation.State).To(Equal("failed"))
+				Expect(serviceBindingRecord.LastOperation.Description).To(PointTo(Equal("broker said no")))
 				Expect(serviceBindingRecord.LastOperation.CreatedAt).To(Equal(serviceBindingRecord.CreatedAt))
 				Expect(serviceBindingRecord.LastOperation.UpdatedAt).To(PointTo(Equal(time.UnixMilli(2000))))
 			})
 		})
+
+		When("the binding secret is not available", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, cfServiceBinding, func() {
+					meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+						Type:               korifiv1alpha1.StatusConditionReady,
+						Status:             metav1.ConditionFalse,
+						LastTransitionTime: metav1.NewTime(time.UnixMilli(2000)),
+						Reason:             "ServiceInstanceDeleted",
+					})
+
+					meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+						Type:               korifiv1alpha1.BindingSecretAvailableCondition,
+						Status:             metav1.ConditionFalse,
+						LastTransitionTime: metav1.NewTime(time.UnixMilli(3000)),
+						Reason:             "ServiceInstanceDeleted",
+						Message:            "Service instance has been deleted",
+					})
+				})).To(Succeed())
+			})
+
+			It("returns failed last operation with the condition message", func() {
+				Expect(serviceBindingRecord.LastOperation.Type).To(Equal("create"))
+				Expect(serviceBindingRecord.LastOperation.State).To(Equal("failed"))
+				Expect(serviceBindingRecord.LastOperation.Description).To(PointTo(Equal("Service instance has been deleted")))
+				Expect(serviceBindingRecord.LastOperation.CreatedAt).To(Equal(serviceBindingRecord.CreatedAt))
+				Expect(serviceBindingRecord.LastOperation.UpdatedAt).To(PointTo(Equal(time.UnixMilli(3000))))
+			})
+		})
 	})
 
 	Describe("CreateManagedServiceBinding", func() {
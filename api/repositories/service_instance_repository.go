@@ -32,7 +32,7 @@ import (
 )
 
 const (
-	CFServiceInstanceGUIDLabel  = "korifi.cloudfoundry.org/service-instance-guid"
+	CFServiceInstanceGUIDLabel  = korifiv1alpha1.CFServiceInstanceGUIDLabelKey
 	ServiceInstanceResourceType = "Service Instance"
 )
 
@@ -104,12 +104,13 @@ func NewServiceInstanceRepo(
 }
 
 type CreateUPSIMessage struct {
-	Name        string
-	SpaceGUID   string
-	Credentials map[string]any
-	Tags        []string
-	Labels      map[string]string
-	Annotations map[string]string
+	Name           string
+	SpaceGUID      string
+	Credentials    map[string]any
+	Tags           []string
+	SyslogDrainURL *string
+	Labels         map[string]string
+	Annotations    map[string]string
 }
 
 type CreateManagedSIMessage struct {
@@ -122,12 +123,25 @@ type CreateManagedSIMessage struct {
 	Annotations map[string]string
 }
 
+type CreateVolumeSIMessage struct {
+	Name             string
+	SpaceGUID        string
+	SizeMB           int64
+	StorageClassName *string
+	AccessMode       string
+	MountPath        string
+	Tags             []string
+	Labels           map[string]string
+	Annotations      map[string]string
+}
+
 type PatchServiceInstanceMessage struct {
-	GUID        string
-	SpaceGUID   string
-	Name        *string
-	Credentials *map[string]any
-	Tags        *[]string
+	GUID           string
+	SpaceGUID      string
+	Name           *string
+	Credentials    *map[string]any
+	Tags           *[]string
+	SyslogDrainURL *string
 	MetadataPatch
 }
 
@@ -138,6 +152,9 @@ func (p PatchServiceInstanceMessage) Apply(cfServiceInstance *korifiv1alpha1.CFS
 	if p.Tags != nil {
 		cfServiceInstance.Spec.Tags = *p.Tags
 	}
+	if p.SyslogDrainURL != nil {
+		cfServiceInstance.Spec.SyslogDrainURL = p.SyslogDrainURL
+	}
 	p.MetadataPatch.Apply(cfServiceInstance)
 }
 
@@ -145,6 +162,7 @@ type ListServiceInstanceMessage struct {
 	Names         []string
 	SpaceGUIDs    []string
 	GUIDs         []string
+	Type          string
 	LabelSelector string
 	OrderBy       string
 	PlanGUIDs     []string
@@ -154,7 +172,8 @@ func (m *ListServiceInstanceMessage) matches(serviceInstance korifiv1alpha1.CFSe
 	return tools.EmptyOrContains(m.Names, serviceInstance.Spec.DisplayName) &&
 		tools.EmptyOrContains(m.GUIDs, serviceInstance.Name) &&
 		tools.EmptyOrContains(m.PlanGUIDs, serviceInstance.Spec.PlanGUID) &&
-		tools.EmptyOrContains(m.SpaceGUIDs, serviceInstance.Namespace)
+		tools.EmptyOrContains(m.SpaceGUIDs, serviceInstance.Namespace) &&
+		tools.ZeroOrEquals(m.Type, string(serviceInstance.Spec.Type))
 }
 
 type DeleteServiceInstanceMessage struct {
@@ -163,19 +182,20 @@ type DeleteServiceInstanceMessage struct {
 }
 
 type ServiceInstanceRecord struct {
-	Name          string
-	GUID          string
-	SpaceGUID     string
-	PlanGUID      string
-	Tags          []string
-	Type          string
-	Labels        map[string]string
-	Annotations   map[string]string
-	CreatedAt     time.Time
-	UpdatedAt     *time.Time
-	DeletedAt     *time.Time
-	LastOperation services.LastOperation
-	Ready         bool
+	Name           string
+	GUID           string
+	SpaceGUID      string
+	PlanGUID       string
+	Tags           []string
+	Type           string
+	SyslogDrainURL *string
+	Labels         map[string]string
+	Annotations    map[string]string
+	CreatedAt      time.Time
+	UpdatedAt      *time.Time
+	DeletedAt      *time.Time
+	LastOperation  services.LastOperation
+	Ready          bool
 }
 
 func (r ServiceInstanceRecord) Relationships() map[string]string {
@@ -203,10 +223,11 @@ func (r *ServiceInstanceRepo) CreateUserProvidedServiceInstance(ctx context.Cont
 			Annotations: message.Annotations,
 		},
 		Spec: korifiv1alpha1.CFServiceInstanceSpec{
-			DisplayName: message.Name,
-			SecretName:  uuid.NewString(),
-			Type:        korifiv1alpha1.UserProvidedType,
-			Tags:        message.Tags,
+			DisplayName:    message.Name,
+			SecretName:     uuid.NewString(),
+			Type:           korifiv1alpha1.UserProvidedType,
+			Tags:           message.Tags,
+			SyslogDrainURL: message.SyslogDrainURL,
 		},
 	}
 	err = userClient.Create(ctx, cfServiceInstance)
@@ -267,6 +288,39 @@ func (r *ServiceInstanceRepo) CreateManagedServiceInstance(ctx context.Context,
 	return cfServiceInstanceToRecord(*cfServiceInstance), nil
 }
 
+func (r *ServiceInstanceRepo) CreateVolumeServiceInstance(ctx context.Context, authInfo authorization.Info, message CreateVolumeSIMessage) (ServiceInstanceRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceInstance := &korifiv1alpha1.CFServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        uuid.NewString(),
+			Namespace:   message.SpaceGUID,
+			Labels:      message.Labels,
+			Annotations: message.Annotations,
+		},
+		Spec: korifiv1alpha1.CFServiceInstanceSpec{
+			DisplayName: message.Name,
+			Type:        korifiv1alpha1.VolumeType,
+			Tags:        message.Tags,
+			Volume: &korifiv1alpha1.VolumeServiceSpec{
+				SizeMB:           message.SizeMB,
+				StorageClassName: message.StorageClassName,
+				AccessMode:       corev1.PersistentVolumeAccessMode(message.AccessMode),
+				MountPath:        message.MountPath,
+			},
+		},
+	}
+	err = userClient.Create(ctx, cfServiceInstance)
+	if err != nil {
+		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
+	}
+
+	return cfServiceInstanceToRecord(*cfServiceInstance), nil
+}
+
 func (r *ServiceInstanceRepo) servicePlanVisible(ctx context.Context, userClient client.Client, planGUID string, spaceGUID string) (bool, error) {
 	servicePlan := &korifiv1alpha1.CFServicePlan{
 		ObjectMeta: metav1.ObjectMeta{
@@ -362,6 +416,7 @@ func (r *ServiceInstanceRepo) patchCredentialsSecret(
 		return errors.New("failed to marshal credentials for service instance")
 	}
 	return PatchResource(ctx, userClient, credentialsSecret, func() {
+		credentialsSecret.Labels = tools.SetMapValue(credentialsSecret.Labels, CFServiceInstanceGUIDLabel, cfServiceInstance.Name)
 		credentialsSecret.Data = credentialsSecretData
 	})
 }
@@ -560,19 +615,20 @@ func (r *ServiceInstanceRepo) removeBindingsFinalizer(ctx context.Context, userC
 
 func cfServiceInstanceToRecord(cfServiceInstance korifiv1alpha1.CFServiceInstance) ServiceInstanceRecord {
 	return ServiceInstanceRecord{
-		Name:          cfServiceInstance.Spec.DisplayName,
-		GUID:          cfServiceInstance.Name,
-		SpaceGUID:     cfServiceInstance.Namespace,
-		PlanGUID:      cfServiceInstance.Spec.PlanGUID,
-		Tags:          cfServiceInstance.Spec.Tags,
-		Type:          string(cfServiceInstance.Spec.Type),
-		Labels:        cfServiceInstance.Labels,
-		Annotations:   cfServiceInstance.Annotations,
-		CreatedAt:     cfServiceInstance.CreationTimestamp.Time,
-		UpdatedAt:     getLastUpdatedTime(&cfServiceInstance),
-		DeletedAt:     golangTime(cfServiceInstance.DeletionTimestamp),
-		LastOperation: cfServiceInstance.Status.LastOperation,
-		Ready:         isInstanceReady(cfServiceInstance),
+		Name:           cfServiceInstance.Spec.DisplayName,
+		GUID:           cfServiceInstance.Name,
+		SpaceGUID:      cfServiceInstance.Namespace,
+		PlanGUID:       cfServiceInstance.Spec.PlanGUID,
+		Tags:           cfServiceInstance.Spec.Tags,
+		Type:           string(cfServiceInstance.Spec.Type),
+		SyslogDrainURL: cfServiceInstance.Spec.SyslogDrainURL,
+		Labels:         cfServiceInstance.Labels,
+		Annotations:    cfServiceInstance.Annotations,
+		CreatedAt:      cfServiceInstance.CreationTimestamp.Time,
+		UpdatedAt:      getLastUpdatedTime(&cfServiceInstance),
+		DeletedAt:      golangTime(cfServiceInstance.DeletionTimestamp),
+		LastOperation:  cfServiceInstance.Status.LastOperation,
+		Ready:          isInstanceReady(cfServiceInstance),
 	}
 }
 
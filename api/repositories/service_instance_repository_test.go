@@ -89,7 +89,8 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Credentials: map[string]any{
 					"object": map[string]any{"a": "b"},
 				},
-				Tags: []string{"foo", "bar"},
+				Tags:           []string{"foo", "bar"},
+				SyslogDrainURL: tools.PtrTo("syslog://logs.example.org"),
 			}
 		})
 
@@ -114,6 +115,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(record.Name).To(Equal(serviceInstanceName))
 				Expect(record.Type).To(Equal("user-provided"))
 				Expect(record.Tags).To(ConsistOf([]string{"foo", "bar"}))
+				Expect(record.SyslogDrainURL).To(PointTo(Equal("syslog://logs.example.org")))
 				Expect(record.Relationships()).To(Equal(map[string]string{
 					"space": space.Name,
 				}))
@@ -137,6 +139,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(cfServiceInstance.Spec.SecretName).NotTo(BeEmpty())
 				Expect(cfServiceInstance.Spec.Type).To(BeEquivalentTo(korifiv1alpha1.UserProvidedType))
 				Expect(cfServiceInstance.Spec.Tags).To(ConsistOf("foo", "bar"))
+				Expect(cfServiceInstance.Spec.SyslogDrainURL).To(PointTo(Equal("syslog://logs.example.org")))
 			})
 
 			It("creates the credentials secret", func() {
@@ -169,6 +172,71 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		})
 	})
 
+	Describe("CreateVolumeServiceInstance", func() {
+		var (
+			serviceInstanceCreateMessage repositories.CreateVolumeSIMessage
+			record                       repositories.ServiceInstanceRecord
+			createErr                    error
+		)
+
+		BeforeEach(func() {
+			serviceInstanceCreateMessage = repositories.CreateVolumeSIMessage{
+				Name:             serviceInstanceName,
+				SpaceGUID:        space.Name,
+				SizeMB:           1024,
+				StorageClassName: tools.PtrTo("nfs"),
+				AccessMode:       "ReadWriteMany",
+				MountPath:        "/var/vcap/data/shared",
+				Tags:             []string{"foo", "bar"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = serviceInstanceRepo.CreateVolumeServiceInstance(ctx, authInfo, serviceInstanceCreateMessage)
+		})
+
+		It("returns a Forbidden error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has permissions to create ServiceInstances", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns a service instance record", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				Expect(record.GUID).To(matchers.BeValidUUID())
+				Expect(record.SpaceGUID).To(Equal(space.Name))
+				Expect(record.Name).To(Equal(serviceInstanceName))
+				Expect(record.Type).To(Equal("volume"))
+				Expect(record.Tags).To(ConsistOf("foo", "bar"))
+			})
+
+			It("creates a CFServiceInstance resource", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				cfServiceInstance := &korifiv1alpha1.CFServiceInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: record.SpaceGUID,
+						Name:      record.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), cfServiceInstance)).To(Succeed())
+
+				Expect(cfServiceInstance.Spec.DisplayName).To(Equal(serviceInstanceName))
+				Expect(cfServiceInstance.Spec.Type).To(BeEquivalentTo(korifiv1alpha1.VolumeType))
+				Expect(cfServiceInstance.Spec.Volume).To(PointTo(Equal(korifiv1alpha1.VolumeServiceSpec{
+					SizeMB:           1024,
+					StorageClassName: tools.PtrTo("nfs"),
+					AccessMode:       corev1.ReadWriteMany,
+					MountPath:        "/var/vcap/data/shared",
+				})))
+			})
+		})
+	})
+
 	Describe("CreateManagedServiceInstance", func() {
 		var (
 			servicePlan                  *korifiv1alpha1.CFServicePlan
@@ -525,11 +593,12 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
 
 			patchMessage = repositories.PatchServiceInstanceMessage{
-				GUID:        cfServiceInstance.Name,
-				SpaceGUID:   space.Name,
-				Name:        tools.PtrTo("new-name"),
-				Credentials: nil,
-				Tags:        &[]string{"new"},
+				GUID:           cfServiceInstance.Name,
+				SpaceGUID:      space.Name,
+				Name:           tools.PtrTo("new-name"),
+				Credentials:    nil,
+				Tags:           &[]string{"new"},
+				SyslogDrainURL: tools.PtrTo("syslog://new.example.org"),
 				MetadataPatch: repositories.MetadataPatch{
 					Labels:      map[string]*string{"new-label": tools.PtrTo("new-label-value")},
 					Annotations: map[string]*string{"new-annotation": tools.PtrTo("new-annotation-value")},
@@ -551,6 +620,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(serviceInstanceRecord.Name).To(Equal("new-name"))
 				Expect(serviceInstanceRecord.Tags).To(ConsistOf("new"))
+				Expect(serviceInstanceRecord.SyslogDrainURL).To(PointTo(Equal("syslog://new.example.org")))
 				Expect(serviceInstanceRecord.Labels).To(HaveLen(2))
 				Expect(serviceInstanceRecord.Labels).To(HaveKeyWithValue("a-label", "a-label-value"))
 				Expect(serviceInstanceRecord.Labels).To(HaveKeyWithValue("new-label", "new-label-value"))
@@ -567,6 +637,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
 					g.Expect(serviceInstance.Spec.DisplayName).To(Equal("new-name"))
 					g.Expect(serviceInstance.Spec.Tags).To(ConsistOf("new"))
+					g.Expect(serviceInstance.Spec.SyslogDrainURL).To(PointTo(Equal("syslog://new.example.org")))
 					g.Expect(serviceInstance.Labels).To(HaveLen(2))
 					g.Expect(serviceInstance.Labels).To(HaveKeyWithValue("a-label", "a-label-value"))
 					g.Expect(serviceInstance.Labels).To(HaveKeyWithValue("new-label", "new-label-value"))
@@ -648,6 +719,12 @@ var _ = Describe("ServiceInstanceRepository", func() {
 					}).Should(Succeed())
 				})
 
+				It("labels the credentials secret with the service instance guid", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+					Expect(secret.Labels).To(HaveKeyWithValue(repositories.CFServiceInstanceGUIDLabel, cfServiceInstance.Name))
+				})
+
 				When("the credentials secret available condition is not met", func() {
 					BeforeEach(func() {
 						conditionAwaiter.AwaitConditionReturns(&korifiv1alpha1.CFServiceInstance{}, errors.New("timed-out"))
@@ -688,7 +765,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 
 	Describe("ListServiceInstances", func() {
 		var (
-			space2                                                     *korifiv1alpha1.CFSpace
+			space2, space3                                             *korifiv1alpha1.CFSpace
 			cfServiceInstance1, cfServiceInstance2, cfServiceInstance3 *korifiv1alpha1.CFServiceInstance
 			filters                                                    repositories.ListServiceInstanceMessage
 			listErr                                                    error
@@ -747,7 +824,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			}
 			Expect(k8sClient.Create(ctx, cfServiceInstance3)).To(Succeed())
 
-			space3 := createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space3"))
+			space3 = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space3"))
 			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceInstance{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: space3.Name,
@@ -855,6 +932,43 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				})
 			})
 
+			When("the spaceGUID filter includes spaces the user has no access to", func() {
+				BeforeEach(func() {
+					filters = repositories.ListServiceInstanceMessage{
+						SpaceGUIDs: []string{
+							cfServiceInstance1.Namespace,
+							space3.Name,
+						},
+					}
+				})
+
+				It("returns only records for the ServiceInstances within the spaces the user has access to", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(serviceInstanceList).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfServiceInstance1.Name)}),
+					))
+				})
+			})
+
+			When("the type filter is set", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfServiceInstance2, func() {
+						cfServiceInstance2.Spec.Type = korifiv1alpha1.ManagedType
+					})).To(Succeed())
+
+					filters = repositories.ListServiceInstanceMessage{
+						Type: "managed",
+					}
+				})
+
+				It("returns only records for the ServiceInstances of the matching type", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(serviceInstanceList).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfServiceInstance2.Name)}),
+					))
+				})
+			})
+
 			When("the serviceGUID filter is set", func() {
 				BeforeEach(func() {
 					filters = repositories.ListServiceInstanceMessage{
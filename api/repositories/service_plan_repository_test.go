@@ -37,7 +37,7 @@ var _ = Describe("ServicePlanRepo", func() {
 			korifiv1alpha1.CFOrg,
 			korifiv1alpha1.CFOrgList,
 			*korifiv1alpha1.CFOrgList,
-		]{})
+		]{}, repositories.NewOrgSorter())
 		repo = repositories.NewServicePlanRepo(userClientFactory, rootNamespace, orgRepo)
 
 		planGUID = uuid.NewString()
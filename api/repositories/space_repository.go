@@ -1,14 +1,18 @@
 package repositories
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/compare"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -18,6 +22,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -34,6 +39,8 @@ type ListSpacesMessage struct {
 	Names             []string
 	GUIDs             []string
 	OrganizationGUIDs []string
+	LabelSelector     string
+	OrderBy           string
 }
 
 func (m *ListSpacesMessage) matches(space korifiv1alpha1.CFSpace) bool {
@@ -61,6 +68,7 @@ type SpaceRecord struct {
 	Name             string
 	GUID             string
 	OrganizationGUID string
+	ResourceVersion  string
 	Labels           map[string]string
 	Annotations      map[string]string
 	CreatedAt        time.Time
@@ -80,6 +88,50 @@ type SpaceRepo struct {
 	userClientFactory  authorization.UserClientFactory
 	nsPerms            *authorization.NamespacePermissions
 	conditionAwaiter   Awaiter[*korifiv1alpha1.CFSpace]
+	sorter             SpaceSorter
+}
+
+//counterfeiter:generate -o fake -fake-name SpaceSorter . SpaceSorter
+type SpaceSorter interface {
+	Sort(records []SpaceRecord, order string) []SpaceRecord
+}
+
+type spaceSorter struct {
+	sorter *compare.Sorter[SpaceRecord]
+}
+
+func NewSpaceSorter() *spaceSorter {
+	return &spaceSorter{
+		sorter: compare.NewSorter(SpaceComparator),
+	}
+}
+
+func (s *spaceSorter) Sort(records []SpaceRecord, order string) []SpaceRecord {
+	return s.sorter.Sort(records, order)
+}
+
+func SpaceComparator(fieldName string) func(SpaceRecord, SpaceRecord) int {
+	return func(s1, s2 SpaceRecord) int {
+		return cmp.Or(compareSpaces(fieldName, s1, s2), strings.Compare(s1.GUID, s2.GUID))
+	}
+}
+
+func compareSpaces(fieldName string, s1, s2 SpaceRecord) int {
+	switch fieldName {
+	case "", "created_at":
+		return tools.CompareTimePtr(&s1.CreatedAt, &s2.CreatedAt)
+	case "-created_at":
+		return tools.CompareTimePtr(&s2.CreatedAt, &s1.CreatedAt)
+	case "updated_at":
+		return tools.CompareTimePtr(s1.UpdatedAt, s2.UpdatedAt)
+	case "-updated_at":
+		return tools.CompareTimePtr(s2.UpdatedAt, s1.UpdatedAt)
+	case "name":
+		return strings.Compare(s1.Name, s2.Name)
+	case "-name":
+		return strings.Compare(s2.Name, s1.Name)
+	}
+	return 0
 }
 
 func NewSpaceRepo(
@@ -88,6 +140,7 @@ func NewSpaceRepo(
 	userClientFactory authorization.UserClientFactory,
 	nsPerms *authorization.NamespacePermissions,
 	conditionAwaiter Awaiter[*korifiv1alpha1.CFSpace],
+	sorter SpaceSorter,
 ) *SpaceRepo {
 	return &SpaceRepo{
 		orgRepo:            orgRepo,
@@ -95,6 +148,7 @@ func NewSpaceRepo(
 		userClientFactory:  userClientFactory,
 		nsPerms:            nsPerms,
 		conditionAwaiter:   conditionAwaiter,
+		sorter:             sorter,
 	}
 }
 
@@ -120,6 +174,12 @@ func (r *SpaceRepo) CreateSpace(ctx context.Context, info authorization.Info, me
 	}
 	err = userClient.Create(ctx, cfSpace)
 	if err != nil {
+		if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+			if validationError.Type == validation.DuplicateNameErrorType {
+				return SpaceRecord{}, apierrors.NewUniquenessError(err, validationError.GetMessage())
+			}
+		}
+
 		return SpaceRecord{}, apierrors.FromK8sError(err, SpaceResourceType)
 	}
 
@@ -147,11 +207,16 @@ func (r *SpaceRepo) ListSpaces(ctx context.Context, authInfo authorization.Info,
 		return nil, err
 	}
 
+	labelSelector, err := labels.Parse(message.LabelSelector)
+	if err != nil {
+		return []SpaceRecord{}, apierrors.NewUnprocessableEntityError(err, "invalid label selector")
+	}
+
 	orgNsList := authorizedOrgNamespaces.Filter(message.matchesNamespace).Collect()
 	cfSpaces := []korifiv1alpha1.CFSpace{}
 	for _, org := range orgNsList {
 		cfSpaceList := new(korifiv1alpha1.CFSpaceList)
-		err = userClient.List(ctx, cfSpaceList, client.InNamespace(org))
+		err = userClient.List(ctx, cfSpaceList, client.InNamespace(org), client.MatchingLabelsSelector{Selector: labelSelector})
 		if k8serrors.IsForbidden(err) {
 			continue
 		}
@@ -166,7 +231,7 @@ func (r *SpaceRepo) ListSpaces(ctx context.Context, authInfo authorization.Info,
 		return authorizedSpaceNamespaces[s.Name] && message.matches(s)
 	})
 
-	return slices.Collect(it.Map(filteredSpaces, cfSpaceToSpaceRecord)), nil
+	return r.sorter.Sort(slices.Collect(it.Map(filteredSpaces, cfSpaceToSpaceRecord)), message.OrderBy), nil
 }
 
 func (r *SpaceRepo) GetSpace(ctx context.Context, info authorization.Info, spaceGUID string) (SpaceRecord, error) {
@@ -194,6 +259,7 @@ func cfSpaceToSpaceRecord(cfSpace korifiv1alpha1.CFSpace) SpaceRecord {
 		Name:             cfSpace.Spec.DisplayName,
 		GUID:             cfSpace.Name,
 		OrganizationGUID: cfSpace.Namespace,
+		ResourceVersion:  cfSpace.ResourceVersion,
 		Annotations:      cfSpace.Annotations,
 		Labels:           cfSpace.Labels,
 		CreatedAt:        cfSpace.CreationTimestamp.Time,
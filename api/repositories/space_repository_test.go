@@ -7,6 +7,7 @@ import (
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
@@ -16,6 +17,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	gomega_types "github.com/onsi/gomega/types"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +33,7 @@ var _ = Describe("SpaceRepository", func() {
 			korifiv1alpha1.CFSpaceList,
 			*korifiv1alpha1.CFSpaceList,
 		]
+		sorter    *fake.SpaceSorter
 		spaceRepo *repositories.SpaceRepo
 	)
 
@@ -40,7 +43,7 @@ var _ = Describe("SpaceRepository", func() {
 			korifiv1alpha1.CFOrg,
 			korifiv1alpha1.CFOrgList,
 			*korifiv1alpha1.CFOrgList,
-		]{})
+		]{}, repositories.NewOrgSorter())
 
 		conditionAwaiter = &fakeawaiter.FakeAwaiter[
 			*korifiv1alpha1.CFSpace,
@@ -48,7 +51,11 @@ var _ = Describe("SpaceRepository", func() {
 			korifiv1alpha1.CFSpaceList,
 			*korifiv1alpha1.CFSpaceList,
 		]{}
-		spaceRepo = repositories.NewSpaceRepo(namespaceRetriever, orgRepo, userClientFactory, nsPerms, conditionAwaiter)
+		sorter = new(fake.SpaceSorter)
+		sorter.SortStub = func(records []repositories.SpaceRecord, _ string) []repositories.SpaceRecord {
+			return records
+		}
+		spaceRepo = repositories.NewSpaceRepo(namespaceRetriever, orgRepo, userClientFactory, nsPerms, conditionAwaiter, sorter)
 	})
 
 	Describe("CreateSpace", func() {
@@ -231,6 +238,19 @@ var _ = Describe("SpaceRepository", func() {
 			))
 		})
 
+		It("sorts the spaces", func() {
+			_, err := spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{OrderBy: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sorter.SortCallCount()).To(Equal(1))
+			sortedSpaces, field := sorter.SortArgsForCall(0)
+			Expect(field).To(Equal("foo"))
+			Expect(sortedSpaces).To(ContainElements(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(space11.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(space22.Name)}),
+			))
+		})
+
 		When("the space anchor is not ready", func() {
 			BeforeEach(func() {
 				meta.SetStatusCondition(&(space11.Status.Conditions), metav1.Condition{
@@ -317,6 +337,58 @@ var _ = Describe("SpaceRepository", func() {
 			})
 		})
 
+		Describe("filtering by label selector", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, space11, func() {
+					space11.Labels = map[string]string{"foo": "FOO1"}
+				})).To(Succeed())
+				Expect(k8s.PatchResource(ctx, k8sClient, space12, func() {
+					space12.Labels = map[string]string{"foo": "FOO2"}
+				})).To(Succeed())
+				Expect(k8s.PatchResource(ctx, k8sClient, space21, func() {
+					space21.Labels = map[string]string{"not_foo": "NOT_FOO"}
+				})).To(Succeed())
+			})
+
+			DescribeTable("valid label selectors",
+				func(selector string, spaceNames ...string) {
+					spaces, err := spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{
+						OrganizationGUIDs: []string{cfOrg1.Name, cfOrg2.Name},
+						LabelSelector:     selector,
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					spacesByName := map[string]*korifiv1alpha1.CFSpace{
+						"space11": space11,
+						"space12": space12,
+						"space21": space21,
+						"space22": space22,
+					}
+
+					matchers := []any{}
+					for _, name := range spaceNames {
+						matchers = append(matchers, MatchFields(IgnoreExtras, Fields{"GUID": Equal(spacesByName[name].Name)}))
+					}
+
+					Expect(spaces).To(ConsistOf(matchers...))
+				},
+				Entry("key", "foo", "space11", "space12"),
+				Entry("!key", "!foo", "space21", "space22"),
+				Entry("key=value", "foo=FOO1", "space11"),
+				Entry("key==value", "foo==FOO2", "space12"),
+				Entry("key!=value", "foo!=FOO1", "space12", "space21", "space22"),
+				Entry("key in (value1,value2)", "foo in (FOO1,FOO2)", "space11", "space12"),
+				Entry("key notin (value1,value2)", "foo notin (FOO2)", "space11", "space21", "space22"),
+			)
+
+			When("the label selector is invalid", func() {
+				It("returns an unprocessable entity error", func() {
+					_, err := spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{LabelSelector: "~"})
+					Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+
 		When("filtering by org guids, space names and space guids", func() {
 			It("only returns the spaces matching the specified names", func() {
 				spaces, err := spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{
@@ -397,6 +469,7 @@ var _ = Describe("SpaceRepository", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(spaceRecord.Name).To(Equal("the-space"))
 				Expect(spaceRecord.OrganizationGUID).To(Equal(cfOrg.Name))
+				Expect(spaceRecord.ResourceVersion).NotTo(BeEmpty())
 			})
 		})
 
@@ -738,3 +811,51 @@ var _ = Describe("SpaceRepository", func() {
 		})
 	})
 })
+
+var _ = DescribeTable("SpaceSorter",
+	func(s1, s2 repositories.SpaceRecord, field string, match gomega_types.GomegaMatcher) {
+		Expect(repositories.SpaceComparator(field)(s1, s2)).To(match)
+	},
+	Entry("default sorting",
+		repositories.SpaceRecord{CreatedAt: time.UnixMilli(1)},
+		repositories.SpaceRecord{CreatedAt: time.UnixMilli(2)},
+		"",
+		BeNumerically("<", 0),
+	),
+	Entry("name",
+		repositories.SpaceRecord{Name: "first-space"},
+		repositories.SpaceRecord{Name: "second-space"},
+		"name",
+		BeNumerically("<", 0),
+	),
+	Entry("-name",
+		repositories.SpaceRecord{Name: "first-space"},
+		repositories.SpaceRecord{Name: "second-space"},
+		"-name",
+		BeNumerically(">", 0),
+	),
+	Entry("created_at",
+		repositories.SpaceRecord{CreatedAt: time.UnixMilli(1)},
+		repositories.SpaceRecord{CreatedAt: time.UnixMilli(2)},
+		"created_at",
+		BeNumerically("<", 0),
+	),
+	Entry("-created_at",
+		repositories.SpaceRecord{CreatedAt: time.UnixMilli(1)},
+		repositories.SpaceRecord{CreatedAt: time.UnixMilli(2)},
+		"-created_at",
+		BeNumerically(">", 0),
+	),
+	Entry("updated_at",
+		repositories.SpaceRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(1))},
+		repositories.SpaceRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(2))},
+		"updated_at",
+		BeNumerically("<", 0),
+	),
+	Entry("equal keys fall back to the guid",
+		repositories.SpaceRecord{Name: "space", GUID: "a-guid"},
+		repositories.SpaceRecord{Name: "space", GUID: "b-guid"},
+		"name",
+		BeNumerically("<", 0),
+	),
+)
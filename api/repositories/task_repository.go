@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"slices"
@@ -60,17 +61,24 @@ type CreateTaskMessage struct {
 	Command   string
 	SpaceGUID string
 	AppGUID   string
+	Name      string
+	MemoryMB  int64
+	DiskMB    int64
 	Metadata
 }
 
 type ListTaskMessage struct {
 	AppGUIDs    []string
+	SpaceGUIDs  []string
+	States      []string
 	SequenceIDs []int64
 }
 
 func (m *ListTaskMessage) matches(task korifiv1alpha1.CFTask) bool {
 	return tools.EmptyOrContains(m.SequenceIDs, task.Status.SequenceID) &&
-		tools.EmptyOrContains(m.AppGUIDs, task.Spec.AppRef.Name)
+		tools.EmptyOrContains(m.AppGUIDs, task.Spec.AppRef.Name) &&
+		tools.EmptyOrContains(m.SpaceGUIDs, task.Namespace) &&
+		tools.EmptyOrContains(m.States, toRecordState(&task))
 }
 
 type PatchTaskMetadataMessage struct {
@@ -92,6 +100,9 @@ func (m *CreateTaskMessage) toCFTask() *korifiv1alpha1.CFTask {
 			AppRef: v1.LocalObjectReference{
 				Name: m.AppGUID,
 			},
+			DisplayName: m.Name,
+			MemoryMB:    m.MemoryMB,
+			DiskQuotaMB: m.DiskMB,
 		},
 	}
 }
@@ -241,7 +252,7 @@ func (r *TaskRepo) PatchTaskMetadata(ctx context.Context, authInfo authorization
 
 func taskToRecord(task korifiv1alpha1.CFTask) TaskRecord {
 	taskRecord := TaskRecord{
-		Name:        task.Name,
+		Name:        cmp.Or(task.Spec.DisplayName, task.Name),
 		GUID:        task.Name,
 		SpaceGUID:   task.Namespace,
 		Command:     task.Spec.Command,
@@ -143,6 +143,25 @@ var _ = Describe("TaskRepository", func() {
 				Expect(taskRecord.Annotations).To(Equal(map[string]string{"extra-bugs": "true"}))
 			})
 
+			When("the task has a name and resource limits", func() {
+				BeforeEach(func() {
+					createMessage.Name = "migrate"
+					createMessage.MemoryMB = 256
+					createMessage.DiskMB = 128
+				})
+
+				It("sets them on the task spec", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(taskRecord.Name).To(Equal("migrate"))
+
+					cfTask := &korifiv1alpha1.CFTask{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: taskRecord.GUID}, cfTask)).To(Succeed())
+					Expect(cfTask.Spec.DisplayName).To(Equal("migrate"))
+					Expect(cfTask.Spec.MemoryMB).To(BeEquivalentTo(256))
+					Expect(cfTask.Spec.DiskQuotaMB).To(BeEquivalentTo(128))
+				})
+			})
+
 			When("the task never becomes initialized", func() {
 				BeforeEach(func() {
 					conditionAwaiter.AwaitConditionReturns(&korifiv1alpha1.CFTask{}, errors.New("timed-out-error"))
@@ -510,6 +529,61 @@ var _ = Describe("TaskRepository", func() {
 						Expect(listedTasks).To(BeEmpty())
 					})
 				})
+
+				When("filtering by space", func() {
+					var task3 *korifiv1alpha1.CFTask
+
+					BeforeEach(func() {
+						cfApp3 := createApp(space.Name)
+						task3 = &korifiv1alpha1.CFTask{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      prefixedGUID("task3"),
+								Namespace: space.Name,
+								Labels: map[string]string{
+									korifiv1alpha1.SpaceGUIDKey: space.Name,
+								},
+							},
+							Spec: korifiv1alpha1.CFTaskSpec{
+								Command: "echo hello",
+								AppRef: corev1.LocalObjectReference{
+									Name: cfApp3.Name,
+								},
+							},
+						}
+						Expect(k8sClient.Create(ctx, task3)).To(Succeed())
+
+						listTaskMsg.SpaceGUIDs = []string{space.Name}
+					})
+
+					It("lists the tasks of all apps in that space", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(listedTasks).To(ConsistOf(
+							gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"Name": Equal(task1.Name)}),
+							gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"Name": Equal(task3.Name)}),
+						))
+					})
+
+					When("filtering by state as well", func() {
+						BeforeEach(func() {
+							Expect(k8s.Patch(ctx, k8sClient, task3, func() {
+								meta.SetStatusCondition(&task3.Status.Conditions, metav1.Condition{
+									Type:    korifiv1alpha1.TaskSucceededConditionType,
+									Status:  metav1.ConditionTrue,
+									Reason:  "foo",
+									Message: "bar",
+								})
+							})).To(Succeed())
+
+							listTaskMsg.States = []string{repositories.TaskStateSucceeded}
+						})
+
+						It("lists only the tasks in that state", func() {
+							Expect(listErr).NotTo(HaveOccurred())
+							Expect(listedTasks).To(HaveLen(1))
+							Expect(listedTasks[0].Name).To(Equal(task3.Name))
+						})
+					})
+				})
 			})
 		})
 	})
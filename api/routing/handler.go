@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/presenter"
@@ -15,9 +18,17 @@ import (
 type Response struct {
 	httpStatus int
 	body       interface{}
+	stream     StreamFunc
+	upgrade    http.Handler
 	headers    map[string][]string
+	etag       string
 }
 
+// StreamFunc writes a response body incrementally. It is called once the
+// status and headers have been sent, and should call flush whenever the data
+// written so far is to be pushed to the client.
+type StreamFunc func(w io.Writer, flush func()) error
+
 func NewResponse(httpStatus int) *Response {
 	return &Response{
 		httpStatus: httpStatus,
@@ -35,6 +46,30 @@ func (r *Response) WithBody(body interface{}) *Response {
 	return r
 }
 
+func (r *Response) WithStream(stream StreamFunc) *Response {
+	r.stream = stream
+	return r
+}
+
+// WithUpgrade hands the request over to the given handler instead of writing
+// a response, for requests that switch protocols such as proxied exec
+// sessions. The status and headers of the response are ignored.
+func (r *Response) WithUpgrade(handler http.Handler) *Response {
+	r.upgrade = handler
+	return r
+}
+
+// WithETag tags the response with a weak ETag derived from the resource
+// version of the presented object. Requests with a matching If-None-Match
+// header get a 304 Not Modified instead of the body. An empty version leaves
+// the response untagged.
+func (r *Response) WithETag(resourceVersion string) *Response {
+	if resourceVersion != "" {
+		r.etag = `W/"` + resourceVersion + `"`
+	}
+	return r
+}
+
 //counterfeiter:generate -o fake -fake-name Handler . Handler
 
 type Handler func(r *http.Request) (*Response, error)
@@ -49,6 +84,17 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if handlerResponse.upgrade != nil {
+		handlerResponse.serveUpgrade(w, r)
+		return
+	}
+
+	if handlerResponse.notModified(r) {
+		w.Header().Set("ETag", handlerResponse.etag)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	if err := handlerResponse.writeTo(w); err != nil {
 		_ = apierrors.LogAndReturn(logger, err, "failed to write result to the HTTP response", "handlerResponse", handlerResponse, "method", r.Method, "URL", r.URL)
 	}
@@ -79,13 +125,40 @@ func PresentError(logger logr.Logger, w http.ResponseWriter, err error) {
 	PresentError(logger, w, apierrors.NewUnknownError(err))
 }
 
+func (response *Response) notModified(r *http.Request) bool {
+	if response.etag == "" || response.httpStatus != http.StatusOK {
+		return false
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(response.etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (response *Response) writeTo(w http.ResponseWriter) error {
+	if response.etag != "" {
+		w.Header().Set("ETag", response.etag)
+	}
+
 	for header, headerValues := range response.headers {
 		for _, value := range headerValues {
 			w.Header().Add(header, value)
 		}
 	}
 
+	if response.stream != nil {
+		return response.writeStreamTo(w)
+	}
+
 	if response.body == nil {
 		w.WriteHeader(response.httpStatus)
 		return nil
@@ -104,3 +177,33 @@ func (response *Response) writeTo(w http.ResponseWriter) error {
 
 	return nil
 }
+
+func (response *Response) serveUpgrade(w http.ResponseWriter, r *http.Request) {
+	// upgraded connections outlive the server timeouts, ignore the errors for
+	// writers that do not support deadlines
+	responseController := http.NewResponseController(w)
+	_ = responseController.SetReadDeadline(time.Time{})
+	_ = responseController.SetWriteDeadline(time.Time{})
+
+	response.upgrade.ServeHTTP(w, r)
+}
+
+func (response *Response) writeStreamTo(w http.ResponseWriter) error {
+	responseController := http.NewResponseController(w)
+	// streams outlive the server write timeout, ignore the error for writers
+	// that do not support deadlines
+	_ = responseController.SetWriteDeadline(time.Time{})
+
+	flush := func() {
+		_ = responseController.Flush()
+	}
+
+	w.WriteHeader(response.httpStatus)
+	flush()
+
+	if err := response.stream(w, flush); err != nil {
+		return fmt.Errorf("failed to stream response: %w", err)
+	}
+
+	return nil
+}
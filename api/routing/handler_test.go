@@ -2,6 +2,7 @@ package routing_test
 
 import (
 	"errors"
+	"io"
 	"net/http"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -16,6 +17,7 @@ var _ = Describe("Handler", func() {
 		delegate *fake.Handler
 		handler  routing.Handler
 		response *routing.Response
+		req      *http.Request
 	)
 
 	BeforeEach(func() {
@@ -23,11 +25,13 @@ var _ = Describe("Handler", func() {
 		delegate = new(fake.Handler)
 		delegate.Returns(response, nil)
 		handler = delegate.Spy
+
+		var err error
+		req, err = http.NewRequest("GET", "/foo", nil)
+		Expect(err).NotTo(HaveOccurred())
 	})
 
 	JustBeforeEach(func() {
-		req, err := http.NewRequest("GET", "/foo", nil)
-		Expect(err).NotTo(HaveOccurred())
 		handler.ServeHTTP(rr, req)
 	})
 
@@ -59,6 +63,51 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	When("the response is streamed", func() {
+		BeforeEach(func() {
+			response = response.WithStream(func(w io.Writer, flush func()) error {
+				_, err := io.WriteString(w, "hello\n")
+				flush()
+				return err
+			})
+		})
+
+		It("writes the streamed body", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			Expect(rr).To(HaveHTTPBody("hello\n"))
+		})
+
+		It("flushes the response", func() {
+			Expect(rr.Flushed).To(BeTrue())
+		})
+
+		It("does not set content type header on the response", func() {
+			Expect(rr.Header()).NotTo(HaveKey("Content-Type"))
+		})
+	})
+
+	When("the response is an upgrade", func() {
+		var upgradeReq *http.Request
+
+		BeforeEach(func() {
+			upgradeReq = nil
+			response = response.WithHeader("Location", "/home").WithUpgrade(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				upgradeReq = r
+				w.WriteHeader(http.StatusSwitchingProtocols)
+			}))
+		})
+
+		It("hands the request over to the upgrade handler", func() {
+			Expect(upgradeReq).NotTo(BeNil())
+			Expect(upgradeReq.URL.Path).To(Equal("/foo"))
+			Expect(rr).To(HaveHTTPStatus(http.StatusSwitchingProtocols))
+		})
+
+		It("does not write the response headers", func() {
+			Expect(rr.Header()).NotTo(HaveKey("Location"))
+		})
+	})
+
 	When("the response sets header values", func() {
 		BeforeEach(func() {
 			response = response.WithHeader("Location", "/home")
@@ -71,6 +120,87 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	When("the response has an ETag", func() {
+		BeforeEach(func() {
+			response = routing.NewResponse(http.StatusOK).
+				WithBody(map[string]string{"hello": "world"}).
+				WithETag("123")
+			delegate.Returns(response, nil)
+		})
+
+		It("sets the ETag header", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("ETag", `W/"123"`))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{"hello":"world"}`)))
+		})
+
+		When("the request has a matching If-None-Match header", func() {
+			BeforeEach(func() {
+				req.Header.Set("If-None-Match", `W/"abc", W/"123"`)
+			})
+
+			It("returns 304 without a body", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusNotModified))
+				Expect(rr).To(HaveHTTPHeaderWithValue("ETag", `W/"123"`))
+				Expect(rr.Body.Len()).To(BeZero())
+			})
+		})
+
+		When("the If-None-Match header uses the strong form", func() {
+			BeforeEach(func() {
+				req.Header.Set("If-None-Match", `"123"`)
+			})
+
+			It("compares weakly and returns 304", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusNotModified))
+			})
+		})
+
+		When("the If-None-Match header is a wildcard", func() {
+			BeforeEach(func() {
+				req.Header.Set("If-None-Match", "*")
+			})
+
+			It("returns 304", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusNotModified))
+			})
+		})
+
+		When("the If-None-Match header does not match", func() {
+			BeforeEach(func() {
+				req.Header.Set("If-None-Match", `W/"122"`)
+			})
+
+			It("returns the full response", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{"hello":"world"}`)))
+			})
+		})
+
+		When("the request is not a read", func() {
+			BeforeEach(func() {
+				req.Method = http.MethodPatch
+				req.Header.Set("If-None-Match", `W/"123"`)
+			})
+
+			It("returns the full response", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			})
+		})
+	})
+
+	When("the response has an empty ETag", func() {
+		BeforeEach(func() {
+			response = response.WithETag("")
+			req.Header.Set("If-None-Match", "*")
+		})
+
+		It("does not tag the response", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			Expect(rr.Header()).NotTo(HaveKey("Etag"))
+		})
+	})
+
 	When("the delegate returns an unknown error", func() {
 		BeforeEach(func() {
 			delegate.Stub = func(*http.Request) (*routing.Response, error) {
@@ -14,7 +14,7 @@ func Get[T resource](objects []T) (T, error) {
 	var t T
 
 	if len(objects) == 0 {
-		return t, apierrors.NewNotFoundError(nil, fmt.Sprintf("%T", t))
+		return t, apierrors.NewNotFoundError(nil, t.GetResourceType())
 	}
 
 	if len(objects) > 1 {
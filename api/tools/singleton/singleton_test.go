@@ -43,6 +43,7 @@ var _ = Describe("Get", func() {
 
 		It("returns a not found error", func() {
 			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			Expect(err.(apierrors.NotFoundError).Detail()).To(Equal("my-type not found. Ensure it exists and you have access to it."))
 		})
 	})
 
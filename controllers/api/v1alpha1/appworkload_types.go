@@ -43,6 +43,27 @@ type AppWorkloadSpec struct {
 	ReadinessProbe *corev1.Probe   `json:"readinessProbe,omitempty"`
 	Ports          []int32         `json:"ports,omitempty"`
 
+	// The number of seconds instances keep serving after being marked for termination, so that routes can be deregistered
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	ConnectionDrainTimeoutSeconds *int32 `json:"connectionDrainTimeoutSeconds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	EphemeralVolumes []EphemeralVolume `json:"ephemeralVolumes,omitempty"`
+
+	// Persistent volume claims of bound volume services
+	// +kubebuilder:validation:Optional
+	PersistentVolumes []PersistentVolumeMount `json:"persistentVolumes,omitempty"`
+
+	// Additional containers to run next to the app container
+	// +kubebuilder:validation:Optional
+	Sidecars []AppWorkloadSidecar `json:"sidecars,omitempty"`
+
+	// The name of the secret holding the VCAP_SERVICES of the app. It is
+	// projected into the app container as a file
+	// +kubebuilder:validation:Optional
+	VCAPServicesSecretName string `json:"vcapServicesSecretName,omitempty"`
+
 	// +kubebuilder:default:=1
 	Instances int32 `json:"instances"`
 
@@ -54,6 +75,29 @@ type AppWorkloadSpec struct {
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
+// PersistentVolumeMount describes a persistent volume claim mounted into the app container
+type PersistentVolumeMount struct {
+	// The name of the pod volume
+	Name string `json:"name"`
+
+	// The name of the persistent volume claim in the workload namespace
+	ClaimName string `json:"claimName"`
+
+	// The absolute path the claim is mounted at
+	MountPath string `json:"mountPath"`
+}
+
+// AppWorkloadSidecar describes a container running the app image next to the app container
+type AppWorkloadSidecar struct {
+	// The name of the sidecar, unique within the workload
+	Name string `json:"name"`
+
+	Command []string `json:"command"`
+
+	// The memory limit in MiB
+	MemoryMB int64 `json:"memoryMB"`
+}
+
 // AppWorkloadStatus defines the observed state of AppWorkload
 type AppWorkloadStatus struct {
 	//+kubebuilder:validation:Optional
@@ -64,6 +108,11 @@ type AppWorkloadStatus struct {
 
 	//+kubebuilder:validation:Optional
 	ActualInstances int32 `json:"actualInstances"`
+
+	// The number of ready instances running the current spec. Instances
+	// still running a previous spec during a rollout are not counted
+	//+kubebuilder:validation:Optional
+	ReadyInstances int32 `json:"readyInstances,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -37,6 +37,12 @@ type CFOrgSpec struct {
 	// The mutable, user-friendly name of the CFOrg. Unlike metadata.name, the user can change this field.
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// Annotations stamped onto the app workloads in the org, e.g. for cost allocation.
+	// They override the cluster-wide defaults and are overridden by the space defaults.
+	// Keys in the kubernetes.io, k8s.io and cloudfoundry.org domains are reserved.
+	//+kubebuilder:validation:Optional
+	DefaultAppAnnotations map[string]string `json:"defaultAppAnnotations,omitempty"`
 }
 
 // CFOrgStatus defines the observed state of CFOrg
@@ -23,6 +23,20 @@ import (
 
 const (
 	CFPackageFinalizerName = "korifi.cloudfoundry.org/cfPackageController"
+
+	// CFPackageCopySourceGUIDAnnotation and CFPackageCopySourceNamespaceAnnotation
+	// reference the package a package is a copy of. The source of the
+	// referenced package is copied onto the annotated package.
+	CFPackageCopySourceGUIDAnnotation      = "korifi.cloudfoundry.org/copy-source-package-guid"
+	CFPackageCopySourceNamespaceAnnotation = "korifi.cloudfoundry.org/copy-source-package-namespace"
+
+	// CFPackageRepositoryAnnotation records the repository the bits of a
+	// package are uploaded to
+	CFPackageRepositoryAnnotation = "korifi.cloudfoundry.org/package-repository"
+
+	// CFPackageCopyFailedReason is the reason of the Ready condition of
+	// packages whose source could not be copied
+	CFPackageCopyFailedReason = "CopyFailed"
 )
 
 // CFPackageSpec defines the desired state of CFPackage
@@ -17,14 +17,40 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	ProcessTypeWeb = "web"
+
+	// ServiceVolumeNamePrefix prefixes the names of the pod volumes of the
+	// volume service bindings of an app
+	ServiceVolumeNamePrefix = "service-"
+	// VCAPServicesVolumeName is the name of the pod volume the VCAP_SERVICES
+	// file is projected from
+	VCAPServicesVolumeName = "vcap-services"
 )
 
+// systemMountPaths are the paths the platform mounts into (or relies on in)
+// app containers. Volumes must not shadow them.
+var systemMountPaths = []string{
+	"/bindings",
+	"/cnb",
+	"/dev",
+	"/etc",
+	"/layers",
+	"/proc",
+	"/sys",
+	"/var/run/secrets",
+	"/workspace",
+}
+
 // CFProcessSpec defines the desired state of CFProcess
 type CFProcessSpec struct {
 	// A reference to the CFApp that owns this CFProcess. The CFApp must be in the same namespace.
@@ -51,12 +77,40 @@ type CFProcessSpec struct {
 	// The disk limit in MiB
 	DiskQuotaMB int64 `json:"diskQuotaMB"`
 
+	// The number of seconds instances are given to drain in-flight connections before they are sent a SIGTERM.
+	// Zero disables draining, the configured default is used when unset
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	ConnectionDrainTimeoutSeconds *int32 `json:"connectionDrainTimeoutSeconds,omitempty"`
+
+	// Scratch volumes to mount into the process containers. Their content does not survive an instance restart
+	// +kubebuilder:validation:Optional
+	EphemeralVolumes []EphemeralVolume `json:"ephemeralVolumes,omitempty"`
+
+	// Additional processes to run in the process instances next to the app. Their memory is taken out of the process memory
+	// +kubebuilder:validation:Optional
+	Sidecars []Sidecar `json:"sidecars,omitempty"`
+
 	// The ports to expose
 	// Deprecated: No longer used
 	// +kubebuilder:validation:Optional
 	Ports []int32 `json:"ports,omitempty"`
 }
 
+// EphemeralVolume describes an emptyDir volume mounted into the app container
+type EphemeralVolume struct {
+	// The name of the volume, unique within the process
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+
+	// The absolute path the volume is mounted at
+	MountPath string `json:"mountPath"`
+
+	// The maximum size of the volume in MiB
+	// +kubebuilder:validation:Minimum=1
+	SizeLimitMB int64 `json:"sizeLimitMB"`
+}
+
 type HealthCheck struct {
 	// The type of Health Check the App process will use
 	// Valid values are "http", "port", and "process".
@@ -80,6 +134,24 @@ type HealthCheckData struct {
 	TimeoutSeconds           int32 `json:"timeoutSeconds"`
 }
 
+// Sidecar describes an additional process run in the instances of the app processes
+type Sidecar struct {
+	// The name of the sidecar, unique within the process
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+
+	// The command used to start the sidecar
+	Command string `json:"command"`
+
+	// The types of the app processes the sidecar runs alongside. The sidecar is not run in processes of other types
+	// +kubebuilder:validation:MinItems=1
+	ProcessTypes []string `json:"processTypes"`
+
+	// The memory limit in MiB
+	// +kubebuilder:validation:Minimum=1
+	MemoryMB int64 `json:"memoryMB"`
+}
+
 // CFProcessStatus defines the observed state of CFProcess
 type CFProcessStatus struct {
 	//+kubebuilder:validation:Optional
@@ -119,6 +191,87 @@ func (p *CFProcess) StatusConditions() *[]metav1.Condition {
 	return &p.Status.Conditions
 }
 
+// ProcessSidecars returns the sidecars that run alongside the process, i.e.
+// the ones listing its type
+func (p *CFProcess) ProcessSidecars() []Sidecar {
+	sidecars := []Sidecar{}
+	for _, sidecar := range p.Spec.Sidecars {
+		if slices.Contains(sidecar.ProcessTypes, p.Spec.ProcessType) {
+			sidecars = append(sidecars, sidecar)
+		}
+	}
+
+	return sidecars
+}
+
+// SidecarsMemoryMB returns the memory the sidecars running alongside the
+// process take out of the process memory
+func (p *CFProcess) SidecarsMemoryMB() int64 {
+	var memoryMB int64
+	for _, sidecar := range p.ProcessSidecars() {
+		memoryMB += sidecar.MemoryMB
+	}
+
+	return memoryMB
+}
+
+// ValidateVolumes checks that the ephemeral volumes of the process, together
+// with the persistent volumes of the app service bindings, have unique names
+// and mount paths and do not shadow the volumes and paths of the platform
+func (p *CFProcess) ValidateVolumes(persistentVolumes []PersistentVolumeMount) error {
+	names := map[string]bool{}
+	mountPaths := map[string]bool{}
+
+	validate := func(name, mountPath string) error {
+		if names[name] {
+			return fmt.Errorf("volume name %q is used more than once", name)
+		}
+		names[name] = true
+
+		if !path.IsAbs(mountPath) || path.Clean(mountPath) != mountPath {
+			return fmt.Errorf("volume %q mount path %q must be a clean absolute path", name, mountPath)
+		}
+
+		if mountPaths[mountPath] {
+			return fmt.Errorf("mount path %q is used more than once", mountPath)
+		}
+		mountPaths[mountPath] = true
+
+		for _, systemPath := range systemMountPaths {
+			if isSameOrNested(mountPath, systemPath) {
+				return fmt.Errorf("volume %q mount path %q collides with system path %q", name, mountPath, systemPath)
+			}
+		}
+
+		return nil
+	}
+
+	for _, volume := range p.Spec.EphemeralVolumes {
+		if volume.Name == VCAPServicesVolumeName || strings.HasPrefix(volume.Name, ServiceVolumeNamePrefix) {
+			return fmt.Errorf("volume name %q is reserved", volume.Name)
+		}
+
+		if err := validate(volume.Name, volume.MountPath); err != nil {
+			return err
+		}
+	}
+
+	for _, volume := range persistentVolumes {
+		if err := validate(volume.Name, volume.MountPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isSameOrNested tells whether one of the paths is the same as or contains
+// the other one
+func isSameOrNested(a, b string) bool {
+	return a == b || a == "/" || b == "/" ||
+		strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
+
 func init() {
 	SchemeBuilder.Register(&CFProcess{}, &CFProcessList{})
 }
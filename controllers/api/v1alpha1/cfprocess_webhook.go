@@ -29,16 +29,20 @@ import (
 var cfprocesslog = logf.Log.WithName("cfprocess-resource")
 
 type CFProcessDefaulter struct {
-	defaultMemoryMB    int64
-	defaultDiskQuotaMB int64
-	defaultTimeout     int32
+	defaultMemoryMB               int64
+	defaultDiskQuotaMB            int64
+	defaultWebInstances           int32
+	defaultTimeout                int32
+	defaultConnectionDrainTimeout int32
 }
 
-func NewCFProcessDefaulter(defaultMemoryMB, defaultDiskQuotaMB int64, defaultTimeout int32) *CFProcessDefaulter {
+func NewCFProcessDefaulter(defaultMemoryMB, defaultDiskQuotaMB int64, defaultWebInstances, defaultTimeout, defaultConnectionDrainTimeout int32) *CFProcessDefaulter {
 	return &CFProcessDefaulter{
-		defaultMemoryMB:    defaultMemoryMB,
-		defaultDiskQuotaMB: defaultDiskQuotaMB,
-		defaultTimeout:     defaultTimeout,
+		defaultMemoryMB:               defaultMemoryMB,
+		defaultDiskQuotaMB:            defaultDiskQuotaMB,
+		defaultWebInstances:           defaultWebInstances,
+		defaultTimeout:                defaultTimeout,
+		defaultConnectionDrainTimeout: defaultConnectionDrainTimeout,
 	}
 }
 
@@ -59,6 +63,7 @@ func (d *CFProcessDefaulter) Default(ctx context.Context, obj runtime.Object) er
 	d.defaultResources(process)
 	d.defaultInstances(process)
 	d.defaultHealthCheck(process)
+	d.defaultConnectionDraining(process)
 
 	return nil
 }
@@ -87,6 +92,8 @@ func (d *CFProcessDefaulter) defaultResources(process *CFProcess) {
 	}
 }
 
+// defaultInstances only scales up web processes by default, other process
+// types have to be scaled explicitly, as in CF.
 func (d *CFProcessDefaulter) defaultInstances(process *CFProcess) {
 	if process.Spec.DesiredInstances != nil {
 		return
@@ -94,7 +101,7 @@ func (d *CFProcessDefaulter) defaultInstances(process *CFProcess) {
 
 	defaultInstances := int32(0)
 	if process.Spec.ProcessType == ProcessTypeWeb {
-		defaultInstances = 1
+		defaultInstances = d.defaultWebInstances
 	}
 	process.Spec.DesiredInstances = tools.PtrTo[int32](defaultInstances)
 }
@@ -115,3 +122,9 @@ func (d *CFProcessDefaulter) defaultHealthCheck(process *CFProcess) {
 
 	process.Spec.HealthCheck.Type = "process"
 }
+
+func (d *CFProcessDefaulter) defaultConnectionDraining(process *CFProcess) {
+	if process.Spec.ConnectionDrainTimeoutSeconds == nil {
+		process.Spec.ConnectionDrainTimeoutSeconds = tools.PtrTo(d.defaultConnectionDrainTimeout)
+	}
+}
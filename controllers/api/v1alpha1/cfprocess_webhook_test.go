@@ -91,6 +91,18 @@ var _ = Describe("CFProcessMutatingWebhook", func() {
 			})
 		})
 
+		When("the process already has memory and disk values set", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.MemoryMB = 42
+				cfProcess.Spec.DiskQuotaMB = 43
+			})
+
+			It("preserves them", func() {
+				Expect(cfProcess.Spec.MemoryMB).To(BeEquivalentTo(42))
+				Expect(cfProcess.Spec.DiskQuotaMB).To(BeEquivalentTo(43))
+			})
+		})
+
 		When("the process already has a timeout value set", func() {
 			BeforeEach(func() {
 				cfProcess.Spec.HealthCheck.Data.TimeoutSeconds = 16
@@ -104,6 +116,32 @@ var _ = Describe("CFProcessMutatingWebhook", func() {
 		})
 	})
 
+	Describe("connection drain timeout", func() {
+		It("sets the configured default connection drain timeout", func() {
+			Expect(cfProcess.Spec.ConnectionDrainTimeoutSeconds).To(gstruct.PointTo(BeEquivalentTo(defaultConnectionDrainTimeout)))
+		})
+
+		When("the process already has a connection drain timeout set", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.ConnectionDrainTimeoutSeconds = tools.PtrTo[int32](25)
+			})
+
+			It("preserves it", func() {
+				Expect(cfProcess.Spec.ConnectionDrainTimeoutSeconds).To(gstruct.PointTo(BeEquivalentTo(25)))
+			})
+		})
+
+		When("the process disables connection draining", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.ConnectionDrainTimeoutSeconds = tools.PtrTo[int32](0)
+			})
+
+			It("keeps it disabled", func() {
+				Expect(cfProcess.Spec.ConnectionDrainTimeoutSeconds).To(gstruct.PointTo(BeEquivalentTo(0)))
+			})
+		})
+	})
+
 	Describe("instances", func() {
 		It("defaults desired instances to zero", func() {
 			Expect(cfProcess.Spec.DesiredInstances).To(gstruct.PointTo(BeZero()))
@@ -124,8 +162,18 @@ var _ = Describe("CFProcessMutatingWebhook", func() {
 				cfProcess.Spec.ProcessType = "web"
 			})
 
-			It("defaults instances to 1", func() {
-				Expect(cfProcess.Spec.DesiredInstances).To(gstruct.PointTo(BeEquivalentTo(1)))
+			It("sets the configured default instances", func() {
+				Expect(cfProcess.Spec.DesiredInstances).To(gstruct.PointTo(BeEquivalentTo(defaultWebInstances)))
+			})
+
+			When("the process has zero instances set", func() {
+				BeforeEach(func() {
+					cfProcess.Spec.DesiredInstances = tools.PtrTo[int32](0)
+				})
+
+				It("keeps the process scaled down", func() {
+					Expect(cfProcess.Spec.DesiredInstances).To(gstruct.PointTo(BeZero()))
+				})
 			})
 
 			When("the process has the instance number set", func() {
@@ -0,0 +1,95 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFRevisionSpec is a snapshot of the configuration a CFApp was running with
+type CFRevisionSpec struct {
+	// A reference to the CFApp the revision was taken from. The CFApp must be in the same namespace.
+	AppRef v1.LocalObjectReference `json:"appRef"`
+
+	// The revision number. It starts at 1 and increases with every revision of the app
+	// +kubebuilder:validation:Minimum=1
+	Version int `json:"version"`
+
+	// A reference to the droplet the app was running. The droplet is a CFBuild in the same namespace.
+	DropletRef v1.LocalObjectReference `json:"dropletRef"`
+
+	// The name of a Secret holding a copy of the app environment variables at the time of the revision
+	// +kubebuilder:validation:Optional
+	EnvSecretName string `json:"envSecretName,omitempty"`
+
+	// The configuration of the app processes at the time of the revision
+	// +kubebuilder:validation:Optional
+	Processes []CFRevisionProcess `json:"processes,omitempty"`
+
+	// A human readable description of what caused the revision
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+}
+
+// CFRevisionProcess captures the configuration of a single process
+type CFRevisionProcess struct {
+	// The name of the process within the CFApp (e.g. "web")
+	ProcessType string `json:"processType"`
+
+	// The command the process was running. Empty if the process used the command detected by the build
+	Command string `json:"command,omitempty"`
+
+	// The desired number of replicas
+	DesiredInstances *int32 `json:"desiredInstances,omitempty"`
+
+	// The memory limit in MiB
+	MemoryMB int64 `json:"memoryMB"`
+
+	// The disk limit in MiB
+	DiskQuotaMB int64 `json:"diskQuotaMB"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:printcolumn:name="App",type=string,JSONPath=`.spec.appRef.name`
+//+kubebuilder:printcolumn:name="Version",type=integer,JSONPath=`.spec.version`
+//+kubebuilder:printcolumn:name="Droplet",type=string,JSONPath=`.spec.dropletRef.name`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFRevision is the Schema for the cfrevisions API
+type CFRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFRevisionSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFRevisionList contains a list of CFRevision
+type CFRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFRevision `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFRevision{}, &CFRevisionList{})
+}
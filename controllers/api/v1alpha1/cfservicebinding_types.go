@@ -28,6 +28,8 @@ const (
 
 	UnbindingFailedCondition = "UnbindingFailed"
 
+	BindingSecretAvailableCondition = "BindingSecretAvailable"
+
 	CFServiceBindingTypeKey = "key"
 	CFServiceBindingTypeApp = "app"
 
@@ -75,6 +77,11 @@ type CFServiceBindingStatus struct {
 	// +optional
 	Credentials v1.LocalObjectReference `json:"credentials"`
 
+	// The persistent volume claim mounted into the app. Only set for
+	// bindings to volume service instances
+	// +optional
+	Volume *BindingVolume `json:"volume,omitempty"`
+
 	//+kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
@@ -82,6 +89,16 @@ type CFServiceBindingStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// BindingVolume describes where the volume of a volume service binding is
+// mounted
+type BindingVolume struct {
+	// The name of the persistent volume claim in the binding namespace
+	ClaimName string `json:"claimName"`
+
+	// The absolute path the claim is mounted at in the app containers
+	MountPath string `json:"mountPath"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
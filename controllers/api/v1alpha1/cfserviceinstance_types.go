@@ -28,8 +28,10 @@ import (
 const (
 	UserProvidedType = "user-provided"
 	ManagedType      = "managed"
+	VolumeType       = "volume"
 
 	CFServiceInstanceFinalizerName = "cfServiceInstance.korifi.cloudfoundry.org"
+	CFServiceInstanceGUIDLabelKey  = "korifi.cloudfoundry.org/service-instance-guid"
 
 	ProvisioningFailedCondition   = "ProvisioningFailed"
 	DeprovisioningFailedCondition = "DeprovisioningFailed"
@@ -43,7 +45,7 @@ type CFServiceInstanceSpec struct {
 	// Name of a secret containing the service credentials. The Secret must be in the same namespace
 	SecretName string `json:"secretName"`
 
-	// Type of the Service Instance. Must be `user-provided` or `managed`
+	// Type of the Service Instance. Must be `user-provided`, `managed` or `volume`
 	Type InstanceType `json:"type"`
 
 	// Service label to use when adding this instance to VCAP_SERVICES. If not
@@ -55,15 +57,47 @@ type CFServiceInstanceSpec struct {
 	// Tags are used by apps to identify service instances
 	Tags []string `json:"tags,omitempty"`
 
+	// URL to which logs of bound apps are streamed. Only applicable to
+	// user-provided service instances
+	// +optional
+	SyslogDrainURL *string `json:"syslogDrainURL,omitempty"`
+
+	// The storage bound apps share. Only applicable to volume service
+	// instances
+	// +optional
+	Volume *VolumeServiceSpec `json:"volume,omitempty"`
+
 	PlanGUID string `json:"plan_guid"`
 
 	Parameters *runtime.RawExtension `json:"parameters,omitempty"`
 }
 
 // InstanceType defines the type of the Service Instance
-// +kubebuilder:validation:Enum=user-provided;managed
+// +kubebuilder:validation:Enum=user-provided;managed;volume
 type InstanceType string
 
+// VolumeServiceSpec describes the persistent volume claim backing a volume
+// service instance
+type VolumeServiceSpec struct {
+	// The requested size of the volume in MiB
+	// +kubebuilder:validation:Minimum=1
+	SizeMB int64 `json:"sizeMB"`
+
+	// The storage class of the volume. The cluster default storage class is used when not set
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// The access mode of the volume. Defaults to ReadWriteOnce, which only
+	// lets app instances running on the same node share the volume. Use
+	// ReadWriteMany for storage classes that support it
+	// +kubebuilder:validation:Enum=ReadWriteOnce;ReadWriteOncePod;ReadWriteMany
+	// +optional
+	AccessMode corev1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
+
+	// The absolute path the volume is mounted at in bound app containers
+	MountPath string `json:"mountPath"`
+}
+
 // CFServiceInstanceStatus defines the observed state of CFServiceInstance
 type CFServiceInstanceStatus struct {
 	//+kubebuilder:validation:Optional
@@ -35,6 +35,12 @@ type CFSpaceSpec struct {
 	// The mutable, user-friendly name of the space. Unlike metadata.name, the user can change this field
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// Annotations stamped onto the app workloads in the space, e.g. for cost allocation.
+	// They override the org and cluster-wide defaults.
+	// Keys in the kubernetes.io, k8s.io and cloudfoundry.org domains are reserved.
+	//+kubebuilder:validation:Optional
+	DefaultAppAnnotations map[string]string `json:"defaultAppAnnotations,omitempty"`
 }
 
 // CFSpaceStatus defines the observed state of CFSpace
@@ -38,6 +38,17 @@ type CFTaskSpec struct {
 	// A boolean describing whether the CFTask has been canceled
 	// +optional
 	Canceled bool `json:"canceled"`
+	// The user-friendly name of the task. Unlike metadata.name, this does not have to be unique
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// The memory limit in MB for the task. Defaults to the process default when unset
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+	// The ephemeral disk limit in MB for the task. Defaults to the process default when unset
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	DiskQuotaMB int64 `json:"diskQuotaMB,omitempty"`
 }
 
 // CFTaskStatus defines the observed state of CFTask
@@ -2,6 +2,7 @@ package v1alpha1
 
 const (
 	BuildpackLifecycle LifecycleType = "buildpack"
+	DockerLifecycle    LifecycleType = "docker"
 	DockerPackage      PackageType   = "docker"
 
 	StartedState AppState = "STARTED"
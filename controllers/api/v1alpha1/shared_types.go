@@ -27,6 +27,38 @@ const (
 	StagingConditionType   = "Staging"
 	SucceededConditionType = "Succeeded"
 
+	BuildQueuedReason = "BuildQueued"
+
+	// Orgs and spaces annotated with DeletionProtectedAnnotation set to
+	// "true" cannot be deleted via the API unless the protection is
+	// explicitly overridden
+	DeletionProtectedAnnotation = "korifi.cloudfoundry.org/deletion-protected"
+
+	// Org and space namespaces record the effective default app annotations
+	// (cluster, org and space defaults combined) as JSON under this key
+	DefaultAppAnnotationsKey = "korifi.cloudfoundry.org/default-app-annotations"
+
+	// Deployments record the droplet they replaced. Those created with a
+	// readiness timeout also record the time by which the app has to become
+	// ready. Apps that are not ready by then are rolled back to the previous
+	// droplet and annotated with the droplet that failed to become ready
+	CFAppPreviousDropletAnnotation   = "korifi.cloudfoundry.org/deployment-previous-droplet"
+	CFAppReadinessDeadlineAnnotation = "korifi.cloudfoundry.org/deployment-readiness-deadline"
+	CFAppRolledBackDropletAnnotation = "korifi.cloudfoundry.org/deployment-rolled-back-droplet"
+
+	// Canceling an active deployment puts the previous droplet back and
+	// records the droplet that was being deployed
+	CFAppCanceledDropletAnnotation = "korifi.cloudfoundry.org/deployment-canceled-droplet"
+
+	// Exec sessions into app instances are only allowed for apps with the
+	// ssh feature enabled, which is recorded as "true" under this key
+	CFAppSSHEnabledAnnotation = "korifi.cloudfoundry.org/ssh-enabled"
+
+	// The default buildpack order is stored in the root namespace as a
+	// newline separated list of buildpack names
+	DefaultBuildpackOrderConfigMapName = "korifi-default-buildpack-order"
+	DefaultBuildpackOrderKey           = "buildpacks"
+
 	PropagateRoleBindingAnnotation    = "cloudfoundry.org/propagate-cf-role"
 	PropagateServiceAccountAnnotation = "cloudfoundry.org/propagate-service-account"
 	PropagateDeletionAnnotation       = "cloudfoundry.org/propagate-deletion"
@@ -75,3 +107,27 @@ type Registry struct {
 type RequiredLocalObjectReference struct {
 	Name string `json:"name"`
 }
+
+// AppsQuota caps the resources used by the app processes of an org or space.
+// A limit that is not set is unlimited.
+type AppsQuota struct {
+	// The total memory in MB that all process instances may use
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	TotalMemoryMB *int64 `json:"totalMemoryMB,omitempty"`
+
+	// The memory in MB that a single process instance may use
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	PerProcessMemoryMB *int64 `json:"perProcessMemoryMB,omitempty"`
+
+	// The total number of process instances
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	TotalInstances *int32 `json:"totalInstances,omitempty"`
+
+	// The total number of apps, started or stopped
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	TotalApps *int32 `json:"totalApps,omitempty"`
+}
@@ -45,9 +45,11 @@ import (
 )
 
 const (
-	defaultMemoryMB    = 128
-	defaultDiskQuotaMB = 256
-	defaultTimeout     = 60
+	defaultMemoryMB               = 128
+	defaultDiskQuotaMB            = 256
+	defaultWebInstances           = 2
+	defaultTimeout                = 60
+	defaultConnectionDrainTimeout = 10
 )
 
 var (
@@ -101,6 +103,8 @@ var _ = BeforeSuite(func() {
 	Expect((&korifiv1alpha1.CFApp{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(apps.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, apps.AppEntityType)),
+		validation.NewPlacementValidator(uncachedClient, namespace),
+		validation.NewQuotaValidator(uncachedClient, namespace, korifiv1alpha1.NewCFProcessDefaulter(defaultMemoryMB, defaultDiskQuotaMB, defaultWebInstances, defaultTimeout, defaultConnectionDrainTimeout)),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect((&korifiv1alpha1.CFRoute{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
@@ -114,7 +118,7 @@ var _ = BeforeSuite(func() {
 
 	Expect((&korifiv1alpha1.CFPackage{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
-	Expect(korifiv1alpha1.NewCFProcessDefaulter(defaultMemoryMB, defaultDiskQuotaMB, defaultTimeout).
+	Expect(korifiv1alpha1.NewCFProcessDefaulter(defaultMemoryMB, defaultDiskQuotaMB, defaultWebInstances, defaultTimeout, defaultConnectionDrainTimeout).
 		SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect((&korifiv1alpha1.CFBuild{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
@@ -12,12 +12,15 @@ type ControllerConfig struct {
 	// core controllers
 	CFProcessDefaults                CFProcessDefaults  `yaml:"cfProcessDefaults"`
 	CFStagingResources               CFStagingResources `yaml:"cfStagingResources"`
+	BuildConcurrency                 BuildConcurrency   `yaml:"buildConcurrency"`
 	CFRootNamespace                  string             `yaml:"cfRootNamespace"`
 	ContainerRegistrySecretNames     []string           `yaml:"containerRegistrySecretNames"`
 	TaskTTL                          string             `yaml:"taskTTL"`
+	StagingTimeout                   string             `yaml:"stagingTimeout"`
 	BuilderName                      string             `yaml:"builderName"`
 	RunnerName                       string             `yaml:"runnerName"`
 	NamespaceLabels                  map[string]string  `yaml:"namespaceLabels"`
+	DefaultAppAnnotations            map[string]string  `yaml:"defaultAppAnnotations"`
 	ExtraVCAPApplicationValues       map[string]any     `yaml:"extraVCAPApplicationValues"`
 	MaxRetainedPackagesPerApp        int                `yaml:"maxRetainedPackagesPerApp"`
 	MaxRetainedBuildsPerApp          int                `yaml:"maxRetainedBuildsPerApp"`
@@ -31,9 +34,11 @@ type ControllerConfig struct {
 }
 
 type CFProcessDefaults struct {
-	MemoryMB    int64  `yaml:"memoryMB"`
-	DiskQuotaMB int64  `yaml:"diskQuotaMB"`
-	Timeout     *int32 `yaml:"timeout"`
+	MemoryMB                      int64  `yaml:"memoryMB"`
+	DiskQuotaMB                   int64  `yaml:"diskQuotaMB"`
+	Instances                     *int32 `yaml:"instances"`
+	Timeout                       *int32 `yaml:"timeout"`
+	ConnectionDrainTimeoutSeconds *int32 `yaml:"connectionDrainTimeoutSeconds"`
 }
 
 type CFStagingResources struct {
@@ -42,16 +47,26 @@ type CFStagingResources struct {
 	MemoryMB     int64 `yaml:"memoryMB"`
 }
 
+// BuildConcurrency caps the number of builds that may run at the same time.
+// A zero (or negative) limit means unlimited.
+type BuildConcurrency struct {
+	MaxInFlightBuilds         int `yaml:"maxInFlightBuilds"`
+	MaxInFlightBuildsPerSpace int `yaml:"maxInFlightBuildsPerSpace"`
+}
+
 type Networking struct {
 	GatewayName      string `yaml:"gatewayName"`
 	GatewayNamespace string `yaml:"gatewayNamespace"`
 }
 
 const (
-	defaultTaskTTL            = 30 * 24 * time.Hour
-	defaultTimeout      int32 = 60
-	defaultJobTTL             = 24 * time.Hour
-	defaultBuildCacheMB       = 2048
+	defaultTaskTTL                      = 30 * 24 * time.Hour
+	defaultStagingTimeout               = 15 * time.Minute
+	defaultTimeout                int32 = 60
+	defaultWebInstances           int32 = 1
+	defaultConnectionDrainTimeout int32 = 0
+	defaultJobTTL                       = 24 * time.Hour
+	defaultBuildCacheMB                 = 2048
 )
 
 func LoadFromPath(path string) (*ControllerConfig, error) {
@@ -61,10 +76,18 @@ func LoadFromPath(path string) (*ControllerConfig, error) {
 		return nil, err
 	}
 
+	if config.CFProcessDefaults.Instances == nil {
+		config.CFProcessDefaults.Instances = tools.PtrTo(defaultWebInstances)
+	}
+
 	if config.CFProcessDefaults.Timeout == nil {
 		config.CFProcessDefaults.Timeout = tools.PtrTo(defaultTimeout)
 	}
 
+	if config.CFProcessDefaults.ConnectionDrainTimeoutSeconds == nil {
+		config.CFProcessDefaults.ConnectionDrainTimeoutSeconds = tools.PtrTo(defaultConnectionDrainTimeout)
+	}
+
 	if config.SpaceFinalizerAppDeletionTimeout == nil {
 		config.SpaceFinalizerAppDeletionTimeout = tools.PtrTo(defaultTimeout)
 	}
@@ -92,3 +115,11 @@ func (c ControllerConfig) ParseTaskTTL() (time.Duration, error) {
 
 	return tools.ParseDuration(c.TaskTTL)
 }
+
+func (c ControllerConfig) ParseStagingTimeout() (time.Duration, error) {
+	if c.StagingTimeout == "" {
+		return defaultStagingTimeout, nil
+	}
+
+	return tools.ParseDuration(c.StagingTimeout)
+}
@@ -31,20 +31,27 @@ var _ = Describe("LoadFromPath", func() {
 
 		cfg = config.ControllerConfig{
 			CFProcessDefaults: config.CFProcessDefaults{
-				MemoryMB:    1024,
-				DiskQuotaMB: 512,
-				Timeout:     tools.PtrTo(int32(30)),
+				MemoryMB:                      1024,
+				DiskQuotaMB:                   512,
+				Instances:                     tools.PtrTo(int32(3)),
+				Timeout:                       tools.PtrTo(int32(30)),
+				ConnectionDrainTimeoutSeconds: tools.PtrTo(int32(15)),
 			},
 			CFStagingResources: config.CFStagingResources{
 				BuildCacheMB: 1024,
 				DiskMB:       512,
 				MemoryMB:     2048,
 			},
+			BuildConcurrency: config.BuildConcurrency{
+				MaxInFlightBuilds:         10,
+				MaxInFlightBuildsPerSpace: 2,
+			},
 			CFRootNamespace:                  "rootNamespace",
 			ContainerRegistrySecretNames:     []string{"packageRegistrySecretName"},
 			TaskTTL:                          "taskTTL",
 			BuilderName:                      "buildReconciler",
 			RunnerName:                       "statefulset-runner",
+			DefaultAppAnnotations:            map[string]string{"cost-center": "cc-1"},
 			LogLevel:                         zapcore.DebugLevel,
 			SpaceFinalizerAppDeletionTimeout: tools.PtrTo(int32(42)),
 			Networking: config.Networking{
@@ -72,21 +79,28 @@ var _ = Describe("LoadFromPath", func() {
 		Expect(retErr).NotTo(HaveOccurred())
 		Expect(*retConfig).To(Equal(config.ControllerConfig{
 			CFProcessDefaults: config.CFProcessDefaults{
-				MemoryMB:    1024,
-				DiskQuotaMB: 512,
-				Timeout:     tools.PtrTo(int32(30)),
+				MemoryMB:                      1024,
+				DiskQuotaMB:                   512,
+				Instances:                     tools.PtrTo(int32(3)),
+				Timeout:                       tools.PtrTo(int32(30)),
+				ConnectionDrainTimeoutSeconds: tools.PtrTo(int32(15)),
 			},
 			CFStagingResources: config.CFStagingResources{
 				BuildCacheMB: 1024,
 				DiskMB:       512,
 				MemoryMB:     2048,
 			},
+			BuildConcurrency: config.BuildConcurrency{
+				MaxInFlightBuilds:         10,
+				MaxInFlightBuildsPerSpace: 2,
+			},
 			CFRootNamespace:                  "rootNamespace",
 			ContainerRegistrySecretNames:     []string{"packageRegistrySecretName"},
 			TaskTTL:                          "taskTTL",
 			BuilderName:                      "buildReconciler",
 			RunnerName:                       "statefulset-runner",
 			NamespaceLabels:                  map[string]string{},
+			DefaultAppAnnotations:            map[string]string{"cost-center": "cc-1"},
 			ExtraVCAPApplicationValues:       map[string]any{},
 			LogLevel:                         zapcore.DebugLevel,
 			SpaceFinalizerAppDeletionTimeout: tools.PtrTo(int32(42)),
@@ -99,6 +113,16 @@ var _ = Describe("LoadFromPath", func() {
 		}))
 	})
 
+	When("the CFProcess default instances is not set", func() {
+		BeforeEach(func() {
+			cfg.CFProcessDefaults.Instances = nil
+		})
+
+		It("uses the default", func() {
+			Expect(retConfig.CFProcessDefaults.Instances).To(gstruct.PointTo(BeEquivalentTo(1)))
+		})
+	})
+
 	When("the CFProcess default timeout is not set", func() {
 		BeforeEach(func() {
 			cfg.CFProcessDefaults.Timeout = nil
@@ -109,6 +133,16 @@ var _ = Describe("LoadFromPath", func() {
 		})
 	})
 
+	When("the CFProcess default connection drain timeout is not set", func() {
+		BeforeEach(func() {
+			cfg.CFProcessDefaults.ConnectionDrainTimeoutSeconds = nil
+		})
+
+		It("uses the default", func() {
+			Expect(retConfig.CFProcessDefaults.ConnectionDrainTimeoutSeconds).To(gstruct.PointTo(BeEquivalentTo(0)))
+		})
+	})
+
 	When("log level is not set", func() {
 		BeforeEach(func() {
 			cfg.LogLevel = 0
@@ -185,3 +219,49 @@ var _ = Describe("ParseTaskTTL", func() {
 		})
 	})
 })
+
+var _ = Describe("ParseStagingTimeout", func() {
+	var (
+		stagingTimeoutString string
+		stagingTimeout       time.Duration
+		parseErr             error
+	)
+
+	BeforeEach(func() {
+		stagingTimeoutString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			StagingTimeout: stagingTimeoutString,
+		}
+
+		stagingTimeout, parseErr = cfg.ParseStagingTimeout()
+	})
+
+	It("return 15 minutes by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(stagingTimeout).To(Equal(15 * time.Minute))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			stagingTimeoutString = "1h30m"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(stagingTimeout).To(Equal(90 * time.Minute))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			stagingTimeoutString = "forever"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
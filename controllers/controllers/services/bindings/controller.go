@@ -18,17 +18,23 @@ package bindings
 
 import (
 	"context"
+	"fmt"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/sbio"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/go-logr/logr"
 	servicebindingv1beta1 "github.com/servicebinding/runtime/apis/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -49,24 +55,30 @@ type DelegateReconciler interface {
 type Reconciler struct {
 	k8sClient         client.Client
 	scheme            *runtime.Scheme
+	recorder          record.EventRecorder
 	log               logr.Logger
 	upsiReconciler    DelegateReconciler
 	managedReconciler DelegateReconciler
+	volumeReconciler  DelegateReconciler
 }
 
 func NewReconciler(
 	k8sClient client.Client,
 	scheme *runtime.Scheme,
+	recorder record.EventRecorder,
 	log logr.Logger,
 	upsiCredentialsReconciler DelegateReconciler,
 	managedCredentialsReconciler DelegateReconciler,
+	volumeReconciler DelegateReconciler,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceBinding, *korifiv1alpha1.CFServiceBinding] {
 	cfBindingReconciler := &Reconciler{
 		k8sClient:         k8sClient,
 		scheme:            scheme,
+		recorder:          recorder,
 		log:               log,
 		upsiReconciler:    upsiCredentialsReconciler,
 		managedReconciler: managedCredentialsReconciler,
+		volumeReconciler:  volumeReconciler,
 	}
 	return k8s.NewPatchingReconciler(log, k8sClient, cfBindingReconciler)
 }
@@ -108,7 +120,8 @@ func (r *Reconciler) serviceInstanceToServiceBindings(ctx context.Context, o cli
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings/finalizers,verbs=update
-//+kubebuilder:rbac:groups=servicebinding.io,resources=servicebindings,verbs=get;list;create;update;patch;watch
+//+kubebuilder:rbac:groups=servicebinding.io,resources=servicebindings,verbs=get;list;create;update;patch;watch;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
@@ -119,10 +132,17 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBinding *ko
 	cfServiceInstance := new(korifiv1alpha1.CFServiceInstance)
 	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.Namespace}, cfServiceInstance)
 	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return r.handleServiceInstanceDeleted(ctx, cfServiceBinding)
+		}
 		log.Info("service instance not found", "service-instance", cfServiceBinding.Spec.Service.Name, "error", err)
 		return ctrl.Result{}, err
 	}
 
+	if !cfServiceInstance.GetDeletionTimestamp().IsZero() && cfServiceBinding.GetDeletionTimestamp().IsZero() {
+		return r.handleServiceInstanceDeleted(ctx, cfServiceBinding)
+	}
+
 	cfServiceBinding.Annotations = tools.SetMapValue(cfServiceBinding.Annotations, korifiv1alpha1.ServiceInstanceTypeAnnotationKey, string(cfServiceInstance.Spec.Type))
 
 	if err = k8s.Patch(ctx, r.k8sClient, cfServiceInstance, func() {
@@ -138,22 +158,108 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBinding *ko
 		return ctrl.Result{}, err
 	}
 
+	if !cfServiceBinding.GetDeletionTimestamp().IsZero() {
+		if err = r.withdrawCredentials(ctx, cfServiceBinding, "BindingDeleted", "The binding is being deleted"); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	res, err := r.reconcileByType(ctx, cfServiceInstance, cfServiceBinding)
 	if needsRequeue(res, err) {
 		if err != nil {
 			log.Error(err, "failed to reconcile binding credentials")
 		}
+		if k8serrors.IsNotFound(err) && cfServiceInstance.Spec.Type == korifiv1alpha1.UserProvidedType {
+			r.recorder.Eventf(cfServiceBinding, "Warning", "SecretNotFound", "Did not find credentials secret %s of service instance %s", cfServiceInstance.Status.Credentials.Name, cfServiceInstance.Name)
+		}
 		return res, err
 	}
 
+	if cfServiceBinding.Status.Binding.Name != "" {
+		if !meta.IsStatusConditionTrue(cfServiceBinding.Status.Conditions, korifiv1alpha1.BindingSecretAvailableCondition) {
+			r.recorder.Eventf(cfServiceBinding, "Normal", "SecretFound", "Found binding secret %s for service instance %s", cfServiceBinding.Status.Binding.Name, cfServiceInstance.Name)
+		}
+		meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.BindingSecretAvailableCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: cfServiceBinding.Generation,
+			Reason:             "SecretAvailable",
+			Message:            "The binding secret is available",
+		})
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// handleServiceInstanceDeleted withdraws the binding credentials from the
+// app once the service instance they come from is gone or being deleted
+func (r *Reconciler) handleServiceInstanceDeleted(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("handleServiceInstanceDeleted")
+
+	if !cfServiceBinding.GetDeletionTimestamp().IsZero() {
+		// there is no instance left to unbind from
+		if controllerutil.RemoveFinalizer(cfServiceBinding, korifiv1alpha1.CFServiceBindingFinalizerName) {
+			log.V(1).Info("finalizer removed")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.withdrawCredentials(ctx, cfServiceBinding, "ServiceInstanceDeleted", fmt.Sprintf("Service instance %q has been deleted", cfServiceBinding.Spec.Service.Name)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, k8s.NewNotReadyError().
+		WithReason("ServiceInstanceDeleted").
+		WithMessage("The service instance has been deleted").
+		WithNoRequeue()
+}
+
+// withdrawCredentials removes the servicebinding.io binding and the binding
+// secret so that the credentials are no longer projected into the app
+func (r *Reconciler) withdrawCredentials(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, reason, message string) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("withdrawCredentials")
+
+	sbServiceBinding := sbio.ToSBServiceBinding(cfServiceBinding, "")
+	if err := r.k8sClient.Delete(ctx, sbServiceBinding); client.IgnoreNotFound(err) != nil {
+		log.Info("failed to delete servicebinding.io servicebinding", "reason", err)
+		return err
+	}
+
+	if cfServiceBinding.Status.Binding.Name != "" {
+		if err := r.k8sClient.Delete(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cfServiceBinding.Status.Binding.Name,
+				Namespace: cfServiceBinding.Namespace,
+			},
+		}); client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete binding secret", "reason", err)
+			return err
+		}
+	}
+
+	cfServiceBinding.Status.Binding = corev1.LocalObjectReference{}
+	cfServiceBinding.Status.Credentials = corev1.LocalObjectReference{}
+	cfServiceBinding.Status.Volume = nil
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.BindingSecretAvailableCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: cfServiceBinding.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+
+	return nil
+}
+
 func (r *Reconciler) reconcileByType(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
 	if cfServiceInstance.Spec.Type == korifiv1alpha1.UserProvidedType {
 		return r.upsiReconciler.ReconcileResource(ctx, cfServiceBinding, cfServiceInstance)
 	}
 
+	if cfServiceInstance.Spec.Type == korifiv1alpha1.VolumeType {
+		return r.volumeReconciler.ReconcileResource(ctx, cfServiceBinding, cfServiceInstance)
+	}
+
 	return r.managedReconciler.ReconcileResource(ctx, cfServiceBinding, cfServiceInstance)
 }
 
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"sync"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
@@ -22,7 +25,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -213,8 +218,10 @@ var _ = Describe("CFServiceBinding", func() {
 				))
 
 				g.Expect(sbServiceBinding.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
-					"Kind": Equal("CFServiceBinding"),
-					"Name": Equal(binding.Name),
+					"Kind":       Equal("CFServiceBinding"),
+					"Name":       Equal(binding.Name),
+					"UID":        Equal(binding.UID),
+					"Controller": PointTo(BeTrue()),
 				})))
 
 				g.Expect(sbServiceBinding.Spec.Workload).To(MatchFields(IgnoreExtras, Fields{
@@ -242,6 +249,16 @@ var _ = Describe("CFServiceBinding", func() {
 			}).Should(Succeed())
 		})
 
+		It("records a SecretFound event", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(recordedEvents(binding)).To(ContainElement(recordedEvent{
+					eventType: "Normal",
+					reason:    "SecretFound",
+					message:   fmt.Sprintf("Found binding secret %s for service instance %s", binding.Name, instance.Name),
+				}))
+			}).Should(Succeed())
+		})
+
 		When("the CFServiceBinding has a displayName set", func() {
 			BeforeEach(func() {
 				Expect(k8s.PatchResource(ctx, adminClient, binding, func() {
@@ -453,6 +470,140 @@ var _ = Describe("CFServiceBinding", func() {
 			})
 		})
 
+		When("the service instance is deleted after the binding secret is available", func() {
+			BeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.Binding.Name).NotTo(BeEmpty())
+					g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.BindingSecretAvailableCondition)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					)))
+				}).Should(Succeed())
+
+				Expect(adminClient.Delete(ctx, instance)).To(Succeed())
+				// there is no garbage collector in envtest to finalize the instance
+				Expect(k8s.Patch(ctx, adminClient, instance, func() {
+					instance.Finalizers = nil
+				})).To(Succeed())
+			})
+
+			It("marks the binding secret as unavailable", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.Conditions).To(ContainElements(
+						SatisfyAll(
+							HasType(Equal(korifiv1alpha1.BindingSecretAvailableCondition)),
+							HasStatus(Equal(metav1.ConditionFalse)),
+							HasReason(Equal("ServiceInstanceDeleted")),
+						),
+						SatisfyAll(
+							HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+							HasStatus(Equal(metav1.ConditionFalse)),
+							HasReason(Equal("ServiceInstanceDeleted")),
+						),
+					))
+				}).Should(Succeed())
+			})
+
+			It("withdraws the binding credentials", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.Binding.Name).To(BeEmpty())
+					g.Expect(binding.Status.Credentials.Name).To(BeEmpty())
+
+					err := adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: binding.Name}, &corev1.Secret{})
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+					err = adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "cf-binding-" + binding.Name}, &servicebindingv1beta1.ServiceBinding{})
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			When("the binding is deleted", func() {
+				JustBeforeEach(func() {
+					Expect(adminClient.Delete(ctx, binding)).To(Succeed())
+				})
+
+				It("is deleted", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)
+						g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the credentials secret does not exist", func() {
+			var (
+				eventTimesMutex sync.Mutex
+				eventTimes      []time.Time
+			)
+
+			BeforeEach(func() {
+				Expect(adminClient.Delete(ctx, instanceCredentialsSecret)).To(Succeed())
+
+				eventTimes = nil
+				eventRecorder.EventfStub = func(obj runtime.Object, _, reason, _ string, _ ...any) {
+					if reason != "SecretNotFound" || client.ObjectKeyFromObject(obj.(client.Object)) != client.ObjectKeyFromObject(binding) {
+						return
+					}
+
+					eventTimesMutex.Lock()
+					defer eventTimesMutex.Unlock()
+					eventTimes = append(eventTimes, time.Now())
+				}
+			})
+
+			secretNotFoundTimes := func() []time.Time {
+				eventTimesMutex.Lock()
+				defer eventTimesMutex.Unlock()
+				return slices.Clone(eventTimes)
+			}
+
+			It("requeues the binding with growing intervals", func() {
+				Eventually(secretNotFoundTimes).WithTimeout(30 * time.Second).Should(WithTransform(func(t []time.Time) int { return len(t) }, BeNumerically(">=", 5)))
+
+				times := secretNotFoundTimes()
+				Expect(times[4].Sub(times[3])).To(BeNumerically(">", times[2].Sub(times[1])))
+			})
+
+			When("the credentials secret is created later", func() {
+				JustBeforeEach(func() {
+					Eventually(secretNotFoundTimes).Should(WithTransform(func(t []time.Time) int { return len(t) }, BeNumerically(">=", 2)))
+
+					Expect(adminClient.Create(ctx, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      instanceCredentialsSecret.Name,
+							Namespace: testNamespace,
+						},
+						Data: instanceCredentialsSecret.Data,
+					})).To(Succeed())
+				})
+
+				It("picks up the secret and stops requeuing", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.Binding.Name).To(Equal(binding.Name))
+						g.Expect(meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.BindingSecretAvailableCondition)).To(BeTrue())
+					}).WithTimeout(30 * time.Second).Should(Succeed())
+
+					attempts := len(secretNotFoundTimes())
+					Consistently(secretNotFoundTimes).Should(HaveLen(attempts))
+				})
+			})
+
+			It("records a SecretNotFound event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(recordedEvents(binding)).To(ContainElement(recordedEvent{
+						eventType: "Warning",
+						reason:    "SecretNotFound",
+						message:   fmt.Sprintf("Did not find credentials secret %s of service instance %s", instanceCredentialsSecret.Name, instance.Name),
+					}))
+				}).Should(Succeed())
+			})
+		})
+
 		When("the credentials secret is not available", func() {
 			BeforeEach(func() {
 				Expect(k8s.Patch(ctx, adminClient, instance, func() {
@@ -485,6 +636,162 @@ var _ = Describe("CFServiceBinding", func() {
 		})
 	})
 
+	Describe("volume service bindings", func() {
+		var (
+			instance *korifiv1alpha1.CFServiceInstance
+			claim    *corev1.PersistentVolumeClaim
+		)
+
+		BeforeEach(func() {
+			instance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      instanceGUID,
+					Namespace: testNamespace,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "shared-volume",
+					Type:        korifiv1alpha1.VolumeType,
+					Volume: &korifiv1alpha1.VolumeServiceSpec{
+						SizeMB:           1024,
+						StorageClassName: tools.PtrTo("nfs"),
+						MountPath:        "/var/vcap/data/shared",
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, instance)).To(Succeed())
+
+			claim = &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      instance.Name,
+					Namespace: testNamespace,
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, claim)).To(Succeed())
+			Expect(k8s.Patch(ctx, adminClient, claim, func() {
+				claim.Status.Phase = corev1.ClaimBound
+			})).To(Succeed())
+		})
+
+		It("sets the binding volume status", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+				g.Expect(binding.Status.Volume).To(PointTo(Equal(korifiv1alpha1.BindingVolume{
+					ClaimName: instance.Name,
+					MountPath: "/var/vcap/data/shared",
+				})))
+			}).Should(Succeed())
+		})
+
+		It("sets the binding Ready status condition to true", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+				g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionTrue)),
+				)))
+			}).Should(Succeed())
+		})
+
+		It("does not create a servicebinding.io ServiceBinding", func() {
+			Consistently(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "cf-binding-" + binding.Name}, &servicebindingv1beta1.ServiceBinding{})
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		When("the persistent volume claim is not bound yet", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, claim, func() {
+					claim.Status.Phase = corev1.ClaimPending
+				})).To(Succeed())
+			})
+
+			It("sets the binding volume status, so that the claim can be bound on first use", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.Volume).To(PointTo(Equal(korifiv1alpha1.BindingVolume{
+						ClaimName: instance.Name,
+						MountPath: "/var/vcap/data/shared",
+					})))
+					g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					)))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the persistent volume of the claim is lost", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, claim, func() {
+					claim.Status.Phase = corev1.ClaimLost
+				})).To(Succeed())
+			})
+
+			It("sets the binding Ready status condition to false", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("VolumeLost")),
+					)))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the service instance does not specify a volume", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.Volume = nil
+				})).To(Succeed())
+			})
+
+			It("sets the binding Ready status condition to false", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("VolumeNotConfigured")),
+					)))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the binding is deleted", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.Volume).NotTo(BeNil())
+				}).Should(Succeed())
+
+				Expect(adminClient.Delete(ctx, binding)).To(Succeed())
+			})
+
+			It("is deleted", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			It("keeps the persistent volume claim of the service instance", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: instance.Name}, &corev1.PersistentVolumeClaim{})).To(Succeed())
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	Describe("managed service bindings", func() {
 		var (
 			brokerClient *fake.BrokerClient
@@ -729,7 +1036,9 @@ var _ = Describe("CFServiceBinding", func() {
 					tools.CredentialsSecretKey: BeEquivalentTo(`{"foo":"bar"}`),
 				}))
 				g.Expect(credentialsSecret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
-					"Name": Equal(binding.Name),
+					"Name":       Equal(binding.Name),
+					"UID":        Equal(binding.UID),
+					"Controller": PointTo(BeTrue()),
 				})))
 			}).Should(Succeed())
 		})
@@ -837,7 +1146,9 @@ var _ = Describe("CFServiceBinding", func() {
 				}))
 
 				g.Expect(bindingSecret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
-					"Name": Equal(binding.Name),
+					"Name":       Equal(binding.Name),
+					"UID":        Equal(binding.UID),
+					"Controller": PointTo(BeTrue()),
 				})))
 			}).Should(Succeed())
 		})
@@ -1006,7 +1317,9 @@ var _ = Describe("CFServiceBinding", func() {
 							tools.CredentialsSecretKey: BeEquivalentTo(`{"foo":"bar"}`),
 						}))
 						g.Expect(credentialsSecret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
-							"Name": Equal(binding.Name),
+							"Name":       Equal(binding.Name),
+							"UID":        Equal(binding.UID),
+							"Controller": PointTo(BeTrue()),
 						})))
 					}).Should(Succeed())
 				})
@@ -1029,7 +1342,9 @@ var _ = Describe("CFServiceBinding", func() {
 						}))
 
 						g.Expect(bindingSecret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
-							"Name": Equal(binding.Name),
+							"Name":       Equal(binding.Name),
+							"UID":        Equal(binding.UID),
+							"Controller": PointTo(BeTrue()),
 						})))
 					}).Should(Succeed())
 				})
@@ -1187,6 +1502,21 @@ var _ = Describe("CFServiceBinding", func() {
 					}).Should(Succeed())
 				})
 
+				It("withdraws the binding credentials from the app", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.Binding.Name).To(BeEmpty())
+						g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.BindingSecretAvailableCondition)),
+							HasStatus(Equal(metav1.ConditionFalse)),
+							HasReason(Equal("BindingDeleted")),
+						)))
+
+						err := adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "cf-binding-" + binding.Name}, &servicebindingv1beta1.ServiceBinding{})
+						g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+
 				When("the last operation is in progress", func() {
 					BeforeEach(func() {
 						brokerClient.GetServiceBindingLastOperationReturns(osbapi.LastOperationResponse{
@@ -1276,3 +1606,25 @@ var _ = Describe("CFServiceBinding", func() {
 		})
 	})
 })
+
+type recordedEvent struct {
+	eventType string
+	reason    string
+	message   string
+}
+
+func recordedEvents(binding *korifiv1alpha1.CFServiceBinding) []recordedEvent {
+	events := []recordedEvent{}
+	for i := range eventRecorder.EventfCallCount() {
+		obj, eventType, reason, messageFmt, args := eventRecorder.EventfArgsForCall(i)
+		if client.ObjectKeyFromObject(obj.(client.Object)) != client.ObjectKeyFromObject(binding) {
+			continue
+		}
+		events = append(events, recordedEvent{
+			eventType: eventType,
+			reason:    reason,
+			message:   fmt.Sprintf(messageFmt, args...),
+		})
+	}
+	return events
+}
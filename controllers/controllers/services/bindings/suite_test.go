@@ -26,8 +26,10 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/managed"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/upsi"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/volume"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
 	"github.com/google/uuid"
@@ -53,6 +55,7 @@ var (
 	adminClient         client.Client
 	k8sManager          manager.Manager
 	brokerClientFactory *fake.BrokerClientFactory
+	eventRecorder       *controllerfake.EventRecorder
 	rootNamespace       string
 )
 
@@ -104,13 +107,16 @@ var _ = BeforeEach(func() {
 	})).To(Succeed())
 
 	brokerClientFactory = new(fake.BrokerClientFactory)
+	eventRecorder = new(controllerfake.EventRecorder)
 
 	err := bindings.NewReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
+		eventRecorder,
 		ctrl.Log.WithName("controllers").WithName("CFServiceBinding"),
 		upsi.NewReconciler(k8sManager.GetClient(), k8sManager.GetScheme()),
 		managed.NewReconciler(k8sManager.GetClient(), brokerClientFactory, rootNamespace, k8sManager.GetScheme()),
+		volume.NewReconciler(k8sManager.GetClient(), k8sManager.GetScheme()),
 	).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 })
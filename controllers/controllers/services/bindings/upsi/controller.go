@@ -18,10 +18,16 @@ import (
 	servicebindingv1beta1 "github.com/servicebinding/runtime/apis/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+const (
+	minSecretBackoff = time.Second
+	maxSecretBackoff = 5 * time.Minute
+)
+
 type UPSIBindingReconciler struct {
 	k8sClient client.Client
 	scheme    *runtime.Scheme
@@ -46,14 +52,19 @@ func (r *UPSIBindingReconciler) ReconcileResource(ctx context.Context, cfService
 	}
 
 	if cfServiceInstance.Status.Credentials.Name == "" {
-		return ctrl.Result{}, k8s.NewNotReadyError().
+		return ctrl.Result{}, awaitCredentialsSecret(cfServiceBinding, k8s.NewNotReadyError().
 			WithReason("CredentialsSecretNotAvailable").
-			WithMessage("Service instance credentials not available yet").
-			WithRequeueAfter(time.Second)
+			WithMessage("Service instance credentials not available yet"))
 	}
 
 	bindingSecret, err := r.reconcileCredentials(ctx, cfServiceInstance, cfServiceBinding)
 	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, awaitCredentialsSecret(cfServiceBinding, k8s.NewNotReadyError().
+				WithCause(err).
+				WithReason("SecretNotFound"))
+		}
+
 		if k8serrors.IsInvalid(err) {
 			err = r.k8sClient.Delete(ctx, &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
@@ -81,6 +92,21 @@ func (r *UPSIBindingReconciler) ReconcileResource(ctx context.Context, cfService
 	return ctrl.Result{}, nil
 }
 
+// awaitCredentialsSecret requeues the binding with exponential backoff while
+// the credentials secret is missing. Each attempt waits for as long as the
+// binding has already been not ready, so the wait doubles between attempts
+// until it reaches maxSecretBackoff.
+func awaitCredentialsSecret(cfServiceBinding *korifiv1alpha1.CFServiceBinding, notReadyErr k8s.NotReadyError) k8s.NotReadyError {
+	backoff := minSecretBackoff
+
+	readyCondition := meta.FindStatusCondition(cfServiceBinding.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+	if readyCondition != nil && readyCondition.Status == metav1.ConditionFalse {
+		backoff = min(max(time.Since(readyCondition.LastTransitionTime.Time), minSecretBackoff), maxSecretBackoff)
+	}
+
+	return notReadyErr.WithRequeueAfter(backoff)
+}
+
 func (r *UPSIBindingReconciler) reconcileCredentials(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (*corev1.Secret, error) {
 	cfServiceBinding.Status.Credentials.Name = cfServiceInstance.Status.Credentials.Name
 
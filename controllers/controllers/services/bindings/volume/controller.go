@@ -0,0 +1,80 @@
+package volume
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type VolumeBindingReconciler struct {
+	k8sClient client.Client
+	scheme    *runtime.Scheme
+}
+
+func NewReconciler(k8sClient client.Client, scheme *runtime.Scheme) *VolumeBindingReconciler {
+	return &VolumeBindingReconciler{
+		k8sClient: k8sClient,
+		scheme:    scheme,
+	}
+}
+
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+
+func (r *VolumeBindingReconciler) ReconcileResource(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !cfServiceBinding.GetDeletionTimestamp().IsZero() {
+		// the claim belongs to the service instance and outlives its bindings
+		if controllerutil.RemoveFinalizer(cfServiceBinding, korifiv1alpha1.CFServiceBindingFinalizerName) {
+			log.V(1).Info("finalizer removed")
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	if cfServiceInstance.Spec.Volume == nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("VolumeNotConfigured").
+			WithMessage("The volume service instance does not specify a volume").
+			WithNoRequeue()
+	}
+
+	// the claim is provisioned by the service instance controller, whose
+	// status updates retrigger the binding reconciliation
+	claim := &corev1.PersistentVolumeClaim{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: cfServiceInstance.Namespace, Name: cfServiceInstance.Name}, claim)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, k8s.NewNotReadyError().
+				WithReason("VolumeNotBound").
+				WithMessage("The persistent volume claim of the service instance has not been provisioned yet").
+				WithNoRequeue()
+		}
+		return ctrl.Result{}, err
+	}
+
+	// pending claims are mounted too: claims of storage classes binding
+	// volumes on first use are only bound once an app instance mounts them
+	if claim.Status.Phase == corev1.ClaimLost {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("VolumeLost").
+			WithMessage("The persistent volume of the service instance claim has been lost").
+			WithNoRequeue()
+	}
+
+	cfServiceBinding.Status.Volume = &korifiv1alpha1.BindingVolume{
+		ClaimName: claim.Name,
+		MountPath: cfServiceInstance.Spec.Volume.MountPath,
+	}
+
+	return ctrl.Result{}, nil
+}
@@ -133,6 +133,15 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceInstance *k
 		return ctrl.Result{}, notReadyErr
 	}
 
+	// Owning the secret lets garbage collection delete the credentials with
+	// the instance, including the ones of instances with migrated legacy
+	// credentials
+	if err = k8s.PatchResource(ctx, r.k8sClient, credentialsSecret, func() {
+		_ = controllerutil.SetOwnerReference(cfServiceInstance, credentialsSecret, r.scheme)
+	}); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to set the owner of the credentials secret")
+	}
+
 	if err = r.validateCredentials(credentialsSecret); err != nil {
 		cfServiceInstance.Status.LastOperation = services.LastOperation{
 			Type:  "create",
@@ -0,0 +1,174 @@
+package volume
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/services"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+type Reconciler struct {
+	k8sClient client.Client
+	scheme    *runtime.Scheme
+	log       logr.Logger
+}
+
+func NewReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceInstance, *korifiv1alpha1.CFServiceInstance] {
+	serviceInstanceReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log}
+	return k8s.NewPatchingReconciler(log, client, &serviceInstanceReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFServiceInstance{}).
+		Named("volume-cfserviceinstance").
+		WithEventFilter(predicate.NewPredicateFuncs(r.isVolume)).
+		Owns(&corev1.PersistentVolumeClaim{})
+}
+
+func (r *Reconciler) isVolume(object client.Object) bool {
+	serviceInstance, ok := object.(*korifiv1alpha1.CFServiceInstance)
+	if !ok {
+		return true
+	}
+
+	return serviceInstance.Spec.Type == korifiv1alpha1.VolumeType
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;patch
+//+kubebuilder:rbac:groups="storage.k8s.io",resources=storageclasses,verbs=get;list;watch
+
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cfServiceInstance.Status.ObservedGeneration = cfServiceInstance.Generation
+	log.V(1).Info("set observed generation", "generation", cfServiceInstance.Status.ObservedGeneration)
+
+	if !cfServiceInstance.GetDeletionTimestamp().IsZero() {
+		// the claim is owned by the instance and garbage collected with it
+		controllerutil.RemoveFinalizer(cfServiceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName)
+		log.V(1).Info("finalizer removed")
+
+		return ctrl.Result{}, nil
+	}
+
+	if cfServiceInstance.Spec.Volume == nil {
+		cfServiceInstance.Status.LastOperation = services.LastOperation{
+			Type:  "create",
+			State: "failed",
+		}
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("VolumeNotConfigured").
+			WithMessage("The volume service instance does not specify a volume").
+			WithNoRequeue()
+	}
+
+	claim, err := r.reconcileClaim(ctx, cfServiceInstance)
+	if err != nil {
+		log.Info("error creating/updating persistent volume claim", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	waitsForConsumer, err := r.waitsForFirstConsumer(ctx, claim)
+	if err != nil {
+		log.Info("error getting the storage class of the persistent volume claim", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if claim.Status.Phase != corev1.ClaimBound && !waitsForConsumer {
+		cfServiceInstance.Status.LastOperation = services.LastOperation{
+			Type:  "create",
+			State: "in progress",
+		}
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("VolumeNotBound").
+			WithMessage("Waiting for the persistent volume claim to be bound").
+			WithNoRequeue()
+	}
+
+	cfServiceInstance.Status.LastOperation = services.LastOperation{
+		Type:  "create",
+		State: "succeeded",
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileClaim provisions the claim shared by all the bindings of the
+// service instance. The claim spec is immutable, so it is only set on creation
+func (r *Reconciler) reconcileClaim(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (*corev1.PersistentVolumeClaim, error) {
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfServiceInstance.Name,
+			Namespace: cfServiceInstance.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.k8sClient, claim, func() error {
+		if claim.CreationTimestamp.IsZero() {
+			accessMode := cfServiceInstance.Spec.Volume.AccessMode
+			if accessMode == "" {
+				accessMode = corev1.ReadWriteOnce
+			}
+
+			claim.Spec = corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{accessMode},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: *resource.NewQuantity(cfServiceInstance.Spec.Volume.SizeMB*1024*1024, resource.BinarySI),
+					},
+				},
+				StorageClassName: cfServiceInstance.Spec.Volume.StorageClassName,
+			}
+		}
+
+		return controllerutil.SetControllerReference(cfServiceInstance, claim, r.scheme)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+// waitsForFirstConsumer tells whether a pending claim is only bound once a
+// pod uses it, as done by storage classes with the WaitForFirstConsumer
+// binding mode. Such claims are bound when the first bound app instance
+// starts, so they are ready to be bound to apps.
+func (r *Reconciler) waitsForFirstConsumer(ctx context.Context, claim *corev1.PersistentVolumeClaim) (bool, error) {
+	if claim.Status.Phase != corev1.ClaimPending || claim.Spec.StorageClassName == nil {
+		return false, nil
+	}
+
+	storageClass := &storagev1.StorageClass{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Name: *claim.Spec.StorageClassName}, storageClass)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return storageClass.VolumeBindingMode != nil && *storageClass.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer, nil
+}
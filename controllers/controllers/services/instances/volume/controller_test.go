@@ -0,0 +1,260 @@
+package volume_test
+
+import (
+	"github.com/google/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/services"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFServiceInstance", func() {
+	var (
+		testNamespace string
+		instance      *korifiv1alpha1.CFServiceInstance
+	)
+
+	BeforeEach(func() {
+		testNamespace = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testNamespace,
+			},
+		})).To(Succeed())
+	})
+
+	When("the service instance is a volume", func() {
+		BeforeEach(func() {
+			instance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+					Finalizers: []string{
+						korifiv1alpha1.CFServiceInstanceFinalizerName,
+					},
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "shared-volume",
+					Type:        korifiv1alpha1.VolumeType,
+					Tags:        []string{},
+					Volume: &korifiv1alpha1.VolumeServiceSpec{
+						SizeMB:           1024,
+						StorageClassName: tools.PtrTo("nfs"),
+						MountPath:        "/var/vcap/data/shared",
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, instance)).To(Succeed())
+		})
+
+		It("sets the ObservedGeneration status field", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.ObservedGeneration).To(Equal(instance.Generation))
+			}).Should(Succeed())
+		})
+
+		It("provisions a persistent volume claim owned by the service instance", func() {
+			Eventually(func(g Gomega) {
+				claim := &corev1.PersistentVolumeClaim{}
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), claim)).To(Succeed())
+				g.Expect(claim.Spec.AccessModes).To(ConsistOf(corev1.ReadWriteOnce))
+				g.Expect(claim.Spec.Resources.Requests.Storage()).To(RepresentResourceQuantity(1024, "Mi"))
+				g.Expect(claim.Spec.StorageClassName).To(PointTo(Equal("nfs")))
+				g.Expect(claim.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Name":       Equal(instance.Name),
+					"UID":        Equal(instance.UID),
+					"Controller": PointTo(BeTrue()),
+				})))
+			}).Should(Succeed())
+		})
+
+		It("sets the Ready condition to false until the claim is bound", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionFalse)),
+					HasReason(Equal("VolumeNotBound")),
+				)))
+				g.Expect(instance.Status.LastOperation).To(Equal(services.LastOperation{
+					Type:  "create",
+					State: "in progress",
+				}))
+			}).Should(Succeed())
+		})
+
+		When("the service instance sets the access mode", func() {
+			BeforeEach(func() {
+				instance = &korifiv1alpha1.CFServiceInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFServiceInstanceSpec{
+						DisplayName: "shared-volume-rwx",
+						Type:        korifiv1alpha1.VolumeType,
+						Tags:        []string{},
+						Volume: &korifiv1alpha1.VolumeServiceSpec{
+							SizeMB:     1024,
+							AccessMode: corev1.ReadWriteMany,
+							MountPath:  "/var/vcap/data/shared",
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, instance)).To(Succeed())
+			})
+
+			It("requests it for the claim", func() {
+				Eventually(func(g Gomega) {
+					claim := &corev1.PersistentVolumeClaim{}
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), claim)).To(Succeed())
+					g.Expect(claim.Spec.AccessModes).To(ConsistOf(corev1.ReadWriteMany))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the claim is pending and its storage class binds volumes on first use", func() {
+			BeforeEach(func() {
+				storageClass := &storagev1.StorageClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: uuid.NewString(),
+					},
+					Provisioner:       "example.com/volumes",
+					VolumeBindingMode: tools.PtrTo(storagev1.VolumeBindingWaitForFirstConsumer),
+				}
+				Expect(adminClient.Create(ctx, storageClass)).To(Succeed())
+
+				instance = &korifiv1alpha1.CFServiceInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFServiceInstanceSpec{
+						DisplayName: "local-volume",
+						Type:        korifiv1alpha1.VolumeType,
+						Tags:        []string{},
+						Volume: &korifiv1alpha1.VolumeServiceSpec{
+							SizeMB:           1024,
+							StorageClassName: tools.PtrTo(storageClass.Name),
+							MountPath:        "/var/vcap/data/local",
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, instance)).To(Succeed())
+
+				claim := &corev1.PersistentVolumeClaim{}
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), claim)).To(Succeed())
+				}).Should(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, claim, func() {
+					claim.Status.Phase = corev1.ClaimPending
+				})).To(Succeed())
+			})
+
+			It("sets the Ready condition to true", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					)))
+					g.Expect(instance.Status.LastOperation.State).To(Equal("succeeded"))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the claim gets bound", func() {
+			BeforeEach(func() {
+				claim := &corev1.PersistentVolumeClaim{}
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), claim)).To(Succeed())
+				}).Should(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, claim, func() {
+					claim.Status.Phase = corev1.ClaimBound
+				})).To(Succeed())
+			})
+
+			It("sets the Ready condition to true", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					)))
+					g.Expect(instance.Status.LastOperation).To(Equal(services.LastOperation{
+						Type:  "create",
+						State: "succeeded",
+					}))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the service instance does not specify a volume", func() {
+			BeforeEach(func() {
+				instance.Spec.Volume = nil
+			})
+
+			It("sets the Ready condition to false", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("VolumeNotConfigured")),
+					)))
+					g.Expect(instance.Status.LastOperation.State).To(Equal("failed"))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the instance is deleted", func() {
+			JustBeforeEach(func() {
+				Expect(adminClient.Delete(ctx, instance)).To(Succeed())
+			})
+
+			It("is deleted", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the service instance is user-provided", func() {
+		BeforeEach(func() {
+			instance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "service-instance-name",
+					Type:        korifiv1alpha1.UserProvidedType,
+					Tags:        []string{},
+				},
+			}
+			Expect(adminClient.Create(ctx, instance)).To(Succeed())
+		})
+
+		It("does not reconcile it", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status).To(BeZero())
+			}).Should(Succeed())
+		})
+	})
+})
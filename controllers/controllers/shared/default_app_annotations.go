@@ -0,0 +1,48 @@
+package shared
+
+import (
+	"encoding/json"
+	"maps"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+)
+
+// GetDefaultAppAnnotations returns the effective default app annotations
+// recorded in the annotations of an org or space namespace
+func GetDefaultAppAnnotations(namespaceAnnotations map[string]string) map[string]string {
+	defaults := map[string]string{}
+
+	value, ok := namespaceAnnotations[korifiv1alpha1.DefaultAppAnnotationsKey]
+	if !ok {
+		return defaults
+	}
+
+	// the value is only ever written by StampDefaultAppAnnotations, so a
+	// malformed value is treated as if there were no defaults
+	if err := json.Unmarshal([]byte(value), &defaults); err != nil {
+		return map[string]string{}
+	}
+
+	return defaults
+}
+
+// StampDefaultAppAnnotations returns the namespace annotations with the
+// defaults stamped on them and recorded under DefaultAppAnnotationsKey.
+// Previously stamped defaults that are no longer present are removed.
+func StampDefaultAppAnnotations(namespaceAnnotations, defaults map[string]string) map[string]string {
+	result := maps.Clone(namespaceAnnotations)
+	if result == nil {
+		result = map[string]string{}
+	}
+
+	for key := range GetDefaultAppAnnotations(namespaceAnnotations) {
+		delete(result, key)
+	}
+	maps.Copy(result, defaults)
+
+	// marshalling a string map cannot fail
+	value, _ := json.Marshal(defaults)
+	result[korifiv1alpha1.DefaultAppAnnotationsKey] = string(value)
+
+	return result
+}
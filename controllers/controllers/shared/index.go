@@ -7,6 +7,8 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
@@ -22,6 +24,12 @@ const (
 	IndexOrgNamespaceName                     = "orgNamespace"
 	IndexServiceBrokerCredentialsSecretName   = "serviceBrokerCredentialsSecretName"
 	IndexServiceInstancePlanGUID              = "serviceInstancePlanGUID"
+	IndexBuildStagingState                    = "buildStagingState"
+	IndexBuildWorkloadStagingState            = "buildWorkloadStagingState"
+
+	StagingStateQueued  = "queued"
+	StagingStateRunning = "running"
+	StagingStateDone    = "done"
 )
 
 func SetupIndexWithManager(mgr manager.Manager) error {
@@ -93,6 +101,16 @@ func SetupIndexWithManager(mgr manager.Manager) error {
 		return err
 	}
 
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &korifiv1alpha1.CFBuild{}, IndexBuildStagingState, buildStagingStateIndexFn)
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &korifiv1alpha1.BuildWorkload{}, IndexBuildWorkloadStagingState, buildWorkloadStagingStateIndexFn)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -120,6 +138,30 @@ func serviceBindingServiceInstanceGUIDIndexFn(rawObj client.Object) []string {
 	return []string{serviceBinding.Spec.Service.Name}
 }
 
+func buildStagingStateIndexFn(rawObj client.Object) []string {
+	cfBuild := rawObj.(*korifiv1alpha1.CFBuild)
+	if meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType) != nil {
+		return []string{StagingStateDone}
+	}
+
+	stagingStatus := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)
+	if stagingStatus == nil || stagingStatus.Reason == korifiv1alpha1.BuildQueuedReason {
+		return []string{StagingStateQueued}
+	}
+
+	return []string{StagingStateRunning}
+}
+
+func buildWorkloadStagingStateIndexFn(rawObj client.Object) []string {
+	buildWorkload := rawObj.(*korifiv1alpha1.BuildWorkload)
+	succeededStatus := meta.FindStatusCondition(buildWorkload.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+	if succeededStatus == nil || succeededStatus.Status == metav1.ConditionUnknown {
+		return []string{StagingStateRunning}
+	}
+
+	return []string{StagingStateDone}
+}
+
 func RemovePackageManagerKeys(src map[string]string, log logr.Logger) map[string]string {
 	if src == nil {
 		return src
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -23,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -60,9 +62,30 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&korifiv1alpha1.CFServiceBinding{},
 			handler.EnqueueRequestsFromMapFunc(serviceBindingToApp),
+		).
+		Watches(
+			&korifiv1alpha1.AppWorkload{},
+			handler.EnqueueRequestsFromMapFunc(appWorkloadToApp),
+		).
+		Watches(
+			&korifiv1alpha1.CFRoute{},
+			handler.EnqueueRequestsFromMapFunc(routeToApps),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.credentialsSecretToApps),
+			builder.WithPredicates(predicate.NewPredicateFuncs(isServiceInstanceCredentialsSecret)),
 		)
 }
 
+// isServiceInstanceCredentialsSecret selects the credentials secrets of
+// user-provided service instances, the only credentials that can change
+// after binding
+func isServiceInstanceCredentialsSecret(o client.Object) bool {
+	_, ok := o.GetLabels()[korifiv1alpha1.CFServiceInstanceGUIDLabelKey]
+	return ok
+}
+
 func buildToApp(ctx context.Context, o client.Object) []reconcile.Request {
 	cfBuild, ok := o.(*korifiv1alpha1.CFBuild)
 	if !ok {
@@ -79,6 +102,43 @@ func buildToApp(ctx context.Context, o client.Object) []reconcile.Request {
 	}
 }
 
+func appWorkloadToApp(ctx context.Context, o client.Object) []reconcile.Request {
+	appGUID, ok := o.GetLabels()[korifiv1alpha1.CFAppGUIDLabelKey]
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      appGUID,
+				Namespace: o.GetNamespace(),
+			},
+		},
+	}
+}
+
+// routeToApps enqueues the destination apps of a route, so that the uris in
+// their VCAP_APPLICATION secret follow route changes
+func routeToApps(ctx context.Context, o client.Object) []reconcile.Request {
+	cfRoute, ok := o.(*korifiv1alpha1.CFRoute)
+	if !ok {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, destination := range cfRoute.Spec.Destinations {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      destination.AppRef.Name,
+				Namespace: o.GetNamespace(),
+			},
+		})
+	}
+
+	return requests
+}
+
 func serviceBindingToApp(ctx context.Context, o client.Object) []reconcile.Request {
 	serviceBinding, ok := o.(*korifiv1alpha1.CFServiceBinding)
 	if !ok {
@@ -95,11 +155,38 @@ func serviceBindingToApp(ctx context.Context, o client.Object) []reconcile.Reque
 	}
 }
 
+// credentialsSecretToApps enqueues the apps bound to the credentials in the
+// secret so that their VCAP_SERVICES reflect credential changes
+func (r *Reconciler) credentialsSecretToApps(ctx context.Context, o client.Object) []reconcile.Request {
+	serviceBindings := korifiv1alpha1.CFServiceBindingList{}
+	if err := r.k8sClient.List(ctx, &serviceBindings, client.InNamespace(o.GetNamespace())); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, serviceBinding := range serviceBindings.Items {
+		if serviceBinding.Status.Credentials.Name != o.GetName() {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      serviceBinding.Spec.AppRef.Name,
+				Namespace: serviceBinding.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps,verbs=get;list;watch;create;update;patch;delete;deletecollection
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps/finalizers,verbs=update
 
-//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads,verbs=get;list;watch
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch;delete
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
@@ -120,6 +207,15 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha
 		cfApp.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey] = cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey]
 	}
 
+	result, err := r.reconcileApp(ctx, cfApp)
+	if cfApp.Annotations[korifiv1alpha1.CFAppReadinessDeadlineAnnotation] == "" {
+		return result, err
+	}
+
+	return r.reconcileReadinessDeadline(ctx, cfApp, result, err)
+}
+
+func (r *Reconciler) reconcileApp(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (ctrl.Result, error) {
 	bindingsReady, err := r.serviceBindingsReady(ctx, cfApp)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -163,9 +259,97 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("DesiredStateNotReached")
 	}
 
+	if _, deploying := cfApp.Annotations[korifiv1alpha1.CFAppReadinessDeadlineAnnotation]; deploying {
+		var dropletReady bool
+		dropletReady, err = r.dropletReady(ctx, cfApp, droplet, reconciledProcesses)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if !dropletReady {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithReason("DropletNotReady")
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// dropletReady checks that all the instances of the workloads running the
+// droplet are ready. The app actual state is not enough during a rolling
+// deployment, as the instances of the previous droplet keep running until
+// they are replaced
+func (r *Reconciler) dropletReady(ctx context.Context, cfApp *korifiv1alpha1.CFApp, droplet *korifiv1alpha1.BuildDropletStatus, processes []*korifiv1alpha1.CFProcess) (bool, error) {
+	appWorkloads := &korifiv1alpha1.AppWorkloadList{}
+	if err := r.k8sClient.List(ctx, appWorkloads,
+		client.InNamespace(cfApp.Namespace),
+		client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+	); err != nil {
+		return false, err
+	}
+
+	for _, process := range processes {
+		if process.Spec.DesiredInstances == nil || *process.Spec.DesiredInstances == 0 {
+			continue
+		}
+
+		if !slices.ContainsFunc(appWorkloads.Items, func(appWorkload korifiv1alpha1.AppWorkload) bool {
+			return appWorkload.Labels[korifiv1alpha1.CFProcessGUIDLabelKey] == process.Name &&
+				appWorkload.Spec.Image == droplet.Registry.Image &&
+				appWorkload.Status.ObservedGeneration == appWorkload.Generation &&
+				appWorkload.Status.ReadyInstances >= appWorkload.Spec.Instances
+		}) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// reconcileReadinessDeadline rolls the app back to the droplet it was running
+// before the deployment if it has not become ready by the readiness deadline
+func (r *Reconciler) reconcileReadinessDeadline(ctx context.Context, cfApp *korifiv1alpha1.CFApp, result ctrl.Result, reconcileErr error) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileReadinessDeadline")
+
+	if reconcileErr == nil {
+		clearReadinessDeadline(cfApp)
+		return result, nil
+	}
+
+	var notReadyErr k8s.NotReadyError
+	if !errors.As(reconcileErr, &notReadyErr) {
+		return result, reconcileErr
+	}
+
+	deadline, err := time.Parse(time.RFC3339, cfApp.Annotations[korifiv1alpha1.CFAppReadinessDeadlineAnnotation])
+	if err != nil {
+		log.Info("ignoring invalid readiness deadline", "reason", err)
+		clearReadinessDeadline(cfApp)
+		return result, reconcileErr
+	}
+
+	if remaining := time.Until(deadline); remaining > 0 {
+		return result, notReadyErr.WithRequeueAfter(remaining)
+	}
+
+	failedDropletGUID := cfApp.Spec.CurrentDropletRef.Name
+	previousDropletGUID := cfApp.Annotations[korifiv1alpha1.CFAppPreviousDropletAnnotation]
+	log.Info("app did not become ready in time, rolling back", "droplet", failedDropletGUID, "previousDroplet", previousDropletGUID)
+
+	cfApp.Spec.CurrentDropletRef.Name = previousDropletGUID
+	cfApp.Annotations[korifiv1alpha1.CFAppRolledBackDropletAnnotation] = failedDropletGUID
+	clearReadinessDeadline(cfApp)
+
+	return ctrl.Result{}, k8s.NewNotReadyError().
+		WithReason("DeploymentRolledBack").
+		WithMessage(fmt.Sprintf("droplet %q did not become ready in time, rolled back to droplet %q", failedDropletGUID, previousDropletGUID)).
+		WithRequeue()
+}
+
+func clearReadinessDeadline(cfApp *korifiv1alpha1.CFApp) {
+	delete(cfApp.Annotations, korifiv1alpha1.CFAppPreviousDropletAnnotation)
+	delete(cfApp.Annotations, korifiv1alpha1.CFAppReadinessDeadlineAnnotation)
+}
+
 func (r *Reconciler) serviceBindingsReady(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (bool, error) {
 	bindings := &korifiv1alpha1.CFServiceBindingList{}
 	if err := r.k8sClient.List(ctx, bindings,
@@ -327,7 +511,21 @@ func (r *Reconciler) finalizeCFApp(ctx context.Context, cfApp *korifiv1alpha1.CF
 		return ctrl.Result{}, nil
 	}
 
-	err := r.finalizeCFAppRoutes(ctx, cfApp)
+	processesFinalizationResult, err := r.finalizeCFProcesses(ctx, cfApp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if (processesFinalizationResult != ctrl.Result{}) {
+		return processesFinalizationResult, nil
+	}
+
+	err = r.finalizeEnvSecret(ctx, cfApp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.finalizeCFAppRoutes(ctx, cfApp)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -348,6 +546,52 @@ func (r *Reconciler) finalizeCFApp(ctx context.Context, cfApp *korifiv1alpha1.CF
 	return ctrl.Result{}, nil
 }
 
+func (r *Reconciler) finalizeCFProcesses(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCFProcesses")
+
+	processList := korifiv1alpha1.CFProcessList{}
+	err := r.k8sClient.List(ctx, &processList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		log.Info("failed to list app processes", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if len(processList.Items) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	for i := range processList.Items {
+		err = r.k8sClient.Delete(ctx, &processList.Items[i])
+		if client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete process", "processName", processList.Items[i].Name, "reason", err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: time.Second}, nil
+}
+
+func (r *Reconciler) finalizeEnvSecret(ctx context.Context, cfApp *korifiv1alpha1.CFApp) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("finalizeEnvSecret")
+
+	if cfApp.Spec.EnvSecretName == "" {
+		return nil
+	}
+
+	err := r.k8sClient.Delete(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfApp.Namespace,
+			Name:      cfApp.Spec.EnvSecretName,
+		},
+	})
+	if client.IgnoreNotFound(err) != nil {
+		log.Info("failed to delete env secret", "secretName", cfApp.Spec.EnvSecretName, "reason", err)
+		return err
+	}
+
+	return nil
+}
+
 func (r *Reconciler) finalizeCFAppRoutes(ctx context.Context, cfApp *korifiv1alpha1.CFApp) error {
 	cfRoutes, err := r.getCFRoutes(ctx, cfApp.Name, cfApp.Namespace)
 	if err != nil {
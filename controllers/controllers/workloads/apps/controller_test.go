@@ -1,6 +1,10 @@
 package apps_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
@@ -14,6 +18,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -137,12 +142,19 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
 
 			g.Expect(cfApp.Status.VCAPApplicationSecretName).NotTo(BeEmpty())
+			secret := &corev1.Secret{}
 			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(&corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: cfApp.Namespace,
 					Name:      cfApp.Status.VCAPApplicationSecretName,
 				},
-			}), &corev1.Secret{})).To(Succeed())
+			}), secret)).To(Succeed())
+			g.Expect(secret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind":       Equal("CFApp"),
+				"Name":       Equal(cfApp.Name),
+				"UID":        Equal(cfApp.UID),
+				"Controller": PointTo(BeTrue()),
+			})))
 		}).Should(Succeed())
 	})
 
@@ -151,12 +163,19 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
 			g.Expect(cfApp.Status.VCAPServicesSecretName).NotTo(BeEmpty())
 
+			secret := &corev1.Secret{}
 			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(&corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: cfApp.Namespace,
 					Name:      cfApp.Status.VCAPServicesSecretName,
 				},
-			}), &corev1.Secret{})).To(Succeed())
+			}), secret)).To(Succeed())
+			g.Expect(secret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind":       Equal("CFApp"),
+				"Name":       Equal(cfApp.Name),
+				"UID":        Equal(cfApp.UID),
+				"Controller": PointTo(BeTrue()),
+			})))
 		}).Should(Succeed())
 	})
 
@@ -212,7 +231,9 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 					}),
 					"ObjectMeta": MatchFields(IgnoreExtras, Fields{
 						"OwnerReferences": ConsistOf(MatchFields(IgnoreExtras, Fields{
-							"Name": Equal(cfApp.Name),
+							"Name":       Equal(cfApp.Name),
+							"UID":        Equal(cfApp.UID),
+							"Controller": PointTo(BeTrue()),
 						})),
 					}),
 				}),
@@ -344,6 +365,119 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 		})
 	})
 
+	When("the app is deployed with a readiness timeout", func() {
+		var (
+			previousDropletGUID string
+			webProcess          *korifiv1alpha1.CFProcess
+		)
+
+		createReadyAppWorkload := func(image string) {
+			appWorkload := &korifiv1alpha1.AppWorkload{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+					Labels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+						korifiv1alpha1.CFProcessGUIDLabelKey: webProcess.Name,
+					},
+				},
+				Spec: korifiv1alpha1.AppWorkloadSpec{
+					GUID:        webProcess.Name,
+					Version:     "1",
+					AppGUID:     cfApp.Name,
+					ProcessType: "web",
+					Image:       image,
+					Instances:   1,
+					RunnerName:  "statefulset-runner",
+				},
+			}
+			Expect(adminClient.Create(ctx, appWorkload)).To(Succeed())
+			Expect(k8s.Patch(ctx, adminClient, appWorkload, func() {
+				appWorkload.Status.ObservedGeneration = appWorkload.Generation
+				appWorkload.Status.ActualInstances = 1
+				appWorkload.Status.ReadyInstances = 1
+			})).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			previousDropletGUID = cfBuild.Name
+
+			newBuild := &korifiv1alpha1.CFBuild{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+				},
+				Spec: cfBuild.Spec,
+			}
+			Expect(adminClient.Create(ctx, newBuild)).To(Succeed())
+			Expect(k8s.Patch(ctx, adminClient, newBuild, func() {
+				newBuild.Status = korifiv1alpha1.CFBuildStatus{
+					Droplet: &korifiv1alpha1.BuildDropletStatus{
+						Registry: korifiv1alpha1.Registry{Image: "new/droplet"},
+					},
+				}
+			})).To(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+				cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				cfApp.Spec.CurrentDropletRef.Name = newBuild.Name
+				cfApp.Annotations[korifiv1alpha1.CFAppPreviousDropletAnnotation] = previousDropletGUID
+				cfApp.Annotations[korifiv1alpha1.CFAppReadinessDeadlineAnnotation] = time.Now().Add(2 * time.Second).UTC().Format(time.RFC3339)
+			})).To(Succeed())
+
+			webProcess = &korifiv1alpha1.CFProcess{}
+			Eventually(func(g Gomega) {
+				cfProcessList := &korifiv1alpha1.CFProcessList{}
+				g.Expect(adminClient.List(ctx, cfProcessList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{
+					korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+				})).To(Succeed())
+				g.Expect(cfProcessList.Items).To(HaveLen(1))
+
+				webProcess = &cfProcessList.Items[0]
+				g.Expect(k8s.Patch(ctx, adminClient, webProcess, func() {
+					webProcess.Spec.DesiredInstances = tools.PtrTo[int32](1)
+					webProcess.Status.ActualInstances = 1
+				})).To(Succeed())
+			}).Should(Succeed())
+		})
+
+		When("only the instances of the previous droplet are ready", func() {
+			BeforeEach(func() {
+				createReadyAppWorkload("previous/droplet")
+			})
+
+			It("rolls the app back to the previous droplet", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					g.Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(previousDropletGUID))
+					g.Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRolledBackDropletAnnotation, Not(Equal(previousDropletGUID))))
+					g.Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppReadinessDeadlineAnnotation))
+					g.Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppPreviousDropletAnnotation))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the new droplet becomes ready in time", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations[korifiv1alpha1.CFAppReadinessDeadlineAnnotation] = time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+				})).To(Succeed())
+
+				createReadyAppWorkload("new/droplet")
+			})
+
+			It("keeps the new droplet and clears the readiness deadline", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(cfApp.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+					g.Expect(cfApp.Spec.CurrentDropletRef.Name).NotTo(Equal(previousDropletGUID))
+					g.Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppReadinessDeadlineAnnotation))
+					g.Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppRolledBackDropletAnnotation))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("the app has a service binding", func() {
 		var binding *korifiv1alpha1.CFServiceBinding
 
@@ -427,10 +561,179 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 		})
 	})
 
+	When("the app has several service bindings", func() {
+		var credentialsSecrets []*corev1.Secret
+
+		vcapServicesCredentials := func(g Gomega) []map[string]any {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+			g.Expect(cfApp.Status.VCAPServicesSecretName).NotTo(BeEmpty())
+
+			vcapServicesSecret := &corev1.Secret{}
+			g.Expect(adminClient.Get(ctx, types.NamespacedName{
+				Namespace: cfApp.Namespace,
+				Name:      cfApp.Status.VCAPServicesSecretName,
+			}, vcapServicesSecret)).To(Succeed())
+
+			vcapServices := map[string][]map[string]any{}
+			g.Expect(json.Unmarshal(vcapServicesSecret.Data["VCAP_SERVICES"], &vcapServices)).To(Succeed())
+
+			creds := []map[string]any{}
+			for _, services := range vcapServices {
+				for _, service := range services {
+					creds = append(creds, service["credentials"].(map[string]any))
+				}
+			}
+			return creds
+		}
+
+		BeforeEach(func() {
+			credentialsSecrets = nil
+
+			for _, dbName := range []string{"db-one", "db-two"} {
+				instanceGUID := uuid.NewString()
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: cfApp.Namespace,
+						Labels: map[string]string{
+							korifiv1alpha1.CFServiceInstanceGUIDLabelKey: instanceGUID,
+						},
+					},
+					Data: map[string][]byte{
+						tools.CredentialsSecretKey: []byte(fmt.Sprintf(`{"db":%q}`, dbName)),
+					},
+				}
+				Expect(adminClient.Create(ctx, secret)).To(Succeed())
+				credentialsSecrets = append(credentialsSecrets, secret)
+
+				instance := &korifiv1alpha1.CFServiceInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      instanceGUID,
+						Namespace: cfApp.Namespace,
+					},
+					Spec: korifiv1alpha1.CFServiceInstanceSpec{
+						DisplayName: dbName,
+						SecretName:  secret.Name,
+						Type:        "user-provided",
+					},
+				}
+				Expect(adminClient.Create(ctx, instance)).To(Succeed())
+
+				binding := &korifiv1alpha1.CFServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: cfApp.Namespace,
+					},
+					Spec: korifiv1alpha1.CFServiceBindingSpec{
+						Service: corev1.ObjectReference{
+							Namespace: cfApp.Namespace,
+							Name:      instance.Name,
+						},
+						AppRef: corev1.LocalObjectReference{Name: cfApp.Name},
+						Type:   korifiv1alpha1.CFServiceBindingTypeApp,
+					},
+				}
+				Expect(adminClient.Create(ctx, binding)).To(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, binding, func() {
+					binding.Status.Credentials.Name = secret.Name
+					meta.SetStatusCondition(&binding.Status.Conditions, metav1.Condition{
+						Type:   korifiv1alpha1.StatusConditionReady,
+						Status: metav1.ConditionTrue,
+						Reason: "BindingReady",
+					})
+				})).To(Succeed())
+			}
+		})
+
+		It("puts the credentials of all bindings into the VCAP_SERVICES secret", func() {
+			Eventually(vcapServicesCredentials).Should(ConsistOf(
+				map[string]any{"db": "db-one"},
+				map[string]any{"db": "db-two"},
+			))
+		})
+
+		When("the credentials of a binding change", func() {
+			BeforeEach(func() {
+				Eventually(vcapServicesCredentials).Should(HaveLen(2))
+
+				Expect(k8s.Patch(ctx, adminClient, credentialsSecrets[0], func() {
+					credentialsSecrets[0].Data[tools.CredentialsSecretKey] = []byte(`{"db":"db-one-rotated"}`)
+				})).To(Succeed())
+			})
+
+			It("updates the VCAP_SERVICES secret", func() {
+				Eventually(vcapServicesCredentials).Should(ConsistOf(
+					map[string]any{"db": "db-one-rotated"},
+					map[string]any{"db": "db-two"},
+				))
+			})
+		})
+	})
+
+	When("a route to the app is added", func() {
+		BeforeEach(func() {
+			cfDomainGUID := uuid.NewString()
+			Expect(adminClient.Create(ctx, &korifiv1alpha1.CFDomain{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      cfDomainGUID,
+				},
+				Spec: korifiv1alpha1.CFDomainSpec{
+					Name: "a" + uuid.NewString() + ".com",
+				},
+			})).To(Succeed())
+
+			cfRoute := &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+				},
+				Spec: korifiv1alpha1.CFRouteSpec{
+					Host:     "my-app",
+					Protocol: "http",
+					DomainRef: corev1.ObjectReference{
+						Name:      cfDomainGUID,
+						Namespace: testNamespace,
+					},
+					Destinations: []korifiv1alpha1.Destination{{
+						GUID:        uuid.NewString(),
+						AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+						ProcessType: "web",
+						Protocol:    tools.PtrTo("http1"),
+					}},
+				},
+			}
+			Expect(adminClient.Create(ctx, cfRoute)).To(Succeed())
+			Expect(k8s.Patch(ctx, adminClient, cfRoute, func() {
+				cfRoute.Status.URI = "my-app.my.domain"
+			})).To(Succeed())
+		})
+
+		It("updates the uris in the VCAP_APPLICATION secret", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				g.Expect(cfApp.Status.VCAPApplicationSecretName).NotTo(BeEmpty())
+
+				secret := &corev1.Secret{}
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{
+					Namespace: cfApp.Namespace,
+					Name:      cfApp.Status.VCAPApplicationSecretName,
+				}, secret)).To(Succeed())
+
+				vcapApplication := map[string]any{}
+				g.Expect(json.Unmarshal(secret.Data["VCAP_APPLICATION"], &vcapApplication)).To(Succeed())
+				g.Expect(vcapApplication).To(HaveKeyWithValue("uris", ConsistOf("my-app.my.domain")))
+				g.Expect(vcapApplication).To(HaveKeyWithValue("application_uris", ConsistOf("my-app.my.domain")))
+			}).Should(Succeed())
+		})
+	})
+
 	Describe("finalization", func() {
 		var (
 			cfDomainGUID string
 			cfRoute      *korifiv1alpha1.CFRoute
+			envSecret    *corev1.Secret
 		)
 
 		BeforeEach(func() {
@@ -487,6 +790,34 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 			}
 			Expect(adminClient.Create(ctx, &cfServiceBinding)).To(Succeed())
 
+			cfProcess := &korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+					Labels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+					},
+				},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					AppRef: corev1.LocalObjectReference{
+						Name: cfApp.Name,
+					},
+					ProcessType: "worker",
+				},
+			}
+			Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+
+			envSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+				},
+			}
+			Expect(adminClient.Create(ctx, envSecret)).To(Succeed())
+			Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+				cfApp.Spec.EnvSecretName = envSecret.Name
+			})).To(Succeed())
+
 			Expect(adminClient.Delete(ctx, cfApp)).To(Succeed())
 			Eventually(func(g Gomega) {
 				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)
@@ -508,5 +839,23 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 				g.Expect(sbList.Items).To(BeEmpty())
 			}).Should(Succeed())
 		})
+
+		It("deletes the app processes", func() {
+			Eventually(func(g Gomega) {
+				processList := korifiv1alpha1.CFProcessList{}
+				g.Expect(adminClient.List(ctx, &processList,
+					client.InNamespace(cfApp.Namespace),
+					client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+				)).To(Succeed())
+				g.Expect(processList.Items).To(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		It("deletes the app env secret", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(envSecret), envSecret)
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
 	})
 })
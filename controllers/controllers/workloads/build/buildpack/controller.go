@@ -19,11 +19,15 @@ package buildpack
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sync"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
@@ -42,17 +46,28 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	StagingTimeExpiredReason   = "StagingTimeExpired"
+	queuedBuildRequeueInterval = 10 * time.Second
+	// startedWorkloadCacheTimeout bounds how long a started workload is
+	// counted while it is missing from the cache, e.g. because it was deleted
+	// before the cache observed it
+	startedWorkloadCacheTimeout = time.Minute
+)
+
 type BuildpackEnvBuilder interface {
 	Build(context.Context, *korifiv1alpha1.CFApp) ([]corev1.EnvVar, error)
 }
 
 func NewReconciler(
 	k8sClient client.Client,
+	apiReader client.Reader,
 	buildCleaner build.BuildCleaner,
 	scheme *runtime.Scheme,
 	log logr.Logger,
 	controllerConfig *config.ControllerConfig,
 	envBuilder BuildpackEnvBuilder,
+	stagingTimeout time.Duration,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFBuild, *korifiv1alpha1.CFBuild] {
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFBuild, *korifiv1alpha1.CFBuild](
 		log,
@@ -64,18 +79,28 @@ func NewReconciler(
 			buildCleaner,
 			&buildpackBuildReconciler{
 				k8sClient:        k8sClient,
+				apiReader:        apiReader,
 				controllerConfig: controllerConfig,
 				envBuilder:       envBuilder,
 				scheme:           scheme,
+				stagingTimeout:   stagingTimeout,
+				startedWorkloads: map[types.NamespacedName]time.Time{},
 			},
 		))
 }
 
 type buildpackBuildReconciler struct {
 	k8sClient        client.Client
+	apiReader        client.Reader
 	controllerConfig *config.ControllerConfig
 	envBuilder       BuildpackEnvBuilder
 	scheme           *runtime.Scheme
+	stagingTimeout   time.Duration
+
+	// startedWorkloadsMutex guards startedWorkloads, the workloads created
+	// by the reconciler that have not yet been observed in the cache
+	startedWorkloadsMutex sync.Mutex
+	startedWorkloads      map[types.NamespacedName]time.Time
 }
 
 func (r *buildpackBuildReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
@@ -124,6 +149,8 @@ func buildworkloadToBuild(ctx context.Context, o client.Object) []reconcile.Requ
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads/status,verbs=get
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads/finalizers,verbs=update
 
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get,namespace=ROOT_NAMESPACE
+
 func (r *buildpackBuildReconciler) ReconcileBuild(
 	ctx context.Context,
 	cfBuild *korifiv1alpha1.CFBuild,
@@ -133,12 +160,35 @@ func (r *buildpackBuildReconciler) ReconcileBuild(
 	log := logr.FromContextOrDiscard(ctx)
 
 	stagingStatus := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)
-	if stagingStatus == nil {
-		err := r.createBuildWorkload(ctx, cfBuild, cfApp, cfPackage)
+	if stagingStatus == nil || stagingStatus.Reason == korifiv1alpha1.BuildQueuedReason {
+		if r.stagingTimeRemaining(cfBuild) <= 0 {
+			return r.expireStaging(ctx, cfBuild)
+		}
+
+		slotAvailable, err := r.buildSlotAvailable(ctx, cfBuild)
+		if err != nil {
+			log.Info("failed to count in-flight builds", "reason", err)
+			return ctrl.Result{}, err
+		}
+
+		if !slotAvailable {
+			meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+				Type:               korifiv1alpha1.StagingConditionType,
+				Status:             metav1.ConditionFalse,
+				Reason:             korifiv1alpha1.BuildQueuedReason,
+				Message:            "Waiting for other builds to complete",
+				ObservedGeneration: cfBuild.Generation,
+			})
+
+			return ctrl.Result{RequeueAfter: queuedBuildRequeueInterval}, nil
+		}
+
+		err = r.createBuildWorkload(ctx, cfBuild, cfApp, cfPackage)
 		if err != nil {
 			log.Info("failed to create BuildWorkload", "reason", err)
 			return ctrl.Result{}, err
 		}
+		r.workloadStarted(client.ObjectKeyFromObject(cfBuild))
 
 		meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
 			Type:               korifiv1alpha1.StagingConditionType,
@@ -147,14 +197,14 @@ func (r *buildpackBuildReconciler) ReconcileBuild(
 			ObservedGeneration: cfBuild.Generation,
 		})
 
-		return ctrl.Result{}, nil
+		return r.awaitStaging(ctx, cfBuild)
 	}
 
 	var buildWorkload korifiv1alpha1.BuildWorkload
 	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), &buildWorkload)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return ctrl.Result{}, nil
+			return r.awaitStaging(ctx, cfBuild)
 		} else {
 			log.Info("error when fetching BuildWorkload", "reason", err)
 			return ctrl.Result{}, err
@@ -163,7 +213,7 @@ func (r *buildpackBuildReconciler) ReconcileBuild(
 
 	workloadSucceededStatus := meta.FindStatusCondition(buildWorkload.Status.Conditions, korifiv1alpha1.SucceededConditionType)
 	if workloadSucceededStatus == nil {
-		return ctrl.Result{}, nil
+		return r.awaitStaging(ctx, cfBuild)
 	}
 
 	switch workloadSucceededStatus.Status {
@@ -199,15 +249,226 @@ func (r *buildpackBuildReconciler) ReconcileBuild(
 
 		cfBuild.Status.Droplet = buildWorkload.Status.Droplet
 	default:
-		return ctrl.Result{}, nil
+		return r.awaitStaging(ctx, cfBuild)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// stagingTimeRemaining returns how long the build may keep staging, measured
+// from its creation
+func (r *buildpackBuildReconciler) stagingTimeRemaining(cfBuild *korifiv1alpha1.CFBuild) time.Duration {
+	return time.Until(cfBuild.CreationTimestamp.Add(r.stagingTimeout))
+}
+
+// awaitStaging requeues the build for when its staging time runs out, or
+// fails it if that has already happened
+func (r *buildpackBuildReconciler) awaitStaging(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild) (ctrl.Result, error) {
+	if remaining := r.stagingTimeRemaining(cfBuild); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	return r.expireStaging(ctx, cfBuild)
+}
+
+func (r *buildpackBuildReconciler) expireStaging(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("expireStaging")
+
+	err := r.k8sClient.Delete(ctx, &korifiv1alpha1.BuildWorkload{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfBuild.Namespace,
+			Name:      cfBuild.Name,
+		},
+	})
+	if client.IgnoreNotFound(err) != nil {
+		log.Info("failed to delete BuildWorkload", "reason", err)
+		return ctrl.Result{}, err
 	}
 
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.StagingConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "BuildNotRunning",
+		ObservedGeneration: cfBuild.Generation,
+	})
+
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             StagingTimeExpiredReason,
+		Message:            fmt.Sprintf("%s: staging did not complete within %s", StagingTimeExpiredReason, r.stagingTimeout),
+		ObservedGeneration: cfBuild.Generation,
+	})
+
 	return ctrl.Result{}, nil
 }
 
+// buildSlotAvailable checks whether starting another build would exceed the
+// configured cluster-wide or per-space concurrency limits. Builds are admitted
+// in the order they were created: builds queued before this one that fit in
+// the limits are given their slots first. The workload of the build being
+// reconciled is not counted against the limits.
+//
+// Builds and workloads are listed from the cache by their staging state.
+// Workloads started by the reconciler that the cache has not caught up with
+// yet are counted as well.
+func (r *buildpackBuildReconciler) buildSlotAvailable(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild) (bool, error) {
+	limits := r.controllerConfig.BuildConcurrency
+	if limits.MaxInFlightBuilds <= 0 && limits.MaxInFlightBuildsPerSpace <= 0 {
+		return true, nil
+	}
+
+	var buildWorkloads korifiv1alpha1.BuildWorkloadList
+	if err := r.k8sClient.List(ctx, &buildWorkloads, client.MatchingFields{
+		shared.IndexBuildWorkloadStagingState: shared.StagingStateRunning,
+	}); err != nil {
+		return false, err
+	}
+
+	var cfBuilds korifiv1alpha1.CFBuildList
+	if err := r.k8sClient.List(ctx, &cfBuilds, client.MatchingFields{
+		shared.IndexBuildStagingState: shared.StagingStateQueued,
+	}); err != nil {
+		return false, err
+	}
+
+	inFlight := map[types.NamespacedName]bool{}
+	for _, workload := range buildWorkloads.Items {
+		inFlight[client.ObjectKeyFromObject(&workload)] = true
+	}
+
+	startedWorkloads, err := r.uncachedStartedWorkloads(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, key := range startedWorkloads {
+		inFlight[key] = true
+	}
+
+	slots := buildSlots{limits: limits, inFlightPerSpace: map[string]int{}}
+	for key := range inFlight {
+		if key == client.ObjectKeyFromObject(cfBuild) {
+			continue
+		}
+
+		slots.take(key.Namespace)
+	}
+
+	var queuedBefore []korifiv1alpha1.CFBuild
+	for _, queued := range cfBuilds.Items {
+		if inFlight[client.ObjectKeyFromObject(&queued)] || !r.isQueued(&queued) {
+			continue
+		}
+
+		if createdBefore(&queued, cfBuild) {
+			queuedBefore = append(queuedBefore, queued)
+		}
+	}
+
+	slices.SortFunc(queuedBefore, func(a, b korifiv1alpha1.CFBuild) int {
+		if createdBefore(&a, &b) {
+			return -1
+		}
+		return 1
+	})
+
+	for _, queued := range queuedBefore {
+		if slots.available(queued.Namespace) {
+			slots.take(queued.Namespace)
+		}
+	}
+
+	return slots.available(cfBuild.Namespace), nil
+}
+
+func (r *buildpackBuildReconciler) workloadStarted(key types.NamespacedName) {
+	r.startedWorkloadsMutex.Lock()
+	defer r.startedWorkloadsMutex.Unlock()
+
+	r.startedWorkloads[key] = time.Now()
+}
+
+// uncachedStartedWorkloads returns the workloads started by the reconciler
+// that are not in the cache yet. Workloads that have made it to the cache, or
+// have been missing from it for too long, are forgotten.
+func (r *buildpackBuildReconciler) uncachedStartedWorkloads(ctx context.Context) ([]types.NamespacedName, error) {
+	r.startedWorkloadsMutex.Lock()
+	defer r.startedWorkloadsMutex.Unlock()
+
+	var uncached []types.NamespacedName
+	for key, startedAt := range r.startedWorkloads {
+		if time.Since(startedAt) > startedWorkloadCacheTimeout {
+			delete(r.startedWorkloads, key)
+			continue
+		}
+
+		err := r.k8sClient.Get(ctx, key, new(korifiv1alpha1.BuildWorkload))
+		if err == nil {
+			delete(r.startedWorkloads, key)
+			continue
+		}
+
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		uncached = append(uncached, key)
+	}
+
+	return uncached, nil
+}
+
+// isQueued returns whether the build is waiting for a build slot and has not
+// yet run out of staging time
+func (r *buildpackBuildReconciler) isQueued(cfBuild *korifiv1alpha1.CFBuild) bool {
+	if cfBuild.Spec.Lifecycle.Type != "buildpack" || !cfBuild.DeletionTimestamp.IsZero() {
+		return false
+	}
+
+	return r.stagingTimeRemaining(cfBuild) > 0
+}
+
+func createdBefore(a, b *korifiv1alpha1.CFBuild) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+
+	return a.Name < b.Name
+}
+
+type buildSlots struct {
+	limits           config.BuildConcurrency
+	inFlight         int
+	inFlightPerSpace map[string]int
+}
+
+func (s *buildSlots) take(namespace string) {
+	s.inFlight++
+	s.inFlightPerSpace[namespace]++
+}
+
+func (s *buildSlots) available(namespace string) bool {
+	return belowLimit(s.inFlight, s.limits.MaxInFlightBuilds) &&
+		belowLimit(s.inFlightPerSpace[namespace], s.limits.MaxInFlightBuildsPerSpace)
+}
+
+func belowLimit(count, limit int) bool {
+	return limit <= 0 || count < limit
+}
+
 func (r *buildpackBuildReconciler) createBuildWorkload(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild, cfApp *korifiv1alpha1.CFApp, cfPackage *korifiv1alpha1.CFPackage) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("createBuildWorkload")
 
+	buildpacks, err := r.buildpacks(ctx, cfBuild)
+	if err != nil {
+		log.Info("failed to get the default buildpack order", "reason", err)
+		return err
+	}
+
 	namespace := cfBuild.Namespace
 	desiredWorkload := korifiv1alpha1.BuildWorkload{
 		ObjectMeta: metav1.ObjectMeta{
@@ -229,7 +490,7 @@ func (r *buildpackBuildReconciler) createBuildWorkload(ctx context.Context, cfBu
 				},
 			},
 			BuilderName: r.controllerConfig.BuilderName,
-			Buildpacks:  cfBuild.Spec.Lifecycle.Data.Buildpacks,
+			Buildpacks:  buildpacks,
 		},
 	}
 
@@ -260,6 +521,27 @@ func (r *buildpackBuildReconciler) createBuildWorkload(ctx context.Context, cfBu
 	return nil
 }
 
+// buildpacks returns the buildpacks requested by the build, falling back to
+// the default buildpack order configured by the admin. When no order has been
+// configured the order of the builder is used. The order is read uncached, so
+// that the controllers do not need to watch config maps.
+func (r *buildpackBuildReconciler) buildpacks(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild) ([]string, error) {
+	if len(cfBuild.Spec.Lifecycle.Data.Buildpacks) > 0 {
+		return cfBuild.Spec.Lifecycle.Data.Buildpacks, nil
+	}
+
+	orderConfigMap := &corev1.ConfigMap{}
+	err := r.apiReader.Get(ctx, types.NamespacedName{
+		Namespace: r.controllerConfig.CFRootNamespace,
+		Name:      korifiv1alpha1.DefaultBuildpackOrderConfigMapName,
+	}, orderConfigMap)
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	return tools.FromBuildpackOrderData(orderConfigMap.Data[korifiv1alpha1.DefaultBuildpackOrderKey]), nil
+}
+
 func (r *buildpackBuildReconciler) prepareBuildServices(ctx context.Context, namespace, appGUID string) ([]corev1.ObjectReference, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("prepareBuildServices")
 
@@ -2,6 +2,7 @@ package buildpack_test
 
 import (
 	"context"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
@@ -13,6 +14,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -212,6 +214,178 @@ var _ = Describe("CFBuildpackBuildReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	When("the build does not specify buildpacks", func() {
+		BeforeEach(func() {
+			cfBuild.Spec.Lifecycle.Data.Buildpacks = nil
+		})
+
+		It("leaves the buildpacks to the builder", func() {
+			eventuallyBuildWorkloadShould(func(workload *korifiv1alpha1.BuildWorkload, g Gomega) {
+				g.Expect(workload.Spec.Buildpacks).To(BeEmpty())
+			})
+		})
+
+		When("the default buildpack order has been configured", func() {
+			var orderConfigMap *corev1.ConfigMap
+
+			BeforeEach(func() {
+				orderConfigMap = &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.DefaultBuildpackOrderConfigMapName,
+					},
+					Data: map[string]string{
+						korifiv1alpha1.DefaultBuildpackOrderKey: "second-buildpack\nfirst-buildpack",
+					},
+				}
+				Expect(adminClient.Create(ctx, orderConfigMap)).To(Succeed())
+				DeferCleanup(func() {
+					Expect(adminClient.Delete(ctx, orderConfigMap)).To(Succeed())
+				})
+			})
+
+			It("uses the default buildpack order", func() {
+				eventuallyBuildWorkloadShould(func(workload *korifiv1alpha1.BuildWorkload, g Gomega) {
+					g.Expect(workload.Spec.Buildpacks).To(Equal([]string{"second-buildpack", "first-buildpack"}))
+				})
+			})
+
+			When("the buildpacks are reordered", func() {
+				var nextBuild *korifiv1alpha1.CFBuild
+
+				JustBeforeEach(func() {
+					eventuallyBuildWorkloadShould(func(_ *korifiv1alpha1.BuildWorkload, _ Gomega) {})
+
+					Expect(k8s.Patch(ctx, adminClient, orderConfigMap, func() {
+						orderConfigMap.Data[korifiv1alpha1.DefaultBuildpackOrderKey] = "first-buildpack\nsecond-buildpack"
+					})).To(Succeed())
+
+					// let the next build through the per-space concurrency limit
+					firstWorkload := &korifiv1alpha1.BuildWorkload{
+						ObjectMeta: metav1.ObjectMeta{Name: cfBuild.Name, Namespace: testNamespace},
+					}
+					Expect(k8s.Patch(ctx, adminClient, firstWorkload, func() {
+						meta.SetStatusCondition(&firstWorkload.Status.Conditions, metav1.Condition{
+							Type:   korifiv1alpha1.SucceededConditionType,
+							Status: metav1.ConditionTrue,
+							Reason: "shrug",
+						})
+					})).To(Succeed())
+
+					nextBuild = &korifiv1alpha1.CFBuild{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      uuid.NewString(),
+							Namespace: testNamespace,
+						},
+						Spec: cfBuild.Spec,
+					}
+					Expect(adminClient.Create(ctx, nextBuild)).To(Succeed())
+				})
+
+				It("uses the new order for subsequent builds", func() {
+					Eventually(func(g Gomega) {
+						workload := new(korifiv1alpha1.BuildWorkload)
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(nextBuild), workload)).To(Succeed())
+						g.Expect(workload.Spec.Buildpacks).To(Equal([]string{"first-buildpack", "second-buildpack"}))
+					}, "20s").Should(Succeed())
+				})
+			})
+		})
+	})
+
+	When("the space already has a build in flight", func() {
+		var queuedBuild *korifiv1alpha1.CFBuild
+
+		JustBeforeEach(func() {
+			eventuallyBuildWorkloadShould(func(_ *korifiv1alpha1.BuildWorkload, _ Gomega) {})
+
+			queuedBuild = &korifiv1alpha1.CFBuild{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+				},
+				Spec: cfBuild.Spec,
+			}
+			Expect(adminClient.Create(ctx, queuedBuild)).To(Succeed())
+		})
+
+		It("queues the extra build until the first one completes", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(queuedBuild), queuedBuild)).To(Succeed())
+
+				stagingCondition := meta.FindStatusCondition(queuedBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)
+				g.Expect(stagingCondition).NotTo(BeNil())
+				g.Expect(stagingCondition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(stagingCondition.Reason).To(Equal("BuildQueued"))
+			}).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(queuedBuild), new(korifiv1alpha1.BuildWorkload))
+				g.Expect(err).To(MatchError(ContainSubstring("not found")))
+			}).Should(Succeed())
+
+			firstWorkload := &korifiv1alpha1.BuildWorkload{
+				ObjectMeta: metav1.ObjectMeta{Name: cfBuild.Name, Namespace: testNamespace},
+			}
+			Expect(k8s.Patch(ctx, adminClient, firstWorkload, func() {
+				meta.SetStatusCondition(&firstWorkload.Status.Conditions, metav1.Condition{
+					Type:   korifiv1alpha1.SucceededConditionType,
+					Status: metav1.ConditionTrue,
+					Reason: "shrug",
+				})
+			})).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(queuedBuild), new(korifiv1alpha1.BuildWorkload))).To(Succeed())
+			}, "20s").Should(Succeed())
+		})
+
+		When("another build is queued after the extra build", func() {
+			var laterBuild *korifiv1alpha1.CFBuild
+
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(queuedBuild), queuedBuild)).To(Succeed())
+					g.Expect(meta.FindStatusCondition(queuedBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)).NotTo(BeNil())
+				}).Should(Succeed())
+
+				// creation timestamps have a resolution of one second
+				time.Sleep(time.Second)
+
+				laterBuild = &korifiv1alpha1.CFBuild{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+					},
+					Spec: cfBuild.Spec,
+				}
+				Expect(adminClient.Create(ctx, laterBuild)).To(Succeed())
+			})
+
+			It("starts the queued builds in the order they were created", func() {
+				firstWorkload := &korifiv1alpha1.BuildWorkload{
+					ObjectMeta: metav1.ObjectMeta{Name: cfBuild.Name, Namespace: testNamespace},
+				}
+				Expect(k8s.Patch(ctx, adminClient, firstWorkload, func() {
+					meta.SetStatusCondition(&firstWorkload.Status.Conditions, metav1.Condition{
+						Type:   korifiv1alpha1.SucceededConditionType,
+						Status: metav1.ConditionTrue,
+						Reason: "shrug",
+					})
+				})).To(Succeed())
+
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(queuedBuild), new(korifiv1alpha1.BuildWorkload))).To(Succeed())
+				}, "20s").Should(Succeed())
+
+				Consistently(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(laterBuild), new(korifiv1alpha1.BuildWorkload))
+					g.Expect(err).To(MatchError(ContainSubstring("not found")))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("the referenced app has a ServiceBinding", func() {
 		BeforeEach(func() {
 			serviceBinding := &korifiv1alpha1.CFServiceBinding{
@@ -291,9 +465,10 @@ var _ = Describe("CFBuildpackBuildReconciler Integration Tests", func() {
 				g.Expect(adminClient.Get(ctx, lookupKey, workload)).To(Succeed())
 				g.Expect(k8s.Patch(ctx, adminClient, workload, func() {
 					meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
-						Type:   korifiv1alpha1.SucceededConditionType,
-						Status: metav1.ConditionFalse,
-						Reason: "shrug",
+						Type:    korifiv1alpha1.SucceededConditionType,
+						Status:  metav1.ConditionFalse,
+						Reason:  "DetectionFailed",
+						Message: `step "detect" exited with code 20`,
 					})
 				})).To(Succeed())
 			}).Should(Succeed())
@@ -313,6 +488,7 @@ var _ = Describe("CFBuildpackBuildReconciler Integration Tests", func() {
 				g.Expect(succeededStatusCondition).NotTo(BeNil())
 				g.Expect(succeededStatusCondition.Status).To(Equal(metav1.ConditionFalse))
 				g.Expect(succeededStatusCondition.Reason).To(Equal("BuildFailed"))
+				g.Expect(succeededStatusCondition.Message).To(Equal(`DetectionFailed: step "detect" exited with code 20`))
 				g.Expect(succeededStatusCondition.ObservedGeneration).To(Equal(cfBuild.Generation))
 			}).Should(Succeed())
 		})
@@ -383,5 +559,39 @@ var _ = Describe("CFBuildpackBuildReconciler Integration Tests", func() {
 				g.Expect(cfBuild.Status.Droplet.Ports).To(ConsistOf(BeEquivalentTo(42)))
 			}).Should(Succeed())
 		})
+
+		It("does not fail the build once the staging timeout expires", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)).To(BeTrue())
+			}).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)).To(BeTrue())
+			}, stagingTimeout+5*time.Second, time.Second).Should(Succeed())
+		})
+	})
+
+	When("the BuildWorkload does not complete within the staging timeout", func() {
+		It("fails the build and deletes the BuildWorkload", func() {
+			eventuallyBuildWorkloadShould(func(_ *korifiv1alpha1.BuildWorkload, _ Gomega) {})
+
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+
+				stagingStatusCondition := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)
+				g.Expect(stagingStatusCondition).NotTo(BeNil())
+				g.Expect(stagingStatusCondition.Status).To(Equal(metav1.ConditionFalse))
+
+				succeededStatusCondition := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+				g.Expect(succeededStatusCondition).NotTo(BeNil())
+				g.Expect(succeededStatusCondition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(succeededStatusCondition.Reason).To(Equal("StagingTimeExpired"))
+
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), new(korifiv1alpha1.BuildWorkload))
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}, stagingTimeout+10*time.Second).Should(Succeed())
+		})
 	})
 })
@@ -28,6 +28,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
 
+const stagingTimeout = 30 * time.Second
+
 var (
 	ctx             context.Context
 	stopManager     context.CancelFunc
@@ -35,6 +37,7 @@ var (
 	testEnv         *envtest.Environment
 	adminClient     client.Client
 	testNamespace   string
+	rootNamespace   string
 )
 
 func TestWorkloadsControllers(t *testing.T) {
@@ -63,22 +66,35 @@ var _ = BeforeSuite(func() {
 	Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
 	Expect(corev1.AddToScheme(scheme.Scheme)).To(Succeed())
 
-	k8sManager := helpers.NewK8sManager(testEnv, filepath.Join("helm", "korifi", "controllers", "role.yaml"))
-	Expect(shared.SetupIndexWithManager(k8sManager)).To(Succeed())
-
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	rootNamespace = uuid.NewString()
+	Expect(adminClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: rootNamespace,
+		},
+	})).To(Succeed())
+
+	k8sManager := helpers.NewK8sManagerWithRootNamespace(testEnv, filepath.Join("helm", "korifi", "controllers", "role.yaml"), rootNamespace)
+	Expect(shared.SetupIndexWithManager(k8sManager)).To(Succeed())
+
 	controllerConfig := &config.ControllerConfig{
-		BuilderName: "buildpack-builder-name",
+		CFRootNamespace: rootNamespace,
+		BuilderName:     "buildpack-builder-name",
+		BuildConcurrency: config.BuildConcurrency{
+			MaxInFlightBuildsPerSpace: 1,
+		},
 	}
 
 	cfBuildpackBuildReconciler := buildpack.NewReconciler(
 		k8sManager.GetClient(),
+		k8sManager.GetAPIReader(),
 		new(buildfake.BuildCleaner),
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFBuildpackBuild"),
 		controllerConfig,
 		env.NewAppEnvBuilder(k8sManager.GetClient()),
+		stagingTimeout,
 	)
 	err = (cfBuildpackBuildReconciler).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
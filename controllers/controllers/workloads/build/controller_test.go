@@ -105,6 +105,7 @@ var _ = Describe("CFBuildReconciler", func() {
 			g.Expect(cfBuild.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
 				"Kind":               Equal("CFApp"),
 				"Name":               Equal(cfApp.Name),
+				"UID":                Equal(cfApp.UID),
 				"Controller":         PointTo(BeTrue()),
 				"BlockOwnerDeletion": PointTo(BeTrue()),
 			})))
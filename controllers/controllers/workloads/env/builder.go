@@ -28,7 +28,13 @@ type ServiceDetails struct {
 	BindingName    *string        `json:"binding_name"`
 	Credentials    map[string]any `json:"credentials"`
 	SyslogDrainURL *string        `json:"syslog_drain_url"`
-	VolumeMounts   []string       `json:"volume_mounts"`
+	VolumeMounts   []VolumeMount  `json:"volume_mounts"`
+}
+
+type VolumeMount struct {
+	ContainerDir string `json:"container_dir"`
+	Mode         string `json:"mode"`
+	DeviceType   string `json:"device_type"`
 }
 
 type AppEnvBuilder struct {
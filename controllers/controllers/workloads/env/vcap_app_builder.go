@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
@@ -33,12 +34,13 @@ func (b *VCAPApplicationEnvValueBuilder) BuildEnvValue(ctx context.Context, cfAp
 		return nil, fmt.Errorf("failed retrieving org for CFSpace: %w", err)
 	}
 
-	appURIs, err := b.getAppURIs(ctx, cfApp)
+	appURIs, err := getAppURIs(ctx, b.k8sClient, cfApp)
 	if err != nil {
 		return nil, fmt.Errorf("failed retrieving app routes: %w", err)
 	}
 
-	vars := b.extraValues
+	// the extra values are shared by all apps, never modify them
+	vars := maps.Clone(b.extraValues)
 	if vars == nil {
 		vars = map[string]any{}
 	}
@@ -59,9 +61,9 @@ func (b *VCAPApplicationEnvValueBuilder) BuildEnvValue(ctx context.Context, cfAp
 	}, nil
 }
 
-func (b *VCAPApplicationEnvValueBuilder) getAppURIs(ctx context.Context, cfApp *korifiv1alpha1.CFApp) ([]string, error) {
+func getAppURIs(ctx context.Context, k8sClient client.Client, cfApp *korifiv1alpha1.CFApp) ([]string, error) {
 	var appRoutes korifiv1alpha1.CFRouteList
-	err := b.k8sClient.List(
+	err := k8sClient.List(
 		ctx,
 		&appRoutes,
 		client.InNamespace(cfApp.Namespace),
@@ -47,14 +47,23 @@ var _ = Describe("VCAP_APPLICATION env value builder", func() {
 		})
 
 		When("extra values are provided", func() {
+			var extraValues map[string]any
+
 			BeforeEach(func() {
-				builder = env.NewVCAPApplicationEnvValueBuilder(controllersClient, map[string]any{
+				extraValues = map[string]any{
 					"application_id": "not-the-application-id",
 					"foo":            "bar",
 					"answer":         42,
 					"innit":          true,
 					"x":              map[string]any{"y": "z"},
-				})
+				}
+				builder = env.NewVCAPApplicationEnvValueBuilder(controllersClient, extraValues)
+			})
+
+			It("does not modify them", func() {
+				Expect(buildVCAPApplicationEnvValueErr).ToNot(HaveOccurred())
+				Expect(extraValues).To(HaveKeyWithValue("application_id", "not-the-application-id"))
+				Expect(extraValues).NotTo(HaveKey("space_id"))
 			})
 
 			It("includes them", func() {
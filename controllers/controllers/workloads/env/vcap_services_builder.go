@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,6 +45,11 @@ func (b *VCAPServicesEnvValueBuilder) BuildEnvValue(ctx context.Context, cfApp *
 			continue
 		}
 
+		// The service instance is gone, the binding credentials have been withdrawn
+		if meta.IsStatusConditionFalse(currentServiceBinding.Status.Conditions, korifiv1alpha1.BindingSecretAvailableCondition) {
+			continue
+		}
+
 		var serviceEnv ServiceDetails
 		var serviceLabel string
 		serviceEnv, serviceLabel, err = buildSingleServiceEnv(ctx, b.k8sClient, currentServiceBinding)
@@ -65,7 +71,8 @@ func (b *VCAPServicesEnvValueBuilder) BuildEnvValue(ctx context.Context, cfApp *
 }
 
 func buildSingleServiceEnv(ctx context.Context, k8sClient client.Client, serviceBinding korifiv1alpha1.CFServiceBinding) (ServiceDetails, string, error) {
-	if serviceBinding.Status.Credentials.Name == "" {
+	// volume service bindings carry a volume mount rather than credentials
+	if serviceBinding.Status.Credentials.Name == "" && serviceBinding.Status.Volume == nil {
 		return ServiceDetails{}, "", fmt.Errorf("credentials secret name not set for service binding %q", serviceBinding.Name)
 	}
 
@@ -77,35 +84,38 @@ func buildSingleServiceEnv(ctx context.Context, k8sClient client.Client, service
 		return ServiceDetails{}, "", fmt.Errorf("error fetching CFServiceInstance: %w", err)
 	}
 
-	credentialsSecret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: serviceBinding.Namespace,
-			Name:      serviceBinding.Status.Credentials.Name,
-		},
-	}
-	err = k8sClient.Get(ctx, client.ObjectKeyFromObject(credentialsSecret), credentialsSecret)
-	if err != nil {
-		return ServiceDetails{}, "", fmt.Errorf("error fetching CFServiceBinding Secret: %w", err)
+	creds := map[string]any{}
+	if serviceBinding.Status.Credentials.Name != "" {
+		credentialsSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: serviceBinding.Namespace,
+				Name:      serviceBinding.Status.Credentials.Name,
+			},
+		}
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(credentialsSecret), credentialsSecret)
+		if err != nil {
+			return ServiceDetails{}, "", fmt.Errorf("error fetching CFServiceBinding Secret: %w", err)
+		}
+
+		err = credentials.GetCredentials(credentialsSecret, &creds)
+		if err != nil {
+			return ServiceDetails{}, "", fmt.Errorf("failed to get credentials for service binding %q: %w", serviceBinding.Name, err)
+		}
 	}
 
 	if serviceInstance.Spec.ServiceLabel != nil && *serviceInstance.Spec.ServiceLabel != "" {
 		serviceLabel = *serviceInstance.Spec.ServiceLabel
 	}
 
-	serviceDetails, err := fromServiceBinding(serviceBinding, serviceInstance, credentialsSecret, serviceLabel)
-	if err != nil {
-		return ServiceDetails{}, "", fmt.Errorf("error fetching CFServiceBinding details: %w", err)
-	}
-
-	return serviceDetails, serviceLabel, nil
+	return fromServiceBinding(serviceBinding, serviceInstance, creds, serviceLabel), serviceLabel, nil
 }
 
 func fromServiceBinding(
 	serviceBinding korifiv1alpha1.CFServiceBinding,
 	serviceInstance korifiv1alpha1.CFServiceInstance,
-	credentialsSecret *corev1.Secret,
+	creds map[string]any,
 	serviceLabel string,
-) (ServiceDetails, error) {
+) ServiceDetails {
 	var serviceName string
 	var bindingName *string
 
@@ -122,10 +132,13 @@ func fromServiceBinding(
 		tags = []string{}
 	}
 
-	creds := map[string]any{}
-	err := credentials.GetCredentials(credentialsSecret, &creds)
-	if err != nil {
-		return ServiceDetails{}, fmt.Errorf("failed to get credentials for service binding %q: %w", serviceBinding.Name, err)
+	volumeMounts := []VolumeMount{}
+	if serviceBinding.Status.Volume != nil {
+		volumeMounts = append(volumeMounts, VolumeMount{
+			ContainerDir: serviceBinding.Status.Volume.MountPath,
+			Mode:         "rw",
+			DeviceType:   "shared",
+		})
 	}
 
 	return ServiceDetails{
@@ -138,6 +151,6 @@ func fromServiceBinding(
 		BindingName:    bindingName,
 		Credentials:    creds,
 		SyslogDrainURL: nil,
-		VolumeMounts:   []string{},
-	}, nil
+		VolumeMounts:   volumeMounts,
+	}
 }
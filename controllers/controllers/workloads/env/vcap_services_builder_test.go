@@ -16,12 +16,14 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("Builder", func() {
 	var (
 		serviceBinding     *korifiv1alpha1.CFServiceBinding
+		serviceBinding2    *korifiv1alpha1.CFServiceBinding
 		serviceInstance    *korifiv1alpha1.CFServiceInstance
 		credentialsSecret  *corev1.Secret
 		vcapServicesSecret *corev1.Secret
@@ -103,7 +105,7 @@ var _ = Describe("Builder", func() {
 		})
 
 		serviceBindingName2 := "my-service-binding-2"
-		serviceBinding2 := &korifiv1alpha1.CFServiceBinding{
+		serviceBinding2 = &korifiv1alpha1.CFServiceBinding{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: cfSpace.Status.GUID,
 				Name:      "my-service-binding-guid-2",
@@ -265,6 +267,52 @@ var _ = Describe("Builder", func() {
 			})
 		})
 
+		When("the service instance of a binding has been deleted", func() {
+			BeforeEach(func() {
+				helpers.EnsurePatch(controllersClient, serviceBinding, func(sb *korifiv1alpha1.CFServiceBinding) {
+					sb.Status.Credentials = corev1.LocalObjectReference{}
+					meta.SetStatusCondition(&sb.Status.Conditions, metav1.Condition{
+						Type:    korifiv1alpha1.BindingSecretAvailableCondition,
+						Status:  metav1.ConditionFalse,
+						Reason:  "ServiceInstanceDeleted",
+						Message: "deleted",
+					})
+				})
+				helpers.EnsureDelete(controllersClient, serviceInstance)
+			})
+
+			It("omits the binding", func() {
+				Expect(buildVCAPServicesEnvValueErr).NotTo(HaveOccurred())
+				Expect(slices.Collect(maps.Keys(parseVcapServices(vcapServices)))).To(ConsistOf("custom-service-2"))
+			})
+		})
+
+		When("a binding is a volume service binding", func() {
+			BeforeEach(func() {
+				helpers.EnsurePatch(controllersClient, serviceBinding2, func(sb *korifiv1alpha1.CFServiceBinding) {
+					sb.Status.Credentials = corev1.LocalObjectReference{}
+					sb.Status.Volume = &korifiv1alpha1.BindingVolume{
+						ClaimName: "my-service-instance-guid-2",
+						MountPath: "/var/vcap/data/shared",
+					}
+				})
+			})
+
+			It("lists the volume mount instead of credentials", func() {
+				Expect(buildVCAPServicesEnvValueErr).NotTo(HaveOccurred())
+				Expect(parseVcapServices(vcapServices)).To(MatchKeys(IgnoreExtras, Keys{
+					"custom-service-2": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"credentials": BeEmpty(),
+						"volume_mounts": ConsistOf(MatchAllKeys(Keys{
+							"container_dir": Equal("/var/vcap/data/shared"),
+							"mode":          Equal("rw"),
+							"device_type":   Equal("shared"),
+						})),
+					})),
+				}))
+			})
+		})
+
 		When("getting the service binding secret fails", func() {
 			BeforeEach(func() {
 				helpers.EnsureDelete(controllersClient, credentialsSecret)
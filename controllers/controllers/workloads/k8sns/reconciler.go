@@ -47,6 +47,7 @@ type NamespaceObject[T any] interface {
 type MetadataCompiler[T any, NS NamespaceObject[T]] interface {
 	CompileLabels(NS) map[string]string
 	CompileAnnotations(NS) map[string]string
+	CompileDefaultAppAnnotations(context.Context, NS) (map[string]string, error)
 }
 
 type Reconciler[T any, NS NamespaceObject[T]] struct {
@@ -114,7 +115,13 @@ func (r *Reconciler[T, NS]) createOrPatchNamespace(ctx context.Context, obj NS)
 		},
 	}
 
+	defaultAppAnnotations, err := r.metadataCompiler.CompileDefaultAppAnnotations(ctx, obj)
+	if err != nil {
+		return fmt.Errorf("failed to compile default app annotations: %w", err)
+	}
+
 	result, err := controllerutil.CreateOrPatch(ctx, r.client, namespace, func() error {
+		namespace.Annotations = shared.StampDefaultAppAnnotations(namespace.Annotations, defaultAppAnnotations)
 		updateMap(&namespace.Annotations, r.metadataCompiler.CompileAnnotations(obj))
 		updateMap(&namespace.Labels, r.metadataCompiler.CompileLabels(obj))
 		return nil
@@ -24,9 +24,11 @@ import (
 )
 
 type mockMetadataCompiler[T any, NS k8sns.NamespaceObject[T]] struct {
-	processedObjects map[NS]any
-	labels           map[string]string
-	annotations      map[string]string
+	processedObjects         map[NS]any
+	labels                   map[string]string
+	annotations              map[string]string
+	defaultAppAnnotations    map[string]string
+	defaultAppAnnotationsErr error
 }
 
 func (c *mockMetadataCompiler[T, NS]) CompileLabels(obj NS) map[string]string {
@@ -39,6 +41,11 @@ func (c *mockMetadataCompiler[T, NS]) CompileAnnotations(obj NS) map[string]stri
 	return c.annotations
 }
 
+func (c *mockMetadataCompiler[T, NS]) CompileDefaultAppAnnotations(_ context.Context, obj NS) (map[string]string, error) {
+	c.processedObjects[obj] = struct{}{}
+	return c.defaultAppAnnotations, c.defaultAppAnnotationsErr
+}
+
 var _ = Describe("K8S NS Reconciler Integration Tests", func() {
 	var (
 		orgGUID string
@@ -64,6 +71,9 @@ var _ = Describe("K8S NS Reconciler Integration Tests", func() {
 			annotations: map[string]string{
 				"org-annotation": "org-annotation-value",
 			},
+			defaultAppAnnotations: map[string]string{
+				"cost-center": "cc-1",
+			},
 		}
 		reconciler = k8sns.NewReconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg](controllersClient, finalizer, metadataCompiler, []string{})
 
@@ -98,6 +108,16 @@ var _ = Describe("K8S NS Reconciler Integration Tests", func() {
 		Expect(nsObj.Status.ObservedGeneration).To(Equal(nsObj.Generation))
 	})
 
+	When("compiling the default app annotations fails", func() {
+		BeforeEach(func() {
+			metadataCompiler.defaultAppAnnotationsErr = errors.New("compile-error")
+		})
+
+		It("returns the error", func() {
+			Expect(reconcileErr).To(MatchError(ContainSubstring("compile-error")))
+		})
+	})
+
 	Describe("the underlying namespace", func() {
 		var underlyingNamespace *corev1.Namespace
 
@@ -143,6 +163,30 @@ var _ = Describe("K8S NS Reconciler Integration Tests", func() {
 				))
 			})
 		})
+
+		It("stamps the default app annotations", func() {
+			Expect(underlyingNamespace.Annotations).To(SatisfyAll(
+				HaveKeyWithValue("cost-center", "cc-1"),
+				HaveKeyWithValue(korifiv1alpha1.DefaultAppAnnotationsKey, `{"cost-center":"cc-1"}`),
+			))
+		})
+
+		When("a default app annotation is no longer present", func() {
+			BeforeEach(func() {
+				ns := createNamespace(nsObj.Name)
+				Expect(k8s.PatchResource(ctx, controllersClient, ns, func() {
+					ns.Annotations = map[string]string{
+						"team":                                  "a-team",
+						korifiv1alpha1.DefaultAppAnnotationsKey: `{"team":"a-team"}`,
+					}
+				})).To(Succeed())
+			})
+
+			It("removes it from the namespace", func() {
+				Expect(underlyingNamespace.Annotations).NotTo(HaveKey("team"))
+				Expect(underlyingNamespace.Annotations).To(HaveKeyWithValue("cost-center", "cc-1"))
+			})
+		})
 	})
 
 	Describe("image registry secrets propagation", func() {
@@ -18,6 +18,7 @@ package orgs
 
 import (
 	"context"
+	"maps"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/k8sns"
@@ -44,6 +45,7 @@ func NewReconciler(
 	log logr.Logger,
 	containerRegistrySecretNames []string,
 	labelCompiler labels.Compiler,
+	defaultAppAnnotations map[string]string,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg] {
 	namespaceController := k8sns.NewReconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg](
 		client,
@@ -53,7 +55,8 @@ func NewReconciler(
 			korifiv1alpha1.CFOrgFinalizerName,
 		),
 		&cfOrgMetadataCompiler{
-			labelCompiler: labelCompiler,
+			labelCompiler:         labelCompiler,
+			defaultAppAnnotations: defaultAppAnnotations,
 		},
 		containerRegistrySecretNames,
 	)
@@ -99,6 +102,7 @@ func (r *Reconciler) enqueueCFOrgRequests(ctx context.Context, object client.Obj
 //+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=create;patch;delete;get;list;watch
+//+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterroles,resourceNames=korifi-controllers-admin;korifi-controllers-space-developer;korifi-api-exec-role,verbs=bind
 
 /* These rbac annotations are not used directly by this controller.
    However, the application's service account must have them to create roles and servicebindings for CF roles,
@@ -131,7 +135,8 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfOrg *korifiv1alpha
 }
 
 type cfOrgMetadataCompiler struct {
-	labelCompiler labels.Compiler
+	labelCompiler         labels.Compiler
+	defaultAppAnnotations map[string]string
 }
 
 func (c *cfOrgMetadataCompiler) CompileLabels(cfOrg *korifiv1alpha1.CFOrg) map[string]string {
@@ -146,3 +151,13 @@ func (c *cfOrgMetadataCompiler) CompileAnnotations(cfOrg *korifiv1alpha1.CFOrg)
 		korifiv1alpha1.OrgNameKey: cfOrg.Spec.DisplayName,
 	}
 }
+
+// CompileDefaultAppAnnotations combines the cluster-wide defaults with the
+// org defaults, the latter taking precedence
+func (c *cfOrgMetadataCompiler) CompileDefaultAppAnnotations(_ context.Context, cfOrg *korifiv1alpha1.CFOrg) (map[string]string, error) {
+	defaults := map[string]string{}
+	maps.Copy(defaults, c.defaultAppAnnotations)
+	maps.Copy(defaults, cfOrg.Spec.DefaultAppAnnotations)
+
+	return defaults, nil
+}
@@ -2,6 +2,7 @@ package orgs_test
 
 import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
@@ -47,6 +48,63 @@ var _ = Describe("CFOrgReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	It("stamps the cluster-wide default app annotations on the org namespace", func() {
+		Eventually(func(g Gomega) {
+			var orgNamespace corev1.Namespace
+			g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfOrg.Name}, &orgNamespace)).To(Succeed())
+
+			g.Expect(orgNamespace.Annotations).To(SatisfyAll(
+				HaveKeyWithValue("cluster-default", "cluster-value"),
+				HaveKeyWithValue(korifiv1alpha1.DefaultAppAnnotationsKey, `{"cluster-default":"cluster-value"}`),
+			))
+		}).Should(Succeed())
+	})
+
+	When("the org has default app annotations", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, adminClient, cfOrg, func() {
+				cfOrg.Spec.DefaultAppAnnotations = map[string]string{
+					"cluster-default": "org-value",
+					"cost-center":     "org-cc",
+				}
+			})).To(Succeed())
+		})
+
+		It("stamps them on the org namespace, overriding the cluster-wide defaults", func() {
+			Eventually(func(g Gomega) {
+				var orgNamespace corev1.Namespace
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfOrg.Name}, &orgNamespace)).To(Succeed())
+
+				g.Expect(orgNamespace.Annotations).To(SatisfyAll(
+					HaveKeyWithValue("cluster-default", "org-value"),
+					HaveKeyWithValue("cost-center", "org-cc"),
+				))
+			}).Should(Succeed())
+		})
+
+		When("an org default app annotation is removed", func() {
+			BeforeEach(func() {
+				Eventually(func(g Gomega) {
+					var orgNamespace corev1.Namespace
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfOrg.Name}, &orgNamespace)).To(Succeed())
+					g.Expect(orgNamespace.Annotations).To(HaveKey("cost-center"))
+				}).Should(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, cfOrg, func() {
+					delete(cfOrg.Spec.DefaultAppAnnotations, "cost-center")
+				})).To(Succeed())
+			})
+
+			It("removes it from the org namespace", func() {
+				Eventually(func(g Gomega) {
+					var orgNamespace corev1.Namespace
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfOrg.Name}, &orgNamespace)).To(Succeed())
+					g.Expect(orgNamespace.Annotations).NotTo(HaveKey("cost-center"))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	It("propagates the image-registry-credentials secrets from root-ns to org namespace", func() {
 		Eventually(func(g Gomega) {
 			g.Expect(adminClient.Get(ctx, types.NamespacedName{Namespace: cfOrg.Name, Name: packageRegistrySecretName}, &corev1.Secret{})).To(Succeed())
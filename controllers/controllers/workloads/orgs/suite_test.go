@@ -81,6 +81,7 @@ var _ = BeforeSuite(func() {
 		ctrl.Log.WithName("controllers").WithName("CFOrg"),
 		[]string{packageRegistrySecretName},
 		labelCompiler,
+		map[string]string{"cluster-default": "cluster-value"},
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -18,12 +18,16 @@ package packages
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools/image"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -44,6 +48,12 @@ type ImageDeleter interface {
 	Delete(ctx context.Context, creds image.Creds, imageRef string, tagsToDelete ...string) error
 }
 
+//counterfeiter:generate -o fake -fake-name ImageCopier . ImageCopier
+
+type ImageCopier interface {
+	Copy(ctx context.Context, creds image.Creds, imageRef string, repoRef string, tags ...string) (string, error)
+}
+
 //counterfeiter:generate -o fake -fake-name PackageCleaner . PackageCleaner
 
 type PackageCleaner interface {
@@ -54,6 +64,7 @@ type Reconciler struct {
 	k8sClient              client.Client
 	scheme                 *runtime.Scheme
 	imageDeleter           ImageDeleter
+	imageCopier            ImageCopier
 	packageCleaner         PackageCleaner
 	packageRepoSecretNames []string
 	log                    logr.Logger
@@ -64,6 +75,7 @@ func NewReconciler(
 	scheme *runtime.Scheme,
 	log logr.Logger,
 	imageDeleter ImageDeleter,
+	imageCopier ImageCopier,
 	packageCleaner PackageCleaner,
 	packageRepoSecretNames []string,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFPackage, *korifiv1alpha1.CFPackage] {
@@ -72,6 +84,7 @@ func NewReconciler(
 		scheme:                 scheme,
 		log:                    log,
 		imageDeleter:           imageDeleter,
+		imageCopier:            imageCopier,
 		packageCleaner:         packageCleaner,
 		packageRepoSecretNames: packageRepoSecretNames,
 	})
@@ -86,6 +99,8 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfpackages/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfpackages/finalizers,verbs=get;update;patch
 
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;create
+
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfPackage *korifiv1alpha1.CFPackage) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -109,6 +124,7 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfPackage *korifiv1a
 		return ctrl.Result{}, err
 	}
 
+	wasInitialized := meta.IsStatusConditionTrue(cfPackage.Status.Conditions, InitializedConditionType)
 	meta.SetStatusCondition(&cfPackage.Status.Conditions, metav1.Condition{
 		Type:               InitializedConditionType,
 		Status:             metav1.ConditionTrue,
@@ -125,6 +141,18 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfPackage *korifiv1a
 		}
 	}()
 
+	if _, isCopy := cfPackage.Annotations[korifiv1alpha1.CFPackageCopySourceGUIDAnnotation]; isCopy {
+		// report the package as initialized before copying, as copying can
+		// take a while and clients wait for the package to be initialized
+		if !wasInitialized {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithReason("Copying").WithRequeue()
+		}
+
+		if err = r.copySource(ctx, cfPackage); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	if cfPackage.Spec.Source.Registry.Image == "" {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("Initialized").WithNoRequeue()
 	}
@@ -132,6 +160,122 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfPackage *korifiv1a
 	return ctrl.Result{}, nil
 }
 
+// copySource copies the source of the package the package is a copy of: the
+// bits image of bits packages and the image pull secrets of docker packages.
+// Copying is done once, a package whose source could not be copied stays
+// failed.
+func (r *Reconciler) copySource(ctx context.Context, cfPackage *korifiv1alpha1.CFPackage) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("copySource")
+
+	readyCondition := meta.FindStatusCondition(cfPackage.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+	if readyCondition != nil {
+		if readyCondition.Reason == korifiv1alpha1.CFPackageCopyFailedReason {
+			return copyFailedError(errors.New(readyCondition.Message))
+		}
+
+		if readyCondition.Status == metav1.ConditionTrue {
+			return nil
+		}
+	}
+
+	sourcePackage := &korifiv1alpha1.CFPackage{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{
+		Namespace: cfPackage.Annotations[korifiv1alpha1.CFPackageCopySourceNamespaceAnnotation],
+		Name:      cfPackage.Annotations[korifiv1alpha1.CFPackageCopySourceGUIDAnnotation],
+	}, sourcePackage)
+	if err != nil {
+		log.Info("failed to get the source package", "reason", err)
+		if k8serrors.IsNotFound(err) {
+			return copyFailedError(errors.New("source package not found"))
+		}
+		return err
+	}
+
+	if sourcePackage.Spec.Source.Registry.Image == "" {
+		return copyFailedError(errors.New("source package has no source"))
+	}
+
+	if cfPackage.Spec.Type == "docker" {
+		return r.copyImagePullSecrets(ctx, sourcePackage, cfPackage)
+	}
+
+	repositoryRef, ok := cfPackage.Annotations[korifiv1alpha1.CFPackageRepositoryAnnotation]
+	if !ok {
+		return copyFailedError(errors.New("package repository not recorded on the package"))
+	}
+
+	copiedImageRef, err := r.imageCopier.Copy(ctx, image.Creds{
+		Namespace:   cfPackage.Namespace,
+		SecretNames: r.packageRepoSecretNames,
+	}, sourcePackage.Spec.Source.Registry.Image, repositoryRef, cfPackage.Name)
+	if err != nil {
+		log.Info("failed to copy the source image", "reason", err)
+		return copyFailedError(err)
+	}
+
+	cfPackage.Spec.Source.Registry.Image = copiedImageRef
+	cfPackage.Spec.Source.Registry.ImagePullSecrets = nil
+	for _, secretName := range r.packageRepoSecretNames {
+		cfPackage.Spec.Source.Registry.ImagePullSecrets = append(cfPackage.Spec.Source.Registry.ImagePullSecrets, corev1.LocalObjectReference{Name: secretName})
+	}
+
+	return nil
+}
+
+// copyImagePullSecrets gives the package its own copy of the image pull
+// secrets of the source package, as the source package may be in another
+// space
+func (r *Reconciler) copyImagePullSecrets(ctx context.Context, sourcePackage, cfPackage *korifiv1alpha1.CFPackage) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("copyImagePullSecrets")
+
+	imagePullSecrets := []corev1.LocalObjectReference{}
+	for i, sourceSecretRef := range sourcePackage.Spec.Source.Registry.ImagePullSecrets {
+		sourceSecret := &corev1.Secret{}
+		err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: sourcePackage.Namespace, Name: sourceSecretRef.Name}, sourceSecret)
+		if err != nil {
+			log.Info("failed to get the source image pull secret", "reason", err)
+			if k8serrors.IsNotFound(err) {
+				return copyFailedError(fmt.Errorf("image pull secret %q of the source package not found", sourceSecretRef.Name))
+			}
+			return err
+		}
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfPackage.Namespace,
+				Name:      fmt.Sprintf("%s-%d", cfPackage.Name, i),
+			},
+			Type: sourceSecret.Type,
+			Data: sourceSecret.Data,
+		}
+
+		err = controllerutil.SetOwnerReference(cfPackage, secret, r.scheme)
+		if err != nil {
+			log.Info("failed to set owner reference on the image pull secret", "reason", err)
+			return err
+		}
+
+		err = r.k8sClient.Create(ctx, secret)
+		if client.IgnoreAlreadyExists(err) != nil {
+			log.Info("failed to create the image pull secret", "reason", err)
+			return err
+		}
+
+		imagePullSecrets = append(imagePullSecrets, corev1.LocalObjectReference{Name: secret.Name})
+	}
+
+	cfPackage.Spec.Source.Registry.ImagePullSecrets = imagePullSecrets
+
+	return nil
+}
+
+func copyFailedError(err error) error {
+	return k8s.NewNotReadyError().
+		WithReason(korifiv1alpha1.CFPackageCopyFailedReason).
+		WithMessage(err.Error()).
+		WithNoRequeue()
+}
+
 func (r *Reconciler) finalize(ctx context.Context, cfPackage *korifiv1alpha1.CFPackage) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("finalize")
 
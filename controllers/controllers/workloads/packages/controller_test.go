@@ -7,6 +7,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/packages"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -203,4 +204,132 @@ var _ = Describe("CFPackageReconciler Integration Tests", func() {
 			})
 		})
 	})
+
+	Describe("copying a package", func() {
+		var (
+			sourcePackage *korifiv1alpha1.CFPackage
+			copyCount     int
+		)
+
+		BeforeEach(func() {
+			copyCount = imageCopier.CopyCallCount()
+			imageCopier.CopyReturns("target-repo@sha256:copied", nil)
+
+			sourcePackage = &korifiv1alpha1.CFPackage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+				},
+				Spec: korifiv1alpha1.CFPackageSpec{
+					Type:   "bits",
+					AppRef: corev1.LocalObjectReference{Name: cfApp.Name},
+					Source: korifiv1alpha1.PackageSource{
+						Registry: korifiv1alpha1.Registry{
+							Image: "source-repo@sha256:source",
+						},
+					},
+				},
+			}
+			Expect(adminClient.Create(context.Background(), sourcePackage)).To(Succeed())
+
+			cfPackage.Spec.Source = korifiv1alpha1.PackageSource{}
+			cfPackage.Annotations = map[string]string{
+				korifiv1alpha1.CFPackageCopySourceGUIDAnnotation:      sourcePackage.Name,
+				korifiv1alpha1.CFPackageCopySourceNamespaceAnnotation: sourcePackage.Namespace,
+				korifiv1alpha1.CFPackageRepositoryAnnotation:          "target-repo",
+			}
+		})
+
+		It("copies the source image into the package repository", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(cfPackage), cfPackage)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfPackage.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				g.Expect(cfPackage.Spec.Source.Registry.Image).To(Equal("target-repo@sha256:copied"))
+				g.Expect(cfPackage.Spec.Source.Registry.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: "package-repo-secret-name"}))
+			}).Should(Succeed())
+
+			Expect(imageCopier.CopyCallCount()).To(Equal(copyCount + 1))
+			_, creds, imageRef, repoRef, tags := imageCopier.CopyArgsForCall(copyCount)
+			Expect(creds.Namespace).To(Equal(testNamespace))
+			Expect(creds.SecretNames).To(ConsistOf("package-repo-secret-name"))
+			Expect(imageRef).To(Equal("source-repo@sha256:source"))
+			Expect(repoRef).To(Equal("target-repo"))
+			Expect(tags).To(ConsistOf(cfPackage.Name))
+		})
+
+		When("copying the image fails", func() {
+			BeforeEach(func() {
+				imageCopier.CopyReturns("", errors.New("copy-error"))
+			})
+
+			It("fails the package", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(cfPackage), cfPackage)).To(Succeed())
+					readyCondition := meta.FindStatusCondition(cfPackage.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+					g.Expect(readyCondition).NotTo(BeNil())
+					g.Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(readyCondition.Reason).To(Equal(korifiv1alpha1.CFPackageCopyFailedReason))
+					g.Expect(readyCondition.Message).To(ContainSubstring("copy-error"))
+				}).Should(Succeed())
+
+				Consistently(imageCopier.CopyCallCount).Should(Equal(copyCount + 1))
+			})
+		})
+
+		When("the source package does not exist", func() {
+			BeforeEach(func() {
+				cfPackage.Annotations[korifiv1alpha1.CFPackageCopySourceGUIDAnnotation] = uuid.NewString()
+			})
+
+			It("fails the package without copying", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(cfPackage), cfPackage)).To(Succeed())
+					readyCondition := meta.FindStatusCondition(cfPackage.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+					g.Expect(readyCondition).NotTo(BeNil())
+					g.Expect(readyCondition.Reason).To(Equal(korifiv1alpha1.CFPackageCopyFailedReason))
+				}).Should(Succeed())
+
+				Expect(imageCopier.CopyCallCount()).To(Equal(copyCount))
+			})
+		})
+
+		When("the package is a docker package", func() {
+			BeforeEach(func() {
+				sourceSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+					},
+					Type: corev1.SecretTypeDockerConfigJson,
+					Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+				}
+				Expect(adminClient.Create(context.Background(), sourceSecret)).To(Succeed())
+
+				Expect(k8s.PatchResource(context.Background(), adminClient, sourcePackage, func() {
+					sourcePackage.Spec.Type = "docker"
+					sourcePackage.Spec.Source.Registry.Image = "some/image"
+					sourcePackage.Spec.Source.Registry.ImagePullSecrets = []corev1.LocalObjectReference{{Name: sourceSecret.Name}}
+				})).To(Succeed())
+
+				cfPackage.Spec.Type = "docker"
+				cfPackage.Spec.Source.Registry.Image = "some/image"
+			})
+
+			It("gives the package a copy of the image pull secrets of the source package", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(cfPackage), cfPackage)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(cfPackage.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+					g.Expect(cfPackage.Spec.Source.Registry.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: cfPackage.Name + "-0"}))
+				}).Should(Succeed())
+
+				secret := &corev1.Secret{}
+				Expect(adminClient.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: cfPackage.Name + "-0"}, secret)).To(Succeed())
+				Expect(secret.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+				Expect(secret.Data).To(HaveKeyWithValue(corev1.DockerConfigJsonKey, []byte("{}")))
+				Expect(secret.OwnerReferences).To(ContainElement(HaveField("UID", cfPackage.UID)))
+
+				Expect(imageCopier.CopyCallCount()).To(Equal(copyCount))
+			})
+		})
+	})
 })
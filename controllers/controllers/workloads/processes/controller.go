@@ -21,6 +21,7 @@ import (
 	"crypto/sha1"
 	"errors"
 	"fmt"
+	"maps"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
@@ -77,6 +78,14 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&korifiv1alpha1.CFRoute{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForRoute),
+		).
+		Watches(
+			&korifiv1alpha1.CFServiceBinding{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForServiceBinding),
+		).
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForNamespace),
 		)
 }
 
@@ -115,6 +124,34 @@ func (r *Reconciler) enqueueCFProcessRequestsForRoute(ctx context.Context, o cli
 	return result
 }
 
+func (r *Reconciler) enqueueCFProcessRequestsForServiceBinding(ctx context.Context, o client.Object) []reconcile.Request {
+	cfServiceBinding, ok := o.(*korifiv1alpha1.CFServiceBinding)
+	if !ok {
+		r.log.Error(errors.New("listing CFProcesses for service binding failed"), "expected", "CFServiceBinding", "got", o)
+		return []reconcile.Request{}
+	}
+
+	return r.cfProcessRequestsForAppGUID(ctx, cfServiceBinding.Namespace, cfServiceBinding.Spec.AppRef.Name)
+}
+
+// enqueueCFProcessRequestsForNamespace enqueues the processes in a space
+// namespace so that they pick up changes to the default app annotations
+func (r *Reconciler) enqueueCFProcessRequestsForNamespace(ctx context.Context, o client.Object) []reconcile.Request {
+	processList := &korifiv1alpha1.CFProcessList{}
+	err := r.k8sClient.List(ctx, processList, client.InNamespace(o.GetName()))
+	if err != nil {
+		r.log.Error(fmt.Errorf("listing CFProcesses for namespace failed: %w", err), "namespace", o.GetName())
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for i := range processList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&processList.Items[i])})
+	}
+
+	return requests
+}
+
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses/finalizers,verbs=update
@@ -122,6 +159,7 @@ func (r *Reconciler) enqueueCFProcessRequestsForRoute(ctx context.Context, o cli
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
@@ -216,12 +254,36 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 
 	appPorts := ports.FromRoutes(cfRoutesForProcess.Items, cfApp.Name, cfProcess.Spec.ProcessType)
 
+	var serviceBindings korifiv1alpha1.CFServiceBindingList
+	err = r.k8sClient.List(ctx, &serviceBindings,
+		client.InNamespace(cfProcess.Namespace),
+		client.MatchingFields{shared.IndexServiceBindingAppGUID: cfApp.Name},
+	)
+	if err != nil {
+		return err
+	}
+
+	appVolumes := persistentVolumes(serviceBindings.Items)
+	if err = cfProcess.ValidateVolumes(appVolumes); err != nil {
+		return k8s.NewNotReadyError().
+			WithReason("InvalidVolumes").
+			WithMessage(err.Error()).
+			WithNoRequeue()
+	}
+
 	envVars, err := r.envBuilder.Build(ctx, cfApp, cfProcess)
 	if err != nil {
 		log.Info("error when trying build the process environment for app", "namespace", cfProcess.Namespace, "name", cfApp.Spec.DisplayName, "reason", err)
 		return err
 	}
 
+	spaceNamespace := new(corev1.Namespace)
+	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: cfProcess.Namespace}, spaceNamespace)
+	if err != nil {
+		log.Info("error when trying to fetch the space namespace", "name", cfProcess.Namespace, "reason", err)
+		return err
+	}
+
 	actualAppWorkload := &korifiv1alpha1.AppWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cfProcess.Namespace,
@@ -230,12 +292,18 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 	}
 
 	var desiredAppWorkload *korifiv1alpha1.AppWorkload
-	desiredAppWorkload, err = r.generateAppWorkload(actualAppWorkload, cfApp, cfProcess, cfBuild, appPorts, envVars, cfAppRev, cfLastStopAppRev)
+	desiredAppWorkload, err = r.generateAppWorkload(actualAppWorkload, cfApp, cfProcess, cfBuild, appPorts, appVolumes, envVars, shared.GetDefaultAppAnnotations(spaceNamespace.Annotations), cfAppRev, cfLastStopAppRev)
 	if err != nil {
 		log.Info("error when initializing AppWorkload", "reason", err)
 		return err
 	}
 
+	// only apps with bindings get the VCAP_SERVICES file, so that the pod
+	// template (and hence the instances) of unbound apps is left untouched
+	if len(serviceBindings.Items) > 0 {
+		desiredAppWorkload.Spec.VCAPServicesSecretName = cfApp.Status.VCAPServicesSecretName
+	}
+
 	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, actualAppWorkload, appWorkloadMutateFunction(actualAppWorkload, desiredAppWorkload))
 	if err != nil {
 		log.Info("error calling CreateOrPatch on AppWorkload", "reason", err)
@@ -286,7 +354,7 @@ func appWorkloadMutateFunction(actualAppWorkload, desiredAppWorkload *korifiv1al
 	}
 }
 
-func (r *Reconciler) generateAppWorkload(actualAppWorkload *korifiv1alpha1.AppWorkload, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, cfBuild *korifiv1alpha1.CFBuild, appPorts []int32, envVars []corev1.EnvVar, cfAppRev, cfLastStopAppRev string) (*korifiv1alpha1.AppWorkload, error) {
+func (r *Reconciler) generateAppWorkload(actualAppWorkload *korifiv1alpha1.AppWorkload, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, cfBuild *korifiv1alpha1.CFBuild, appPorts []int32, appVolumes []korifiv1alpha1.PersistentVolumeMount, envVars []corev1.EnvVar, defaultAppAnnotations map[string]string, cfAppRev, cfLastStopAppRev string) (*korifiv1alpha1.AppWorkload, error) {
 	var desiredAppWorkload korifiv1alpha1.AppWorkload
 	actualAppWorkload.DeepCopyInto(&desiredAppWorkload)
 
@@ -297,18 +365,23 @@ func (r *Reconciler) generateAppWorkload(actualAppWorkload *korifiv1alpha1.AppWo
 	desiredAppWorkload.Labels[korifiv1alpha1.CFProcessTypeLabelKey] = cfProcess.Spec.ProcessType
 
 	desiredAppWorkload.Annotations = make(map[string]string)
+	maps.Copy(desiredAppWorkload.Annotations, defaultAppAnnotations)
 	desiredAppWorkload.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey] = cfLastStopAppRev
 
+	sidecars := cfProcess.ProcessSidecars()
+	// the sidecars share the process memory with the app
+	appMemoryMB := cfProcess.Spec.MemoryMB - cfProcess.SidecarsMemoryMB()
+
 	desiredAppWorkload.Spec.GUID = cfProcess.Name
 	desiredAppWorkload.Spec.Version = cfAppRev
 	desiredAppWorkload.Spec.Resources.Requests = corev1.ResourceList{
-		corev1.ResourceCPU:              calculateCPURequest(cfProcess.Spec.MemoryMB),
+		corev1.ResourceCPU:              calculateCPURequest(appMemoryMB),
 		corev1.ResourceEphemeralStorage: mebibyteQuantity(cfProcess.Spec.DiskQuotaMB),
-		corev1.ResourceMemory:           mebibyteQuantity(cfProcess.Spec.MemoryMB),
+		corev1.ResourceMemory:           mebibyteQuantity(appMemoryMB),
 	}
 	desiredAppWorkload.Spec.Resources.Limits = corev1.ResourceList{
 		corev1.ResourceEphemeralStorage: mebibyteQuantity(cfProcess.Spec.DiskQuotaMB),
-		corev1.ResourceMemory:           mebibyteQuantity(cfProcess.Spec.MemoryMB),
+		corev1.ResourceMemory:           mebibyteQuantity(appMemoryMB),
 	}
 	desiredAppWorkload.Spec.ProcessType = cfProcess.Spec.ProcessType
 	desiredAppWorkload.Spec.Command = commandForProcess(cfProcess, cfApp)
@@ -317,6 +390,17 @@ func (r *Reconciler) generateAppWorkload(actualAppWorkload *korifiv1alpha1.AppWo
 	desiredAppWorkload.Spec.ImagePullSecrets = cfBuild.Status.Droplet.Registry.ImagePullSecrets
 
 	desiredAppWorkload.Spec.Ports = appPorts
+	desiredAppWorkload.Spec.EphemeralVolumes = cfProcess.Spec.EphemeralVolumes
+	desiredAppWorkload.Spec.PersistentVolumes = appVolumes
+	desiredAppWorkload.Spec.Sidecars = nil
+	for _, sidecar := range sidecars {
+		desiredAppWorkload.Spec.Sidecars = append(desiredAppWorkload.Spec.Sidecars, korifiv1alpha1.AppWorkloadSidecar{
+			Name:     sidecar.Name,
+			Command:  wrapCommand(sidecar.Command, cfApp),
+			MemoryMB: sidecar.MemoryMB,
+		})
+	}
+	desiredAppWorkload.Spec.ConnectionDrainTimeoutSeconds = cfProcess.Spec.ConnectionDrainTimeoutSeconds
 	if cfProcess.Spec.DesiredInstances != nil {
 		desiredAppWorkload.Spec.Instances = int32(*cfProcess.Spec.DesiredInstances)
 	}
@@ -325,6 +409,7 @@ func (r *Reconciler) generateAppWorkload(actualAppWorkload *korifiv1alpha1.AppWo
 
 	desiredAppWorkload.Spec.StartupProbe = startupProbe(cfProcess, appPorts)
 	desiredAppWorkload.Spec.LivenessProbe = livenessProbe(cfProcess, appPorts)
+	desiredAppWorkload.Spec.ReadinessProbe = readinessProbe(cfProcess, appPorts)
 	desiredAppWorkload.Spec.RunnerName = r.controllerConfig.RunnerName
 
 	err := controllerutil.SetControllerReference(cfProcess, &desiredAppWorkload, r.scheme)
@@ -372,12 +457,19 @@ func (r *Reconciler) fetchAppWorkloadsForProcess(ctx context.Context, cfProcess
 	return appWorkloadsForProcess, err
 }
 
+// commandForProcess wraps the process command so that it is evaluated by a
+// shell and references to environment variables such as $PORT are expanded at
+// runtime: the buildpack launcher does that itself, other images get /bin/sh.
 func commandForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CFApp) []string {
 	cmd := process.Spec.Command
 	if cmd == "" {
 		cmd = process.Spec.DetectedCommand
 	}
 
+	return wrapCommand(cmd, app)
+}
+
+func wrapCommand(cmd string, app *korifiv1alpha1.CFApp) []string {
 	if cmd == "" {
 		return []string{}
 	}
@@ -442,6 +534,23 @@ func livenessProbe(cfProcess *korifiv1alpha1.CFProcess, ports []int32) *corev1.P
 	}
 }
 
+func readinessProbe(cfProcess *korifiv1alpha1.CFProcess, ports []int32) *corev1.Probe {
+	if cfProcess.Spec.HealthCheck.Type == korifiv1alpha1.ProcessHealthCheckType {
+		return nil
+	}
+
+	if len(ports) == 0 {
+		return nil
+	}
+
+	return &corev1.Probe{
+		ProbeHandler:     makeProbeHandler(cfProcess, ports[0]),
+		TimeoutSeconds:   int32(cfProcess.Spec.HealthCheck.Data.InvocationTimeoutSeconds),
+		PeriodSeconds:    10,
+		FailureThreshold: 1,
+	}
+}
+
 func mebibyteQuantity(miB int64) resource.Quantity {
 	return *resource.NewQuantity(miB*1024*1024, resource.BinarySI)
 }
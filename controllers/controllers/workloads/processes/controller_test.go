@@ -14,6 +14,7 @@ import (
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -238,6 +239,7 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 				))
 
 				g.Expect(appWorkload.Spec.RunnerName).To(Equal("cf-process-controller-test"))
+				g.Expect(appWorkload.Spec.VCAPServicesSecretName).To(BeEmpty())
 			})
 		})
 
@@ -269,6 +271,13 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 					g.Expect(appWorkload.Spec.LivenessProbe.PeriodSeconds).To(BeEquivalentTo(30))
 					g.Expect(appWorkload.Spec.LivenessProbe.TimeoutSeconds).To(BeEquivalentTo(3))
 					g.Expect(appWorkload.Spec.LivenessProbe.FailureThreshold).To(BeEquivalentTo(1))
+
+					g.Expect(appWorkload.Spec.ReadinessProbe).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.ReadinessProbe.ProbeHandler).To(Equal(appWorkload.Spec.StartupProbe.ProbeHandler))
+					g.Expect(appWorkload.Spec.ReadinessProbe.InitialDelaySeconds).To(BeZero())
+					g.Expect(appWorkload.Spec.ReadinessProbe.PeriodSeconds).To(BeEquivalentTo(10))
+					g.Expect(appWorkload.Spec.ReadinessProbe.TimeoutSeconds).To(BeEquivalentTo(3))
+					g.Expect(appWorkload.Spec.ReadinessProbe.FailureThreshold).To(BeEquivalentTo(1))
 				})
 			})
 		})
@@ -299,6 +308,13 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 					g.Expect(appWorkload.Spec.LivenessProbe.PeriodSeconds).To(BeEquivalentTo(30))
 					g.Expect(appWorkload.Spec.LivenessProbe.TimeoutSeconds).To(BeEquivalentTo(3))
 					g.Expect(appWorkload.Spec.LivenessProbe.FailureThreshold).To(BeEquivalentTo(1))
+
+					g.Expect(appWorkload.Spec.ReadinessProbe).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.ReadinessProbe.ProbeHandler).To(Equal(appWorkload.Spec.StartupProbe.ProbeHandler))
+					g.Expect(appWorkload.Spec.ReadinessProbe.InitialDelaySeconds).To(BeZero())
+					g.Expect(appWorkload.Spec.ReadinessProbe.PeriodSeconds).To(BeEquivalentTo(10))
+					g.Expect(appWorkload.Spec.ReadinessProbe.TimeoutSeconds).To(BeEquivalentTo(3))
+					g.Expect(appWorkload.Spec.ReadinessProbe.FailureThreshold).To(BeEquivalentTo(1))
 				})
 			})
 		})
@@ -308,10 +324,241 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 				cfProcess.Spec.HealthCheck = korifiv1alpha1.HealthCheck{Type: "process"}
 			})
 
-			It("does not set liveness and startup probes on the AppWorkload", func() {
+			It("does not set liveness, readiness and startup probes on the AppWorkload", func() {
 				eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
 					g.Expect(appWorkload.Spec.StartupProbe).To(BeNil())
 					g.Expect(appWorkload.Spec.LivenessProbe).To(BeNil())
+					g.Expect(appWorkload.Spec.ReadinessProbe).To(BeNil())
+				})
+			})
+		})
+
+		When("the CFProcess declares ephemeral volumes", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.EphemeralVolumes = []korifiv1alpha1.EphemeralVolume{{
+					Name:        "scratch",
+					MountPath:   "/home/vcap/scratch",
+					SizeLimitMB: 64,
+				}}
+			})
+
+			It("sets the volumes on the AppWorkload", func() {
+				eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.EphemeralVolumes).To(ConsistOf(korifiv1alpha1.EphemeralVolume{
+						Name:        "scratch",
+						MountPath:   "/home/vcap/scratch",
+						SizeLimitMB: 64,
+					}))
+				})
+			})
+
+			When("a volume mount path collides with a system mount", func() {
+				BeforeEach(func() {
+					cfProcess.Spec.EphemeralVolumes[0].MountPath = "/bindings/scratch"
+				})
+
+				It("sets the ready condition to false", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+						g.Expect(cfProcess.Status.Conditions).To(ContainElement(SatisfyAll(
+							matchers.HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+							matchers.HasStatus(Equal(metav1.ConditionFalse)),
+							matchers.HasReason(Equal("InvalidVolumes")),
+							matchers.HasMessage(ContainSubstring(`collides with system path "/bindings"`)),
+						)))
+					}).Should(Succeed())
+				})
+
+				It("does not create an AppWorkload", func() {
+					Consistently(func(g Gomega) {
+						var appWorkloads korifiv1alpha1.AppWorkloadList
+						g.Expect(adminClient.List(ctx, &appWorkloads, client.InNamespace(testNamespace), client.MatchingLabels{
+							korifiv1alpha1.CFProcessGUIDLabelKey: cfProcess.Name,
+						})).To(Succeed())
+						g.Expect(appWorkloads.Items).To(BeEmpty())
+					}).Should(Succeed())
+				})
+			})
+
+			When("a volume mount path is not absolute", func() {
+				BeforeEach(func() {
+					cfProcess.Spec.EphemeralVolumes[0].MountPath = "scratch"
+				})
+
+				It("sets the ready condition to false", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+						g.Expect(cfProcess.Status.Conditions).To(ContainElement(SatisfyAll(
+							matchers.HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+							matchers.HasStatus(Equal(metav1.ConditionFalse)),
+							matchers.HasReason(Equal("InvalidVolumes")),
+						)))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the CFProcess declares sidecars", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.Sidecars = []korifiv1alpha1.Sidecar{
+					{
+						Name:         "log-forwarder",
+						Command:      "forward-logs",
+						ProcessTypes: []string{korifiv1alpha1.ProcessTypeWeb},
+						MemoryMB:     256,
+					},
+					{
+						Name:         "worker-helper",
+						Command:      "help-workers",
+						ProcessTypes: []string{"worker"},
+						MemoryMB:     128,
+					},
+				}
+			})
+
+			It("sets the sidecars running alongside the process type on the AppWorkload", func() {
+				eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Sidecars).To(ConsistOf(korifiv1alpha1.AppWorkloadSidecar{
+						Name:     "log-forwarder",
+						Command:  []string{"/cnb/lifecycle/launcher", "forward-logs"},
+						MemoryMB: 256,
+					}))
+				})
+			})
+
+			It("takes the sidecars memory out of the app memory", func() {
+				eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Resources.Limits.Memory()).To(matchers.RepresentResourceQuantity(768, "Mi"))
+					g.Expect(appWorkload.Spec.Resources.Requests.Memory()).To(matchers.RepresentResourceQuantity(768, "Mi"))
+				})
+			})
+		})
+
+		When("the app is bound to a volume service", func() {
+			var serviceBinding *korifiv1alpha1.CFServiceBinding
+
+			BeforeEach(func() {
+				serviceBinding = &korifiv1alpha1.CFServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFServiceBindingSpec{
+						Service: corev1.ObjectReference{
+							Kind:       "CFServiceInstance",
+							APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+							Name:       "volume-instance-guid",
+						},
+						AppRef: corev1.LocalObjectReference{Name: cfApp.Name},
+						Type:   korifiv1alpha1.CFServiceBindingTypeApp,
+					},
+				}
+				Expect(adminClient.Create(ctx, serviceBinding)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, serviceBinding, func() {
+					serviceBinding.Status.Volume = &korifiv1alpha1.BindingVolume{
+						ClaimName: "volume-instance-guid",
+						MountPath: "/var/vcap/data/shared",
+					}
+				})).To(Succeed())
+			})
+
+			It("mounts the volume claim into the AppWorkload", func() {
+				eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.PersistentVolumes).To(ConsistOf(korifiv1alpha1.PersistentVolumeMount{
+						Name:      "service-" + serviceBinding.Name,
+						ClaimName: "volume-instance-guid",
+						MountPath: "/var/vcap/data/shared",
+					}))
+				})
+			})
+
+			It("references the VCAP_SERVICES secret in the AppWorkload", func() {
+				eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.VCAPServicesSecretName).To(Equal(cfApp.Status.VCAPServicesSecretName))
+				})
+			})
+
+			When("the binding is deleted", func() {
+				JustBeforeEach(func() {
+					eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.PersistentVolumes).NotTo(BeEmpty())
+					})
+					Expect(adminClient.Delete(ctx, serviceBinding)).To(Succeed())
+				})
+
+				It("unmounts the volume claim from the AppWorkload", func() {
+					eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.PersistentVolumes).To(BeEmpty())
+					})
+				})
+
+				It("stops referencing the VCAP_SERVICES secret", func() {
+					eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.VCAPServicesSecretName).To(BeEmpty())
+					})
+				})
+			})
+
+			When("the volume mount path collides with an ephemeral volume", func() {
+				BeforeEach(func() {
+					cfProcess.Spec.EphemeralVolumes = []korifiv1alpha1.EphemeralVolume{{
+						Name:        "scratch",
+						MountPath:   "/var/vcap/data/shared",
+						SizeLimitMB: 64,
+					}}
+				})
+
+				It("sets the ready condition to false", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+						g.Expect(cfProcess.Status.Conditions).To(ContainElement(SatisfyAll(
+							matchers.HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+							matchers.HasStatus(Equal(metav1.ConditionFalse)),
+							matchers.HasReason(Equal("InvalidVolumes")),
+							matchers.HasMessage(ContainSubstring("is used more than once")),
+						)))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the space namespace records default app annotations", func() {
+			var spaceNamespace *corev1.Namespace
+
+			BeforeEach(func() {
+				spaceNamespace = &corev1.Namespace{}
+				Expect(adminClient.Get(ctx, types.NamespacedName{Name: testNamespace}, spaceNamespace)).To(Succeed())
+				Expect(k8s.PatchResource(ctx, adminClient, spaceNamespace, func() {
+					spaceNamespace.Annotations = map[string]string{
+						korifiv1alpha1.DefaultAppAnnotationsKey: `{"cost-center":"cc-1"}`,
+					}
+				})).To(Succeed())
+			})
+
+			It("stamps them on the AppWorkload", func() {
+				eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Annotations).To(SatisfyAll(
+						HaveKeyWithValue("cost-center", "cc-1"),
+						HaveKeyWithValue(korifiv1alpha1.CFAppLastStopRevisionKey, cfApp.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey]),
+					))
+				})
+			})
+
+			When("the default app annotations change", func() {
+				JustBeforeEach(func() {
+					eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Annotations).To(HaveKey("cost-center"))
+					})
+
+					Expect(k8s.PatchResource(ctx, adminClient, spaceNamespace, func() {
+						spaceNamespace.Annotations[korifiv1alpha1.DefaultAppAnnotationsKey] = `{"cost-center":"cc-2"}`
+					})).To(Succeed())
+				})
+
+				It("updates the AppWorkload", func() {
+					eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Annotations).To(HaveKeyWithValue("cost-center", "cc-2"))
+					})
 				})
 			})
 		})
@@ -345,6 +592,22 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the app is a docker app and the command references $PORT", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.Lifecycle = korifiv1alpha1.Lifecycle{Type: "docker"}
+				})).To(Succeed())
+				cfProcess.Spec.Command = "my-server --port $PORT"
+			})
+
+			It("runs the command under a shell with PORT set so that it gets expanded", func() {
+				eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Command).To(Equal([]string{"/bin/sh", "-c", "my-server --port $PORT"}))
+					g.Expect(appWorkload.Spec.Env).To(ContainElement(corev1.EnvVar{Name: "PORT", Value: "8080"}))
+				})
+			})
+		})
+
 		When("there are no route destinations for the process app", func() {
 			BeforeEach(func() {
 				Expect(k8s.Patch(ctx, adminClient, cfRoute, func() {
@@ -352,10 +615,11 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 				})).To(Succeed())
 			})
 
-			It("does not create startup and liveness probes on the app workload", func() {
+			It("does not create startup, liveness and readiness probes on the app workload", func() {
 				eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
 					g.Expect(appWorkload.Spec.StartupProbe).To(BeNil())
 					g.Expect(appWorkload.Spec.LivenessProbe).To(BeNil())
+					g.Expect(appWorkload.Spec.ReadinessProbe).To(BeNil())
 				})
 			})
 
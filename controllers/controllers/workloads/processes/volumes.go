@@ -0,0 +1,31 @@
+package processes
+
+import (
+	"slices"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+)
+
+// persistentVolumes returns the claims of the volume service bindings of the
+// app, sorted by binding to keep the app workload spec stable
+func persistentVolumes(serviceBindings []korifiv1alpha1.CFServiceBinding) []korifiv1alpha1.PersistentVolumeMount {
+	volumes := []korifiv1alpha1.PersistentVolumeMount{}
+	for _, binding := range serviceBindings {
+		if !binding.DeletionTimestamp.IsZero() || binding.Status.Volume == nil {
+			continue
+		}
+
+		volumes = append(volumes, korifiv1alpha1.PersistentVolumeMount{
+			Name:      korifiv1alpha1.ServiceVolumeNamePrefix + binding.Name,
+			ClaimName: binding.Status.Volume.ClaimName,
+			MountPath: binding.Status.Volume.MountPath,
+		})
+	}
+
+	slices.SortFunc(volumes, func(a, b korifiv1alpha1.PersistentVolumeMount) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return volumes
+}
@@ -18,6 +18,8 @@ package spaces
 
 import (
 	"context"
+	"fmt"
+	"maps"
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -31,6 +33,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8s_labels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -54,6 +57,7 @@ func NewReconciler(
 	rootNamespace string,
 	appDeletionTimeout int32,
 	labelCompiler labels.Compiler,
+	defaultAppAnnotations map[string]string,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFSpace, *korifiv1alpha1.CFSpace] {
 	namespaceController := k8sns.NewReconciler[korifiv1alpha1.CFSpace, *korifiv1alpha1.CFSpace](
 		client,
@@ -63,7 +67,9 @@ func NewReconciler(
 			korifiv1alpha1.CFSpaceFinalizerName,
 		),
 		&cfSpaceMetadataCompiler{
-			labelCompiler: labelCompiler,
+			client:                client,
+			labelCompiler:         labelCompiler,
+			defaultAppAnnotations: defaultAppAnnotations,
 		},
 		containerRegistrySecretNames,
 	)
@@ -91,6 +97,10 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&corev1.ServiceAccount{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequestsForServiceAccount),
+		).
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequestsForOrgNamespace),
 		)
 }
 
@@ -109,6 +119,24 @@ func (r *Reconciler) enqueueCFSpaceRequests(ctx context.Context, object client.O
 	return requests
 }
 
+// enqueueCFSpaceRequestsForOrgNamespace enqueues the spaces of an org when its
+// namespace changes, so that they pick up changes to the org default app
+// annotations
+func (r *Reconciler) enqueueCFSpaceRequestsForOrgNamespace(ctx context.Context, object client.Object) []reconcile.Request {
+	cfSpaceList := &korifiv1alpha1.CFSpaceList{}
+	err := r.client.List(ctx, cfSpaceList, client.InNamespace(object.GetName()))
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(cfSpaceList.Items))
+	for i := range cfSpaceList.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cfSpaceList.Items[i])}
+	}
+
+	return requests
+}
+
 func (r *Reconciler) enqueueCFSpaceRequestsForServiceAccount(ctx context.Context, object client.Object) []reconcile.Request {
 	if object.GetNamespace() != r.rootNamespace {
 		return nil
@@ -279,7 +307,9 @@ func keepImagePullSecrets(serviceAccountName string, secretRefs []corev1.LocalOb
 }
 
 type cfSpaceMetadataCompiler struct {
-	labelCompiler labels.Compiler
+	client                client.Client
+	labelCompiler         labels.Compiler
+	defaultAppAnnotations map[string]string
 }
 
 func (c *cfSpaceMetadataCompiler) CompileLabels(cfSpace *korifiv1alpha1.CFSpace) map[string]string {
@@ -295,3 +325,21 @@ func (c *cfSpaceMetadataCompiler) CompileAnnotations(cfSpace *korifiv1alpha1.CFS
 		korifiv1alpha1.SpaceNameKey: cfSpace.Spec.DisplayName,
 	}
 }
+
+// CompileDefaultAppAnnotations combines the cluster-wide defaults, the org
+// defaults recorded on the org namespace and the space defaults, the more
+// specific defaults taking precedence
+func (c *cfSpaceMetadataCompiler) CompileDefaultAppAnnotations(ctx context.Context, cfSpace *korifiv1alpha1.CFSpace) (map[string]string, error) {
+	orgNamespace := &corev1.Namespace{}
+	err := c.client.Get(ctx, types.NamespacedName{Name: cfSpace.Namespace}, orgNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get org namespace %q: %w", cfSpace.Namespace, err)
+	}
+
+	defaults := map[string]string{}
+	maps.Copy(defaults, c.defaultAppAnnotations)
+	maps.Copy(defaults, shared.GetDefaultAppAnnotations(orgNamespace.Annotations))
+	maps.Copy(defaults, cfSpace.Spec.DefaultAppAnnotations)
+
+	return defaults, nil
+}
@@ -52,6 +52,46 @@ var _ = Describe("CFSpaceReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	When("there are org and space default app annotations", func() {
+		BeforeEach(func() {
+			orgNamespace := &corev1.Namespace{}
+			Expect(adminClient.Get(ctx, types.NamespacedName{Name: testNamespace}, orgNamespace)).To(Succeed())
+			Expect(k8s.PatchResource(ctx, adminClient, orgNamespace, func() {
+				orgNamespace.Annotations = map[string]string{
+					korifiv1alpha1.DefaultAppAnnotationsKey: `{"cluster-default":"org-value","cost-center":"org-cc","team":"org-team"}`,
+				}
+			})).To(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfSpace, func() {
+				cfSpace.Spec.DefaultAppAnnotations = map[string]string{"cost-center": "space-cc"}
+			})).To(Succeed())
+		})
+
+		It("stamps them on the space namespace, the more specific defaults taking precedence", func() {
+			Eventually(func(g Gomega) {
+				var ns corev1.Namespace
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfSpace.Name}, &ns)).To(Succeed())
+
+				g.Expect(ns.Annotations).To(SatisfyAll(
+					HaveKeyWithValue("cluster-default", "org-value"),
+					HaveKeyWithValue("team", "org-team"),
+					HaveKeyWithValue("cost-center", "space-cc"),
+				))
+				g.Expect(ns.Annotations[korifiv1alpha1.DefaultAppAnnotationsKey]).To(MatchJSON(
+					`{"cluster-default":"org-value","cost-center":"space-cc","team":"org-team"}`,
+				))
+			}).Should(Succeed())
+		})
+	})
+
+	It("stamps the cluster-wide default app annotations on the space namespace", func() {
+		Eventually(func(g Gomega) {
+			var ns corev1.Namespace
+			g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfSpace.Name}, &ns)).To(Succeed())
+			g.Expect(ns.Annotations).To(HaveKeyWithValue("cluster-default", "cluster-value"))
+		}).Should(Succeed())
+	})
+
 	It("propagates the image-registry-credentials secrets to CFSpace", func() {
 		Eventually(func(g Gomega) {
 			g.Expect(adminClient.Get(ctx, types.NamespacedName{Namespace: cfSpace.Name, Name: packageRegistrySecretName}, &corev1.Secret{})).To(Succeed())
@@ -91,6 +91,7 @@ var _ = BeforeSuite(func() {
 		cfRootNamespace,
 		int32(2),
 		labelCompiler,
+		map[string]string{"cluster-default": "cluster-value"},
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -261,7 +261,7 @@ func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *kori
 
 		taskWorkload.Labels[korifiv1alpha1.CFTaskGUIDLabelKey] = cfTask.Name
 
-		taskWorkload.Spec.Command = []string{LifecycleLauncherPath, cfTask.Spec.Command}
+		taskWorkload.Spec.Command = taskCommand(cfTask, cfDroplet)
 		taskWorkload.Spec.Image = cfDroplet.Status.Droplet.Registry.Image
 		taskWorkload.Spec.ImagePullSecrets = cfDroplet.Status.Droplet.Registry.ImagePullSecrets
 
@@ -299,6 +299,18 @@ func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *kori
 	return taskWorkload, nil
 }
 
+// taskCommand runs the task command through a shell so that references to
+// environment variables such as $PORT are expanded at runtime. The buildpack
+// launcher already does that for buildpack droplets. Other droplets, such as
+// docker images, do not have it so the command is passed to /bin/sh instead.
+func taskCommand(cfTask *korifiv1alpha1.CFTask, cfDroplet *korifiv1alpha1.CFBuild) []string {
+	if cfDroplet.Spec.Lifecycle.Type == korifiv1alpha1.BuildpackLifecycle {
+		return []string{LifecycleLauncherPath, cfTask.Spec.Command}
+	}
+
+	return []string{"/bin/sh", "-c", cfTask.Spec.Command}
+}
+
 func calculateDefaultCPURequestMillicores(memoryMiB int64) int64 {
 	const (
 		cpuRequestRatio         int64 = 1024
@@ -277,6 +277,38 @@ var _ = Describe("CFTaskReconciler Integration Tests", func() {
 			))
 		})
 
+		When("the droplet is not a buildpack droplet", func() {
+			var dockerTask *korifiv1alpha1.CFTask
+
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfDroplet, func() {
+					cfDroplet.Spec.Lifecycle = korifiv1alpha1.Lifecycle{Type: "docker"}
+				})).To(Succeed())
+
+				dockerTask = &korifiv1alpha1.CFTask{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testNamespace,
+						Name:      uuid.NewString(),
+					},
+					Spec: korifiv1alpha1.CFTaskSpec{
+						Command: "echo $PORT",
+						AppRef: corev1.LocalObjectReference{
+							Name: cfApp.Name,
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, dockerTask)).To(Succeed())
+			})
+
+			It("runs the task command under a shell so that env vars are expanded", func() {
+				Eventually(func(g Gomega) {
+					var taskWorkload korifiv1alpha1.TaskWorkload
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(dockerTask), &taskWorkload)).To(Succeed())
+					g.Expect(taskWorkload.Spec.Command).To(Equal([]string{"/bin/sh", "-c", "echo $PORT"}))
+				}).Should(Succeed())
+			})
+		})
+
 		It("records a TaskWorkloadCreated event", func() {
 			Expect(eventRecorder.EventfCallCount()).To(Equal(eventCallCount+1), "eventRecorder.Eventf call count mismatch")
 			eventTaskObj, eventType, eventReason, eventMessage, eventMessageArgs := eventRecorder.EventfArgsForCall(eventCallCount)
@@ -32,9 +32,11 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings"
 	managed_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/managed"
 	upsi_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/upsi"
+	volume_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/volume"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/brokers"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/instances/managed"
 	upsi_instances "code.cloudfoundry.org/korifi/controllers/controllers/services/instances/upsi"
+	volume_instances "code.cloudfoundry.org/korifi/controllers/controllers/services/instances/volume"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/apps"
@@ -60,6 +62,7 @@ import (
 	appswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/apps"
 	orgswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/orgs"
 	packageswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/packages"
+	processeswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/processes"
 	spaceswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/spaces"
 	taskswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/tasks"
 	"code.cloudfoundry.org/korifi/tools"
@@ -180,13 +183,23 @@ func main() {
 		}
 
 		buildCleaner := cleanup.NewBuildCleaner(mgr.GetClient(), controllerConfig.MaxRetainedBuildsPerApp)
+
+		var stagingTimeout time.Duration
+		stagingTimeout, err = controllerConfig.ParseStagingTimeout()
+		if err != nil {
+			setupLog.Error(err, "failed to parse staging timeout", "controller", "CFBuildpackBuild", "stagingTimeout", controllerConfig.StagingTimeout)
+			os.Exit(1)
+		}
+
 		if err = buildpack.NewReconciler(
 			mgr.GetClient(),
+			mgr.GetAPIReader(),
 			buildCleaner,
 			mgr.GetScheme(),
 			controllersLog,
 			controllerConfig,
 			env.NewAppEnvBuilder(mgr.GetClient()),
+			stagingTimeout,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFBuildpackBuild")
 			os.Exit(1)
@@ -208,6 +221,7 @@ func main() {
 			mgr.GetScheme(),
 			controllersLog,
 			imageClient,
+			imageClient,
 			cleanup.NewPackageCleaner(mgr.GetClient(), controllerConfig.MaxRetainedPackagesPerApp),
 			controllerConfig.ContainerRegistrySecretNames,
 		).SetupWithManager(mgr); err != nil {
@@ -235,9 +249,19 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (volume_instances.NewReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			controllersLog,
+		)).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "VolumeCFServiceInstance")
+			os.Exit(1)
+		}
+
 		if err = (bindings.NewReconciler(
 			mgr.GetClient(),
 			mgr.GetScheme(),
+			mgr.GetEventRecorderFor("cfservicebinding-controller"),
 			controllersLog,
 			upsi_bindings.NewReconciler(mgr.GetClient(), mgr.GetScheme()),
 			managed_bindings.NewReconciler(
@@ -246,6 +270,7 @@ func main() {
 				controllerConfig.CFRootNamespace,
 				mgr.GetScheme(),
 			),
+			volume_bindings.NewReconciler(mgr.GetClient(), mgr.GetScheme()),
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFServiceBinding")
 			os.Exit(1)
@@ -263,6 +288,7 @@ func main() {
 			controllersLog,
 			controllerConfig.ContainerRegistrySecretNames,
 			labelCompiler,
+			controllerConfig.DefaultAppAnnotations,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFOrg")
 			os.Exit(1)
@@ -275,6 +301,7 @@ func main() {
 			controllerConfig.CFRootNamespace,
 			*controllerConfig.SpaceFinalizerAppDeletionTimeout,
 			labelCompiler,
+			controllerConfig.DefaultAppAnnotations,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFSpace")
 			os.Exit(1)
@@ -374,7 +401,9 @@ func main() {
 		if err = korifiv1alpha1.NewCFProcessDefaulter(
 			controllerConfig.CFProcessDefaults.MemoryMB,
 			controllerConfig.CFProcessDefaults.DiskQuotaMB,
+			*controllerConfig.CFProcessDefaults.Instances,
 			*controllerConfig.CFProcessDefaults.Timeout,
+			*controllerConfig.CFProcessDefaults.ConnectionDrainTimeoutSeconds,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFProcess")
 			os.Exit(1)
@@ -388,8 +417,22 @@ func main() {
 			os.Exit(1)
 		}
 
+		quotaValidator := validation.NewQuotaValidator(
+			uncachedClient,
+			controllerConfig.CFRootNamespace,
+			korifiv1alpha1.NewCFProcessDefaulter(
+				controllerConfig.CFProcessDefaults.MemoryMB,
+				controllerConfig.CFProcessDefaults.DiskQuotaMB,
+				*controllerConfig.CFProcessDefaults.Instances,
+				*controllerConfig.CFProcessDefaults.Timeout,
+				*controllerConfig.CFProcessDefaults.ConnectionDrainTimeoutSeconds,
+			),
+		)
+
 		if err = appswebhook.NewValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, appswebhook.AppEntityType)),
+			validation.NewPlacementValidator(uncachedClient, controllerConfig.CFRootNamespace),
+			quotaValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFApp")
 			os.Exit(1)
@@ -471,6 +514,11 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = processeswebhook.NewValidator(quotaValidator).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFProcess")
+			os.Exit(1)
+		}
+
 		relationships.NewSpaceGUIDWebhook().SetupWebhookWithManager(mgr)
 
 		if err = mgr.AddReadyzCheck("readyz", mgr.GetWebhookServer().StartedChecker()); err != nil {
@@ -9,6 +9,17 @@ import (
 )
 
 type NamespaceValidator struct {
+	ValidateAppCreateStub        func(v1alpha1.CFApp) error
+	validateAppCreateMutex       sync.RWMutex
+	validateAppCreateArgsForCall []struct {
+		arg1 v1alpha1.CFApp
+	}
+	validateAppCreateReturns struct {
+		result1 error
+	}
+	validateAppCreateReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ValidateOrgCreateStub        func(v1alpha1.CFOrg) error
 	validateOrgCreateMutex       sync.RWMutex
 	validateOrgCreateArgsForCall []struct {
@@ -35,6 +46,67 @@ type NamespaceValidator struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *NamespaceValidator) ValidateAppCreate(arg1 v1alpha1.CFApp) error {
+	fake.validateAppCreateMutex.Lock()
+	ret, specificReturn := fake.validateAppCreateReturnsOnCall[len(fake.validateAppCreateArgsForCall)]
+	fake.validateAppCreateArgsForCall = append(fake.validateAppCreateArgsForCall, struct {
+		arg1 v1alpha1.CFApp
+	}{arg1})
+	stub := fake.ValidateAppCreateStub
+	fakeReturns := fake.validateAppCreateReturns
+	fake.recordInvocation("ValidateAppCreate", []interface{}{arg1})
+	fake.validateAppCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *NamespaceValidator) ValidateAppCreateCallCount() int {
+	fake.validateAppCreateMutex.RLock()
+	defer fake.validateAppCreateMutex.RUnlock()
+	return len(fake.validateAppCreateArgsForCall)
+}
+
+func (fake *NamespaceValidator) ValidateAppCreateCalls(stub func(v1alpha1.CFApp) error) {
+	fake.validateAppCreateMutex.Lock()
+	defer fake.validateAppCreateMutex.Unlock()
+	fake.ValidateAppCreateStub = stub
+}
+
+func (fake *NamespaceValidator) ValidateAppCreateArgsForCall(i int) v1alpha1.CFApp {
+	fake.validateAppCreateMutex.RLock()
+	defer fake.validateAppCreateMutex.RUnlock()
+	argsForCall := fake.validateAppCreateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *NamespaceValidator) ValidateAppCreateReturns(result1 error) {
+	fake.validateAppCreateMutex.Lock()
+	defer fake.validateAppCreateMutex.Unlock()
+	fake.ValidateAppCreateStub = nil
+	fake.validateAppCreateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *NamespaceValidator) ValidateAppCreateReturnsOnCall(i int, result1 error) {
+	fake.validateAppCreateMutex.Lock()
+	defer fake.validateAppCreateMutex.Unlock()
+	fake.ValidateAppCreateStub = nil
+	if fake.validateAppCreateReturnsOnCall == nil {
+		fake.validateAppCreateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateAppCreateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *NamespaceValidator) ValidateOrgCreate(arg1 v1alpha1.CFOrg) error {
 	fake.validateOrgCreateMutex.Lock()
 	ret, specificReturn := fake.validateOrgCreateReturnsOnCall[len(fake.validateOrgCreateArgsForCall)]
@@ -160,6 +232,8 @@ func (fake *NamespaceValidator) ValidateSpaceCreateReturnsOnCall(i int, result1
 func (fake *NamespaceValidator) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.validateAppCreateMutex.RLock()
+	defer fake.validateAppCreateMutex.RUnlock()
 	fake.validateOrgCreateMutex.RLock()
 	defer fake.validateOrgCreateMutex.RUnlock()
 	fake.validateSpaceCreateMutex.RLock()
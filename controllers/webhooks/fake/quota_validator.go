@@ -0,0 +1,267 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+)
+
+type QuotaValidator struct {
+	ValidateAppCreateStub        func(context.Context, v1alpha1.CFApp) error
+	validateAppCreateMutex       sync.RWMutex
+	validateAppCreateArgsForCall []struct {
+		arg1 context.Context
+		arg2 v1alpha1.CFApp
+	}
+	validateAppCreateReturns struct {
+		result1 error
+	}
+	validateAppCreateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ValidateAppStartStub        func(context.Context, v1alpha1.CFApp) error
+	validateAppStartMutex       sync.RWMutex
+	validateAppStartArgsForCall []struct {
+		arg1 context.Context
+		arg2 v1alpha1.CFApp
+	}
+	validateAppStartReturns struct {
+		result1 error
+	}
+	validateAppStartReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ValidateProcessStub        func(context.Context, v1alpha1.CFProcess) error
+	validateProcessMutex       sync.RWMutex
+	validateProcessArgsForCall []struct {
+		arg1 context.Context
+		arg2 v1alpha1.CFProcess
+	}
+	validateProcessReturns struct {
+		result1 error
+	}
+	validateProcessReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *QuotaValidator) ValidateAppCreate(arg1 context.Context, arg2 v1alpha1.CFApp) error {
+	fake.validateAppCreateMutex.Lock()
+	ret, specificReturn := fake.validateAppCreateReturnsOnCall[len(fake.validateAppCreateArgsForCall)]
+	fake.validateAppCreateArgsForCall = append(fake.validateAppCreateArgsForCall, struct {
+		arg1 context.Context
+		arg2 v1alpha1.CFApp
+	}{arg1, arg2})
+	stub := fake.ValidateAppCreateStub
+	fakeReturns := fake.validateAppCreateReturns
+	fake.recordInvocation("ValidateAppCreate", []interface{}{arg1, arg2})
+	fake.validateAppCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *QuotaValidator) ValidateAppCreateCallCount() int {
+	fake.validateAppCreateMutex.RLock()
+	defer fake.validateAppCreateMutex.RUnlock()
+	return len(fake.validateAppCreateArgsForCall)
+}
+
+func (fake *QuotaValidator) ValidateAppCreateCalls(stub func(context.Context, v1alpha1.CFApp) error) {
+	fake.validateAppCreateMutex.Lock()
+	defer fake.validateAppCreateMutex.Unlock()
+	fake.ValidateAppCreateStub = stub
+}
+
+func (fake *QuotaValidator) ValidateAppCreateArgsForCall(i int) (context.Context, v1alpha1.CFApp) {
+	fake.validateAppCreateMutex.RLock()
+	defer fake.validateAppCreateMutex.RUnlock()
+	argsForCall := fake.validateAppCreateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *QuotaValidator) ValidateAppCreateReturns(result1 error) {
+	fake.validateAppCreateMutex.Lock()
+	defer fake.validateAppCreateMutex.Unlock()
+	fake.ValidateAppCreateStub = nil
+	fake.validateAppCreateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateAppCreateReturnsOnCall(i int, result1 error) {
+	fake.validateAppCreateMutex.Lock()
+	defer fake.validateAppCreateMutex.Unlock()
+	fake.ValidateAppCreateStub = nil
+	if fake.validateAppCreateReturnsOnCall == nil {
+		fake.validateAppCreateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateAppCreateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateAppStart(arg1 context.Context, arg2 v1alpha1.CFApp) error {
+	fake.validateAppStartMutex.Lock()
+	ret, specificReturn := fake.validateAppStartReturnsOnCall[len(fake.validateAppStartArgsForCall)]
+	fake.validateAppStartArgsForCall = append(fake.validateAppStartArgsForCall, struct {
+		arg1 context.Context
+		arg2 v1alpha1.CFApp
+	}{arg1, arg2})
+	stub := fake.ValidateAppStartStub
+	fakeReturns := fake.validateAppStartReturns
+	fake.recordInvocation("ValidateAppStart", []interface{}{arg1, arg2})
+	fake.validateAppStartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *QuotaValidator) ValidateAppStartCallCount() int {
+	fake.validateAppStartMutex.RLock()
+	defer fake.validateAppStartMutex.RUnlock()
+	return len(fake.validateAppStartArgsForCall)
+}
+
+func (fake *QuotaValidator) ValidateAppStartCalls(stub func(context.Context, v1alpha1.CFApp) error) {
+	fake.validateAppStartMutex.Lock()
+	defer fake.validateAppStartMutex.Unlock()
+	fake.ValidateAppStartStub = stub
+}
+
+func (fake *QuotaValidator) ValidateAppStartArgsForCall(i int) (context.Context, v1alpha1.CFApp) {
+	fake.validateAppStartMutex.RLock()
+	defer fake.validateAppStartMutex.RUnlock()
+	argsForCall := fake.validateAppStartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *QuotaValidator) ValidateAppStartReturns(result1 error) {
+	fake.validateAppStartMutex.Lock()
+	defer fake.validateAppStartMutex.Unlock()
+	fake.ValidateAppStartStub = nil
+	fake.validateAppStartReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateAppStartReturnsOnCall(i int, result1 error) {
+	fake.validateAppStartMutex.Lock()
+	defer fake.validateAppStartMutex.Unlock()
+	fake.ValidateAppStartStub = nil
+	if fake.validateAppStartReturnsOnCall == nil {
+		fake.validateAppStartReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateAppStartReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateProcess(arg1 context.Context, arg2 v1alpha1.CFProcess) error {
+	fake.validateProcessMutex.Lock()
+	ret, specificReturn := fake.validateProcessReturnsOnCall[len(fake.validateProcessArgsForCall)]
+	fake.validateProcessArgsForCall = append(fake.validateProcessArgsForCall, struct {
+		arg1 context.Context
+		arg2 v1alpha1.CFProcess
+	}{arg1, arg2})
+	stub := fake.ValidateProcessStub
+	fakeReturns := fake.validateProcessReturns
+	fake.recordInvocation("ValidateProcess", []interface{}{arg1, arg2})
+	fake.validateProcessMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *QuotaValidator) ValidateProcessCallCount() int {
+	fake.validateProcessMutex.RLock()
+	defer fake.validateProcessMutex.RUnlock()
+	return len(fake.validateProcessArgsForCall)
+}
+
+func (fake *QuotaValidator) ValidateProcessCalls(stub func(context.Context, v1alpha1.CFProcess) error) {
+	fake.validateProcessMutex.Lock()
+	defer fake.validateProcessMutex.Unlock()
+	fake.ValidateProcessStub = stub
+}
+
+func (fake *QuotaValidator) ValidateProcessArgsForCall(i int) (context.Context, v1alpha1.CFProcess) {
+	fake.validateProcessMutex.RLock()
+	defer fake.validateProcessMutex.RUnlock()
+	argsForCall := fake.validateProcessArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *QuotaValidator) ValidateProcessReturns(result1 error) {
+	fake.validateProcessMutex.Lock()
+	defer fake.validateProcessMutex.Unlock()
+	fake.ValidateProcessStub = nil
+	fake.validateProcessReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateProcessReturnsOnCall(i int, result1 error) {
+	fake.validateProcessMutex.Lock()
+	defer fake.validateProcessMutex.Unlock()
+	fake.ValidateProcessStub = nil
+	if fake.validateProcessReturnsOnCall == nil {
+		fake.validateProcessReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateProcessReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.validateAppCreateMutex.RLock()
+	defer fake.validateAppCreateMutex.RUnlock()
+	fake.validateAppStartMutex.RLock()
+	defer fake.validateAppStartMutex.RUnlock()
+	fake.validateProcessMutex.RLock()
+	defer fake.validateProcessMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *QuotaValidator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ webhooks.QuotaValidator = new(QuotaValidator)
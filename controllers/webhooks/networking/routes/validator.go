@@ -12,6 +12,7 @@ import (
 	validationwebhook "code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"github.com/hashicorp/go-multierror"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -27,6 +28,7 @@ const (
 	RouteEntityType = "route"
 
 	RouteDestinationNotInSpaceErrorType    = "RouteDestinationNotInSpaceError"
+	RouteDomainNotInOrgErrorType           = "RouteDomainNotInOrgError"
 	RouteDestinationNotInSpaceErrorMessage = "Route destination app not found in space"
 	RouteHostNameValidationErrorType       = "RouteHostNameValidationError"
 	RoutePathValidationErrorType           = "RoutePathValidationError"
@@ -152,6 +154,11 @@ func (v *Validator) validateRoute(ctx context.Context, route *korifiv1alpha1.CFR
 		return domain, err
 	}
 
+	err = v.validateDomainOrg(ctx, route, domain)
+	if err != nil {
+		return domain, err
+	}
+
 	err = v.validateDestinations(ctx, route)
 	if err != nil {
 		return domain, err
@@ -182,6 +189,35 @@ func (v *Validator) fetchDomain(ctx context.Context, route *korifiv1alpha1.CFRou
 	return domain, err
 }
 
+// validateDomainOrg ensures that routes on private domains, i.e. domains
+// living in an org namespace rather than the root namespace, are only
+// created in spaces of that org
+func (v *Validator) validateDomainOrg(ctx context.Context, route *korifiv1alpha1.CFRoute, domain *korifiv1alpha1.CFDomain) error {
+	if domain.Namespace == v.rootNamespace {
+		return nil
+	}
+
+	spaceNamespace := &corev1.Namespace{}
+	err := v.client.Get(ctx, types.NamespacedName{Name: route.Namespace}, spaceNamespace)
+	if err != nil {
+		logger.Info("failed to get the route namespace", "reason", err)
+		return validationwebhook.ValidationError{
+			Type:    validationwebhook.UnknownErrorType,
+			Message: validationwebhook.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	orgGUID := spaceNamespace.Labels[korifiv1alpha1.OrgGUIDKey]
+	if orgGUID != domain.Namespace {
+		return validationwebhook.ValidationError{
+			Type:    RouteDomainNotInOrgErrorType,
+			Message: fmt.Sprintf("Invalid domain. Domain '%s' is not available in organization '%s'.", domain.Spec.Name, orgGUID),
+		}.ExportJSONError()
+	}
+
+	return nil
+}
+
 func (v *Validator) validateDestinations(ctx context.Context, route *korifiv1alpha1.CFRoute) error {
 	err := v.checkDestinationsExistInNamespace(ctx, *route)
 	if err != nil {
@@ -45,9 +45,12 @@ var _ = Describe("CFRouteValidator", func() {
 		testDomainNamespace string
 		rootNamespace       string
 
-		getDomainError error
-		getAppError    error
-		retErr         error
+		routeNamespace *v1.Namespace
+
+		getDomainError    error
+		getAppError       error
+		getNamespaceError error
+		retErr            error
 
 		getDomainCallCount int
 	)
@@ -70,13 +73,15 @@ var _ = Describe("CFRouteValidator", func() {
 		rootNamespace = "root-ns"
 		getDomainError = nil
 		getAppError = nil
+		getNamespaceError = nil
 		getDomainCallCount = 0
 
 		cfRoute = initializeRouteCR(testRouteProtocol, testRouteHost, testRoutePath, testRouteGUID, testRouteNamespace, testDomainGUID, testDomainNamespace)
 
 		cfDomain = &korifiv1alpha1.CFDomain{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: testDomainGUID,
+				Name:      testDomainGUID,
+				Namespace: rootNamespace,
 			},
 			Spec: korifiv1alpha1.CFDomainSpec{
 				Name: testDomainName,
@@ -85,6 +90,15 @@ var _ = Describe("CFRouteValidator", func() {
 
 		cfApp = &korifiv1alpha1.CFApp{}
 
+		routeNamespace = &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testRouteNamespace,
+				Labels: map[string]string{
+					korifiv1alpha1.OrgGUIDKey: "my-org",
+				},
+			},
+		}
+
 		duplicateValidator = new(fake.NameValidator)
 		fakeClient = new(controllerfake.Client)
 
@@ -97,6 +111,9 @@ var _ = Describe("CFRouteValidator", func() {
 			case *korifiv1alpha1.CFApp:
 				cfApp.DeepCopyInto(obj)
 				return getAppError
+			case *v1.Namespace:
+				routeNamespace.DeepCopyInto(obj)
+				return getNamespaceError
 			default:
 				panic("TestClient Get provided an unexpected object type")
 			}
@@ -127,6 +144,43 @@ var _ = Describe("CFRouteValidator", func() {
 			Expect(getDomainCallCount).To(Equal(1), "Expected get domain call count mismatch")
 		})
 
+		When("the domain is a private domain of the route org", func() {
+			BeforeEach(func() {
+				cfDomain.Namespace = "my-org"
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the domain is a private domain of another org", func() {
+			BeforeEach(func() {
+				cfDomain.Namespace = "another-org"
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RouteDomainNotInOrgErrorType,
+					Equal("Invalid domain. Domain 'test.domain.name' is not available in organization 'my-org'."),
+				))
+			})
+		})
+
+		When("getting the route namespace fails", func() {
+			BeforeEach(func() {
+				cfDomain.Namespace = "my-org"
+				getNamespaceError = errors.New("boom")
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					validationwebhook.UnknownErrorType,
+					Equal(validationwebhook.UnknownErrorMessage),
+				))
+			})
+		})
+
 		When("the host is '*'", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Host = "*"
@@ -30,6 +30,15 @@ type NameValidator interface {
 type NamespaceValidator interface {
 	ValidateOrgCreate(org korifiv1alpha1.CFOrg) error
 	ValidateSpaceCreate(space korifiv1alpha1.CFSpace) error
+	ValidateAppCreate(app korifiv1alpha1.CFApp) error
+}
+
+//counterfeiter:generate -o fake -fake-name QuotaValidator . QuotaValidator
+
+type QuotaValidator interface {
+	ValidateProcess(ctx context.Context, process korifiv1alpha1.CFProcess) error
+	ValidateAppStart(ctx context.Context, app korifiv1alpha1.CFApp) error
+	ValidateAppCreate(ctx context.Context, app korifiv1alpha1.CFApp) error
 }
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const DefaultAppAnnotationsErrorType = "DefaultAppAnnotationsError"
+
+// reservedAnnotationDomains are the annotation domains, including their
+// subdomains, that control the behaviour of kubernetes and korifi and must not
+// be set on app workloads by org and space managers
+var reservedAnnotationDomains = []string{"kubernetes.io", "k8s.io", "cloudfoundry.org"}
+
+// ValidateDefaultAppAnnotations ensures that the default app annotations of
+// an org or space are valid annotations outside the reserved domains, as they
+// are stamped onto the org and space namespaces and the app pods
+func ValidateDefaultAppAnnotations(annotations map[string]string) error {
+	for key := range annotations {
+		if domain, _, ok := strings.Cut(key, "/"); ok && isReservedAnnotationDomain(domain) {
+			return defaultAppAnnotationsError(fmt.Sprintf("Default app annotation %q uses the reserved domain %q.", key, domain))
+		}
+	}
+
+	if errs := apivalidation.ValidateAnnotations(annotations, field.NewPath("spec", "defaultAppAnnotations")); len(errs) > 0 {
+		return defaultAppAnnotationsError(errs.ToAggregate().Error())
+	}
+
+	return nil
+}
+
+func isReservedAnnotationDomain(domain string) bool {
+	for _, reserved := range reservedAnnotationDomains {
+		if domain == reserved || strings.HasSuffix(domain, "."+reserved) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func defaultAppAnnotationsError(message string) error {
+	return ValidationError{
+		Type:    DefaultAppAnnotationsErrorType,
+		Message: message,
+	}.ExportJSONError()
+}
@@ -0,0 +1,70 @@
+package validation_test
+
+import (
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateDefaultAppAnnotations", func() {
+	var (
+		annotations   map[string]string
+		validationErr error
+	)
+
+	BeforeEach(func() {
+		annotations = map[string]string{
+			"cost-center":             "42",
+			"example.com/cost-center": "42",
+		}
+	})
+
+	JustBeforeEach(func() {
+		validationErr = validation.ValidateDefaultAppAnnotations(annotations)
+	})
+
+	It("succeeds", func() {
+		Expect(validationErr).NotTo(HaveOccurred())
+	})
+
+	DescribeTable("reserved domains",
+		func(key string) {
+			annotations[key] = "value"
+			Expect(validation.ValidateDefaultAppAnnotations(annotations)).To(matchers.BeValidationError(
+				validation.DefaultAppAnnotationsErrorType,
+				ContainSubstring("reserved domain"),
+			))
+		},
+		Entry("kubernetes.io", "kubernetes.io/psp"),
+		Entry("a kubernetes.io subdomain", "pod-security.kubernetes.io/enforce"),
+		Entry("k8s.io", "k8s.io/foo"),
+		Entry("a k8s.io subdomain", "cluster-autoscaler.k8s.io/safe-to-evict"),
+		Entry("cloudfoundry.org", "cloudfoundry.org/propagate-cf-role"),
+		Entry("a cloudfoundry.org subdomain", "korifi.cloudfoundry.org/app-guid"),
+	)
+
+	When("an annotation key is not a valid annotation key", func() {
+		BeforeEach(func() {
+			annotations["not a valid key"] = "value"
+		})
+
+		It("returns a validation error", func() {
+			Expect(validationErr).To(matchers.BeValidationError(
+				validation.DefaultAppAnnotationsErrorType,
+				ContainSubstring("spec.defaultAppAnnotations"),
+			))
+		})
+	})
+
+	When("a domain only ends with a reserved domain name", func() {
+		BeforeEach(func() {
+			annotations["notkubernetes.io/foo"] = "value"
+		})
+
+		It("succeeds", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+	})
+})
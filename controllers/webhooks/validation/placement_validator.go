@@ -6,6 +6,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -15,6 +16,8 @@ const (
 	OrgPlacementErrorMessage   = "Organization '%s' must be placed in the root 'cf' namespace"
 	SpacePlacementErrorType    = "SpacePlacementError"
 	SpacePlacementErrorMessage = "Organization '%s' does not exist for Space '%s'"
+	AppPlacementErrorType      = "AppPlacementError"
+	AppPlacementErrorMessage   = "Space '%s' does not exist for App '%s'"
 )
 
 type PlacementValidator struct {
@@ -50,3 +53,31 @@ func (v PlacementValidator) ValidateSpaceCreate(space korifiv1alpha1.CFSpace) er
 
 	return nil
 }
+
+// ValidateAppCreate checks that the app namespace belongs to a space. The
+// space is looked up in the org namespace recorded on the space namespace.
+func (v PlacementValidator) ValidateAppCreate(app korifiv1alpha1.CFApp) error {
+	appPlacementErr := ValidationError{
+		Type:    AppPlacementErrorType,
+		Message: fmt.Sprintf(AppPlacementErrorMessage, app.Namespace, app.Spec.DisplayName),
+	}.ExportJSONError()
+
+	namespace := corev1.Namespace{}
+	err := v.client.Get(context.Background(), types.NamespacedName{Name: app.Namespace}, &namespace)
+	if err != nil {
+		return appPlacementErr
+	}
+
+	orgGUID := namespace.Labels[korifiv1alpha1.OrgGUIDKey]
+	if orgGUID == "" {
+		return appPlacementErr
+	}
+
+	cfSpace := korifiv1alpha1.CFSpace{}
+	err = v.client.Get(context.Background(), types.NamespacedName{Name: app.Namespace, Namespace: orgGUID}, &cfSpace)
+	if err != nil {
+		return appPlacementErr
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package validation_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -12,8 +13,10 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("CFPlacementValidation", func() {
@@ -108,4 +111,82 @@ var _ = Describe("CFPlacementValidation", func() {
 			})
 		})
 	})
+
+	Describe("ValidateAppCreate", func() {
+		var (
+			app             korifiv1alpha1.CFApp
+			namespaceLabels map[string]string
+		)
+
+		BeforeEach(func() {
+			app = korifiv1alpha1.CFApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "space-ns",
+				},
+				Spec: korifiv1alpha1.CFAppSpec{
+					DisplayName: "test-app-display-name",
+				},
+			}
+
+			namespaceLabels = map[string]string{korifiv1alpha1.OrgGUIDKey: "org-ns"}
+			fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				if namespace, ok := obj.(*corev1.Namespace); ok {
+					namespace.Labels = namespaceLabels
+				}
+				return nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			validationErr = placementValidator.ValidateAppCreate(app)
+		})
+
+		It("succeeds", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		It("looks up the space in the org namespace", func() {
+			Expect(fakeClient.GetCallCount()).To(Equal(2))
+
+			_, namespaceKey, _, _ := fakeClient.GetArgsForCall(0)
+			Expect(namespaceKey.Name).To(Equal("space-ns"))
+
+			_, spaceKey, obj, _ := fakeClient.GetArgsForCall(1)
+			Expect(obj).To(BeAssignableToTypeOf(&korifiv1alpha1.CFSpace{}))
+			Expect(spaceKey).To(Equal(client.ObjectKey{Namespace: "org-ns", Name: "space-ns"}))
+		})
+
+		When("the app namespace does not belong to an org", func() {
+			BeforeEach(func() {
+				namespaceLabels = nil
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.AppPlacementErrorType,
+					Equal(fmt.Sprintf(validation.AppPlacementErrorMessage, "space-ns", app.Spec.DisplayName)),
+				))
+			})
+		})
+
+		When("the space does not exist", func() {
+			BeforeEach(func() {
+				fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					if namespace, ok := obj.(*corev1.Namespace); ok {
+						namespace.Labels = namespaceLabels
+						return nil
+					}
+					return errors.New("not found")
+				}
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.AppPlacementErrorType,
+					Equal(fmt.Sprintf(validation.AppPlacementErrorMessage, "space-ns", app.Spec.DisplayName)),
+				))
+			})
+		})
+	})
 })
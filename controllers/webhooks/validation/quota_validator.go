@@ -0,0 +1,424 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const QuotaExceededErrorType = "QuotaExceededError"
+
+var quotalog = logf.Log.WithName("quota-validate")
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgquotas;cfspacequotas,verbs=get;list;watch
+
+// QuotaValidator checks the processes of started apps against the app limits
+// of the space and org quotas. The processes of stopped apps do not run, so
+// they do not count towards the quotas and are only checked once their app is
+// started. The number of apps is limited regardless of their state.
+type QuotaValidator struct {
+	client           client.Client
+	rootNamespace    string
+	processDefaulter *korifiv1alpha1.CFProcessDefaulter
+}
+
+func NewQuotaValidator(client client.Client, rootNamespace string, processDefaulter *korifiv1alpha1.CFProcessDefaulter) *QuotaValidator {
+	return &QuotaValidator{
+		client:           client,
+		rootNamespace:    rootNamespace,
+		processDefaulter: processDefaulter,
+	}
+}
+
+// ValidateProcess checks a process that is created or scaled up
+func (v QuotaValidator) ValidateProcess(ctx context.Context, process korifiv1alpha1.CFProcess) error {
+	app := korifiv1alpha1.CFApp{}
+	err := v.client.Get(ctx, types.NamespacedName{Namespace: process.Namespace, Name: process.Spec.AppRef.Name}, &app)
+	if k8serrors.IsNotFound(err) {
+		quotalog.V(1).Info("process app does not exist, skipping quota checks", "namespace", process.Namespace, "name", process.Name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get app %q: %w", process.Spec.AppRef.Name, err)
+	}
+
+	if app.Spec.DesiredState != korifiv1alpha1.StartedState {
+		return nil
+	}
+
+	return v.validateProcesses(ctx, process.Namespace, []korifiv1alpha1.CFProcess{process})
+}
+
+// ValidateAppStart checks the processes an app runs once it is started. Besides
+// the processes the app already has, these are the processes the app
+// controller creates with the default resources for the process types of the
+// app droplet.
+func (v QuotaValidator) ValidateAppStart(ctx context.Context, app korifiv1alpha1.CFApp) error {
+	processes, err := v.appProcesses(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return v.validateProcesses(ctx, app.Namespace, processes)
+}
+
+// ValidateAppCreate checks that there is room for another app in the space
+// and its org
+func (v QuotaValidator) ValidateAppCreate(ctx context.Context, app korifiv1alpha1.CFApp) error {
+	orgGUID, err := v.orgGUIDFor(ctx, app.Namespace)
+	if err != nil || orgGUID == "" {
+		return err
+	}
+
+	spaceQuotas, err := v.spaceQuotasFor(ctx, orgGUID, app.Namespace)
+	if err != nil {
+		return err
+	}
+	if len(spaceQuotas) > 0 {
+		spaceApps, err := v.countApps(ctx, app, app.Namespace)
+		if err != nil {
+			return err
+		}
+
+		for _, quota := range spaceQuotas {
+			if err = checkAppCount("space", quota.Spec.DisplayName, quota.Spec.Apps, spaceApps); err != nil {
+				return err
+			}
+		}
+	}
+
+	orgQuotas, err := v.orgQuotasFor(ctx, orgGUID)
+	if err != nil || len(orgQuotas) == 0 {
+		return err
+	}
+
+	spaceGUIDs, err := v.orgSpaceGUIDs(ctx, orgGUID, app.Namespace)
+	if err != nil {
+		return err
+	}
+
+	orgApps, err := v.countApps(ctx, app, spaceGUIDs...)
+	if err != nil {
+		return err
+	}
+
+	for _, quota := range orgQuotas {
+		if err = checkAppCount("organization", quota.Spec.DisplayName, quota.Spec.Apps, orgApps); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// countApps counts the apps in the given spaces, leaving out the app being
+// validated
+func (v QuotaValidator) countApps(ctx context.Context, app korifiv1alpha1.CFApp, spaceGUIDs ...string) (int32, error) {
+	count := int32(0)
+	for _, spaceGUID := range spaceGUIDs {
+		apps := korifiv1alpha1.CFAppList{}
+		if err := v.client.List(ctx, &apps, client.InNamespace(spaceGUID)); err != nil {
+			return 0, fmt.Errorf("failed to list apps in space %q: %w", spaceGUID, err)
+		}
+
+		for _, spaceApp := range apps.Items {
+			if spaceApp.Namespace == app.Namespace && spaceApp.Name == app.Name {
+				continue
+			}
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func checkAppCount(quotaKind, quotaName string, quota korifiv1alpha1.AppsQuota, apps int32) error {
+	if quota.TotalApps != nil && apps >= *quota.TotalApps {
+		return quotaExceededError(fmt.Sprintf(
+			"App quota exceeded: %s quota '%s' allows %d apps in total",
+			quotaKind, quotaName, *quota.TotalApps,
+		))
+	}
+
+	return nil
+}
+
+func (v QuotaValidator) appProcesses(ctx context.Context, app korifiv1alpha1.CFApp) ([]korifiv1alpha1.CFProcess, error) {
+	processList := korifiv1alpha1.CFProcessList{}
+	err := v.client.List(ctx, &processList, client.InNamespace(app.Namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: app.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes of app %q: %w", app.Name, err)
+	}
+	processes := processList.Items
+
+	if app.Spec.CurrentDropletRef.Name == "" {
+		return processes, nil
+	}
+
+	build := korifiv1alpha1.CFBuild{}
+	err = v.client.Get(ctx, types.NamespacedName{Namespace: app.Namespace, Name: app.Spec.CurrentDropletRef.Name}, &build)
+	if k8serrors.IsNotFound(err) {
+		return processes, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get droplet %q: %w", app.Spec.CurrentDropletRef.Name, err)
+	}
+
+	if build.Status.Droplet == nil {
+		return processes, nil
+	}
+
+	processTypes := []string{korifiv1alpha1.ProcessTypeWeb}
+	for _, processType := range build.Status.Droplet.ProcessTypes {
+		processTypes = append(processTypes, processType.Type)
+	}
+
+	for _, processType := range processTypes {
+		if slices.ContainsFunc(processes, func(process korifiv1alpha1.CFProcess) bool {
+			return process.Spec.ProcessType == processType
+		}) {
+			continue
+		}
+
+		process := korifiv1alpha1.CFProcess{}
+		process.Namespace = app.Namespace
+		process.Name = tools.NamespacedUUID(app.Name, processType)
+		process.Spec.AppRef.Name = app.Name
+		process.Spec.ProcessType = processType
+		if err = v.processDefaulter.Default(ctx, &process); err != nil {
+			return nil, fmt.Errorf("failed to default process %q: %w", processType, err)
+		}
+
+		processes = append(processes, process)
+	}
+
+	return processes, nil
+}
+
+// validateProcesses checks the processes, which are all in the same
+// namespace, against the quotas of the space and of its org
+func (v QuotaValidator) validateProcesses(ctx context.Context, namespace string, processes []korifiv1alpha1.CFProcess) error {
+	orgGUID, err := v.orgGUIDFor(ctx, namespace)
+	if err != nil || orgGUID == "" {
+		return err
+	}
+
+	spaceQuotas, err := v.spaceQuotasFor(ctx, orgGUID, namespace)
+	if err != nil {
+		return err
+	}
+	if len(spaceQuotas) > 0 {
+		spaceUsage, err := v.allocatedInSpaces(ctx, processes, namespace)
+		if err != nil {
+			return err
+		}
+
+		for _, quota := range spaceQuotas {
+			if err = checkQuota("space", quota.Spec.DisplayName, quota.Spec.Apps, processes, spaceUsage); err != nil {
+				return err
+			}
+		}
+	}
+
+	orgQuotas, err := v.orgQuotasFor(ctx, orgGUID)
+	if err != nil || len(orgQuotas) == 0 {
+		return err
+	}
+
+	spaceGUIDs, err := v.orgSpaceGUIDs(ctx, orgGUID, namespace)
+	if err != nil {
+		return err
+	}
+
+	orgUsage, err := v.allocatedInSpaces(ctx, processes, spaceGUIDs...)
+	if err != nil {
+		return err
+	}
+
+	for _, quota := range orgQuotas {
+		if err = checkQuota("organization", quota.Spec.DisplayName, quota.Spec.Apps, processes, orgUsage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// orgGUIDFor returns the org of a space namespace, or an empty string if the
+// namespace does not belong to a space
+func (v QuotaValidator) orgGUIDFor(ctx context.Context, namespace string) (string, error) {
+	spaceNamespace := corev1.Namespace{}
+	err := v.client.Get(ctx, types.NamespacedName{Name: namespace}, &spaceNamespace)
+	if err != nil {
+		return "", fmt.Errorf("failed to get namespace %q: %w", namespace, err)
+	}
+
+	orgGUID := spaceNamespace.Labels[korifiv1alpha1.OrgGUIDKey]
+	if orgGUID == "" {
+		quotalog.V(1).Info("namespace does not belong to a space, skipping quota checks", "namespace", namespace)
+	}
+
+	return orgGUID, nil
+}
+
+func (v QuotaValidator) spaceQuotasFor(ctx context.Context, orgGUID, namespace string) ([]korifiv1alpha1.CFSpaceQuota, error) {
+	spaceQuotas := korifiv1alpha1.CFSpaceQuotaList{}
+	if err := v.client.List(ctx, &spaceQuotas, client.InNamespace(orgGUID)); err != nil {
+		return nil, fmt.Errorf("failed to list space quotas: %w", err)
+	}
+
+	return slices.DeleteFunc(spaceQuotas.Items, func(quota korifiv1alpha1.CFSpaceQuota) bool {
+		return !slices.Contains(quota.Spec.Spaces, namespace)
+	}), nil
+}
+
+func (v QuotaValidator) orgQuotasFor(ctx context.Context, orgGUID string) ([]korifiv1alpha1.CFOrgQuota, error) {
+	orgQuotas := korifiv1alpha1.CFOrgQuotaList{}
+	if err := v.client.List(ctx, &orgQuotas, client.InNamespace(v.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list org quotas: %w", err)
+	}
+
+	return slices.DeleteFunc(orgQuotas.Items, func(quota korifiv1alpha1.CFOrgQuota) bool {
+		return !slices.Contains(quota.Spec.Orgs, orgGUID)
+	}), nil
+}
+
+// orgSpaceGUIDs returns the spaces of the org, including the given space
+// namespace in case its CFSpace is not there yet
+func (v QuotaValidator) orgSpaceGUIDs(ctx context.Context, orgGUID, namespace string) ([]string, error) {
+	spaces := korifiv1alpha1.CFSpaceList{}
+	if err := v.client.List(ctx, &spaces, client.InNamespace(orgGUID)); err != nil {
+		return nil, fmt.Errorf("failed to list spaces: %w", err)
+	}
+
+	spaceGUIDs := []string{}
+	for _, space := range spaces.Items {
+		spaceGUIDs = append(spaceGUIDs, space.Name)
+	}
+	if !slices.Contains(spaceGUIDs, namespace) {
+		spaceGUIDs = append(spaceGUIDs, namespace)
+	}
+
+	return spaceGUIDs, nil
+}
+
+// allocatedInSpaces sums the resources allocated to the processes of the
+// started apps in the given spaces, leaving out the processes being validated
+func (v QuotaValidator) allocatedInSpaces(ctx context.Context, processes []korifiv1alpha1.CFProcess, spaceGUIDs ...string) (usage, error) {
+	total := usage{}
+
+	for _, spaceGUID := range spaceGUIDs {
+		apps := korifiv1alpha1.CFAppList{}
+		if err := v.client.List(ctx, &apps, client.InNamespace(spaceGUID)); err != nil {
+			return usage{}, fmt.Errorf("failed to list apps in space %q: %w", spaceGUID, err)
+		}
+
+		startedApps := map[string]bool{}
+		for _, app := range apps.Items {
+			startedApps[app.Name] = app.Spec.DesiredState == korifiv1alpha1.StartedState
+		}
+
+		spaceProcesses := korifiv1alpha1.CFProcessList{}
+		if err := v.client.List(ctx, &spaceProcesses, client.InNamespace(spaceGUID)); err != nil {
+			return usage{}, fmt.Errorf("failed to list processes in space %q: %w", spaceGUID, err)
+		}
+
+		for i := range spaceProcesses.Items {
+			spaceProcess := &spaceProcesses.Items[i]
+			if !startedApps[spaceProcess.Spec.AppRef.Name] {
+				continue
+			}
+
+			if slices.ContainsFunc(processes, func(process korifiv1alpha1.CFProcess) bool {
+				return process.Namespace == spaceProcess.Namespace && process.Name == spaceProcess.Name
+			}) {
+				continue
+			}
+
+			total = total.add(processUsage(spaceProcess))
+		}
+	}
+
+	return total, nil
+}
+
+// checkQuota checks the processes against the limits of the quota. The totals
+// are checked against what remains of the quota once the resources allocated
+// to the other processes are taken into account.
+func checkQuota(quotaKind, quotaName string, quota korifiv1alpha1.AppsQuota, processes []korifiv1alpha1.CFProcess, allocated usage) error {
+	requested := usage{}
+	for i := range processes {
+		process := &processes[i]
+
+		if quota.PerProcessMemoryMB != nil && process.Spec.MemoryMB > *quota.PerProcessMemoryMB {
+			return quotaExceededError(fmt.Sprintf(
+				"Memory quota exceeded: %s quota '%s' allows at most %d MB per process instance, requested %d MB",
+				quotaKind, quotaName, *quota.PerProcessMemoryMB, process.Spec.MemoryMB,
+			))
+		}
+
+		requested = requested.add(processUsage(process))
+	}
+
+	if quota.TotalMemoryMB != nil {
+		remaining := max(*quota.TotalMemoryMB-allocated.memoryMB, 0)
+		if requested.memoryMB > remaining {
+			return quotaExceededError(fmt.Sprintf(
+				"Memory quota exceeded: %s quota '%s' allows %d MB in total, %d MB remaining, requested %d MB",
+				quotaKind, quotaName, *quota.TotalMemoryMB, remaining, requested.memoryMB,
+			))
+		}
+	}
+
+	if quota.TotalInstances != nil {
+		remaining := max(*quota.TotalInstances-allocated.instances, 0)
+		if requested.instances > remaining {
+			return quotaExceededError(fmt.Sprintf(
+				"Instance quota exceeded: %s quota '%s' allows %d instances in total, %d remaining, requested %d",
+				quotaKind, quotaName, *quota.TotalInstances, remaining, requested.instances,
+			))
+		}
+	}
+
+	return nil
+}
+
+func quotaExceededError(message string) error {
+	return ValidationError{
+		Type:    QuotaExceededErrorType,
+		Message: message,
+	}.ExportJSONError()
+}
+
+type usage struct {
+	memoryMB  int64
+	instances int32
+}
+
+func (u usage) add(other usage) usage {
+	return usage{
+		memoryMB:  u.memoryMB + other.memoryMB,
+		instances: u.instances + other.instances,
+	}
+}
+
+func processUsage(process *korifiv1alpha1.CFProcess) usage {
+	instances := int32(0)
+	if process.Spec.DesiredInstances != nil {
+		instances = *process.Spec.DesiredInstances
+	}
+
+	return usage{
+		memoryMB:  process.Spec.MemoryMB * int64(instances),
+		instances: instances,
+	}
+}
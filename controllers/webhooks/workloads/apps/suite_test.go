@@ -34,6 +34,7 @@ var (
 	adminNonSyncClient client.Client
 
 	ctx           context.Context
+	rootNamespace string
 	testNamespace string
 )
 
@@ -48,6 +49,8 @@ func TestWorkloadsWebhooks(t *testing.T) {
 var _ = BeforeSuite(func() {
 	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
 
+	rootNamespace = uuid.NewString()
+
 	webhookManifestsPath := helpers.GenerateWebhookManifest(
 		"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/apps",
 	)
@@ -83,7 +86,9 @@ var _ = BeforeSuite(func() {
 
 	uncachedClient := helpers.NewUncachedClient(k8sManager.GetConfig())
 	appNameDuplicateValidator := validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, apps.AppEntityType))
-	Expect(apps.NewValidator(appNameDuplicateValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	appPlacementValidator := validation.NewPlacementValidator(uncachedClient, rootNamespace)
+	appQuotaValidator := validation.NewQuotaValidator(uncachedClient, rootNamespace, korifiv1alpha1.NewCFProcessDefaulter(1024, 1024, 1, 60, 30))
+	Expect(apps.NewValidator(appNameDuplicateValidator, appPlacementValidator, appQuotaValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
@@ -91,14 +96,41 @@ var _ = BeforeSuite(func() {
 var _ = BeforeEach(func() {
 	ctx = context.Background()
 
-	testNamespace = uuid.NewString()
+	testNamespace = createSpaceNamespace()
+})
 
+// createSpaceNamespace creates a namespace backed by a CFSpace, so that apps
+// created in it pass the placement validation.
+func createSpaceNamespace() string {
+	orgNamespace := uuid.NewString()
 	Expect(adminClient.Create(ctx, &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: testNamespace,
+			Name: orgNamespace,
 		},
 	})).To(Succeed())
-})
+
+	spaceNamespace := uuid.NewString()
+	Expect(adminClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: spaceNamespace,
+			Labels: map[string]string{
+				korifiv1alpha1.OrgGUIDKey: orgNamespace,
+			},
+		},
+	})).To(Succeed())
+
+	Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      spaceNamespace,
+			Namespace: orgNamespace,
+		},
+		Spec: korifiv1alpha1.CFSpaceSpec{
+			DisplayName: uuid.NewString(),
+		},
+	})).To(Succeed())
+
+	return spaceNamespace
+}
 
 var _ = AfterSuite(func() {
 	stopClientCache()
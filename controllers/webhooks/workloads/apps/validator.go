@@ -3,6 +3,8 @@ package apps
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
@@ -25,15 +27,24 @@ var cfapplog = logf.Log.WithName("cfapp-validate")
 
 //+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfapp,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfapps,verbs=create;update;delete,versions=v1alpha1,name=vcfapp.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
+var supportedLifecycleTypes = []korifiv1alpha1.LifecycleType{
+	korifiv1alpha1.BuildpackLifecycle,
+	korifiv1alpha1.DockerLifecycle,
+}
+
 type Validator struct {
 	duplicateValidator webhooks.NameValidator
+	placementValidator webhooks.NamespaceValidator
+	quotaValidator     webhooks.QuotaValidator
 }
 
 var _ webhook.CustomValidator = &Validator{}
 
-func NewValidator(duplicateValidator webhooks.NameValidator) *Validator {
+func NewValidator(duplicateValidator webhooks.NameValidator, placementValidator webhooks.NamespaceValidator, quotaValidator webhooks.QuotaValidator) *Validator {
 	return &Validator{
 		duplicateValidator: duplicateValidator,
+		placementValidator: placementValidator,
+		quotaValidator:     quotaValidator,
 	}
 }
 
@@ -50,6 +61,24 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFApp but got a %T", obj))
 	}
 
+	if err := validateSpec(app); err != nil {
+		return nil, err
+	}
+
+	if err := v.placementValidator.ValidateAppCreate(*app); err != nil {
+		return nil, err
+	}
+
+	if err := v.quotaValidator.ValidateAppCreate(ctx, *app); err != nil {
+		return nil, err
+	}
+
+	if app.Spec.DesiredState == korifiv1alpha1.StartedState {
+		if err := v.quotaValidator.ValidateAppStart(ctx, *app); err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfapplog, app.Namespace, app)
 }
 
@@ -75,6 +104,19 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Obje
 		}.ExportJSONError()
 	}
 
+	if err := validateSpec(app); err != nil {
+		return nil, err
+	}
+
+	// Starting the app, or changing the droplet of a started app, makes the
+	// app controller create or update its processes, so the quotas are
+	// checked here where they can be reported to the user
+	if isStarting(oldApp, app) {
+		if err := v.quotaValidator.ValidateAppStart(ctx, *app); err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfapplog, app.Namespace, oldApp, app)
 }
 
@@ -86,3 +128,33 @@ func (v *Validator) ValidateDelete(ctx context.Context, obj runtime.Object) (adm
 
 	return nil, v.duplicateValidator.ValidateDelete(ctx, cfapplog, app.Namespace, app)
 }
+
+func isStarting(oldApp, app *korifiv1alpha1.CFApp) bool {
+	if app.Spec.DesiredState != korifiv1alpha1.StartedState {
+		return false
+	}
+
+	return oldApp.Spec.DesiredState != korifiv1alpha1.StartedState ||
+		oldApp.Spec.CurrentDropletRef.Name != app.Spec.CurrentDropletRef.Name
+}
+
+// validateSpec rejects apps the reconciler cannot handle. Most of this is
+// also covered by the CRD schema, the webhook makes sure it is reported with
+// a CF validation error regardless.
+func validateSpec(app *korifiv1alpha1.CFApp) error {
+	if strings.TrimSpace(app.Spec.DisplayName) == "" {
+		return validation.ValidationError{
+			Type:    webhooks.MissingRequredFieldErrorType,
+			Message: fmt.Sprintf("app %s:%s is missing required field 'Spec.DisplayName'", app.Namespace, app.Name),
+		}.ExportJSONError()
+	}
+
+	if !slices.Contains(supportedLifecycleTypes, app.Spec.Lifecycle.Type) {
+		return validation.ValidationError{
+			Type:    webhooks.InvalidFieldValueErrorType,
+			Message: fmt.Sprintf("app %s:%s has unsupported lifecycle type %q, it must be one of %q", app.Namespace, app.Name, app.Spec.Lifecycle.Type, supportedLifecycleTypes),
+		}.ExportJSONError()
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -71,12 +72,7 @@ var _ = Describe("CFAppValidatingWebhook", func() {
 
 		When("another CFApp exists with the same name in a different namespace", func() {
 			BeforeEach(func() {
-				anotherNamespace := uuid.NewString()
-				Expect(adminClient.Create(ctx, &corev1.Namespace{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: anotherNamespace,
-					},
-				})).To(Succeed())
+				anotherNamespace := createSpaceNamespace()
 
 				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFApp{
 					ObjectMeta: metav1.ObjectMeta{
@@ -98,6 +94,42 @@ var _ = Describe("CFAppValidatingWebhook", func() {
 			})
 		})
 
+		When("the display name is blank", func() {
+			BeforeEach(func() {
+				app.Spec.DisplayName = " "
+			})
+
+			It("should fail", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("missing required field 'Spec.DisplayName'")))
+			})
+		})
+
+		When("the lifecycle type is not supported", func() {
+			BeforeEach(func() {
+				app.Spec.Lifecycle.Type = "kpack"
+			})
+
+			It("should fail", func() {
+				Expect(createErr).To(HaveOccurred())
+				Expect(createErr.Error()).To(ContainSubstring("kpack"))
+			})
+		})
+
+		When("the namespace does not belong to a space", func() {
+			BeforeEach(func() {
+				app.Namespace = uuid.NewString()
+				Expect(adminClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: app.Namespace,
+					},
+				})).To(Succeed())
+			})
+
+			It("should fail", func() {
+				Expect(createErr).To(MatchError(ContainSubstring(fmt.Sprintf("Space '%s' does not exist for App '%s'", app.Namespace, app.Spec.DisplayName))))
+			})
+		})
+
 		When("another CFApp exists with the same name in the same namespace", func() {
 			BeforeEach(func() {
 				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFApp{
@@ -141,6 +173,94 @@ var _ = Describe("CFAppValidatingWebhook", func() {
 				Expect(createErr).To(MatchError(ContainSubstring(fmt.Sprintf("App with the name '%s' already exists.", app.Spec.DisplayName))))
 			})
 		})
+
+		Describe("app count quotas", func() {
+			var orgGUID string
+
+			createOtherApp := func() {
+				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFApp{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFAppSpec{
+						DisplayName:  uuid.NewString(),
+						DesiredState: "STOPPED",
+						Lifecycle: korifiv1alpha1.Lifecycle{
+							Type: "buildpack",
+						},
+					},
+				})).To(Succeed())
+			}
+
+			BeforeEach(func() {
+				namespace := &corev1.Namespace{}
+				Expect(adminClient.Get(ctx, client.ObjectKey{Name: testNamespace}, namespace)).To(Succeed())
+				orgGUID = namespace.Labels[korifiv1alpha1.OrgGUIDKey]
+			})
+
+			When("a space quota allows a single app", func() {
+				BeforeEach(func() {
+					Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpaceQuota{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: orgGUID,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+							DisplayName: "my-space-quota",
+							Apps: korifiv1alpha1.AppsQuota{
+								TotalApps: tools.PtrTo[int32](1),
+							},
+							Spaces: []string{testNamespace},
+						},
+					})).To(Succeed())
+				})
+
+				It("allows the first app", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+				})
+
+				When("the space already has an app", func() {
+					BeforeEach(func() {
+						createOtherApp()
+					})
+
+					It("rejects the second app", func() {
+						Expect(createErr).To(MatchError(ContainSubstring("App quota exceeded: space quota 'my-space-quota' allows 1 apps in total")))
+					})
+				})
+			})
+
+			When("an org quota allows a single app", func() {
+				BeforeEach(func() {
+					Expect(client.IgnoreAlreadyExists(adminClient.Create(ctx, &corev1.Namespace{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: rootNamespace,
+						},
+					}))).To(Succeed())
+
+					Expect(adminClient.Create(ctx, &korifiv1alpha1.CFOrgQuota{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFOrgQuotaSpec{
+							DisplayName: "my-org-quota",
+							Apps: korifiv1alpha1.AppsQuota{
+								TotalApps: tools.PtrTo[int32](1),
+							},
+							Orgs: []string{orgGUID},
+						},
+					})).To(Succeed())
+
+					createOtherApp()
+				})
+
+				It("rejects the second app", func() {
+					Expect(createErr).To(MatchError(ContainSubstring("App quota exceeded: organization quota 'my-org-quota' allows 1 apps in total")))
+				})
+			})
+		})
 	})
 
 	Describe("Update", func() {
@@ -230,6 +350,18 @@ var _ = Describe("CFAppValidatingWebhook", func() {
 			})
 		})
 
+		Describe("clearing the display name", func() {
+			JustBeforeEach(func() {
+				updateErr = k8s.Patch(ctx, adminClient, app, func() {
+					app.Spec.DisplayName = " "
+				})
+			})
+
+			It("should fail", func() {
+				Expect(updateErr).To(MatchError(ContainSubstring("missing required field 'Spec.DisplayName'")))
+			})
+		})
+
 		Describe("changing the lifecycle type", func() {
 			JustBeforeEach(func() {
 				updateErr = k8s.Patch(ctx, adminClient, app, func() {
@@ -241,6 +373,148 @@ var _ = Describe("CFAppValidatingWebhook", func() {
 				Expect(updateErr).To(MatchError(ContainSubstring("cannot be changed from buildpack to docker")))
 			})
 		})
+
+		Describe("starting the app", func() {
+			var spaceQuota *korifiv1alpha1.CFSpaceQuota
+
+			createProcess := func(appGUID string, memoryMB int64, instances int32) {
+				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFProcess{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testNamespace,
+						Name:      uuid.NewString(),
+						Labels: map[string]string{
+							korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
+						},
+					},
+					Spec: korifiv1alpha1.CFProcessSpec{
+						AppRef:           corev1.LocalObjectReference{Name: appGUID},
+						ProcessType:      korifiv1alpha1.ProcessTypeWeb,
+						MemoryMB:         memoryMB,
+						DiskQuotaMB:      1024,
+						DesiredInstances: tools.PtrTo(instances),
+					},
+				})).To(Succeed())
+			}
+
+			BeforeEach(func() {
+				createProcess(app.Name, 512, 2)
+
+				namespace := &corev1.Namespace{}
+				Expect(adminClient.Get(ctx, client.ObjectKey{Name: testNamespace}, namespace)).To(Succeed())
+
+				spaceQuota = &korifiv1alpha1.CFSpaceQuota{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace.Labels[korifiv1alpha1.OrgGUIDKey],
+						Name:      uuid.NewString(),
+					},
+					Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+						DisplayName: "my-space-quota",
+						Apps: korifiv1alpha1.AppsQuota{
+							TotalMemoryMB:      tools.PtrTo[int64](1024),
+							PerProcessMemoryMB: tools.PtrTo[int64](512),
+						},
+						Spaces: []string{testNamespace},
+					},
+				}
+				Expect(adminClient.Create(ctx, spaceQuota)).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				updateErr = k8s.Patch(ctx, adminClient, app, func() {
+					app.Spec.DesiredState = korifiv1alpha1.StartedState
+				})
+			})
+
+			It("succeeds when its processes fit into the quota", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+			})
+
+			When("another started app uses part of the quota", func() {
+				BeforeEach(func() {
+					otherApp := &korifiv1alpha1.CFApp{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      uuid.NewString(),
+							Namespace: testNamespace,
+						},
+						Spec: korifiv1alpha1.CFAppSpec{
+							DisplayName:  uuid.NewString(),
+							DesiredState: korifiv1alpha1.StartedState,
+							Lifecycle: korifiv1alpha1.Lifecycle{
+								Type: "buildpack",
+							},
+						},
+					}
+					Expect(adminClient.Create(ctx, otherApp)).To(Succeed())
+					createProcess(otherApp.Name, 256, 1)
+				})
+
+				It("fails naming the quota", func() {
+					Expect(updateErr).To(MatchError(ContainSubstring("Memory quota exceeded: space quota 'my-space-quota' allows 1024 MB in total, 768 MB remaining, requested 1024 MB")))
+				})
+			})
+
+			When("the droplet has process types the app has no processes for yet", func() {
+				BeforeEach(func() {
+					cfBuild := &korifiv1alpha1.CFBuild{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      uuid.NewString(),
+							Namespace: testNamespace,
+						},
+						Spec: korifiv1alpha1.CFBuildSpec{
+							Lifecycle: korifiv1alpha1.Lifecycle{
+								Type: "buildpack",
+							},
+						},
+					}
+					Expect(adminClient.Create(ctx, cfBuild)).To(Succeed())
+					Expect(k8s.Patch(ctx, adminClient, cfBuild, func() {
+						cfBuild.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+							ProcessTypes: []korifiv1alpha1.ProcessType{
+								{Type: korifiv1alpha1.ProcessTypeWeb, Command: "web"},
+								{Type: "worker", Command: "work"},
+							},
+						}
+					})).To(Succeed())
+
+					app.Spec.CurrentDropletRef.Name = cfBuild.Name
+				})
+
+				It("checks the processes the app will get with the default resources", func() {
+					Expect(updateErr).To(MatchError(ContainSubstring("Memory quota exceeded: space quota 'my-space-quota' allows at most 512 MB per process instance, requested 1024 MB")))
+				})
+			})
+		})
+
+		Describe("updating a stopped app", func() {
+			BeforeEach(func() {
+				namespace := &corev1.Namespace{}
+				Expect(adminClient.Get(ctx, client.ObjectKey{Name: testNamespace}, namespace)).To(Succeed())
+
+				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpaceQuota{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace.Labels[korifiv1alpha1.OrgGUIDKey],
+						Name:      uuid.NewString(),
+					},
+					Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+						DisplayName: "my-space-quota",
+						Apps: korifiv1alpha1.AppsQuota{
+							TotalInstances: tools.PtrTo[int32](0),
+						},
+						Spaces: []string{testNamespace},
+					},
+				})).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				updateErr = k8s.Patch(ctx, adminClient, app, func() {
+					app.Spec.CurrentDropletRef.Name = uuid.NewString()
+				})
+			})
+
+			It("does not check the quotas", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+			})
+		})
 	})
 
 	Describe("Delete", func() {
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -56,6 +58,11 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		return nil, errors.New("org name cannot be longer than 63 chars")
 	}
 
+	if err := validation.ValidateDefaultAppAnnotations(org.Spec.DefaultAppAnnotations); err != nil {
+		cfOrgLog.Info(err.Error())
+		return nil, err
+	}
+
 	err := v.placementValidator.ValidateOrgCreate(*org)
 	if err != nil {
 		cfOrgLog.Info(err.Error())
@@ -80,6 +87,13 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Obje
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFOrg but got a %T", obj))
 	}
 
+	if !maps.Equal(oldOrg.Spec.DefaultAppAnnotations, org.Spec.DefaultAppAnnotations) {
+		if err := validation.ValidateDefaultAppAnnotations(org.Spec.DefaultAppAnnotations); err != nil {
+			cfOrgLog.Info(err.Error())
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfOrgLog, org.Namespace, oldOrg, org)
 }
 
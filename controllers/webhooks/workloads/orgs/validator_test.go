@@ -63,6 +63,16 @@ var _ = Describe("CFOrgValidatingWebhook", func() {
 			})
 		})
 
+		When("the default app annotations use a reserved domain", func() {
+			BeforeEach(func() {
+				org.Spec.DefaultAppAnnotations = map[string]string{"korifi.cloudfoundry.org/app-guid": "my-app"}
+			})
+
+			It("should fail", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("uses the reserved domain")))
+			})
+		})
+
 		When("another CFOrg exists with a different name", func() {
 			BeforeEach(func() {
 				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFOrg{
@@ -181,6 +191,34 @@ var _ = Describe("CFOrgValidatingWebhook", func() {
 			})
 		})
 
+		Describe("changing the default app annotations", func() {
+			var defaultAppAnnotations map[string]string
+
+			BeforeEach(func() {
+				defaultAppAnnotations = map[string]string{"example.com/cost-center": "42"}
+			})
+
+			JustBeforeEach(func() {
+				updateErr = k8s.Patch(ctx, adminClient, org, func() {
+					org.Spec.DefaultAppAnnotations = defaultAppAnnotations
+				})
+			})
+
+			It("should succeed", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+			})
+
+			When("the default app annotations use a reserved domain", func() {
+				BeforeEach(func() {
+					defaultAppAnnotations = map[string]string{"pod-security.kubernetes.io/enforce": "privileged"}
+				})
+
+				It("should fail", func() {
+					Expect(updateErr).To(MatchError(ContainSubstring("uses the reserved domain")))
+				})
+			})
+		})
+
 		When("not changing the name", func() {
 			JustBeforeEach(func() {
 				updateErr = k8s.Patch(ctx, adminClient, org, func() {
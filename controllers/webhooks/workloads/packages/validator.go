@@ -23,6 +23,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	validationwebhook "code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	authv1 "k8s.io/api/authorization/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -37,7 +38,11 @@ var (
 	cfpackagelog = logf.Log.WithName("cftask-resource")
 )
 
-//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfpackage,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfpackages,verbs=update,versions=v1alpha1,name=vcfpackage.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfpackage,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfpackages,verbs=create;update,versions=v1alpha1,name=vcfpackage.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+const CopySourceForbiddenErrorType = "CopySourceForbiddenError"
 
 type Validator struct {
 	client client.Client
@@ -61,7 +66,64 @@ func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 var _ webhook.CustomValidator = &Validator{}
 
 func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
-	return nil, nil
+	cfPackage, ok := obj.(*v1alpha1.CFPackage)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFPackage but got a %T", obj))
+	}
+
+	if _, isCopy := cfPackage.Annotations[v1alpha1.CFPackageCopySourceGUIDAnnotation]; !isCopy {
+		return nil, nil
+	}
+
+	cfpackagelog.V(1).Info("validate package copy", "namespace", cfPackage.Namespace, "name", cfPackage.Name)
+
+	return nil, v.validateCopySourceAccess(ctx, cfPackage)
+}
+
+// validateCopySourceAccess checks that the user creating a package copy can
+// read the source package. The package controller copies the source image
+// and image pull secrets on behalf of the user, so it must not be possible to
+// copy a package from a space the user has no access to.
+func (v *Validator) validateCopySourceAccess(ctx context.Context, cfPackage *v1alpha1.CFPackage) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return apierrors.NewBadRequest(fmt.Sprintf("failed to get the admission request: %v", err))
+	}
+
+	extra := map[string]authv1.ExtraValue{}
+	for key, value := range req.UserInfo.Extra {
+		extra[key] = authv1.ExtraValue(value)
+	}
+
+	sourceNamespace := cfPackage.Annotations[v1alpha1.CFPackageCopySourceNamespaceAnnotation]
+	sourceName := cfPackage.Annotations[v1alpha1.CFPackageCopySourceGUIDAnnotation]
+	review := authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			UID:    req.UserInfo.UID,
+			Groups: req.UserInfo.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: sourceNamespace,
+				Verb:      "get",
+				Group:     v1alpha1.SchemeGroupVersion.Group,
+				Resource:  "cfpackages",
+				Name:      sourceName,
+			},
+		},
+	}
+	if err = v.client.Create(ctx, &review); err != nil {
+		return fmt.Errorf("failed to create subject access review: %w", err)
+	}
+
+	if !review.Status.Allowed {
+		return validationwebhook.ValidationError{
+			Type:    CopySourceForbiddenErrorType,
+			Message: fmt.Sprintf("source package %s:%s not found", sourceNamespace, sourceName),
+		}.ExportJSONError()
+	}
+
+	return nil
 }
 
 func (v *Validator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (admission.Warnings, error) {
@@ -88,6 +150,15 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, o
 		}.ExportJSONError()
 	}
 
+	for _, annotation := range []string{v1alpha1.CFPackageCopySourceGUIDAnnotation, v1alpha1.CFPackageCopySourceNamespaceAnnotation} {
+		if newCFPackage.Annotations[annotation] != oldCFPackage.Annotations[annotation] {
+			return nil, validationwebhook.ValidationError{
+				Type:    webhooks.ImmutableFieldModificationErrorType,
+				Message: fmt.Sprintf("package %s:%s annotation %s is immutable", newCFPackage.Namespace, newCFPackage.Name, annotation),
+			}.ExportJSONError()
+		}
+	}
+
 	return nil, nil
 }
 
@@ -8,7 +8,12 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
 )
 
 var _ = Describe("CFPackage Validation", func() {
@@ -53,4 +58,117 @@ var _ = Describe("CFPackage Validation", func() {
 			})
 		})
 	})
+
+	Describe("copy source annotations immutability", func() {
+		var updateErr error
+
+		JustBeforeEach(func() {
+			updateErr = k8s.Patch(context.Background(), adminClient, cfPackage, func() {
+				cfPackage.Annotations = map[string]string{
+					korifiv1alpha1.CFPackageCopySourceGUIDAnnotation:      uuid.NewString(),
+					korifiv1alpha1.CFPackageCopySourceNamespaceAnnotation: uuid.NewString(),
+				}
+			})
+		})
+
+		It("does not allow setting the copy source on an existing package", func() {
+			Expect(updateErr).To(MatchError(ContainSubstring("is immutable")))
+		})
+	})
+
+	Describe("copying a package", func() {
+		var (
+			userName        string
+			userClient      client.Client
+			sourceNamespace string
+			sourcePackage   *korifiv1alpha1.CFPackage
+			createErr       error
+		)
+
+		grantPackageAccess := func(namespace string, verbs ...string) {
+			role := &rbacv1.Role{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      uuid.NewString(),
+				},
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{korifiv1alpha1.SchemeGroupVersion.Group},
+					Resources: []string{"cfpackages"},
+					Verbs:     verbs,
+				}},
+			}
+			Expect(adminClient.Create(ctx, role)).To(Succeed())
+
+			Expect(adminClient.Create(ctx, &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      uuid.NewString(),
+				},
+				Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: userName}},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: rbacv1.GroupName,
+					Kind:     "Role",
+					Name:     role.Name,
+				},
+			})).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			sourceNamespace = uuid.NewString()
+			Expect(adminClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: sourceNamespace,
+				},
+			})).To(Succeed())
+
+			sourcePackage = &korifiv1alpha1.CFPackage{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: sourceNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFPackageSpec{
+					Type: "docker",
+				},
+			}
+			Expect(adminClient.Create(ctx, sourcePackage)).To(Succeed())
+
+			userName = uuid.NewString()
+			user, err := testEnv.ControlPlane.AddUser(envtest.User{Name: userName}, testEnv.Config)
+			Expect(err).NotTo(HaveOccurred())
+			userClient, err = client.New(user.Config(), client.Options{Scheme: scheme.Scheme})
+			Expect(err).NotTo(HaveOccurred())
+
+			grantPackageAccess(testNamespace, "create")
+		})
+
+		JustBeforeEach(func() {
+			createErr = userClient.Create(ctx, &korifiv1alpha1.CFPackage{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      uuid.NewString(),
+					Annotations: map[string]string{
+						korifiv1alpha1.CFPackageCopySourceGUIDAnnotation:      sourcePackage.Name,
+						korifiv1alpha1.CFPackageCopySourceNamespaceAnnotation: sourceNamespace,
+					},
+				},
+				Spec: korifiv1alpha1.CFPackageSpec{
+					Type: "docker",
+				},
+			})
+		})
+
+		It("does not allow copying a package the user cannot read", func() {
+			Expect(createErr).To(MatchError(ContainSubstring("CopySourceForbiddenError")))
+		})
+
+		When("the user can read the source package", func() {
+			BeforeEach(func() {
+				grantPackageAccess(sourceNamespace, "get")
+			})
+
+			It("allows it", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+			})
+		})
+	})
 })
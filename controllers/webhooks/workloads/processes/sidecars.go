@@ -0,0 +1,34 @@
+package processes
+
+import (
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+)
+
+// validateSidecars ensures that the names of the sidecars running alongside
+// the process are unique and that the sidecars leave some of the process
+// memory to the app, as their memory is taken out of it
+func validateSidecars(process *korifiv1alpha1.CFProcess) error {
+	names := map[string]bool{}
+	for _, sidecar := range process.ProcessSidecars() {
+		if names[sidecar.Name] {
+			return invalidSidecarsError(fmt.Sprintf("Sidecar name %q is used more than once.", sidecar.Name))
+		}
+		names[sidecar.Name] = true
+	}
+
+	if sidecarsMemoryMB := process.SidecarsMemoryMB(); sidecarsMemoryMB > 0 && sidecarsMemoryMB >= process.Spec.MemoryMB {
+		return invalidSidecarsError("The requested memory allocation is not large enough to run all of your sidecar processes.")
+	}
+
+	return nil
+}
+
+func invalidSidecarsError(message string) error {
+	return validation.ValidationError{
+		Type:    InvalidSidecarsErrorType,
+		Message: message,
+	}.ExportJSONError()
+}
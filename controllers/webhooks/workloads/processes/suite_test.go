@@ -0,0 +1,152 @@
+package processes_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/processes"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	//+kubebuilder:scaffold:imports
+)
+
+var (
+	stopManager     context.CancelFunc
+	stopClientCache context.CancelFunc
+	testEnv         *envtest.Environment
+	adminClient     client.Client
+
+	ctx           context.Context
+	rootNamespace string
+	orgNamespace  string
+	testNamespace string
+)
+
+func TestWorkloadsWebhooks(t *testing.T) {
+	SetDefaultEventuallyTimeout(10 * time.Second)
+	SetDefaultEventuallyPollingInterval(250 * time.Millisecond)
+
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CFProcess Webhooks Integration Test Suite")
+}
+
+var _ = BeforeSuite(func() {
+	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
+
+	webhookManifestsPath := helpers.GenerateWebhookManifest(
+		"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/processes",
+	)
+	DeferCleanup(func() {
+		Expect(os.RemoveAll(filepath.Dir(webhookManifestsPath))).To(Succeed())
+	})
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "..", "..", "helm", "korifi", "controllers", "crds"),
+		},
+		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			Paths: []string{webhookManifestsPath},
+		},
+	}
+
+	adminConfig, err := testEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(adminConfig).NotTo(BeNil())
+
+	Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+
+	k8sManager := helpers.NewK8sManager(testEnv, filepath.Join("helm", "korifi", "controllers", "role.yaml"))
+
+	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
+
+	rootNamespace = uuid.NewString()
+	uncachedClient := helpers.NewUncachedClient(k8sManager.GetConfig())
+	quotaValidator := validation.NewQuotaValidator(uncachedClient, rootNamespace, korifiv1alpha1.NewCFProcessDefaulter(1024, 1024, 1, 60, 30))
+	Expect(processes.NewValidator(quotaValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
+
+	stopManager = helpers.StartK8sManager(k8sManager)
+
+	Expect(adminClient.Create(context.Background(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: rootNamespace,
+		},
+	})).To(Succeed())
+})
+
+var _ = BeforeEach(func() {
+	ctx = context.Background()
+
+	orgNamespace = uuid.NewString()
+	Expect(adminClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: orgNamespace,
+		},
+	})).To(Succeed())
+
+	testNamespace = createSpace(orgNamespace)
+})
+
+var _ = AfterSuite(func() {
+	stopClientCache()
+	stopManager()
+	Expect(testEnv.Stop()).To(Succeed())
+})
+
+func createSpace(orgGUID string) string {
+	spaceGUID := uuid.NewString()
+
+	Expect(adminClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: spaceGUID,
+			Labels: map[string]string{
+				korifiv1alpha1.OrgGUIDKey: orgGUID,
+			},
+		},
+	})).To(Succeed())
+
+	Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      spaceGUID,
+			Namespace: orgGUID,
+		},
+		Spec: korifiv1alpha1.CFSpaceSpec{
+			DisplayName: uuid.NewString(),
+		},
+	})).To(Succeed())
+
+	return spaceGUID
+}
+
+func createApp(namespace string, desiredState korifiv1alpha1.AppState) *korifiv1alpha1.CFApp {
+	app := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFAppSpec{
+			DisplayName:  uuid.NewString(),
+			DesiredState: desiredState,
+			Lifecycle: korifiv1alpha1.Lifecycle{
+				Type: "buildpack",
+			},
+		},
+	}
+	Expect(adminClient.Create(ctx, app)).To(Succeed())
+
+	return app
+}
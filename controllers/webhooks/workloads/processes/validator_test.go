@@ -0,0 +1,365 @@
+package processes_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFProcess Validation", func() {
+	var (
+		existingApp *korifiv1alpha1.CFApp
+		processApp  *korifiv1alpha1.CFApp
+		process     *korifiv1alpha1.CFProcess
+		createErr   error
+	)
+
+	newProcess := func(app *korifiv1alpha1.CFApp, memoryMB int64, instances int32) *korifiv1alpha1.CFProcess {
+		return &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: app.Namespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:           corev1.LocalObjectReference{Name: app.Name},
+				ProcessType:      "web",
+				MemoryMB:         memoryMB,
+				DiskQuotaMB:      1024,
+				DesiredInstances: tools.PtrTo(instances),
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		existingApp = createApp(testNamespace, korifiv1alpha1.StartedState)
+		Expect(adminClient.Create(ctx, newProcess(existingApp, 256, 2))).To(Succeed())
+
+		processApp = createApp(testNamespace, korifiv1alpha1.StartedState)
+		process = newProcess(processApp, 512, 1)
+	})
+
+	JustBeforeEach(func() {
+		createErr = adminClient.Create(ctx, process)
+	})
+
+	It("succeeds when there are no quotas", func() {
+		Expect(createErr).NotTo(HaveOccurred())
+	})
+
+	When("the process declares sidecars", func() {
+		BeforeEach(func() {
+			process.Spec.Sidecars = []korifiv1alpha1.Sidecar{
+				{Name: "log-forwarder", Command: "forward-logs", ProcessTypes: []string{"web"}, MemoryMB: 128},
+				{Name: "worker-helper", Command: "help-workers", ProcessTypes: []string{"worker"}, MemoryMB: 1024},
+			}
+		})
+
+		It("only accounts for the sidecars running alongside the process", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+		})
+
+		When("the sidecars use all the process memory", func() {
+			BeforeEach(func() {
+				process.Spec.Sidecars[0].MemoryMB = 512
+			})
+
+			It("fails", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("The requested memory allocation is not large enough to run all of your sidecar processes.")))
+			})
+		})
+
+		When("sidecar names are not unique", func() {
+			BeforeEach(func() {
+				process.Spec.Sidecars[1].Name = "log-forwarder"
+				process.Spec.Sidecars[1].ProcessTypes = []string{"web"}
+				process.Spec.Sidecars[1].MemoryMB = 128
+			})
+
+			It("fails", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("log-forwarder")))
+				Expect(createErr).To(MatchError(ContainSubstring("is used more than once")))
+			})
+		})
+
+		When("the process is scaled down below the sidecars memory", func() {
+			var scaleErr error
+
+			JustBeforeEach(func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				scaleErr = k8s.Patch(ctx, adminClient, process, func() {
+					process.Spec.MemoryMB = 128
+				})
+			})
+
+			It("fails", func() {
+				Expect(scaleErr).To(MatchError(ContainSubstring("not large enough to run all of your sidecar processes")))
+			})
+		})
+	})
+
+	When("the process declares ephemeral volumes", func() {
+		BeforeEach(func() {
+			process.Spec.EphemeralVolumes = []korifiv1alpha1.EphemeralVolume{
+				{Name: "cache", MountPath: "/tmp/cache", SizeLimitMB: 64},
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+		})
+
+		When("a volume is mounted at a system path", func() {
+			BeforeEach(func() {
+				process.Spec.EphemeralVolumes[0].MountPath = "/workspace/cache"
+			})
+
+			It("fails", func() {
+				Expect(createErr).To(MatchError(ContainSubstring(`collides with system path "/workspace"`)))
+			})
+		})
+
+		When("a volume uses the name of the VCAP services volume", func() {
+			BeforeEach(func() {
+				process.Spec.EphemeralVolumes[0].Name = korifiv1alpha1.VCAPServicesVolumeName
+			})
+
+			It("fails", func() {
+				Expect(createErr).To(MatchError(ContainSubstring(`volume name "vcap-services" is reserved`)))
+			})
+		})
+
+		When("a volume uses the prefix of the service binding volumes", func() {
+			BeforeEach(func() {
+				process.Spec.EphemeralVolumes[0].Name = "service-cache"
+			})
+
+			It("fails", func() {
+				Expect(createErr).To(MatchError(ContainSubstring(`volume name "service-cache" is reserved`)))
+			})
+		})
+
+		When("volume names are not unique", func() {
+			BeforeEach(func() {
+				process.Spec.EphemeralVolumes = append(process.Spec.EphemeralVolumes,
+					korifiv1alpha1.EphemeralVolume{Name: "cache", MountPath: "/tmp/other", SizeLimitMB: 64},
+				)
+			})
+
+			It("fails", func() {
+				Expect(createErr).To(MatchError(ContainSubstring(`volume name "cache" is used more than once`)))
+			})
+		})
+	})
+
+	When("the space has a quota", func() {
+		var spaceQuota *korifiv1alpha1.CFSpaceQuota
+
+		BeforeEach(func() {
+			spaceQuota = &korifiv1alpha1.CFSpaceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: orgNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+					DisplayName: "my-space-quota",
+					Apps: korifiv1alpha1.AppsQuota{
+						TotalMemoryMB:      tools.PtrTo[int64](1024),
+						PerProcessMemoryMB: tools.PtrTo[int64](512),
+						TotalInstances:     tools.PtrTo[int32](3),
+					},
+					Spaces: []string{testNamespace},
+				},
+			}
+			Expect(adminClient.Create(ctx, spaceQuota)).To(Succeed())
+		})
+
+		patchQuota := func(setLimits func(apps *korifiv1alpha1.AppsQuota)) {
+			Expect(k8s.Patch(ctx, adminClient, spaceQuota, func() {
+				setLimits(&spaceQuota.Spec.Apps)
+			})).To(Succeed())
+		}
+
+		It("allows processes within the quota", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+		})
+
+		When("the total memory would be exceeded", func() {
+			BeforeEach(func() {
+				patchQuota(func(apps *korifiv1alpha1.AppsQuota) {
+					apps.TotalMemoryMB = tools.PtrTo[int64](1000)
+				})
+			})
+
+			It("fails naming the memory quota", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("Memory quota exceeded: space quota 'my-space-quota' allows 1000 MB in total, 488 MB remaining, requested 512 MB")))
+			})
+		})
+
+		When("the process memory is above the per process limit", func() {
+			BeforeEach(func() {
+				patchQuota(func(apps *korifiv1alpha1.AppsQuota) {
+					apps.PerProcessMemoryMB = tools.PtrTo[int64](511)
+				})
+			})
+
+			It("fails naming the per process memory limit", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("Memory quota exceeded: space quota 'my-space-quota' allows at most 511 MB per process instance, requested 512 MB")))
+			})
+		})
+
+		When("the total instances would be exceeded", func() {
+			BeforeEach(func() {
+				patchQuota(func(apps *korifiv1alpha1.AppsQuota) {
+					apps.TotalInstances = tools.PtrTo[int32](2)
+				})
+			})
+
+			It("fails naming the instance quota", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("Instance quota exceeded: space quota 'my-space-quota' allows 2 instances in total, 0 remaining, requested 1")))
+			})
+		})
+
+		When("the app of the process is stopped", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, processApp, func() {
+					processApp.Spec.DesiredState = korifiv1alpha1.StoppedState
+				})).To(Succeed())
+
+				patchQuota(func(apps *korifiv1alpha1.AppsQuota) {
+					apps.TotalInstances = tools.PtrTo[int32](0)
+				})
+			})
+
+			It("does not check the process, as it is checked when the app is started", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the other app is stopped", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, existingApp, func() {
+					existingApp.Spec.DesiredState = korifiv1alpha1.StoppedState
+				})).To(Succeed())
+
+				patchQuota(func(apps *korifiv1alpha1.AppsQuota) {
+					apps.TotalMemoryMB = tools.PtrTo[int64](600)
+					apps.TotalInstances = tools.PtrTo[int32](1)
+				})
+			})
+
+			It("does not count its processes", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the quota is applied to another space", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, spaceQuota, func() {
+					spaceQuota.Spec.Apps.TotalInstances = tools.PtrTo[int32](0)
+					spaceQuota.Spec.Spaces = []string{createSpace(orgNamespace)}
+				})).To(Succeed())
+			})
+
+			It("does not apply to the process", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+			})
+		})
+
+		Describe("scaling", func() {
+			var scaleErr error
+
+			When("scaling up beyond the quota", func() {
+				JustBeforeEach(func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					scaleErr = k8s.Patch(ctx, adminClient, process, func() {
+						process.Spec.DesiredInstances = tools.PtrTo[int32](2)
+					})
+				})
+
+				It("fails", func() {
+					Expect(scaleErr).To(MatchError(ContainSubstring("Memory quota exceeded")))
+				})
+			})
+
+			When("scaling into a nearly full quota", func() {
+				JustBeforeEach(func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					patchQuota(func(apps *korifiv1alpha1.AppsQuota) {
+						apps.TotalMemoryMB = tools.PtrTo[int64](1200)
+					})
+
+					scaleErr = k8s.Patch(ctx, adminClient, process, func() {
+						process.Spec.MemoryMB = 400
+						process.Spec.DesiredInstances = tools.PtrTo[int32](2)
+					})
+				})
+
+				It("fails naming what remains of the quota", func() {
+					Expect(scaleErr).To(MatchError(ContainSubstring("Memory quota exceeded: space quota 'my-space-quota' allows 1200 MB in total, 688 MB remaining, requested 800 MB")))
+				})
+			})
+
+			When("scaling down while the quota is exceeded", func() {
+				JustBeforeEach(func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					patchQuota(func(apps *korifiv1alpha1.AppsQuota) {
+						apps.TotalMemoryMB = tools.PtrTo[int64](100)
+					})
+
+					scaleErr = k8s.Patch(ctx, adminClient, process, func() {
+						process.Spec.DesiredInstances = tools.PtrTo[int32](0)
+					})
+				})
+
+				It("succeeds", func() {
+					Expect(scaleErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+	})
+
+	When("the org has a quota", func() {
+		var orgQuota *korifiv1alpha1.CFOrgQuota
+
+		BeforeEach(func() {
+			otherSpace := createSpace(orgNamespace)
+			Expect(adminClient.Create(ctx, newProcess(createApp(otherSpace, korifiv1alpha1.StartedState), 512, 1))).To(Succeed())
+
+			orgQuota = &korifiv1alpha1.CFOrgQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFOrgQuotaSpec{
+					DisplayName: "my-org-quota",
+					Apps: korifiv1alpha1.AppsQuota{
+						TotalMemoryMB: tools.PtrTo[int64](1024),
+					},
+					Orgs: []string{orgNamespace},
+				},
+			}
+			Expect(adminClient.Create(ctx, orgQuota)).To(Succeed())
+		})
+
+		It("sums the usage across the spaces of the org", func() {
+			Expect(createErr).To(MatchError(ContainSubstring("Memory quota exceeded: organization quota 'my-org-quota' allows 1024 MB in total, 0 MB remaining, requested 512 MB")))
+		})
+
+		When("the quota is applied to another org", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, orgQuota, func() {
+					orgQuota.Spec.Orgs = []string{uuid.NewString()}
+				})).To(Succeed())
+			})
+
+			It("does not apply to the process", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
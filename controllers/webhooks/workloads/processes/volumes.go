@@ -0,0 +1,20 @@
+package processes
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+)
+
+// validateVolumes checks the ephemeral volumes of the process. The volumes
+// of the app service bindings are checked against them by the process
+// controller, as they can change without the process being updated.
+func validateVolumes(process *korifiv1alpha1.CFProcess) error {
+	if err := process.ValidateVolumes(nil); err != nil {
+		return validation.ValidationError{
+			Type:    InvalidVolumesErrorType,
+			Message: err.Error(),
+		}.ExportJSONError()
+	}
+
+	return nil
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -55,6 +57,11 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		return nil, errors.New("space name cannot be longer than 63 chars")
 	}
 
+	if err := validation.ValidateDefaultAppAnnotations(space.Spec.DefaultAppAnnotations); err != nil {
+		spaceLogger.Info(err.Error())
+		return nil, err
+	}
+
 	err := v.duplicateValidator.ValidateCreate(ctx, spaceLogger, space.Namespace, space)
 	if err != nil {
 		return nil, err
@@ -78,6 +85,13 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Obje
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFSpace but got a %T", obj))
 	}
 
+	if !maps.Equal(oldSpace.Spec.DefaultAppAnnotations, space.Spec.DefaultAppAnnotations) {
+		if err := validation.ValidateDefaultAppAnnotations(space.Spec.DefaultAppAnnotations); err != nil {
+			spaceLogger.Info(err.Error())
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateUpdate(ctx, spaceLogger, oldSpace.Namespace, oldSpace, space)
 }
 
@@ -84,6 +84,16 @@ var _ = Describe("CFSpaceValidatingWebhook", func() {
 			})
 		})
 
+		When("the default app annotations use a reserved domain", func() {
+			BeforeEach(func() {
+				cfSpace.Spec.DefaultAppAnnotations = map[string]string{"k8s.io/foo": "bar"}
+			})
+
+			It("fails", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("uses the reserved domain")))
+			})
+		})
+
 		When("the name already exists in the org namespace", func() {
 			BeforeEach(func() {
 				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpace{
@@ -153,6 +163,37 @@ var _ = Describe("CFSpaceValidatingWebhook", func() {
 		})
 	})
 
+	Describe("changing the default app annotations", func() {
+		var (
+			defaultAppAnnotations map[string]string
+			updateErr             error
+		)
+
+		BeforeEach(func() {
+			defaultAppAnnotations = map[string]string{"example.com/cost-center": "42"}
+		})
+
+		JustBeforeEach(func() {
+			updateErr = k8s.Patch(ctx, adminClient, cfSpace, func() {
+				cfSpace.Spec.DefaultAppAnnotations = defaultAppAnnotations
+			})
+		})
+
+		It("succeeds", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+		})
+
+		When("the default app annotations use a reserved domain", func() {
+			BeforeEach(func() {
+				defaultAppAnnotations = map[string]string{"cloudfoundry.org/propagate-cf-role": "true"}
+			})
+
+			It("fails", func() {
+				Expect(updateErr).To(MatchError(ContainSubstring("uses the reserved domain")))
+			})
+		})
+	})
+
 	Describe("deleting a space", func() {
 		It("can delete the space", func() {
 			Expect(adminNonSyncClient.Delete(ctx, cfSpace)).To(Succeed())
@@ -71,7 +71,14 @@ func (d *Defaulter) Default(ctx context.Context, obj runtime.Object) error {
 	cfTask.Status.SequenceID = seqId
 
 	cfTask.Status.MemoryMB = d.cfProcessDefaults.MemoryMB
+	if cfTask.Spec.MemoryMB != 0 {
+		cfTask.Status.MemoryMB = cfTask.Spec.MemoryMB
+	}
+
 	cfTask.Status.DiskQuotaMB = d.cfProcessDefaults.DiskQuotaMB
+	if cfTask.Spec.DiskQuotaMB != 0 {
+		cfTask.Status.DiskQuotaMB = cfTask.Spec.DiskQuotaMB
+	}
 
 	return nil
 }
@@ -56,6 +56,18 @@ var _ = Describe("CFTaskMutatingWebhook", func() {
 		Expect(cfTask.Status.DiskQuotaMB).To(BeNumerically("==", 512))
 	})
 
+	When("the task requests memory and disk", func() {
+		BeforeEach(func() {
+			cfTask.Spec.MemoryMB = 256
+			cfTask.Spec.DiskQuotaMB = 1024
+		})
+
+		It("uses the requested values instead of the defaults", func() {
+			Expect(cfTask.Status.MemoryMB).To(BeNumerically("==", 256))
+			Expect(cfTask.Status.DiskQuotaMB).To(BeNumerically("==", 1024))
+		})
+	})
+
 	Describe("subsequent updates", func() {
 		var (
 			updateTaskFunc func()
@@ -29,8 +29,10 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/kpack-image-builder/controllers/config"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/image"
 	"code.cloudfoundry.org/korifi/tools/k8s"
+	"code.cloudfoundry.org/korifi/tools/registry"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
@@ -60,6 +62,11 @@ const (
 	ImageGenerationKey          = "korifi.cloudfoundry.org/kpack-image-generation"
 	KpackReconcilerName         = "kpack-image-builder"
 	buildpackBuildMetadataLabel = "io.buildpacks.build.metadata"
+
+	// DropletRepositoryAnnotation records the repository the droplet of a
+	// build workload is pushed to. It is resolved once, so that changing the
+	// repository path template does not affect builds in flight.
+	DropletRepositoryAnnotation = "korifi.cloudfoundry.org/droplet-repository"
 )
 
 //counterfeiter:generate -o fake -fake-name ImageConfigGetter . ImageConfigGetter
@@ -81,26 +88,29 @@ func NewBuildWorkloadReconciler(
 	config *config.Config,
 	imageConfigGetter ImageConfigGetter,
 	imageRepoCreator RepositoryCreator,
+	repositoryRefBuilder *registry.RepositoryRefBuilder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.BuildWorkload, *korifiv1alpha1.BuildWorkload] {
 	buildWorkloadReconciler := BuildWorkloadReconciler{
-		k8sClient:         c,
-		scheme:            scheme,
-		log:               log,
-		controllerConfig:  config,
-		imageConfigGetter: imageConfigGetter,
-		imageRepoCreator:  imageRepoCreator,
+		k8sClient:            c,
+		scheme:               scheme,
+		log:                  log,
+		controllerConfig:     config,
+		imageConfigGetter:    imageConfigGetter,
+		imageRepoCreator:     imageRepoCreator,
+		repositoryRefBuilder: repositoryRefBuilder,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.BuildWorkload, *korifiv1alpha1.BuildWorkload](log, c, &buildWorkloadReconciler)
 }
 
 // BuildWorkloadReconciler reconciles a BuildWorkload object
 type BuildWorkloadReconciler struct {
-	k8sClient         client.Client
-	scheme            *runtime.Scheme
-	log               logr.Logger
-	controllerConfig  *config.Config
-	imageConfigGetter ImageConfigGetter
-	imageRepoCreator  RepositoryCreator
+	k8sClient            client.Client
+	scheme               *runtime.Scheme
+	log                  logr.Logger
+	controllerConfig     *config.Config
+	imageConfigGetter    ImageConfigGetter
+	imageRepoCreator     RepositoryCreator
+	repositoryRefBuilder *registry.RepositoryRefBuilder
 }
 
 func (r *BuildWorkloadReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
@@ -161,6 +171,7 @@ func filterBuildWorkloads(object client.Object) bool {
 
 //+kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=list;watch
 
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=serviceaccounts;secrets,verbs=get;list;watch;patch
 //+kubebuilder:rbac:groups="",resources=serviceaccounts/status;secrets/status,verbs=get
 
@@ -243,11 +254,12 @@ func (r *BuildWorkloadReconciler) ReconcileResource(ctx context.Context, buildWo
 
 	latestBuildSuccessful := latestBuild.Status.GetCondition(corev1alpha1.ConditionSucceeded)
 	if latestBuildSuccessful.IsFalse() {
+		reason, message := buildFailure(latestBuild)
 		meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
 			Type:               korifiv1alpha1.SucceededConditionType,
 			Status:             metav1.ConditionFalse,
-			Reason:             "BuildFailed",
-			Message:            "Check build log output",
+			Reason:             reason,
+			Message:            message,
 			ObservedGeneration: buildWorkload.Generation,
 		})
 	} else if latestBuildSuccessful.IsTrue() {
@@ -279,6 +291,26 @@ func (r *BuildWorkloadReconciler) ReconcileResource(ctx context.Context, buildWo
 	return ctrl.Result{}, nil
 }
 
+// buildFailure returns the reason and message of a failed kpack build based on
+// the first build step that exited with a non-zero code
+func buildFailure(kpackBuild *buildv1alpha2.Build) (string, string) {
+	for i, stepState := range kpackBuild.Status.StepStates {
+		if stepState.Terminated == nil || stepState.Terminated.ExitCode == 0 || i >= len(kpackBuild.Status.StepsCompleted) {
+			continue
+		}
+
+		step := kpackBuild.Status.StepsCompleted[i]
+		reason := "BuildFailed"
+		if step == "detect" {
+			reason = "DetectionFailed"
+		}
+
+		return reason, fmt.Sprintf("step %q exited with code %d, check build log output", step, stepState.Terminated.ExitCode)
+	}
+
+	return "BuildFailed", "Check build log output"
+}
+
 func (r *BuildWorkloadReconciler) recoverIfBuildCreationHasBeenSkipped(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload, kpackImage *buildv1alpha2.Image) error {
 	workloadImageGeneration, err := strconv.ParseInt(buildWorkload.Labels[ImageGenerationKey], 10, 64)
 	if err != nil {
@@ -642,7 +674,12 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 ) error {
 	appGUID := buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey]
 	kpackImageNamespace := buildWorkload.Namespace
-	kpackImageTag := r.repositoryRef(appGUID)
+	kpackImageTag, err := r.repositoryRef(ctx, buildWorkload)
+	if err != nil {
+		log.Info("failed to build image repository ref", "reason", err)
+		return err
+	}
+
 	desiredKpackImage := buildv1alpha2.Image{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      appGUID,
@@ -650,7 +687,7 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 		},
 	}
 
-	if err := r.imageRepoCreator.CreateRepository(ctx, r.repositoryRef(appGUID)); err != nil {
+	if err = r.imageRepoCreator.CreateRepository(ctx, kpackImageTag); err != nil {
 		log.Info("failed to create image repository", "reason", err)
 		return err
 	}
@@ -923,6 +960,25 @@ func (r *BuildWorkloadReconciler) hasRemainingBuilds(ctx context.Context, buildW
 	return len(buildList.Items) != 0, nil
 }
 
-func (r *BuildWorkloadReconciler) repositoryRef(appGUID string) string {
-	return r.controllerConfig.ContainerRepositoryPrefix + appGUID + "-droplets"
+func (r *BuildWorkloadReconciler) repositoryRef(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload) (string, error) {
+	if repositoryRef, ok := buildWorkload.Annotations[DropletRepositoryAnnotation]; ok {
+		return repositoryRef, nil
+	}
+
+	spaceNamespace := new(corev1.Namespace)
+	if err := r.k8sClient.Get(ctx, client.ObjectKey{Name: buildWorkload.Namespace}, spaceNamespace); err != nil {
+		return "", fmt.Errorf("failed to get namespace %q: %w", buildWorkload.Namespace, err)
+	}
+
+	repositoryRef, err := r.repositoryRefBuilder.Build(registry.RepositoryPathValues{
+		OrgGUID:   spaceNamespace.Labels[korifiv1alpha1.OrgGUIDKey],
+		SpaceGUID: buildWorkload.Namespace,
+		AppGUID:   buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey],
+	}, "-droplets")
+	if err != nil {
+		return "", err
+	}
+
+	buildWorkload.Annotations = tools.SetMapValue(buildWorkload.Annotations, DropletRepositoryAnnotation, repositoryRef)
+	return repositoryRef, nil
 }
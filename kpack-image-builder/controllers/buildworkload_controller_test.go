@@ -53,7 +53,12 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 		expectedCacheVolumeSize = "1024Mi"
 		reconcilerName = "kpack-image-builder"
 		namespaceGUID = PrefixedGUID("namespace")
-		Expect(adminClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespaceGUID}})).To(Succeed())
+		Expect(adminClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: namespaceGUID,
+			Labels: map[string]string{
+				korifiv1alpha1.OrgGUIDKey: "org-guid",
+			},
+		}})).To(Succeed())
 
 		dockerRegistrySecret := buildDockerRegistrySecret(wellFormedRegistryCredentialsSecret, namespaceGUID)
 		Expect(adminClient.Create(ctx, dockerRegistrySecret)).To(Succeed())
@@ -191,8 +196,15 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 	})
 
 	Describe("BuildWorkload initialization phase", func() {
+		var buildWorkloadAnnotations map[string]string
+
+		BeforeEach(func() {
+			buildWorkloadAnnotations = nil
+		})
+
 		JustBeforeEach(func() {
 			buildWorkload = buildWorkloadObject(buildWorkloadGUID, namespaceGUID, source, env, services, reconcilerName, buildpacks)
+			buildWorkload.Annotations = buildWorkloadAnnotations
 			Expect(adminClient.Create(ctx, buildWorkload)).To(Succeed())
 		})
 
@@ -231,7 +243,17 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 				Eventually(func(g Gomega) {
 					g.Expect(imageRepoCreator.CreateRepositoryCallCount()).To(BeNumerically(">", imageRepoCreatorCallCount))
 					_, repoName := imageRepoCreator.CreateRepositoryArgsForCall(imageRepoCreatorCallCount)
-					g.Expect(repoName).To(Equal("my.repository/my-prefix/app-guid-droplets"))
+					g.Expect(repoName).To(Equal("my.repository/my-prefix/org-guid/" + namespaceGUID + "/app-guid-droplets"))
+				}).Should(Succeed())
+			})
+
+			It("records the droplet repository on the BuildWorkload", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), buildWorkload)).To(Succeed())
+					g.Expect(buildWorkload.Annotations).To(HaveKeyWithValue(
+						controllers.DropletRepositoryAnnotation,
+						"my.repository/my-prefix/org-guid/"+namespaceGUID+"/app-guid-droplets",
+					))
 				}).Should(Succeed())
 			})
 		}
@@ -240,6 +262,22 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 			ItDoesInitialReconciliationWithDefaultBuilder()
 		})
 
+		When("the droplet repository has been recorded on the BuildWorkload", func() {
+			BeforeEach(func() {
+				buildWorkloadAnnotations = map[string]string{
+					controllers.DropletRepositoryAnnotation: "my.repository/my-prefix/old-path-droplets",
+				}
+			})
+
+			It("uses the recorded repository rather than the current path template", func() {
+				Eventually(func(g Gomega) {
+					kpackImage := new(buildv1alpha2.Image)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: appGUID, Namespace: namespaceGUID}, kpackImage)).To(Succeed())
+					g.Expect(kpackImage.Spec.Tag).To(Equal("my.repository/my-prefix/old-path-droplets"))
+				}).Should(Succeed())
+			})
+		})
+
 		When("a kpack.Image already exists for the BuildWorkload", func() {
 			BeforeEach(func() {
 				Expect(adminClient.Create(ctx, &buildv1alpha2.Image{
@@ -619,8 +657,34 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 					g.Expect(err).NotTo(HaveOccurred())
 					g.Expect(mustHaveCondition(g, updatedWorkload.Status.Conditions, "Succeeded").Status).To(Equal(metav1.ConditionFalse))
 					g.Expect(mustHaveCondition(g, updatedWorkload.Status.Conditions, "Succeeded").Reason).To(Equal("BuildFailed"))
+					g.Expect(mustHaveCondition(g, updatedWorkload.Status.Conditions, "Succeeded").Message).To(Equal("Check build log output"))
 				}).Should(Succeed())
 			})
+
+			When("the buildpack detection step exits with a non-zero code", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, adminClient, build1, func() {
+						build1.Status.StepsCompleted = []string{"prepare", "analyze", "detect"}
+						build1.Status.StepStates = []corev1.ContainerState{
+							{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+							{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+							{Terminated: &corev1.ContainerStateTerminated{ExitCode: 20}},
+						}
+					})).To(Succeed())
+				})
+
+				It("fails the build workload with the detection failure", func() {
+					lookupKey := types.NamespacedName{Name: buildWorkloadGUID, Namespace: namespaceGUID}
+					updatedWorkload := new(korifiv1alpha1.BuildWorkload)
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, lookupKey, updatedWorkload)).To(Succeed())
+						succeededCondition := mustHaveCondition(g, updatedWorkload.Status.Conditions, "Succeeded")
+						g.Expect(succeededCondition.Status).To(Equal(metav1.ConditionFalse))
+						g.Expect(succeededCondition.Reason).To(Equal("DetectionFailed"))
+						g.Expect(succeededCondition.Message).To(Equal(`step "detect" exited with code 20, check build log output`))
+					}).Should(Succeed())
+				})
+			})
 		})
 
 		When("the kpack.Build succeeded", func() {
@@ -7,11 +7,12 @@ import (
 )
 
 type Config struct {
-	CFRootNamespace           string                               `yaml:"cfRootNamespace"`
-	CFStagingResources        controllersconfig.CFStagingResources `yaml:"cfStagingResources"`
-	ClusterBuilderName        string                               `yaml:"clusterBuilderName"`
-	BuilderServiceAccount     string                               `yaml:"builderServiceAccount"`
-	BuilderReadinessTimeout   time.Duration                        `yaml:"builderReadinessTimeout"`
-	ContainerRepositoryPrefix string                               `yaml:"containerRepositoryPrefix"`
-	ContainerRegistryType     string                               `yaml:"containerRegistryType"`
+	CFRootNamespace                 string                               `yaml:"cfRootNamespace"`
+	CFStagingResources              controllersconfig.CFStagingResources `yaml:"cfStagingResources"`
+	ClusterBuilderName              string                               `yaml:"clusterBuilderName"`
+	BuilderServiceAccount           string                               `yaml:"builderServiceAccount"`
+	BuilderReadinessTimeout         time.Duration                        `yaml:"builderReadinessTimeout"`
+	ContainerRepositoryPrefix       string                               `yaml:"containerRepositoryPrefix"`
+	ContainerRepositoryPathTemplate string                               `yaml:"containerRepositoryPathTemplate"`
+	ContainerRegistryType           string                               `yaml:"containerRegistryType"`
 }
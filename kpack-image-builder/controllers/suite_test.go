@@ -30,6 +30,7 @@ import (
 	"code.cloudfoundry.org/korifi/tests/helpers"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
+	"code.cloudfoundry.org/korifi/tools/registry"
 
 	controllersconfig "code.cloudfoundry.org/korifi/controllers/config"
 	. "github.com/onsi/ginkgo/v2"
@@ -121,6 +122,8 @@ var _ = BeforeSuite(func() {
 
 	imageRepoCreator = new(fake.RepositoryCreator)
 	fakeImageConfigGetter = new(fake.ImageConfigGetter)
+	repositoryRefBuilder, err := registry.NewRepositoryRefBuilder(controllerConfig.ContainerRepositoryPrefix, "{{.OrgGUID}}/{{.SpaceGUID}}/{{.AppGUID}}")
+	Expect(err).NotTo(HaveOccurred())
 	buildWorkloadReconciler = controllers.NewBuildWorkloadReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
@@ -128,6 +131,7 @@ var _ = BeforeSuite(func() {
 		controllerConfig,
 		fakeImageConfigGetter,
 		imageRepoCreator,
+		repositoryRefBuilder,
 	)
 	err = buildWorkloadReconciler.SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
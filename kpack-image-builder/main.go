@@ -112,6 +112,11 @@ func setupControllers(mgr manager.Manager, restConf *rest.Config, configPath str
 		return fmt.Errorf("config could not be read: %v", err)
 	}
 
+	repositoryRefBuilder, err := registry.NewRepositoryRefBuilder(controllerConfig.ContainerRepositoryPrefix, controllerConfig.ContainerRepositoryPathTemplate)
+	if err != nil {
+		return fmt.Errorf("could not create container repository ref builder: %v", err)
+	}
+
 	imageClient := image.NewClient(imageClientSet)
 	if err = controllers.NewBuildWorkloadReconciler(
 		mgr.GetClient(),
@@ -120,6 +125,7 @@ func setupControllers(mgr manager.Manager, restConf *rest.Config, configPath str
 		controllerConfig,
 		imageClient,
 		registry.NewRepositoryCreator(controllerConfig.ContainerRegistryType),
+		repositoryRefBuilder,
 	).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create BuildWorkload controller: %v", err)
 	}
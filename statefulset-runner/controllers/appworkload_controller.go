@@ -43,6 +43,12 @@ const (
 	EnvCFInstanceGUID       = "CF_INSTANCE_GUID"
 	EnvCFInstanceInternalIP = "CF_INSTANCE_INTERNAL_IP"
 	EnvCFInstanceIndex      = "CF_INSTANCE_INDEX"
+	EnvVCAPServicesFilePath = "VCAP_SERVICES_FILE_PATH"
+
+	// The VCAP_SERVICES file is projected at the same location as on CF
+	VCAPServicesVolumeName = korifiv1alpha1.VCAPServicesVolumeName
+	VCAPServicesMountPath  = "/etc/cf-service-bindings"
+	VCAPServicesFileName   = "vcap_services"
 
 	// StatefulSet Keys
 	AnnotationVersion     = "korifi.cloudfoundry.org/version"
@@ -55,9 +61,10 @@ const (
 	LabelAppWorkloadGUID = "korifi.cloudfoundry.org/appworkload-guid"
 	LabelProcessType     = "korifi.cloudfoundry.org/process-type"
 
-	ApplicationContainerName  = "application"
-	AppWorkloadReconcilerName = "statefulset-runner"
-	ServiceAccountName        = "korifi-app"
+	ApplicationContainerName   = "application"
+	SidecarContainerNamePrefix = "sidecar-"
+	AppWorkloadReconcilerName  = "statefulset-runner"
+	ServiceAccountName         = "korifi-app"
 
 	LivenessFailureThreshold  = 4
 	ReadinessFailureThreshold = 1
@@ -182,6 +189,23 @@ func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorklo
 	}
 
 	appWorkload.Status.ActualInstances = createdStSet.Status.Replicas
+	appWorkload.Status.ReadyInstances = updatedReadyReplicas(createdStSet)
 
 	return ctrl.Result{}, nil
 }
+
+// updatedReadyReplicas only counts the ready replicas once all replicas run
+// the current pod template. The rolling update replaces one pod at a time
+// and waits for it to become ready, so until then the ready replicas may
+// include pods running the previous template
+func updatedReadyReplicas(statefulSet *appsv1.StatefulSet) int32 {
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+		return 0
+	}
+
+	if statefulSet.Status.UpdatedReplicas < statefulSet.Status.Replicas {
+		return 0
+	}
+
+	return statefulSet.Status.ReadyReplicas
+}
@@ -190,6 +190,41 @@ var _ = Describe("AppWorkload Reconcile", func() {
 			Expect(updatedStSet.Spec.Replicas).To(Equal(tools.PtrTo(int32(2))))
 		})
 
+		When("all the statefulset replicas run the current pod template", func() {
+			BeforeEach(func() {
+				statefulSet.Status = v1.StatefulSetStatus{
+					Replicas:        2,
+					UpdatedReplicas: 2,
+					ReadyReplicas:   1,
+				}
+			})
+
+			It("sets the ready instances", func() {
+				_, object, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+				patchedAppWorkload, ok := object.(*korifiv1alpha1.AppWorkload)
+				Expect(ok).To(BeTrue())
+				Expect(patchedAppWorkload.Status.ActualInstances).To(BeEquivalentTo(2))
+				Expect(patchedAppWorkload.Status.ReadyInstances).To(BeEquivalentTo(1))
+			})
+		})
+
+		When("the statefulset is rolling out a new pod template", func() {
+			BeforeEach(func() {
+				statefulSet.Status = v1.StatefulSetStatus{
+					Replicas:        2,
+					UpdatedReplicas: 1,
+					ReadyReplicas:   2,
+				}
+			})
+
+			It("does not count the ready instances running the previous template", func() {
+				_, object, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+				patchedAppWorkload, ok := object.(*korifiv1alpha1.AppWorkload)
+				Expect(ok).To(BeTrue())
+				Expect(patchedAppWorkload.Status.ReadyInstances).To(BeZero())
+			})
+		})
+
 		When("updating the pod disruption budget fails", func() {
 			BeforeEach(func() {
 				fakePDB.UpdateReturns(errors.New("boom"))
@@ -4,6 +4,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"maps"
+	"path"
 	"regexp"
 	"slices"
 	"sort"
@@ -14,11 +16,15 @@ import (
 	"github.com/BooleanCat/go-functional/v2/it"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// The grace period kubernetes gives containers to exit after the SIGTERM
+const defaultTerminationGracePeriodSeconds int64 = 30
+
 type AppWorkloadToStatefulsetConverter struct {
 	scheme *runtime.Scheme
 }
@@ -93,6 +99,13 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 
 	envs = append(envs, fieldEnvs...)
 
+	if appWorkload.Spec.VCAPServicesSecretName != "" {
+		envs = append(envs, corev1.EnvVar{
+			Name:  EnvVCAPServicesFilePath,
+			Value: path.Join(VCAPServicesMountPath, VCAPServicesFileName),
+		})
+	}
+
 	// Sort env vars to guarantee idempotency
 	sort.SliceStable(envs, func(i, j int) bool {
 		return envs[i].Name < envs[j].Name
@@ -117,9 +130,10 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 					Type: corev1.SeccompProfileTypeRuntimeDefault,
 				},
 			},
-			Resources:     appWorkload.Spec.Resources,
-			StartupProbe:  appWorkload.Spec.StartupProbe,
-			LivenessProbe: appWorkload.Spec.LivenessProbe,
+			Resources:      appWorkload.Spec.Resources,
+			StartupProbe:   appWorkload.Spec.StartupProbe,
+			LivenessProbe:  appWorkload.Spec.LivenessProbe,
+			ReadinessProbe: appWorkload.Spec.ReadinessProbe,
 		},
 	}
 
@@ -153,6 +167,91 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 	}
 
 	statefulSet.Spec.Template.Spec.AutomountServiceAccountToken = tools.PtrTo(false)
+
+	for _, volume := range appWorkload.Spec.EphemeralVolumes {
+		statefulSet.Spec.Template.Spec.Volumes = append(statefulSet.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: volume.Name,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					SizeLimit: resource.NewQuantity(volume.SizeLimitMB*1024*1024, resource.BinarySI),
+				},
+			},
+		})
+		statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: volume.MountPath,
+		})
+	}
+
+	for _, volume := range appWorkload.Spec.PersistentVolumes {
+		statefulSet.Spec.Template.Spec.Volumes = append(statefulSet.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: volume.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: volume.ClaimName,
+				},
+			},
+		})
+		statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: volume.MountPath,
+		})
+	}
+
+	if appWorkload.Spec.VCAPServicesSecretName != "" {
+		// unlike env vars, the mounted secret is kept up to date as
+		// bindings are added, removed or have their credentials changed
+		statefulSet.Spec.Template.Spec.Volumes = append(statefulSet.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: VCAPServicesVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: appWorkload.Spec.VCAPServicesSecretName,
+					Items: []corev1.KeyToPath{{
+						Key:  "VCAP_SERVICES",
+						Path: VCAPServicesFileName,
+					}},
+				},
+			},
+		})
+		statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      VCAPServicesVolumeName,
+			MountPath: VCAPServicesMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	if drainTimeoutSeconds := appWorkload.Spec.ConnectionDrainTimeoutSeconds; drainTimeoutSeconds != nil && *drainTimeoutSeconds > 0 {
+		// Keep serving in-flight requests while the pod is removed from the
+		// service endpoints (and hence from the HTTPProxy upstreams) before it
+		// gets a SIGTERM
+		drainTimeout := int64(*drainTimeoutSeconds)
+		statefulSet.Spec.Template.Spec.Containers[0].Lifecycle = &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				Sleep: &corev1.SleepAction{Seconds: drainTimeout},
+			},
+		}
+		statefulSet.Spec.Template.Spec.TerminationGracePeriodSeconds = tools.PtrTo(drainTimeout + defaultTerminationGracePeriodSeconds)
+	}
+
+	// sidecars run the app image with the app environment and mounts
+	appContainer := statefulSet.Spec.Template.Spec.Containers[0]
+	for _, sidecar := range appWorkload.Spec.Sidecars {
+		memory := *resource.NewQuantity(sidecar.MemoryMB*1024*1024, resource.BinarySI)
+		statefulSet.Spec.Template.Spec.Containers = append(statefulSet.Spec.Template.Spec.Containers, corev1.Container{
+			Name:            SidecarContainerNamePrefix + sidecar.Name,
+			Image:           appContainer.Image,
+			ImagePullPolicy: appContainer.ImagePullPolicy,
+			Command:         sidecar.Command,
+			Env:             appContainer.Env,
+			VolumeMounts:    appContainer.VolumeMounts,
+			SecurityContext: appContainer.SecurityContext.DeepCopy(),
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: memory},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: memory},
+			},
+		})
+	}
+
 	statefulSet.Spec.Selector = statefulSetLabelSelector(appWorkload)
 
 	statefulSet.Spec.Template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
@@ -192,11 +291,17 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 	statefulSet.Spec.Template.Labels = labels
 	statefulSet.Labels = labels
 
-	annotations := map[string]string{
-		AnnotationAppID:       appWorkload.Spec.AppGUID,
-		AnnotationVersion:     appWorkload.Spec.Version,
-		AnnotationProcessGUID: fmt.Sprintf("%s-%s", appWorkload.Spec.GUID, appWorkload.Spec.Version),
+	// carry over the app workload annotations (e.g. the default app
+	// annotations used for cost allocation), except for the last stop
+	// revision which is already part of the statefulset name
+	annotations := maps.Clone(appWorkload.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
 	}
+	delete(annotations, korifiv1alpha1.CFAppLastStopRevisionKey)
+	annotations[AnnotationAppID] = appWorkload.Spec.AppGUID
+	annotations[AnnotationVersion] = appWorkload.Spec.Version
+	annotations[AnnotationProcessGUID] = fmt.Sprintf("%s-%s", appWorkload.Spec.GUID, appWorkload.Spec.Version)
 
 	statefulSet.Annotations = annotations
 	statefulSet.Spec.Template.Annotations = annotations
@@ -68,6 +68,16 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 					PeriodSeconds:    30,
 					FailureThreshold: 1,
 				},
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Path: "/healthz",
+							Port: intstr.IntOrString{Type: intstr.Int, IntVal: int32(8080)},
+						},
+					},
+					PeriodSeconds:    10,
+					FailureThreshold: 1,
+				},
 				Ports:      []int32{8888, 9999},
 				Instances:  1,
 				RunnerName: "statefulset-runner",
@@ -112,6 +122,21 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		Entry("Version", controllers.AnnotationVersion, "version_1234"),
 	)
 
+	It("does not carry the last stop revision over to the pods", func() {
+		Expect(statefulSet.Spec.Template.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppLastStopRevisionKey))
+	})
+
+	When("the app workload has further annotations", func() {
+		BeforeEach(func() {
+			appWorkload.Annotations["cost-center"] = "cc-1"
+		})
+
+		It("carries them over to the statefulset and its pods", func() {
+			Expect(statefulSet.Annotations).To(HaveKeyWithValue("cost-center", "cc-1"))
+			Expect(statefulSet.Spec.Template.Annotations).To(HaveKeyWithValue("cost-center", "cc-1"))
+		})
+	})
+
 	It("should be owned by the AppWorkload", func() {
 		Expect(statefulSet.OwnerReferences).To(HaveLen(1))
 		Expect(statefulSet.OwnerReferences[0].Kind).To(Equal("AppWorkload"))
@@ -186,6 +211,10 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		Expect(statefulSet.Spec.Template.Spec.Containers[0].LivenessProbe).To(Equal(appWorkload.Spec.LivenessProbe))
 	})
 
+	It("should set the readiness probe", func() {
+		Expect(statefulSet.Spec.Template.Spec.Containers[0].ReadinessProbe).To(Equal(appWorkload.Spec.ReadinessProbe))
+	})
+
 	It("should not automount service account token", func() {
 		Expect(statefulSet.Spec.Template.Spec.AutomountServiceAccountToken).To(Equal(tools.PtrTo(false)))
 	})
@@ -308,6 +337,172 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		Expect(statefulSet.Spec.Template.Spec.ServiceAccountName).To(Equal("korifi-app"))
 	})
 
+	It("should not set a preStop hook", func() {
+		Expect(statefulSet.Spec.Template.Spec.Containers[0].Lifecycle).To(BeNil())
+		Expect(statefulSet.Spec.Template.Spec.TerminationGracePeriodSeconds).To(BeNil())
+	})
+
+	When("the connection drain timeout is set", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.ConnectionDrainTimeoutSeconds = tools.PtrTo[int32](15)
+		})
+
+		It("drains connections in the preStop hook for the configured timeout", func() {
+			Expect(statefulSet.Spec.Template.Spec.Containers).To(HaveLen(1))
+			container := statefulSet.Spec.Template.Spec.Containers[0]
+			Expect(container.Lifecycle).NotTo(BeNil())
+			Expect(container.Lifecycle.PreStop).To(Equal(&corev1.LifecycleHandler{
+				Sleep: &corev1.SleepAction{Seconds: 15},
+			}))
+		})
+
+		It("extends the termination grace period by the drain timeout", func() {
+			Expect(statefulSet.Spec.Template.Spec.TerminationGracePeriodSeconds).To(Equal(tools.PtrTo[int64](45)))
+		})
+	})
+
+	When("the connection drain timeout is zero", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.ConnectionDrainTimeoutSeconds = tools.PtrTo[int32](0)
+		})
+
+		It("does not set a preStop hook", func() {
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].Lifecycle).To(BeNil())
+			Expect(statefulSet.Spec.Template.Spec.TerminationGracePeriodSeconds).To(BeNil())
+		})
+	})
+
+	It("should not set any volumes", func() {
+		Expect(statefulSet.Spec.Template.Spec.Volumes).To(BeEmpty())
+		Expect(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts).To(BeEmpty())
+	})
+
+	When("the app workload has ephemeral volumes", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.EphemeralVolumes = []korifiv1alpha1.EphemeralVolume{{
+				Name:        "scratch",
+				MountPath:   "/home/vcap/scratch",
+				SizeLimitMB: 64,
+			}}
+		})
+
+		It("adds a size limited emptyDir volume to the pod", func() {
+			Expect(statefulSet.Spec.Template.Spec.Volumes).To(ConsistOf(corev1.Volume{
+				Name: "scratch",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{
+						SizeLimit: resource.NewQuantity(64*1024*1024, resource.BinarySI),
+					},
+				},
+			}))
+		})
+
+		It("mounts the volume into the container", func() {
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+				Name:      "scratch",
+				MountPath: "/home/vcap/scratch",
+			}))
+		})
+	})
+
+	When("the app workload has sidecars", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.EphemeralVolumes = []korifiv1alpha1.EphemeralVolume{{
+				Name:        "scratch",
+				MountPath:   "/home/vcap/scratch",
+				SizeLimitMB: 64,
+			}}
+			appWorkload.Spec.Sidecars = []korifiv1alpha1.AppWorkloadSidecar{{
+				Name:     "log-forwarder",
+				Command:  []string{"/bin/sh", "-c", "forward-logs"},
+				MemoryMB: 32,
+			}}
+		})
+
+		It("runs the sidecar next to the app container", func() {
+			Expect(statefulSet.Spec.Template.Spec.Containers).To(HaveLen(2))
+			appContainer := statefulSet.Spec.Template.Spec.Containers[0]
+			sidecarContainer := statefulSet.Spec.Template.Spec.Containers[1]
+
+			Expect(sidecarContainer.Name).To(Equal("sidecar-log-forwarder"))
+			Expect(sidecarContainer.Image).To(Equal(appWorkload.Spec.Image))
+			Expect(sidecarContainer.Command).To(Equal([]string{"/bin/sh", "-c", "forward-logs"}))
+			Expect(sidecarContainer.Env).To(Equal(appContainer.Env))
+			Expect(sidecarContainer.VolumeMounts).To(Equal(appContainer.VolumeMounts))
+			Expect(sidecarContainer.SecurityContext).To(Equal(appContainer.SecurityContext))
+			Expect(sidecarContainer.Resources.Limits.Memory().String()).To(Equal("32Mi"))
+			Expect(sidecarContainer.Resources.Requests.Memory().String()).To(Equal("32Mi"))
+		})
+
+		It("does not set probes on the sidecar", func() {
+			sidecarContainer := statefulSet.Spec.Template.Spec.Containers[1]
+			Expect(sidecarContainer.StartupProbe).To(BeNil())
+			Expect(sidecarContainer.LivenessProbe).To(BeNil())
+			Expect(sidecarContainer.ReadinessProbe).To(BeNil())
+		})
+	})
+
+	When("the app workload has persistent volumes", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.PersistentVolumes = []korifiv1alpha1.PersistentVolumeMount{{
+				Name:      "service-binding-guid",
+				ClaimName: "instance-guid",
+				MountPath: "/var/vcap/data/shared",
+			}}
+		})
+
+		It("adds the claim as a volume to the pod", func() {
+			Expect(statefulSet.Spec.Template.Spec.Volumes).To(ConsistOf(corev1.Volume{
+				Name: "service-binding-guid",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "instance-guid",
+					},
+				},
+			}))
+		})
+
+		It("mounts the claim into the container", func() {
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+				Name:      "service-binding-guid",
+				MountPath: "/var/vcap/data/shared",
+			}))
+		})
+	})
+
+	When("the app workload references a VCAP_SERVICES secret", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.VCAPServicesSecretName = "app-guid-vcap-services"
+		})
+
+		It("projects the VCAP_SERVICES into a file", func() {
+			Expect(statefulSet.Spec.Template.Spec.Volumes).To(ConsistOf(corev1.Volume{
+				Name: "vcap-services",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: "app-guid-vcap-services",
+						Items: []corev1.KeyToPath{{
+							Key:  "VCAP_SERVICES",
+							Path: "vcap_services",
+						}},
+					},
+				},
+			}))
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+				Name:      "vcap-services",
+				MountPath: "/etc/cf-service-bindings",
+				ReadOnly:  true,
+			}))
+		})
+
+		It("points the app to the VCAP_SERVICES file", func() {
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+				Name:  "VCAP_SERVICES_FILE_PATH",
+				Value: "/etc/cf-service-bindings/vcap_services",
+			}))
+		})
+	})
+
 	When("the app has environment set", func() {
 		BeforeEach(func() {
 			appWorkload.Spec.Env = []corev1.EnvVar{
@@ -71,6 +71,26 @@ var _ = Describe("CFApp", func() {
 				g.Expect(process.Status.ActualInstances).To(BeEquivalentTo(1))
 			}).Should(Succeed())
 		})
+
+		When("the space has a default cost annotation", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, testSpace, func() {
+					testSpace.Spec.DefaultAppAnnotations = map[string]string{"cost-center": "cc-42"}
+				})).To(Succeed())
+			})
+
+			It("stamps it onto the app pods", func() {
+				Eventually(func(g Gomega) {
+					pods := &corev1.PodList{}
+					g.Expect(k8sClient.List(ctx, pods,
+						client.InNamespace(testSpace.Status.GUID),
+						client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: app.Name},
+					)).To(Succeed())
+					g.Expect(pods.Items).To(HaveLen(1))
+					g.Expect(pods.Items[0].Annotations).To(HaveKeyWithValue("cost-center", "cc-42"))
+				}).Should(Succeed())
+			})
+		})
 	})
 })
 
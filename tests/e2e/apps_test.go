@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 
+	"code.cloudfoundry.org/korifi/tests/helpers"
 	"code.cloudfoundry.org/korifi/tools"
 	"github.com/go-resty/resty/v2"
 	. "github.com/onsi/ginkgo/v2"
@@ -101,6 +102,17 @@ var _ = Describe("Apps", func() {
 			Expect(resp).To(HaveRestyStatusCode(http.StatusCreated))
 		})
 
+		When("an app with the same name already exists in the space", func() {
+			BeforeEach(func() {
+				createBuildpackApp(space1GUID, appRes.Name)
+			})
+
+			It("returns a uniqueness error", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+				Expect(resp).To(HaveRestyBody(ContainSubstring("CF-UniquenessError")))
+			})
+		})
+
 		When("the lifecycle is docker", func() {
 			BeforeEach(func() {
 				appRes.Lifecycle = &lifecycle{
@@ -114,11 +126,75 @@ var _ = Describe("Apps", func() {
 		})
 	})
 
+	Describe("Update an app", func() {
+		var (
+			patch  map[string]any
+			result appResource
+		)
+
+		BeforeEach(func() {
+			appGUID = createBuildpackApp(space1GUID, generateGUID("app"))
+			patch = map[string]any{
+				"name": generateGUID("renamed-app"),
+				"metadata": map[string]any{
+					"labels": map[string]any{"foo": "bar"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetBody(patch).
+				SetResult(&result).
+				Patch("/v3/apps/" + appGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the app", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.GUID).To(Equal(appGUID))
+			Expect(result.Name).To(Equal(patch["name"]))
+			Expect(result.Metadata).NotTo(BeNil())
+			Expect(result.Metadata.Labels).To(HaveKeyWithValue("foo", "bar"))
+		})
+
+		When("the name is already in use in the space", func() {
+			BeforeEach(func() {
+				otherAppName := generateGUID("other-app")
+				createBuildpackApp(space1GUID, otherAppName)
+				patch = map[string]any{"name": otherAppName}
+			})
+
+			It("returns a uniqueness error", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+				Expect(resp).To(HaveRestyBody(ContainSubstring("CF-UniquenessError")))
+			})
+		})
+
+		When("the lifecycle type is changed", func() {
+			BeforeEach(func() {
+				patch = map[string]any{
+					"lifecycle": map[string]any{
+						"type": "docker",
+						"data": map[string]any{},
+					},
+				}
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+				Expect(resp).To(HaveRestyBody(ContainSubstring("Lifecycle type cannot be changed from buildpack to docker")))
+			})
+		})
+	})
+
 	Describe("Delete an app", func() {
 		var (
 			err         error
 			pkgGUID     string
 			buildGUID   string
+			routeGUID   string
 			processType string
 		)
 
@@ -148,6 +224,10 @@ var _ = Describe("Apps", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(processResult.Type).To(Equal(processType))
 
+			domainGUID := getDomainGUID(helpers.GetRequiredEnvVar("APP_FQDN"))
+			routeGUID = createRoute(generateGUID("app"), generateGUID("/some-path"), space1GUID, domainGUID)
+			Expect(addDestinationForRoute(appGUID, routeGUID)).To(HaveLen(1))
+
 			resp, err = adminClient.R().Delete("/v3/apps/" + appGUID)
 		})
 
@@ -174,6 +254,26 @@ var _ = Describe("Apps", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp).To(HaveRestyStatusCode(http.StatusNotFound))
 		})
+
+		It("removes the app from the route destinations", func() {
+			expectJobCompletes(resp)
+
+			var destinations destinationsResource
+			resp, err = adminClient.R().SetResult(&destinations).Get("/v3/routes/" + routeGUID + "/destinations")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(destinations.Destinations).To(BeEmpty())
+		})
+	})
+
+	Describe("Delete an app that does not exist", func() {
+		It("returns a not found error", func() {
+			var err error
+			resp, err = adminClient.R().Delete("/v3/apps/" + generateGUID("no-such-app"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusNotFound))
+			Expect(resp).To(HaveRestyBody(ContainSubstring("CF-ResourceNotFound")))
+		})
 	})
 
 	Describe("Fetch an app", func() {
@@ -218,17 +318,20 @@ var _ = Describe("Apps", func() {
 	Describe("Get app process by type", func() {
 		var (
 			result      resource
+			errResp     cfErrs
 			processGUID string
+			processType string
 		)
 
 		BeforeEach(func() {
 			appGUID, _ = pushTestApp(space1GUID, defaultAppBitsFile)
 			processGUID = getProcess(appGUID, "web").GUID
+			processType = "web"
 		})
 
 		JustBeforeEach(func() {
 			var err error
-			resp, err = adminClient.R().SetResult(&result).Get("/v3/apps/" + appGUID + "/processes/web")
+			resp, err = adminClient.R().SetResult(&result).SetError(&errResp).Get("/v3/apps/" + appGUID + "/processes/" + processType)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -236,6 +339,21 @@ var _ = Describe("Apps", func() {
 			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
 			Expect(result.GUID).To(Equal(processGUID))
 		})
+
+		When("the app has no process of the requested type", func() {
+			BeforeEach(func() {
+				processType = "worker"
+			})
+
+			It("returns a not found error", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusNotFound))
+				Expect(errResp.Errors).To(ConsistOf(cfErr{
+					Detail: "Process not found. Ensure it exists and you have access to it.",
+					Title:  "CF-ResourceNotFound",
+					Code:   10010,
+				}))
+			})
+		})
 	})
 
 	Describe("Get app process stats by type", func() {
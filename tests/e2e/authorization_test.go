@@ -103,4 +103,41 @@ var _ = Describe("Authorization", func() {
 			Expect(resp).To(HaveRestyStatusCode(http.StatusForbidden))
 		})
 	})
+
+	Describe("Space Manager", func() {
+		var (
+			orgGUID   string
+			spaceGUID string
+		)
+
+		BeforeEach(func() {
+			orgGUID = createOrg(generateGUID("org"))
+			createOrgRole("organization_user", serviceAccountFactory.FullyQualifiedName(userName), orgGUID)
+
+			spaceGUID = createSpace(generateGUID("space"), orgGUID)
+			createSpaceRole("space_manager", serviceAccountFactory.FullyQualifiedName(userName), spaceGUID)
+		})
+
+		AfterEach(func() {
+			deleteOrg(orgGUID)
+		})
+
+		It("cannot create an app", func() {
+			resp, reqErr = userClient.R().SetBody(appResource{
+				resource: resource{
+					Name: "test-app",
+					Relationships: relationships{
+						"space": {
+							Data: resource{
+								GUID: spaceGUID,
+							},
+						},
+					},
+				},
+			}).Post("/v3/apps")
+			Expect(reqErr).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusForbidden))
+			Expect(resp).To(HaveRestyBody(ContainSubstring("CF-NotAuthorized")))
+		})
+	})
 })
@@ -31,4 +31,45 @@ var _ = Describe("Buildpacks", func() {
 			))
 		})
 	})
+
+	Describe("order", func() {
+		type buildpackOrder struct {
+			Buildpacks []string `json:"buildpacks"`
+		}
+
+		var order buildpackOrder
+
+		BeforeEach(func() {
+			resp, err := adminClient.R().
+				SetResult(&order).
+				Get("/v3/buildpacks/order")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+		})
+
+		It("returns the default buildpack order", func() {
+			Expect(order.Buildpacks).To(ContainElement(ContainSubstring("java")))
+		})
+
+		// other tests stage apps concurrently, so keep the order as it is
+		It("updates the default buildpack order", func() {
+			var updatedOrder buildpackOrder
+			resp, err := adminClient.R().
+				SetBody(order).
+				SetResult(&updatedOrder).
+				Put("/v3/buildpacks/order")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(updatedOrder).To(Equal(order))
+		})
+
+		It("refuses buildpacks that are not available in the builder", func() {
+			resp, err := adminClient.R().
+				SetBody(buildpackOrder{Buildpacks: append(order.Buildpacks, "not-a-buildpack")}).
+				Put("/v3/buildpacks/order")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+			Expect(resp).To(HaveRestyBody(ContainSubstring("not-a-buildpack")))
+		})
+	})
 })
@@ -50,6 +50,54 @@ var _ = Describe("Orgs", func() {
 			Expect(result.Name).To(Equal(orgName))
 			Expect(result.GUID).NotTo(BeEmpty())
 		})
+
+		When("an org with the same name already exists", func() {
+			var existingOrgGUID string
+
+			BeforeEach(func() {
+				existingOrgGUID = createOrg(orgName)
+			})
+
+			AfterEach(func() {
+				deleteOrg(existingOrgGUID)
+			})
+
+			It("returns a uniqueness error", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+				Expect(resultErr.Errors).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Title": Equal("CF-UniquenessError"),
+				})))
+			})
+		})
+	})
+
+	Describe("concurrent create", func() {
+		var org1GUID, org2GUID string
+
+		BeforeEach(func() {
+			var wg sync.WaitGroup
+			errChan := make(chan error, 2)
+			orgName := generateGUID("org")
+
+			wg.Add(2)
+			asyncCreateOrg(orgName, &org1GUID, &wg, errChan)
+			asyncCreateOrg(orgName, &org2GUID, &wg, errChan)
+			wg.Wait()
+			close(errChan)
+
+			Expect(errChan).To(HaveLen(1))
+		})
+
+		AfterEach(func() {
+			for _, id := range []string{org1GUID, org2GUID} {
+				deleteOrg(id)
+			}
+		})
+
+		It("creates only one of the orgs", func() {
+			Expect([]string{org1GUID, org2GUID}).To(ContainElement(BeEmpty()))
+			Expect([]string{org1GUID, org2GUID}).To(ContainElement(Not(BeEmpty())))
+		})
 	})
 
 	Describe("list", func() {
@@ -70,6 +118,9 @@ var _ = Describe("Orgs", func() {
 
 			org1Name = generateGUID("org1")
 			org2Name = generateGUID("org2")
+			// only look at the orgs created by the test, there might be more
+			// orgs than fit on the first page
+			query["names"] = org1Name + "," + org2Name
 
 			wg.Add(2)
 			asyncCreateOrg(org1Name, &org1GUID, &wg, errChan)
@@ -104,6 +155,53 @@ var _ = Describe("Orgs", func() {
 			))
 		})
 
+		When("filtering by guids", func() {
+			BeforeEach(func() {
+				query["guids"] = org1GUID
+			})
+
+			It("returns the matching org only", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+				Expect(result.Resources).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(org1GUID)}),
+				))
+			})
+		})
+
+		When("the client is a token user with a role in one of the orgs", func() {
+			var userName string
+
+			BeforeEach(func() {
+				userName = uuid.NewString()
+				restyClient = makeTokenClient(serviceAccountFactory.CreateServiceAccount(userName))
+				createOrgRole("organization_user", serviceAccountFactory.FullyQualifiedName(userName), org1GUID)
+			})
+
+			AfterEach(func() {
+				serviceAccountFactory.DeleteServiceAccount(userName)
+			})
+
+			It("returns only the orgs the user has a role in", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+				Expect(result.Resources).To(ContainElement(MatchFields(IgnoreExtras, Fields{"Name": Equal(org1Name)})))
+				Expect(result.Resources).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"Name": Equal(org2Name)})))
+			})
+		})
+
+		When("the client is a cert user with a role in one of the orgs", func() {
+			BeforeEach(func() {
+				userName := uuid.NewString()
+				restyClient = makeCertClientForUserName(userName, time.Hour)
+				createOrgRole("organization_user", userName, org2GUID)
+			})
+
+			It("returns only the orgs the user has a role in", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+				Expect(result.Resources).To(ContainElement(MatchFields(IgnoreExtras, Fields{"Name": Equal(org2Name)})))
+				Expect(result.Resources).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"Name": Equal(org1Name)})))
+			})
+		})
+
 		It("doesn't set an HTTP warning header for short lived certs", func() {
 			Expect(resp.Header().Get("X-Cf-Warnings")).To(BeEmpty())
 		})
@@ -2,6 +2,8 @@ package e2e_test
 
 import (
 	"net/http"
+	"os"
+	"path/filepath"
 
 	"github.com/go-resty/resty/v2"
 	. "github.com/onsi/ginkgo/v2"
@@ -128,14 +130,17 @@ var _ = Describe("Package", func() {
 	})
 
 	Describe("Upload", func() {
+		var bitsFile string
+
 		BeforeEach(func() {
 			packageGUID = createBitsPackage(appGUID)
+			bitsFile = defaultAppBitsFile
 		})
 
 		JustBeforeEach(func() {
 			var err error
 			resp, err = adminClient.R().
-				SetFile("bits", defaultAppBitsFile).
+				SetFile("bits", bitsFile).
 				SetError(&resultErr).
 				SetResult(&result).
 				Post("/v3/packages/" + packageGUID + "/upload")
@@ -145,6 +150,129 @@ var _ = Describe("Package", func() {
 		It("succeeds", func() {
 			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
 		})
+
+		When("the bits are not a zip archive", func() {
+			BeforeEach(func() {
+				bitsFile = filepath.Join(GinkgoT().TempDir(), "bits.zip")
+				Expect(os.WriteFile(bitsFile, []byte("not-a-zip"), 0o600)).To(Succeed())
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+				Expect(resultErr.Errors).To(ConsistOf(cfErr{
+					Title:  "CF-UnprocessableEntity",
+					Detail: "Bits must be a valid zip archive.",
+					Code:   10008,
+				}))
+			})
+		})
+
+		When("the bits have already been uploaded", func() {
+			BeforeEach(func() {
+				uploadTestApp(packageGUID, defaultAppBitsFile)
+			})
+
+			It("returns a bits already uploaded error", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusBadRequest))
+				Expect(resultErr.Errors).To(ConsistOf(cfErr{
+					Title:  "CF-PackageBitsAlreadyUploaded",
+					Detail: "Bits may be uploaded only once. Create a new package to upload different bits.",
+					Code:   150004,
+				}))
+			})
+		})
+
+		When("the package is a docker package", func() {
+			BeforeEach(func() {
+				dockerAppGUID := createApp(appResource{
+					resource: resource{
+						Name:          generateGUID("docker-app"),
+						Relationships: relationships{"space": {Data: resource{GUID: spaceGUID}}},
+					},
+					Lifecycle: &lifecycle{Type: "docker"},
+				})
+
+				packageGUID = createPackage(packageResource{
+					typedResource: typedResource{
+						Type: "docker",
+						resource: resource{
+							Relationships: relationships{
+								"app": relationship{Data: resource{GUID: dockerAppGUID}},
+							},
+						},
+					},
+					Data: &packageData{Image: "eirini/dorini"},
+				})
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+				Expect(resultErr.Errors).To(ConsistOf(cfErr{
+					Title:  "CF-UnprocessableEntity",
+					Detail: "Package type must be bits.",
+					Code:   10008,
+				}))
+			})
+		})
+	})
+
+	Describe("Copy", func() {
+		var (
+			targetAppGUID string
+			copyResult    struct {
+				GUID  string `json:"guid"`
+				State string `json:"state"`
+			}
+		)
+
+		BeforeEach(func() {
+			packageGUID = createBitsPackage(appGUID)
+			targetAppGUID = createBuildpackApp(spaceGUID, generateGUID("target-app"))
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetBody(typedResource{
+					resource: resource{
+						Relationships: relationships{
+							"app": relationship{Data: resource{GUID: targetAppGUID}},
+						},
+					},
+				}).
+				SetResult(&copyResult).
+				Post("/v3/packages?source_guid=" + packageGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fails when the source package has no bits", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+			Expect(resp).To(HaveRestyBody(ContainSubstring("Source package must be in the READY state to be copied.")))
+		})
+
+		When("the source package bits have been uploaded", func() {
+			BeforeEach(func() {
+				uploadTestApp(packageGUID, defaultAppBitsFile)
+			})
+
+			It("copies the package to the target app", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusCreated))
+				Expect(copyResult.GUID).NotTo(Equal(packageGUID))
+				Expect(copyResult.State).To(Equal("COPYING"))
+
+				Eventually(func(g Gomega) {
+					var copiedPackage struct {
+						State string `json:"state"`
+					}
+					getResp, err := adminClient.R().
+						SetResult(&copiedPackage).
+						Get("/v3/packages/" + copyResult.GUID)
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(getResp).To(HaveRestyStatusCode(http.StatusOK))
+					g.Expect(copiedPackage.State).To(Equal("READY"))
+				}).Should(Succeed())
+			})
+		})
 	})
 
 	Describe("Get", func() {
@@ -202,16 +330,19 @@ var _ = Describe("Package", func() {
 		var (
 			result       resourceList[resource]
 			space2GUID   string
+			app2GUID     string
 			package1GUID string
 			package2GUID string
+			query        string
 		)
 
 		BeforeEach(func() {
 			space2GUID = createSpace(generateGUID("space2"), commonTestOrgGUID)
 
 			package1GUID = createBitsPackage(appGUID)
-			app2GUID := createBuildpackApp(space2GUID, generateGUID("app2"))
+			app2GUID = createBuildpackApp(space2GUID, generateGUID("app2"))
 			package2GUID = createBitsPackage(app2GUID)
+			query = "app_guids=" + appGUID + "," + app2GUID
 		})
 
 		AfterEach(func() {
@@ -222,7 +353,7 @@ var _ = Describe("Package", func() {
 			var err error
 			resp, err = adminClient.R().
 				SetResult(&result).
-				Get("/v3/packages")
+				Get("/v3/packages?" + query)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -233,5 +364,30 @@ var _ = Describe("Package", func() {
 				MatchFields(IgnoreExtras, Fields{"GUID": Equal(package2GUID)}),
 			))
 		})
+
+		When("filtering packages that were never uploaded", func() {
+			BeforeEach(func() {
+				query += "&types=bits&states=AWAITING_UPLOAD"
+			})
+
+			It("returns them", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+				Expect(result.Resources).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(package1GUID)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(package2GUID)}),
+				))
+			})
+		})
+
+		When("filtering by a package type none of the packages have", func() {
+			BeforeEach(func() {
+				query += "&types=docker"
+			})
+
+			It("returns an empty list", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+				Expect(result.Resources).To(BeEmpty())
+			})
+		})
 	})
 })
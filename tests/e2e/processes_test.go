@@ -174,6 +174,69 @@ var _ = Describe("Processes", func() {
 		})
 	})
 
+	Describe("Patch a process health check", func() {
+		var (
+			healthCheck map[string]any
+			result      struct {
+				HealthCheck struct {
+					Type string `json:"type"`
+					Data struct {
+						Endpoint          string `json:"endpoint"`
+						Timeout           int    `json:"timeout"`
+						InvocationTimeout int    `json:"invocation_timeout"`
+					} `json:"data"`
+				} `json:"health_check"`
+			}
+		)
+
+		BeforeEach(func() {
+			healthCheck = map[string]any{
+				"type": "http",
+				"data": map[string]any{
+					"endpoint":           "/health",
+					"timeout":            60,
+					"invocation_timeout": 2,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetBody(map[string]any{"health_check": healthCheck}).
+				SetError(&errResp).
+				SetResult(&result).
+				Patch("/v3/processes/" + webProcessGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the updated health check", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.HealthCheck.Type).To(Equal("http"))
+			Expect(result.HealthCheck.Data.Endpoint).To(Equal("/health"))
+			Expect(result.HealthCheck.Data.Timeout).To(Equal(60))
+			Expect(result.HealthCheck.Data.InvocationTimeout).To(Equal(2))
+		})
+
+		When("an http health check has no endpoint", func() {
+			BeforeEach(func() {
+				healthCheck = map[string]any{
+					"type": "http",
+					"data": map[string]any{"timeout": 60},
+				}
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+				Expect(errResp.Errors).To(ConsistOf(cfErr{
+					Detail: "health_check.data.endpoint cannot be blank",
+					Title:  "CF-UnprocessableEntity",
+					Code:   10008,
+				}))
+			})
+		})
+	})
+
 	Describe("Patch process metadata", func() {
 		var result responseResource
 
@@ -3,6 +3,7 @@ package e2e_test
 import (
 	"net/http"
 
+	"code.cloudfoundry.org/korifi/tests/helpers"
 	"github.com/go-resty/resty/v2"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
@@ -89,6 +90,110 @@ var _ = Describe("Roles", func() {
 			Expect(result.Relationships).To(HaveKey("space"))
 			Expect(result.Relationships["space"].Data.GUID).To(Equal(spaceGUID))
 		})
+
+		When("the user already has the role in the space", func() {
+			BeforeEach(func() {
+				createSpaceRole("space_developer", userName, spaceGUID)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+				Expect(resp).To(HaveRestyBody(SatisfyAll(
+					ContainSubstring("CF-UnprocessableEntity"),
+					ContainSubstring("already has 'space_developer' role"),
+				)))
+			})
+		})
+
+		When("the service account already has the role in the space", func() {
+			BeforeEach(func() {
+				userName = serviceAccountFactory.FullyQualifiedName(uuid.NewString())
+				createOrgRole("organization_user", userName, commonTestOrgGUID)
+				createSpaceRole("space_developer", userName, spaceGUID)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+				Expect(resp).To(HaveRestyBody(ContainSubstring("already has 'space_developer' role")))
+			})
+		})
+	})
+
+	Describe("creating a space role for a user without an org role", func() {
+		var (
+			spaceGUID  string
+			resultList resourceList[typedResource]
+		)
+
+		BeforeEach(func() {
+			spaceGUID = createSpace(uuid.NewString(), commonTestOrgGUID)
+		})
+
+		AfterEach(func() {
+			deleteSpace(spaceGUID)
+		})
+
+		JustBeforeEach(func() {
+			createSpaceRole("space_developer", userName, spaceGUID)
+		})
+
+		It("makes the user an organization user of the parent org", func() {
+			var err error
+			resp, err = adminClient.R().
+				SetResult(&resultList).
+				Get("/v3/roles?types=organization_user&user_guids=" + userName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(resultList.Resources).To(ConsistOf(
+				HaveRelationship("organization", "GUID", commonTestOrgGUID),
+			))
+		})
+	})
+
+	Describe("creating roles of every cf role type", func() {
+		var spaceGUID string
+
+		BeforeEach(func() {
+			createOrgRole("organization_user", userName, commonTestOrgGUID)
+			spaceGUID = createSpace(uuid.NewString(), commonTestOrgGUID)
+		})
+
+		AfterEach(func() {
+			deleteSpace(spaceGUID)
+		})
+
+		DescribeTable("creates the role",
+			func(roleType, orgSpaceType string) {
+				orgSpaceGUID := commonTestOrgGUID
+				if orgSpaceType == "space" {
+					orgSpaceGUID = spaceGUID
+				}
+
+				var err error
+				resp, err = adminClient.R().
+					SetBody(typedResource{
+						Type: roleType,
+						resource: resource{
+							Relationships: relationships{
+								"user":       {Data: resource{GUID: userName}},
+								orgSpaceType: {Data: resource{GUID: orgSpaceGUID}},
+							},
+						},
+					}).
+					SetResult(&result).
+					Post("/v3/roles")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(HaveRestyStatusCode(http.StatusCreated))
+				Expect(result.Type).To(Equal(roleType))
+			},
+			Entry("organization_auditor", "organization_auditor", "organization"),
+			Entry("organization_billing_manager", "organization_billing_manager", "organization"),
+			Entry("organization_manager", "organization_manager", "organization"),
+			Entry("space_auditor", "space_auditor", "space"),
+			Entry("space_developer", "space_developer", "space"),
+			Entry("space_manager", "space_manager", "space"),
+			Entry("space_supporter", "space_supporter", "space"),
+		)
 	})
 
 	Describe("listing roles", func() {
@@ -111,7 +216,7 @@ var _ = Describe("Roles", func() {
 			var err error
 			resp, err = adminClient.R().
 				SetResult(&resultList).
-				Get("/v3/roles")
+				Get("/v3/roles?user_guids=" + userName)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -138,11 +243,13 @@ var _ = Describe("Roles", func() {
 
 	Describe("deleting a role", func() {
 		var (
-			spaceGUID string
-			roleGUID  string
+			spaceGUID   string
+			roleGUID    string
+			restyClient *helpers.CorrelatedRestyClient
 		)
 
 		BeforeEach(func() {
+			restyClient = adminClient
 			createOrgRole("organization_user", userName, commonTestOrgGUID)
 			spaceGUID = createSpace(uuid.NewString(), commonTestOrgGUID)
 			roleGUID = createSpaceRole("space_developer", userName, spaceGUID)
@@ -154,7 +261,7 @@ var _ = Describe("Roles", func() {
 
 		JustBeforeEach(func() {
 			var err error
-			resp, err = adminClient.R().
+			resp, err = restyClient.R().
 				SetResult(&result).
 				Delete("/v3/roles/" + roleGUID)
 			Expect(err).NotTo(HaveOccurred())
@@ -171,5 +278,25 @@ var _ = Describe("Roles", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp).To(HaveRestyStatusCode(http.StatusNotFound))
 		})
+
+		When("the user is not allowed to manage roles in the space", func() {
+			var developerName string
+
+			BeforeEach(func() {
+				developerName = uuid.NewString()
+				restyClient = makeTokenClient(serviceAccountFactory.CreateServiceAccount(developerName))
+				createOrgRole("organization_user", serviceAccountFactory.FullyQualifiedName(developerName), commonTestOrgGUID)
+				createSpaceRole("space_developer", serviceAccountFactory.FullyQualifiedName(developerName), spaceGUID)
+			})
+
+			AfterEach(func() {
+				serviceAccountFactory.DeleteServiceAccount(developerName)
+			})
+
+			It("returns a not authorized error", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusForbidden))
+				Expect(resp).To(HaveRestyBody(ContainSubstring("CF-NotAuthorized")))
+			})
+		})
 	})
 })
@@ -139,6 +139,42 @@ var _ = Describe("Routes", func() {
 			))
 			Expect(route.GUID).NotTo(BeEmpty())
 		})
+
+		When("the domain is private to another org", func() {
+			var otherOrgGUID string
+
+			BeforeEach(func() {
+				otherOrgGUID = createOrg(generateGUID("org"))
+
+				var domain responseResource
+				resp, err := adminClient.R().
+					SetBody(domainResource{
+						resource: resource{
+							Name: generateGUID("private") + ".com",
+							Relationships: map[string]relationship{
+								"organization": {Data: resource{GUID: otherOrgGUID}},
+							},
+						},
+					}).
+					SetResult(&domain).
+					Post("/v3/domains")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(HaveRestyStatusCode(http.StatusCreated))
+
+				domainGUID = domain.GUID
+			})
+
+			AfterEach(func() {
+				deleteOrg(otherOrgGUID)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+				Expect(createErr.Errors).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Detail": ContainSubstring("is not available in organization"),
+				})))
+			})
+		})
 	})
 
 	Describe("delete", func() {
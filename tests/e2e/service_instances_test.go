@@ -219,6 +219,22 @@ var _ = Describe("Service Instances", func() {
 					}),
 				))
 			})
+
+			When("the service instance is bound to an app", func() {
+				var bindingGUID string
+
+				BeforeEach(func() {
+					appGUID := createBuildpackApp(spaceGUID, generateGUID("app"))
+					bindingGUID = createUPSIServiceBinding(appGUID, upsiGUID, "")
+				})
+
+				It("fails listing the blocking bindings", func() {
+					Expect(httpError).NotTo(HaveOccurred())
+					Expect(httpResp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+					Expect(httpResp).To(HaveRestyBody(ContainSubstring("CF-AssociationNotEmpty")))
+					Expect(httpResp).To(HaveRestyBody(ContainSubstring(bindingGUID)))
+				})
+			})
 		})
 
 		When("deleting a managed service instance", func() {
@@ -276,6 +292,23 @@ var _ = Describe("Service Instances", func() {
 				}),
 			))
 		})
+
+		When("filtering by type", func() {
+			JustBeforeEach(func() {
+				serviceInstancesList = resourceList[resource]{}
+				httpResp, httpError = adminClient.R().SetResult(&serviceInstancesList).Get("/v3/service_instances?type=managed")
+			})
+
+			It("does not list user-provided service instances", func() {
+				Expect(httpError).NotTo(HaveOccurred())
+				Expect(httpResp).To(HaveRestyStatusCode(http.StatusOK))
+				Expect(serviceInstancesList.Resources).NotTo(ContainElements(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(upsiGUID),
+					}),
+				))
+			})
+		})
 	})
 })
 
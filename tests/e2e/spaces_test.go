@@ -12,6 +12,8 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	"gopkg.in/yaml.v3"
+
+	"code.cloudfoundry.org/korifi/tests/helpers"
 )
 
 var _ = Describe("Spaces", func() {
@@ -57,6 +59,25 @@ var _ = Describe("Spaces", func() {
 			Expect(result.Name).To(Equal(spaceName))
 			Expect(result.GUID).NotTo(BeEmpty())
 		})
+
+		When("a space with the same name already exists in the org", func() {
+			var existingSpaceGUID string
+
+			BeforeEach(func() {
+				existingSpaceGUID = createSpace(spaceName, parentGUID)
+			})
+
+			AfterEach(func() {
+				deleteSpace(existingSpaceGUID)
+			})
+
+			It("returns a uniqueness error", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+				Expect(createErr.Errors).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Title": Equal("CF-UniquenessError"),
+				})))
+			})
+		})
 	})
 
 	Describe("list", func() {
@@ -67,9 +88,11 @@ var _ = Describe("Spaces", func() {
 			space11Name, space12Name string
 			space21Name, space22Name string
 			result                   resourceList[resource]
+			restyClient              *helpers.CorrelatedRestyClient
 		)
 
 		BeforeEach(func() {
+			restyClient = adminClient
 			org1GUID, org2GUID = "", ""
 			space11GUID, space12GUID = "", ""
 			space21GUID, space22GUID = "", ""
@@ -115,12 +138,35 @@ var _ = Describe("Spaces", func() {
 
 		JustBeforeEach(func() {
 			var err error
-			resp, err = adminClient.R().
+			resp, err = restyClient.R().
+				SetQueryParam("organization_guids", org1GUID+","+org2GUID).
 				SetResult(&result).
 				Get("/v3/spaces")
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		When("the client is a user with a role in one of the spaces", func() {
+			var userName string
+
+			BeforeEach(func() {
+				userName = uuid.NewString()
+				restyClient = makeTokenClient(serviceAccountFactory.CreateServiceAccount(userName))
+				createOrgRole("organization_user", serviceAccountFactory.FullyQualifiedName(userName), org1GUID)
+				createSpaceRole("space_developer", serviceAccountFactory.FullyQualifiedName(userName), space11GUID)
+			})
+
+			AfterEach(func() {
+				serviceAccountFactory.DeleteServiceAccount(userName)
+			})
+
+			It("lists only the spaces the user has a role in", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+				Expect(result.Resources).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"Name": Equal(space11Name)}),
+				))
+			})
+		})
+
 		It("lists spaces", func() {
 			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
 			Expect(result.Resources).To(ContainElements(
@@ -162,6 +208,23 @@ var _ = Describe("Spaces", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(spaceResp).To(HaveRestyStatusCode(http.StatusNotFound))
 		})
+
+		When("the space contains an app", func() {
+			var appGUID string
+
+			BeforeEach(func() {
+				appGUID = createBuildpackApp(spaceGUID, generateGUID("app"))
+			})
+
+			It("deletes the app along with the space", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusAccepted))
+				expectJobCompletes(resp)
+
+				appResp, err := adminClient.R().Get("/v3/apps/" + appGUID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(appResp).To(HaveRestyStatusCode(http.StatusNotFound))
+			})
+		})
 	})
 
 	Describe("manifests", func() {
@@ -373,7 +436,16 @@ var _ = Describe("Spaces", func() {
 
 		BeforeEach(func() {
 			spaceGUID = createSpace(generateGUID("space"), commonTestOrgGUID)
-			manifestBytes = []byte{}
+
+			var err error
+			manifestBytes, err = yaml.Marshal(manifestResource{
+				Version: 1,
+				Applications: []applicationResource{{
+					Name:    generateGUID("app"),
+					Command: "whatever",
+				}},
+			})
+			Expect(err).NotTo(HaveOccurred())
 		})
 
 		AfterEach(func() {
@@ -395,9 +467,10 @@ var _ = Describe("Spaces", func() {
 
 			diff := map[string]interface{}{}
 			Expect(json.Unmarshal(resp.Body(), &diff)).To(Succeed())
-			// The `manifest_diff` endpoint is currently a stub to return
-			// an empty diff
-			Expect(diff).To(HaveKeyWithValue("diff", BeEmpty()))
+			Expect(diff).To(HaveKeyWithValue("diff", ContainElements(
+				SatisfyAll(HaveKeyWithValue("op", "add"), HaveKeyWithValue("path", "/applications/0/name")),
+				SatisfyAll(HaveKeyWithValue("op", "add"), HaveKeyWithValue("path", "/applications/0/processes/0/command"), HaveKeyWithValue("value", "whatever")),
+			)))
 		})
 	})
 
@@ -424,4 +497,36 @@ var _ = Describe("Spaces", func() {
 			Expect(result.GUID).To(Equal(spaceGUID))
 		})
 	})
+
+	Describe("get summary", func() {
+		var (
+			spaceGUID string
+			result    map[string]any
+		)
+
+		BeforeEach(func() {
+			spaceGUID = createSpace(generateGUID("space"), commonTestOrgGUID)
+			createBuildpackApp(spaceGUID, generateGUID("app"))
+			createBuildpackApp(spaceGUID, generateGUID("app"))
+			createServiceInstance(spaceGUID, generateGUID("service-instance"), nil)
+			createRoute(generateGUID("host"), "", spaceGUID, getDomainGUID(appFQDN))
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetResult(&result).
+				Get("/v3/spaces/" + spaceGUID + "/summary")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("counts the resources in the space", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result).To(HaveKeyWithValue("guid", spaceGUID))
+			Expect(result).To(HaveKeyWithValue("apps_count", BeNumerically("==", 2)))
+			Expect(result).To(HaveKeyWithValue("running_instances", BeNumerically("==", 0)))
+			Expect(result).To(HaveKeyWithValue("service_instances_count", BeNumerically("==", 1)))
+			Expect(result).To(HaveKeyWithValue("routes_count", BeNumerically("==", 1)))
+		})
+	})
 })
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/tools"
 	"github.com/google/uuid"
@@ -12,6 +13,7 @@ import (
 	. "github.com/onsi/gomega"    //lint:ignore ST1001 this is a test file
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 
@@ -56,7 +58,18 @@ func NewUncachedClient(cfg *rest.Config) client.Client {
 }
 
 func NewK8sManager(testEnv *envtest.Environment, managerRolePath string) manager.Manager {
-	k8sManager, err := ctrl.NewManager(SetupTestEnvUser(testEnv, managerRolePath), ctrl.Options{
+	return newK8sManager(testEnv, SetupTestEnvUser(testEnv, managerRolePath))
+}
+
+// NewK8sManagerWithRootNamespace creates a manager whose user is also granted
+// the namespaced roles of the role definition in the root namespace, as the
+// helm chart does
+func NewK8sManagerWithRootNamespace(testEnv *envtest.Environment, managerRolePath, rootNamespace string) manager.Manager {
+	return newK8sManager(testEnv, SetupTestEnvUserWithRootNamespace(testEnv, managerRolePath, rootNamespace))
+}
+
+func newK8sManager(testEnv *envtest.Environment, managerConfig *rest.Config) manager.Manager {
+	k8sManager, err := ctrl.NewManager(managerConfig, ctrl.Options{
 		Controller: config.Controller{
 			SkipNameValidation: tools.PtrTo(true),
 		},
@@ -95,6 +108,13 @@ func StartK8sManager(k8sManager manager.Manager) context.CancelFunc {
 }
 
 func SetupTestEnvUser(testEnv *envtest.Environment, roleDefinitionPath string) *rest.Config {
+	return SetupTestEnvUserWithRootNamespace(testEnv, roleDefinitionPath, "")
+}
+
+// SetupTestEnvUserWithRootNamespace creates a user bound to the cluster role
+// of the role definition. The namespaced roles of the role definition are
+// created and bound in the root namespace, unless it is empty.
+func SetupTestEnvUserWithRootNamespace(testEnv *envtest.Environment, roleDefinitionPath, rootNamespace string) *rest.Config {
 	userName := uuid.NewString()
 	controllersUser, err := testEnv.ControlPlane.AddUser(envtest.User{Name: userName}, testEnv.Config)
 	Expect(err).NotTo(HaveOccurred())
@@ -102,8 +122,20 @@ func SetupTestEnvUser(testEnv *envtest.Environment, roleDefinitionPath string) *
 	adminClient, err := client.New(testEnv.Config, client.Options{Scheme: scheme.Scheme})
 	Expect(err).NotTo(HaveOccurred())
 
-	controllersRole := ensureClusterRole(adminClient, roleDefinitionPath)
-	bindUserToClusterRole(adminClient, userName, controllersRole.Name)
+	for _, roleObject := range decodeRoleDefinition(roleDefinitionPath) {
+		switch role := roleObject.(type) {
+		case *rbacv1.ClusterRole:
+			Expect(client.IgnoreAlreadyExists(adminClient.Create(context.Background(), role))).To(Succeed())
+			bindUserToClusterRole(adminClient, userName, role.Name)
+		case *rbacv1.Role:
+			if rootNamespace == "" {
+				continue
+			}
+			role.Namespace = rootNamespace
+			Expect(client.IgnoreAlreadyExists(adminClient.Create(context.Background(), role))).To(Succeed())
+			bindUserToRole(adminClient, userName, role)
+		}
+	}
 
 	return controllersUser.Config()
 }
@@ -126,19 +158,45 @@ func bindUserToClusterRole(k8sClient client.Client, userName, roleName string) {
 	})).To(Succeed())
 }
 
-func ensureClusterRole(k8sClient client.Client, roleDefinitionPath string) *rbacv1.ClusterRole {
-	clusterRoleDefinition, err := os.ReadFile(toAbsPath(roleDefinitionPath))
-	Expect(err).NotTo(HaveOccurred())
+func bindUserToRole(k8sClient client.Client, userName string, role *rbacv1.Role) {
+	GinkgoHelper()
+
+	Expect(k8sClient.Create(context.Background(), &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: role.Namespace,
+			Name:      userName,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind: "User",
+			Name: userName,
+		}},
+		RoleRef: rbacv1.RoleRef{
+			Kind: "Role",
+			Name: role.Name,
+		},
+	})).To(Succeed())
+}
 
-	roleObject, _, err := scheme.Codecs.UniversalDeserializer().Decode(clusterRoleDefinition, nil, new(rbacv1.ClusterRole))
+// decodeRoleDefinition decodes the cluster role and roles of a (possibly
+// multi document) role definition
+func decodeRoleDefinition(roleDefinitionPath string) []k8sruntime.Object {
+	GinkgoHelper()
+
+	roleDefinition, err := os.ReadFile(toAbsPath(roleDefinitionPath))
 	Expect(err).NotTo(HaveOccurred())
 
-	clusterRole, ok := roleObject.(*rbacv1.ClusterRole)
-	Expect(ok).To(BeTrue())
+	roleObjects := []k8sruntime.Object{}
+	for _, document := range strings.Split(string(roleDefinition), "\n---") {
+		if strings.TrimSpace(strings.TrimPrefix(document, "---")) == "" {
+			continue
+		}
 
-	Expect(client.IgnoreAlreadyExists(k8sClient.Create(context.Background(), clusterRole))).To(Succeed())
+		roleObject, _, err := scheme.Codecs.UniversalDeserializer().Decode([]byte(document), nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		roleObjects = append(roleObjects, roleObject)
+	}
 
-	return clusterRole
+	return roleObjects
 }
 
 func toAbsPath(roleDefinitionPath string) string {
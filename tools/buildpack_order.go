@@ -0,0 +1,18 @@
+package tools
+
+import (
+	"slices"
+	"strings"
+)
+
+// ToBuildpackOrderData renders the buildpack order as stored in the default
+// buildpack order config map, one buildpack name per line
+func ToBuildpackOrderData(buildpacks []string) string {
+	return strings.Join(buildpacks, "\n")
+}
+
+func FromBuildpackOrderData(data string) []string {
+	return slices.DeleteFunc(strings.Split(data, "\n"), func(name string) bool {
+		return strings.TrimSpace(name) == ""
+	})
+}
@@ -0,0 +1,23 @@
+package tools_test
+
+import (
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildpackOrder", func() {
+	It("round trips the buildpack order", func() {
+		data := tools.ToBuildpackOrderData([]string{"paketo-buildpacks/go", "paketo-buildpacks/java"})
+		Expect(data).To(Equal("paketo-buildpacks/go\npaketo-buildpacks/java"))
+		Expect(tools.FromBuildpackOrderData(data)).To(Equal([]string{"paketo-buildpacks/go", "paketo-buildpacks/java"}))
+	})
+
+	It("ignores blank lines", func() {
+		Expect(tools.FromBuildpackOrderData("\npaketo-buildpacks/go\n\n  \npaketo-buildpacks/java\n")).To(Equal([]string{"paketo-buildpacks/go", "paketo-buildpacks/java"}))
+	})
+
+	It("parses empty data as an empty order", func() {
+		Expect(tools.FromBuildpackOrderData("")).To(BeEmpty())
+	})
+})
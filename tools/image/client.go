@@ -1,11 +1,16 @@
 package image
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"strings"
 
 	"github.com/buildpacks/pack/pkg/archive"
@@ -13,6 +18,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
@@ -23,6 +29,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+// lifecycleMetadataLabel is the label the buildpacks lifecycle records the
+// layers of a built image in
+const lifecycleMetadataLabel = "io.buildpacks.lifecycle.metadata"
+
 type Client struct {
 	clientset kubernetes.Interface
 	logger    logr.Logger
@@ -106,6 +116,48 @@ func (c Client) Push(ctx context.Context, creds Creds, repoRef string, zipReader
 	return refWithDigest.Name(), nil
 }
 
+// Copy copies the image into the repository, tagging the copy with the given
+// tags, and returns the reference of the copy with its digest
+func (c Client) Copy(ctx context.Context, creds Creds, imageRef string, repoRef string, tags ...string) (string, error) {
+	srcRef, err := name.ParseReference(imageRef)
+	if err != nil {
+		return "", fmt.Errorf("error parsing image reference %s: %w", imageRef, err)
+	}
+
+	ref, err := name.ParseReference(repoRef)
+	if err != nil {
+		return "", fmt.Errorf("error parsing repository reference %s: %w", repoRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return "", fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	image, err := remote.Image(srcRef, authOpt, remote.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to get image: %w", err)
+	}
+
+	if err = remote.Write(ref, image, authOpt, remote.WithContext(ctx)); err != nil {
+		return "", fmt.Errorf("failed to upload image: %w", err)
+	}
+
+	for _, tag := range tags {
+		err = remote.Tag(ref.Context().Tag(tag), image, authOpt, remote.WithContext(ctx))
+		if err != nil {
+			return "", fmt.Errorf("failed to tag image: %w", err)
+		}
+	}
+
+	imgDigest, err := image.Digest()
+	if err != nil {
+		return "", fmt.Errorf("failed to get image digest: %w", err)
+	}
+
+	return ref.Context().Digest(imgDigest.String()).Name(), nil
+}
+
 func (c Client) Config(ctx context.Context, creds Creds, imageRef string) (Config, error) {
 	ref, err := name.ParseReference(imageRef)
 	if err != nil {
@@ -143,6 +195,135 @@ func (c Client) Config(ctx context.Context, creds Creds, imageRef string) (Confi
 	}, nil
 }
 
+// Export returns the flattened filesystem of the image as a zip archive. For
+// images built by the buildpacks lifecycle only the layers added on top of
+// the run image, i.e. the droplet, are exported. The archive is produced while
+// it is being read, so callers are expected to consume it as a stream and
+// close it once done.
+func (c Client) Export(ctx context.Context, creds Creds, imageRef string) (io.ReadCloser, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing repository reference %s: %w", imageRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(ref, authOpt, remote.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	img, err = withoutRunImage(img)
+	if err != nil {
+		return nil, err
+	}
+
+	zipReader, zipWriter := io.Pipe()
+	go func() {
+		fs := mutate.Extract(img)
+		defer fs.Close()
+
+		zipWriter.CloseWithError(tarToZip(tar.NewReader(fs), zipWriter))
+	}()
+
+	return zipReader, nil
+}
+
+// withoutRunImage returns an image made of the layers of img that sit on top
+// of the run image it was built on. Images that have not been built by the
+// buildpacks lifecycle are returned unchanged.
+func withoutRunImage(img v1.Image) (v1.Image, error) {
+	cfgFile, err := img.ConfigFile()
+	if err != nil {
+		return nil, fmt.Errorf("error getting image config file: %w", err)
+	}
+
+	metadataJSON, ok := cfgFile.Config.Labels[lifecycleMetadataLabel]
+	if !ok {
+		return img, nil
+	}
+
+	var metadata struct {
+		RunImage struct {
+			TopLayer string `json:"topLayer"`
+		} `json:"runImage"`
+	}
+	if err = json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse the %s label: %w", lifecycleMetadataLabel, err)
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image layers: %w", err)
+	}
+
+	for i, layer := range layers {
+		diffID, err := layer.DiffID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get layer diff ID: %w", err)
+		}
+
+		if diffID.String() == metadata.RunImage.TopLayer {
+			return mutate.AppendLayers(empty.Image, layers[i+1:]...)
+		}
+	}
+
+	return nil, fmt.Errorf("run image top layer %q not found in image", metadata.RunImage.TopLayer)
+}
+
+func tarToZip(tarReader *tar.Reader, w io.Writer) error {
+	zipWriter := zip.NewWriter(w)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read image filesystem: %w", err)
+		}
+
+		// devices, fifos and the like have no zip representation
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeDir && header.Typeflag != tar.TypeSymlink {
+			continue
+		}
+
+		zipHeader, err := zip.FileInfoHeader(header.FileInfo())
+		if err != nil {
+			return fmt.Errorf("failed to create zip header for %q: %w", header.Name, err)
+		}
+		zipHeader.Name = strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		if zipHeader.Name == "" {
+			continue
+		}
+		if header.Typeflag == tar.TypeDir {
+			zipHeader.Name += "/"
+		} else {
+			zipHeader.Method = zip.Deflate
+		}
+
+		entry, err := zipWriter.CreateHeader(zipHeader)
+		if err != nil {
+			return fmt.Errorf("failed to create zip entry for %q: %w", header.Name, err)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeSymlink:
+			_, err = io.WriteString(entry, header.Linkname)
+		case tar.TypeReg:
+			_, err = io.Copy(entry, tarReader) // #nosec G110
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write zip entry for %q: %w", header.Name, err)
+		}
+	}
+
+	return zipWriter.Close()
+}
+
 func parseExposedPorts(ports map[string]struct{}) []string {
 	result := []string{}
 	for p := range ports {
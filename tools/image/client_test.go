@@ -1,11 +1,22 @@
 package image_test
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
 	"os"
 
 	"code.cloudfoundry.org/korifi/tests/helpers/oci"
 	"code.cloudfoundry.org/korifi/tools/image"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -209,6 +220,129 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("Export", func() {
+		var exported io.ReadCloser
+
+		BeforeEach(func() {
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			exported, testErr = imgClient.Export(ctx, creds, imgRef)
+		})
+
+		It("exports the image filesystem as a zip archive", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			defer exported.Close()
+
+			zipBytes, err := io.ReadAll(exported)
+			Expect(err).NotTo(HaveOccurred())
+
+			zipReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zipReader.File).To(ContainElement(HaveField("Name", "foo")))
+		})
+
+		When("the image has been built by the buildpacks lifecycle", func() {
+			BeforeEach(func() {
+				runImageLayer := singleFileLayer("etc/os-release")
+				runImageTopLayer, err := runImageLayer.DiffID()
+				Expect(err).NotTo(HaveOccurred())
+
+				img, err := mutate.AppendLayers(empty.Image, runImageLayer, singleFileLayer("workspace/app.rb"))
+				Expect(err).NotTo(HaveOccurred())
+				img, err = mutate.Config(img, v1.Config{
+					Labels: map[string]string{
+						"io.buildpacks.lifecycle.metadata": fmt.Sprintf(`{"runImage":{"topLayer":%q}}`, runImageTopLayer.String()),
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				ref, err := name.ParseReference(containerRegistry.ImageRef("foo/droplet"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(remote.Write(ref, img, remote.WithAuth(&authn.Basic{Username: "user", Password: "password"}))).To(Succeed())
+				imgRef = ref.String()
+			})
+
+			It("exports only the layers on top of the run image", func() {
+				Expect(testErr).NotTo(HaveOccurred())
+				defer exported.Close()
+
+				zipBytes, err := io.ReadAll(exported)
+				Expect(err).NotTo(HaveOccurred())
+
+				zipReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(zipReader.File).To(ConsistOf(HaveField("Name", "workspace/app.rb")))
+			})
+		})
+
+		When("the ref is invalid", func() {
+			BeforeEach(func() {
+				imgRef += "::ads"
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("error parsing repository reference")))
+			})
+		})
+
+		When("the secret doesn't exist", func() {
+			BeforeEach(func() {
+				creds.SecretNames = []string{"not-a-secret"}
+			})
+
+			It("fails to authenticate", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("UNAUTHORIZED")))
+			})
+		})
+	})
+
+	Describe("Copy", func() {
+		var copyRef string
+
+		BeforeEach(func() {
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			copyRef, testErr = imgClient.Copy(ctx, creds, imgRef, containerRegistry.ImageRef("foo/copy"), "jim")
+		})
+
+		It("copies the image into the repository", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(copyRef).To(HavePrefix(containerRegistry.ImageRef("foo/copy") + "@sha256:"))
+			Expect(copyRef).To(HaveSuffix(imgRef[len(pushRef):]))
+
+			_, err := imgClient.Config(ctx, creds, containerRegistry.ImageRef("foo/copy")+":jim")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the image ref is invalid", func() {
+			BeforeEach(func() {
+				imgRef += "::ads"
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("error parsing image reference")))
+			})
+		})
+
+		When("the secret doesn't exist", func() {
+			BeforeEach(func() {
+				creds.SecretNames = []string{"not-a-secret"}
+			})
+
+			It("fails to authenticate", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("UNAUTHORIZED")))
+			})
+		})
+	})
+
 	Describe("Delete", func() {
 		var tagsToDelete []string
 
@@ -375,3 +509,19 @@ var _ = Describe("Client", func() {
 		})
 	}
 })
+
+func singleFileLayer(fileName string) v1.Layer {
+	buf := bytes.Buffer{}
+	tarWriter := tar.NewWriter(&buf)
+	Expect(tarWriter.WriteHeader(&tar.Header{Name: fileName, Mode: 0o644, Size: 2, Typeflag: tar.TypeReg})).To(Succeed())
+	_, err := tarWriter.Write([]byte("hi"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(tarWriter.Close()).To(Succeed())
+
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	return layer
+}
@@ -134,6 +134,10 @@ func (e NotReadyError) Error() string {
 	return fmt.Sprintf("%s%s", message, e.cause.Error())
 }
 
+func (e NotReadyError) Unwrap() error {
+	return e.cause
+}
+
 func NewNotReadyError() NotReadyError {
 	return NotReadyError{}
 }
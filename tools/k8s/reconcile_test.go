@@ -294,6 +294,10 @@ var _ = Describe("Reconcile", func() {
 						HasMessage(Equal("test-err")),
 					)))
 				})
+
+				It("wraps the cause", func() {
+					Expect(errors.Unwrap(objectReconciler.reconcileResourceError)).To(MatchError("test-err"))
+				})
 			})
 
 			When("cause and message are specified", func() {
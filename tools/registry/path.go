@@ -0,0 +1,54 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// DefaultRepositoryPathTemplate keeps app images directly under the
+// repository prefix, named after the app
+const DefaultRepositoryPathTemplate = "{{.AppGUID}}"
+
+type RepositoryPathValues struct {
+	OrgGUID   string
+	SpaceGUID string
+	AppGUID   string
+}
+
+type RepositoryRefBuilder struct {
+	prefix       string
+	pathTemplate *template.Template
+}
+
+func NewRepositoryRefBuilder(prefix, pathTemplate string) (*RepositoryRefBuilder, error) {
+	if pathTemplate == "" {
+		pathTemplate = DefaultRepositoryPathTemplate
+	}
+
+	tmpl, err := template.New("repositoryPath").Option("missingkey=error").Parse(pathTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse container repository path template %q: %w", pathTemplate, err)
+	}
+
+	return &RepositoryRefBuilder{
+		prefix:       prefix,
+		pathTemplate: tmpl,
+	}, nil
+}
+
+func (b *RepositoryRefBuilder) Build(values RepositoryPathValues, suffix string) (string, error) {
+	var path strings.Builder
+	if err := b.pathTemplate.Execute(&path, values); err != nil {
+		return "", fmt.Errorf("failed to render container repository path: %w", err)
+	}
+
+	ref := b.prefix + path.String() + suffix
+	if _, err := name.NewRepository(ref); err != nil {
+		return "", fmt.Errorf("invalid container repository %q: %w", ref, err)
+	}
+
+	return ref, nil
+}
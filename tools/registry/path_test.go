@@ -0,0 +1,81 @@
+package registry_test
+
+import (
+	"code.cloudfoundry.org/korifi/tools/registry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RepositoryRefBuilder", func() {
+	var (
+		pathTemplate string
+		builder      *registry.RepositoryRefBuilder
+		newErr       error
+		ref          string
+		buildErr     error
+	)
+
+	BeforeEach(func() {
+		pathTemplate = "{{.OrgGUID}}/{{.SpaceGUID}}/{{.AppGUID}}"
+	})
+
+	JustBeforeEach(func() {
+		builder, newErr = registry.NewRepositoryRefBuilder("my.registry/korifi/", pathTemplate)
+		if newErr != nil {
+			return
+		}
+
+		ref, buildErr = builder.Build(registry.RepositoryPathValues{
+			OrgGUID:   "org-guid",
+			SpaceGUID: "space-guid",
+			AppGUID:   "app-guid",
+		}, "-droplets")
+	})
+
+	It("renders the configured template", func() {
+		Expect(newErr).NotTo(HaveOccurred())
+		Expect(buildErr).NotTo(HaveOccurred())
+		Expect(ref).To(Equal("my.registry/korifi/org-guid/space-guid/app-guid-droplets"))
+	})
+
+	When("the template is empty", func() {
+		BeforeEach(func() {
+			pathTemplate = ""
+		})
+
+		It("names the repository after the app", func() {
+			Expect(buildErr).NotTo(HaveOccurred())
+			Expect(ref).To(Equal("my.registry/korifi/app-guid-droplets"))
+		})
+	})
+
+	When("the template cannot be parsed", func() {
+		BeforeEach(func() {
+			pathTemplate = "{{.AppGUID"
+		})
+
+		It("returns an error", func() {
+			Expect(newErr).To(MatchError(ContainSubstring("failed to parse container repository path template")))
+		})
+	})
+
+	When("the template refers to an unknown value", func() {
+		BeforeEach(func() {
+			pathTemplate = "{{.Foo}}"
+		})
+
+		It("returns an error", func() {
+			Expect(buildErr).To(MatchError(ContainSubstring("failed to render container repository path")))
+		})
+	})
+
+	When("the rendered repository is not a valid reference", func() {
+		BeforeEach(func() {
+			pathTemplate = "{{.AppGUID}}/UPPER CASE"
+		})
+
+		It("returns an error", func() {
+			Expect(buildErr).To(MatchError(ContainSubstring("invalid container repository")))
+		})
+	})
+})